@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/pkg/tradealgo"
+	"github.com/shopspring/decimal"
+)
+
+const usage = `usage: simctl [-addr url] <command> [args]
+
+commands:
+  shock <symbol> <impact>        move the price by impact (-0.1 is a 10% fall)
+  spike <symbol> <multiplier>    multiply volatility until the spike decays
+  trend <symbol> <value>         set the trend
+  vol <symbol> <value>           set the volatility
+  event <symbol> <type> <impact> apply a price_shock, volatility_spike or trend_change
+  list                           show the adjustments made so far
+
+Use * as the symbol to adjust every simulated symbol.`
+
+var errUsage = errors.New(usage)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("simctl", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	addr := flags.String("addr", "http://localhost:8080", "Base URL of the engine's HTTP API (-http-addr)")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	base := strings.TrimSuffix(*addr, "/")
+
+	args = flags.Args()
+	if len(args) == 1 && args[0] == "list" {
+		var adjustments []tradealgo.SimulatorAdjustment
+		if err := call(client, http.MethodGet, base+"/sim", nil, &adjustments); err != nil {
+			return err
+		}
+		printAdjustments(out, adjustments)
+		return nil
+	}
+	if len(args) < 3 {
+		return errUsage
+	}
+
+	command, symbol := args[0], args[1]
+	var kind tradealgo.AdjustmentKind
+	body := map[string]interface{}{}
+	switch {
+	case command == "shock" && len(args) == 3:
+		kind, body["type"] = tradealgo.AdjustmentEvent, tradealgo.EventPriceShock
+	case command == "spike" && len(args) == 3:
+		kind, body["type"] = tradealgo.AdjustmentEvent, tradealgo.EventVolatilitySpike
+	case command == "event" && len(args) == 4:
+		kind, body["type"] = tradealgo.AdjustmentEvent, args[2]
+	case command == "trend" && len(args) == 3:
+		kind = tradealgo.AdjustmentTrend
+	case command == "vol" && len(args) == 3:
+		kind = tradealgo.AdjustmentVolatility
+	default:
+		return errUsage
+	}
+
+	value, err := decimal.NewFromString(args[len(args)-1])
+	if err != nil {
+		return fmt.Errorf("invalid value %q: %w", args[len(args)-1], err)
+	}
+	if kind == tradealgo.AdjustmentEvent {
+		body["impact"] = value
+	} else {
+		body["value"] = value
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	var applied []tradealgo.SimulatorAdjustment
+	if err := call(client, http.MethodPost, base+"/sim/"+symbol+"/"+string(kind), payload, &applied); err != nil {
+		return err
+	}
+	printAdjustments(out, applied)
+	return nil
+}
+
+func call(client *http.Client, method, url string, payload []byte, out interface{}) error {
+	request, err := http.NewRequest(method, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(response.Body).Decode(&failure) == nil && failure.Error != "" {
+			return fmt.Errorf("%s: %s", response.Status, failure.Error)
+		}
+		return errors.New(response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(out)
+}
+
+func printAdjustments(out io.Writer, adjustments []tradealgo.SimulatorAdjustment) {
+	for _, adjustment := range adjustments {
+		kind := string(adjustment.Kind)
+		if adjustment.EventType != "" {
+			kind = adjustment.EventType
+		}
+		fmt.Fprintf(out, "%s %s %s %s (was %s)\n", adjustment.At.Format(time.RFC3339), adjustment.Symbol, kind, adjustment.Value, adjustment.Previous)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/api"
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/simulator"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func createSimServer(t *testing.T) (*simulator.MarketSimulator, string) {
+	t.Helper()
+	sim := simulator.NewMarketSimulator(zap.NewNop(), simulator.WithSeed(42))
+	sim.AddSymbol("TSLA", decimal.NewFromInt(200), decimal.NewFromFloat(0.6))
+	server := api.NewServer("", engine.NewTradingEngine(decimal.NewFromInt(100000), zap.NewNop()), zap.NewNop())
+	server.SetSimulator(sim)
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+	return sim, httpServer.URL
+}
+
+func TestRun_ShockAndList(t *testing.T) {
+	sim, addr := createSimServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, run([]string{"-addr", addr, "shock", "TSLA", "-0.1"}, &out))
+	assert.Contains(t, out.String(), "TSLA price_shock -0.1 (was 200)")
+	assert.True(t, decimal.NewFromInt(180).Equal(sim.GetSymbolData("TSLA").CurrentPrice))
+
+	require.NoError(t, run([]string{"-addr", addr, "vol", "TSLA", "1.2"}, &out))
+	assert.True(t, decimal.NewFromFloat(1.2).Equal(sim.GetSymbolData("TSLA").Volatility))
+
+	out.Reset()
+	require.NoError(t, run([]string{"-addr", addr, "list"}, &out))
+	assert.Equal(t, 2, bytes.Count(out.Bytes(), []byte("\n")))
+}
+
+func TestRun_ReportsErrors(t *testing.T) {
+	_, addr := createSimServer(t)
+
+	err := run([]string{"-addr", addr, "event", "TSLA", "halt", "0.1"}, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400 Bad Request: unknown market event type")
+
+	assert.ErrorIs(t, run([]string{"-addr", addr, "shock", "TSLA"}, &bytes.Buffer{}), errUsage)
+	assert.Error(t, run([]string{"-addr", addr, "trend", "TSLA", "steep"}, &bytes.Buffer{}))
+}
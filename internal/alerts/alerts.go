@@ -0,0 +1,56 @@
+package alerts
+
+import (
+	"context"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeDrawdownLiquidation Type = "drawdown_liquidation"
+	TypeStrategyDisabled    Type = "strategy_disabled"
+	TypePortfolioDrawdown   Type = "portfolio_drawdown"
+	TypeMarginCall          Type = "margin_call"
+	TypeOrderRejections     Type = "order_rejections"
+	TypeLargeLoss           Type = "large_loss"
+	TypeCircuitBreaker      Type = "circuit_breaker"
+	TypeTradingResumed      Type = "trading_resumed"
+)
+
+var Types = []Type{
+	TypeDrawdownLiquidation,
+	TypeStrategyDisabled,
+	TypePortfolioDrawdown,
+	TypeMarginCall,
+	TypeOrderRejections,
+	TypeLargeLoss,
+	TypeCircuitBreaker,
+	TypeTradingResumed,
+}
+
+func (t Type) Valid() bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+type Alert struct {
+	Type       Type              `json:"type"`
+	Symbol     string            `json:"symbol,omitempty"`
+	StrategyID string            `json:"strategy_id,omitempty"`
+	Message    string            `json:"message"`
+	Values     map[string]string `json:"values,omitempty"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
+
+func (a Alert) key() string {
+	return string(a.Type) + "|" + a.Symbol + "|" + a.StrategyID
+}
+
+type Alerter interface {
+	Send(ctx context.Context, alert Alert) error
+}
@@ -0,0 +1,205 @@
+package alerts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultQueueSize  = 100
+	defaultRetries    = 3
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = 30 * time.Second
+	defaultCooldown   = 5 * time.Minute
+)
+
+type Dispatcher struct {
+	alerters   []Alerter
+	logger     *zap.Logger
+	clock      clock.Clock
+	queue      chan Alert
+	retries    int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	cooldown   time.Duration
+
+	mu         sync.Mutex
+	lastSent   map[string]time.Time
+	closed     bool
+	suppressed uint64
+	dropped    uint64
+	failed     uint64
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+type Option func(*Dispatcher)
+
+func WithClock(c clock.Clock) Option {
+	return func(d *Dispatcher) {
+		d.clock = c
+	}
+}
+
+func WithRetries(retries int) Option {
+	return func(d *Dispatcher) {
+		d.retries = retries
+	}
+}
+
+func WithBackoff(min, max time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.minBackoff = min
+		d.maxBackoff = max
+	}
+}
+
+func WithCooldown(cooldown time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.cooldown = cooldown
+	}
+}
+
+func WithQueueSize(size int) Option {
+	return func(d *Dispatcher) {
+		d.queue = make(chan Alert, max(size, 1))
+	}
+}
+
+func NewDispatcher(alerters []Alerter, logger *zap.Logger, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		alerters:   alerters,
+		logger:     logger,
+		clock:      clock.New(),
+		queue:      make(chan Alert, defaultQueueSize),
+		retries:    defaultRetries,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		cooldown:   defaultCooldown,
+		lastSent:   make(map[string]time.Time),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	go d.run()
+	return d
+}
+
+func (d *Dispatcher) Publish(alert Alert) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return false
+	}
+
+	now := d.clock.Now()
+	if alert.Timestamp.IsZero() {
+		alert.Timestamp = now
+	}
+	key := alert.key()
+	if last, exists := d.lastSent[key]; exists && now.Sub(last) < d.cooldown {
+		d.suppressed++
+		return false
+	}
+
+	select {
+	case d.queue <- alert:
+		d.lastSent[key] = now
+		return true
+	default:
+		d.dropped++
+		return false
+	}
+}
+
+func (d *Dispatcher) Suppressed() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.suppressed
+}
+
+func (d *Dispatcher) Dropped() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dropped
+}
+
+func (d *Dispatcher) Failed() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.failed
+}
+
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	d.mu.Unlock()
+
+	close(d.stop)
+	<-d.done
+}
+
+func (d *Dispatcher) run() {
+	defer close(d.done)
+	for {
+		select {
+		case alert := <-d.queue:
+			d.deliver(alert)
+		case <-d.stop:
+			for {
+				select {
+				case alert := <-d.queue:
+					d.deliver(alert)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(alert Alert) {
+	for _, alerter := range d.alerters {
+		if err := d.send(alerter, alert); err != nil {
+			d.mu.Lock()
+			d.failed++
+			d.mu.Unlock()
+			d.logger.Error("Failed to send alert",
+				zap.String("type", string(alert.Type)),
+				zap.String("symbol", alert.Symbol),
+				zap.Error(err))
+		}
+	}
+}
+
+func (d *Dispatcher) send(alerter Alerter, alert Alert) error {
+	backoff := d.minBackoff
+	for attempt := 0; ; attempt++ {
+		err := alerter.Send(context.Background(), alert)
+		if err == nil || attempt >= d.retries {
+			return err
+		}
+
+		d.logger.Warn("Alert delivery failed, retrying",
+			zap.String("type", string(alert.Type)),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		select {
+		case <-d.clock.After(backoff):
+		case <-d.stop:
+			return err
+		}
+		backoff = min(backoff*2, d.maxBackoff)
+	}
+}
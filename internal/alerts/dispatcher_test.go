@@ -0,0 +1,175 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordingServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	payloads [][]byte
+	requests int32
+}
+
+func newRecordingServer(t *testing.T, failures int32) *recordingServer {
+	t.Helper()
+	server := &recordingServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&server.requests, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		server.mu.Lock()
+		server.payloads = append(server.payloads, body)
+		server.mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *recordingServer) received() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]byte(nil), s.payloads...)
+}
+
+func testAlert() Alert {
+	return Alert{
+		Type:       TypeDrawdownLiquidation,
+		Symbol:     "AAPL",
+		StrategyID: "ma_crossover",
+		Message:    "Position drawdown exceeded, liquidating",
+		Values:     map[string]string{"drawdown": "0.12", "threshold": "0.1"},
+		Timestamp:  time.Date(2024, 3, 5, 3, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookAlerter_Send_PostsJSON(t *testing.T) {
+	server := newRecordingServer(t, 0)
+
+	require.NoError(t, NewWebhookAlerter(server.URL, nil).Send(context.Background(), testAlert()))
+
+	payloads := server.received()
+	require.Len(t, payloads, 1)
+	var alert Alert
+	require.NoError(t, json.Unmarshal(payloads[0], &alert))
+	assert.Equal(t, testAlert(), alert)
+}
+
+func TestWebhookAlerter_Send_SlackPayload(t *testing.T) {
+	server := newRecordingServer(t, 0)
+
+	require.NoError(t, NewSlackAlerter(server.URL).Send(context.Background(), testAlert()))
+
+	var message slackMessage
+	require.NoError(t, json.Unmarshal(server.received()[0], &message))
+	assert.Equal(t, "*[drawdown_liquidation AAPL ma_crossover]* Position drawdown exceeded, liquidating", message.Text)
+	require.Len(t, message.Attachments, 1)
+	assert.Equal(t, []slackField{
+		{Title: "drawdown", Value: "0.12", Short: true},
+		{Title: "threshold", Value: "0.1", Short: true},
+	}, message.Attachments[0].Fields)
+	assert.Equal(t, testAlert().Timestamp.Unix(), message.Attachments[0].Ts)
+}
+
+func TestWebhookAlerter_Send_FailsOnErrorStatus(t *testing.T) {
+	server := newRecordingServer(t, 1)
+
+	assert.ErrorIs(t, NewWebhookAlerter(server.URL, nil).Send(context.Background(), testAlert()), ErrDeliveryFailed)
+}
+
+func TestNewWebhook_UnknownFormat(t *testing.T) {
+	_, err := NewWebhook("http://localhost", "teams")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestDispatcher_Publish_RetriesWithBackoff(t *testing.T) {
+	server := newRecordingServer(t, 2)
+	dispatcher := NewDispatcher([]Alerter{NewWebhookAlerter(server.URL, nil)}, zap.NewNop(),
+		WithBackoff(time.Millisecond, 5*time.Millisecond))
+
+	require.True(t, dispatcher.Publish(testAlert()))
+	require.Eventually(t, func() bool { return len(server.received()) == 1 }, 2*time.Second, time.Millisecond)
+	dispatcher.Close()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&server.requests))
+	assert.Zero(t, dispatcher.Failed())
+}
+
+func TestDispatcher_Publish_GivesUpAfterRetries(t *testing.T) {
+	server := newRecordingServer(t, 10)
+	dispatcher := NewDispatcher([]Alerter{NewWebhookAlerter(server.URL, nil)}, zap.NewNop(),
+		WithRetries(2), WithBackoff(time.Millisecond, time.Millisecond))
+
+	dispatcher.Publish(testAlert())
+	require.Eventually(t, func() bool { return dispatcher.Failed() == 1 }, 2*time.Second, time.Millisecond)
+	dispatcher.Close()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&server.requests))
+	assert.Empty(t, server.received())
+}
+
+func TestDispatcher_Publish_RateLimitsRepeatedAlerts(t *testing.T) {
+	server := newRecordingServer(t, 0)
+	fake := clock.NewFake(time.Date(2024, 3, 5, 3, 0, 0, 0, time.UTC))
+	dispatcher := NewDispatcher([]Alerter{NewWebhookAlerter(server.URL, nil)}, zap.NewNop(),
+		WithClock(fake), WithCooldown(time.Minute))
+
+	assert.True(t, dispatcher.Publish(testAlert()))
+	for i := 0; i < 10; i++ {
+		fake.Advance(time.Second)
+		assert.False(t, dispatcher.Publish(testAlert()))
+	}
+	other := testAlert()
+	other.Symbol = "MSFT"
+	assert.True(t, dispatcher.Publish(other))
+
+	fake.Advance(time.Minute)
+	assert.True(t, dispatcher.Publish(testAlert()))
+	dispatcher.Close()
+
+	assert.Equal(t, uint64(10), dispatcher.Suppressed())
+	assert.Len(t, server.received(), 3)
+}
+
+func TestDispatcher_Publish_NeverBlocks(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	dispatcher := NewDispatcher([]Alerter{NewWebhookAlerter(server.URL, nil)}, zap.NewNop(),
+		WithQueueSize(1), WithCooldown(0))
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			dispatcher.Publish(testAlert())
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a slow webhook")
+	}
+
+	close(release)
+	dispatcher.Close()
+	assert.Positive(t, dispatcher.Dropped())
+	assert.False(t, dispatcher.Publish(testAlert()))
+}
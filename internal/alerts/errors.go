@@ -0,0 +1,8 @@
+package alerts
+
+import "errors"
+
+var (
+	ErrUnknownFormat  = errors.New("unknown alert format")
+	ErrDeliveryFailed = errors.New("alert delivery failed")
+)
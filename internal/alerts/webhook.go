@@ -0,0 +1,128 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatSlack Format = "slack"
+)
+
+type Formatter func(alert Alert) ([]byte, error)
+
+type WebhookAlerter struct {
+	url    string
+	client *http.Client
+	format Formatter
+}
+
+func NewWebhookAlerter(url string, format Formatter) *WebhookAlerter {
+	if format == nil {
+		format = JSONPayload
+	}
+	return &WebhookAlerter{
+		url:    url,
+		client: &http.Client{Timeout: defaultWebhookTimeout},
+		format: format,
+	}
+}
+
+func NewSlackAlerter(url string) *WebhookAlerter {
+	return NewWebhookAlerter(url, SlackPayload)
+}
+
+func NewWebhook(url string, format Format) (*WebhookAlerter, error) {
+	switch format {
+	case "", FormatJSON:
+		return NewWebhookAlerter(url, JSONPayload), nil
+	case FormatSlack:
+		return NewSlackAlerter(url), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+func (w *WebhookAlerter) Send(ctx context.Context, alert Alert) error {
+	payload, err := w.format(alert)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%w: %s returned %s", ErrDeliveryFailed, w.url, response.Status)
+	}
+	return nil
+}
+
+func JSONPayload(alert Alert) ([]byte, error) {
+	return json.Marshal(alert)
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Fields []slackField `json:"fields,omitempty"`
+	Ts     int64        `json:"ts"`
+}
+
+type slackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+func SlackPayload(alert Alert) ([]byte, error) {
+	subject := []string{string(alert.Type)}
+	if alert.Symbol != "" {
+		subject = append(subject, alert.Symbol)
+	}
+	if alert.StrategyID != "" {
+		subject = append(subject, alert.StrategyID)
+	}
+
+	names := make([]string, 0, len(alert.Values))
+	for name := range alert.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fields := make([]slackField, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, slackField{Title: name, Value: alert.Values[name], Short: true})
+	}
+
+	return json.Marshal(slackMessage{
+		Text: fmt.Sprintf("*[%s]* %s", strings.Join(subject, " "), alert.Message),
+		Attachments: []slackAttachment{{
+			Color:  "danger",
+			Fields: fields,
+			Ts:     alert.Timestamp.Unix(),
+		}},
+	})
+}
@@ -0,0 +1,98 @@
+package analytics
+
+import (
+	"math"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/shopspring/decimal"
+)
+
+type BenchmarkReport struct {
+	Symbol           string          `json:"symbol"`
+	TotalReturn      decimal.Decimal `json:"total_return"`
+	ExcessReturn     decimal.Decimal `json:"excess_return"`
+	Alpha            decimal.Decimal `json:"alpha"`
+	Beta             decimal.Decimal `json:"beta"`
+	TrackingError    decimal.Decimal `json:"tracking_error"`
+	InformationRatio decimal.Decimal `json:"information_ratio"`
+}
+
+func (r *PerformanceReport) AddBenchmark(symbol string, equityCurve, benchmarkCurve []models.EquityPoint) {
+	r.Benchmark = GenerateBenchmarkReport(symbol, equityCurve, benchmarkCurve)
+}
+
+func GenerateBenchmarkReport(symbol string, equityCurve, benchmarkCurve []models.EquityPoint) *BenchmarkReport {
+	if symbol == "" {
+		return nil
+	}
+
+	values, benchmark, elapsed := alignCurves(equityCurve, benchmarkCurve)
+	if len(values) < 2 || !values[0].IsPositive() || !benchmark[0].IsPositive() {
+		return nil
+	}
+
+	portfolioReturn := values[len(values)-1].Div(values[0]).Sub(decimal.NewFromInt(1))
+	report := &BenchmarkReport{
+		Symbol:      symbol,
+		TotalReturn: benchmark[len(benchmark)-1].Div(benchmark[0]).Sub(decimal.NewFromInt(1)),
+	}
+	report.ExcessReturn = portfolioReturn.Sub(report.TotalReturn)
+
+	returns, benchmarkReturns := risk.Returns(values), risk.Returns(benchmark)
+	n := min(len(returns), len(benchmarkReturns))
+	returns, benchmarkReturns = returns[:n], benchmarkReturns[:n]
+	periods := periodsPerYear(elapsed, n)
+	annualize := 1.0
+	if periods > 0 {
+		annualize = float64(periods)
+	}
+
+	if beta, ok := risk.Beta(returns, benchmarkReturns); ok {
+		report.Beta = finite(beta)
+		report.Alpha = finite((risk.Mean(returns) - beta*risk.Mean(benchmarkReturns)) * annualize)
+	}
+
+	active := make([]float64, n)
+	for i := range active {
+		active[i] = returns[i] - benchmarkReturns[i]
+	}
+	deviation := risk.StdDev(active)
+	report.TrackingError = finite(deviation * math.Sqrt(annualize))
+	if deviation > 0 {
+		report.InformationRatio = finite(risk.Mean(active) / deviation * math.Sqrt(annualize))
+	}
+	return report
+}
+
+func alignCurves(a, b []models.EquityPoint) ([]decimal.Decimal, []decimal.Decimal, time.Duration) {
+	var alignedA, alignedB []decimal.Decimal
+	var first, last time.Time
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i].Timestamp.Before(b[j].Timestamp):
+			i++
+		case b[j].Timestamp.Before(a[i].Timestamp):
+			j++
+		default:
+			if len(alignedA) == 0 {
+				first = a[i].Timestamp
+			}
+			last = a[i].Timestamp
+			alignedA = append(alignedA, a[i].Value)
+			alignedB = append(alignedB, b[j].Value)
+			i++
+			j++
+		}
+	}
+	return alignedA, alignedB, last.Sub(first)
+}
+
+func periodsPerYear(elapsed time.Duration, returns int) int {
+	if returns <= 0 || elapsed <= 0 {
+		return 0
+	}
+	return int(year / (elapsed / time.Duration(returns)))
+}
@@ -0,0 +1,91 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestBenchmarkCurve(n int) []models.EquityPoint {
+	values := make([]float64, n)
+	value := 400.0
+	for i := range values {
+		values[i] = value
+		if i%3 == 0 {
+			value *= 0.98
+		} else {
+			value *= 1.015
+		}
+	}
+	return createTestEquityCurve(values...)
+}
+
+func scaleCurve(curve []models.EquityPoint, factor float64) []models.EquityPoint {
+	scaled := make([]models.EquityPoint, len(curve))
+	for i, point := range curve {
+		scaled[i] = models.EquityPoint{Timestamp: point.Timestamp, Value: point.Value.Mul(decimal.NewFromFloat(factor))}
+	}
+	return scaled
+}
+
+func TestGenerateBenchmarkReport_ExactTracking(t *testing.T) {
+	benchmark := createTestBenchmarkCurve(30)
+	equity := scaleCurve(benchmark, 250)
+
+	report := GenerateBenchmarkReport("SPY", equity, benchmark)
+
+	require.NotNil(t, report)
+	assert.Equal(t, "SPY", report.Symbol)
+	assert.InDelta(t, 1.0, report.Beta.InexactFloat64(), 1e-9)
+	assert.InDelta(t, 0.0, report.Alpha.InexactFloat64(), 1e-9)
+	assert.InDelta(t, 0.0, report.TrackingError.InexactFloat64(), 1e-9)
+	assert.InDelta(t, 0.0, report.ExcessReturn.InexactFloat64(), 1e-9)
+	assert.True(t, report.InformationRatio.IsZero())
+	assertDecimal(t, benchmark[29].Value.Div(benchmark[0].Value).InexactFloat64()-1, report.TotalReturn)
+}
+
+func TestGenerateBenchmarkReport_OutperformingPortfolio(t *testing.T) {
+	benchmark := createTestBenchmarkCurve(30)
+	equity := make([]models.EquityPoint, len(benchmark))
+	value := 100000.0
+	for i := range benchmark {
+		if i > 0 {
+			benchmarkReturn := benchmark[i].Value.Div(benchmark[i-1].Value).InexactFloat64() - 1
+			value *= 1 + 2*benchmarkReturn + 0.001
+		}
+		equity[i] = models.EquityPoint{Timestamp: benchmark[i].Timestamp, Value: decimal.NewFromFloat(value)}
+	}
+
+	report := GenerateBenchmarkReport("SPY", equity, benchmark)
+
+	require.NotNil(t, report)
+	assert.InDelta(t, 2.0, report.Beta.InexactFloat64(), 1e-6)
+	assert.InDelta(t, 0.001*365, report.Alpha.InexactFloat64(), 1e-4)
+	assert.True(t, report.TrackingError.IsPositive())
+	assert.True(t, report.InformationRatio.IsPositive())
+	assert.True(t, report.ExcessReturn.IsPositive())
+}
+
+func TestGenerateBenchmarkReport_AlignsTimestamps(t *testing.T) {
+	benchmark := createTestBenchmarkCurve(30)
+	equity := scaleCurve(benchmark, 250)
+
+	report := GenerateBenchmarkReport("SPY", equity, append(benchmark[:5:5], benchmark[6:]...))
+
+	require.NotNil(t, report)
+	assert.InDelta(t, 0.0, report.TrackingError.InexactFloat64(), 1e-9)
+}
+
+func TestGenerateBenchmarkReport_Empty(t *testing.T) {
+	equity := createTestEquityCurve(100, 110, 120)
+
+	assert.Nil(t, GenerateBenchmarkReport("", equity, createTestBenchmarkCurve(3)))
+	assert.Nil(t, GenerateBenchmarkReport("SPY", equity, nil))
+
+	report := GeneratePerformanceReport(&models.Portfolio{}, equity)
+	report.AddBenchmark("SPY", equity, nil)
+	assert.Nil(t, report.Benchmark)
+}
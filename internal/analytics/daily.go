@@ -0,0 +1,31 @@
+package analytics
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/shopspring/decimal"
+)
+
+const tradingDaysPerYear = 252
+
+func (r *PerformanceReport) AddDailyStatements(statements []models.DailyStatement) {
+	r.DailyStatements = statements
+	r.TradingDays = len(statements)
+	if len(statements) == 0 {
+		return
+	}
+
+	returns := make([]float64, len(statements))
+	r.BestDay, r.WorstDay = statements[0].Return, statements[0].Return
+	for i, statement := range statements {
+		returns[i] = statement.Return.InexactFloat64()
+		r.BestDay = decimal.Max(r.BestDay, statement.Return)
+		r.WorstDay = decimal.Min(r.WorstDay, statement.Return)
+	}
+	if sharpe, ok := risk.SharpeRatio(returns, 0, tradingDaysPerYear); ok {
+		r.SharpeRatio = finite(sharpe)
+	}
+	if sortino, ok := risk.SortinoRatio(returns, 0, tradingDaysPerYear); ok {
+		r.SortinoRatio = finite(sortino)
+	}
+}
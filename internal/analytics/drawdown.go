@@ -0,0 +1,55 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type Drawdown struct {
+	MaxDrawdown    decimal.Decimal `json:"max_drawdown"`
+	Peak           time.Time       `json:"peak"`
+	Trough         time.Time       `json:"trough"`
+	Recovery       time.Time       `json:"recovery"`
+	Duration       time.Duration   `json:"duration"`
+	TimeToRecovery time.Duration   `json:"time_to_recovery"`
+}
+
+func MaxDrawdown(curve []models.EquityPoint) Drawdown {
+	var result Drawdown
+	if len(curve) == 0 {
+		return result
+	}
+
+	peak, maxPeak, maxTrough := 0, -1, -1
+	for i, point := range curve {
+		if point.Value.GreaterThan(curve[peak].Value) {
+			peak = i
+		}
+		if !curve[peak].Value.IsPositive() {
+			continue
+		}
+		drawdown := curve[peak].Value.Sub(point.Value).Div(curve[peak].Value)
+		if drawdown.GreaterThan(result.MaxDrawdown) {
+			result.MaxDrawdown = drawdown
+			maxPeak, maxTrough = peak, i
+		}
+	}
+	if maxTrough < 0 {
+		return result
+	}
+
+	result.Peak = curve[maxPeak].Timestamp
+	result.Trough = curve[maxTrough].Timestamp
+	result.Duration = curve[len(curve)-1].Timestamp.Sub(result.Peak)
+	for _, point := range curve[maxTrough+1:] {
+		if !point.Value.LessThan(curve[maxPeak].Value) {
+			result.Recovery = point.Timestamp
+			result.Duration = result.Recovery.Sub(result.Peak)
+			result.TimeToRecovery = result.Recovery.Sub(result.Trough)
+			break
+		}
+	}
+	return result
+}
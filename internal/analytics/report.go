@@ -0,0 +1,172 @@
+package analytics
+
+import (
+	"math"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/shopspring/decimal"
+)
+
+const year = 365 * 24 * time.Hour
+
+type PerformanceReport struct {
+	StartTime               time.Time                             `json:"start_time"`
+	EndTime                 time.Time                             `json:"end_time"`
+	InitialValue            decimal.Decimal                       `json:"initial_value"`
+	FinalValue              decimal.Decimal                       `json:"final_value"`
+	TotalReturn             decimal.Decimal                       `json:"total_return"`
+	AnnualizedReturn        decimal.Decimal                       `json:"annualized_return"`
+	MaxDrawdown             decimal.Decimal                       `json:"max_drawdown"`
+	MaxDrawdownPeak         time.Time                             `json:"max_drawdown_peak"`
+	MaxDrawdownTrough       time.Time                             `json:"max_drawdown_trough"`
+	MaxDrawdownDuration     time.Duration                         `json:"max_drawdown_duration"`
+	TimeToRecovery          time.Duration                         `json:"time_to_recovery"`
+	SharpeRatio             decimal.Decimal                       `json:"sharpe_ratio"`
+	SortinoRatio            decimal.Decimal                       `json:"sortino_ratio"`
+	TotalTrades             int                                   `json:"total_trades"`
+	RoundTrips              int                                   `json:"round_trips"`
+	WinningTrades           int                                   `json:"winning_trades"`
+	LosingTrades            int                                   `json:"losing_trades"`
+	WinRate                 decimal.Decimal                       `json:"win_rate"`
+	AverageWin              decimal.Decimal                       `json:"average_win"`
+	AverageLoss             decimal.Decimal                       `json:"average_loss"`
+	ProfitFactor            decimal.Decimal                       `json:"profit_factor"`
+	LargestWin              decimal.Decimal                       `json:"largest_win"`
+	LargestLoss             decimal.Decimal                       `json:"largest_loss"`
+	AverageHoldingPeriod    time.Duration                         `json:"average_holding_period"`
+	TotalCommission         decimal.Decimal                       `json:"total_commission"`
+	TotalImpactCost         decimal.Decimal                       `json:"total_impact_cost"`
+	ImplementationShortfall decimal.Decimal                       `json:"implementation_shortfall"`
+	TransactionCosts        *TransactionCostReport                `json:"transaction_costs"`
+	TradingDays             int                                   `json:"trading_days"`
+	BestDay                 decimal.Decimal                       `json:"best_day"`
+	WorstDay                decimal.Decimal                       `json:"worst_day"`
+	DailyStatements         []models.DailyStatement               `json:"daily_statements,omitempty"`
+	Benchmark               *BenchmarkReport                      `json:"benchmark,omitempty"`
+	Strategies              map[string]models.StrategyPerformance `json:"strategies,omitempty"`
+}
+
+func GeneratePerformanceReport(portfolio *models.Portfolio, equityCurve []models.EquityPoint) *PerformanceReport {
+	report := &PerformanceReport{
+		FinalValue:       portfolio.TotalValue,
+		TotalTrades:      len(portfolio.TradeHistory),
+		TransactionCosts: AnalyzeTransactionCosts(portfolio.TradeHistory),
+	}
+
+	for _, trade := range portfolio.TradeHistory {
+		report.TotalCommission = report.TotalCommission.Add(trade.Commission)
+		report.TotalImpactCost = report.TotalImpactCost.Add(trade.ImpactCost)
+		report.ImplementationShortfall = report.ImplementationShortfall.Add(implementationShortfall(trade))
+	}
+
+	report.addEquityStats(equityCurve)
+	report.addRoundTripStats(MatchRoundTrips(portfolio.TradeHistory))
+
+	return report
+}
+
+func (r *PerformanceReport) addEquityStats(curve []models.EquityPoint) {
+	if len(curve) == 0 {
+		return
+	}
+
+	first, last := curve[0], curve[len(curve)-1]
+	r.StartTime = first.Timestamp
+	r.EndTime = last.Timestamp
+	r.InitialValue = first.Value
+	r.FinalValue = last.Value
+
+	if !first.Value.IsPositive() {
+		return
+	}
+
+	totalReturn := last.Value.Div(first.Value).Sub(decimal.NewFromInt(1))
+	r.TotalReturn = totalReturn
+
+	elapsed := last.Timestamp.Sub(first.Timestamp)
+	if elapsed > 0 {
+		r.AnnualizedReturn = finite(math.Pow(1+totalReturn.InexactFloat64(), float64(year)/float64(elapsed)) - 1)
+	}
+
+	prices := make([]decimal.Decimal, len(curve))
+	for i, point := range curve {
+		prices[i] = point.Value
+	}
+	drawdown := MaxDrawdown(curve)
+	r.MaxDrawdown = drawdown.MaxDrawdown
+	r.MaxDrawdownPeak = drawdown.Peak
+	r.MaxDrawdownTrough = drawdown.Trough
+	r.MaxDrawdownDuration = drawdown.Duration
+	r.TimeToRecovery = drawdown.TimeToRecovery
+
+	returns := risk.Returns(prices)
+	periods := periodsPerYear(elapsed, len(returns))
+	if sharpe, ok := risk.SharpeRatio(returns, 0, periods); ok {
+		r.SharpeRatio = finite(sharpe)
+	}
+	if sortino, ok := risk.SortinoRatio(returns, 0, periods); ok {
+		r.SortinoRatio = finite(sortino)
+	}
+}
+
+func (r *PerformanceReport) addRoundTripStats(roundTrips []RoundTrip) {
+	r.RoundTrips = len(roundTrips)
+	if len(roundTrips) == 0 {
+		return
+	}
+
+	grossProfit := decimal.Zero
+	grossLoss := decimal.Zero
+	var holding time.Duration
+
+	for _, trip := range roundTrips {
+		holding += trip.HoldingPeriod()
+
+		switch {
+		case trip.PnL.IsPositive():
+			r.WinningTrades++
+			grossProfit = grossProfit.Add(trip.PnL)
+			if trip.PnL.GreaterThan(r.LargestWin) {
+				r.LargestWin = trip.PnL
+			}
+		case trip.PnL.IsNegative():
+			r.LosingTrades++
+			grossLoss = grossLoss.Add(trip.PnL)
+			if trip.PnL.LessThan(r.LargestLoss) {
+				r.LargestLoss = trip.PnL
+			}
+		}
+	}
+
+	count := decimal.NewFromInt(int64(len(roundTrips)))
+	r.WinRate = decimal.NewFromInt(int64(r.WinningTrades)).Div(count)
+	r.AverageHoldingPeriod = holding / time.Duration(len(roundTrips))
+
+	if r.WinningTrades > 0 {
+		r.AverageWin = grossProfit.Div(decimal.NewFromInt(int64(r.WinningTrades)))
+	}
+	if r.LosingTrades > 0 {
+		r.AverageLoss = grossLoss.Div(decimal.NewFromInt(int64(r.LosingTrades)))
+		r.ProfitFactor = grossProfit.Div(grossLoss.Abs())
+	}
+}
+
+func finite(value float64) decimal.Decimal {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat(value)
+}
+
+func implementationShortfall(trade *models.Trade) decimal.Decimal {
+	if !trade.RequestedPrice.IsPositive() {
+		return trade.Commission
+	}
+	shortfall := trade.Price.Sub(trade.RequestedPrice)
+	if trade.Side == models.OrderSideSell {
+		shortfall = shortfall.Neg()
+	}
+	return shortfall.Mul(trade.Quantity).Add(trade.Commission)
+}
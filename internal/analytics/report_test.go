@@ -0,0 +1,241 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var reportStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func day(n int) time.Time {
+	return reportStart.Add(time.Duration(n) * 24 * time.Hour)
+}
+
+func createTestTrade(symbol string, side models.OrderSide, quantity int64, price, commission float64, timestamp time.Time) *models.Trade {
+	return &models.Trade{
+		Symbol:     symbol,
+		Side:       side,
+		Quantity:   decimal.NewFromInt(quantity),
+		Price:      decimal.NewFromFloat(price),
+		Commission: decimal.NewFromFloat(commission),
+		Timestamp:  timestamp,
+	}
+}
+
+func createTestTradeHistory() []*models.Trade {
+	return []*models.Trade{
+		createTestTrade("AAPL", models.OrderSideBuy, 100, 100.0, 1.0, day(0)),
+		createTestTrade("AAPL", models.OrderSideSell, 40, 110.0, 0.4, day(1)),
+		createTestTrade("AAPL", models.OrderSideSell, 60, 95.0, 0.6, day(3)),
+		createTestTrade("MSFT", models.OrderSideSell, 10, 200.0, 0.0, day(4)),
+		createTestTrade("MSFT", models.OrderSideBuy, 10, 190.0, 0.0, day(5)),
+	}
+}
+
+func createTestEquityCurve(values ...float64) []models.EquityPoint {
+	curve := make([]models.EquityPoint, len(values))
+	for i, value := range values {
+		curve[i] = models.EquityPoint{Timestamp: day(i), Value: decimal.NewFromFloat(value)}
+	}
+	return curve
+}
+
+func assertDecimal(t *testing.T, expected float64, actual decimal.Decimal) {
+	t.Helper()
+	assert.InDelta(t, expected, actual.InexactFloat64(), 1e-9)
+}
+
+func TestMatchRoundTrips_PartialCloses(t *testing.T) {
+	roundTrips := MatchRoundTrips(createTestTradeHistory())
+
+	require.Len(t, roundTrips, 3)
+
+	assert.Equal(t, "AAPL", roundTrips[0].Symbol)
+	assert.Equal(t, models.OrderSideBuy, roundTrips[0].Side)
+	assert.True(t, decimal.NewFromInt(40).Equal(roundTrips[0].Quantity))
+	assertDecimal(t, 0.8, roundTrips[0].Commission)
+	assertDecimal(t, 399.2, roundTrips[0].PnL)
+	assert.Equal(t, 24*time.Hour, roundTrips[0].HoldingPeriod())
+
+	assert.True(t, decimal.NewFromInt(60).Equal(roundTrips[1].Quantity))
+	assertDecimal(t, 1.2, roundTrips[1].Commission)
+	assertDecimal(t, -301.2, roundTrips[1].PnL)
+	assert.Equal(t, 72*time.Hour, roundTrips[1].HoldingPeriod())
+
+	assert.Equal(t, "MSFT", roundTrips[2].Symbol)
+	assert.Equal(t, models.OrderSideSell, roundTrips[2].Side)
+	assertDecimal(t, 100.0, roundTrips[2].PnL)
+}
+
+func TestMatchRoundTrips_FIFOAcrossLots(t *testing.T) {
+	trades := []*models.Trade{
+		createTestTrade("AAPL", models.OrderSideBuy, 10, 100.0, 0.0, day(0)),
+		createTestTrade("AAPL", models.OrderSideBuy, 10, 120.0, 0.0, day(1)),
+		createTestTrade("AAPL", models.OrderSideSell, 15, 130.0, 0.0, day(2)),
+		createTestTrade("AAPL", models.OrderSideSell, 10, 110.0, 0.0, day(3)),
+	}
+
+	roundTrips := MatchRoundTrips(trades)
+
+	require.Len(t, roundTrips, 3)
+	assertDecimal(t, 300.0, roundTrips[0].PnL)
+	assertDecimal(t, 100.0, roundTrips[0].EntryPrice)
+	assertDecimal(t, 50.0, roundTrips[1].PnL)
+	assert.True(t, decimal.NewFromInt(5).Equal(roundTrips[1].Quantity))
+	assertDecimal(t, -50.0, roundTrips[2].PnL)
+	assert.True(t, decimal.NewFromInt(5).Equal(roundTrips[2].Quantity))
+	assert.Equal(t, day(1), roundTrips[2].EntryTime)
+}
+
+func TestMatchRoundTrips_FlipOpensOppositeLot(t *testing.T) {
+	trades := []*models.Trade{
+		createTestTrade("AAPL", models.OrderSideBuy, 10, 100.0, 1.0, day(0)),
+		createTestTrade("AAPL", models.OrderSideSell, 20, 110.0, 2.0, day(1)),
+		createTestTrade("AAPL", models.OrderSideBuy, 10, 105.0, 1.0, day(2)),
+	}
+
+	roundTrips := MatchRoundTrips(trades)
+
+	require.Len(t, roundTrips, 2)
+	assertDecimal(t, 98.0, roundTrips[0].PnL)
+	assert.Equal(t, models.OrderSideSell, roundTrips[1].Side)
+	assertDecimal(t, 110.0, roundTrips[1].EntryPrice)
+	assertDecimal(t, 48.0, roundTrips[1].PnL)
+}
+
+func TestGeneratePerformanceReport(t *testing.T) {
+	portfolio := &models.Portfolio{
+		TotalValue:   decimal.NewFromFloat(108.9),
+		TradeHistory: createTestTradeHistory(),
+	}
+	curve := createTestEquityCurve(100.0, 110.0, 99.0, 108.9)
+
+	report := GeneratePerformanceReport(portfolio, curve)
+
+	assert.Equal(t, day(0), report.StartTime)
+	assert.Equal(t, day(3), report.EndTime)
+	assertDecimal(t, 100.0, report.InitialValue)
+	assertDecimal(t, 108.9, report.FinalValue)
+	assertDecimal(t, 0.089, report.TotalReturn)
+	assertDecimal(t, math.Pow(1.089, 365.0/3.0)-1, report.AnnualizedReturn)
+	assertDecimal(t, 0.1, report.MaxDrawdown)
+	assert.Equal(t, day(1), report.MaxDrawdownPeak)
+	assert.Equal(t, day(2), report.MaxDrawdownTrough)
+	assert.Equal(t, 48*time.Hour, report.MaxDrawdownDuration)
+	assert.Zero(t, report.TimeToRecovery)
+
+	returns := []float64{0.1, -0.1, 0.1}
+	sharpe, _ := risk.SharpeRatio(returns, 0, 365)
+	sortino, _ := risk.SortinoRatio(returns, 0, 365)
+	assertDecimal(t, sharpe, report.SharpeRatio)
+	assertDecimal(t, sortino, report.SortinoRatio)
+
+	assert.Equal(t, 5, report.TotalTrades)
+	assert.Equal(t, 3, report.RoundTrips)
+	assert.Equal(t, 2, report.WinningTrades)
+	assert.Equal(t, 1, report.LosingTrades)
+	assertDecimal(t, 2.0/3.0, report.WinRate)
+	assertDecimal(t, 249.6, report.AverageWin)
+	assertDecimal(t, -301.2, report.AverageLoss)
+	assertDecimal(t, 499.2/301.2, report.ProfitFactor)
+	assertDecimal(t, 399.2, report.LargestWin)
+	assertDecimal(t, -301.2, report.LargestLoss)
+	assert.Equal(t, 40*time.Hour, report.AverageHoldingPeriod)
+	assertDecimal(t, 2.0, report.TotalCommission)
+}
+
+func TestGeneratePerformanceReport_ImplementationShortfall(t *testing.T) {
+	buy := createTestTrade("AAPL", models.OrderSideBuy, 100, 100.5, 1.0, day(0))
+	buy.RequestedPrice = decimal.NewFromFloat(100.0)
+	buy.ImpactCost = decimal.NewFromFloat(30.0)
+	sell := createTestTrade("AAPL", models.OrderSideSell, 100, 109.8, 1.0, day(1))
+	sell.RequestedPrice = decimal.NewFromFloat(110.0)
+	sell.ImpactCost = decimal.NewFromFloat(10.0)
+	portfolio := &models.Portfolio{TradeHistory: []*models.Trade{buy, sell}}
+
+	report := GeneratePerformanceReport(portfolio, nil)
+
+	assertDecimal(t, 40.0, report.TotalImpactCost)
+	assertDecimal(t, 50.0+1.0+20.0+1.0, report.ImplementationShortfall)
+}
+
+func TestGeneratePerformanceReport_Empty(t *testing.T) {
+	portfolio := &models.Portfolio{TotalValue: decimal.NewFromFloat(1000.0)}
+
+	report := GeneratePerformanceReport(portfolio, nil)
+
+	assertDecimal(t, 1000.0, report.FinalValue)
+	assert.Zero(t, report.RoundTrips)
+	assert.True(t, report.WinRate.IsZero())
+	assert.True(t, report.ProfitFactor.IsZero())
+	assert.True(t, report.SharpeRatio.IsZero())
+}
+
+func TestMaxDrawdown_PeakTroughAndRecovery(t *testing.T) {
+	drawdown := MaxDrawdown(createTestEquityCurve(100.0, 120.0, 90.0, 130.0))
+
+	assert.True(t, decimal.NewFromFloat(0.25).Equal(drawdown.MaxDrawdown), drawdown.MaxDrawdown.String())
+	assert.Equal(t, day(1), drawdown.Peak)
+	assert.Equal(t, day(2), drawdown.Trough)
+	assert.Equal(t, day(3), drawdown.Recovery)
+	assert.Equal(t, 48*time.Hour, drawdown.Duration)
+	assert.Equal(t, 24*time.Hour, drawdown.TimeToRecovery)
+}
+
+func TestMaxDrawdown_Unrecovered(t *testing.T) {
+	drawdown := MaxDrawdown(createTestEquityCurve(100.0, 80.0, 90.0))
+
+	assert.True(t, decimal.NewFromFloat(0.2).Equal(drawdown.MaxDrawdown))
+	assert.Equal(t, day(0), drawdown.Peak)
+	assert.Equal(t, day(1), drawdown.Trough)
+	assert.True(t, drawdown.Recovery.IsZero())
+	assert.Equal(t, 48*time.Hour, drawdown.Duration)
+	assert.Zero(t, drawdown.TimeToRecovery)
+}
+
+func TestAnalyzeTransactionCosts_WeightsByDecisionNotional(t *testing.T) {
+	small := createTestTrade("AAPL", models.OrderSideBuy, 10, 101.0, 0, day(0))
+	small.StrategyID, small.DecisionPrice = "ma", decimal.NewFromInt(100)
+	small.Costs = models.TransactionCost{DelayBps: decimal.NewFromInt(40), SpreadBps: decimal.NewFromInt(10), ImpactBps: decimal.NewFromInt(50), ShortfallBps: decimal.NewFromInt(100)}
+	large := createTestTrade("MSFT", models.OrderSideSell, 30, 199.0, 0, day(1))
+	large.StrategyID, large.DecisionPrice = "rsi", decimal.NewFromInt(200)
+	large.Costs = models.TransactionCost{DelayBps: decimal.NewFromInt(20), ShortfallBps: decimal.NewFromInt(50)}
+	undecided := createTestTrade("AAPL", models.OrderSideSell, 10, 90.0, 0, day(2))
+
+	report := AnalyzeTransactionCosts([]*models.Trade{small, large, undecided})
+
+	assert.Equal(t, 2, report.Total.Trades)
+	assert.True(t, decimal.NewFromInt(7000).Equal(report.Total.Notional), report.Total.Notional.String())
+	assert.True(t, decimal.NewFromInt(20).Add(decimal.NewFromInt(20).Div(decimal.NewFromInt(7))).Equal(report.Total.DelayBps), report.Total.DelayBps.String())
+	assert.True(t, decimal.NewFromInt(50).Add(decimal.NewFromInt(50).Div(decimal.NewFromInt(7))).Equal(report.Total.ShortfallBps), report.Total.ShortfallBps.String())
+	require.Contains(t, report.Strategies, "ma")
+	assert.True(t, decimal.NewFromInt(100).Equal(report.Strategies["ma"].ShortfallBps))
+	assert.True(t, decimal.NewFromInt(50).Equal(report.Symbols["MSFT"].ShortfallBps))
+	assert.Equal(t, 1, report.Symbols["AAPL"].Trades)
+}
+
+func TestPerformanceReport_AddDailyStatements(t *testing.T) {
+	returns := []float64{0.01, -0.005, 0.02}
+	statements := make([]models.DailyStatement, len(returns))
+	for i, value := range returns {
+		statements[i] = models.DailyStatement{Date: day(i), Return: decimal.NewFromFloat(value)}
+	}
+	report := GeneratePerformanceReport(&models.Portfolio{}, nil)
+
+	report.AddDailyStatements(statements)
+
+	sharpe, ok := risk.SharpeRatio(returns, 0, tradingDaysPerYear)
+	require.True(t, ok)
+	assert.InDelta(t, sharpe, report.SharpeRatio.InexactFloat64(), 1e-9)
+	assert.True(t, report.SortinoRatio.IsPositive())
+	assert.Equal(t, 3, report.TradingDays)
+	assert.True(t, decimal.NewFromFloat(0.02).Equal(report.BestDay))
+	assert.True(t, decimal.NewFromFloat(-0.005).Equal(report.WorstDay))
+}
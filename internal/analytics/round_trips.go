@@ -0,0 +1,102 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type RoundTrip struct {
+	Symbol     string           `json:"symbol"`
+	Side       models.OrderSide `json:"side"`
+	Quantity   decimal.Decimal  `json:"quantity"`
+	EntryPrice decimal.Decimal  `json:"entry_price"`
+	ExitPrice  decimal.Decimal  `json:"exit_price"`
+	EntryTime  time.Time        `json:"entry_time"`
+	ExitTime   time.Time        `json:"exit_time"`
+	Commission decimal.Decimal  `json:"commission"`
+	PnL        decimal.Decimal  `json:"pnl"`
+}
+
+func (r RoundTrip) HoldingPeriod() time.Duration {
+	return r.ExitTime.Sub(r.EntryTime)
+}
+
+type lot struct {
+	quantity   decimal.Decimal
+	price      decimal.Decimal
+	commission decimal.Decimal
+	timestamp  time.Time
+}
+
+func MatchRoundTrips(trades []*models.Trade) []RoundTrip {
+	open := make(map[string][]*lot)
+	var roundTrips []RoundTrip
+
+	for _, trade := range trades {
+		if !trade.Quantity.IsPositive() {
+			continue
+		}
+
+		quantity := trade.Quantity
+		if trade.Side == models.OrderSideSell {
+			quantity = quantity.Neg()
+		}
+		commissionPerShare := trade.Commission.Div(trade.Quantity)
+
+		lots := open[trade.Symbol]
+		for len(lots) > 0 && !quantity.IsZero() && lots[0].quantity.IsPositive() != quantity.IsPositive() {
+			entry := lots[0]
+			matched := decimal.Min(entry.quantity.Abs(), quantity.Abs())
+			roundTrips = append(roundTrips, closeLot(trade, entry, matched, commissionPerShare))
+
+			entry.commission = entry.commission.Mul(entry.quantity.Abs().Sub(matched)).Div(entry.quantity.Abs())
+			if entry.quantity.IsPositive() {
+				entry.quantity = entry.quantity.Sub(matched)
+				quantity = quantity.Add(matched)
+			} else {
+				entry.quantity = entry.quantity.Add(matched)
+				quantity = quantity.Sub(matched)
+			}
+			if entry.quantity.IsZero() {
+				lots = lots[1:]
+			}
+		}
+
+		if !quantity.IsZero() {
+			lots = append(lots, &lot{
+				quantity:   quantity,
+				price:      trade.Price,
+				commission: commissionPerShare.Mul(quantity.Abs()),
+				timestamp:  trade.Timestamp,
+			})
+		}
+		open[trade.Symbol] = lots
+	}
+
+	return roundTrips
+}
+
+func closeLot(trade *models.Trade, entry *lot, matched, exitCommissionPerShare decimal.Decimal) RoundTrip {
+	commission := entry.commission.Mul(matched).Div(entry.quantity.Abs()).Add(exitCommissionPerShare.Mul(matched))
+
+	side := models.OrderSideBuy
+	gross := trade.Price.Sub(entry.price).Mul(matched)
+	if entry.quantity.IsNegative() {
+		side = models.OrderSideSell
+		gross = gross.Neg()
+	}
+
+	return RoundTrip{
+		Symbol:     trade.Symbol,
+		Side:       side,
+		Quantity:   matched,
+		EntryPrice: entry.price,
+		ExitPrice:  trade.Price,
+		EntryTime:  entry.timestamp,
+		ExitTime:   trade.Timestamp,
+		Commission: commission,
+		PnL:        gross.Sub(commission),
+	}
+}
@@ -0,0 +1,88 @@
+package analytics
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type CostSummary struct {
+	Trades       int             `json:"trades"`
+	Notional     decimal.Decimal `json:"notional"`
+	DelayBps     decimal.Decimal `json:"delay_bps"`
+	SpreadBps    decimal.Decimal `json:"spread_bps"`
+	ImpactBps    decimal.Decimal `json:"impact_bps"`
+	ShortfallBps decimal.Decimal `json:"shortfall_bps"`
+}
+
+type TransactionCostReport struct {
+	Total      CostSummary            `json:"total"`
+	Strategies map[string]CostSummary `json:"strategies"`
+	Symbols    map[string]CostSummary `json:"symbols"`
+}
+
+type costTotals struct {
+	trades    int
+	notional  decimal.Decimal
+	delay     decimal.Decimal
+	spread    decimal.Decimal
+	impact    decimal.Decimal
+	shortfall decimal.Decimal
+}
+
+func AnalyzeTransactionCosts(trades []*models.Trade) *TransactionCostReport {
+	total := &costTotals{}
+	strategies := make(map[string]*costTotals)
+	symbols := make(map[string]*costTotals)
+	for _, trade := range trades {
+		if !trade.DecisionPrice.IsPositive() {
+			continue
+		}
+		notional := trade.DecisionPrice.Mul(trade.Quantity)
+		total.add(trade.Costs, notional)
+		totalsFor(strategies, trade.StrategyID).add(trade.Costs, notional)
+		totalsFor(symbols, trade.Symbol).add(trade.Costs, notional)
+	}
+
+	report := &TransactionCostReport{
+		Total:      total.summary(),
+		Strategies: make(map[string]CostSummary, len(strategies)),
+		Symbols:    make(map[string]CostSummary, len(symbols)),
+	}
+	for strategyID, totals := range strategies {
+		report.Strategies[strategyID] = totals.summary()
+	}
+	for symbol, totals := range symbols {
+		report.Symbols[symbol] = totals.summary()
+	}
+	return report
+}
+
+func totalsFor(groups map[string]*costTotals, key string) *costTotals {
+	totals, exists := groups[key]
+	if !exists {
+		totals = &costTotals{}
+		groups[key] = totals
+	}
+	return totals
+}
+
+func (t *costTotals) add(costs models.TransactionCost, notional decimal.Decimal) {
+	t.trades++
+	t.notional = t.notional.Add(notional)
+	t.delay = t.delay.Add(costs.DelayBps.Mul(notional))
+	t.spread = t.spread.Add(costs.SpreadBps.Mul(notional))
+	t.impact = t.impact.Add(costs.ImpactBps.Mul(notional))
+	t.shortfall = t.shortfall.Add(costs.ShortfallBps.Mul(notional))
+}
+
+func (t *costTotals) summary() CostSummary {
+	summary := CostSummary{Trades: t.trades, Notional: t.notional}
+	if !t.notional.IsPositive() {
+		return summary
+	}
+	summary.DelayBps = t.delay.Div(t.notional)
+	summary.SpreadBps = t.spread.Div(t.notional)
+	summary.ImpactBps = t.impact.Div(t.notional)
+	summary.ShortfallBps = t.shortfall.Div(t.notional)
+	return summary
+}
@@ -0,0 +1,335 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/analytics"
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/feed"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/simulator"
+	"github.com/1cbyc/trade-algo-go/internal/stress"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const defaultTradeLimit = 100
+
+type Engine interface {
+	PortfolioSnapshot() *models.Portfolio
+	GetOpenOrders() []*models.Order
+	GetStrategyConfigs() []*models.StrategyConfig
+	GetStrategyPerformance() map[string]models.StrategyPerformance
+	GetLatestMarketData(symbol string) (*models.MarketData, bool)
+	GetOrderBook(symbol string) models.OrderBook
+	SetStrategyEnabled(strategyID string, enabled bool) (*models.StrategyConfig, error)
+	RunStressTests() []*stress.Result
+	RunStressTest(scenario stress.Scenario) (*stress.Result, error)
+	TradingStatus() models.TradingStatus
+	RecordAdjustments(adjustments []models.SimulatorAdjustment)
+}
+
+type Simulator interface {
+	Adjust(adjustment models.SimulatorAdjustment) ([]models.SimulatorAdjustment, error)
+	Adjustments() []models.SimulatorAdjustment
+}
+
+type simulatorRequest struct {
+	Value  decimal.Decimal `json:"value"`
+	Type   string          `json:"type"`
+	Impact decimal.Decimal `json:"impact"`
+}
+
+type Server struct {
+	engine Engine
+	mux    *http.ServeMux
+	server *http.Server
+	logger *zap.Logger
+}
+
+func NewServer(addr string, engine Engine, logger *zap.Logger) *Server {
+	s := &Server{
+		engine: engine,
+		mux:    http.NewServeMux(),
+		logger: logger,
+	}
+	s.mux.HandleFunc("/portfolio", s.get(s.handlePortfolio))
+	s.mux.HandleFunc("/positions", s.get(s.handlePositions))
+	s.mux.HandleFunc("/orders", s.get(s.handleOrders))
+	s.mux.HandleFunc("/trades", s.get(s.handleTrades))
+	s.mux.HandleFunc("/strategies", s.get(s.handleStrategies))
+	s.mux.HandleFunc("/strategies/", s.handleStrategyAction)
+	s.mux.HandleFunc("/performance", s.get(s.handlePerformance))
+	s.mux.HandleFunc("/tca", s.get(s.handleTransactionCosts))
+	s.mux.HandleFunc("/status", s.get(s.handleStatus))
+	s.mux.HandleFunc("/marketdata/", s.get(s.handleMarketData))
+	s.mux.HandleFunc("/orderbook/", s.get(s.handleOrderBook))
+	s.mux.HandleFunc("/stress", s.handleStress)
+
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) SetFeed(reporter feed.StatusReporter) {
+	s.mux.HandleFunc("/feed", s.get(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, reporter.Status())
+	}))
+}
+
+func (s *Server) SetSimulator(sim Simulator) {
+	s.mux.HandleFunc("/sim", s.get(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, sim.Adjustments())
+	}))
+	s.mux.HandleFunc("/sim/", func(w http.ResponseWriter, r *http.Request) {
+		s.handleSimulatorAdjust(w, r, sim)
+	})
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+func (s *Server) Start() {
+	go func() {
+		s.logger.Info("HTTP API listening", zap.String("addr", s.server.Addr))
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("HTTP API stopped", zap.Error(err))
+		}
+	}()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Server) get(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) handlePortfolio(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.engine.PortfolioSnapshot())
+}
+
+func (s *Server) handlePositions(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.engine.PortfolioSnapshot().SortedPositions())
+}
+
+func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request) {
+	status := models.OrderStatus(r.URL.Query().Get("status"))
+
+	orders := s.engine.GetOpenOrders()
+	if status != models.OrderStatusPending {
+		orders = append(s.engine.PortfolioSnapshot().OrderHistory, orders...)
+	}
+
+	filtered := make([]*models.Order, 0, len(orders))
+	for _, order := range orders {
+		if status == "" || order.Status == status {
+			filtered = append(filtered, order)
+		}
+	}
+	writeJSON(w, http.StatusOK, filtered)
+}
+
+func (s *Server) handleTrades(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	symbol := query.Get("symbol")
+
+	limit := defaultTradeLimit
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
+
+	history := s.engine.PortfolioSnapshot().TradeHistory
+	trades := make([]*models.Trade, 0, limit)
+	for i := len(history) - 1; i >= 0 && len(trades) < limit; i-- {
+		if symbol == "" || history[i].Symbol == symbol {
+			trades = append(trades, history[i])
+		}
+	}
+	for i, j := 0, len(trades)-1; i < j; i, j = i+1, j-1 {
+		trades[i], trades[j] = trades[j], trades[i]
+	}
+	writeJSON(w, http.StatusOK, trades)
+}
+
+func (s *Server) handleStrategies(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.engine.GetStrategyConfigs())
+}
+
+func (s *Server) handlePerformance(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.engine.GetStrategyPerformance())
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.engine.TradingStatus())
+}
+
+func (s *Server) handleTransactionCosts(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, analytics.AnalyzeTransactionCosts(s.engine.PortfolioSnapshot().TradeHistory))
+}
+
+func (s *Server) handleStrategyAction(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/strategies/"), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	var enabled bool
+	switch parts[1] {
+	case "enable":
+		enabled = true
+	case "disable":
+		enabled = false
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	config, err := s.engine.SetStrategyEnabled(parts[0], enabled)
+	if errors.Is(err, engine.ErrStrategyNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, config)
+}
+
+func (s *Server) handleSimulatorAdjust(w http.ResponseWriter, r *http.Request, sim Simulator) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/sim/"), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	adjustment := models.SimulatorAdjustment{Symbol: parts[0], Kind: models.AdjustmentKind(parts[1])}
+	switch adjustment.Kind {
+	case models.AdjustmentVolatility, models.AdjustmentTrend, models.AdjustmentEvent:
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var request simulatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid adjustment: "+err.Error())
+		return
+	}
+	adjustment.Value = request.Value
+	if adjustment.Kind == models.AdjustmentEvent {
+		adjustment.EventType, adjustment.Value = request.Type, request.Impact
+	}
+
+	applied, err := sim.Adjust(adjustment)
+	switch {
+	case errors.Is(err, simulator.ErrUnknownSymbol):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, simulator.ErrInvalidAdjustment), errors.Is(err, simulator.ErrUnknownEventType):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.engine.RecordAdjustments(applied)
+	writeJSON(w, http.StatusOK, applied)
+}
+
+func (s *Server) handleMarketData(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/marketdata/")
+	if symbol == "" || strings.Contains(symbol, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	data, exists := s.engine.GetLatestMarketData(symbol)
+	if !exists {
+		writeError(w, http.StatusNotFound, "no market data for "+symbol)
+		return
+	}
+	writeJSON(w, http.StatusOK, data)
+}
+
+func (s *Server) handleOrderBook(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/orderbook/")
+	if symbol == "" || strings.Contains(symbol, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.engine.GetOrderBook(symbol))
+}
+
+func (s *Server) handleStress(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.engine.RunStressTests())
+	case http.MethodPost:
+		var scenario stress.Scenario
+		if err := json.NewDecoder(r.Body).Decode(&scenario); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid scenario: "+err.Error())
+			return
+		}
+		result, err := s.engine.RunStressTest(scenario)
+		if errors.Is(err, stress.ErrInvalidScenario) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,330 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/analytics"
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/feed"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/simulator"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/1cbyc/trade-algo-go/internal/stress"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeEngine struct {
+	portfolio   *models.Portfolio
+	openOrders  []*models.Order
+	performance map[string]models.StrategyPerformance
+	status      models.TradingStatus
+	adjustments []models.SimulatorAdjustment
+}
+
+func (f *fakeEngine) PortfolioSnapshot() *models.Portfolio {
+	snapshot := *f.portfolio
+	snapshot.OrderHistory = append([]*models.Order(nil), f.portfolio.OrderHistory...)
+	return &snapshot
+}
+
+func (f *fakeEngine) TradingStatus() models.TradingStatus {
+	return f.status
+}
+
+func (f *fakeEngine) RecordAdjustments(adjustments []models.SimulatorAdjustment) {
+	f.adjustments = append(f.adjustments, adjustments...)
+}
+
+func (f *fakeEngine) GetOpenOrders() []*models.Order {
+	return f.openOrders
+}
+
+func (f *fakeEngine) GetStrategyConfigs() []*models.StrategyConfig {
+	return nil
+}
+
+func (f *fakeEngine) GetStrategyPerformance() map[string]models.StrategyPerformance {
+	return f.performance
+}
+
+func (f *fakeEngine) GetLatestMarketData(symbol string) (*models.MarketData, bool) {
+	return nil, false
+}
+
+func (f *fakeEngine) GetOrderBook(symbol string) models.OrderBook {
+	return models.OrderBook{Symbol: symbol}
+}
+
+func (f *fakeEngine) SetStrategyEnabled(strategyID string, enabled bool) (*models.StrategyConfig, error) {
+	return nil, engine.ErrStrategyNotFound
+}
+
+func (f *fakeEngine) RunStressTests() []*stress.Result {
+	return nil
+}
+
+func (f *fakeEngine) RunStressTest(scenario stress.Scenario) (*stress.Result, error) {
+	return nil, stress.ErrInvalidScenario
+}
+
+func createTestFakeEngine() *fakeEngine {
+	start := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+	var trades []*models.Trade
+	for i, symbol := range []string{"AAPL", "MSFT", "AAPL", "AAPL"} {
+		trades = append(trades, &models.Trade{
+			ID:        "trade_" + string(rune('a'+i)),
+			Symbol:    symbol,
+			Price:     decimal.NewFromInt(int64(100 + i)),
+			Timestamp: start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	return &fakeEngine{
+		portfolio: &models.Portfolio{
+			ID: "PORT-1",
+			Positions: map[string]*models.Position{
+				"MSFT": {Symbol: "MSFT", Quantity: decimal.NewFromInt(5)},
+				"AAPL": {Symbol: "AAPL", Quantity: decimal.NewFromInt(10)},
+			},
+			TradeHistory: trades,
+			OrderHistory: []*models.Order{
+				{ID: "filled", Status: models.OrderStatusFilled},
+				{ID: "rejected", Status: models.OrderStatusRejected},
+			},
+		},
+		openOrders: []*models.Order{{ID: "open", Status: models.OrderStatusPending}},
+		performance: map[string]models.StrategyPerformance{
+			"ma":  {StrategyID: "ma", RealizedPnL: decimal.NewFromInt(120), Trades: 4},
+			"rsi": {StrategyID: "rsi", RealizedPnL: decimal.NewFromInt(-30), Trades: 2},
+		},
+	}
+}
+
+func serve(t *testing.T, handler http.Handler, method, target string, out interface{}) int {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	if out != nil {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), out))
+	}
+	return recorder.Code
+}
+
+func TestServer_ReadEndpoints(t *testing.T) {
+	handler := NewServer("", createTestFakeEngine(), zap.NewNop()).Handler()
+
+	var portfolio models.Portfolio
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/portfolio", &portfolio))
+	assert.Equal(t, "PORT-1", portfolio.ID)
+
+	var positions []*models.Position
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/positions", &positions))
+	require.Len(t, positions, 2)
+	assert.Equal(t, "AAPL", positions[0].Symbol)
+
+	var orders []*models.Order
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/orders?status=pending", &orders))
+	require.Len(t, orders, 1)
+	assert.Equal(t, "open", orders[0].ID)
+
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/orders?status=rejected", &orders))
+	require.Len(t, orders, 1)
+	assert.Equal(t, "rejected", orders[0].ID)
+
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/orders", &orders))
+	assert.Len(t, orders, 3)
+
+	var performance map[string]models.StrategyPerformance
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/performance", &performance))
+	require.Len(t, performance, 2)
+	assert.True(t, decimal.NewFromInt(120).Equal(performance["ma"].RealizedPnL))
+	assert.Equal(t, 2, performance["rsi"].Trades)
+}
+
+func TestServer_Trades(t *testing.T) {
+	handler := NewServer("", createTestFakeEngine(), zap.NewNop()).Handler()
+
+	var trades []*models.Trade
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/trades?symbol=AAPL&limit=2", &trades))
+	require.Len(t, trades, 2)
+	assert.Equal(t, "trade_c", trades[0].ID)
+	assert.Equal(t, "trade_d", trades[1].ID)
+
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/trades", &trades))
+	assert.Len(t, trades, 4)
+
+	assert.Equal(t, http.StatusBadRequest, serve(t, handler, http.MethodGet, "/trades?limit=abc", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(t, handler, http.MethodPost, "/trades", nil))
+}
+
+func TestServer_Status(t *testing.T) {
+	fake := createTestFakeEngine()
+	handler := NewServer("", fake, zap.NewNop()).Handler()
+
+	var status models.TradingStatus
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/status", &status))
+	assert.False(t, status.Halted)
+
+	haltedAt := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	fake.status = models.TradingStatus{
+		Halted: true,
+		Halt:   &models.TradingHalt{Reason: "loss_limit", Loss: decimal.NewFromFloat(0.04), Threshold: decimal.NewFromFloat(0.03), HaltedAt: haltedAt},
+	}
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/status", &status))
+	assert.True(t, status.Halted)
+	require.NotNil(t, status.Halt)
+	assert.Equal(t, "loss_limit", status.Halt.Reason)
+	assert.True(t, decimal.NewFromFloat(0.04).Equal(status.Halt.Loss))
+	assert.Equal(t, haltedAt, status.Halt.HaltedAt)
+}
+
+func TestServer_TransactionCosts(t *testing.T) {
+	fake := createTestFakeEngine()
+	for i, trade := range fake.portfolio.TradeHistory {
+		trade.StrategyID = []string{"ma", "rsi"}[i%2]
+		trade.Quantity = decimal.NewFromInt(10)
+		trade.DecisionPrice = decimal.NewFromInt(100)
+		trade.Costs = models.TransactionCost{DelayBps: decimal.NewFromInt(int64(10 * (i + 1))), ShortfallBps: decimal.NewFromInt(int64(10 * (i + 1)))}
+	}
+	handler := NewServer("", fake, zap.NewNop()).Handler()
+
+	var report analytics.TransactionCostReport
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/tca", &report))
+	assert.Equal(t, 4, report.Total.Trades)
+	assert.True(t, decimal.NewFromInt(25).Equal(report.Total.ShortfallBps), report.Total.ShortfallBps.String())
+	assert.True(t, decimal.NewFromInt(20).Equal(report.Strategies["ma"].DelayBps), report.Strategies["ma"].DelayBps.String())
+	assert.True(t, decimal.NewFromInt(20).Equal(report.Symbols["MSFT"].DelayBps))
+	assert.Equal(t, 3, report.Symbols["AAPL"].Trades)
+}
+
+func TestServer_StrategiesAndMarketData(t *testing.T) {
+	tradingEngine := engine.NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	tradingEngine.AddStrategy(strategies.NewMovingAverageStrategy(&models.StrategyConfig{ID: "ma", Name: "MA", Enabled: true}))
+	tradingEngine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(150.25), Timestamp: time.Now()})
+	handler := NewServer("", tradingEngine, zap.NewNop()).Handler()
+
+	var configs []*models.StrategyConfig
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/strategies", &configs))
+	require.Len(t, configs, 1)
+	assert.True(t, configs[0].Enabled)
+
+	var config models.StrategyConfig
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodPost, "/strategies/ma/disable", &config))
+	assert.False(t, config.Enabled)
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/strategies", &configs))
+	assert.False(t, configs[0].Enabled)
+
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodPost, "/strategies/ma/enable", &config))
+	assert.True(t, config.Enabled)
+
+	assert.Equal(t, http.StatusNotFound, serve(t, handler, http.MethodPost, "/strategies/unknown/enable", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(t, handler, http.MethodGet, "/strategies/ma/enable", nil))
+	assert.Equal(t, http.StatusNotFound, serve(t, handler, http.MethodPost, "/strategies/ma/pause", nil))
+
+	var data models.MarketData
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/marketdata/AAPL", &data))
+	assert.True(t, decimal.NewFromFloat(150.25).Equal(data.Price))
+	assert.Equal(t, http.StatusNotFound, serve(t, handler, http.MethodGet, "/marketdata/TSLA", nil))
+
+	var book models.OrderBook
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/orderbook/AAPL", &book))
+	assert.Equal(t, "AAPL", book.Symbol)
+	assert.Empty(t, book.Bids)
+	assert.Equal(t, http.StatusNotFound, serve(t, handler, http.MethodGet, "/orderbook/", nil))
+}
+
+func TestServer_Stress(t *testing.T) {
+	tradingEngine := engine.NewTradingEngine(decimal.NewFromInt(100000), zap.NewNop())
+	require.NoError(t, tradingEngine.SetStressScenarios([]stress.Scenario{{Name: "crash", Shocks: []stress.Shock{{PriceChange: decimal.NewFromFloat(-0.2)}}}}))
+	handler := NewServer("", tradingEngine, zap.NewNop()).Handler()
+
+	var results []*stress.Result
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/stress", &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, "crash", results[0].Scenario)
+	assert.True(t, decimal.NewFromInt(100000).Equal(results[0].StressedValue))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/stress", strings.NewReader(body)))
+		return recorder
+	}
+
+	recorder := post(`{"name": "tsla", "shocks": [{"symbols": ["TSLA"], "price_change": -0.5}]}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var result stress.Result
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	assert.Equal(t, "tsla", result.Scenario)
+
+	assert.Equal(t, http.StatusBadRequest, post(`{"name": "empty"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`not json`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(t, handler, http.MethodDelete, "/stress", nil))
+}
+
+type fakeFeedStatus feed.Status
+
+func (f fakeFeedStatus) Status() feed.Status {
+	return feed.Status(f)
+}
+
+func TestServer_FeedStatus(t *testing.T) {
+	server := NewServer("", createTestFakeEngine(), zap.NewNop())
+	assert.Equal(t, http.StatusNotFound, serve(t, server.Handler(), http.MethodGet, "/feed", nil))
+
+	server.SetFeed(fakeFeedStatus{State: feed.StateConnecting, Reconnects: 3, LastError: "connection reset"})
+
+	var status feed.Status
+	assert.Equal(t, http.StatusOK, serve(t, server.Handler(), http.MethodGet, "/feed", &status))
+	assert.Equal(t, feed.StateConnecting, status.State)
+	assert.Equal(t, 3, status.Reconnects)
+	assert.Equal(t, "connection reset", status.LastError)
+}
+
+func TestServer_SimulatorAdjustments(t *testing.T) {
+	fake := createTestFakeEngine()
+	server := NewServer("", fake, zap.NewNop())
+	assert.Equal(t, http.StatusNotFound, serve(t, server.Handler(), http.MethodGet, "/sim", nil))
+
+	sim := simulator.NewMarketSimulator(zap.NewNop(), simulator.WithSeed(42))
+	sim.AddSymbol("TSLA", decimal.NewFromInt(200), decimal.NewFromFloat(0.6))
+	server.SetSimulator(sim)
+	handler := server.Handler()
+	post := func(target, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+		return recorder
+	}
+
+	recorder := post("/sim/TSLA/event", `{"type": "price_shock", "impact": -0.1}`)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var applied []models.SimulatorAdjustment
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &applied))
+	require.Len(t, applied, 1)
+	assert.True(t, decimal.NewFromInt(200).Equal(applied[0].Previous))
+	assert.True(t, decimal.NewFromInt(180).Equal(sim.GetSymbolData("TSLA").CurrentPrice))
+
+	assert.Equal(t, http.StatusOK, post("/sim/TSLA/volatility", `{"value": 1.2}`).Code)
+	assert.True(t, decimal.NewFromFloat(1.2).Equal(sim.GetSymbolData("TSLA").Volatility))
+	require.Len(t, fake.adjustments, 2)
+	assert.Equal(t, models.AdjustmentVolatility, fake.adjustments[1].Kind)
+
+	var logged []models.SimulatorAdjustment
+	assert.Equal(t, http.StatusOK, serve(t, handler, http.MethodGet, "/sim", &logged))
+	assert.Len(t, logged, 2)
+
+	assert.Equal(t, http.StatusNotFound, post("/sim/AAPL/trend", `{"value": 0.1}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post("/sim/TSLA/event", `{"type": "halt", "impact": 0.1}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post("/sim/TSLA/event", `{"type": "price_shock", "impact": -1.5}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post("/sim/TSLA/trend", `not json`).Code)
+	assert.Equal(t, http.StatusNotFound, post("/sim/TSLA/drift", `{"value": 0.1}`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(t, handler, http.MethodGet, "/sim/TSLA/trend", nil))
+	assert.Len(t, fake.adjustments, 2)
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type EventType string
+
+const (
+	EventOrderCreated      EventType = "order_created"
+	EventOrderValidated    EventType = "order_validated"
+	EventOrderRejected     EventType = "order_rejected"
+	EventRiskCalculated    EventType = "risk_calculated"
+	EventOrderFilled       EventType = "order_filled"
+	EventOrderCancelled    EventType = "order_cancelled"
+	EventOrderModified     EventType = "order_modified"
+	EventOrderCompleted    EventType = "order_completed"
+	EventCorporateAction   EventType = "corporate_action"
+	EventMarginInterest    EventType = "margin_interest"
+	EventTradingHalted     EventType = "trading_halted"
+	EventTradingResumed    EventType = "trading_resumed"
+	EventSimulatorAdjusted EventType = "simulator_adjusted"
+)
+
+type Record struct {
+	Sequence        uint64                      `json:"seq"`
+	Timestamp       time.Time                   `json:"timestamp"`
+	Type            EventType                   `json:"type"`
+	OrderID         string                      `json:"order_id,omitempty"`
+	Order           *models.Order               `json:"order,omitempty"`
+	Signal          *models.AlgorithmResult     `json:"signal,omitempty"`
+	RiskMetrics     *models.RiskMetrics         `json:"risk_metrics,omitempty"`
+	Trade           *models.Trade               `json:"trade,omitempty"`
+	Slippage        *decimal.Decimal            `json:"slippage,omitempty"`
+	ReplacesID      string                      `json:"replaces_id,omitempty"`
+	CorporateAction *models.CorporateAction     `json:"corporate_action,omitempty"`
+	Interest        *decimal.Decimal            `json:"interest,omitempty"`
+	Halt            *models.TradingHalt         `json:"halt,omitempty"`
+	Adjustment      *models.SimulatorAdjustment `json:"adjustment,omitempty"`
+	Error           string                      `json:"error,omitempty"`
+}
@@ -0,0 +1,8 @@
+package audit
+
+import "errors"
+
+var (
+	ErrWriterClosed = errors.New("audit log is closed")
+	ErrOutOfOrder   = errors.New("audit records are out of sequence")
+)
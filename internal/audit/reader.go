@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const maxLineSize = 16 * 1024 * 1024
+
+func Read(path string) ([]Record, error) {
+	var files []string
+	for i := 1; ; i++ {
+		if _, err := os.Stat(backupPath(path, i)); err != nil {
+			break
+		}
+		files = append([]string{backupPath(path, i)}, files...)
+	}
+	files = append(files, path)
+
+	var records []Record
+	for _, file := range files {
+		read, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, read...)
+	}
+
+	for i := 1; i < len(records); i++ {
+		if records[i].Sequence <= records[i-1].Sequence {
+			return nil, fmt.Errorf("%w: %d follows %d", ErrOutOfOrder, records[i].Sequence, records[i-1].Sequence)
+		}
+	}
+	return records, nil
+}
+
+func readFile(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, defaultBufferSize), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
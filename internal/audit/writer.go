@@ -0,0 +1,169 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const defaultBufferSize = 64 * 1024
+
+type Writer struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	unbuffered bool
+
+	mu       sync.Mutex
+	file     *os.File
+	buffer   *bufio.Writer
+	size     int64
+	sequence uint64
+	closed   bool
+}
+
+type Option func(*Writer)
+
+func WithMaxSize(bytes int64) Option {
+	return func(w *Writer) {
+		w.maxSize = bytes
+	}
+}
+
+func WithMaxBackups(backups int) Option {
+	return func(w *Writer) {
+		w.maxBackups = backups
+	}
+}
+
+func WithUnbuffered() Option {
+	return func(w *Writer) {
+		w.unbuffered = true
+	}
+}
+
+func NewWriter(path string, opts ...Option) (*Writer, error) {
+	w := &Writer{path: path}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	records, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 {
+		w.sequence = records[len(records)-1].Sequence
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	w.file = file
+	w.buffer = bufio.NewWriterSize(file, defaultBufferSize)
+	w.size = info.Size()
+	return nil
+}
+
+func (w *Writer) Write(record Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrWriterClosed
+	}
+
+	record.Sequence = w.sequence + 1
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(line)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := w.buffer.Write(line); err != nil {
+		return err
+	}
+	w.size += int64(len(line))
+	w.sequence = record.Sequence
+	if w.unbuffered {
+		return w.buffer.Flush()
+	}
+	return nil
+}
+
+func (w *Writer) Sequence() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.sequence
+}
+
+func (w *Writer) rotate() error {
+	if err := w.buffer.Flush(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	if w.maxBackups > 0 {
+		os.Remove(backupPath(w.path, w.maxBackups))
+	}
+	for i := w.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(backupPath(w.path, i), backupPath(w.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if w.maxBackups > 0 {
+		if err := os.Rename(w.path, backupPath(w.path, 1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(w.path); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	return w.buffer.Flush()
+}
+
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if err := w.buffer.Flush(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+func backupPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
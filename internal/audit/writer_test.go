@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRecords(t *testing.T, writer *Writer, count int) {
+	for i := 0; i < count; i++ {
+		require.NoError(t, writer.Write(Record{Type: EventOrderCreated, OrderID: "order"}))
+	}
+}
+
+func sequences(records []Record) []uint64 {
+	var seqs []uint64
+	for _, record := range records {
+		seqs = append(seqs, record.Sequence)
+	}
+	return seqs
+}
+
+func TestWriter_Write_BuffersUntilFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writer, err := NewWriter(path)
+	require.NoError(t, err)
+	defer writer.Close()
+
+	writeRecords(t, writer, 2)
+	records, err := Read(path)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	require.NoError(t, writer.Flush())
+	records, err = Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, sequences(records))
+	assert.Equal(t, EventOrderCreated, records[0].Type)
+}
+
+func TestWriter_WithUnbuffered_WritesThrough(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writer, err := NewWriter(path, WithUnbuffered())
+	require.NoError(t, err)
+	defer writer.Close()
+
+	writeRecords(t, writer, 2)
+	records, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, sequences(records))
+	assert.Equal(t, uint64(2), writer.Sequence())
+}
+
+func TestWriter_NewWriter_ContinuesSequence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writer, err := NewWriter(path)
+	require.NoError(t, err)
+	writeRecords(t, writer, 3)
+	require.NoError(t, writer.Close())
+
+	writer, err = NewWriter(path)
+	require.NoError(t, err)
+	writeRecords(t, writer, 2)
+	require.NoError(t, writer.Close())
+
+	records, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, sequences(records))
+}
+
+func TestWriter_Write_RotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writer, err := NewWriter(path, WithMaxSize(200), WithMaxBackups(10))
+	require.NoError(t, err)
+	writeRecords(t, writer, 10)
+	require.NoError(t, writer.Close())
+
+	_, err = os.Stat(backupPath(path, 1))
+	require.NoError(t, err)
+	for _, file := range []string{path, backupPath(path, 1)} {
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(200))
+	}
+
+	records, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, sequences(records))
+}
+
+func TestWriter_Write_DropsOldestBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writer, err := NewWriter(path, WithMaxSize(100), WithMaxBackups(2))
+	require.NoError(t, err)
+	writeRecords(t, writer, 10)
+	require.NoError(t, writer.Close())
+
+	_, err = os.Stat(backupPath(path, 3))
+	assert.True(t, os.IsNotExist(err))
+
+	records, err := Read(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	assert.Equal(t, uint64(10), records[len(records)-1].Sequence)
+	assert.Greater(t, records[0].Sequence, uint64(1))
+}
+
+func TestWriter_Write_AfterClose(t *testing.T) {
+	writer, err := NewWriter(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	assert.ErrorIs(t, writer.Write(Record{Type: EventOrderCreated}), ErrWriterClosed)
+}
+
+func TestRead_RejectsOutOfOrderRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"seq":2,"type":"order_created"}`+"\n"+`{"seq":1,"type":"order_created"}`+"\n"), 0o644))
+
+	_, err := Read(path)
+	assert.ErrorIs(t, err, ErrOutOfOrder)
+}
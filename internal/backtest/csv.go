@@ -0,0 +1,152 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+var csvHeader = []string{"timestamp", "open", "high", "low", "close", "volume"}
+
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func LoadDirectory(dir string) ([]*models.MarketData, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*models.MarketData
+	for _, path := range paths {
+		symbol := strings.ToUpper(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+		bars, err := LoadFile(path, symbol)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, bars...)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%s: %w", dir, ErrNoData)
+	}
+
+	sort.SliceStable(data, func(i, j int) bool {
+		if data[i].Timestamp.Equal(data[j].Timestamp) {
+			return data[i].Symbol < data[j].Symbol
+		}
+		return data[i].Timestamp.Before(data[j].Timestamp)
+	})
+	return data, nil
+}
+
+func LoadFile(path, symbol string) ([]*models.MarketData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := ReadCSV(file, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return data, nil
+}
+
+func ReadCSV(r io.Reader, symbol string) ([]*models.MarketData, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrNoData
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range csvHeader {
+		if strings.ToLower(strings.TrimSpace(header[i])) != column {
+			return nil, fmt.Errorf("%w: expected %s", ErrInvalidHeader, strings.Join(csvHeader, ","))
+		}
+	}
+
+	var data []*models.MarketData
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		bar, err := parseRecord(record, symbol)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		data = append(data, bar)
+	}
+
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].Timestamp.Before(data[j].Timestamp)
+	})
+	return data, nil
+}
+
+func parseRecord(record []string, symbol string) (*models.MarketData, error) {
+	timestamp, err := parseTimestamp(record[0])
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]decimal.Decimal, 4)
+	for i := range prices {
+		prices[i], err = decimal.NewFromString(record[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %q", ErrInvalidRecord, csvHeader[i+1], record[i+1])
+		}
+	}
+
+	volume, err := decimal.NewFromString(record[5])
+	if err != nil {
+		return nil, fmt.Errorf("%w: volume %q", ErrInvalidRecord, record[5])
+	}
+
+	return &models.MarketData{
+		Symbol:    symbol,
+		Kind:      models.MarketDataKindBar,
+		Price:     prices[3],
+		Volume:    volume.IntPart(),
+		Open:      prices[0],
+		High:      prices[1],
+		Low:       prices[2],
+		Close:     prices[3],
+		Timestamp: timestamp,
+	}, nil
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if timestamp, err := time.Parse(layout, value); err == nil {
+			return timestamp, nil
+		}
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: timestamp %q", ErrInvalidRecord, value)
+}
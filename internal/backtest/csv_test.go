@@ -0,0 +1,79 @@
+package backtest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	input := "timestamp,open,high,low,close,volume\n" +
+		"2024-01-02,101,103,100,102.5,1500\n" +
+		"2024-01-01,100,102,99,101,1200.0\n"
+
+	data, err := ReadCSV(strings.NewReader(input), "AAPL")
+
+	require.NoError(t, err)
+	require.Len(t, data, 2)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), data[0].Timestamp)
+	assert.Equal(t, "AAPL", data[1].Symbol)
+	assert.True(t, decimal.NewFromFloat(101).Equal(data[1].Open))
+	assert.True(t, decimal.NewFromFloat(103).Equal(data[1].High))
+	assert.True(t, decimal.NewFromFloat(100).Equal(data[1].Low))
+	assert.True(t, decimal.NewFromFloat(102.5).Equal(data[1].Close))
+	assert.True(t, data[1].Close.Equal(data[1].Price))
+	assert.Equal(t, int64(1500), data[1].Volume)
+}
+
+func TestReadCSV_TimestampFormats(t *testing.T) {
+	input := "timestamp,open,high,low,close,volume\n" +
+		"2024-01-01T09:30:00Z,1,1,1,1,1\n" +
+		"2024-01-01 09:31:00,1,1,1,1,1\n" +
+		"1704101520,1,1,1,1,1\n"
+
+	data, err := ReadCSV(strings.NewReader(input), "AAPL")
+
+	require.NoError(t, err)
+	require.Len(t, data, 3)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC), data[0].Timestamp)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 31, 0, 0, time.UTC), data[1].Timestamp)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 32, 0, 0, time.UTC), data[2].Timestamp)
+}
+
+func TestReadCSV_InvalidHeader(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("date,o,h,l,c,v\n"), "AAPL")
+
+	assert.True(t, errors.Is(err, ErrInvalidHeader))
+}
+
+func TestReadCSV_InvalidRecord(t *testing.T) {
+	input := "timestamp,open,high,low,close,volume\n" +
+		"2024-01-01,100,102,99,101,1200\n" +
+		"2024-01-02,101,abc,100,102,1500\n"
+
+	_, err := ReadCSV(strings.NewReader(input), "AAPL")
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidRecord))
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func TestLoadDirectory(t *testing.T) {
+	data, err := LoadDirectory("testdata")
+
+	require.NoError(t, err)
+	assert.Len(t, data, 60)
+	assert.Equal(t, "AAPL", data[0].Symbol)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), data[0].Timestamp)
+}
+
+func TestLoadDirectory_Empty(t *testing.T) {
+	_, err := LoadDirectory(t.TempDir())
+
+	assert.True(t, errors.Is(err, ErrNoData))
+}
@@ -0,0 +1,9 @@
+package backtest
+
+import "errors"
+
+var (
+	ErrInvalidHeader = errors.New("invalid csv header")
+	ErrInvalidRecord = errors.New("invalid csv record")
+	ErrNoData        = errors.New("no market data found")
+)
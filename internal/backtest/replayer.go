@@ -0,0 +1,41 @@
+package backtest
+
+import (
+	"context"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"go.uber.org/zap"
+)
+
+type Replayer struct {
+	data       []*models.MarketData
+	logger     *zap.Logger
+	updateChan chan *models.MarketData
+}
+
+func NewReplayer(data []*models.MarketData, logger *zap.Logger) *Replayer {
+	return &Replayer{
+		data:       data,
+		logger:     logger,
+		updateChan: make(chan *models.MarketData, 1000),
+	}
+}
+
+func (r *Replayer) Start(ctx context.Context) {
+	r.logger.Info("Backtest replay started", zap.Int("bars", len(r.data)))
+
+	go func() {
+		defer close(r.updateChan)
+		for _, data := range r.data {
+			select {
+			case r.updateChan <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (r *Replayer) GetUpdateChannel() <-chan *models.MarketData {
+	return r.updateChan
+}
@@ -0,0 +1,84 @@
+package backtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"go.uber.org/zap"
+)
+
+type Runner struct {
+	engine *engine.TradingEngine
+	logger *zap.Logger
+	warmUp int
+}
+
+type Option func(*Runner)
+
+func WithWarmUp(bars int) Option {
+	return func(r *Runner) {
+		r.warmUp = bars
+	}
+}
+
+func NewRunner(tradingEngine *engine.TradingEngine, logger *zap.Logger, opts ...Option) *Runner {
+	r := &Runner{
+		engine: tradingEngine,
+		logger: logger,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Runner) Run(ctx context.Context, updates <-chan *models.MarketData) (*models.Portfolio, error) {
+	var current time.Time
+	bars := 0
+	warmed := make(map[string]int)
+
+	for data := range updates {
+		if !current.IsZero() && data.Timestamp.After(current) {
+			r.engine.Advance(ctx, current)
+		}
+
+		if data.CorporateAction != nil {
+			if err := r.engine.ApplyCorporateAction(*data.CorporateAction); err != nil {
+				r.logger.Warn("Corporate action skipped", zap.String("symbol", data.Symbol), zap.Error(err))
+			}
+			continue
+		}
+		if data.News != nil {
+			r.engine.ProcessNews(*data.News)
+			continue
+		}
+
+		if warmed[data.Symbol] < r.warmUp {
+			if err := r.engine.SeedMarketHistory(data.Symbol, []*models.MarketData{data}); err != nil {
+				return r.engine.GetPortfolio(), err
+			}
+			warmed[data.Symbol]++
+			continue
+		}
+
+		r.engine.UpdateMarketData(data.Symbol, data)
+		if data.Timestamp.After(current) {
+			current = data.Timestamp
+		}
+		bars++
+	}
+
+	if err := ctx.Err(); err != nil {
+		return r.engine.GetPortfolio(), err
+	}
+	if bars == 0 {
+		return r.engine.GetPortfolio(), ErrNoData
+	}
+	r.engine.Advance(ctx, current)
+	r.engine.CloseBooks()
+
+	r.logger.Info("Backtest completed", zap.Int("bars", bars), zap.Int("warm_up_bars", r.warmUp), zap.Time("last_bar", current))
+	return r.engine.GetPortfolio(), nil
+}
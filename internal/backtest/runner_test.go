@@ -0,0 +1,129 @@
+package backtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRunner_Run(t *testing.T) {
+	data, err := LoadDirectory("testdata")
+	require.NoError(t, err)
+
+	tradingEngine := engine.NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	tradingEngine.AddStrategy(strategies.NewMovingAverageStrategy(createTestStrategyConfig()))
+	replayer := NewReplayer(data, zap.NewNop())
+	replayer.Start(context.Background())
+
+	portfolio, err := NewRunner(tradingEngine, zap.NewNop()).Run(context.Background(), replayer.GetUpdateChannel())
+
+	require.NoError(t, err)
+	require.Len(t, portfolio.TradeHistory, 2)
+	assert.Equal(t, models.OrderSideBuy, portfolio.TradeHistory[0].Side)
+	assert.True(t, decimal.NewFromInt(99).Equal(portfolio.TradeHistory[0].Quantity))
+	assert.True(t, decimal.NewFromFloat(101.0).Equal(portfolio.TradeHistory[0].Price))
+	assert.Equal(t, models.OrderSideSell, portfolio.TradeHistory[1].Side)
+	assert.True(t, decimal.NewFromFloat(90.0).Equal(portfolio.TradeHistory[1].Price))
+	assert.Equal(t, data[30].Timestamp, portfolio.TradeHistory[0].Timestamp)
+	assert.Empty(t, portfolio.Positions)
+	assert.True(t, decimal.NewFromFloat(98892.091).Equal(portfolio.Cash), portfolio.Cash.String())
+}
+
+type recordingStrategy struct {
+	strategies.Strategy
+	results []*models.AlgorithmResult
+	bars    []time.Time
+}
+
+func (s *recordingStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	result, err := s.Strategy.Execute(ctx, portfolio, market)
+	if err == nil && result != nil {
+		s.results = append(s.results, result)
+		s.bars = append(s.bars, market.Latest[result.Symbol].Timestamp)
+	}
+	return result, err
+}
+
+func TestRunner_Run_SignalsCarryBarTime(t *testing.T) {
+	data, err := LoadDirectory("testdata")
+	require.NoError(t, err)
+
+	strategy := &recordingStrategy{Strategy: strategies.NewMovingAverageStrategy(createTestStrategyConfig())}
+	tradingEngine := engine.NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	tradingEngine.AddStrategy(strategy)
+	replayer := NewReplayer(data, zap.NewNop())
+	replayer.Start(context.Background())
+
+	_, err = NewRunner(tradingEngine, zap.NewNop()).Run(context.Background(), replayer.GetUpdateChannel())
+
+	require.NoError(t, err)
+	require.NotEmpty(t, strategy.results)
+	for i, result := range strategy.results {
+		assert.Equal(t, strategy.bars[i], result.Timestamp)
+	}
+}
+
+func TestRunner_Run_WarmUpBarsDoNotTrade(t *testing.T) {
+	data, err := LoadDirectory("testdata")
+	require.NoError(t, err)
+
+	tradingEngine := engine.NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	tradingEngine.AddStrategy(strategies.NewMovingAverageStrategy(createTestStrategyConfig()))
+	replayer := NewReplayer(data, zap.NewNop())
+	replayer.Start(context.Background())
+
+	portfolio, err := NewRunner(tradingEngine, zap.NewNop(), WithWarmUp(35)).Run(context.Background(), replayer.GetUpdateChannel())
+
+	require.NoError(t, err)
+	require.NotEmpty(t, portfolio.TradeHistory)
+	assert.Equal(t, models.OrderSideBuy, portfolio.TradeHistory[0].Side)
+	assert.Equal(t, data[35].Timestamp, portfolio.TradeHistory[0].Timestamp)
+	assert.True(t, decimal.NewFromFloat(106.0).Equal(portfolio.TradeHistory[0].Price))
+}
+
+func TestRunner_Run_NoData(t *testing.T) {
+	tradingEngine := engine.NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	replayer := NewReplayer(nil, zap.NewNop())
+	replayer.Start(context.Background())
+
+	_, err := NewRunner(tradingEngine, zap.NewNop()).Run(context.Background(), replayer.GetUpdateChannel())
+
+	assert.Equal(t, ErrNoData, err)
+}
+
+func TestRunner_Run_Cancelled(t *testing.T) {
+	data, err := LoadDirectory("testdata")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tradingEngine := engine.NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	replayer := NewReplayer(data, zap.NewNop())
+	replayer.Start(ctx)
+
+	_, err = NewRunner(tradingEngine, zap.NewNop()).Run(ctx, replayer.GetUpdateChannel())
+
+	assert.Equal(t, context.Canceled, err)
+}
+
+func createTestStrategyConfig() *models.StrategyConfig {
+	return &models.StrategyConfig{
+		ID:               "backtest_ma",
+		Name:             "Backtest MA",
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+		MinOrderSize:     decimal.NewFromFloat(1000.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+		CommissionRate:   decimal.NewFromFloat(0.001),
+		MarketDataWindow: 30,
+		Enabled:          true,
+	}
+}
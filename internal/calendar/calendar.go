@@ -0,0 +1,60 @@
+package calendar
+
+import "time"
+
+type Calendar struct {
+	defaultSession *Session
+	symbols        map[string]*Session
+}
+
+func NewCalendar(defaultSession *Session) *Calendar {
+	if defaultSession == nil {
+		defaultSession = AlwaysOpen()
+	}
+	return &Calendar{
+		defaultSession: defaultSession,
+		symbols:        make(map[string]*Session),
+	}
+}
+
+func (c *Calendar) SetSession(symbol string, session *Session) {
+	c.symbols[symbol] = session
+}
+
+func (c *Calendar) Session(symbol string) *Session {
+	if session, exists := c.symbols[symbol]; exists {
+		return session
+	}
+	return c.defaultSession
+}
+
+func (c *Calendar) IsOpen(symbol string, t time.Time) bool {
+	if c == nil {
+		return true
+	}
+	return c.Session(symbol).IsOpen(t)
+}
+
+func (c *Calendar) Location() *time.Location {
+	if c == nil {
+		return time.UTC
+	}
+	return c.defaultSession.location
+}
+
+func (c *Calendar) TradingDay(t time.Time) bool {
+	if c == nil {
+		return true
+	}
+	return c.defaultSession.TradingDay(t)
+}
+
+func (c *Calendar) SameSession(symbol string, a, b time.Time) bool {
+	if c == nil {
+		return true
+	}
+	session := c.Session(symbol)
+	openA, inA := session.Opened(a)
+	openB, inB := session.Opened(b)
+	return inA && inB && openA.Equal(openB)
+}
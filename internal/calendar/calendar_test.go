@@ -0,0 +1,91 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSession(t *testing.T) *Session {
+	t.Helper()
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	session, err := ParseSession("09:30-16:00", location)
+	require.NoError(t, err)
+	return session
+}
+
+func TestSession_IsOpen(t *testing.T) {
+	session := createTestSession(t)
+	newYork := session.location
+
+	assert.False(t, session.IsOpen(time.Date(2024, 3, 5, 9, 29, 59, 0, newYork)))
+	assert.True(t, session.IsOpen(time.Date(2024, 3, 5, 9, 30, 0, 0, newYork)))
+	assert.True(t, session.IsOpen(time.Date(2024, 3, 5, 15, 59, 59, 0, newYork)))
+	assert.False(t, session.IsOpen(time.Date(2024, 3, 5, 16, 0, 0, 0, newYork)))
+	assert.False(t, session.IsOpen(time.Date(2024, 3, 9, 12, 0, 0, 0, newYork)))
+	assert.True(t, session.IsOpen(time.Date(2024, 3, 5, 15, 0, 0, 0, time.UTC)))
+	assert.False(t, session.IsOpen(time.Date(2024, 3, 5, 21, 0, 0, 0, time.UTC)))
+}
+
+func TestSession_Opened(t *testing.T) {
+	session := createTestSession(t)
+	newYork := session.location
+
+	open, inSession := session.Opened(time.Date(2024, 3, 5, 11, 15, 0, 0, newYork))
+	assert.True(t, inSession)
+	assert.Equal(t, time.Date(2024, 3, 5, 9, 30, 0, 0, newYork), open)
+
+	_, inSession = session.Opened(time.Date(2024, 3, 5, 17, 0, 0, 0, newYork))
+	assert.False(t, inSession)
+}
+
+func TestParseSession_Invalid(t *testing.T) {
+	for _, spec := range []string{"09:30", "16:00-09:30", "9am-4pm", "09:30-25:00"} {
+		_, err := ParseSession(spec, time.UTC)
+		assert.ErrorIs(t, err, ErrInvalidSession, spec)
+	}
+
+	session, err := ParseSession(AlwaysOpenSpec, time.UTC)
+	require.NoError(t, err)
+	assert.True(t, session.AlwaysOpen())
+}
+
+func TestCalendar_PerSymbolSessions(t *testing.T) {
+	cal := NewCalendar(createTestSession(t))
+	cal.SetSession("BTCUSDT", AlwaysOpen())
+	saturday := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, cal.IsOpen("AAPL", saturday))
+	assert.True(t, cal.IsOpen("BTCUSDT", saturday))
+
+	var unset *Calendar
+	assert.True(t, unset.IsOpen("AAPL", saturday))
+	assert.True(t, unset.SameSession("AAPL", saturday, saturday.Add(72*time.Hour)))
+}
+
+func TestCalendar_SameSession(t *testing.T) {
+	cal := NewCalendar(createTestSession(t))
+	cal.SetSession("BTCUSDT", AlwaysOpen())
+	beforeClose := time.Date(2024, 3, 5, 20, 59, 0, 0, time.UTC)
+
+	assert.True(t, cal.SameSession("AAPL", beforeClose.Add(-time.Hour), beforeClose))
+	assert.False(t, cal.SameSession("AAPL", beforeClose, beforeClose.Add(2*time.Minute)))
+	assert.False(t, cal.SameSession("AAPL", beforeClose, beforeClose.Add(24*time.Hour)))
+	assert.True(t, cal.SameSession("BTCUSDT", beforeClose, beforeClose.Add(24*time.Hour)))
+}
+
+func TestCalendar_TradingDay(t *testing.T) {
+	cal := NewCalendar(createTestSession(t))
+	fridayNight := time.Date(2024, 3, 9, 2, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "America/New_York", cal.Location().String())
+	assert.True(t, cal.TradingDay(fridayNight))
+	assert.False(t, cal.TradingDay(fridayNight.Add(24*time.Hour)))
+
+	var unset *Calendar
+	assert.Equal(t, time.UTC, unset.Location())
+	assert.True(t, unset.TradingDay(fridayNight.Add(24*time.Hour)))
+}
@@ -0,0 +1,5 @@
+package calendar
+
+import "errors"
+
+var ErrInvalidSession = errors.New("invalid trading session")
@@ -0,0 +1,99 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const AlwaysOpenSpec = "24/7"
+
+var weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+type Session struct {
+	open       time.Duration
+	close      time.Duration
+	location   *time.Location
+	days       [7]bool
+	alwaysOpen bool
+}
+
+func AlwaysOpen() *Session {
+	return &Session{alwaysOpen: true, location: time.UTC}
+}
+
+func NewSession(open, close string, location *time.Location, days ...time.Weekday) (*Session, error) {
+	openOffset, err := parseClock(open)
+	if err != nil {
+		return nil, err
+	}
+	closeOffset, err := parseClock(close)
+	if err != nil {
+		return nil, err
+	}
+	if closeOffset <= openOffset {
+		return nil, fmt.Errorf("%w: close %s must be after open %s", ErrInvalidSession, close, open)
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	if len(days) == 0 {
+		days = weekdays
+	}
+
+	session := &Session{open: openOffset, close: closeOffset, location: location}
+	for _, day := range days {
+		session.days[day] = true
+	}
+	return session, nil
+}
+
+func ParseSession(spec string, location *time.Location) (*Session, error) {
+	if spec == "" || spec == AlwaysOpenSpec {
+		return AlwaysOpen(), nil
+	}
+	open, close, found := strings.Cut(spec, "-")
+	if !found {
+		return nil, fmt.Errorf("%w: %q is not in HH:MM-HH:MM form", ErrInvalidSession, spec)
+	}
+	return NewSession(strings.TrimSpace(open), strings.TrimSpace(close), location)
+}
+
+func parseClock(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not an HH:MM time", ErrInvalidSession, value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+func (s *Session) AlwaysOpen() bool {
+	return s.alwaysOpen
+}
+
+func (s *Session) TradingDay(t time.Time) bool {
+	return s.alwaysOpen || s.days[t.In(s.location).Weekday()]
+}
+
+func (s *Session) IsOpen(t time.Time) bool {
+	_, open := s.Opened(t)
+	return open
+}
+
+func (s *Session) Opened(t time.Time) (time.Time, bool) {
+	if s.alwaysOpen {
+		return time.Time{}, true
+	}
+
+	local := t.In(s.location)
+	if !s.days[local.Weekday()] {
+		return time.Time{}, false
+	}
+
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.location)
+	open := midnight.Add(s.open)
+	if local.Before(open) || !local.Before(midnight.Add(s.close)) {
+		return time.Time{}, false
+	}
+	return open, true
+}
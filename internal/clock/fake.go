@@ -0,0 +1,126 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	fake   *Fake
+	at     time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+func NewFake(start time.Time) *Fake {
+	f := &Fake{now: start}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+	f.add(&fakeWaiter{fake: f, at: f.now.Add(d), ch: ch})
+	return ch
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ticker := &fakeWaiter{fake: f, at: f.now.Add(d), period: d, ch: make(chan time.Time, 1)}
+	f.add(ticker)
+	return ticker
+}
+
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(f.now.Add(d))
+}
+
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(now)
+}
+
+func (f *Fake) set(end time.Time) {
+	for {
+		next := -1
+		for i, waiter := range f.waiters {
+			if !waiter.at.After(end) && (next < 0 || waiter.at.Before(f.waiters[next].at)) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+
+		waiter := f.waiters[next]
+		f.now = waiter.at
+		select {
+		case waiter.ch <- f.now:
+		default:
+		}
+		if waiter.period > 0 {
+			waiter.at = waiter.at.Add(waiter.period)
+		} else {
+			f.waiters = append(f.waiters[:next], f.waiters[next+1:]...)
+		}
+	}
+	f.now = end
+}
+
+func (f *Fake) BlockUntil(waiters int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < waiters {
+		f.cond.Wait()
+	}
+}
+
+func (f *Fake) add(waiter *fakeWaiter) {
+	f.waiters = append(f.waiters, waiter)
+	f.cond.Broadcast()
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.ch
+}
+
+func (w *fakeWaiter) Stop() {
+	f := w.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, waiter := range f.waiters {
+		if waiter == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+
+func TestFake_NewTicker(t *testing.T) {
+	fake := NewFake(start)
+	ticker := fake.NewTicker(time.Second)
+
+	fake.Advance(999 * time.Millisecond)
+	assert.Empty(t, ticker.C())
+
+	fake.Advance(time.Millisecond)
+	require.Len(t, ticker.C(), 1)
+	assert.Equal(t, start.Add(time.Second), <-ticker.C())
+
+	fake.Advance(3 * time.Second)
+	require.Len(t, ticker.C(), 1, "ticks are dropped while the channel is full")
+	assert.Equal(t, start.Add(2*time.Second), <-ticker.C())
+	assert.Equal(t, start.Add(4*time.Second), fake.Now())
+
+	ticker.Stop()
+	fake.Advance(time.Second)
+	assert.Empty(t, ticker.C())
+}
+
+func TestFake_After(t *testing.T) {
+	fake := NewFake(start)
+	late := fake.After(2 * time.Minute)
+	early := fake.After(time.Minute)
+
+	fake.Advance(90 * time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-early)
+	assert.Empty(t, late)
+
+	fake.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(2*time.Minute), <-late)
+	assert.Equal(t, start.Add(2*time.Minute), <-fake.After(0))
+}
+
+func TestFake_BlockUntil(t *testing.T) {
+	fake := NewFake(start)
+	done := make(chan struct{})
+	go func() {
+		fake.BlockUntil(2)
+		close(done)
+	}()
+
+	fake.NewTicker(time.Second)
+	fake.After(time.Second)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("BlockUntil did not return once two waiters were registered")
+	}
+}
+
+func TestNew_Real(t *testing.T) {
+	system := New()
+	before := time.Now()
+
+	assert.False(t, system.Now().Before(before))
+	ticker := system.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	<-ticker.C()
+	<-system.After(time.Millisecond)
+}
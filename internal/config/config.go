@@ -0,0 +1,574 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/alerts"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/1cbyc/trade-algo-go/internal/stress"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5m\": %w", err)
+	}
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+type Config struct {
+	InitialCash  decimal.Decimal               `json:"initial_cash"`
+	Duration     Duration                      `json:"duration"`
+	Benchmark    string                        `json:"benchmark"`
+	BarInterval  Duration                      `json:"bar_interval"`
+	Timeframes   []Duration                    `json:"timeframes"`
+	Symbols      []SymbolConfig                `json:"symbols"`
+	Correlations map[string]map[string]float64 `json:"correlations"`
+	Actions      []CorporateActionConfig       `json:"corporate_actions"`
+	Strategies   []StrategyConfig              `json:"strategies"`
+	Alerts       *AlertsConfig                 `json:"alerts"`
+	Stress       []stress.Scenario             `json:"stress_scenarios"`
+	Exposure     *models.ExposureLimits        `json:"exposure_limits"`
+	Currencies   *CurrencyConfig               `json:"currencies"`
+}
+
+type CurrencyConfig struct {
+	Base        string                     `json:"base"`
+	Rates       map[string]decimal.Decimal `json:"rates"`
+	Cash        map[string]decimal.Decimal `json:"cash"`
+	AutoConvert bool                       `json:"auto_convert"`
+}
+
+type SymbolConfig struct {
+	Symbol     string             `json:"symbol"`
+	BasePrice  decimal.Decimal    `json:"base_price"`
+	Volatility decimal.Decimal    `json:"volatility"`
+	Trend      decimal.Decimal    `json:"trend"`
+	LotSize    decimal.Decimal    `json:"lot_size"`
+	TickSize   decimal.Decimal    `json:"tick_size"`
+	Precision  int32              `json:"price_precision"`
+	Currency   string             `json:"currency"`
+	Session    models.SessionType `json:"session_type"`
+}
+
+type CorporateActionConfig struct {
+	Symbol string                     `json:"symbol"`
+	Type   models.CorporateActionType `json:"type"`
+	Amount decimal.Decimal            `json:"amount"`
+	Ratio  decimal.Decimal            `json:"ratio"`
+	Date   time.Time                  `json:"date"`
+}
+
+type AlertsConfig struct {
+	Webhooks           []WebhookConfig `json:"webhooks"`
+	Events             []alerts.Type   `json:"events"`
+	Cooldown           Duration        `json:"cooldown"`
+	Retries            int             `json:"retries"`
+	RejectionThreshold int             `json:"rejection_threshold"`
+	RejectionWindow    Duration        `json:"rejection_window"`
+	LargeLoss          decimal.Decimal `json:"large_loss"`
+}
+
+type WebhookConfig struct {
+	URL    string        `json:"url"`
+	Format alerts.Format `json:"format"`
+}
+
+type StrategyConfig struct {
+	Type           string          `json:"type"`
+	Allocation     decimal.Decimal `json:"allocation"`
+	ExecuteTimeout Duration        `json:"execute_timeout"`
+	models.StrategyConfig
+}
+
+func Load(path string) (*Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+func Parse(r io.Reader) (*Config, error) {
+	var document interface{}
+	if err := yaml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (c *Config) Validate() error {
+	if !c.InitialCash.IsPositive() {
+		return invalid("initial_cash", "must be positive")
+	}
+	if c.Duration.Duration < 0 {
+		return invalid("duration", "must not be negative")
+	}
+	if c.BarInterval.Duration < 0 {
+		return invalid("bar_interval", "must not be negative")
+	}
+	for i, timeframe := range c.Timeframes {
+		if timeframe.Duration <= 0 {
+			return invalid(fmt.Sprintf("timeframes[%d]", i), "must be positive")
+		}
+	}
+
+	symbols := make(map[string]bool, len(c.Symbols))
+	for i, symbol := range c.Symbols {
+		field := fmt.Sprintf("symbols[%d]", i)
+		switch {
+		case symbol.Symbol == "":
+			return invalid(field+".symbol", "is required")
+		case symbols[symbol.Symbol]:
+			return invalid(field+".symbol", fmt.Sprintf("duplicate symbol %q", symbol.Symbol))
+		case !symbol.BasePrice.IsPositive():
+			return invalid(field+".base_price", "must be positive")
+		case symbol.Volatility.IsNegative():
+			return invalid(field+".volatility", "must not be negative")
+		case symbol.LotSize.IsNegative():
+			return invalid(field+".lot_size", "must not be negative")
+		case symbol.TickSize.IsNegative():
+			return invalid(field+".tick_size", "must not be negative")
+		case symbol.Precision < 0:
+			return invalid(field+".price_precision", "must not be negative")
+		case symbol.Session != "" && symbol.Session != models.SessionTypeRegular && symbol.Session != models.SessionTypeContinuous:
+			return invalid(field+".session_type", fmt.Sprintf("unknown session type %q", symbol.Session))
+		}
+		symbols[symbol.Symbol] = true
+	}
+
+	if err := validateCorrelations(c.Correlations, symbols); err != nil {
+		return err
+	}
+
+	for i, action := range c.Actions {
+		field := fmt.Sprintf("corporate_actions[%d]", i)
+		switch {
+		case !symbols[action.Symbol]:
+			return invalid(field+".symbol", fmt.Sprintf("unknown symbol %q", action.Symbol))
+		case action.Date.IsZero():
+			return invalid(field+".date", "is required")
+		case action.Type == models.CorporateActionDividend && !action.Amount.IsPositive():
+			return invalid(field+".amount", "must be positive")
+		case action.Type == models.CorporateActionSplit && !action.Ratio.IsPositive():
+			return invalid(field+".ratio", "must be positive")
+		case action.Type != models.CorporateActionDividend && action.Type != models.CorporateActionSplit:
+			return invalid(field+".type", fmt.Sprintf("unknown type %q (expected dividend or split)", action.Type))
+		}
+	}
+
+	if c.Alerts != nil {
+		if err := validateAlerts(c.Alerts); err != nil {
+			return err
+		}
+	}
+
+	scenarios := make(map[string]bool, len(c.Stress))
+	for i, scenario := range c.Stress {
+		field := fmt.Sprintf("stress_scenarios[%d]", i)
+		if scenarios[scenario.Name] {
+			return invalid(field+".name", fmt.Sprintf("duplicate scenario %q", scenario.Name))
+		}
+		scenarios[scenario.Name] = true
+		if err := scenario.Validate(); err != nil {
+			return invalid(field, err.Error())
+		}
+	}
+
+	if c.Exposure != nil {
+		if err := validateExposure(c.Exposure, symbols); err != nil {
+			return err
+		}
+	}
+
+	if err := c.validateCurrencies(symbols); err != nil {
+		return err
+	}
+
+	ids := make(map[string]bool, len(c.Strategies))
+	allocated := decimal.Zero
+	for i, strategy := range c.Strategies {
+		field := fmt.Sprintf("strategies[%d]", i)
+		if strategy.ID == "" {
+			return invalid(field+".id", "is required")
+		}
+		if ids[strategy.ID] {
+			return invalid(field+".id", fmt.Sprintf("duplicate strategy id %q", strategy.ID))
+		}
+		ids[strategy.ID] = true
+
+		if _, err := strategies.New(strategy.Type, &models.StrategyConfig{}); err != nil {
+			return invalid(field+".type", err.Error())
+		}
+		if err := validateStrategy(field, &strategy.StrategyConfig); err != nil {
+			return err
+		}
+		if strategy.ExecuteTimeout.Duration < 0 {
+			return invalid(field+".execute_timeout", "must not be negative")
+		}
+		if strategy.Type == strategies.TypeGrid {
+			if err := validateGrid(field+".grid", strategy.Grid); err != nil {
+				return err
+			}
+		}
+
+		if strategy.Allocation.IsNegative() || strategy.Allocation.GreaterThan(decimal.NewFromInt(1)) {
+			return invalid(field+".allocation", "must be between 0 and 1")
+		}
+		allocated = allocated.Add(strategy.Allocation)
+		if allocated.GreaterThan(decimal.NewFromInt(1)) {
+			return invalid(field+".allocation", "brings total allocation above 1")
+		}
+	}
+
+	return nil
+}
+
+func validateCorrelations(correlations map[string]map[string]float64, symbols map[string]bool) error {
+	for _, a := range sortedKeys(correlations) {
+		if !symbols[a] {
+			return invalid("correlations."+a, "is not a configured symbol")
+		}
+		for _, b := range sortedKeys(correlations[a]) {
+			field := "correlations." + a + "." + b
+			if !symbols[b] {
+				return invalid(field, "is not a configured symbol")
+			}
+			if rho := correlations[a][b]; rho < -1 || rho > 1 {
+				return invalid(field, "must be between -1 and 1")
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateCurrencies(symbols map[string]bool) error {
+	currencies := c.Currencies
+	if currencies == nil {
+		currencies = &CurrencyConfig{}
+	}
+	base := currencies.BaseCurrency()
+	for _, currency := range sortedKeys(currencies.Rates) {
+		if !currencies.Rates[currency].IsPositive() {
+			return invalid("currencies.rates."+currency, "must be positive")
+		}
+	}
+	for _, currency := range sortedKeys(currencies.Cash) {
+		switch {
+		case currency == base:
+			return invalid("currencies.cash."+currency, "must be a currency other than the base; use initial_cash")
+		case currencies.Cash[currency].IsNegative():
+			return invalid("currencies.cash."+currency, "must not be negative")
+		}
+	}
+	for i, symbol := range c.Symbols {
+		currency := symbol.Currency
+		if currency == "" || currency == base {
+			continue
+		}
+		if _, rated := currencies.Rates[currency]; !rated && !symbols[currency+base] && !symbols[base+currency] {
+			return invalid(fmt.Sprintf("symbols[%d].currency", i), fmt.Sprintf("%s needs a currencies.rates entry or a %s%s symbol", currency, currency, base))
+		}
+	}
+	return nil
+}
+
+func (c *CurrencyConfig) BaseCurrency() string {
+	if c.Base == "" {
+		return "USD"
+	}
+	return c.Base
+}
+
+func validateAlerts(config *AlertsConfig) error {
+	if len(config.Webhooks) == 0 {
+		return invalid("alerts.webhooks", "must list at least one webhook")
+	}
+	for i, webhook := range config.Webhooks {
+		field := fmt.Sprintf("alerts.webhooks[%d]", i)
+		parsed, err := url.Parse(webhook.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return invalid(field+".url", fmt.Sprintf("must be an http or https URL, got %q", webhook.URL))
+		}
+		if _, err := alerts.NewWebhook(webhook.URL, webhook.Format); err != nil {
+			return invalid(field+".format", fmt.Sprintf("must be json or slack, got %q", webhook.Format))
+		}
+	}
+	for i, event := range config.Events {
+		if !event.Valid() {
+			return invalid(fmt.Sprintf("alerts.events[%d]", i), fmt.Sprintf("unknown alert type %q", event))
+		}
+	}
+
+	switch {
+	case config.Cooldown.Duration < 0:
+		return invalid("alerts.cooldown", "must not be negative")
+	case config.Retries < 0:
+		return invalid("alerts.retries", "must not be negative")
+	case config.RejectionThreshold < 0:
+		return invalid("alerts.rejection_threshold", "must not be negative")
+	case config.RejectionWindow.Duration < 0:
+		return invalid("alerts.rejection_window", "must not be negative")
+	case config.LargeLoss.IsNegative():
+		return invalid("alerts.large_loss", "must not be negative")
+	}
+	return nil
+}
+
+func validateExposure(limits *models.ExposureLimits, symbols map[string]bool) error {
+	switch {
+	case limits.MaxSymbolWeight.IsNegative() || limits.MaxSymbolWeight.GreaterThan(decimal.NewFromInt(1)):
+		return invalid("exposure_limits.max_symbol_weight", "must be between 0 and 1")
+	case limits.MaxPositions < 0:
+		return invalid("exposure_limits.max_positions", "must not be negative")
+	}
+	for _, name := range sortedKeys(limits.Groups) {
+		field := "exposure_limits.groups." + name
+		group := limits.Groups[name]
+		if len(group.Symbols) == 0 {
+			return invalid(field+".symbols", "must list at least one symbol")
+		}
+		for i, symbol := range group.Symbols {
+			if !symbols[symbol] {
+				return invalid(fmt.Sprintf("%s.symbols[%d]", field, i), fmt.Sprintf("unknown symbol %q", symbol))
+			}
+		}
+		if !group.MaxWeight.IsPositive() || group.MaxWeight.GreaterThan(decimal.NewFromInt(1)) {
+			return invalid(field+".max_weight", "must be greater than 0 and at most 1")
+		}
+	}
+	return nil
+}
+
+func (c *AlertsConfig) BuildAlerters() ([]alerts.Alerter, error) {
+	alerters := make([]alerts.Alerter, 0, len(c.Webhooks))
+	for _, webhook := range c.Webhooks {
+		alerter, err := alerts.NewWebhook(webhook.URL, webhook.Format)
+		if err != nil {
+			return nil, err
+		}
+		alerters = append(alerters, alerter)
+	}
+	return alerters, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func validateStrategy(field string, config *models.StrategyConfig) error {
+	nonNegative := []struct {
+		name  string
+		value decimal.Decimal
+	}{
+		{"max_position_size", config.MaxPositionSize},
+		{"min_confidence", config.MinConfidence},
+		{"sizing_fraction", config.SizingFraction},
+		{"kelly_fraction", config.KellyFraction},
+		{"max_portfolio_risk", config.MaxPortfolioRisk},
+		{"max_drawdown", config.MaxDrawdown},
+		{"liquidation_fraction", config.LiquidationFraction},
+		{"stop_loss_percent", config.StopLossPercent},
+		{"take_profit_percent", config.TakeProfitPercent},
+		{"trailing_stop_percent", config.TrailingStopPercent},
+		{"rebalance_threshold", config.RebalanceThreshold},
+		{"min_order_size", config.MinOrderSize},
+		{"max_order_size", config.MaxOrderSize},
+		{"commission_rate", config.CommissionRate},
+		{"slippage_tolerance", config.SlippageTolerance},
+	}
+	for _, check := range nonNegative {
+		if check.value.IsNegative() {
+			return invalid(field+"."+check.name, "must not be negative")
+		}
+	}
+
+	if config.MinOrderSize.GreaterThan(config.MaxOrderSize) {
+		return invalid(field+".min_order_size", "must not exceed max_order_size")
+	}
+	if config.MaxOrdersPerDay < 0 {
+		return invalid(field+".max_orders_per_day", "must not be negative")
+	}
+	if config.MarketDataWindow < 0 {
+		return invalid(field+".market_data_window", "must not be negative")
+	}
+	if config.VaRHoldingPeriod < 0 {
+		return invalid(field+".var_holding_period", "must not be negative")
+	}
+	if _, err := risk.NewVaRModel(config.VaRMethod, config.VaRHoldingPeriod); err != nil {
+		return invalid(field+".var_method", err.Error())
+	}
+	if config.MaxSignalsPerRun < 0 {
+		return invalid(field+".max_signals_per_run", "must not be negative")
+	}
+	if config.MaxFailures < 0 {
+		return invalid(field+".max_failures", "must not be negative")
+	}
+	if config.MinConfidence.GreaterThan(decimal.NewFromInt(1)) {
+		return invalid(field+".min_confidence", "must not exceed 1")
+	}
+	if _, err := strategies.NewPositionSizer(config); err != nil {
+		return invalid(field+".position_sizing", err.Error())
+	}
+	if config.LiquidationFraction.GreaterThan(decimal.NewFromInt(1)) {
+		return invalid(field+".liquidation_fraction", "must not exceed 1")
+	}
+	if config.SizingFraction.GreaterThan(decimal.NewFromInt(1)) {
+		return invalid(field+".sizing_fraction", "must not exceed 1")
+	}
+	if config.KellyFraction.GreaterThan(decimal.NewFromInt(1)) {
+		return invalid(field+".kelly_fraction", "must not exceed 1")
+	}
+	switch config.ConfidenceScaling {
+	case models.ConfidenceScalingNone, models.ConfidenceScalingLinear, models.ConfidenceScalingSquare:
+	default:
+		return invalid(field+".confidence_scaling", fmt.Sprintf("must be linear or square, got %q", config.ConfidenceScaling))
+	}
+	return nil
+}
+
+func validateGrid(field string, grid *models.GridConfig) error {
+	switch {
+	case grid == nil:
+		return invalid(field, "is required for grid strategies")
+	case grid.Symbol == "":
+		return invalid(field+".symbol", "is required")
+	case !grid.Step.IsPositive():
+		return invalid(field+".step", "must be positive")
+	case !grid.LevelQuantity.IsPositive():
+		return invalid(field+".level_quantity", "must be positive")
+	case grid.Lower.IsNegative() || grid.Upper.IsNegative():
+		return invalid(field, "lower and upper must not be negative")
+	case grid.Lower.IsPositive() && grid.Upper.IsPositive() && !grid.Lower.LessThan(grid.Upper):
+		return invalid(field+".lower", "must be below upper")
+	case grid.Lookback < 0:
+		return invalid(field+".lookback", "must not be negative")
+	}
+	return nil
+}
+
+func (c *Config) BuildTimeframes() []time.Duration {
+	timeframes := make([]time.Duration, len(c.Timeframes))
+	for i, timeframe := range c.Timeframes {
+		timeframes[i] = timeframe.Duration
+	}
+	return timeframes
+}
+
+func (c *Config) BuildStrategies() ([]strategies.Strategy, error) {
+	now := time.Now()
+	built := make([]strategies.Strategy, 0, len(c.Strategies))
+	for _, spec := range c.Strategies {
+		strategy, err := spec.build(now)
+		if err != nil {
+			return nil, err
+		}
+		built = append(built, strategy)
+	}
+	return built, nil
+}
+
+func (c *Config) BuildStrategy(id string) (strategies.Strategy, error) {
+	for _, spec := range c.Strategies {
+		if spec.ID == id {
+			return spec.build(time.Now())
+		}
+	}
+	return nil, invalid("strategies", fmt.Sprintf("has no strategy %q", id))
+}
+
+func (s StrategyConfig) model() models.StrategyConfig {
+	config := s.StrategyConfig
+	config.ExecuteTimeout = s.ExecuteTimeout.Duration
+	return config
+}
+
+func (s StrategyConfig) build(now time.Time) (strategies.Strategy, error) {
+	config := s.model()
+	config.CreatedAt = now
+	config.UpdatedAt = now
+	return strategies.New(s.Type, &config)
+}
+
+func (c *Config) BuildSymbols() (*symbols.Registry, error) {
+	registry := symbols.NewRegistry()
+	for _, symbol := range c.Symbols {
+		if err := registry.Register(symbol.Instrument()); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+func (s SymbolConfig) Instrument() models.Instrument {
+	instrument := symbols.Default(s.Symbol)
+	if s.LotSize.IsPositive() {
+		instrument.LotSize = s.LotSize
+	}
+	instrument.TickSize = s.TickSize
+	instrument.PricePrecision = s.Precision
+	if s.Currency != "" {
+		instrument.Currency = s.Currency
+	}
+	if s.Session != "" {
+		instrument.SessionType = s.Session
+	}
+	return instrument
+}
+
+func invalid(field, message string) error {
+	return fmt.Errorf("%w: %s %s", ErrInvalidConfig, field, message)
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/alerts"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Example(t *testing.T) {
+	config, err := Load("../../examples/config.yaml")
+
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(100000).Equal(config.InitialCash))
+	assert.Equal(t, 5*time.Minute, config.Duration.Duration)
+	assert.Equal(t, "SPY", config.Benchmark)
+	require.Len(t, config.Symbols, 5)
+	assert.Equal(t, "TSLA", config.Symbols[3].Symbol)
+	assert.True(t, decimal.NewFromFloat(0.5).Equal(config.Symbols[3].Trend))
+	registry, err := config.BuildSymbols()
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromFloat(0.01).Equal(registry.TickSize("AAPL")))
+	assert.Equal(t, int32(2), registry.Instrument("AAPL").PricePrecision)
+	assert.True(t, decimal.NewFromInt(1).Equal(registry.LotSize("GOOGL")))
+	assert.Equal(t, 0.8, config.Correlations["AAPL"]["MSFT"])
+	require.Len(t, config.Actions, 2)
+	assert.Equal(t, models.CorporateActionDividend, config.Actions[0].Type)
+	assert.True(t, decimal.NewFromFloat(0.24).Equal(config.Actions[0].Amount))
+	assert.Equal(t, time.Date(2030, 2, 9, 0, 0, 0, 0, time.UTC), config.Actions[0].Date)
+	assert.True(t, decimal.NewFromInt(4).Equal(config.Actions[1].Ratio))
+	require.Len(t, config.Stress, 3)
+	assert.Equal(t, "tech_selloff", config.Stress[1].Name)
+	assert.Equal(t, []string{"AAPL", "GOOGL", "MSFT"}, config.Stress[1].Shocks[0].Symbols)
+	assert.True(t, decimal.NewFromFloat(-0.3).Equal(config.Stress[1].Shocks[0].PriceChange))
+	assert.True(t, decimal.NewFromInt(3).Equal(config.Stress[1].Shocks[0].VolatilityMultiplier))
+	require.NotNil(t, config.Exposure)
+	assert.True(t, decimal.NewFromFloat(0.25).Equal(config.Exposure.MaxSymbolWeight))
+	assert.Equal(t, 4, config.Exposure.MaxPositions)
+	assert.Equal(t, []string{"AAPL", "GOOGL", "MSFT"}, config.Exposure.Groups["tech"].Symbols)
+	assert.True(t, decimal.NewFromFloat(0.5).Equal(config.Exposure.Groups["tech"].MaxWeight))
+
+	require.Len(t, config.Strategies, 2)
+	ma := config.Strategies[0]
+	assert.Equal(t, strategies.TypeMovingAverage, ma.Type)
+	assert.Equal(t, "ma_crossover_001", ma.ID)
+	assert.True(t, decimal.NewFromFloat(0.03).Equal(ma.TrailingStopPercent))
+	assert.Equal(t, 50, ma.MaxOrdersPerDay)
+	assert.Equal(t, []string{"SMA", "EMA", "RSI"}, ma.TechnicalIndicators)
+	assert.True(t, ma.Enabled)
+	assert.True(t, decimal.NewFromFloat(0.6).Equal(ma.Allocation))
+	assert.False(t, config.Strategies[1].Enabled)
+
+	built, err := config.BuildStrategies()
+	require.NoError(t, err)
+	require.Len(t, built, 2)
+	assert.IsType(t, &strategies.MovingAverageStrategy{}, built[0])
+	assert.IsType(t, &strategies.RSIStrategy{}, built[1])
+	assert.Equal(t, "rsi_001", built[1].ID())
+
+	strategy, err := config.BuildStrategy("rsi_001")
+	require.NoError(t, err)
+	assert.IsType(t, &strategies.RSIStrategy{}, strategy)
+	assert.NotSame(t, built[1].GetConfig(), strategy.GetConfig())
+
+	_, err = config.BuildStrategy("missing")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestParse_JSON(t *testing.T) {
+	config, err := Parse(strings.NewReader(`{"initial_cash": "2500.50", "duration": "90s", "timeframes": ["1m", "5m"], "strategies": [{"type": "macd", "id": "m1", "enabled": true, "execute_timeout": "500ms", "max_failures": 5}]}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, "2500.5", config.InitialCash.String())
+	assert.Equal(t, 90*time.Second, config.Duration.Duration)
+	assert.Equal(t, []time.Duration{time.Minute, 5 * time.Minute}, config.BuildTimeframes())
+	assert.Equal(t, strategies.TypeMACD, config.Strategies[0].Type)
+
+	built, err := config.BuildStrategies()
+	require.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, built[0].GetConfig().ExecuteTimeout)
+	assert.Equal(t, 5, built[0].GetConfig().MaxFailures)
+}
+
+func TestParse_Currencies(t *testing.T) {
+	config, err := Parse(strings.NewReader(`initial_cash: 1000
+symbols:
+  - {symbol: SAP, base_price: 120, currency: EUR}
+  - {symbol: EURUSD, base_price: 1.1}
+  - {symbol: VOD, base_price: 0.7, currency: GBP}
+currencies:
+  rates: {GBP: 1.25}
+  cash: {EUR: 500}
+  auto_convert: true
+`))
+
+	require.NoError(t, err)
+	require.NotNil(t, config.Currencies)
+	assert.Equal(t, "USD", config.Currencies.BaseCurrency())
+	assert.True(t, decimal.NewFromFloat(1.25).Equal(config.Currencies.Rates["GBP"]))
+	assert.True(t, decimal.NewFromInt(500).Equal(config.Currencies.Cash["EUR"]))
+	assert.True(t, config.Currencies.AutoConvert)
+	registry, err := config.BuildSymbols()
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", registry.Instrument("SAP").Currency)
+}
+
+func TestParse_Alerts(t *testing.T) {
+	config, err := Parse(strings.NewReader(`initial_cash: 1000
+alerts:
+  webhooks:
+    - {url: "https://hooks.example.com/trading"}
+    - {url: "https://hooks.slack.com/services/T0/B0/x", format: slack}
+  events: [drawdown_liquidation, large_loss]
+  cooldown: 10m
+  rejection_threshold: 5
+  large_loss: 2500
+`))
+
+	require.NoError(t, err)
+	require.NotNil(t, config.Alerts)
+	assert.Equal(t, []alerts.Type{alerts.TypeDrawdownLiquidation, alerts.TypeLargeLoss}, config.Alerts.Events)
+	assert.Equal(t, 10*time.Minute, config.Alerts.Cooldown.Duration)
+	assert.True(t, decimal.NewFromInt(2500).Equal(config.Alerts.LargeLoss))
+
+	alerters, err := config.Alerts.BuildAlerters()
+	require.NoError(t, err)
+	assert.Len(t, alerters, 2)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	const valid = "initial_cash: 1000\n"
+
+	tests := []struct {
+		name     string
+		contents string
+		field    string
+	}{
+		{"negative cash", "initial_cash: -5\n", "initial_cash"},
+		{"bad duration", valid + "duration: soon\n", "duration"},
+		{"zero timeframe", valid + "timeframes: [1m, 0s]\n", "timeframes[1]"},
+		{"unknown field", valid + "initial_cahs: 5\n", "initial_cahs"},
+		{"unknown strategy type", valid + "strategies:\n  - {type: martingale, id: s1}\n", "strategies[0].type"},
+		{"missing strategy id", valid + "strategies:\n  - {type: rsi}\n", "strategies[0].id"},
+		{"duplicate strategy id", valid + "strategies:\n  - {type: rsi, id: s1}\n  - {type: macd, id: s1}\n", "strategies[1].id"},
+		{"min above max order size", valid + "strategies:\n  - {type: rsi, id: s1, min_order_size: 500, max_order_size: 100}\n", "strategies[0].min_order_size"},
+		{"negative commission", valid + "strategies:\n  - {type: rsi, id: s1, commission_rate: -0.1}\n", "strategies[0].commission_rate"},
+		{"duplicate symbol", valid + "symbols:\n  - {symbol: AAPL, base_price: 1}\n  - {symbol: AAPL, base_price: 2}\n", "symbols[1].symbol"},
+		{"negative lot size", valid + "symbols:\n  - {symbol: BTCUSDT, base_price: 1, lot_size: -0.001}\n", "symbols[0].lot_size"},
+		{"negative tick size", valid + "symbols:\n  - {symbol: ES, base_price: 1, tick_size: -0.25}\n", "symbols[0].tick_size"},
+		{"unknown session type", valid + "symbols:\n  - {symbol: ES, base_price: 1, session_type: overnight}\n", "symbols[0].session_type"},
+		{"correlation with unknown symbol", valid + "symbols:\n  - {symbol: AAPL, base_price: 1}\ncorrelations:\n  AAPL: {MSFT: 0.5}\n", "correlations.AAPL.MSFT"},
+		{"correlation out of range", valid + "symbols:\n  - {symbol: AAPL, base_price: 1}\n  - {symbol: MSFT, base_price: 1}\ncorrelations:\n  AAPL: {MSFT: 1.5}\n", "correlations.AAPL.MSFT"},
+		{"corporate action for unknown symbol", valid + "corporate_actions:\n  - {symbol: AAPL, type: split, ratio: 2, date: 2024-06-10}\n", "corporate_actions[0].symbol"},
+		{"split without ratio", valid + "symbols:\n  - {symbol: AAPL, base_price: 1}\ncorporate_actions:\n  - {symbol: AAPL, type: split, date: 2024-06-10}\n", "corporate_actions[0].ratio"},
+		{"unknown corporate action", valid + "symbols:\n  - {symbol: AAPL, base_price: 1}\ncorporate_actions:\n  - {symbol: AAPL, type: spinoff, date: 2024-06-10}\n", "corporate_actions[0].type"},
+		{"alerts without webhooks", valid + "alerts:\n  events: [margin_call]\n", "alerts.webhooks"},
+		{"alert webhook without scheme", valid + "alerts:\n  webhooks:\n    - {url: hooks.example.com}\n", "alerts.webhooks[0].url"},
+		{"unknown alert format", valid + "alerts:\n  webhooks:\n    - {url: https://hooks.example.com, format: teams}\n", "alerts.webhooks[0].format"},
+		{"unknown alert event", valid + "alerts:\n  webhooks:\n    - {url: https://hooks.example.com}\n  events: [earthquake]\n", "alerts.events[0]"},
+		{"allocation above one", valid + "strategies:\n  - {type: rsi, id: s1, allocation: 1.5}\n", "strategies[0].allocation"},
+		{"allocations above one", valid + "strategies:\n  - {type: rsi, id: s1, allocation: 0.7}\n  - {type: macd, id: s2, allocation: 0.4}\n", "strategies[1].allocation"},
+		{"min confidence above one", valid + "strategies:\n  - {type: rsi, id: s1, min_confidence: 1.2}\n", "strategies[0].min_confidence"},
+		{"unknown confidence scaling", valid + "strategies:\n  - {type: rsi, id: s1, confidence_scaling: cubic}\n", "strategies[0].confidence_scaling"},
+		{"unknown position sizer", valid + "strategies:\n  - {type: rsi, id: s1, position_sizing: martingale}\n", "strategies[0].position_sizing"},
+		{"kelly fraction above one", valid + "strategies:\n  - {type: rsi, id: s1, position_sizing: kelly, kelly_fraction: 2}\n", "strategies[0].kelly_fraction"},
+		{"grid without grid settings", valid + "strategies:\n  - {type: grid, id: g1}\n", "strategies[0].grid"},
+		{"grid without step", valid + "strategies:\n  - {type: grid, id: g1, grid: {symbol: AAPL, level_quantity: 10}}\n", "strategies[0].grid.step"},
+		{"grid with inverted range", valid + "strategies:\n  - {type: grid, id: g1, grid: {symbol: AAPL, lower: 110, upper: 90, step: 2, level_quantity: 10}}\n", "strategies[0].grid.lower"},
+		{"unknown var method", valid + "strategies:\n  - {type: rsi, id: s1, var_method: cornish_fisher}\n", "strategies[0].var_method"},
+		{"negative execute timeout", valid + "strategies:\n  - {type: rsi, id: s1, execute_timeout: -1s}\n", "strategies[0].execute_timeout"},
+		{"negative max failures", valid + "strategies:\n  - {type: rsi, id: s1, max_failures: -1}\n", "strategies[0].max_failures"},
+		{"negative var holding period", valid + "strategies:\n  - {type: rsi, id: s1, var_holding_period: -1}\n", "strategies[0].var_holding_period"},
+		{"stress scenario without shocks", valid + "stress_scenarios:\n  - {name: crash}\n", "stress_scenarios[0]"},
+		{"stress shock wipes out price", valid + "stress_scenarios:\n  - {name: crash, shocks: [{price_change: -1}]}\n", "stress_scenarios[0]"},
+		{"duplicate stress scenario", valid + "stress_scenarios:\n  - {name: crash, shocks: [{price_change: -0.2}]}\n  - {name: crash, shocks: [{price_change: -0.3}]}\n", "stress_scenarios[1].name"},
+		{"symbol weight above one", valid + "exposure_limits: {max_symbol_weight: 1.5}\n", "exposure_limits.max_symbol_weight"},
+		{"negative max positions", valid + "exposure_limits: {max_positions: -1}\n", "exposure_limits.max_positions"},
+		{"exposure group with unknown symbol", valid + "exposure_limits:\n  groups:\n    tech: {symbols: [AAPL], max_weight: 0.4}\n", "exposure_limits.groups.tech.symbols[0]"},
+		{"exposure group without weight", valid + "symbols:\n  - {symbol: AAPL, base_price: 1}\nexposure_limits:\n  groups:\n    tech: {symbols: [AAPL]}\n", "exposure_limits.groups.tech.max_weight"},
+		{"non-positive fx rate", valid + "currencies: {rates: {EUR: 0}}\n", "currencies.rates.EUR"},
+		{"cash in base currency", valid + "currencies: {base: EUR, cash: {EUR: 100}}\n", "currencies.cash.EUR"},
+		{"negative foreign cash", valid + "currencies: {cash: {EUR: -100}}\n", "currencies.cash.EUR"},
+		{"foreign symbol without rate", valid + "symbols:\n  - {symbol: SAP, base_price: 1, currency: EUR}\n", "symbols[0].currency"},
+		{"zero base price", valid + "symbols:\n  - {symbol: AAPL, base_price: 0}\n", "symbols[0].base_price"},
+		{"malformed", "initial_cash: [1000\n", "yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.contents))
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+			assert.Contains(t, err.Error(), tt.field)
+		})
+	}
+}
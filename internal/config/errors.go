@@ -0,0 +1,5 @@
+package config
+
+import "errors"
+
+var ErrInvalidConfig = errors.New("invalid config")
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type StrategyEngine interface {
+	AddStrategy(strategy strategies.Strategy)
+	AddStrategyWithAllocation(strategy strategies.Strategy, weight decimal.Decimal) error
+	RemoveStrategy(strategyID string)
+	UpdateStrategyConfig(strategyID string, config *models.StrategyConfig) error
+}
+
+type Watcher struct {
+	path    string
+	engine  StrategyEngine
+	logger  *zap.Logger
+	current *Config
+	modTime time.Time
+	size    int64
+}
+
+func NewWatcher(path string, current *Config, engine StrategyEngine, logger *zap.Logger) *Watcher {
+	watcher := &Watcher{path: path, engine: engine, logger: logger, current: current}
+	if info, err := os.Stat(path); err == nil {
+		watcher.modTime, watcher.size = info.ModTime(), info.Size()
+	}
+	return watcher
+}
+
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !w.changed() {
+				continue
+			}
+			if err := w.Reload(); err != nil {
+				w.logger.Error("Config reload rejected, keeping the previous strategies", zap.String("config", w.path), zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *Watcher) changed() bool {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		w.logger.Warn("Failed to stat config", zap.String("config", w.path), zap.Error(err))
+		return false
+	}
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false
+	}
+	w.modTime, w.size = info.ModTime(), info.Size()
+	return true
+}
+
+func (w *Watcher) Reload() error {
+	next, err := Load(w.path)
+	if err != nil {
+		return err
+	}
+
+	previous := make(map[string]StrategyConfig, len(w.current.Strategies))
+	for _, spec := range w.current.Strategies {
+		previous[spec.ID] = spec
+	}
+
+	now := time.Now()
+	var added []StrategyConfig
+	var updated []StrategyConfig
+	replaced := make(map[string]StrategyConfig)
+	built := make(map[string]strategies.Strategy)
+	for _, spec := range next.Strategies {
+		old, exists := previous[spec.ID]
+		delete(previous, spec.ID)
+		switch {
+		case !exists:
+			added = append(added, spec)
+		case !old.Allocation.Equal(spec.Allocation):
+			return invalid("strategies."+spec.ID+".allocation", "cannot change while running")
+		case old.Type != spec.Type:
+			return invalid("strategies."+spec.ID+".type", "cannot change while running")
+		case !reflect.DeepEqual(old.model(), spec.model()):
+			updated = append(updated, spec)
+			replaced[spec.ID] = old
+			continue
+		default:
+			continue
+		}
+
+		strategy, err := spec.build(now)
+		if err != nil {
+			return fmt.Errorf("strategies.%s: %w", spec.ID, err)
+		}
+		built[spec.ID] = strategy
+	}
+
+	for i, spec := range added {
+		strategy := built[spec.ID]
+		if spec.Allocation.IsPositive() {
+			if err := w.engine.AddStrategyWithAllocation(strategy, spec.Allocation); err != nil {
+				for _, undo := range added[:i] {
+					w.engine.RemoveStrategy(undo.ID)
+				}
+				return fmt.Errorf("strategies.%s: %w", spec.ID, err)
+			}
+		} else {
+			w.engine.AddStrategy(strategy)
+		}
+		w.logger.Info("Strategy added from config", zap.String("strategy_id", spec.ID), zap.String("name", strategy.Name()))
+	}
+	kept := make(map[string]StrategyConfig)
+	for _, spec := range updated {
+		config := spec.model()
+		if err := w.engine.UpdateStrategyConfig(spec.ID, &config); err != nil {
+			kept[spec.ID] = replaced[spec.ID]
+			w.logger.Warn("Strategy config not updated, keeping the previous settings", zap.String("strategy_id", spec.ID), zap.Error(err))
+		}
+	}
+	for id := range previous {
+		w.engine.RemoveStrategy(id)
+		w.logger.Info("Strategy removed from config, keeping its positions", zap.String("strategy_id", id))
+	}
+
+	for i, spec := range next.Strategies {
+		if old, failed := kept[spec.ID]; failed {
+			next.Strategies[i] = old
+		}
+	}
+	w.current = next
+	w.logger.Info("Config reloaded",
+		zap.String("config", w.path),
+		zap.Int("added", len(added)),
+		zap.Int("updated", len(updated)-len(kept)),
+		zap.Int("removed", len(previous)),
+	)
+	return nil
+}
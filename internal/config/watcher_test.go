@@ -0,0 +1,137 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const watchedConfig = `initial_cash: 100000
+strategies:
+  - {type: moving_average, id: ma, enabled: true, max_order_size: 10000}
+  - {type: rsi, id: rsi, enabled: true}
+`
+
+func createWatchedEngine(t *testing.T) (*Watcher, *engine.TradingEngine, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(watchedConfig), 0o644))
+
+	config, err := Load(path)
+	require.NoError(t, err)
+	built, err := config.BuildStrategies()
+	require.NoError(t, err)
+
+	tradingEngine := engine.NewTradingEngine(config.InitialCash, zap.NewNop())
+	for _, strategy := range built {
+		tradingEngine.AddStrategy(strategy)
+	}
+	return NewWatcher(path, config, tradingEngine, zap.NewNop()), tradingEngine, path
+}
+
+func strategyConfigs(tradingEngine *engine.TradingEngine) map[string]*models.StrategyConfig {
+	configs := make(map[string]*models.StrategyConfig)
+	for _, config := range tradingEngine.GetStrategyConfigs() {
+		configs[config.ID] = config
+	}
+	return configs
+}
+
+func TestWatcher_Reload_UpdatesAddsAndRemovesStrategies(t *testing.T) {
+	watcher, tradingEngine, path := createWatchedEngine(t)
+	created := strategyConfigs(tradingEngine)["ma"].CreatedAt
+
+	require.NoError(t, os.WriteFile(path, []byte(`initial_cash: 100000
+strategies:
+  - {type: moving_average, id: ma, enabled: false, max_order_size: 2500}
+  - {type: macd, id: macd, enabled: true}
+`), 0o644))
+	require.NoError(t, watcher.Reload())
+
+	configs := strategyConfigs(tradingEngine)
+	require.Len(t, configs, 2)
+	assert.False(t, configs["ma"].Enabled)
+	assert.True(t, decimal.NewFromInt(2500).Equal(configs["ma"].MaxOrderSize))
+	assert.Equal(t, created, configs["ma"].CreatedAt)
+	assert.True(t, configs["macd"].Enabled)
+	assert.NotContains(t, configs, "rsi")
+}
+
+func TestWatcher_Reload_RejectsInvalidEdits(t *testing.T) {
+	watcher, tradingEngine, path := createWatchedEngine(t)
+
+	tests := []string{
+		"initial_cash: 100000\nstrategies:\n  - {type: moving_average, id: ma, min_order_size: 500, max_order_size: 100}\n",
+		"initial_cash: 100000\nstrategies:\n  - {type: rsi, id: ma, enabled: true, max_order_size: 10000}\n  - {type: rsi, id: rsi, enabled: true}\n",
+		"initial_cash: 100000\nstrategies:\n  - {type: moving_average, id: ma, enabled: true, max_order_size: 10000, allocation: 0.5}\n  - {type: rsi, id: rsi, enabled: true}\n",
+		"initial_cash: [",
+	}
+	for _, contents := range tests {
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+		assert.ErrorIs(t, watcher.Reload(), ErrInvalidConfig, contents)
+	}
+
+	configs := strategyConfigs(tradingEngine)
+	require.Len(t, configs, 2)
+	assert.True(t, configs["ma"].Enabled)
+	assert.True(t, decimal.NewFromInt(10000).Equal(configs["ma"].MaxOrderSize))
+
+	require.NoError(t, os.WriteFile(path, []byte(watchedConfig), 0o644))
+	require.NoError(t, watcher.Reload())
+	assert.Len(t, strategyConfigs(tradingEngine), 2)
+}
+
+type rejectingEngine struct {
+	StrategyEngine
+	attempts int
+}
+
+func (e *rejectingEngine) UpdateStrategyConfig(strategyID string, config *models.StrategyConfig) error {
+	e.attempts++
+	return errors.New("update rejected")
+}
+
+func TestWatcher_Reload_KeepsPreviousSpecWhenUpdateFails(t *testing.T) {
+	watcher, tradingEngine, path := createWatchedEngine(t)
+	rejecting := &rejectingEngine{StrategyEngine: tradingEngine}
+	watcher.engine = rejecting
+
+	edited := "initial_cash: 100000\nstrategies:\n  - {type: moving_average, id: ma, enabled: true, max_order_size: 2500}\n  - {type: rsi, id: rsi, enabled: true}\n"
+	require.NoError(t, os.WriteFile(path, []byte(edited), 0o644))
+	require.NoError(t, watcher.Reload())
+
+	require.Len(t, watcher.current.Strategies, 2)
+	assert.Equal(t, "ma", watcher.current.Strategies[0].ID)
+	assert.True(t, decimal.NewFromInt(10000).Equal(watcher.current.Strategies[0].MaxOrderSize))
+	assert.True(t, decimal.NewFromInt(10000).Equal(strategyConfigs(tradingEngine)["ma"].MaxOrderSize))
+
+	watcher.engine = tradingEngine
+	require.NoError(t, watcher.Reload())
+	assert.Equal(t, 1, rejecting.attempts)
+	assert.True(t, decimal.NewFromInt(2500).Equal(watcher.current.Strategies[0].MaxOrderSize))
+	assert.True(t, decimal.NewFromInt(2500).Equal(strategyConfigs(tradingEngine)["ma"].MaxOrderSize))
+}
+
+func TestWatcher_Run_AppliesChangedFile(t *testing.T) {
+	watcher, tradingEngine, path := createWatchedEngine(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte("initial_cash: 100000\nstrategies:\n  - {type: moving_average, id: ma, enabled: true, max_order_size: 10000}\n"), 0o644))
+
+	assert.Eventually(t, func() bool {
+		_, exists := strategyConfigs(tradingEngine)["rsi"]
+		return !exists
+	}, time.Second, 10*time.Millisecond)
+}
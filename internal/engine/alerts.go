@@ -0,0 +1,148 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/alerts"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const defaultRejectionWindow = time.Minute
+
+type AlertPublisher interface {
+	Publish(alert alerts.Alert) bool
+}
+
+type AlertConfig struct {
+	Types              []alerts.Type
+	RejectionThreshold int
+	RejectionWindow    time.Duration
+	LargeLoss          decimal.Decimal
+}
+
+type alerting struct {
+	publisher  AlertPublisher
+	config     AlertConfig
+	types      map[alerts.Type]bool
+	rejections []time.Time
+}
+
+var riskEventMessages = map[models.RiskEventType]string{
+	models.RiskEventDrawdownLiquidation: "Position drawdown exceeded, liquidating",
+	models.RiskEventStrategyDisabled:    "Strategy disabled after exceeding its max drawdown",
+	models.RiskEventMarginCall:          "Margin call, liquidating position",
+	models.RiskEventPortfolioDrawdown:   "Portfolio drawdown exceeded, strategies disabled",
+	models.RiskEventCircuitBreaker:      "Circuit breaker tripped, trading halted",
+	models.RiskEventTradingResumed:      "Trading resumed after circuit breaker",
+}
+
+func (e *TradingEngine) SetAlerts(publisher AlertPublisher, config AlertConfig) error {
+	types := make(map[alerts.Type]bool, len(config.Types))
+	for _, alertType := range config.Types {
+		if !alertType.Valid() {
+			return fmt.Errorf("%w: unknown alert type %q", ErrInvalidAlerts, alertType)
+		}
+		types[alertType] = true
+	}
+	if config.RejectionThreshold < 0 || config.RejectionWindow < 0 || config.LargeLoss.IsNegative() {
+		return fmt.Errorf("%w: rejection threshold, rejection window and large loss must not be negative", ErrInvalidAlerts)
+	}
+	if config.RejectionWindow == 0 {
+		config.RejectionWindow = defaultRejectionWindow
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if publisher == nil {
+		e.alerting = nil
+		return nil
+	}
+	e.alerting = &alerting{publisher: publisher, config: config, types: types}
+	return nil
+}
+
+func (e *TradingEngine) alert(alert alerts.Alert) {
+	if e.alerting == nil {
+		return
+	}
+	if len(e.alerting.types) > 0 && !e.alerting.types[alert.Type] {
+		return
+	}
+	alert.Timestamp = e.now()
+	e.alerting.publisher.Publish(alert)
+}
+
+func (e *TradingEngine) alertRiskEvent(event models.RiskEvent) {
+	values := map[string]string{
+		"drawdown":  event.Drawdown.String(),
+		"threshold": event.Threshold.String(),
+	}
+	if !event.Quantity.IsZero() {
+		values["quantity"] = event.Quantity.String()
+	}
+	if event.OrderID != "" {
+		values["order_id"] = event.OrderID
+	}
+	e.alert(alerts.Alert{
+		Type:       alerts.Type(event.Type),
+		Symbol:     event.Symbol,
+		StrategyID: event.StrategyID,
+		Message:    riskEventMessages[event.Type],
+		Values:     values,
+	})
+}
+
+func (e *TradingEngine) alertRejection(order *models.Order) {
+	if e.alerting == nil || e.alerting.config.RejectionThreshold == 0 {
+		return
+	}
+
+	now := e.now()
+	cutoff := now.Add(-e.alerting.config.RejectionWindow)
+	rejections := append(e.alerting.rejections, now)
+	for len(rejections) > 0 && !rejections[0].After(cutoff) {
+		rejections = rejections[1:]
+	}
+	e.alerting.rejections = rejections
+	if len(rejections) < e.alerting.config.RejectionThreshold {
+		return
+	}
+
+	e.alert(alerts.Alert{
+		Type:    alerts.TypeOrderRejections,
+		Message: "Order rejections above threshold",
+		Values: map[string]string{
+			"rejections":  strconv.Itoa(len(rejections)),
+			"threshold":   strconv.Itoa(e.alerting.config.RejectionThreshold),
+			"window":      e.alerting.config.RejectionWindow.String(),
+			"last_order":  order.ID,
+			"last_symbol": order.Symbol,
+		},
+	})
+}
+
+func (e *TradingEngine) alertLargeLoss(trade *models.Trade) {
+	if e.alerting == nil || !e.alerting.config.LargeLoss.IsPositive() {
+		return
+	}
+	if trade.RealizedPnL.Neg().LessThan(e.alerting.config.LargeLoss) {
+		return
+	}
+
+	e.alert(alerts.Alert{
+		Type:       alerts.TypeLargeLoss,
+		Symbol:     trade.Symbol,
+		StrategyID: trade.StrategyID,
+		Message:    "Large single-trade loss",
+		Values: map[string]string{
+			"realized_pnl": trade.RealizedPnL.String(),
+			"threshold":    e.alerting.config.LargeLoss.String(),
+			"quantity":     trade.Quantity.String(),
+			"price":        trade.Price.String(),
+			"trade_id":     trade.ID,
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/alerts"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	mu     sync.Mutex
+	alerts []alerts.Alert
+}
+
+func (p *recordingPublisher) Publish(alert alerts.Alert) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.alerts = append(p.alerts, alert)
+	return true
+}
+
+func (p *recordingPublisher) published() []alerts.Alert {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]alerts.Alert(nil), p.alerts...)
+}
+
+func TestTradingEngine_SetAlerts_Validates(t *testing.T) {
+	engine := createTestEngine()
+	publisher := &recordingPublisher{}
+
+	assert.ErrorIs(t, engine.SetAlerts(publisher, AlertConfig{Types: []alerts.Type{"pager"}}), ErrInvalidAlerts)
+	assert.ErrorIs(t, engine.SetAlerts(publisher, AlertConfig{RejectionThreshold: -1}), ErrInvalidAlerts)
+	assert.ErrorIs(t, engine.SetAlerts(publisher, AlertConfig{LargeLoss: decimal.NewFromInt(-1)}), ErrInvalidAlerts)
+
+	require.NoError(t, engine.SetAlerts(publisher, AlertConfig{RejectionThreshold: 3}))
+	assert.Equal(t, defaultRejectionWindow, engine.alerting.config.RejectionWindow)
+}
+
+func TestTradingEngine_ManageRisk_AlertsOnDrawdownLiquidation(t *testing.T) {
+	engine, _ := createDrawdownEngine(0.1)
+	publisher := &recordingPublisher{}
+	require.NoError(t, engine.SetAlerts(publisher, AlertConfig{}))
+
+	markPrice(engine, 120.0)
+	markPrice(engine, 107.0)
+	engine.manageRisk()
+
+	published := publisher.published()
+	require.Len(t, published, 1)
+	alert := published[0]
+	assert.Equal(t, alerts.TypeDrawdownLiquidation, alert.Type)
+	assert.Equal(t, "AAPL", alert.Symbol)
+	assert.Equal(t, "test_strategy", alert.StrategyID)
+	assert.Equal(t, "0.1", alert.Values["threshold"])
+	assert.Equal(t, "100", alert.Values["quantity"])
+	assert.Equal(t, engine.GetRiskEvents()[0].OrderID, alert.Values["order_id"])
+	assert.False(t, alert.Timestamp.IsZero())
+}
+
+func TestTradingEngine_SetAlerts_FiltersTypes(t *testing.T) {
+	engine, _ := createDrawdownEngine(0.1)
+	publisher := &recordingPublisher{}
+	require.NoError(t, engine.SetAlerts(publisher, AlertConfig{Types: []alerts.Type{alerts.TypeMarginCall}}))
+
+	markPrice(engine, 85.0)
+	engine.manageRisk()
+
+	assert.Len(t, engine.GetRiskEvents(), 1)
+	assert.Empty(t, publisher.published())
+}
+
+func TestTradingEngine_ProcessOrder_AlertsOnRejectionRate(t *testing.T) {
+	fake := clock.NewFake(clockStart)
+	engine := createTestEngine(WithClock(fake))
+	publisher := &recordingPublisher{}
+	require.NoError(t, engine.SetAlerts(publisher, AlertConfig{RejectionThreshold: 3, RejectionWindow: time.Minute}))
+
+	reject := func() {
+		order := createTestOrder(models.OrderSideSell, 10, 150.0)
+		engine.openOrders[order.ID] = order
+		engine.processOrder(order)
+		require.Equal(t, models.OrderStatusRejected, order.Status)
+	}
+
+	reject()
+	fake.Advance(40 * time.Second)
+	reject()
+	fake.Advance(40 * time.Second)
+	reject()
+	assert.Empty(t, publisher.published(), "the first rejection fell out of the window")
+
+	fake.Advance(time.Second)
+	reject()
+	published := publisher.published()
+	require.Len(t, published, 1)
+	assert.Equal(t, alerts.TypeOrderRejections, published[0].Type)
+	assert.Equal(t, "3", published[0].Values["rejections"])
+	assert.Equal(t, "1m0s", published[0].Values["window"])
+}
+
+func TestTradingEngine_ProcessTrade_AlertsOnLargeLoss(t *testing.T) {
+	engine, config := createDrawdownEngine(0)
+	publisher := &recordingPublisher{}
+	require.NoError(t, engine.SetAlerts(publisher, AlertConfig{LargeLoss: decimal.NewFromInt(1000)}))
+
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 50, 95.0), config)
+	engine.processTrade(<-engine.tradeQueue)
+	assert.Empty(t, publisher.published())
+
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 50, 70.0), config)
+	trade := <-engine.tradeQueue
+	engine.processTrade(trade)
+
+	published := publisher.published()
+	require.Len(t, published, 1)
+	assert.Equal(t, alerts.TypeLargeLoss, published[0].Type)
+	assert.Equal(t, trade.ID, published[0].Values["trade_id"])
+	assert.Equal(t, "-1500", published[0].Values["realized_pnl"])
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type allocation struct {
+	weight    decimal.Decimal
+	portfolio *models.Portfolio
+}
+
+func (e *TradingEngine) AddStrategyWithAllocation(strategy strategies.Strategy, weight decimal.Decimal) error {
+	if !weight.IsPositive() || weight.GreaterThan(decimal.NewFromInt(1)) {
+		return fmt.Errorf("%w: %s", ErrInvalidAllocation, weight)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	total := weight
+	for strategyID, existing := range e.allocations {
+		if strategyID != strategy.ID() {
+			total = total.Add(existing.weight)
+		}
+	}
+	if total.GreaterThan(decimal.NewFromInt(1)) {
+		return fmt.Errorf("%w: %s of equity allocated", ErrAllocationExceeded, total)
+	}
+
+	sleeve := &allocation{
+		weight: weight,
+		portfolio: &models.Portfolio{
+			ID:        e.portfolio.ID + "/" + strategy.ID(),
+			Positions: make(map[string]*models.Position),
+			CreatedAt: e.now(),
+		},
+	}
+	e.allocations[strategy.ID()] = sleeve
+	e.rebalance(sleeve)
+	e.addStrategy(strategy)
+
+	e.logger.Info("Strategy allocated", zap.String("strategy_id", strategy.ID()), zap.String("weight", weight.String()))
+	return nil
+}
+
+func (e *TradingEngine) GetAllocations() []models.StrategyAllocation {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.allocationSummaries()
+}
+
+func (e *TradingEngine) allocationSummaries() []models.StrategyAllocation {
+	if len(e.allocations) == 0 {
+		return nil
+	}
+
+	summaries := make([]models.StrategyAllocation, 0, len(e.allocations))
+	for strategyID, sleeve := range e.allocations {
+		summaries = append(summaries, models.StrategyAllocation{
+			StrategyID:    strategyID,
+			Weight:        sleeve.weight,
+			Cash:          sleeve.portfolio.Cash,
+			Positions:     copyPositions(sleeve.portfolio.Positions),
+			TotalValue:    sleeve.portfolio.TotalValue,
+			UnrealizedPnL: sleeve.portfolio.UnrealizedPnL,
+			RealizedPnL:   sleeve.portfolio.RealizedPnL,
+			TotalRisk:     sleeve.portfolio.TotalRisk,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].StrategyID < summaries[j].StrategyID
+	})
+	return summaries
+}
+
+func (e *TradingEngine) portfolioFor(strategyID string, portfolio *models.Portfolio) *models.Portfolio {
+	sleeve, exists := e.allocations[strategyID]
+	if !exists {
+		return portfolio
+	}
+
+	view := *sleeve.portfolio
+	view.TradeHistory = portfolio.TradeHistory
+	view.OrderHistory = portfolio.OrderHistory
+	return &view
+}
+
+func (e *TradingEngine) allocateFill(order *models.Order, quantity, price, commission decimal.Decimal) {
+	sleeve, exists := e.allocations[order.StrategyID]
+	if !exists {
+		return
+	}
+
+	if order.ExitReason != "" {
+		held := decimal.Zero
+		if position, open := sleeve.portfolio.Positions[order.Symbol]; open {
+			held = position.Quantity.Abs()
+		}
+		if held.IsZero() {
+			return
+		}
+		if held.LessThan(quantity.Abs()) {
+			commission = commission.Mul(held).Div(quantity.Abs())
+			if quantity.IsNegative() {
+				quantity = held.Neg()
+			} else {
+				quantity = held
+			}
+		}
+	}
+
+	e.applyFill(sleeve.portfolio, order.Symbol, order.StrategyID, quantity, price, commission)
+}
+
+func (e *TradingEngine) rebalanceAllocations() {
+	for _, sleeve := range e.allocations {
+		e.rebalance(sleeve)
+	}
+}
+
+func (e *TradingEngine) rebalance(sleeve *allocation) {
+	portfolio := sleeve.portfolio
+	positionsValue := decimal.Zero
+	unrealizedPnL := decimal.Zero
+
+	for symbol, position := range portfolio.Positions {
+		if marketData, exists := e.marketData[symbol]; exists {
+			position.CurrentPrice = marketData.Price
+			e.markPosition(position, marketData.Price)
+		}
+		positionsValue = positionsValue.Add(position.MarketValue)
+		unrealizedPnL = unrealizedPnL.Add(position.UnrealizedPnL)
+	}
+
+	budget := e.portfolio.TotalValue.Mul(sleeve.weight)
+	portfolio.Cash = budget.Sub(positionsValue)
+	portfolio.TotalValue = budget
+	portfolio.UnrealizedPnL = unrealizedPnL
+	portfolio.TotalRisk = decimal.Zero
+	if budget.IsPositive() {
+		portfolio.TotalRisk = grossExposure(portfolio).Div(budget)
+	}
+	portfolio.UpdatedAt = e.now()
+}
@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func createAllocatedEngine(t *testing.T) *TradingEngine {
+	t.Helper()
+	engine := NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	for _, split := range []struct {
+		id     string
+		weight float64
+	}{{"alpha", 0.6}, {"beta", 0.4}} {
+		config := createTestStrategyConfig()
+		config.ID = split.id
+		config.MaxPositionSize = decimal.NewFromFloat(1.0)
+		config.MaxOrderSize = decimal.NewFromFloat(100000.0)
+		require.NoError(t, engine.AddStrategyWithAllocation(strategies.NewMovingAverageStrategy(config), decimal.NewFromFloat(split.weight)))
+	}
+	return engine
+}
+
+func submitAllocatedOrder(engine *TradingEngine, strategyID string, side models.OrderSide, quantity int64, price float64) *models.Order {
+	order := createTestOrder(side, quantity, price)
+	order.StrategyID = strategyID
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	if order.Status == models.OrderStatusFilled {
+		<-engine.tradeQueue
+	}
+	return order
+}
+
+func TestTradingEngine_Allocation_LimitsEachStrategyToItsBudget(t *testing.T) {
+	engine := createAllocatedEngine(t)
+
+	assert.Equal(t, models.OrderStatusRejected, submitAllocatedOrder(engine, "beta", models.OrderSideBuy, 300, 150.0).Status)
+	assert.Equal(t, models.OrderStatusFilled, submitAllocatedOrder(engine, "beta", models.OrderSideBuy, 250, 150.0).Status)
+	assert.Equal(t, models.OrderStatusRejected, submitAllocatedOrder(engine, "beta", models.OrderSideBuy, 20, 150.0).Status)
+
+	assert.Equal(t, models.OrderStatusRejected, submitAllocatedOrder(engine, "alpha", models.OrderSideBuy, 400, 150.0).Status)
+	assert.Equal(t, models.OrderStatusFilled, submitAllocatedOrder(engine, "alpha", models.OrderSideBuy, 390, 150.0).Status)
+	assert.Equal(t, models.OrderStatusRejected, submitAllocatedOrder(engine, "alpha", models.OrderSideBuy, 20, 150.0).Status)
+
+	assert.True(t, decimal.NewFromInt(640).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+	allocations := engine.GetAllocations()
+	require.Len(t, allocations, 2)
+	assert.True(t, decimal.NewFromInt(390).Equal(allocations[0].Positions["AAPL"].Quantity))
+	assert.True(t, decimal.NewFromInt(250).Equal(allocations[1].Positions["AAPL"].Quantity))
+	assert.True(t, decimal.NewFromFloat(1441.5).Equal(allocations[0].Cash), allocations[0].Cash.String())
+	assert.True(t, decimal.NewFromFloat(2462.5).Equal(allocations[1].Cash), allocations[1].Cash.String())
+}
+
+func TestTradingEngine_Allocation_IdleStrategyBudgetIsReserved(t *testing.T) {
+	engine := createAllocatedEngine(t)
+
+	order := submitAllocatedOrder(engine, "beta", models.OrderSideBuy, 300, 150.0)
+
+	assert.Equal(t, models.OrderStatusRejected, order.Status)
+	assert.True(t, decimal.NewFromFloat(100000.0).Equal(engine.portfolio.Cash))
+	assert.Empty(t, engine.portfolio.Positions)
+}
+
+func TestTradingEngine_Allocation_RebalancesWithEquity(t *testing.T) {
+	engine := createAllocatedEngine(t)
+	engine.strategies["alpha"].GetConfig().CommissionRate = decimal.Zero
+	engine.strategies["beta"].GetConfig().CommissionRate = decimal.Zero
+	submitAllocatedOrder(engine, "beta", models.OrderSideBuy, 200, 100.0)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	engine.updatePortfolio()
+
+	assert.True(t, decimal.NewFromFloat(110000.0).Equal(engine.portfolio.TotalValue), engine.portfolio.TotalValue.String())
+	allocations := engine.GetAllocations()
+	assert.True(t, decimal.NewFromFloat(66000.0).Equal(allocations[0].TotalValue), allocations[0].TotalValue.String())
+	assert.True(t, decimal.NewFromFloat(66000.0).Equal(allocations[0].Cash))
+	assert.True(t, decimal.NewFromFloat(44000.0).Equal(allocations[1].TotalValue))
+	assert.True(t, decimal.NewFromFloat(14000.0).Equal(allocations[1].Cash), allocations[1].Cash.String())
+	assert.True(t, decimal.NewFromFloat(10000.0).Equal(allocations[1].UnrealizedPnL))
+}
+
+func TestTradingEngine_Allocation_BreaksOutRealizedPnL(t *testing.T) {
+	engine := createAllocatedEngine(t)
+	engine.strategies["alpha"].GetConfig().CommissionRate = decimal.Zero
+	engine.strategies["beta"].GetConfig().CommissionRate = decimal.Zero
+	submitAllocatedOrder(engine, "alpha", models.OrderSideBuy, 100, 100.0)
+	submitAllocatedOrder(engine, "beta", models.OrderSideBuy, 100, 100.0)
+
+	submitAllocatedOrder(engine, "alpha", models.OrderSideSell, 100, 110.0)
+	submitAllocatedOrder(engine, "beta", models.OrderSideSell, 50, 90.0)
+
+	summary := engine.GetPortfolioSummary()
+	require.Len(t, summary.Allocations, 2)
+	assert.True(t, decimal.NewFromFloat(1000.0).Equal(summary.Allocations[0].RealizedPnL))
+	assert.Empty(t, summary.Allocations[0].Positions)
+	assert.True(t, decimal.NewFromFloat(-500.0).Equal(summary.Allocations[1].RealizedPnL))
+	assert.True(t, decimal.NewFromInt(50).Equal(summary.Allocations[1].Positions["AAPL"].Quantity))
+	assert.True(t, decimal.NewFromFloat(500.0).Equal(summary.RealizedPnL))
+}
+
+func TestTradingEngine_Allocation_StrategiesSeeTheirSleeve(t *testing.T) {
+	engine := NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	allocated := createRecordingStrategy("allocated", nil)
+	shared := createRecordingStrategy("shared", nil)
+	require.NoError(t, engine.AddStrategyWithAllocation(allocated, decimal.NewFromFloat(0.25)))
+	engine.AddStrategy(shared)
+
+	engine.executeStrategies(context.Background())
+
+	require.Len(t, allocated.portfolios, 1)
+	require.Len(t, shared.portfolios, 1)
+	assert.True(t, decimal.NewFromFloat(25000.0).Equal(allocated.portfolios[0].Cash))
+	assert.True(t, decimal.NewFromFloat(100000.0).Equal(shared.portfolios[0].Cash))
+}
+
+func TestTradingEngine_AddStrategyWithAllocation_Invalid(t *testing.T) {
+	engine := createAllocatedEngine(t)
+
+	extra := createRecordingStrategy("gamma", nil)
+	assert.ErrorIs(t, engine.AddStrategyWithAllocation(extra, decimal.Zero), ErrInvalidAllocation)
+	assert.ErrorIs(t, engine.AddStrategyWithAllocation(extra, decimal.NewFromFloat(0.1)), ErrAllocationExceeded)
+	assert.NotContains(t, engine.strategies, "gamma")
+
+	engine.RemoveStrategy("beta")
+	assert.NoError(t, engine.AddStrategyWithAllocation(extra, decimal.NewFromFloat(0.4)))
+}
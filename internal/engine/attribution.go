@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type strategyLedger struct {
+	RealizedPnL   decimal.Decimal            `json:"realized_pnl"`
+	Commission    decimal.Decimal            `json:"commission"`
+	Dividends     decimal.Decimal            `json:"dividends"`
+	Trades        int                        `json:"trades"`
+	WinningTrades int                        `json:"winning_trades"`
+	LosingTrades  int                        `json:"losing_trades"`
+	Holdings      map[string]decimal.Decimal `json:"holdings,omitempty"`
+}
+
+type holding struct {
+	strategyID string
+	quantity   decimal.Decimal
+}
+
+func (e *TradingEngine) ledger(strategyID string) *strategyLedger {
+	ledger, exists := e.attribution[strategyID]
+	if !exists {
+		ledger = &strategyLedger{Holdings: make(map[string]decimal.Decimal)}
+		e.attribution[strategyID] = ledger
+	}
+	if ledger.Holdings == nil {
+		ledger.Holdings = make(map[string]decimal.Decimal)
+	}
+	return ledger
+}
+
+func (e *TradingEngine) attributeTrade(trade *models.Trade) {
+	ledger := e.ledger(trade.StrategyID)
+	ledger.Commission = ledger.Commission.Add(trade.Commission)
+	ledger.Trades++
+
+	quantity := trade.Quantity
+	if trade.Side == models.OrderSideSell {
+		quantity = quantity.Neg()
+	}
+
+	holders, held := e.holders(trade.Symbol)
+	if held.IsZero() || held.IsPositive() == quantity.IsPositive() {
+		ledger.Holdings[trade.Symbol] = ledger.Holdings[trade.Symbol].Add(quantity)
+		return
+	}
+
+	closed := decimal.Min(quantity.Abs(), held.Abs())
+	realized := split(trade.RealizedPnL, holders, held)
+	for i, holder := range holders {
+		owner := e.attribution[holder.strategyID]
+		owner.RealizedPnL = owner.RealizedPnL.Add(realized[i])
+		switch {
+		case realized[i].IsPositive():
+			owner.WinningTrades++
+		case realized[i].IsNegative():
+			owner.LosingTrades++
+		}
+
+		remaining := holder.quantity.Sub(holder.quantity.Mul(closed).Div(held.Abs()))
+		if closed.Equal(held.Abs()) || remaining.IsZero() {
+			delete(owner.Holdings, trade.Symbol)
+		} else {
+			owner.Holdings[trade.Symbol] = remaining
+		}
+	}
+
+	if quantity.Abs().GreaterThan(held.Abs()) {
+		ledger.Holdings[trade.Symbol] = held.Add(quantity)
+	}
+}
+
+func (e *TradingEngine) attributeDividend(symbol string, cash decimal.Decimal) {
+	holders, held := e.holders(symbol)
+	for i, share := range split(cash, holders, held) {
+		ledger := e.attribution[holders[i].strategyID]
+		ledger.Dividends = ledger.Dividends.Add(share)
+	}
+}
+
+func (e *TradingEngine) splitHoldings(symbol string, ratio decimal.Decimal) {
+	for _, ledger := range e.attribution {
+		if quantity, exists := ledger.Holdings[symbol]; exists {
+			ledger.Holdings[symbol] = quantity.Mul(ratio)
+		}
+	}
+}
+
+func (e *TradingEngine) holders(symbol string) ([]holding, decimal.Decimal) {
+	var holders []holding
+	held := decimal.Zero
+	for strategyID, ledger := range e.attribution {
+		if quantity, exists := ledger.Holdings[symbol]; exists && !quantity.IsZero() {
+			holders = append(holders, holding{strategyID: strategyID, quantity: quantity})
+			held = held.Add(quantity)
+		}
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		return holders[i].strategyID < holders[j].strategyID
+	})
+	return holders, held
+}
+
+func split(amount decimal.Decimal, holders []holding, held decimal.Decimal) []decimal.Decimal {
+	shares := make([]decimal.Decimal, len(holders))
+	remaining := amount
+	for i, holder := range holders {
+		if i == len(holders)-1 {
+			shares[i] = remaining
+			break
+		}
+		shares[i] = amount.Mul(holder.quantity).Div(held)
+		remaining = remaining.Sub(shares[i])
+	}
+	return shares
+}
+
+func (e *TradingEngine) seedAttribution() {
+	for symbol, position := range e.portfolio.Positions {
+		if !position.Quantity.IsZero() {
+			e.ledger(position.StrategyID).Holdings[symbol] = position.Quantity
+		}
+	}
+}
+
+func (e *TradingEngine) GetStrategyPerformance() map[string]models.StrategyPerformance {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	unrealized := make(map[string]decimal.Decimal)
+	for symbol, position := range e.portfolio.Positions {
+		holders, held := e.holders(symbol)
+		if len(holders) == 0 {
+			unrealized[position.StrategyID] = unrealized[position.StrategyID].Add(position.UnrealizedPnL)
+			continue
+		}
+		for i, share := range split(position.UnrealizedPnL, holders, held) {
+			unrealized[holders[i].strategyID] = unrealized[holders[i].strategyID].Add(share)
+		}
+	}
+
+	performance := make(map[string]models.StrategyPerformance)
+	for strategyID := range e.strategies {
+		performance[strategyID] = models.StrategyPerformance{StrategyID: strategyID}
+	}
+	for strategyID, ledger := range e.attribution {
+		closes := ledger.WinningTrades + ledger.LosingTrades
+		winRate := decimal.Zero
+		if closes > 0 {
+			winRate = decimal.NewFromInt(int64(ledger.WinningTrades)).Div(decimal.NewFromInt(int64(closes)))
+		}
+		performance[strategyID] = models.StrategyPerformance{
+			StrategyID:    strategyID,
+			RealizedPnL:   ledger.RealizedPnL,
+			Commission:    ledger.Commission,
+			Dividends:     ledger.Dividends,
+			Trades:        ledger.Trades,
+			WinningTrades: ledger.WinningTrades,
+			LosingTrades:  ledger.LosingTrades,
+			WinRate:       winRate,
+		}
+	}
+	for strategyID, pnl := range unrealized {
+		entry := performance[strategyID]
+		entry.StrategyID = strategyID
+		entry.UnrealizedPnL = pnl
+		performance[strategyID] = entry
+	}
+	for strategyID, entry := range performance {
+		entry.TotalPnL = entry.RealizedPnL.Add(entry.UnrealizedPnL).Add(entry.Dividends)
+		performance[strategyID] = entry
+	}
+	return performance
+}
@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func createAttributionEngine(commissionRate float64) *TradingEngine {
+	engine := NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	for _, id := range []string{"alpha", "beta"} {
+		config := createTestStrategyConfig()
+		config.ID = id
+		config.CommissionRate = decimal.NewFromFloat(commissionRate)
+		engine.AddStrategy(strategies.NewMovingAverageStrategy(config))
+	}
+	return engine
+}
+
+func attributedFill(engine *TradingEngine, strategyID, symbol string, side models.OrderSide, quantity int64, price float64) *models.Trade {
+	order := createTestOrder(side, quantity, price)
+	order.Symbol = symbol
+	order.StrategyID = strategyID
+	engine.executeOrder(order, engine.strategies[strategyID].GetConfig())
+	trade := <-engine.tradeQueue
+	engine.processTrade(trade)
+	return trade
+}
+
+func markPrices(engine *TradingEngine, prices map[string]float64) {
+	for symbol, price := range prices {
+		engine.UpdateMarketData(symbol, createTestMarketData(symbol, price))
+	}
+	engine.updatePortfolio()
+}
+
+func TestTradingEngine_GetStrategyPerformance_DisjointSymbolsReconcile(t *testing.T) {
+	engine := createAttributionEngine(0.001)
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideBuy, 10, 100.0)
+	attributedFill(engine, "beta", "MSFT", models.OrderSideBuy, 20, 50.0)
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideSell, 5, 120.0)
+	attributedFill(engine, "beta", "MSFT", models.OrderSideSell, 10, 45.0)
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideBuy, 5, 110.0)
+	markPrices(engine, map[string]float64{"AAPL": 125.0, "MSFT": 48.0})
+
+	performance := engine.GetStrategyPerformance()
+	require.Len(t, performance, 2)
+	alpha, beta := performance["alpha"], performance["beta"]
+
+	assert.Equal(t, 3, alpha.Trades)
+	assert.Equal(t, 1, alpha.WinningTrades)
+	assert.True(t, decimal.NewFromInt(1).Equal(alpha.WinRate))
+	assert.Equal(t, 2, beta.Trades)
+	assert.Equal(t, 1, beta.LosingTrades)
+	assert.True(t, decimal.Zero.Equal(beta.WinRate))
+
+	portfolio := engine.GetPortfolio()
+	assert.True(t, portfolio.Positions["AAPL"].UnrealizedPnL.Equal(alpha.UnrealizedPnL))
+	assert.True(t, portfolio.Positions["MSFT"].UnrealizedPnL.Equal(beta.UnrealizedPnL))
+	assert.True(t, portfolio.RealizedPnL.Equal(alpha.RealizedPnL.Add(beta.RealizedPnL)))
+	assert.True(t, portfolio.UnrealizedPnL.Equal(alpha.UnrealizedPnL.Add(beta.UnrealizedPnL)))
+	assert.True(t, alpha.TotalPnL.Equal(alpha.RealizedPnL.Add(alpha.UnrealizedPnL)))
+
+	commission := decimal.Zero
+	for _, trade := range portfolio.TradeHistory {
+		commission = commission.Add(trade.Commission)
+	}
+	assert.True(t, commission.Equal(alpha.Commission.Add(beta.Commission)))
+	assert.True(t, alpha.Commission.IsPositive())
+}
+
+func TestTradingEngine_GetStrategyPerformance_SharedPositionSplitsByQuantity(t *testing.T) {
+	engine := createAttributionEngine(0)
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideBuy, 30, 100.0)
+	attributedFill(engine, "beta", "AAPL", models.OrderSideBuy, 10, 100.0)
+	markPrices(engine, map[string]float64{"AAPL": 110.0})
+
+	performance := engine.GetStrategyPerformance()
+	assert.True(t, decimal.NewFromInt(300).Equal(performance["alpha"].UnrealizedPnL))
+	assert.True(t, decimal.NewFromInt(100).Equal(performance["beta"].UnrealizedPnL))
+
+	trade := attributedFill(engine, "alpha", "AAPL", models.OrderSideSell, 20, 120.0)
+	assert.True(t, decimal.NewFromInt(400).Equal(trade.RealizedPnL))
+
+	performance = engine.GetStrategyPerformance()
+	assert.True(t, decimal.NewFromInt(300).Equal(performance["alpha"].RealizedPnL))
+	assert.True(t, decimal.NewFromInt(100).Equal(performance["beta"].RealizedPnL))
+	assert.True(t, decimal.NewFromInt(15).Equal(engine.attribution["alpha"].Holdings["AAPL"]))
+	assert.True(t, decimal.NewFromInt(5).Equal(engine.attribution["beta"].Holdings["AAPL"]))
+
+	attributedFill(engine, "beta", "AAPL", models.OrderSideSell, 30, 120.0)
+	markPrices(engine, map[string]float64{"AAPL": 115.0})
+
+	performance = engine.GetStrategyPerformance()
+	assert.True(t, decimal.NewFromInt(600).Equal(performance["alpha"].RealizedPnL))
+	assert.True(t, decimal.NewFromInt(200).Equal(performance["beta"].RealizedPnL))
+	assert.NotContains(t, engine.attribution["alpha"].Holdings, "AAPL")
+	assert.True(t, decimal.NewFromInt(-10).Equal(engine.attribution["beta"].Holdings["AAPL"]))
+	assert.True(t, decimal.NewFromInt(50).Equal(performance["beta"].UnrealizedPnL))
+	assert.True(t, decimal.Zero.Equal(performance["alpha"].UnrealizedPnL))
+
+	portfolio := engine.GetPortfolio()
+	assert.True(t, portfolio.RealizedPnL.Equal(performance["alpha"].RealizedPnL.Add(performance["beta"].RealizedPnL)))
+	assert.True(t, portfolio.UnrealizedPnL.Equal(performance["beta"].UnrealizedPnL))
+}
+
+func TestTradingEngine_GetStrategyPerformance_SurvivesRestart(t *testing.T) {
+	engine := createAttributionEngine(0)
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideBuy, 10, 100.0)
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideSell, 5, 110.0)
+	markPrices(engine, map[string]float64{"AAPL": 120.0})
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, engine.SaveState(path))
+
+	restored, err := NewTradingEngineFromState(path, zap.NewNop())
+	require.NoError(t, err)
+
+	performance := restored.GetStrategyPerformance()
+	assert.True(t, decimal.NewFromInt(50).Equal(performance["alpha"].RealizedPnL))
+	assert.True(t, decimal.NewFromInt(100).Equal(performance["alpha"].UnrealizedPnL))
+	assert.Equal(t, 2, performance["alpha"].Trades)
+}
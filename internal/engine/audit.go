@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type AuditSink interface {
+	Write(record audit.Record) error
+	Flush() error
+	Sequence() uint64
+}
+
+func (e *TradingEngine) SetAuditLog(sink AuditSink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.auditLog = sink
+}
+
+func (e *TradingEngine) audit(record audit.Record) {
+	if e.auditLog == nil {
+		return
+	}
+	record.Timestamp = e.now()
+	if record.Order != nil {
+		orderCopy := *record.Order
+		record.Order = &orderCopy
+		record.OrderID = orderCopy.ID
+	}
+	if record.Trade != nil {
+		tradeCopy := *record.Trade
+		record.Trade = &tradeCopy
+	}
+	if err := e.auditLog.Write(record); err != nil {
+		e.logger.Error("Failed to write audit record", zap.String("type", string(record.Type)), zap.String("order_id", record.OrderID), zap.Error(err))
+	}
+}
+
+func (e *TradingEngine) auditOrder(eventType audit.EventType, order *models.Order, err error) {
+	record := audit.Record{Type: eventType, Order: order}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	e.audit(record)
+}
+
+func (e *TradingEngine) RecordAdjustments(adjustments []models.SimulatorAdjustment) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, adjustment := range adjustments {
+		recorded := adjustment
+		e.audit(audit.Record{Type: audit.EventSimulatorAdjusted, Adjustment: &recorded})
+		e.logger.Info("Simulator adjustment recorded",
+			zap.String("symbol", adjustment.Symbol),
+			zap.String("kind", string(adjustment.Kind)),
+			zap.String("event_type", adjustment.EventType),
+			zap.String("value", adjustment.Value.String()))
+	}
+}
+
+func (e *TradingEngine) flushAuditLog() {
+	if e.auditLog == nil {
+		return
+	}
+	if err := e.auditLog.Flush(); err != nil {
+		e.logger.Error("Failed to flush audit log", zap.Error(err))
+	}
+}
+
+func (e *TradingEngine) ReplayAudit(records []audit.Record) error {
+	if err := e.replayAudit(records); err != nil {
+		return err
+	}
+	e.resubmit(e.restoreOpenOrders())
+	return nil
+}
+
+func (e *TradingEngine) replayAudit(records []audit.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i, record := range records {
+		if i > 0 && record.Sequence <= records[i-1].Sequence {
+			return fmt.Errorf("%w: %d follows %d", audit.ErrOutOfOrder, record.Sequence, records[i-1].Sequence)
+		}
+		if err := e.replay(record); err != nil {
+			return fmt.Errorf("audit record %d: %w", record.Sequence, err)
+		}
+		e.replayOpenOrder(record)
+	}
+	return nil
+}
+
+func (e *TradingEngine) replay(record audit.Record) error {
+	previous := e.clock.current
+	e.clock.current = record.Timestamp
+	if record.Trade != nil {
+		e.clock.current = record.Trade.Timestamp
+	}
+	defer func() { e.clock.current = previous }()
+
+	switch record.Type {
+	case audit.EventOrderFilled:
+		if record.Trade == nil || record.Order == nil {
+			return fmt.Errorf("%w: fill without trade or order", ErrInvalidState)
+		}
+		e.replayFill(record.Order, record.Trade)
+		e.recordOrder(copyOrder(record.Order))
+	case audit.EventOrderRejected, audit.EventOrderCancelled, audit.EventOrderCompleted:
+		if record.Order == nil {
+			return fmt.Errorf("%w: %s without order", ErrInvalidState, record.Type)
+		}
+		e.recordOrder(copyOrder(record.Order))
+	case audit.EventCorporateAction:
+		if record.CorporateAction == nil {
+			return fmt.Errorf("%w: corporate action missing", ErrInvalidState)
+		}
+		action := *record.CorporateAction
+		if trade := e.applyCorporateAction(&action); trade != nil && record.Trade != nil {
+			e.recordTrade(copyTrade(record.Trade))
+			e.attributeTrade(record.Trade)
+		}
+	case audit.EventMarginInterest:
+		if record.Interest == nil {
+			return fmt.Errorf("%w: margin interest missing", ErrInvalidState)
+		}
+		e.portfolio.Cash = e.portfolio.Cash.Sub(*record.Interest)
+		e.portfolio.InterestExpense = e.portfolio.InterestExpense.Add(*record.Interest)
+	}
+	return nil
+}
+
+func (e *TradingEngine) replayFill(order *models.Order, trade *models.Trade) {
+	quantity := trade.Quantity
+	if trade.Side == models.OrderSideSell {
+		quantity = quantity.Neg()
+	}
+	previous := decimal.Zero
+	if position, exists := e.portfolio.Positions[trade.Symbol]; exists {
+		previous = position.Quantity
+	}
+	e.applyFill(e.portfolio, trade.Symbol, trade.StrategyID, quantity, trade.Price, trade.Commission)
+	e.attachStop(order, previous)
+	e.recordStrategyPnL(trade.StrategyID, trade.RealizedPnL)
+	e.allocateFill(order, quantity, trade.Price, trade.Commission)
+	updateMargin(e.portfolio)
+
+	e.recordTrade(copyTrade(trade))
+	e.attributeTrade(trade)
+}
+
+func copyOrder(order *models.Order) *models.Order {
+	orderCopy := *order
+	return &orderCopy
+}
+
+func copyTrade(trade *models.Trade) *models.Trade {
+	tradeCopy := *trade
+	return &tradeCopy
+}
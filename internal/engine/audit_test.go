@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAuditEngine(t *testing.T) (*TradingEngine, *clock.Fake, *audit.Writer, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writer, err := audit.NewWriter(path)
+	require.NoError(t, err)
+
+	fake := clock.NewFake(clockStart)
+	engine := createTestEngine(WithClock(fake))
+	engine.SetAuditLog(writer)
+	return engine, fake, writer, path
+}
+
+func auditSignal(engine *TradingEngine, action models.Action, quantity int64, price float64) {
+	engine.createOrderFromResult(&models.AlgorithmResult{
+		StrategyID: "test_strategy",
+		Symbol:     "AAPL",
+		Action:     action,
+		Quantity:   decimal.NewFromInt(quantity),
+		Price:      decimal.NewFromFloat(price),
+		Confidence: decimal.NewFromFloat(0.8),
+		Signal:     "test",
+		Reason:     "test reason",
+	}, engine.strategies["test_strategy"], nil)
+	engine.drainQueues()
+}
+
+func auditTypes(records []audit.Record) []audit.EventType {
+	types := make([]audit.EventType, len(records))
+	for i, record := range records {
+		types[i] = record.Type
+	}
+	return types
+}
+
+func assertSameJSON(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+	want, err := json.Marshal(expected)
+	require.NoError(t, err)
+	got, err := json.Marshal(actual)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got))
+}
+
+func TestTradingEngine_SetAuditLog_RecordsOrderLifecycle(t *testing.T) {
+	engine, fake, writer, path := createAuditEngine(t)
+	engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(150), Timestamp: clockStart})
+
+	auditSignal(engine, "buy", 10, 150.0)
+	fake.Advance(time.Second)
+	auditSignal(engine, "sell", 50, 150.0)
+	require.NoError(t, writer.Close())
+
+	records, err := audit.Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, []audit.EventType{
+		audit.EventOrderCreated,
+		audit.EventOrderValidated,
+		audit.EventRiskCalculated,
+		audit.EventOrderFilled,
+		audit.EventOrderCreated,
+		audit.EventOrderRejected,
+	}, auditTypes(records))
+	for i, record := range records {
+		assert.Equal(t, uint64(i+1), record.Sequence)
+	}
+
+	created := records[0]
+	require.NotNil(t, created.Signal)
+	assert.Equal(t, models.ActionBuy, created.Signal.Action)
+	assert.Equal(t, "test reason", created.Signal.Reason)
+	assert.Equal(t, "test reason", created.Order.Reason)
+	assert.True(t, decimal.NewFromFloat(0.8).Equal(created.Signal.Confidence))
+	assert.Equal(t, models.OrderStatusPending, created.Order.Status)
+	assert.Equal(t, clockStart, created.Timestamp)
+
+	assert.NotNil(t, records[2].RiskMetrics)
+	assert.Equal(t, created.OrderID, records[2].OrderID)
+
+	fill := records[3]
+	require.NotNil(t, fill.Trade)
+	assert.True(t, decimal.NewFromInt(150).Equal(fill.Trade.Price))
+	assert.True(t, decimal.NewFromFloat(1.5).Equal(fill.Trade.Commission))
+	require.NotNil(t, fill.Slippage)
+	assert.True(t, fill.Slippage.IsZero())
+
+	rejected := records[5]
+	assert.Equal(t, models.OrderStatusRejected, rejected.Order.Status)
+	assert.Equal(t, "insufficient position", rejected.Error)
+	assert.Equal(t, clockStart.Add(time.Second), rejected.Timestamp)
+}
+
+func TestTradingEngine_ReplayAudit_ReconstructsPortfolio(t *testing.T) {
+	engine, fake, writer, path := createAuditEngine(t)
+	tick := func(price float64) {
+		fake.Advance(time.Second)
+		engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(price), Timestamp: fake.Now()})
+	}
+
+	tick(150.0)
+	auditSignal(engine, "buy", 101, 150.0)
+	tick(152.0)
+	auditSignal(engine, "sell", 30, 152.0)
+	auditSignal(engine, "sell", 500, 152.0)
+
+	limit := createTestOrder(models.OrderSideBuy, 10, 140.0)
+	limit.Type, limit.TimeInForce, limit.Timestamp = models.OrderTypeLimit, models.TimeInForceGTC, fake.Now()
+	engine.submitOrder(limit)
+	engine.drainQueues()
+	require.NoError(t, engine.ModifyOrder(limit.ID, decimal.NewFromInt(145), decimal.Zero))
+	engine.drainQueues()
+	replacement := engine.GetOpenOrders()[0]
+	require.NoError(t, engine.CancelOrder(replacement.ID))
+
+	fake.Advance(time.Hour)
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{Symbol: "AAPL", Type: models.CorporateActionDividend, Amount: decimal.NewFromFloat(0.24)}))
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{Symbol: "AAPL", Type: models.CorporateActionSplit, Ratio: decimal.NewFromFloat(1.5)}))
+	engine.drainQueues()
+	tick(100.0)
+	auditSignal(engine, "sell", 20, 100.0)
+	require.NoError(t, engine.StopAndDrain(context.Background()))
+	require.NoError(t, writer.Close())
+
+	records, err := audit.Read(path)
+	require.NoError(t, err)
+	replayed := createTestEngine()
+	require.NoError(t, replayed.ReplayAudit(records))
+
+	for _, e := range []*TradingEngine{engine, replayed} {
+		e.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(101), Timestamp: fake.Now()})
+		e.updatePortfolio()
+	}
+	original, rebuilt := engine.GetPortfolio(), replayed.GetPortfolio()
+	for _, portfolio := range []*models.Portfolio{original, rebuilt} {
+		for _, position := range portfolio.Positions {
+			position.PeakPrice, position.TroughPrice = decimal.Zero, decimal.Zero
+		}
+	}
+	assert.True(t, original.Cash.Equal(rebuilt.Cash), "cash %s != %s", original.Cash, rebuilt.Cash)
+	assert.True(t, original.TotalValue.Equal(rebuilt.TotalValue))
+	assert.True(t, original.RealizedPnL.Equal(rebuilt.RealizedPnL))
+	assert.True(t, original.DividendIncome.Equal(rebuilt.DividendIncome))
+	assertSameJSON(t, original.Positions, rebuilt.Positions)
+	assertSameJSON(t, original.TradeHistory, rebuilt.TradeHistory)
+	assertSameJSON(t, original.OrderHistory, rebuilt.OrderHistory)
+	assertSameJSON(t, original.CorporateActions, rebuilt.CorporateActions)
+	assertSameJSON(t, engine.GetStrategyPerformance(), replayed.GetStrategyPerformance())
+	assert.Len(t, original.TradeHistory, 4)
+}
+
+func TestTradingEngine_ReplayAudit_RejectsOutOfOrderRecords(t *testing.T) {
+	engine := createTestEngine()
+
+	err := engine.ReplayAudit([]audit.Record{{Sequence: 2}, {Sequence: 1}})
+	assert.ErrorIs(t, err, audit.ErrOutOfOrder)
+}
@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultQueueSize      = 1000
+	defaultEnqueueTimeout = 100 * time.Millisecond
+	protectiveTimeoutMul  = 10
+	queueHighWatermark    = 0.8
+
+	orderQueueName = "orders"
+	tradeQueueName = "trades"
+)
+
+type QueueConfig struct {
+	OrderQueue     int
+	TradeQueue     int
+	EnqueueTimeout time.Duration
+}
+
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		OrderQueue:     defaultQueueSize,
+		TradeQueue:     defaultQueueSize,
+		EnqueueTimeout: defaultEnqueueTimeout,
+	}
+}
+
+type QueueStats struct {
+	Orders        int    `json:"orders"`
+	OrderCapacity int    `json:"order_capacity"`
+	Trades        int    `json:"trades"`
+	TradeCapacity int    `json:"trade_capacity"`
+	Rejected      uint64 `json:"rejected"`
+}
+
+type queueWatch struct {
+	name     string
+	high     atomic.Bool
+	rejected atomic.Uint64
+}
+
+func (e *TradingEngine) SetQueueConfig(config QueueConfig) error {
+	switch {
+	case config.OrderQueue <= 0:
+		return fmt.Errorf("%w: order queue size must be positive", ErrInvalidQueues)
+	case config.TradeQueue <= 0:
+		return fmt.Errorf("%w: trade queue size must be positive", ErrInvalidQueues)
+	case config.EnqueueTimeout <= 0:
+		return fmt.Errorf("%w: enqueue timeout must be positive", ErrInvalidQueues)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.running {
+		return fmt.Errorf("%w: queues cannot be resized while the engine is running", ErrInvalidQueues)
+	}
+	if len(e.orderQueue) > config.OrderQueue || len(e.tradeQueue) > config.TradeQueue {
+		return fmt.Errorf("%w: %d orders and %d trades are already queued", ErrInvalidQueues, len(e.orderQueue), len(e.tradeQueue))
+	}
+
+	orderQueue := make(chan *models.Order, config.OrderQueue)
+	for len(e.orderQueue) > 0 {
+		orderQueue <- <-e.orderQueue
+	}
+	tradeQueue := make(chan *models.Trade, config.TradeQueue)
+	for len(e.tradeQueue) > 0 {
+		tradeQueue <- <-e.tradeQueue
+	}
+	e.orderQueue, e.tradeQueue = orderQueue, tradeQueue
+	e.queues = config
+	return nil
+}
+
+func (e *TradingEngine) QueueStats() QueueStats {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return QueueStats{
+		Orders:        len(e.orderQueue),
+		OrderCapacity: cap(e.orderQueue),
+		Trades:        len(e.tradeQueue),
+		TradeCapacity: cap(e.tradeQueue),
+		Rejected:      e.orderWatch.rejected.Load(),
+	}
+}
+
+func (e *TradingEngine) enqueueOrder(order *models.Order) {
+	e.mu.RLock()
+	queue, timeout := e.orderQueue, e.queues.EnqueueTimeout
+	e.mu.RUnlock()
+	if order.ExitReason != "" {
+		timeout *= protectiveTimeoutMul
+	}
+
+	e.watchQueue(e.orderWatch, len(queue)+1, cap(queue))
+	select {
+	case queue <- order:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case queue <- order:
+		return
+	case <-timer.C:
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, open := e.openOrders[order.ID]; !open {
+		return
+	}
+	delete(e.openOrders, order.ID)
+	rejected := e.orderWatch.rejected.Add(1)
+	e.metrics.QueueRejected(orderQueueName)
+	e.logger.Warn("Order queue full, rejecting order",
+		zap.String("order_id", order.ID),
+		zap.String("strategy_id", order.StrategyID),
+		zap.Duration("timeout", timeout),
+		zap.Uint64("rejected", rejected))
+	e.rejectOrder(order, fmt.Errorf("%w: order queue full after %s", ErrEngineBusy, timeout))
+	e.metrics.ObserveOrder(order, timeout)
+}
+
+func (e *TradingEngine) enqueueTrade(trade *models.Trade) {
+	e.watchQueue(e.tradeWatch, len(e.tradeQueue)+1, cap(e.tradeQueue))
+	select {
+	case e.tradeQueue <- trade:
+	default:
+		e.logger.Warn("Trade queue full, recording trade inline", zap.String("trade_id", trade.ID))
+		e.recordExecutedTrade(trade)
+	}
+}
+
+func (e *TradingEngine) watchQueue(watch *queueWatch, depth, capacity int) {
+	e.metrics.ObserveQueue(watch.name, min(depth, capacity), capacity)
+	high := float64(depth) >= queueHighWatermark*float64(capacity)
+	if watch.high.Swap(high) == high {
+		return
+	}
+	if high {
+		e.metrics.QueueHighWatermark(watch.name)
+		e.logger.Warn("Queue above high watermark", zap.String("queue", watch.name), zap.Int("depth", depth), zap.Int("capacity", capacity))
+		return
+	}
+	e.logger.Info("Queue back below high watermark", zap.String("queue", watch.name), zap.Int("depth", depth), zap.Int("capacity", capacity))
+}
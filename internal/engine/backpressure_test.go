@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/metrics"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_SubmitOrder_RejectsOverflowWhenQueueStaysFull(t *testing.T) {
+	engine := createTestEngine()
+	m := metrics.New()
+	engine.SetMetrics(m)
+	require.NoError(t, engine.SetQueueConfig(QueueConfig{OrderQueue: 1000, TradeQueue: 1000, EnqueueTimeout: time.Millisecond}))
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.StopLossPercent = decimal.NewFromFloat(0.05)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 100.0), config)
+	<-engine.tradeQueue
+
+	const producers, orders = 50, 10000
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < orders/producers; i++ {
+				engine.submitOrder(createTestOrder(models.OrderSideBuy, 1, 100.0))
+			}
+		}()
+	}
+	flooded := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(flooded)
+	}()
+
+	responsive := make(chan QueueStats)
+	go func() { responsive <- engine.QueueStats() }()
+	select {
+	case <-responsive:
+	case <-time.After(time.Second):
+		t.Fatal("engine unresponsive while the order queue is full")
+	}
+	select {
+	case <-flooded:
+	case <-time.After(30 * time.Second):
+		t.Fatal("order producers deadlocked on a full queue")
+	}
+
+	stats := engine.QueueStats()
+	assert.Equal(t, QueueStats{Orders: 1000, OrderCapacity: 1000, TradeCapacity: 1000, Rejected: orders - 1000}, stats)
+	rejected := 0
+	for _, order := range engine.GetPortfolio().OrderHistory {
+		if order.Status == models.OrderStatusRejected {
+			rejected++
+		}
+	}
+	assert.Equal(t, orders-1000, rejected)
+	assert.Len(t, engine.GetOpenOrders(), 1000)
+
+	scraped := scrapeMetrics(m)
+	assert.True(t, strings.Contains(scraped, `trade_algo_queue_rejections_total{queue="orders"} 9000`), scraped)
+	assert.True(t, strings.Contains(scraped, `trade_algo_queue_high_watermark_total{queue="orders"} 1`), scraped)
+	assert.True(t, strings.Contains(scraped, `trade_algo_queue_usage_ratio{queue="orders"} 1`), scraped)
+
+	ticked := make(chan struct{})
+	go func() {
+		engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 90.0))
+		close(ticked)
+	}()
+	select {
+	case <-ticked:
+	case <-time.After(time.Second):
+		t.Fatal("exit order blocked on a full queue")
+	}
+	history := engine.GetPortfolio().OrderHistory
+	exit := history[len(history)-1]
+	assert.Equal(t, models.ExitReasonStopLoss, exit.ExitReason)
+	assert.Equal(t, models.OrderStatusRejected, exit.Status)
+
+	engine.drainQueues()
+	assert.Empty(t, engine.GetOpenOrders())
+	assert.Len(t, engine.GetPortfolio().OrderHistory, orders+1)
+	assert.NotEmpty(t, engine.GetPortfolio().TradeHistory)
+	assert.EqualValues(t, orders-1000+1, engine.QueueStats().Rejected)
+}
+
+func TestTradingEngine_SubmitOrder_WaitsForSlowConsumer(t *testing.T) {
+	engine := createTestEngine()
+	require.NoError(t, engine.SetQueueConfig(QueueConfig{OrderQueue: 1, TradeQueue: 1, EnqueueTimeout: time.Second}))
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 1, 100.0))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		engine.processOrder(<-engine.orderQueue)
+	}()
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 1, 100.0))
+	engine.drainQueues()
+
+	assert.Zero(t, engine.QueueStats().Rejected)
+	assert.Len(t, engine.GetPortfolio().TradeHistory, 2)
+	assert.True(t, engine.GetPortfolio().Positions["AAPL"].Quantity.Equal(createTestOrder(models.OrderSideBuy, 2, 100.0).Quantity))
+}
+
+func TestTradingEngine_SetQueueConfig_Validates(t *testing.T) {
+	engine := createTestEngine()
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 1, 100.0))
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 1, 100.0))
+
+	for _, config := range []QueueConfig{
+		{OrderQueue: 0, TradeQueue: 10, EnqueueTimeout: time.Second},
+		{OrderQueue: 10, TradeQueue: -1, EnqueueTimeout: time.Second},
+		{OrderQueue: 10, TradeQueue: 10},
+		{OrderQueue: 1, TradeQueue: 10, EnqueueTimeout: time.Second},
+	} {
+		assert.ErrorIs(t, engine.SetQueueConfig(config), ErrInvalidQueues, config)
+	}
+
+	require.NoError(t, engine.SetQueueConfig(QueueConfig{OrderQueue: 2, TradeQueue: 2, EnqueueTimeout: time.Second}))
+	assert.Equal(t, QueueStats{Orders: 2, OrderCapacity: 2, TradeCapacity: 2}, engine.QueueStats())
+}
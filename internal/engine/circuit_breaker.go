@@ -0,0 +1,300 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	haltReasonLoss   = "loss_limit"
+	resumeCooldown   = "cooldown_elapsed"
+	resumeRequested  = "requested"
+	portfolioBreaker = ""
+)
+
+type CircuitBreakerConfig struct {
+	MaxLoss         decimal.Decimal
+	StrategyMaxLoss decimal.Decimal
+	Window          time.Duration
+	Cooldown        time.Duration
+}
+
+type circuitBreaker struct {
+	config     CircuitBreakerConfig
+	portfolio  lossWindow
+	strategies map[string]*lossWindow
+	halt       *models.TradingHalt
+	halts      map[string]*models.TradingHalt
+}
+
+type lossWindow struct {
+	samples []pnlSample
+}
+
+type pnlSample struct {
+	at    time.Time
+	value decimal.Decimal
+}
+
+func (w *lossWindow) add(at time.Time, value decimal.Decimal, window time.Duration) decimal.Decimal {
+	cutoff := at.Add(-window)
+	kept := w.samples[:0]
+	for _, sample := range w.samples {
+		if sample.at.After(cutoff) {
+			kept = append(kept, sample)
+		}
+	}
+	w.samples = append(kept, pnlSample{at: at, value: value})
+
+	peak := value
+	for _, sample := range w.samples {
+		peak = decimal.Max(peak, sample.value)
+	}
+	return peak
+}
+
+func (e *TradingEngine) SetCircuitBreaker(config CircuitBreakerConfig) error {
+	one := decimal.NewFromInt(1)
+	if config.MaxLoss.IsNegative() || config.MaxLoss.GreaterThanOrEqual(one) ||
+		config.StrategyMaxLoss.IsNegative() || config.StrategyMaxLoss.GreaterThanOrEqual(one) {
+		return fmt.Errorf("%w: max losses must be at least 0 and below 1", ErrInvalidCircuitBreaker)
+	}
+	enabled := config.MaxLoss.IsPositive() || config.StrategyMaxLoss.IsPositive()
+	if enabled && config.Window <= 0 || config.Cooldown < 0 {
+		return fmt.Errorf("%w: need a positive window and a non-negative cooldown", ErrInvalidCircuitBreaker)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !enabled {
+		e.breaker = nil
+		return nil
+	}
+	e.breaker = &circuitBreaker{
+		config:     config,
+		strategies: make(map[string]*lossWindow),
+		halts:      make(map[string]*models.TradingHalt),
+	}
+	return nil
+}
+
+func (e *TradingEngine) TradingStatus() models.TradingStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var status models.TradingStatus
+	if e.breaker == nil {
+		return status
+	}
+	if halt := e.breaker.halt; halt != nil {
+		copied := *halt
+		status.Halted, status.Halt = true, &copied
+	}
+	for _, strategyID := range e.haltedStrategyIDs() {
+		status.Strategies = append(status.Strategies, *e.breaker.halts[strategyID])
+	}
+	return status
+}
+
+func (e *TradingEngine) ResumeTrading() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.breaker == nil {
+		return
+	}
+	if e.breaker.halt != nil {
+		e.resume(portfolioBreaker, resumeRequested)
+	}
+	for _, strategyID := range e.haltedStrategyIDs() {
+		e.resume(strategyID, resumeRequested)
+	}
+}
+
+func (e *TradingEngine) ResumeStrategy(strategyID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, exists := e.strategies[strategyID]; !exists {
+		return fmt.Errorf("%w: %s", ErrStrategyNotFound, strategyID)
+	}
+	if e.breaker == nil || e.breaker.halts[strategyID] == nil {
+		return fmt.Errorf("%w: %s", ErrNotHalted, strategyID)
+	}
+	e.resume(strategyID, resumeRequested)
+	return nil
+}
+
+func (e *TradingEngine) tradingHalted(strategyID string) bool {
+	return e.breaker != nil && (e.breaker.halt != nil || e.breaker.halts[strategyID] != nil)
+}
+
+func (e *TradingEngine) checkCircuitBreakers() {
+	breaker := e.breaker
+	if breaker == nil {
+		return
+	}
+	now := e.now()
+	e.resumeElapsed(now)
+
+	if breaker.config.MaxLoss.IsPositive() && breaker.halt == nil {
+		value := e.portfolio.TotalValue
+		peak := breaker.portfolio.add(now, value, breaker.config.Window)
+		if peak.IsPositive() {
+			if loss := peak.Sub(value).Div(peak); loss.GreaterThan(breaker.config.MaxLoss) {
+				e.trip(portfolioBreaker, loss, breaker.config.MaxLoss, now)
+			}
+		}
+	}
+
+	if !breaker.config.StrategyMaxLoss.IsPositive() || breaker.halt != nil {
+		return
+	}
+	ids := make([]string, 0, len(e.strategies))
+	for strategyID := range e.strategies {
+		ids = append(ids, strategyID)
+	}
+	sort.Strings(ids)
+	for _, strategyID := range ids {
+		if breaker.halts[strategyID] != nil {
+			continue
+		}
+		window, exists := breaker.strategies[strategyID]
+		if !exists {
+			window = &lossWindow{}
+			breaker.strategies[strategyID] = window
+		}
+		pnl, capital := e.strategyPnL(strategyID)
+		peak := window.add(now, pnl, breaker.config.Window)
+		if !capital.IsPositive() {
+			continue
+		}
+		if loss := peak.Sub(pnl).Div(capital); loss.GreaterThan(breaker.config.StrategyMaxLoss) {
+			e.trip(strategyID, loss, breaker.config.StrategyMaxLoss, now)
+		}
+	}
+}
+
+func (e *TradingEngine) resumeElapsed(now time.Time) {
+	breaker := e.breaker
+	if breaker.config.Cooldown == 0 {
+		return
+	}
+	if breaker.halt != nil && !now.Before(breaker.halt.ResumeAt) {
+		e.resume(portfolioBreaker, resumeCooldown)
+	}
+	for _, strategyID := range e.haltedStrategyIDs() {
+		if !now.Before(breaker.halts[strategyID].ResumeAt) {
+			e.resume(strategyID, resumeCooldown)
+		}
+	}
+}
+
+func (e *TradingEngine) trip(strategyID string, loss, threshold decimal.Decimal, now time.Time) {
+	halt := &models.TradingHalt{
+		StrategyID: strategyID,
+		Reason:     haltReasonLoss,
+		Loss:       loss,
+		Threshold:  threshold,
+		HaltedAt:   now,
+	}
+	if e.breaker.config.Cooldown > 0 {
+		halt.ResumeAt = now.Add(e.breaker.config.Cooldown)
+	}
+	if strategyID == portfolioBreaker {
+		e.breaker.halt = halt
+	} else {
+		e.breaker.halts[strategyID] = halt
+	}
+
+	cancelled := e.cancelForHalt(strategyID)
+	recorded := *halt
+	e.audit(audit.Record{Type: audit.EventTradingHalted, Halt: &recorded})
+	e.recordRiskEvent(models.RiskEvent{
+		Type:       models.RiskEventCircuitBreaker,
+		StrategyID: strategyID,
+		Drawdown:   loss,
+		Threshold:  threshold,
+		Timestamp:  now,
+	})
+	e.logger.Warn("Circuit breaker tripped, trading halted",
+		zap.String("strategy_id", strategyID),
+		zap.String("loss", loss.String()),
+		zap.String("threshold", threshold.String()),
+		zap.Duration("window", e.breaker.config.Window),
+		zap.Time("resume_at", halt.ResumeAt),
+		zap.Int("cancelled_orders", cancelled))
+}
+
+func (e *TradingEngine) resume(strategyID, reason string) {
+	halt := e.breaker.halt
+	if strategyID == portfolioBreaker {
+		e.breaker.halt = nil
+		e.breaker.portfolio = lossWindow{}
+		e.breaker.strategies = make(map[string]*lossWindow)
+	} else {
+		halt = e.breaker.halts[strategyID]
+		delete(e.breaker.halts, strategyID)
+		delete(e.breaker.strategies, strategyID)
+	}
+
+	resumed := *halt
+	resumed.Reason = reason
+	resumed.ResumeAt = e.now()
+	e.audit(audit.Record{Type: audit.EventTradingResumed, Halt: &resumed})
+	e.recordRiskEvent(models.RiskEvent{
+		Type:       models.RiskEventTradingResumed,
+		StrategyID: strategyID,
+		Threshold:  halt.Threshold,
+		Timestamp:  resumed.ResumeAt,
+	})
+	e.logger.Info("Trading resumed", zap.String("strategy_id", strategyID), zap.String("reason", reason))
+}
+
+func (e *TradingEngine) cancelForHalt(strategyID string) int {
+	var orders []*models.Order
+	for _, order := range e.openOrders {
+		if order.ParentID != "" || e.protectiveOrder(order) {
+			continue
+		}
+		if strategyID == portfolioBreaker || order.StrategyID == strategyID {
+			orders = append(orders, order)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ID < orders[j].ID })
+
+	for _, order := range orders {
+		e.cancelSlices(order.ID)
+		delete(e.openOrders, order.ID)
+		e.book.remove(order.ID)
+		e.cancelOrder(order, models.CancelReasonHalted)
+	}
+	return len(orders)
+}
+
+func (e *TradingEngine) protectiveOrder(order *models.Order) bool {
+	if order.ExitReason != "" {
+		return true
+	}
+	if order.Type != models.OrderTypeStop {
+		return false
+	}
+	position, exists := e.portfolio.Positions[order.Symbol]
+	if !exists || position.Quantity.IsZero() {
+		return false
+	}
+	return position.Quantity.IsPositive() == (order.Side == models.OrderSideSell)
+}
+
+func (e *TradingEngine) haltedStrategyIDs() []string {
+	ids := make([]string, 0, len(e.breaker.halts))
+	for strategyID := range e.breaker.halts {
+		ids = append(ids, strategyID)
+	}
+	sort.Strings(ids)
+	return ids
+}
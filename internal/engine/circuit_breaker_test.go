@@ -0,0 +1,188 @@
+package engine
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBreakerEngine(t *testing.T, config CircuitBreakerConfig) (*TradingEngine, *clock.Fake, *stubStrategy) {
+	t.Helper()
+	fake := clock.NewFake(clockStart)
+	engine, strategy := createCountingEngine(Intervals{}, WithClock(fake))
+	engine.strategies["test_strategy"].GetConfig().CommissionRate = decimal.Zero
+	require.NoError(t, engine.SetCircuitBreaker(config))
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 500, 100.0), engine.strategies["test_strategy"].GetConfig())
+	engine.drainQueues()
+	markAndCheck(engine, 100.0)
+	return engine, fake, strategy
+}
+
+func markAndCheck(engine *TradingEngine, price float64) {
+	markPrice(engine, price)
+	engine.manageRisk()
+	engine.drainQueues()
+}
+
+func portfolioBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		MaxLoss:  decimal.NewFromFloat(0.03),
+		Window:   15 * time.Minute,
+		Cooldown: 30 * time.Minute,
+	}
+}
+
+func TestTradingEngine_CircuitBreaker_HaltsOnFastLoss(t *testing.T) {
+	engine, fake, strategy := createBreakerEngine(t, portfolioBreakerConfig())
+	limit := createLimitOrder(models.OrderSideBuy, 10, 90.0)
+	engine.submitOrder(limit)
+	stop := createStopOrder(models.OrderSideSell, 500, 80.0, 80.0)
+	engine.submitOrder(stop)
+	engine.drainQueues()
+	require.Len(t, engine.GetOpenOrders(), 2)
+
+	fake.Advance(5 * time.Minute)
+	markAndCheck(engine, 97.5)
+	assert.False(t, engine.TradingStatus().Halted)
+
+	fake.Advance(5 * time.Minute)
+	markAndCheck(engine, 93.0)
+
+	status := engine.TradingStatus()
+	require.True(t, status.Halted)
+	assert.Equal(t, "loss_limit", status.Halt.Reason)
+	assert.True(t, decimal.NewFromFloat(0.035).Equal(status.Halt.Loss), status.Halt.Loss.String())
+	assert.Equal(t, fake.Now().Add(30*time.Minute), status.Halt.ResumeAt)
+	assert.Equal(t, models.OrderStatusCancelled, limit.Status)
+	assert.Equal(t, models.CancelReasonHalted, limit.CancelReason)
+	assert.Equal(t, models.OrderStatusPending, stop.Status)
+
+	engine.executeStrategies(context.Background())
+	assert.Zero(t, strategy.calls.Load())
+	buy := createTestOrder(models.OrderSideBuy, 10, 93.0)
+	engine.submitOrder(buy)
+	engine.drainQueues()
+	assert.Equal(t, models.OrderStatusRejected, buy.Status)
+
+	markAndCheck(engine, 79.0)
+	assert.Equal(t, models.OrderStatusFilled, stop.Status)
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+
+	events := engine.GetRiskEvents()
+	require.NotEmpty(t, events)
+	assert.Equal(t, models.RiskEventCircuitBreaker, events[len(events)-1].Type)
+}
+
+func TestTradingEngine_CircuitBreaker_IgnoresLossesOutsideWindow(t *testing.T) {
+	engine, fake, _ := createBreakerEngine(t, portfolioBreakerConfig())
+
+	for _, price := range []float64{99.0, 98.0, 97.0, 96.0, 95.0, 94.0} {
+		fake.Advance(10 * time.Minute)
+		markAndCheck(engine, price)
+	}
+
+	assert.False(t, engine.TradingStatus().Halted)
+}
+
+func TestTradingEngine_CircuitBreaker_ResumesAfterCooldown(t *testing.T) {
+	engine, fake, strategy := createBreakerEngine(t, portfolioBreakerConfig())
+	fake.Advance(time.Minute)
+	markAndCheck(engine, 93.0)
+	require.True(t, engine.TradingStatus().Halted)
+
+	fake.Advance(29 * time.Minute)
+	markAndCheck(engine, 93.0)
+	assert.True(t, engine.TradingStatus().Halted)
+
+	fake.Advance(time.Minute)
+	markAndCheck(engine, 93.0)
+	assert.False(t, engine.TradingStatus().Halted)
+	engine.executeStrategies(context.Background())
+	assert.Equal(t, int32(1), strategy.calls.Load())
+
+	events := engine.GetRiskEvents()
+	assert.Equal(t, models.RiskEventTradingResumed, events[len(events)-1].Type)
+}
+
+func TestTradingEngine_CircuitBreaker_ResumeTrading(t *testing.T) {
+	config := portfolioBreakerConfig()
+	config.Cooldown = 0
+	engine, fake, _ := createBreakerEngine(t, config)
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writer, err := audit.NewWriter(path)
+	require.NoError(t, err)
+	engine.SetAuditLog(writer)
+
+	fake.Advance(time.Minute)
+	markAndCheck(engine, 93.0)
+	fake.Advance(24 * time.Hour)
+	markAndCheck(engine, 93.0)
+	require.True(t, engine.TradingStatus().Halted)
+	assert.True(t, engine.TradingStatus().Halt.ResumeAt.IsZero())
+
+	engine.ResumeTrading()
+	assert.False(t, engine.TradingStatus().Halted)
+	buy := createTestOrder(models.OrderSideBuy, 10, 93.0)
+	engine.submitOrder(buy)
+	engine.drainQueues()
+	assert.Equal(t, models.OrderStatusFilled, buy.Status)
+
+	require.NoError(t, writer.Close())
+	records, err := audit.Read(path)
+	require.NoError(t, err)
+	var halts []audit.Record
+	for _, record := range records {
+		if record.Type == audit.EventTradingHalted || record.Type == audit.EventTradingResumed {
+			halts = append(halts, record)
+		}
+	}
+	require.Len(t, halts, 2)
+	assert.Equal(t, audit.EventTradingHalted, halts[0].Type)
+	assert.Equal(t, audit.EventTradingResumed, halts[1].Type)
+	assert.Equal(t, "requested", halts[1].Halt.Reason)
+}
+
+func TestTradingEngine_CircuitBreaker_HaltsOnlyOffendingStrategy(t *testing.T) {
+	engine, fake, strategy := createBreakerEngine(t, CircuitBreakerConfig{
+		StrategyMaxLoss: decimal.NewFromFloat(0.02),
+		Window:          15 * time.Minute,
+	})
+	limit := createLimitOrder(models.OrderSideBuy, 10, 90.0)
+	engine.submitOrder(limit)
+	engine.drainQueues()
+
+	fake.Advance(time.Minute)
+	markAndCheck(engine, 95.0)
+
+	status := engine.TradingStatus()
+	assert.False(t, status.Halted)
+	require.Len(t, status.Strategies, 1)
+	assert.Equal(t, "test_strategy", status.Strategies[0].StrategyID)
+	assert.Equal(t, models.CancelReasonHalted, limit.CancelReason)
+	engine.executeStrategies(context.Background())
+	assert.Equal(t, int32(1), strategy.calls.Load())
+
+	assert.ErrorIs(t, engine.ResumeStrategy("counting"), ErrNotHalted)
+	require.NoError(t, engine.ResumeStrategy("test_strategy"))
+	assert.Empty(t, engine.TradingStatus().Strategies)
+}
+
+func TestTradingEngine_SetCircuitBreaker_RejectsInvalid(t *testing.T) {
+	engine := createTestEngine()
+
+	assert.ErrorIs(t, engine.SetCircuitBreaker(CircuitBreakerConfig{MaxLoss: decimal.NewFromFloat(0.03)}), ErrInvalidCircuitBreaker)
+	assert.ErrorIs(t, engine.SetCircuitBreaker(CircuitBreakerConfig{MaxLoss: decimal.NewFromInt(1), Window: time.Minute}), ErrInvalidCircuitBreaker)
+	assert.ErrorIs(t, engine.SetCircuitBreaker(CircuitBreakerConfig{StrategyMaxLoss: decimal.NewFromFloat(0.02), Window: time.Minute, Cooldown: -time.Minute}), ErrInvalidCircuitBreaker)
+	assert.NoError(t, engine.SetCircuitBreaker(CircuitBreakerConfig{}))
+	assert.Nil(t, engine.breaker)
+}
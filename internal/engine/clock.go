@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+)
+
+const (
+	strategyInterval  = 5 * time.Second
+	riskInterval      = 10 * time.Second
+	portfolioInterval = 1 * time.Second
+	drainTimeout      = 5 * time.Second
+)
+
+type Intervals struct {
+	Strategy  time.Duration
+	Risk      time.Duration
+	Portfolio time.Duration
+	Debounce  time.Duration
+}
+
+func DefaultIntervals() Intervals {
+	return Intervals{
+		Strategy:  strategyInterval,
+		Risk:      riskInterval,
+		Portfolio: portfolioInterval,
+	}
+}
+
+func (i Intervals) eventDriven() bool {
+	return i.Strategy <= 0 || i.Risk <= 0 || i.Portfolio <= 0
+}
+
+type engineClock struct {
+	current      time.Time
+	lastStrategy time.Time
+	lastRisk     time.Time
+}
+
+type Option func(*TradingEngine)
+
+func WithClock(c clock.Clock) Option {
+	return func(e *TradingEngine) {
+		e.wallClock = c
+	}
+}
+
+func (e *TradingEngine) SetIntervals(intervals Intervals) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.intervals = intervals
+}
+
+func (c *engineClock) due(last *time.Time, interval time.Duration) bool {
+	if !last.IsZero() && c.current.Sub(*last) < interval {
+		return false
+	}
+	*last = c.current
+	return true
+}
+
+func (e *TradingEngine) now() time.Time {
+	if e.clock.current.IsZero() {
+		return e.wallClock.Now()
+	}
+	return e.clock.current
+}
+
+func (e *TradingEngine) Advance(ctx context.Context, now time.Time) {
+	e.mu.Lock()
+	e.clock.current = now
+	e.rollBooks()
+	e.bars.advance(now)
+	runStrategies := e.clock.due(&e.clock.lastStrategy, e.intervals.Strategy)
+	runRisk := e.clock.due(&e.clock.lastRisk, e.intervals.Risk)
+	e.executeDueFills()
+	e.expireDayOrders()
+	released := e.releaseSlices()
+	e.mu.Unlock()
+
+	for _, order := range released {
+		e.enqueueOrder(order)
+	}
+	e.drainQueues()
+	e.updatePortfolio()
+	if runStrategies {
+		e.executeStrategies(ctx)
+		e.drainQueues()
+		e.updatePortfolio()
+	}
+	if runRisk {
+		e.manageRisk()
+		e.updateRiskMetrics()
+	}
+}
+
+func (e *TradingEngine) drainQueues() {
+	_ = e.drain(context.Background())
+}
+
+func (e *TradingEngine) drain(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		select {
+		case order := <-e.orderQueue:
+			e.processOrder(order)
+		case trade := <-e.tradeQueue:
+			e.processTrade(trade)
+		default:
+			return nil
+		}
+	}
+}
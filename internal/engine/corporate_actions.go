@@ -0,0 +1,166 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const maxCorporateActions = 1000
+
+func (e *TradingEngine) ApplyCorporateAction(action models.CorporateAction) error {
+	switch action.Type {
+	case models.CorporateActionDividend:
+		if !action.Amount.IsPositive() {
+			return fmt.Errorf("%w: %s dividend of %s per share", ErrInvalidCorporateAction, action.Symbol, action.Amount)
+		}
+	case models.CorporateActionSplit:
+		if !action.Ratio.IsPositive() {
+			return fmt.Errorf("%w: %s split ratio %s", ErrInvalidCorporateAction, action.Symbol, action.Ratio)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidCorporateAction, action.Type)
+	}
+
+	e.mu.Lock()
+	if action.Timestamp.IsZero() {
+		action.Timestamp = e.now()
+	}
+	trade := e.applyCorporateAction(&action)
+	e.audit(audit.Record{Type: audit.EventCorporateAction, CorporateAction: &action, Trade: trade})
+	if trade != nil {
+		e.enqueueTrade(trade)
+	}
+	e.mu.Unlock()
+
+	e.logger.Info("Corporate action applied",
+		zap.String("symbol", action.Symbol),
+		zap.String("type", string(action.Type)),
+		zap.String("amount", action.Amount.String()),
+		zap.String("ratio", action.Ratio.String()),
+		zap.String("quantity", action.Quantity.String()),
+		zap.String("cash", action.Cash.String()))
+	return nil
+}
+
+func (e *TradingEngine) applyCorporateAction(action *models.CorporateAction) *models.Trade {
+	var trade *models.Trade
+	if action.Type == models.CorporateActionDividend {
+		e.payDividend(action)
+	} else {
+		trade = e.applySplit(action)
+	}
+	updateMargin(e.portfolio)
+
+	e.portfolio.CorporateActions = append(e.portfolio.CorporateActions, *action)
+	if len(e.portfolio.CorporateActions) > maxCorporateActions {
+		e.portfolio.CorporateActions = e.portfolio.CorporateActions[len(e.portfolio.CorporateActions)-maxCorporateActions:]
+	}
+	return trade
+}
+
+func (e *TradingEngine) payDividend(action *models.CorporateAction) {
+	if position, exists := e.portfolio.Positions[action.Symbol]; exists {
+		action.Quantity = position.Quantity
+	}
+	action.Cash = action.Quantity.Mul(action.Amount)
+	currency := e.currencyOf(action.Symbol)
+	income := e.toBase(currency, action.Cash)
+	adjustCash(e.portfolio, e.currency.base, currency, action.Cash)
+	e.portfolio.DividendIncome = e.portfolio.DividendIncome.Add(income)
+	e.attributeDividend(action.Symbol, income)
+
+	for _, sleeve := range e.allocations {
+		if position, exists := sleeve.portfolio.Positions[action.Symbol]; exists {
+			sleeve.portfolio.Cash = sleeve.portfolio.Cash.Add(e.toBase(currency, position.Quantity.Mul(action.Amount)))
+		}
+	}
+}
+
+func (e *TradingEngine) applySplit(action *models.CorporateAction) *models.Trade {
+	symbol, ratio := action.Symbol, action.Ratio
+	if data, exists := e.marketData[symbol]; exists {
+		e.marketData[symbol] = data.SplitAdjusted(ratio)
+	}
+	e.history.split(symbol, ratio)
+	e.bars.split(symbol, ratio)
+	e.splitHoldings(symbol, ratio)
+	if !action.Price.IsPositive() {
+		if data, exists := e.marketData[symbol]; exists && data.Price.IsPositive() {
+			action.Price = data.Price
+		} else if position, exists := e.portfolio.Positions[symbol]; exists {
+			action.Price = position.CurrentPrice.Div(ratio)
+		}
+	}
+	e.book.reprice(symbol, func(order *models.Order) {
+		order.Price = order.Price.Div(ratio)
+		order.StopPrice = order.StopPrice.Div(ratio)
+		order.Quantity = e.symbols.RoundDown(symbol, order.Quantity.Mul(ratio))
+	})
+
+	for _, sleeve := range e.allocations {
+		if residue := e.splitPosition(sleeve.portfolio, symbol, ratio); !residue.IsZero() {
+			e.applyFill(sleeve.portfolio, symbol, sleeve.portfolio.Positions[symbol].StrategyID, residue.Neg(), action.Price, decimal.Zero)
+		}
+	}
+
+	position, exists := e.portfolio.Positions[symbol]
+	if !exists {
+		return nil
+	}
+	action.Quantity = position.Quantity
+	residue := e.splitPosition(e.portfolio, symbol, ratio)
+	if residue.IsZero() {
+		return nil
+	}
+	price := action.Price
+
+	side := models.OrderSideSell
+	if residue.IsNegative() {
+		side = models.OrderSideBuy
+	}
+	trade := &models.Trade{
+		ID:             e.newTradeID(),
+		Symbol:         symbol,
+		Side:           side,
+		Quantity:       residue.Abs(),
+		Price:          price,
+		RequestedPrice: price,
+		Timestamp:      action.Timestamp,
+		StrategyID:     position.StrategyID,
+		ExitReason:     models.ExitReasonCashInLieu,
+	}
+	trade.RealizedPnL, trade.ClosedLots = e.applyFill(e.portfolio, symbol, position.StrategyID, residue.Neg(), price, decimal.Zero)
+	e.recordStrategyPnL(position.StrategyID, e.toBase(e.currencyOf(symbol), trade.RealizedPnL))
+	action.Cash = residue.Mul(price)
+	return trade
+}
+
+func (e *TradingEngine) splitPosition(portfolio *models.Portfolio, symbol string, ratio decimal.Decimal) decimal.Decimal {
+	position, exists := portfolio.Positions[symbol]
+	if !exists {
+		return decimal.Zero
+	}
+
+	position.Quantity = position.Quantity.Mul(ratio)
+	position.AveragePrice = position.AveragePrice.Div(ratio)
+	position.CurrentPrice = position.CurrentPrice.Div(ratio)
+	position.PeakPrice = position.PeakPrice.Div(ratio)
+	position.TroughPrice = position.TroughPrice.Div(ratio)
+	position.StopPrice = position.StopPrice.Div(ratio)
+	e.markPosition(position, position.CurrentPrice)
+	for i := range position.Lots {
+		position.Lots[i].Quantity = position.Lots[i].Quantity.Mul(ratio)
+		position.Lots[i].Price = position.Lots[i].Price.Div(ratio)
+	}
+
+	whole := e.symbols.RoundDown(symbol, position.Quantity.Abs())
+	residue := position.Quantity.Abs().Sub(whole)
+	if position.Quantity.IsNegative() {
+		residue = residue.Neg()
+	}
+	return residue
+}
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_ApplyCorporateAction_SplitAdjustsPosition(t *testing.T) {
+	engine := createAttributionEngine(0)
+	for _, price := range []float64{196.0, 198.0, 202.0, 199.0} {
+		engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", price))
+	}
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideBuy, 100, 200.0)
+	markPrices(engine, map[string]float64{"AAPL": 200.0})
+	before := engine.GetPortfolio()
+	atrBefore, ok := indicators.ATR(engine.history.get("AAPL", 0), 3)
+	require.True(t, ok)
+
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{
+		Symbol: "AAPL",
+		Type:   models.CorporateActionSplit,
+		Ratio:  decimal.NewFromInt(2),
+	}))
+	assert.Empty(t, engine.tradeQueue)
+
+	portfolio := engine.GetPortfolio()
+	position := portfolio.Positions["AAPL"]
+	assert.True(t, decimal.NewFromInt(200).Equal(position.Quantity))
+	assert.True(t, decimal.NewFromInt(100).Equal(position.AveragePrice))
+	assert.True(t, decimal.NewFromInt(100).Equal(position.CurrentPrice))
+	assert.True(t, before.Positions["AAPL"].MarketValue.Equal(position.MarketValue))
+	assert.True(t, before.TotalValue.Equal(portfolio.TotalValue))
+	assert.True(t, decimal.NewFromInt(200).Equal(engine.attribution["alpha"].Holdings["AAPL"]))
+
+	require.Len(t, portfolio.CorporateActions, 1)
+	assert.True(t, decimal.NewFromInt(100).Equal(portfolio.CorporateActions[0].Quantity))
+	assert.False(t, portfolio.CorporateActions[0].Timestamp.IsZero())
+
+	markPrices(engine, map[string]float64{"AAPL": 101.0})
+	history := historyPrices(engine.history.get("AAPL", 0))
+	assert.Equal(t, []float64{98, 99, 101, 99.5, 100, 101}, history)
+	atrAfter, ok := indicators.ATR(engine.history.get("AAPL", 0), 3)
+	require.True(t, ok)
+	assert.InDelta(t, atrBefore.InexactFloat64()/2, atrAfter.InexactFloat64(), 1.0)
+
+	position = engine.GetPortfolio().Positions["AAPL"]
+	assert.True(t, decimal.NewFromInt(200).Equal(position.UnrealizedPnL))
+}
+
+func TestTradingEngine_ApplyCorporateAction_SplitPaysCashInLieu(t *testing.T) {
+	engine := createAttributionEngine(0)
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideBuy, 101, 150.0)
+	markPrices(engine, map[string]float64{"AAPL": 150.0})
+	before := engine.GetPortfolio()
+
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{
+		Symbol: "AAPL",
+		Type:   models.CorporateActionSplit,
+		Ratio:  decimal.NewFromFloat(1.5),
+	}))
+
+	require.Len(t, engine.tradeQueue, 1)
+	trade := <-engine.tradeQueue
+	assert.Equal(t, models.ExitReasonCashInLieu, trade.ExitReason)
+	assert.Equal(t, models.OrderSideSell, trade.Side)
+	assert.True(t, decimal.NewFromFloat(0.5).Equal(trade.Quantity))
+	assert.True(t, decimal.NewFromInt(100).Equal(trade.Price))
+	assert.True(t, trade.RealizedPnL.IsZero())
+
+	portfolio := engine.GetPortfolio()
+	assert.True(t, decimal.NewFromInt(151).Equal(portfolio.Positions["AAPL"].Quantity))
+	assert.True(t, before.Cash.Add(decimal.NewFromInt(50)).Equal(portfolio.Cash))
+	assert.True(t, before.TotalValue.Equal(portfolio.TotalValue))
+	assert.True(t, decimal.NewFromInt(50).Equal(portfolio.CorporateActions[0].Cash))
+}
+
+func TestTradingEngine_ApplyCorporateAction_FractionalLotsKeepResidue(t *testing.T) {
+	registry := symbols.NewRegistry()
+	require.NoError(t, registry.Register(models.Instrument{Symbol: "AAPL", LotSize: decimal.NewFromFloat(0.1)}))
+	engine := createAttributionEngine(0)
+	engine.SetSymbols(registry)
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideBuy, 101, 150.0)
+
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{
+		Symbol: "AAPL",
+		Type:   models.CorporateActionSplit,
+		Ratio:  decimal.NewFromFloat(1.5),
+	}))
+
+	assert.Empty(t, engine.tradeQueue)
+	assert.True(t, decimal.NewFromFloat(151.5).Equal(engine.GetPortfolio().Positions["AAPL"].Quantity))
+}
+
+func TestTradingEngine_ApplyCorporateAction_SplitAdjustsRestingOrders(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 200.0))
+	order := createTestOrder(models.OrderSideBuy, 15, 180.0)
+	order.Type = models.OrderTypeLimit
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	require.Contains(t, engine.book.orders, order.ID)
+
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{
+		Symbol: "AAPL",
+		Type:   models.CorporateActionSplit,
+		Ratio:  decimal.NewFromInt(2),
+	}))
+
+	assert.True(t, decimal.NewFromInt(90).Equal(order.Price))
+	assert.True(t, decimal.NewFromInt(30).Equal(order.Quantity))
+}
+
+func TestTradingEngine_ApplyCorporateAction_Dividend(t *testing.T) {
+	engine := createAttributionEngine(0)
+	attributedFill(engine, "alpha", "AAPL", models.OrderSideBuy, 100, 150.0)
+	before := engine.GetPortfolio()
+
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{
+		Symbol: "AAPL",
+		Type:   models.CorporateActionDividend,
+		Amount: decimal.NewFromFloat(0.25),
+	}))
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{
+		Symbol: "MSFT",
+		Type:   models.CorporateActionDividend,
+		Amount: decimal.NewFromInt(1),
+	}))
+
+	portfolio := engine.GetPortfolio()
+	assert.True(t, before.Cash.Add(decimal.NewFromInt(25)).Equal(portfolio.Cash))
+	assert.True(t, decimal.NewFromInt(25).Equal(portfolio.DividendIncome))
+	require.Len(t, portfolio.CorporateActions, 2)
+	assert.True(t, decimal.NewFromInt(25).Equal(portfolio.CorporateActions[0].Cash))
+	assert.True(t, portfolio.CorporateActions[1].Cash.IsZero())
+
+	performance := engine.GetStrategyPerformance()["alpha"]
+	assert.True(t, decimal.NewFromInt(25).Equal(performance.Dividends))
+	assert.True(t, decimal.NewFromInt(25).Equal(performance.TotalPnL))
+}
+
+func TestTradingEngine_ApplyCorporateAction_Invalid(t *testing.T) {
+	engine := createTestEngine()
+
+	assert.ErrorIs(t, engine.ApplyCorporateAction(models.CorporateAction{Symbol: "AAPL", Type: models.CorporateActionSplit}), ErrInvalidCorporateAction)
+	assert.ErrorIs(t, engine.ApplyCorporateAction(models.CorporateAction{Symbol: "AAPL", Type: models.CorporateActionDividend, Amount: decimal.NewFromInt(-1)}), ErrInvalidCorporateAction)
+	assert.ErrorIs(t, engine.ApplyCorporateAction(models.CorporateAction{Symbol: "AAPL", Type: "merger"}), ErrInvalidCorporateAction)
+	assert.Empty(t, engine.GetPortfolio().CorporateActions)
+}
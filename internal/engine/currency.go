@@ -0,0 +1,236 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultBaseCurrency = "USD"
+	fxConversionReason  = "fx_conversion"
+)
+
+type CurrencyConfig struct {
+	Base        string
+	Rates       map[string]decimal.Decimal
+	Cash        map[string]decimal.Decimal
+	AutoConvert bool
+}
+
+type currencyBook struct {
+	base        string
+	rates       map[string]decimal.Decimal
+	last        map[string]decimal.Decimal
+	autoConvert bool
+}
+
+func newCurrencyBook() *currencyBook {
+	return &currencyBook{base: defaultBaseCurrency, rates: map[string]decimal.Decimal{}, last: map[string]decimal.Decimal{}}
+}
+
+func (e *TradingEngine) SetCurrencies(config CurrencyConfig) error {
+	base := config.Base
+	if base == "" {
+		base = defaultBaseCurrency
+	}
+	for currency, rate := range config.Rates {
+		if currency == "" || !rate.IsPositive() {
+			return fmt.Errorf("%w: rate %s for %q must be positive", ErrInvalidCurrencies, rate, currency)
+		}
+	}
+	for currency, balance := range config.Cash {
+		if currency == base || currency == "" || balance.IsNegative() {
+			return fmt.Errorf("%w: cash balance %s in %q must be a non-negative foreign balance", ErrInvalidCurrencies, balance, currency)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.portfolio.BaseCurrency != "" && e.portfolio.BaseCurrency != base {
+		return fmt.Errorf("%w: portfolio is kept in %s, not %s", ErrInvalidCurrencies, e.portfolio.BaseCurrency, base)
+	}
+	e.currency.base = base
+	e.currency.rates = make(map[string]decimal.Decimal, len(config.Rates))
+	for currency, rate := range config.Rates {
+		e.currency.rates[currency] = rate
+	}
+	e.currency.autoConvert = config.AutoConvert
+	e.portfolio.BaseCurrency = base
+
+	for currency, balance := range config.Cash {
+		if _, held := e.portfolio.CashBalances[currency]; held || balance.IsZero() {
+			continue
+		}
+		adjustCash(e.portfolio, base, currency, balance)
+		if rate, ok := e.fxRate(currency); ok {
+			e.portfolio.TotalValue = e.portfolio.TotalValue.Add(balance.Mul(rate))
+		}
+	}
+	return nil
+}
+
+func (e *TradingEngine) BaseCurrency() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.currency.base
+}
+
+func (e *TradingEngine) currencyOf(symbol string) string {
+	return e.symbols.Instrument(symbol).Currency
+}
+
+func (e *TradingEngine) foreign(currency string) bool {
+	return currency != "" && currency != e.currency.base
+}
+
+func (e *TradingEngine) fxRate(currency string) (decimal.Decimal, bool) {
+	if !e.foreign(currency) {
+		return decimal.NewFromInt(1), true
+	}
+	base := e.currency.base
+	rate := decimal.Zero
+	if data, exists := e.marketData[currency+base]; exists && data.Price.IsPositive() {
+		rate = data.Price
+	} else if data, exists := e.marketData[base+currency]; exists && data.Price.IsPositive() {
+		rate = decimal.NewFromInt(1).Div(data.Price)
+	} else if configured, exists := e.currency.rates[currency]; exists {
+		rate = configured
+	}
+	if rate.IsPositive() {
+		e.currency.last[currency] = rate
+		return rate, true
+	}
+	last, known := e.currency.last[currency]
+	return last, known
+}
+
+func (e *TradingEngine) checkFXRate(order *models.Order) error {
+	currency := e.currencyOf(order.Symbol)
+	if _, ok := e.fxRate(currency); !ok {
+		return fmt.Errorf("%w: %s to %s for %s", ErrNoFXRate, currency, e.currency.base, order.Symbol)
+	}
+	return nil
+}
+
+func (e *TradingEngine) currencyCash(portfolio *models.Portfolio, currency string) decimal.Decimal {
+	if !e.foreign(currency) {
+		return portfolio.Cash
+	}
+	balance := portfolio.CashBalances[currency]
+	if rate, ok := e.fxRate(currency); ok && e.currency.autoConvert && portfolio.Cash.IsPositive() {
+		balance = balance.Add(portfolio.Cash.Div(rate))
+	}
+	return balance
+}
+
+func adjustCash(portfolio *models.Portfolio, base, currency string, amount decimal.Decimal) {
+	if currency == "" || currency == base {
+		portfolio.Cash = portfolio.Cash.Add(amount)
+		return
+	}
+	if portfolio.CashBalances == nil {
+		portfolio.CashBalances = make(map[string]decimal.Decimal)
+	}
+	portfolio.CashBalances[currency] = portfolio.CashBalances[currency].Add(amount)
+}
+
+func (e *TradingEngine) toBase(currency string, amount decimal.Decimal) decimal.Decimal {
+	if !e.foreign(currency) {
+		return amount
+	}
+	rate, _ := e.fxRate(currency)
+	return amount.Mul(rate)
+}
+
+func (e *TradingEngine) settleFill(portfolio *models.Portfolio, symbol, strategyID string, amount decimal.Decimal) {
+	currency := e.currencyOf(symbol)
+	if portfolio != e.portfolio {
+		portfolio.Cash = portfolio.Cash.Add(e.toBase(currency, amount))
+		return
+	}
+	adjustCash(portfolio, e.currency.base, currency, amount)
+	if !e.foreign(currency) || !e.currency.autoConvert {
+		return
+	}
+	shortfall := portfolio.CashBalances[currency].Neg()
+	rate, ok := e.fxRate(currency)
+	if !shortfall.IsPositive() || !ok {
+		return
+	}
+
+	cost := shortfall.Mul(rate)
+	portfolio.Cash = portfolio.Cash.Sub(cost)
+	portfolio.CashBalances[currency] = decimal.Zero
+
+	trade := &models.Trade{
+		ID:         e.newTradeID(),
+		Symbol:     currency + e.currency.base,
+		Side:       models.OrderSideBuy,
+		Quantity:   shortfall,
+		Price:      rate,
+		Timestamp:  e.now(),
+		StrategyID: strategyID,
+		Reason:     fxConversionReason,
+	}
+	e.logger.Info("Converted base currency to fund fill",
+		zap.String("currency", currency),
+		zap.String("amount", shortfall.String()),
+		zap.String("rate", rate.String()),
+		zap.String("cost", cost.String()))
+	e.enqueueTrade(trade)
+}
+
+func (e *TradingEngine) cashValue(portfolio *models.Portfolio) decimal.Decimal {
+	value := portfolio.Cash
+	for currency, balance := range portfolio.CashBalances {
+		if rate, ok := e.fxRate(currency); ok {
+			value = value.Add(balance.Mul(rate))
+		}
+	}
+	return value
+}
+
+func (e *TradingEngine) bookEntryRate(position *models.Position, quantity, price decimal.Decimal) {
+	currency := e.currencyOf(position.Symbol)
+	if !e.foreign(currency) {
+		position.FX = nil
+		return
+	}
+	rate, _ := e.fxRate(currency)
+	fx := models.PositionFX{Currency: currency, Rate: rate, EntryRate: rate}
+	if position.FX != nil && !position.Quantity.IsZero() && position.Quantity.IsPositive() == quantity.IsPositive() {
+		held := position.AveragePrice.Mul(position.Quantity.Abs())
+		added := price.Mul(quantity.Abs())
+		if total := held.Add(added); total.IsPositive() {
+			fx.EntryRate = position.FX.EntryRate.Mul(held).Add(rate.Mul(added)).Div(total)
+		}
+	} else if position.FX != nil && !position.Quantity.IsZero() && position.Quantity.Add(quantity).IsPositive() == position.Quantity.IsPositive() {
+		fx.EntryRate = position.FX.EntryRate
+	}
+	position.FX = &fx
+}
+
+func (e *TradingEngine) markPosition(position *models.Position, price decimal.Decimal) {
+	marketValue := price.Mul(position.Quantity)
+	unrealized := price.Sub(position.AveragePrice).Mul(position.Quantity)
+	if position.FX == nil {
+		position.MarketValue = marketValue
+		position.UnrealizedPnL = unrealized
+		return
+	}
+
+	fx := *position.FX
+	if rate, ok := e.fxRate(fx.Currency); ok {
+		fx.Rate = rate
+	}
+	fx.MarketValue = marketValue
+	fx.UnrealizedPnL = unrealized
+	fx.PricePnL = unrealized.Mul(fx.Rate)
+	fx.FXPnL = position.AveragePrice.Mul(position.Quantity).Mul(fx.Rate.Sub(fx.EntryRate))
+	position.FX = &fx
+	position.MarketValue = marketValue.Mul(fx.Rate)
+	position.UnrealizedPnL = fx.PricePnL.Add(fx.FXPnL)
+}
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createCurrencyEngine(t *testing.T, config CurrencyConfig) *TradingEngine {
+	t.Helper()
+	registry := symbols.NewRegistry()
+	sap := symbols.Default("SAP")
+	sap.Currency = "EUR"
+	require.NoError(t, registry.Register(sap))
+	engine := createTestEngine()
+	engine.SetSymbols(registry)
+	require.NoError(t, engine.SetCurrencies(config))
+	return engine
+}
+
+func createForeignOrder(side models.OrderSide, quantity int64, price float64) *models.Order {
+	order := createTestOrder(side, quantity, price)
+	order.Symbol = "SAP"
+	return order
+}
+
+func TestTradingEngine_ForeignPosition_SplitsPnLIntoPriceAndFX(t *testing.T) {
+	engine := createCurrencyEngine(t, CurrencyConfig{AutoConvert: true})
+	engine.UpdateMarketData("EURUSD", createTestMarketData("EURUSD", 1.1))
+	engine.UpdateMarketData("SAP", createTestMarketData("SAP", 100.0))
+
+	engine.submitOrder(createForeignOrder(models.OrderSideBuy, 10, 100.0))
+	engine.drainQueues()
+
+	portfolio := engine.GetPortfolio()
+	require.Len(t, portfolio.TradeHistory, 2)
+	conversion := portfolio.TradeHistory[0]
+	assert.Equal(t, "EURUSD", conversion.Symbol)
+	assert.Equal(t, fxConversionReason, conversion.Reason)
+	assert.True(t, decimal.NewFromInt(1001).Equal(conversion.Quantity))
+	assert.True(t, decimal.NewFromFloat(1.1).Equal(conversion.Price))
+	assert.Equal(t, "SAP", portfolio.TradeHistory[1].Symbol)
+	assert.True(t, decimal.NewFromFloat(98898.9).Equal(portfolio.Cash))
+	assert.True(t, portfolio.CashBalances["EUR"].IsZero())
+
+	engine.UpdateMarketData("SAP", createTestMarketData("SAP", 110.0))
+	engine.UpdateMarketData("EURUSD", createTestMarketData("EURUSD", 1.2))
+	engine.updatePortfolio()
+
+	position := engine.GetPortfolio().Positions["SAP"]
+	require.NotNil(t, position.FX)
+	assert.Equal(t, "EUR", position.FX.Currency)
+	assert.True(t, decimal.NewFromFloat(1.1).Equal(position.FX.EntryRate))
+	assert.True(t, decimal.NewFromFloat(1.2).Equal(position.FX.Rate))
+	assert.True(t, decimal.NewFromInt(1100).Equal(position.FX.MarketValue))
+	assert.True(t, decimal.NewFromInt(100).Equal(position.FX.UnrealizedPnL))
+	assert.True(t, decimal.NewFromInt(120).Equal(position.FX.PricePnL))
+	assert.True(t, decimal.NewFromInt(100).Equal(position.FX.FXPnL))
+	assert.True(t, decimal.NewFromInt(1320).Equal(position.MarketValue))
+	assert.True(t, position.FX.PricePnL.Add(position.FX.FXPnL).Equal(position.UnrealizedPnL))
+	assert.True(t, decimal.NewFromFloat(100218.9).Equal(engine.GetPortfolio().TotalValue))
+	assert.Equal(t, "USD", engine.GetPortfolioSummary().BaseCurrency)
+}
+
+func TestTradingEngine_ForeignOrder_SettlesFromForeignBalance(t *testing.T) {
+	engine := createCurrencyEngine(t, CurrencyConfig{Cash: map[string]decimal.Decimal{"EUR": decimal.NewFromInt(2000)}})
+	engine.UpdateMarketData("EURUSD", createTestMarketData("EURUSD", 1.1))
+	engine.UpdateMarketData("SAP", createTestMarketData("SAP", 100.0))
+
+	engine.submitOrder(createForeignOrder(models.OrderSideBuy, 10, 100.0))
+	engine.drainQueues()
+
+	portfolio := engine.GetPortfolio()
+	require.Len(t, portfolio.TradeHistory, 1)
+	assert.True(t, decimal.NewFromInt(100000).Equal(portfolio.Cash))
+	assert.True(t, decimal.NewFromInt(999).Equal(portfolio.CashBalances["EUR"]))
+	assert.True(t, decimal.NewFromInt(10).Equal(portfolio.Positions["SAP"].Quantity))
+}
+
+func TestTradingEngine_ForeignOrder_RejectedWithoutForeignCash(t *testing.T) {
+	engine := createCurrencyEngine(t, CurrencyConfig{})
+	engine.UpdateMarketData("EURUSD", createTestMarketData("EURUSD", 1.1))
+	engine.UpdateMarketData("SAP", createTestMarketData("SAP", 100.0))
+
+	order := createForeignOrder(models.OrderSideBuy, 10, 100.0)
+	engine.submitOrder(order)
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusRejected, order.Status)
+	assert.Empty(t, engine.GetPortfolio().TradeHistory)
+	assert.True(t, decimal.NewFromInt(100000).Equal(engine.GetPortfolio().Cash))
+}
+
+func TestTradingEngine_ForeignOrder_RejectedWithoutRate(t *testing.T) {
+	engine := createCurrencyEngine(t, CurrencyConfig{AutoConvert: true})
+	engine.UpdateMarketData("SAP", createTestMarketData("SAP", 100.0))
+
+	order := createForeignOrder(models.OrderSideBuy, 10, 100.0)
+	assert.ErrorIs(t, engine.checkFXRate(order), ErrNoFXRate)
+	engine.submitOrder(order)
+	engine.drainQueues()
+	assert.Equal(t, models.OrderStatusRejected, order.Status)
+
+	require.NoError(t, engine.SetCurrencies(CurrencyConfig{AutoConvert: true, Rates: map[string]decimal.Decimal{"EUR": decimal.NewFromFloat(1.05)}}))
+	assert.NoError(t, engine.checkFXRate(order))
+}
+
+func TestTradingEngine_SetCurrencies_Validates(t *testing.T) {
+	engine := createTestEngine()
+
+	for _, config := range []CurrencyConfig{
+		{Rates: map[string]decimal.Decimal{"EUR": decimal.Zero}},
+		{Cash: map[string]decimal.Decimal{"USD": decimal.NewFromInt(10)}},
+		{Cash: map[string]decimal.Decimal{"EUR": decimal.NewFromInt(-10)}},
+	} {
+		assert.ErrorIs(t, engine.SetCurrencies(config), ErrInvalidCurrencies, config)
+	}
+
+	require.NoError(t, engine.SetCurrencies(CurrencyConfig{}))
+	assert.ErrorIs(t, engine.SetCurrencies(CurrencyConfig{Base: "EUR"}), ErrInvalidCurrencies)
+	assert.Equal(t, "USD", engine.BaseCurrency())
+}
+
+func TestTradingEngine_ForeignDividend_CreditsForeignBalance(t *testing.T) {
+	engine := createCurrencyEngine(t, CurrencyConfig{Cash: map[string]decimal.Decimal{"EUR": decimal.NewFromInt(2000)}})
+	engine.UpdateMarketData("EURUSD", createTestMarketData("EURUSD", 1.1))
+	engine.UpdateMarketData("SAP", createTestMarketData("SAP", 100.0))
+	engine.submitOrder(createForeignOrder(models.OrderSideBuy, 10, 100.0))
+	engine.drainQueues()
+
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{
+		Symbol: "SAP",
+		Type:   models.CorporateActionDividend,
+		Amount: decimal.NewFromInt(2),
+	}))
+
+	portfolio := engine.GetPortfolio()
+	assert.True(t, decimal.NewFromInt(100000).Equal(portfolio.Cash), portfolio.Cash.String())
+	assert.True(t, decimal.NewFromInt(1019).Equal(portfolio.CashBalances["EUR"]), portfolio.CashBalances["EUR"].String())
+	assert.True(t, decimal.NewFromInt(22).Equal(portfolio.DividendIncome), portfolio.DividendIncome.String())
+	assert.True(t, decimal.NewFromInt(20).Equal(portfolio.CorporateActions[0].Cash))
+	assert.True(t, decimal.NewFromInt(22).Equal(engine.GetStrategyPerformance()["test_strategy"].Dividends))
+}
+
+func TestTradingEngine_ForeignSplit_RecordsStrategyPnLInBase(t *testing.T) {
+	engine := createCurrencyEngine(t, CurrencyConfig{Cash: map[string]decimal.Decimal{"EUR": decimal.NewFromInt(2000)}})
+	engine.UpdateMarketData("EURUSD", createTestMarketData("EURUSD", 1.1))
+	engine.UpdateMarketData("SAP", createTestMarketData("SAP", 100.0))
+	engine.submitOrder(createForeignOrder(models.OrderSideBuy, 11, 100.0))
+	engine.drainQueues()
+	engine.UpdateMarketData("SAP", createTestMarketData("SAP", 120.0))
+
+	require.NoError(t, engine.ApplyCorporateAction(models.CorporateAction{
+		Symbol: "SAP",
+		Type:   models.CorporateActionSplit,
+		Ratio:  decimal.NewFromFloat(1.5),
+	}))
+
+	require.Len(t, engine.tradeQueue, 1)
+	trade := <-engine.tradeQueue
+	require.True(t, trade.RealizedPnL.IsPositive())
+	assert.True(t, trade.RealizedPnL.Mul(decimal.NewFromFloat(1.1)).Equal(engine.drawdowns["test_strategy"].realized), engine.drawdowns["test_strategy"].realized.String())
+}
@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type StatementSink interface {
+	WriteStatement(statement models.DailyStatement) error
+}
+
+type dailyBooks struct {
+	open       *models.DailyStatement
+	statements []models.DailyStatement
+	realized   decimal.Decimal
+	unrealized decimal.Decimal
+	peak       decimal.Decimal
+}
+
+func (e *TradingEngine) SetStatementSink(sink StatementSink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.statementSink = sink
+}
+
+func (e *TradingEngine) GetDailyStatements() []models.DailyStatement {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]models.DailyStatement(nil), e.books.statements...)
+}
+
+func (e *TradingEngine) CloseBooks() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.books.open != nil {
+		e.closeDay(true)
+	}
+}
+
+func (e *TradingEngine) bookDay(t time.Time) time.Time {
+	local := t.In(e.calendar.Location())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+}
+
+func (e *TradingEngine) rollBooks() {
+	today := e.bookDay(e.now())
+	if e.books.open == nil {
+		e.openDay(today, true)
+		return
+	}
+	if !today.After(e.books.open.Date) {
+		return
+	}
+
+	day := e.books.open.Date
+	e.closeDay(false)
+	for day = nextDay(day); day.Before(today); day = nextDay(day) {
+		if e.calendar.TradingDay(day) {
+			e.openDay(day, false)
+			e.closeDay(false)
+		}
+	}
+	e.openDay(today, false)
+	clear(e.dailyOrders)
+}
+
+func nextDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, day.Location())
+}
+
+func (e *TradingEngine) openDay(day time.Time, partial bool) {
+	value := e.portfolio.TotalValue
+	e.books.open = &models.DailyStatement{
+		Date:           day,
+		Partial:        partial,
+		StartingEquity: value,
+		EndingEquity:   value,
+	}
+	e.books.realized = e.portfolio.RealizedPnL
+	e.books.unrealized = e.portfolio.UnrealizedPnL
+	e.books.peak = value
+}
+
+func (e *TradingEngine) markBooks(value decimal.Decimal) {
+	open := e.books.open
+	if open == nil {
+		return
+	}
+	open.EndingEquity = value
+	e.books.peak = decimal.Max(e.books.peak, value)
+	if !e.books.peak.IsPositive() {
+		return
+	}
+	if drawdown := e.books.peak.Sub(value).Div(e.books.peak); drawdown.GreaterThan(open.MaxDrawdown) {
+		open.MaxDrawdown = drawdown
+	}
+}
+
+func (e *TradingEngine) bookFill(trade *models.Trade) {
+	e.rollBooks()
+	e.books.open.Trades++
+	e.books.open.Commission = e.books.open.Commission.Add(trade.Commission)
+}
+
+func (e *TradingEngine) currentStatement() *models.DailyStatement {
+	if e.books.open == nil {
+		return nil
+	}
+	statement := *e.books.open
+	statement.RealizedPnL = e.portfolio.RealizedPnL.Sub(e.books.realized)
+	statement.UnrealizedChange = e.portfolio.UnrealizedPnL.Sub(e.books.unrealized)
+	if statement.StartingEquity.IsPositive() {
+		statement.Return = statement.EndingEquity.Div(statement.StartingEquity).Sub(decimal.NewFromInt(1))
+	}
+	return &statement
+}
+
+func (e *TradingEngine) restoreBooks(statements []models.DailyStatement, open *models.DailyStatement) {
+	e.books.statements = append([]models.DailyStatement(nil), statements...)
+	if open == nil {
+		return
+	}
+	restored := *open
+	e.books.open = &restored
+	e.books.realized = e.portfolio.RealizedPnL.Sub(open.RealizedPnL)
+	e.books.unrealized = e.portfolio.UnrealizedPnL.Sub(open.UnrealizedChange)
+	e.books.peak = decimal.Max(open.StartingEquity, open.EndingEquity)
+}
+
+func (e *TradingEngine) closeDay(partial bool) {
+	e.markBooks(e.portfolio.TotalValue)
+	statement := *e.currentStatement()
+	statement.Partial = statement.Partial || partial
+	e.books.open = nil
+	e.books.statements = append(e.books.statements, statement)
+
+	e.logger.Info("Daily statement closed",
+		zap.Time("date", statement.Date),
+		zap.Bool("partial", statement.Partial),
+		zap.String("ending_equity", statement.EndingEquity.String()),
+		zap.String("return", statement.Return.String()),
+		zap.Int("trades", statement.Trades))
+	if e.statementSink == nil {
+		return
+	}
+	if err := e.statementSink.WriteStatement(statement); err != nil {
+		e.logger.Error("Failed to write daily statement", zap.Time("date", statement.Date), zap.Error(err))
+	}
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var statementDay = time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+type recordingStatementSink struct {
+	statements []models.DailyStatement
+}
+
+func (s *recordingStatementSink) WriteStatement(statement models.DailyStatement) error {
+	s.statements = append(s.statements, statement)
+	return nil
+}
+
+func tradeAt(engine *TradingEngine, at time.Time, price float64, order *models.Order) {
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", price))
+	engine.Advance(context.Background(), at)
+	if order != nil {
+		engine.submitOrder(order)
+		engine.Advance(context.Background(), at.Add(time.Minute))
+	}
+}
+
+func TestTradingEngine_DailyStatements_ReconcileWithPortfolio(t *testing.T) {
+	engine := createTestEngine()
+	sink := &recordingStatementSink{}
+	engine.SetStatementSink(sink)
+
+	dayOne, dayTwo, dayThree := statementDay.Add(15*time.Hour), statementDay.Add(39*time.Hour), statementDay.Add(63*time.Hour)
+	tradeAt(engine, dayOne, 100.0, createTestOrder(models.OrderSideBuy, 100, 100.0))
+	tradeAt(engine, dayOne.Add(time.Hour), 95.0, nil)
+	tradeAt(engine, dayOne.Add(2*time.Hour), 98.0, nil)
+	tradeAt(engine, dayTwo, 110.0, nil)
+	tradeAt(engine, dayThree, 105.0, createTestOrder(models.OrderSideSell, 50, 105.0))
+	engine.CloseBooks()
+
+	statements := engine.GetDailyStatements()
+	require.Len(t, statements, 3)
+	assert.Equal(t, statements, sink.statements)
+	for i, statement := range statements {
+		assert.Equal(t, statementDay.AddDate(0, 0, i), statement.Date)
+	}
+	assert.True(t, statements[0].Partial)
+	assert.False(t, statements[1].Partial)
+	assert.True(t, statements[2].Partial)
+	assert.Equal(t, []int{1, 0, 1}, []int{statements[0].Trades, statements[1].Trades, statements[2].Trades})
+	assert.True(t, statements[0].MaxDrawdown.IsPositive())
+	assert.True(t, statements[1].Return.IsPositive())
+
+	portfolio := engine.GetPortfolio()
+	change, realized, unrealized, commission, trades := decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, 0
+	for i, statement := range statements {
+		if i > 0 {
+			assert.True(t, statements[i-1].EndingEquity.Equal(statement.StartingEquity))
+		}
+		change = change.Add(statement.EndingEquity.Sub(statement.StartingEquity))
+		realized = realized.Add(statement.RealizedPnL)
+		unrealized = unrealized.Add(statement.UnrealizedChange)
+		commission = commission.Add(statement.Commission)
+		trades += statement.Trades
+	}
+	tradeCommission := decimal.Zero
+	for _, trade := range portfolio.TradeHistory {
+		tradeCommission = tradeCommission.Add(trade.Commission)
+	}
+	assert.True(t, statements[0].StartingEquity.Equal(decimal.NewFromInt(100000)))
+	assert.True(t, portfolio.TotalValue.Sub(decimal.NewFromInt(100000)).Equal(change), change.String())
+	assert.True(t, portfolio.RealizedPnL.Equal(realized), realized.String())
+	assert.True(t, portfolio.RealizedPnL.IsPositive())
+	assert.True(t, portfolio.UnrealizedPnL.Equal(unrealized), unrealized.String())
+	assert.True(t, tradeCommission.Equal(commission))
+	assert.Equal(t, len(portfolio.TradeHistory), trades)
+}
+
+func TestTradingEngine_DailyStatements_SkipNonTradingDays(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetCalendar(createTestCalendar(t))
+	friday := time.Date(2024, 3, 8, 15, 0, 0, 0, time.UTC)
+	engine.dailyOrders["test_strategy"] = &dailyOrderCount{day: statementDay, count: 5}
+
+	tradeAt(engine, friday, 100.0, nil)
+	tradeAt(engine, friday.Add(96*time.Hour), 100.0, nil)
+
+	statements := engine.GetDailyStatements()
+	require.Len(t, statements, 2)
+	assert.Equal(t, friday.Truncate(24*time.Hour), statements[0].Date)
+	assert.Equal(t, friday.Truncate(24*time.Hour).AddDate(0, 0, 3), statements[1].Date)
+	assert.False(t, statements[1].Partial)
+	assert.Zero(t, statements[1].Trades)
+	assert.True(t, statements[1].StartingEquity.Equal(statements[1].EndingEquity))
+	assert.Empty(t, engine.dailyOrders)
+}
+
+func TestTradingEngine_DailyStatements_SurviveRestart(t *testing.T) {
+	engine := createTestEngine()
+	dayOne := statementDay.Add(15 * time.Hour)
+	tradeAt(engine, dayOne, 100.0, createTestOrder(models.OrderSideBuy, 100, 100.0))
+	tradeAt(engine, dayOne.Add(24*time.Hour), 110.0, nil)
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, engine.SaveState(path))
+
+	restored, err := NewTradingEngineFromState(path, engine.logger)
+	require.NoError(t, err)
+	restored.CloseBooks()
+
+	statements := restored.GetDailyStatements()
+	require.Len(t, statements, 2)
+	assertSameJSON(t, engine.GetDailyStatements(), statements[:1])
+	assert.Equal(t, statementDay.AddDate(0, 0, 1), statements[1].Date)
+	assert.True(t, statements[0].EndingEquity.Equal(statements[1].StartingEquity))
+	assert.True(t, restored.GetPortfolio().TotalValue.Equal(statements[1].EndingEquity))
+}
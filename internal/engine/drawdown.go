@@ -0,0 +1,263 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const maxRiskEvents = 1000
+
+type strategyDrawdown struct {
+	realized decimal.Decimal
+	peak     decimal.Decimal
+}
+
+func (e *TradingEngine) recordStrategyPnL(strategyID string, realizedPnL decimal.Decimal) {
+	drawdown, exists := e.drawdowns[strategyID]
+	if !exists {
+		drawdown = &strategyDrawdown{}
+		e.drawdowns[strategyID] = drawdown
+	}
+	drawdown.realized = drawdown.realized.Add(realizedPnL)
+}
+
+func (e *TradingEngine) GetRiskEvents() []models.RiskEvent {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]models.RiskEvent(nil), e.portfolio.RiskEvents...)
+}
+
+func positionDrawdown(position *models.Position, price decimal.Decimal) decimal.Decimal {
+	if position.Quantity.IsPositive() {
+		if !position.PeakPrice.IsPositive() {
+			return decimal.Zero
+		}
+		return position.PeakPrice.Sub(price).Div(position.PeakPrice)
+	}
+	if !position.TroughPrice.IsPositive() {
+		return decimal.Zero
+	}
+	return price.Sub(position.TroughPrice).Div(position.TroughPrice)
+}
+
+func (e *TradingEngine) liquidateDrawdowns() []*models.Order {
+	symbols := make([]string, 0, len(e.portfolio.Positions))
+	for symbol := range e.portfolio.Positions {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	var orders []*models.Order
+	for _, symbol := range symbols {
+		position := e.portfolio.Positions[symbol]
+		strategy, exists := e.strategies[position.StrategyID]
+		if position.Quantity.IsZero() || !exists || e.hasPendingExit(symbol) {
+			continue
+		}
+		config := strategy.GetConfig()
+		if !config.MaxDrawdown.IsPositive() {
+			continue
+		}
+
+		price := position.CurrentPrice
+		if data, exists := e.marketData[symbol]; exists {
+			price = data.Price
+		}
+		trackExtremes(position, price)
+
+		drawdown := positionDrawdown(position, price)
+		if drawdown.LessThanOrEqual(config.MaxDrawdown) {
+			continue
+		}
+
+		order := e.liquidationOrder(position, price, e.liquidationQuantity(position, config.LiquidationFraction), models.ExitReasonDrawdown)
+		e.openOrders[order.ID] = order
+		e.publishOrder(order)
+		e.auditOrder(audit.EventOrderCreated, order, nil)
+		orders = append(orders, order)
+		if order.Quantity.LessThan(position.Quantity.Abs()) {
+			resetExtremes(position, price)
+		}
+
+		e.recordRiskEvent(models.RiskEvent{
+			Type:       models.RiskEventDrawdownLiquidation,
+			Symbol:     symbol,
+			StrategyID: position.StrategyID,
+			Drawdown:   drawdown,
+			Threshold:  config.MaxDrawdown,
+			Quantity:   order.Quantity,
+			OrderID:    order.ID,
+			Timestamp:  e.now(),
+		})
+		e.logger.Warn("Position drawdown exceeded, liquidating",
+			zap.String("symbol", symbol),
+			zap.String("drawdown", drawdown.String()),
+			zap.String("max_drawdown", config.MaxDrawdown.String()),
+			zap.String("quantity", order.Quantity.String()))
+	}
+	return orders
+}
+
+func (e *TradingEngine) liquidationQuantity(position *models.Position, fraction decimal.Decimal) decimal.Decimal {
+	quantity := position.Quantity.Abs()
+	if fraction.IsPositive() && fraction.LessThan(decimal.NewFromInt(1)) {
+		lotSize := e.symbols.LotSize(position.Symbol)
+		quantity = decimal.Min(quantity.Mul(fraction).Div(lotSize).Ceil().Mul(lotSize), quantity)
+	}
+	return quantity
+}
+
+func (e *TradingEngine) liquidationOrder(position *models.Position, price, quantity decimal.Decimal, reason models.ExitReason) *models.Order {
+	side := models.OrderSideSell
+	if position.Quantity.IsNegative() {
+		side = models.OrderSideBuy
+	}
+
+	return &models.Order{
+		ID:         e.newOrderID(),
+		Symbol:     position.Symbol,
+		Side:       side,
+		Type:       models.OrderTypeMarket,
+		Quantity:   quantity,
+		Price:      price,
+		Status:     models.OrderStatusPending,
+		Timestamp:  e.now(),
+		StrategyID: position.StrategyID,
+		ExitReason: reason,
+	}
+}
+
+func (e *TradingEngine) disableDrawdownStrategies() {
+	for strategyID, strategy := range e.strategies {
+		config := strategy.GetConfig()
+		if !config.DisableOnDrawdown || !config.Enabled || !config.MaxDrawdown.IsPositive() {
+			continue
+		}
+
+		drawdown := e.strategyDrawdownFraction(strategyID)
+		if drawdown.LessThanOrEqual(config.MaxDrawdown) {
+			continue
+		}
+
+		disabled := *config
+		disabled.Enabled = false
+		if err := strategy.UpdateConfig(&disabled); err != nil {
+			e.logger.Error("Failed to disable strategy", zap.String("strategy_id", strategyID), zap.Error(err))
+			continue
+		}
+
+		e.recordRiskEvent(models.RiskEvent{
+			Type:       models.RiskEventStrategyDisabled,
+			StrategyID: strategyID,
+			Drawdown:   drawdown,
+			Threshold:  config.MaxDrawdown,
+			Timestamp:  e.now(),
+		})
+		e.logger.Warn("Strategy disabled",
+			zap.String("strategy_id", strategyID),
+			zap.String("drawdown", drawdown.String()),
+			zap.Error(strategies.ErrMaxDrawdownExceeded))
+	}
+}
+
+func (e *TradingEngine) SetMaxPortfolioDrawdown(limit decimal.Decimal) error {
+	if limit.IsNegative() || limit.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return fmt.Errorf("%w: %s", ErrInvalidDrawdownLimit, limit)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.maxDrawdown = limit
+	return nil
+}
+
+func (e *TradingEngine) haltOnPortfolioDrawdown() {
+	if !e.maxDrawdown.IsPositive() {
+		return
+	}
+	drawdown := e.portfolio.Drawdown
+	if drawdown.LessThanOrEqual(e.maxDrawdown) {
+		e.drawdownHalted = false
+		return
+	}
+	if e.drawdownHalted {
+		return
+	}
+	e.drawdownHalted = true
+
+	ids := make([]string, 0, len(e.strategies))
+	for strategyID := range e.strategies {
+		ids = append(ids, strategyID)
+	}
+	sort.Strings(ids)
+	for _, strategyID := range ids {
+		strategy := e.strategies[strategyID]
+		config := strategy.GetConfig()
+		if !config.Enabled {
+			continue
+		}
+		disabled := *config
+		disabled.Enabled = false
+		if err := strategy.UpdateConfig(&disabled); err != nil {
+			e.logger.Error("Failed to disable strategy", zap.String("strategy_id", strategyID), zap.Error(err))
+		}
+	}
+
+	e.recordRiskEvent(models.RiskEvent{
+		Type:      models.RiskEventPortfolioDrawdown,
+		Drawdown:  drawdown,
+		Threshold: e.maxDrawdown,
+		Timestamp: e.now(),
+	})
+	e.logger.Warn("Portfolio drawdown exceeded, strategies disabled",
+		zap.String("drawdown", drawdown.String()),
+		zap.String("max_drawdown", e.maxDrawdown.String()),
+		zap.Error(strategies.ErrMaxDrawdownExceeded))
+}
+
+func (e *TradingEngine) strategyDrawdownFraction(strategyID string) decimal.Decimal {
+	drawdown, exists := e.drawdowns[strategyID]
+	if !exists {
+		drawdown = &strategyDrawdown{}
+		e.drawdowns[strategyID] = drawdown
+	}
+
+	pnl, capital := e.strategyPnL(strategyID)
+	if pnl.GreaterThan(drawdown.peak) {
+		drawdown.peak = pnl
+	}
+	if !capital.IsPositive() {
+		return decimal.Zero
+	}
+	return drawdown.peak.Sub(pnl).Div(capital)
+}
+
+func (e *TradingEngine) strategyPnL(strategyID string) (decimal.Decimal, decimal.Decimal) {
+	pnl := decimal.Zero
+	if drawdown, exists := e.drawdowns[strategyID]; exists {
+		pnl = drawdown.realized
+	}
+	if sleeve, allocated := e.allocations[strategyID]; allocated {
+		return pnl.Add(sleeve.portfolio.UnrealizedPnL), sleeve.portfolio.TotalValue
+	}
+	for _, position := range e.portfolio.Positions {
+		if position.StrategyID == strategyID {
+			pnl = pnl.Add(position.UnrealizedPnL)
+		}
+	}
+	return pnl, e.portfolio.TotalValue
+}
+
+func (e *TradingEngine) recordRiskEvent(event models.RiskEvent) {
+	e.portfolio.RiskEvents = append(e.portfolio.RiskEvents, event)
+	if len(e.portfolio.RiskEvents) > maxRiskEvents {
+		e.portfolio.RiskEvents = e.portfolio.RiskEvents[len(e.portfolio.RiskEvents)-maxRiskEvents:]
+	}
+	e.alertRiskEvent(event)
+}
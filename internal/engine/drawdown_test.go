@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDrawdownEngine(maxDrawdown float64) (*TradingEngine, *models.StrategyConfig) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.MaxDrawdown = decimal.NewFromFloat(maxDrawdown)
+	config.CommissionRate = decimal.Zero
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 100.0), config)
+	<-engine.tradeQueue
+	return engine, config
+}
+
+func markPrice(engine *TradingEngine, price float64) {
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", price))
+	engine.updatePortfolio()
+}
+
+func TestTradingEngine_ManageRisk_LiquidatesOnDrawdownFromPeak(t *testing.T) {
+	engine, _ := createDrawdownEngine(0.1)
+
+	markPrice(engine, 120.0)
+	engine.manageRisk()
+	assert.Empty(t, engine.orderQueue)
+
+	markPrice(engine, 107.0)
+	engine.manageRisk()
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, models.ExitReasonDrawdown, order.ExitReason)
+	assert.Equal(t, models.OrderSideSell, order.Side)
+	assert.True(t, decimal.NewFromInt(100).Equal(order.Quantity))
+
+	events := engine.GetRiskEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, models.RiskEventDrawdownLiquidation, events[0].Type)
+	assert.Equal(t, order.ID, events[0].OrderID)
+	assert.True(t, decimal.NewFromFloat(0.1083333333333333).Equal(events[0].Drawdown), events[0].Drawdown.String())
+
+	engine.manageRisk()
+	assert.Empty(t, engine.orderQueue, "pending liquidation is not duplicated")
+
+	engine.processOrder(order)
+	<-engine.tradeQueue
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+}
+
+func TestTradingEngine_ManageRisk_LiquidatesFraction(t *testing.T) {
+	engine, config := createDrawdownEngine(0.1)
+	config.LiquidationFraction = decimal.NewFromFloat(0.25)
+
+	markPrice(engine, 85.0)
+	engine.manageRisk()
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.True(t, decimal.NewFromInt(25).Equal(order.Quantity))
+	engine.processOrder(order)
+	<-engine.tradeQueue
+
+	engine.manageRisk()
+	assert.Empty(t, engine.orderQueue, "drawdown is measured from the liquidation price after a partial close")
+	assert.True(t, decimal.NewFromInt(75).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+}
+
+func TestTradingEngine_ManageRisk_IgnoresStrategiesWithoutMaxDrawdown(t *testing.T) {
+	engine, _ := createDrawdownEngine(0)
+
+	markPrice(engine, 50.0)
+	engine.manageRisk()
+
+	assert.Empty(t, engine.orderQueue)
+	assert.Empty(t, engine.GetRiskEvents())
+}
+
+func TestTradingEngine_ManageRisk_DisablesStrategyOnCumulativeDrawdown(t *testing.T) {
+	engine, config := createDrawdownEngine(0.15)
+	config.DisableOnDrawdown = true
+	strategy := engine.strategies["test_strategy"]
+
+	markPrice(engine, 90.0)
+	engine.manageRisk()
+	assert.True(t, strategy.IsEnabled())
+
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 100, 90.0), config)
+	<-engine.tradeQueue
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 1000, 90.0), config)
+	<-engine.tradeQueue
+	markPrice(engine, 70.0)
+	engine.manageRisk()
+
+	assert.False(t, strategy.IsEnabled())
+	events := engine.GetRiskEvents()
+	require.NotEmpty(t, events)
+	last := events[len(events)-1]
+	assert.Equal(t, models.RiskEventStrategyDisabled, last.Type)
+	assert.Equal(t, "test_strategy", last.StrategyID)
+	assert.True(t, last.Drawdown.GreaterThan(decimal.NewFromFloat(0.15)), last.Drawdown.String())
+}
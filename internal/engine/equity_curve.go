@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	equityFullResolution = time.Hour
+	equityResolution     = time.Minute
+)
+
+type equitySeries struct {
+	points    []models.EquityPoint
+	compacted int
+}
+
+func (s *equitySeries) add(point models.EquityPoint) {
+	if n := len(s.points); n > 0 && !point.Timestamp.After(s.points[n-1].Timestamp) {
+		point.Timestamp = s.points[n-1].Timestamp
+		s.points[n-1] = point
+		return
+	}
+	s.points = append(s.points, point)
+	s.downsample(point.Timestamp.Add(-equityFullResolution))
+}
+
+func (s *equitySeries) downsample(cutoff time.Time) {
+	end := s.compacted
+	for end < len(s.points) && !s.points[end].Timestamp.Truncate(equityResolution).Add(equityResolution).After(cutoff) {
+		end++
+	}
+	if end == s.compacted {
+		return
+	}
+
+	kept := s.compacted
+	for i := s.compacted; i < end; i++ {
+		bucket := s.points[i].Timestamp.Truncate(equityResolution)
+		if i+1 < end && s.points[i+1].Timestamp.Truncate(equityResolution).Equal(bucket) {
+			continue
+		}
+		s.points[kept] = s.points[i]
+		kept++
+	}
+	s.points = append(s.points[:kept], s.points[end:]...)
+	s.compacted = kept
+}
+
+func (s *equitySeries) snapshot() []models.EquityPoint {
+	points := make([]models.EquityPoint, len(s.points))
+	copy(points, s.points)
+	return points
+}
+
+func (e *TradingEngine) recordEquity(point models.EquityPoint) {
+	e.equityCurve.add(point)
+	e.trackDrawdown(point.Value)
+	if e.benchmark == "" {
+		return
+	}
+	if data, exists := e.marketData[e.benchmark]; exists {
+		e.benchmarkCurve.add(models.EquityPoint{Timestamp: point.Timestamp, Value: data.Price})
+	}
+}
+
+func (e *TradingEngine) trackDrawdown(value decimal.Decimal) {
+	portfolio := e.portfolio
+	if value.GreaterThan(portfolio.PeakValue) {
+		portfolio.PeakValue = value
+	}
+	portfolio.Drawdown = decimal.Zero
+	if portfolio.PeakValue.IsPositive() {
+		portfolio.Drawdown = portfolio.PeakValue.Sub(value).Div(portfolio.PeakValue)
+	}
+	if portfolio.Drawdown.GreaterThan(portfolio.MaxDrawdown) {
+		portfolio.MaxDrawdown = portfolio.Drawdown
+	}
+}
+
+func (e *TradingEngine) GetEquityCurve() []models.EquityPoint {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.equityCurve.snapshot()
+}
+
+func (e *TradingEngine) GetBenchmarkCurve() (string, []models.EquityPoint) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.benchmark, e.benchmarkCurve.snapshot()
+}
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_EquityCurve(t *testing.T) {
+	engine := createTestEngine()
+	start := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+
+	engine.clock.current = start
+	engine.updatePortfolio()
+	engine.portfolio.Cash = decimal.NewFromFloat(99000.0)
+	engine.updatePortfolio()
+	engine.clock.current = start.Add(time.Minute)
+	engine.portfolio.Cash = decimal.NewFromFloat(101000.0)
+	engine.updatePortfolio()
+
+	curve := engine.GetEquityCurve()
+
+	require.Len(t, curve, 2)
+	assert.Equal(t, start, curve[0].Timestamp)
+	assert.True(t, decimal.NewFromFloat(99000.0).Equal(curve[0].Value))
+	assert.Equal(t, start.Add(time.Minute), curve[1].Timestamp)
+	assert.True(t, decimal.NewFromFloat(101000.0).Equal(curve[1].Value))
+}
+
+func TestTradingEngine_BenchmarkCurve(t *testing.T) {
+	engine := createTestEngine()
+	start := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+
+	engine.clock.current = start
+	engine.updatePortfolio()
+	symbol, curve := engine.GetBenchmarkCurve()
+	assert.Empty(t, symbol)
+	assert.Empty(t, curve)
+
+	engine.SetBenchmark("SPY", 0)
+	engine.clock.current = start.Add(time.Minute)
+	engine.updatePortfolio()
+	engine.UpdateMarketData("SPY", createTestMarketData("SPY", 400.0))
+	engine.clock.current = start.Add(2 * time.Minute)
+	engine.updatePortfolio()
+	engine.UpdateMarketData("SPY", createTestMarketData("SPY", 404.0))
+	engine.clock.current = start.Add(3 * time.Minute)
+	engine.updatePortfolio()
+
+	symbol, curve = engine.GetBenchmarkCurve()
+	equity := engine.GetEquityCurve()
+
+	assert.Equal(t, "SPY", symbol)
+	require.Len(t, curve, 2)
+	require.Len(t, equity, 4)
+	assert.Equal(t, equity[2].Timestamp, curve[0].Timestamp)
+	assert.True(t, decimal.NewFromFloat(400.0).Equal(curve[0].Value))
+	assert.Equal(t, equity[3].Timestamp, curve[1].Timestamp)
+	assert.True(t, decimal.NewFromFloat(404.0).Equal(curve[1].Value))
+}
+
+func TestTradingEngine_EquityCurve_DownsamplesOlderThanAnHour(t *testing.T) {
+	engine := createTestEngine()
+	start := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+
+	for i := 0; i <= 90*6; i++ {
+		engine.clock.current = start.Add(time.Duration(i) * 10 * time.Second)
+		engine.portfolio.Cash = decimal.NewFromInt(100000 + int64(i))
+		engine.updatePortfolio()
+	}
+
+	curve := engine.GetEquityCurve()
+	require.Len(t, curve, 30+60*6+1)
+	assert.Equal(t, start.Add(50*time.Second), curve[0].Timestamp)
+	assert.True(t, decimal.NewFromInt(100005).Equal(curve[0].Value))
+	assert.Equal(t, start.Add(29*time.Minute+50*time.Second), curve[29].Timestamp)
+	assert.Equal(t, start.Add(30*time.Minute), curve[30].Timestamp)
+	for i := 31; i < len(curve); i++ {
+		assert.Equal(t, 10*time.Second, curve[i].Timestamp.Sub(curve[i-1].Timestamp))
+	}
+	assert.True(t, decimal.NewFromInt(100540).Equal(curve[len(curve)-1].Cash))
+}
+
+func TestTradingEngine_UpdatePortfolio_TracksPortfolioDrawdown(t *testing.T) {
+	engine, _ := createDrawdownEngine(0)
+	engine.portfolio.Cash = decimal.Zero
+	start := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+
+	for i, price := range []float64{100.0, 120.0, 90.0, 130.0} {
+		engine.clock.current = start.Add(time.Duration(i) * time.Minute)
+		markPrice(engine, price)
+	}
+
+	portfolio := engine.GetPortfolio()
+	assert.True(t, decimal.NewFromFloat(0.25).Equal(portfolio.MaxDrawdown), portfolio.MaxDrawdown.String())
+	assert.True(t, portfolio.Drawdown.IsZero())
+	assert.True(t, decimal.NewFromInt(13000).Equal(portfolio.PeakValue))
+
+	curve := engine.GetEquityCurve()
+	require.Len(t, curve, 4)
+	assert.True(t, decimal.NewFromInt(2000).Equal(curve[1].UnrealizedPnL))
+}
+
+func TestTradingEngine_ManageRisk_HaltsOnPortfolioDrawdown(t *testing.T) {
+	engine, config := createDrawdownEngine(0)
+	engine.portfolio.Cash = decimal.Zero
+	require.NoError(t, engine.SetMaxPortfolioDrawdown(decimal.NewFromFloat(0.2)))
+
+	markPrice(engine, 120.0)
+	markPrice(engine, 100.0)
+	engine.manageRisk()
+	assert.True(t, config.Enabled)
+
+	markPrice(engine, 90.0)
+	engine.manageRisk()
+	engine.manageRisk()
+
+	assert.False(t, engine.strategies["test_strategy"].GetConfig().Enabled)
+	events := engine.GetRiskEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, models.RiskEventPortfolioDrawdown, events[0].Type)
+	assert.True(t, decimal.NewFromFloat(0.25).Equal(events[0].Drawdown))
+	assert.Empty(t, engine.orderQueue)
+
+	assert.ErrorIs(t, engine.SetMaxPortfolioDrawdown(decimal.NewFromInt(1)), ErrInvalidDrawdownLimit)
+}
@@ -0,0 +1,43 @@
+package engine
+
+import "errors"
+
+var (
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderAlreadyFilled     = errors.New("order already filled")
+	ErrOrderNotCancellable    = errors.New("order not cancellable")
+	ErrOrderNotModifiable     = errors.New("order not modifiable")
+	ErrStrategyNotFound       = errors.New("strategy not found")
+	ErrInvalidState           = errors.New("invalid engine state")
+	ErrStateVersion           = errors.New("unsupported engine state version")
+	ErrDrainTimeout           = errors.New("timed out draining engine queues")
+	ErrUnknownCostBasis       = errors.New("unknown cost basis method")
+	ErrInvalidAllocation      = errors.New("allocation must be greater than 0 and at most 1")
+	ErrAllocationExceeded     = errors.New("strategy allocations exceed total equity")
+	ErrUnknownSliceAlgorithm  = errors.New("unknown order slicing algorithm")
+	ErrInvalidSlicing         = errors.New("invalid order slicing configuration")
+	ErrInvalidMargin          = errors.New("invalid margin configuration")
+	ErrInvalidDrawdownLimit   = errors.New("portfolio drawdown limit must be at least 0 and below 1")
+	ErrInvalidStalePrice      = errors.New("invalid stale price check")
+	ErrStalePrice             = errors.New("signal price is stale")
+	ErrInvalidCorporateAction = errors.New("invalid corporate action")
+	ErrInvalidAlerts          = errors.New("invalid alert configuration")
+	ErrNoPositionToExit       = errors.New("exit order has no position to close")
+	ErrUnknownAction          = errors.New("unknown signal action")
+	ErrInvalidMarketData      = errors.New("invalid market data")
+	ErrInvalidExposureLimits  = errors.New("invalid exposure limits")
+	ErrInvalidSnapshot        = errors.New("invalid snapshot settings")
+	ErrInvalidTimeframes      = errors.New("invalid bar timeframes")
+	ErrInvalidQueues          = errors.New("invalid queue configuration")
+	ErrEngineBusy             = errors.New("engine busy")
+	ErrInvalidCurrencies      = errors.New("invalid currency configuration")
+	ErrNoFXRate               = errors.New("no exchange rate")
+	ErrStrategyTimeout        = errors.New("strategy execution timed out")
+	ErrStrategyPanic          = errors.New("strategy panicked")
+	ErrSymbolExposureExceeded = errors.New("symbol exposure limit exceeded")
+	ErrGroupExposureExceeded  = errors.New("group exposure limit exceeded")
+	ErrPositionLimitExceeded  = errors.New("maximum number of positions reached")
+	ErrInvalidCircuitBreaker  = errors.New("invalid circuit breaker configuration")
+	ErrTradingHalted          = errors.New("trading halted by circuit breaker")
+	ErrNotHalted              = errors.New("strategy is not halted")
+)
@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/events"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+)
+
+func (e *TradingEngine) Events() *events.Bus {
+	return e.events
+}
+
+func (e *TradingEngine) publish(eventType events.EventType, symbol string, data interface{}) {
+	e.events.Publish(events.Event{
+		Type:      eventType,
+		Symbol:    symbol,
+		Timestamp: e.now(),
+		Data:      data,
+	})
+}
+
+func (e *TradingEngine) publishMarketData(data *models.MarketData) {
+	if !e.events.HasSubscribers() {
+		return
+	}
+	dataCopy := *data
+	e.publish(events.EventTypeMarketData, data.Symbol, &dataCopy)
+}
+
+func (e *TradingEngine) publishOrder(order *models.Order) {
+	if !e.events.HasSubscribers() {
+		return
+	}
+	orderCopy := *order
+	e.publish(events.EventTypeOrder, order.Symbol, &orderCopy)
+}
+
+func (e *TradingEngine) publishTrade(trade *models.Trade) {
+	if !e.events.HasSubscribers() {
+		return
+	}
+	tradeCopy := *trade
+	e.publish(events.EventTypeTrade, trade.Symbol, &tradeCopy)
+}
+
+func (e *TradingEngine) publishPortfolio() {
+	if !e.events.HasSubscribers() {
+		return
+	}
+
+	snapshot := *e.portfolio
+	snapshot.Positions = make(map[string]*models.Position, len(e.portfolio.Positions))
+	for symbol, position := range e.portfolio.Positions {
+		positionCopy := *position
+		snapshot.Positions[symbol] = &positionCopy
+	}
+	snapshot.TradeHistory = nil
+	snapshot.OrderHistory = nil
+	e.publish(events.EventTypePortfolio, "", &snapshot)
+}
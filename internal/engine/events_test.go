@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/events"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_Events_BuyFillSequence(t *testing.T) {
+	engine := createTestEngine()
+	sub := engine.Events().Subscribe(16)
+	defer sub.Close()
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 10, 150.0))
+	engine.drainQueues()
+	engine.updatePortfolio()
+
+	var received []events.Event
+	for len(sub.Events()) > 0 {
+		received = append(received, <-sub.Events())
+	}
+
+	require.Len(t, received, 5)
+	assert.Equal(t, events.EventTypeMarketData, received[0].Type)
+	assert.Equal(t, events.EventTypeOrder, received[1].Type)
+	assert.Equal(t, models.OrderStatusPending, received[1].Data.(*models.Order).Status)
+	assert.Equal(t, events.EventTypeOrder, received[2].Type)
+	assert.Equal(t, models.OrderStatusFilled, received[2].Data.(*models.Order).Status)
+	assert.Equal(t, events.EventTypeTrade, received[3].Type)
+	assert.Equal(t, "AAPL", received[3].Symbol)
+	assert.Equal(t, events.EventTypePortfolio, received[4].Type)
+	assert.Contains(t, received[4].Data.(*models.Portfolio).Positions, "AAPL")
+}
+
+func TestTradingEngine_Events_RejectedOrder(t *testing.T) {
+	engine := createTestEngine()
+	sub := engine.Events().Subscribe(16)
+	defer sub.Close()
+
+	order := createTestOrder(models.OrderSideBuy, 100000, 150.0)
+	engine.submitOrder(order)
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusPending, (<-sub.Events()).Data.(*models.Order).Status)
+	assert.Equal(t, models.OrderStatusRejected, (<-sub.Events()).Data.(*models.Order).Status)
+}
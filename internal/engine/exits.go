@@ -0,0 +1,162 @@
+package engine
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func (e *TradingEngine) checkExit(symbol string, price decimal.Decimal) *models.Order {
+	position, exists := e.portfolio.Positions[symbol]
+	if !exists || position.Quantity.IsZero() || position.AveragePrice.IsZero() {
+		return nil
+	}
+
+	trackExtremes(position, price)
+
+	strategy, exists := e.strategies[position.StrategyID]
+	if !exists || e.hasPendingExit(symbol) {
+		return nil
+	}
+
+	reason := exitReason(position, price, strategy.GetConfig())
+	if reason == "" {
+		return nil
+	}
+
+	side := models.OrderSideSell
+	if position.Quantity.IsNegative() {
+		side = models.OrderSideBuy
+	}
+
+	order := &models.Order{
+		ID:         e.newOrderID(),
+		Symbol:     symbol,
+		Side:       side,
+		Type:       models.OrderTypeMarket,
+		Quantity:   position.Quantity.Abs(),
+		Price:      price,
+		Status:     models.OrderStatusPending,
+		Timestamp:  e.now(),
+		StrategyID: position.StrategyID,
+		ExitReason: reason,
+	}
+
+	e.logger.Info("Exit triggered",
+		zap.String("symbol", symbol),
+		zap.String("reason", string(reason)),
+		zap.String("price", price.String()),
+		zap.String("average_price", position.AveragePrice.String()),
+	)
+	return order
+}
+
+func exitReason(position *models.Position, price decimal.Decimal, config *models.StrategyConfig) models.ExitReason {
+	if stopPriceHit(position, price) {
+		return models.ExitReasonStopLoss
+	}
+
+	change := price.Sub(position.AveragePrice).Div(position.AveragePrice)
+	if position.Quantity.IsNegative() {
+		change = change.Neg()
+	}
+
+	if config.StopLossPercent.IsPositive() && change.LessThanOrEqual(config.StopLossPercent.Neg()) {
+		return models.ExitReasonStopLoss
+	}
+	if trailingStopHit(position, price, config.TrailingStopPercent) {
+		return models.ExitReasonTrailingStop
+	}
+	if config.TakeProfitPercent.IsPositive() && change.GreaterThanOrEqual(config.TakeProfitPercent) {
+		return models.ExitReasonTakeProfit
+	}
+	return ""
+}
+
+func trailingStopHit(position *models.Position, price, trail decimal.Decimal) bool {
+	if !trail.IsPositive() {
+		return false
+	}
+
+	one := decimal.NewFromInt(1)
+	if position.Quantity.IsPositive() {
+		return position.PeakPrice.IsPositive() && price.LessThanOrEqual(position.PeakPrice.Mul(one.Sub(trail)))
+	}
+	return position.TroughPrice.IsPositive() && price.GreaterThanOrEqual(position.TroughPrice.Mul(one.Add(trail)))
+}
+
+func stopPriceHit(position *models.Position, price decimal.Decimal) bool {
+	if !position.StopPrice.IsPositive() {
+		return false
+	}
+	if position.Quantity.IsPositive() {
+		return price.LessThanOrEqual(position.StopPrice)
+	}
+	return price.GreaterThanOrEqual(position.StopPrice)
+}
+
+func (e *TradingEngine) attachStop(order *models.Order, previous decimal.Decimal) {
+	position, exists := e.portfolio.Positions[order.Symbol]
+	if !exists {
+		return
+	}
+
+	stopPrice := order.StopPrice
+	if order.Type == models.OrderTypeStop {
+		stopPrice = decimal.Zero
+	}
+	flipped := previous.IsZero() || previous.IsPositive() != position.Quantity.IsPositive()
+	added := (order.Side == models.OrderSideBuy) == position.Quantity.IsPositive()
+	switch {
+	case flipped:
+		position.StopPrice = stopPrice
+	case added && stopPrice.IsPositive():
+		position.StopPrice = stopPrice
+	}
+}
+
+func resetExtremes(position *models.Position, price decimal.Decimal) {
+	position.PeakPrice = price
+	position.TroughPrice = price
+}
+
+func trackExtremes(position *models.Position, price decimal.Decimal) {
+	if price.GreaterThan(position.PeakPrice) {
+		position.PeakPrice = price
+	}
+	if position.TroughPrice.IsZero() || price.LessThan(position.TroughPrice) {
+		position.TroughPrice = price
+	}
+}
+
+func (e *TradingEngine) hasPendingExit(symbol string) bool {
+	for _, order := range e.openOrders {
+		if order.Symbol == symbol && order.ExitReason != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *TradingEngine) prepareExit(order *models.Order) bool {
+	position, exists := e.portfolio.Positions[order.Symbol]
+	if !exists || position.Quantity.IsZero() {
+		return false
+	}
+	if order.ExitReason == models.ExitReasonClose {
+		order.Side = models.OrderSideSell
+		if position.Quantity.IsNegative() {
+			order.Side = models.OrderSideBuy
+		}
+		order.Quantity = position.Quantity.Abs()
+		return true
+	}
+	if (order.Side == models.OrderSideSell) != position.Quantity.IsPositive() {
+		return false
+	}
+
+	if order.Quantity.GreaterThan(position.Quantity.Abs()) {
+		order.Quantity = position.Quantity.Abs()
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_StopLoss(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.StopLossPercent = decimal.NewFromFloat(0.05)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 150.0), config)
+	<-engine.tradeQueue
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 143.0))
+	assert.Empty(t, engine.orderQueue)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 142.0))
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 141.0))
+	require.Len(t, engine.orderQueue, 1)
+
+	order := <-engine.orderQueue
+	assert.Equal(t, models.ExitReasonStopLoss, order.ExitReason)
+	assert.Equal(t, models.OrderSideSell, order.Side)
+	assert.True(t, decimal.NewFromInt(100).Equal(order.Quantity))
+
+	engine.processOrder(order)
+	trade := <-engine.tradeQueue
+
+	assert.Equal(t, models.OrderStatusFilled, order.Status)
+	assert.Equal(t, models.ExitReasonStopLoss, trade.ExitReason)
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+}
+
+func TestTradingEngine_TakeProfit(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.TakeProfitPercent = decimal.NewFromFloat(0.1)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 150.0), config)
+	<-engine.tradeQueue
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 164.0))
+	assert.Empty(t, engine.orderQueue)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 165.0))
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, models.ExitReasonTakeProfit, order.ExitReason)
+	assert.True(t, decimal.NewFromInt(100).Equal(order.Quantity))
+}
+
+func TestTradingEngine_StopLoss_Short(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.AllowShort = true
+	config.StopLossPercent = decimal.NewFromFloat(0.05)
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 100, 150.0), config)
+	<-engine.tradeQueue
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 140.0))
+	assert.Empty(t, engine.orderQueue)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 158.0))
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, models.ExitReasonStopLoss, order.ExitReason)
+	assert.Equal(t, models.OrderSideBuy, order.Side)
+
+	engine.processOrder(order)
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+}
+
+func TestTradingEngine_StopLoss_SkipsMinOrderSize(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.StopLossPercent = decimal.NewFromFloat(0.05)
+	config.MinOrderSize = decimal.NewFromFloat(1000.0)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 2, 150.0), config)
+	<-engine.tradeQueue
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 140.0))
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	engine.processOrder(order)
+
+	assert.Equal(t, models.OrderStatusFilled, order.Status)
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+}
+
+func TestTradingEngine_ExitOrder_PositionAlreadyClosed(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.StopLossPercent = decimal.NewFromFloat(0.05)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 150.0), config)
+	<-engine.tradeQueue
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 140.0))
+	exit := <-engine.orderQueue
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 100, 140.0), config)
+	<-engine.tradeQueue
+	engine.processOrder(exit)
+
+	assert.Equal(t, models.OrderStatusRejected, exit.Status)
+	assert.Empty(t, engine.tradeQueue)
+}
+
+func TestTradingEngine_Exits_DisabledWithoutThresholds(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 150.0), config)
+	<-engine.tradeQueue
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 50.0))
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 500.0))
+
+	assert.Empty(t, engine.orderQueue)
+}
+
+func TestTradingEngine_TrailingStop(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.TrailingStopPercent = decimal.NewFromFloat(0.03)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 150.0), config)
+	<-engine.tradeQueue
+
+	for price := 151.0; price <= 180.0; price++ {
+		engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", price))
+		engine.updatePortfolio()
+	}
+	require.Empty(t, engine.orderQueue)
+	assert.True(t, decimal.NewFromFloat(180.0).Equal(engine.portfolio.Positions["AAPL"].PeakPrice))
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 175.0))
+	assert.Empty(t, engine.orderQueue)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 174.6))
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, models.ExitReasonTrailingStop, order.ExitReason)
+	assert.True(t, decimal.NewFromInt(100).Equal(order.Quantity))
+
+	engine.processOrder(order)
+	trade := <-engine.tradeQueue
+
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+	assert.True(t, trade.Price.GreaterThan(decimal.NewFromFloat(174.0)))
+	assert.True(t, trade.RealizedPnL.GreaterThan(decimal.NewFromFloat(2000.0)))
+}
+
+func TestTradingEngine_TrailingStop_Short(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.AllowShort = true
+	config.TrailingStopPercent = decimal.NewFromFloat(0.03)
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 100, 150.0), config)
+	<-engine.tradeQueue
+
+	for price := 149.0; price >= 120.0; price-- {
+		engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", price))
+	}
+	require.Empty(t, engine.orderQueue)
+	assert.True(t, decimal.NewFromFloat(120.0).Equal(engine.portfolio.Positions["AAPL"].TroughPrice))
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 123.6))
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, models.ExitReasonTrailingStop, order.ExitReason)
+	assert.Equal(t, models.OrderSideBuy, order.Side)
+}
+
+func TestTradingEngine_TrailingStop_ResetsOnNewPosition(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 150.0), config)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 200.0))
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 100, 200.0), config)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 10, 190.0), config)
+
+	position := engine.portfolio.Positions["AAPL"]
+	require.NotNil(t, position)
+	assert.True(t, decimal.NewFromFloat(190.0).Equal(position.PeakPrice))
+	assert.True(t, decimal.NewFromFloat(190.0).Equal(position.TroughPrice))
+}
+
+func TestTradingEngine_PositionStopPrice(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	order := createTestOrder(models.OrderSideBuy, 100, 150.0)
+	order.StopPrice = decimal.NewFromFloat(145.0)
+	engine.executeOrder(order, config)
+	<-engine.tradeQueue
+
+	assert.True(t, engine.portfolio.Positions["AAPL"].StopPrice.Equal(decimal.NewFromFloat(145.0)))
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 146.0))
+	assert.Empty(t, engine.orderQueue)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 145.0))
+
+	require.Len(t, engine.orderQueue, 1)
+	exit := <-engine.orderQueue
+	assert.Equal(t, models.ExitReasonStopLoss, exit.ExitReason)
+	assert.True(t, decimal.NewFromInt(100).Equal(exit.Quantity))
+}
+
+func TestTradingEngine_PositionStopPrice_ResetsOnFlip(t *testing.T) {
+	engine := createTestEngine()
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.AllowShort = true
+	order := createTestOrder(models.OrderSideBuy, 100, 150.0)
+	order.StopPrice = decimal.NewFromFloat(145.0)
+	engine.executeOrder(order, config)
+	<-engine.tradeQueue
+
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 10, 150.0), config)
+	<-engine.tradeQueue
+	assert.True(t, engine.portfolio.Positions["AAPL"].StopPrice.Equal(decimal.NewFromFloat(145.0)))
+
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 200, 150.0), config)
+	<-engine.tradeQueue
+	position := engine.portfolio.Positions["AAPL"]
+	assert.True(t, decimal.NewFromInt(-90).Equal(position.Quantity))
+	assert.True(t, position.StopPrice.IsZero())
+}
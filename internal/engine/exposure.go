@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	exposureLimitSymbol    = "symbol"
+	exposureLimitGroup     = "group"
+	exposureLimitPositions = "positions"
+)
+
+func (e *TradingEngine) SetExposureLimits(limits models.ExposureLimits) error {
+	if limits.MaxSymbolWeight.IsNegative() || limits.MaxPositions < 0 {
+		return fmt.Errorf("%w: max symbol weight and max positions must not be negative", ErrInvalidExposureLimits)
+	}
+	for name, group := range limits.Groups {
+		if len(group.Symbols) == 0 || !group.MaxWeight.IsPositive() {
+			return fmt.Errorf("%w: group %q needs symbols and a positive max weight", ErrInvalidExposureLimits, name)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.exposure = &limits
+	return nil
+}
+
+func (e *TradingEngine) checkExposure(order *models.Order, config *models.StrategyConfig) error {
+	limits, portfolioValue := e.exposure, e.portfolio.TotalValue
+	if limits == nil || order.ExitReason != "" || !portfolioValue.IsPositive() {
+		return nil
+	}
+
+	current := e.exposureQuantity(order.Symbol)
+	change := fillQuantity(order)
+	if order.Side == models.OrderSideSell {
+		change = change.Neg()
+	}
+	after := current.Add(change)
+	if !after.Abs().GreaterThan(current.Abs()) {
+		return nil
+	}
+
+	if held := e.exposedSymbols(); limits.MaxPositions > 0 && current.IsZero() && held >= limits.MaxPositions {
+		return e.exposureRejected(exposureLimitPositions, fmt.Errorf("%w: %d positions held, limit %d", ErrPositionLimitExceeded, held, limits.MaxPositions))
+	}
+
+	value := after.Abs().Mul(e.exposurePrice(order.Symbol, order.Price))
+	maxWeight := limits.MaxSymbolWeight
+	if !maxWeight.IsPositive() {
+		maxWeight = config.MaxPositionSize
+	}
+	if weight := value.Div(portfolioValue); maxWeight.IsPositive() && weight.GreaterThan(maxWeight) {
+		return e.exposureRejected(exposureLimitSymbol, fmt.Errorf("%w: %s would be %s of the portfolio, limit %s", ErrSymbolExposureExceeded, order.Symbol, weight.StringFixed(4), maxWeight))
+	}
+
+	names := make([]string, 0, len(limits.Groups))
+	for name := range limits.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		group := limits.Groups[name]
+		if !slices.Contains(group.Symbols, order.Symbol) {
+			continue
+		}
+		groupValue := value
+		for _, symbol := range group.Symbols {
+			if symbol != order.Symbol {
+				groupValue = groupValue.Add(e.exposureQuantity(symbol).Abs().Mul(e.exposurePrice(symbol, decimal.Zero)))
+			}
+		}
+		if weight := groupValue.Div(portfolioValue); weight.GreaterThan(group.MaxWeight) {
+			return e.exposureRejected(exposureLimitGroup, fmt.Errorf("%w: %s would be %s of the portfolio, limit %s", ErrGroupExposureExceeded, name, weight.StringFixed(4), group.MaxWeight))
+		}
+	}
+	return nil
+}
+
+func (e *TradingEngine) exposureRejected(limit string, err error) error {
+	e.metrics.ExposureRejected(limit)
+	return err
+}
+
+func (e *TradingEngine) exposureQuantity(symbol string) decimal.Decimal {
+	quantity := decimal.Zero
+	if position, exists := e.portfolio.Positions[symbol]; exists {
+		quantity = position.Quantity
+	}
+	for _, held := range e.reservations {
+		if held.symbol == symbol {
+			quantity = quantity.Add(held.bought).Sub(held.shares)
+		}
+	}
+	return quantity
+}
+
+func (e *TradingEngine) exposedSymbols() int {
+	symbols := make(map[string]bool, len(e.portfolio.Positions))
+	for symbol := range e.portfolio.Positions {
+		symbols[symbol] = true
+	}
+	for _, held := range e.reservations {
+		symbols[held.symbol] = true
+	}
+
+	held := 0
+	for symbol := range symbols {
+		if !e.exposureQuantity(symbol).IsZero() {
+			held++
+		}
+	}
+	return held
+}
+
+func (e *TradingEngine) exposurePrice(symbol string, fallback decimal.Decimal) decimal.Decimal {
+	if price := e.marketPrice(symbol); price.IsPositive() {
+		return price
+	}
+	if position, exists := e.portfolio.Positions[symbol]; exists && position.CurrentPrice.IsPositive() {
+		return position.CurrentPrice
+	}
+	return fallback
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/metrics"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createExposureEngine(t *testing.T, limits models.ExposureLimits) (*TradingEngine, *metrics.Metrics) {
+	t.Helper()
+	m := metrics.New()
+	engine := createTestEngine()
+	engine.SetMetrics(m)
+	require.NoError(t, engine.SetExposureLimits(limits))
+	for symbol, price := range map[string]float64{"AAPL": 100.0, "MSFT": 200.0, "NVDA": 400.0} {
+		engine.UpdateMarketData(symbol, createTestMarketData(symbol, price))
+	}
+	return engine, m
+}
+
+func buyFor(engine *TradingEngine, symbol string, quantity int64, price float64) *models.Order {
+	order := createTestOrder(models.OrderSideBuy, quantity, price)
+	order.Symbol = symbol
+	engine.submitOrder(order)
+	engine.drainQueues()
+	return order
+}
+
+func TestTradingEngine_Exposure_ThirdBuyBreachesSymbolCap(t *testing.T) {
+	engine, m := createExposureEngine(t, models.ExposureLimits{MaxSymbolWeight: decimal.NewFromFloat(0.2)})
+
+	first := buyFor(engine, "AAPL", 80, 100.0)
+	second := buyFor(engine, "AAPL", 80, 100.0)
+	third := buyFor(engine, "AAPL", 80, 100.0)
+
+	assert.Equal(t, models.OrderStatusFilled, first.Status)
+	assert.Equal(t, models.OrderStatusFilled, second.Status)
+	assert.Equal(t, models.OrderStatusRejected, third.Status)
+	assert.True(t, decimal.NewFromInt(160).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+	assert.Contains(t, scrapeMetrics(m), `trade_algo_exposure_rejections_total{limit="symbol"} 1`)
+}
+
+func TestTradingEngine_Exposure_DefaultsToMaxPositionSize(t *testing.T) {
+	engine, _ := createExposureEngine(t, models.ExposureLimits{})
+	engine.strategies["test_strategy"].GetConfig().MaxPositionSize = decimal.NewFromFloat(0.1)
+
+	first := buyFor(engine, "AAPL", 80, 100.0)
+	second := buyFor(engine, "AAPL", 80, 100.0)
+
+	assert.Equal(t, models.OrderStatusFilled, first.Status)
+	assert.Equal(t, models.OrderStatusRejected, second.Status)
+}
+
+func TestTradingEngine_Exposure_SellsReducingPositionPass(t *testing.T) {
+	engine, _ := createExposureEngine(t, models.ExposureLimits{MaxSymbolWeight: decimal.NewFromFloat(0.2)})
+	buyFor(engine, "AAPL", 150, 100.0)
+	engine.exposure.MaxSymbolWeight = decimal.NewFromFloat(0.05)
+
+	sell := createTestOrder(models.OrderSideSell, 50, 100.0)
+	engine.submitOrder(sell)
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusFilled, sell.Status)
+}
+
+func TestTradingEngine_Exposure_GroupLimit(t *testing.T) {
+	engine, m := createExposureEngine(t, models.ExposureLimits{
+		MaxSymbolWeight: decimal.NewFromFloat(0.2),
+		Groups:          map[string]models.ExposureGroup{"tech": {Symbols: []string{"AAPL", "MSFT", "NVDA"}, MaxWeight: decimal.NewFromFloat(0.3)}},
+	})
+
+	assert.Equal(t, models.OrderStatusFilled, buyFor(engine, "AAPL", 150, 100.0).Status)
+	assert.Equal(t, models.OrderStatusFilled, buyFor(engine, "MSFT", 50, 200.0).Status)
+	rejected := buyFor(engine, "NVDA", 20, 400.0)
+
+	assert.Equal(t, models.OrderStatusRejected, rejected.Status)
+	assert.NotContains(t, engine.portfolio.Positions, "NVDA")
+	assert.Contains(t, scrapeMetrics(m), `trade_algo_exposure_rejections_total{limit="group"} 1`)
+}
+
+func TestTradingEngine_Exposure_MaxPositionsCountsPendingFills(t *testing.T) {
+	engine, m := createExposureEngine(t, models.ExposureLimits{MaxPositions: 2})
+	engine.SetLatencyModel(execution.FixedLatency{Delay: time.Minute})
+
+	assert.Equal(t, models.OrderStatusPending, buyFor(engine, "AAPL", 10, 100.0).Status)
+	assert.Equal(t, models.OrderStatusPending, buyFor(engine, "MSFT", 10, 200.0).Status)
+	assert.Equal(t, models.OrderStatusRejected, buyFor(engine, "NVDA", 10, 400.0).Status)
+	assert.Equal(t, models.OrderStatusPending, buyFor(engine, "AAPL", 10, 100.0).Status)
+	assert.Contains(t, scrapeMetrics(m), `trade_algo_exposure_rejections_total{limit="positions"} 1`)
+}
+
+func TestTradingEngine_SetExposureLimits_RejectsInvalid(t *testing.T) {
+	engine := createTestEngine()
+
+	assert.ErrorIs(t, engine.SetExposureLimits(models.ExposureLimits{MaxPositions: -1}), ErrInvalidExposureLimits)
+	assert.ErrorIs(t, engine.SetExposureLimits(models.ExposureLimits{
+		Groups: map[string]models.ExposureGroup{"tech": {Symbols: []string{"AAPL"}}},
+	}), ErrInvalidExposureLimits)
+	assert.Nil(t, engine.exposure)
+}
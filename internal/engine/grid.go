@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+func (e *TradingEngine) SetGridRounding(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.roundToGrid = enabled
+}
+
+func (e *TradingEngine) conformToGrid(order *models.Order) error {
+	if order.ExitReason != "" || order.ParentID != "" {
+		return nil
+	}
+	if e.roundToGrid {
+		order.Quantity = e.symbols.RoundDown(order.Symbol, order.Quantity)
+		if order.Type == models.OrderTypeLimit {
+			order.Price = e.passivePrice(order)
+		}
+		return nil
+	}
+	if order.Type != models.OrderTypeLimit {
+		return nil
+	}
+	return e.symbols.ValidatePrice(order.Symbol, order.Price)
+}
+
+func (e *TradingEngine) passivePrice(order *models.Order) decimal.Decimal {
+	if order.Side == models.OrderSideBuy {
+		return e.symbols.RoundPriceDown(order.Symbol, order.Price)
+	}
+	return e.symbols.RoundPriceUp(order.Symbol, order.Price)
+}
+
+func (e *TradingEngine) aggressivePrice(side models.OrderSide, symbol string, price decimal.Decimal) decimal.Decimal {
+	if side == models.OrderSideBuy {
+		return e.symbols.RoundPriceUp(symbol, price)
+	}
+	return e.symbols.RoundPriceDown(symbol, price)
+}
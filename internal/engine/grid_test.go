@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var gridTickSize = decimal.NewFromFloat(0.05)
+
+func createGridEngine() *TradingEngine {
+	registry := symbols.NewRegistry()
+	if err := registry.Register(models.Instrument{Symbol: "AAPL", TickSize: gridTickSize, LotSize: decimal.NewFromInt(1)}); err != nil {
+		panic(err)
+	}
+	engine := createTestEngine()
+	engine.SetSymbols(registry)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.03))
+	return engine
+}
+
+func TestTradingEngine_TickGrid_FillsNeverLandOffTick(t *testing.T) {
+	engine := createGridEngine()
+
+	buy := createTestOrder(models.OrderSideBuy, 10, 100.03)
+	engine.openOrders[buy.ID] = buy
+	engine.processOrder(buy)
+	bought := <-engine.tradeQueue
+
+	sell := createTestOrder(models.OrderSideSell, 10, 100.03)
+	engine.openOrders[sell.ID] = sell
+	engine.processOrder(sell)
+	sold := <-engine.tradeQueue
+
+	assert.True(t, decimal.NewFromFloat(100.05).Equal(bought.Price), bought.Price.String())
+	assert.True(t, decimal.NewFromFloat(100.0).Equal(sold.Price), sold.Price.String())
+	for _, trade := range engine.portfolio.TradeHistory {
+		assert.True(t, trade.Price.Mod(gridTickSize).IsZero(), trade.Price.String())
+	}
+}
+
+func TestTradingEngine_TickGrid_RejectsOffTickLimitPrice(t *testing.T) {
+	engine := createGridEngine()
+
+	order := createTestOrder(models.OrderSideBuy, 10, 100.03)
+	order.Type = models.OrderTypeLimit
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+
+	assert.Equal(t, models.OrderStatusRejected, order.Status)
+	assert.Empty(t, engine.book.orders)
+	assert.Empty(t, engine.tradeQueue)
+}
+
+func TestTradingEngine_TickGrid_RoundsOffGridOrders(t *testing.T) {
+	engine := createGridEngine()
+	engine.SetGridRounding(true)
+
+	order := createTestOrder(models.OrderSideBuy, 10, 100.08)
+	order.Type = models.OrderTypeLimit
+	order.Quantity = decimal.NewFromFloat(10.7)
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	trade := <-engine.tradeQueue
+
+	assert.Equal(t, models.OrderStatusFilled, order.Status)
+	assert.True(t, decimal.NewFromFloat(100.05).Equal(order.Price), order.Price.String())
+	assert.True(t, decimal.NewFromInt(10).Equal(trade.Quantity), trade.Quantity.String())
+	assert.True(t, trade.Price.Mod(gridTickSize).IsZero(), trade.Price.String())
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/storage"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createHistoryTrade(i int) *models.Trade {
+	return &models.Trade{ID: fmt.Sprintf("TRD-%05d", i), Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: decimal.NewFromInt(1), Price: decimal.NewFromInt(int64(100 + i%10))}
+}
+
+func TestTradingEngine_HistoryLimit_ArchivesInBatches(t *testing.T) {
+	archive := storage.NewJSONLArchive(t.TempDir())
+	defer archive.Close()
+	engine := createTestEngine()
+	engine.SetHistoryLimit(10000)
+	engine.SetArchive(archive)
+
+	maxResident := 0
+	for i := 0; i < 25000; i++ {
+		engine.mu.Lock()
+		engine.recordTrade(createHistoryTrade(i))
+		resident := len(engine.portfolio.TradeHistory)
+		engine.mu.Unlock()
+		if resident > maxResident {
+			maxResident = resident
+		}
+	}
+	engine.Stop()
+
+	assert.Equal(t, 10999, maxResident)
+	history := engine.GetPortfolio().TradeHistory
+	require.Len(t, history, 10000)
+	assert.Equal(t, "TRD-15000", history[0].ID)
+	assert.Equal(t, "TRD-24999", history[len(history)-1].ID)
+
+	archived, err := archive.LoadTrades()
+	require.NoError(t, err)
+	require.Len(t, archived, 15000)
+	for i, trade := range archived {
+		require.Equal(t, fmt.Sprintf("TRD-%05d", i), trade.ID)
+	}
+}
+
+func TestTradingEngine_HistoryLimit_Orders(t *testing.T) {
+	archive := storage.NewJSONLArchive(t.TempDir())
+	defer archive.Close()
+	engine := createTestEngine()
+	engine.SetHistoryLimit(10)
+	engine.SetArchive(archive)
+
+	engine.mu.Lock()
+	for i := 0; i < 25; i++ {
+		engine.recordOrder(&models.Order{ID: fmt.Sprintf("ORD-%02d", i), Symbol: "AAPL", Status: models.OrderStatusFilled})
+	}
+	engine.mu.Unlock()
+	engine.Stop()
+
+	assert.Len(t, engine.GetPortfolio().OrderHistory, 10)
+	archived, err := archive.LoadOrders()
+	require.NoError(t, err)
+	require.Len(t, archived, 15)
+	assert.Equal(t, "ORD-00", archived[0].ID)
+	assert.Equal(t, "ORD-14", archived[14].ID)
+}
+
+func TestTradingEngine_HistoryLimit_TradeStoreSkipsArchive(t *testing.T) {
+	store, err := storage.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer store.Close()
+	archive := storage.NewJSONLArchive(t.TempDir())
+	defer archive.Close()
+
+	engine := createTestEngine()
+	engine.SetArchive(archive)
+	engine.SetTradeStore(store, 5)
+	engine.mu.Lock()
+	for i := 0; i < 20; i++ {
+		engine.recordTrade(createHistoryTrade(i))
+	}
+	engine.mu.Unlock()
+	engine.Stop()
+
+	assert.LessOrEqual(t, len(engine.GetPortfolio().TradeHistory), 5)
+	stored, err := store.ListTradesBySymbol(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.Len(t, stored, 20)
+	archived, err := archive.LoadTrades()
+	require.NoError(t, err)
+	assert.Empty(t, archived)
+}
+
+func TestTradingEngine_DefaultHistoryLimit(t *testing.T) {
+	engine := createTestEngine()
+	assert.Equal(t, defaultHistoryLimit, engine.historyLimit)
+
+	engine.SetHistoryLimit(0)
+	assert.Equal(t, defaultHistoryLimit, engine.historyLimit)
+}
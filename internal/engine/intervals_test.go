@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var clockStart = time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+
+func createCountingEngine(intervals Intervals, opts ...Option) (*TradingEngine, *stubStrategy) {
+	engine := createTestEngine(opts...)
+	engine.SetIntervals(intervals)
+	strategy := &stubStrategy{config: &models.StrategyConfig{ID: "counting", Name: "Counting", Enabled: true}}
+	engine.AddStrategy(strategy)
+	return engine, strategy
+}
+
+func TestTradingEngine_DefaultIntervals(t *testing.T) {
+	engine := createTestEngine()
+
+	assert.Equal(t, DefaultIntervals(), engine.intervals)
+	assert.Equal(t, 5*time.Second, engine.intervals.Strategy)
+	assert.Equal(t, 10*time.Second, engine.intervals.Risk)
+	assert.Equal(t, time.Second, engine.intervals.Portfolio)
+}
+
+func TestTradingEngine_StrategyInterval(t *testing.T) {
+	fake := clock.NewFake(clockStart)
+	engine, strategy := createCountingEngine(DefaultIntervals(), WithClock(fake))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, engine.Start(ctx))
+	defer engine.Stop()
+	fake.BlockUntil(3)
+
+	fake.Advance(4 * time.Second)
+	assert.Zero(t, strategy.calls.Load())
+	for cycle := int32(1); cycle <= 5; cycle++ {
+		fake.Advance(time.Second)
+		require.Eventually(t, func() bool { return strategy.calls.Load() == cycle }, time.Second, time.Millisecond)
+		fake.Advance(4 * time.Second)
+	}
+}
+
+func TestTradingEngine_TimestampsFollowClock(t *testing.T) {
+	fake := clock.NewFake(clockStart)
+	engine := createTestEngine(WithClock(fake))
+	assert.Equal(t, clockStart, engine.GetPortfolio().CreatedAt)
+
+	fake.Advance(time.Hour)
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	engine.processTrade(<-engine.tradeQueue)
+	engine.updatePortfolio()
+
+	portfolio := engine.GetPortfolio()
+	require.Len(t, portfolio.TradeHistory, 1)
+	assert.Equal(t, clockStart.Add(time.Hour), portfolio.TradeHistory[0].Timestamp)
+	assert.Equal(t, clockStart.Add(time.Hour), portfolio.Positions["AAPL"].LastUpdated)
+	assert.Equal(t, clockStart.Add(time.Hour), portfolio.UpdatedAt)
+}
+
+func TestTradingEngine_AdvanceUsesIntervals(t *testing.T) {
+	engine, strategy := createCountingEngine(Intervals{Strategy: time.Minute, Risk: time.Minute, Portfolio: time.Minute})
+	start := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+
+	for now := start; now.Before(start.Add(5 * time.Minute)); now = now.Add(5 * time.Second) {
+		engine.Advance(context.Background(), now)
+	}
+
+	assert.Equal(t, int32(5), strategy.calls.Load())
+}
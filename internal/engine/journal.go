@@ -0,0 +1,223 @@
+package engine
+
+import (
+	"context"
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRecentHistory = 1000
+	defaultHistoryLimit  = 10000
+	historyBatchDivisor  = 10
+)
+
+type journalEntry struct {
+	trade *models.Trade
+	order *models.Order
+}
+
+type tradeJournal struct {
+	store   storage.TradeSink
+	logger  *zap.Logger
+	mu      sync.Mutex
+	pending []journalEntry
+	closed  bool
+	notify  chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newTradeJournal(store storage.TradeSink, logger *zap.Logger) *tradeJournal {
+	j := &tradeJournal{
+		store:  store,
+		logger: logger,
+		notify: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go j.run()
+	return j
+}
+
+func (j *tradeJournal) enqueue(entries ...journalEntry) {
+	j.mu.Lock()
+	if j.closed {
+		j.mu.Unlock()
+		for _, entry := range entries {
+			j.write(entry)
+		}
+		return
+	}
+	j.pending = append(j.pending, entries...)
+	j.mu.Unlock()
+
+	select {
+	case j.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (j *tradeJournal) run() {
+	defer close(j.done)
+	for {
+		select {
+		case <-j.notify:
+			j.flush()
+		case <-j.stop:
+			j.flush()
+			return
+		}
+	}
+}
+
+func (j *tradeJournal) flush() {
+	j.mu.Lock()
+	batch := j.pending
+	j.pending = nil
+	j.mu.Unlock()
+
+	for _, entry := range batch {
+		j.write(entry)
+	}
+}
+
+func (j *tradeJournal) write(entry journalEntry) {
+	ctx := context.Background()
+	if entry.trade != nil {
+		if err := j.store.SaveTrade(ctx, entry.trade); err != nil {
+			j.logger.Error("Failed to journal trade", zap.String("trade_id", entry.trade.ID), zap.Error(err))
+		}
+	}
+	if entry.order != nil {
+		if err := j.store.SaveOrder(ctx, entry.order); err != nil {
+			j.logger.Error("Failed to journal order", zap.String("order_id", entry.order.ID), zap.Error(err))
+		}
+	}
+}
+
+func (j *tradeJournal) close() {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	if j.closed {
+		j.mu.Unlock()
+		return
+	}
+	j.closed = true
+	j.mu.Unlock()
+
+	close(j.stop)
+	<-j.done
+}
+
+func (j *tradeJournal) reopen() *tradeJournal {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	closed := j.closed
+	j.mu.Unlock()
+	if !closed {
+		return j
+	}
+	return newTradeJournal(j.store, j.logger)
+}
+
+func (e *TradingEngine) SetTradeStore(store storage.TradeStore, recentHistory int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.journal != nil {
+		e.journal.close()
+	}
+	if recentHistory <= 0 {
+		recentHistory = defaultRecentHistory
+	}
+	e.journal = newTradeJournal(store, e.logger)
+	e.historyLimit = recentHistory
+}
+
+func (e *TradingEngine) SetHistoryLimit(limit int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	e.historyLimit = limit
+}
+
+func (e *TradingEngine) SetArchive(sink storage.TradeSink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.archive.close()
+	e.archive = nil
+	if sink != nil {
+		e.archive = newTradeJournal(sink, e.logger)
+	}
+}
+
+func (e *TradingEngine) historyExcess(size int) int {
+	batch := e.historyLimit / historyBatchDivisor
+	if batch < 1 {
+		batch = 1
+	}
+	if size < e.historyLimit+batch {
+		return 0
+	}
+	return size - e.historyLimit
+}
+
+func (e *TradingEngine) recordTrade(trade *models.Trade) {
+	e.portfolio.TradeHistory = append(e.portfolio.TradeHistory, trade)
+	e.publishTrade(trade)
+	if e.journal != nil {
+		tradeCopy := *trade
+		e.journal.enqueue(journalEntry{trade: &tradeCopy})
+	}
+
+	if excess := e.historyExcess(len(e.portfolio.TradeHistory)); excess > 0 {
+		e.archiveEntries(excess, func(i int) journalEntry {
+			tradeCopy := *e.portfolio.TradeHistory[i]
+			return journalEntry{trade: &tradeCopy}
+		})
+		e.portfolio.TradeHistory = append([]*models.Trade(nil), e.portfolio.TradeHistory[excess:]...)
+	}
+}
+
+func (e *TradingEngine) recordOrder(order *models.Order) {
+	e.portfolio.OrderHistory = append(e.portfolio.OrderHistory, order)
+	e.publishOrder(order)
+	if order.ParentID != "" {
+		e.completeSlice(order)
+	}
+	if e.journal != nil {
+		orderCopy := *order
+		e.journal.enqueue(journalEntry{order: &orderCopy})
+	}
+
+	if excess := e.historyExcess(len(e.portfolio.OrderHistory)); excess > 0 {
+		e.archiveEntries(excess, func(i int) journalEntry {
+			orderCopy := *e.portfolio.OrderHistory[i]
+			return journalEntry{order: &orderCopy}
+		})
+		e.portfolio.OrderHistory = append([]*models.Order(nil), e.portfolio.OrderHistory[excess:]...)
+	}
+}
+
+func (e *TradingEngine) archiveEntries(count int, entry func(i int) journalEntry) {
+	if e.journal != nil || e.archive == nil {
+		return
+	}
+	entries := make([]journalEntry, count)
+	for i := range entries {
+		entries[i] = entry(i)
+	}
+	e.archive.enqueue(entries...)
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingStore struct {
+	release chan struct{}
+	mu      sync.Mutex
+	trades  []*models.Trade
+	orders  []*models.Order
+}
+
+func (s *blockingStore) SaveTrade(ctx context.Context, trade *models.Trade) error {
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trades = append(s.trades, trade)
+	return nil
+}
+
+func (s *blockingStore) SaveOrder(ctx context.Context, order *models.Order) error {
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders = append(s.orders, order)
+	return nil
+}
+
+func (s *blockingStore) ListTradesBySymbol(ctx context.Context, symbol string) ([]*models.Trade, error) {
+	return nil, nil
+}
+
+func (s *blockingStore) ListTradesByStrategy(ctx context.Context, strategyID string) ([]*models.Trade, error) {
+	return nil, nil
+}
+
+func (s *blockingStore) ListTradesBetween(ctx context.Context, start, end time.Time) ([]*models.Trade, error) {
+	return nil, nil
+}
+
+func (s *blockingStore) Close() error {
+	return nil
+}
+
+func TestTradingEngine_TradeStore_WritesThroughAndBoundsHistory(t *testing.T) {
+	store, err := storage.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer store.Close()
+
+	engine := createTestEngine()
+	engine.SetTradeStore(store, 2)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+
+	for i := 0; i < 3; i++ {
+		order := createTestOrder(models.OrderSideBuy, 1, 150.0)
+		engine.openOrders[order.ID] = order
+		engine.processOrder(order)
+		engine.drainQueues()
+	}
+	engine.Stop()
+
+	assert.Len(t, engine.portfolio.TradeHistory, 2)
+	assert.Len(t, engine.portfolio.OrderHistory, 2)
+
+	trades, err := store.ListTradesBySymbol(context.Background(), "AAPL")
+	require.NoError(t, err)
+	require.Len(t, trades, 3)
+	assert.Equal(t, engine.portfolio.TradeHistory[1].ID, trades[2].ID)
+	assert.True(t, engine.portfolio.TradeHistory[1].Price.Equal(trades[2].Price))
+}
+
+func TestTradingEngine_TradeStore_DoesNotBlockTrades(t *testing.T) {
+	store := &blockingStore{release: make(chan struct{})}
+	engine := createTestEngine()
+	engine.SetTradeStore(store, 0)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			order := createTestOrder(models.OrderSideBuy, 1, 150.0)
+			engine.submitOrder(order)
+			engine.drainQueues()
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("trade processing blocked on the trade store")
+	}
+
+	close(store.release)
+	engine.Stop()
+
+	assert.Len(t, store.trades, 5)
+	assert.Len(t, store.orders, 5)
+}
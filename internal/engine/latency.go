@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type pendingFill struct {
+	order  *models.Order
+	config *models.StrategyConfig
+	fillAt time.Time
+}
+
+func (e *TradingEngine) SetLatencyModel(model execution.LatencyModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.latencyModel = model
+}
+
+func (e *TradingEngine) fillOrder(order *models.Order, config *models.StrategyConfig) {
+	if e.latencyModel != nil {
+		if delay := e.latencyModel.Latency(); delay > 0 {
+			e.scheduleFill(order, config, delay)
+			return
+		}
+	}
+	e.completeFill(order, config, order.Price)
+}
+
+func (e *TradingEngine) completeFill(order *models.Order, config *models.StrategyConfig, reference decimal.Decimal) {
+	e.release(order.ID)
+	order.Status = models.OrderStatusFilled
+	if order.FilledQuantity.IsPositive() && order.FilledQuantity.LessThan(order.Quantity) {
+		order.Status = models.OrderStatusPartiallyFilled
+	}
+	e.executeOrderAt(order, config, reference)
+	e.recordOrder(order)
+}
+
+func (e *TradingEngine) scheduleFill(order *models.Order, config *models.StrategyConfig, delay time.Duration) {
+	e.openOrders[order.ID] = order
+	e.reserve(order, config)
+	e.pendingFills = append(e.pendingFills, &pendingFill{order: order, config: config, fillAt: e.now().Add(delay)})
+	if e.clock.current.IsZero() {
+		go e.fillAfter(e.wallClock.After(delay))
+	}
+
+	e.logger.Debug("Order fill delayed",
+		zap.String("order_id", order.ID),
+		zap.String("symbol", order.Symbol),
+		zap.Duration("latency", delay),
+	)
+}
+
+func (e *TradingEngine) fillAfter(after <-chan time.Time) {
+	<-after
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.executeDueFills()
+}
+
+func (e *TradingEngine) executeDueFills() {
+	now := e.now()
+	var due []*pendingFill
+	remaining := e.pendingFills[:0]
+	for _, fill := range e.pendingFills {
+		if fill.fillAt.After(now) {
+			remaining = append(remaining, fill)
+		} else {
+			due = append(due, fill)
+		}
+	}
+	for i := len(remaining); i < len(e.pendingFills); i++ {
+		e.pendingFills[i] = nil
+	}
+	e.pendingFills = remaining
+
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].fillAt.Equal(due[j].fillAt) {
+			return due[i].fillAt.Before(due[j].fillAt)
+		}
+		return due[i].order.ID < due[j].order.ID
+	})
+
+	for _, fill := range due {
+		order := fill.order
+		if _, open := e.openOrders[order.ID]; !open {
+			continue
+		}
+		if order.Type == models.OrderTypeLimit && e.restOrder(order) {
+			continue
+		}
+		delete(e.openOrders, order.ID)
+
+		if order.ExitReason != "" && !e.prepareExit(order) {
+			e.rejectOrder(order, fmt.Errorf("%w: %s", ErrNoPositionToExit, order.Symbol))
+			e.logger.Warn("Exit order has no position to close", zap.String("order_id", order.ID), zap.String("symbol", order.Symbol))
+			continue
+		}
+		reference := order.Price
+		if data, exists := e.marketData[order.Symbol]; exists && data.Price.IsPositive() {
+			reference = data.Price
+		}
+		e.completeFill(order, fill.config, reference)
+	}
+}
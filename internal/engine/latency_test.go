@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var latencyStart = time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+func rampPrice(second int) float64 {
+	return 100.0 + 0.5*float64(second)
+}
+
+func tickRamp(engine *TradingEngine, second int) {
+	engine.clock.current = latencyStart.Add(time.Duration(second) * time.Second)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", rampPrice(second)))
+	engine.drainQueues()
+}
+
+func TestTradingEngine_LatencyFillsAtMarketPriceAfterDelay(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetLatencyModel(execution.FixedLatency{Delay: 4 * time.Second})
+	tickRamp(engine, 0)
+
+	order := createTestOrder(models.OrderSideBuy, 100, rampPrice(0))
+	engine.submitOrder(order)
+	engine.drainQueues()
+
+	for second := 1; second < 4; second++ {
+		tickRamp(engine, second)
+		assert.Equal(t, models.OrderStatusPending, order.Status, "second %d", second)
+		assert.Len(t, engine.GetOpenOrders(), 1)
+		assert.Empty(t, engine.portfolio.Positions)
+	}
+
+	tickRamp(engine, 4)
+	assert.Equal(t, models.OrderStatusFilled, order.Status)
+	assert.Empty(t, engine.GetOpenOrders())
+	require.Len(t, engine.portfolio.TradeHistory, 1)
+
+	trade := engine.portfolio.TradeHistory[0]
+	assert.True(t, trade.RequestedPrice.Equal(order.Price))
+	assert.InDelta(t, 0.5*4, trade.Price.Sub(trade.RequestedPrice).InexactFloat64(), 1e-9)
+	assert.InDelta(t, rampPrice(4), engine.portfolio.Positions["AAPL"].AveragePrice.InexactFloat64(), 1e-9)
+}
+
+func TestTradingEngine_LatencyOrderCancellableWhilePending(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetLatencyModel(execution.FixedLatency{Delay: 4 * time.Second})
+	tickRamp(engine, 0)
+
+	order := createTestOrder(models.OrderSideBuy, 100, rampPrice(0))
+	engine.submitOrder(order)
+	engine.drainQueues()
+	tickRamp(engine, 2)
+
+	require.NoError(t, engine.CancelOrder(order.ID))
+	for second := 3; second <= 6; second++ {
+		tickRamp(engine, second)
+	}
+
+	assert.Equal(t, models.OrderStatusCancelled, order.Status)
+	assert.Empty(t, engine.portfolio.TradeHistory)
+	assert.Empty(t, engine.portfolio.Positions)
+}
+
+func TestTradingEngine_LatencyExitRecheckedAtFill(t *testing.T) {
+	engine := createTestEngine()
+	tickRamp(engine, 0)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, rampPrice(0)), engine.strategies["test_strategy"].GetConfig())
+	engine.drainQueues()
+	engine.SetLatencyModel(execution.FixedLatency{Delay: 2 * time.Second})
+
+	exit := createTestOrder(models.OrderSideSell, 100, rampPrice(0))
+	exit.ExitReason = models.ExitReasonStopLoss
+	engine.submitOrder(exit)
+	engine.drainQueues()
+
+	manual := createTestOrder(models.OrderSideSell, 100, rampPrice(1))
+	engine.executeOrder(manual, engine.strategies["test_strategy"].GetConfig())
+	engine.drainQueues()
+
+	tickRamp(engine, 2)
+	assert.Equal(t, models.OrderStatusRejected, exit.Status)
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+}
+
+func TestTradingEngine_LatencyInBacktestUsesSimulatedClock(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetLatencyModel(execution.FixedLatency{Delay: time.Hour})
+	tickRamp(engine, 0)
+
+	order := createTestOrder(models.OrderSideBuy, 100, rampPrice(0))
+	engine.submitOrder(order)
+	engine.drainQueues()
+	assert.Equal(t, models.OrderStatusPending, order.Status)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 120.0))
+	engine.Advance(context.Background(), latencyStart.Add(time.Hour))
+
+	assert.Equal(t, models.OrderStatusFilled, order.Status)
+	require.NotEmpty(t, engine.portfolio.TradeHistory)
+	assert.True(t, engine.portfolio.TradeHistory[0].Price.Equal(createTestMarketData("AAPL", 120.0).Price))
+}
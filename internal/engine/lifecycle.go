@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"context"
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type orderAcceptedHook interface {
+	OnOrderAccepted(order *models.Order)
+}
+
+type orderRejectedHook interface {
+	OnOrderRejected(order *models.Order, err error)
+}
+
+type fillHook interface {
+	OnFill(trade *models.Trade)
+}
+
+type positionClosedHook interface {
+	OnPositionClosed(symbol string, realizedPnL decimal.Decimal)
+}
+
+type hookQueue struct {
+	mu      sync.Mutex
+	pending []func()
+	running bool
+	idle    sync.WaitGroup
+}
+
+func (q *hookQueue) push(hook func()) {
+	q.mu.Lock()
+	q.pending = append(q.pending, hook)
+	if q.running {
+		q.mu.Unlock()
+		return
+	}
+	q.running = true
+	q.idle.Add(1)
+	q.mu.Unlock()
+
+	go q.run()
+}
+
+func (q *hookQueue) run() {
+	defer q.idle.Done()
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		hook := q.pending[0]
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+
+		hook()
+	}
+}
+
+func (e *TradingEngine) enqueueHook(strategyID string, hook func()) {
+	queue, exists := e.hooks[strategyID]
+	if !exists {
+		queue = &hookQueue{}
+		e.hooks[strategyID] = queue
+	}
+	queue.push(hook)
+}
+
+func (e *TradingEngine) waitForHooks(ctx context.Context) error {
+	e.mu.RLock()
+	queues := make([]*hookQueue, 0, len(e.hooks))
+	for _, queue := range e.hooks {
+		queues = append(queues, queue)
+	}
+	e.mu.RUnlock()
+
+	done := make(chan struct{})
+	go func() {
+		for _, queue := range queues {
+			queue.idle.Wait()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *TradingEngine) notifyAccepted(order *models.Order) {
+	if hook, ok := e.strategies[order.StrategyID].(orderAcceptedHook); ok {
+		accepted := *order
+		e.enqueueHook(order.StrategyID, func() { hook.OnOrderAccepted(&accepted) })
+	}
+}
+
+func (e *TradingEngine) notifyRejected(order *models.Order, err error) {
+	if hook, ok := e.strategies[order.StrategyID].(orderRejectedHook); ok {
+		rejected := *order
+		e.enqueueHook(order.StrategyID, func() { hook.OnOrderRejected(&rejected, err) })
+	}
+}
+
+func (e *TradingEngine) notifyFill(trade *models.Trade) {
+	if hook, ok := e.strategies[trade.StrategyID].(fillHook); ok {
+		filled := *trade
+		e.enqueueHook(trade.StrategyID, func() { hook.OnFill(&filled) })
+	}
+}
+
+func (e *TradingEngine) notifyPositionClosed(strategyID, symbol string, realizedPnL decimal.Decimal) {
+	if hook, ok := e.strategies[strategyID].(positionClosedHook); ok {
+		e.enqueueHook(strategyID, func() { hook.OnPositionClosed(symbol, realizedPnL) })
+	}
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hookStrategy struct {
+	stubStrategy
+	mu     sync.Mutex
+	events []string
+	errs   []error
+	block  chan struct{}
+}
+
+func (s *hookStrategy) record(event string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *hookStrategy) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.events...)
+}
+
+func (s *hookStrategy) OnOrderAccepted(order *models.Order) {
+	s.record(fmt.Sprintf("accepted %s %s", order.Side, order.Quantity))
+}
+
+func (s *hookStrategy) OnOrderRejected(order *models.Order, err error) {
+	s.mu.Lock()
+	s.errs = append(s.errs, err)
+	s.mu.Unlock()
+	s.record(fmt.Sprintf("rejected %s %s", order.Side, order.Quantity))
+}
+
+func (s *hookStrategy) OnFill(trade *models.Trade) {
+	if s.block != nil {
+		<-s.block
+	}
+	s.record(fmt.Sprintf("fill %s %s @ %s", trade.Side, trade.Quantity, trade.Price))
+}
+
+func (s *hookStrategy) OnPositionClosed(symbol string, realizedPnL decimal.Decimal) {
+	s.record(fmt.Sprintf("closed %s %s", symbol, realizedPnL))
+}
+
+func createHookEngine() (*TradingEngine, *hookStrategy) {
+	engine := createTestEngine()
+	hooks := &hookStrategy{stubStrategy: stubStrategy{config: &models.StrategyConfig{ID: "hooks", Name: "hooks", Enabled: true}}}
+	engine.AddStrategy(hooks)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	return engine, hooks
+}
+
+func hookOrder(side models.OrderSide, quantity int64, price float64) *models.Order {
+	order := createTestOrder(side, quantity, price)
+	order.StrategyID = "hooks"
+	return order
+}
+
+func TestTradingEngine_Hooks_FireInOrderForRoundTrip(t *testing.T) {
+	engine, hooks := createHookEngine()
+
+	engine.submitOrder(hookOrder(models.OrderSideBuy, 10, 100.0))
+	engine.drainQueues()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 110.0))
+	engine.submitOrder(hookOrder(models.OrderSideSell, 10, 110.0))
+	engine.drainQueues()
+	require.NoError(t, engine.waitForHooks(context.Background()))
+
+	assert.Equal(t, []string{
+		"accepted buy 10",
+		"fill buy 10 @ 100",
+		"accepted sell 10",
+		"fill sell 10 @ 110",
+		"closed AAPL 100",
+	}, hooks.recorded())
+}
+
+func TestTradingEngine_Hooks_ReportRejections(t *testing.T) {
+	engine, hooks := createHookEngine()
+
+	exit := hookOrder(models.OrderSideSell, 10, 100.0)
+	exit.ExitReason = models.ExitReasonStopLoss
+	engine.submitOrder(exit)
+	engine.drainQueues()
+	require.NoError(t, engine.waitForHooks(context.Background()))
+
+	assert.Equal(t, []string{"rejected sell 10"}, hooks.recorded())
+	require.Len(t, hooks.errs, 1)
+	assert.True(t, errors.Is(hooks.errs[0], ErrNoPositionToExit))
+}
+
+func TestTradingEngine_Hooks_SlowHookDoesNotStallOrders(t *testing.T) {
+	engine, hooks := createHookEngine()
+	hooks.block = make(chan struct{})
+
+	first := hookOrder(models.OrderSideBuy, 10, 100.0)
+	second := hookOrder(models.OrderSideBuy, 5, 100.0)
+	engine.submitOrder(first)
+	engine.submitOrder(second)
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusFilled, first.Status)
+	assert.Equal(t, models.OrderStatusFilled, second.Status)
+	assert.NotContains(t, hooks.recorded(), "fill buy 10 @ 100")
+
+	close(hooks.block)
+	require.NoError(t, engine.waitForHooks(context.Background()))
+	assert.Equal(t, []string{"accepted buy 10", "fill buy 10 @ 100", "accepted buy 5", "fill buy 5 @ 100"}, hooks.recorded())
+}
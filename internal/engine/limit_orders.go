@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+)
+
+func limitMarketable(order *models.Order, data *models.MarketData) bool {
+	if data == nil {
+		return false
+	}
+	quote := execution.QuotePrice(order.Side, data.Price, data)
+	if order.Side == models.OrderSideBuy {
+		return !quote.GreaterThan(order.Price)
+	}
+	return !quote.LessThan(order.Price)
+}
+
+func stopTriggered(order *models.Order, data *models.MarketData) bool {
+	if data == nil || !data.Price.IsPositive() {
+		return false
+	}
+	if order.Side == models.OrderSideBuy {
+		return !data.Price.LessThan(order.StopPrice)
+	}
+	return !data.Price.GreaterThan(order.StopPrice)
+}
+
+func (e *TradingEngine) restOrder(order *models.Order) bool {
+	data := e.marketData[order.Symbol]
+	switch {
+	case order.Type == models.OrderTypeStop:
+		if e.book.consumeTrigger(order.ID) || stopTriggered(order, data) {
+			return false
+		}
+	case order.Type != models.OrderTypeLimit || order.TimeInForce == models.TimeInForceIOC || limitMarketable(order, data):
+		return false
+	}
+	e.book.add(order)
+	return true
+}
+
+func (e *TradingEngine) triggerRestingOrders(symbol string, data *models.MarketData) []*models.Order {
+	return e.book.match(symbol, data)
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/analytics"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func createLimitOrder(side models.OrderSide, quantity int64, price float64) *models.Order {
+	order := createTestOrder(side, quantity, price)
+	order.Type = models.OrderTypeLimit
+	order.TimeInForce = models.TimeInForceGTC
+	return order
+}
+
+func TestTradingEngine_LimitOrderRestsUntilMarketable(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 101.0))
+	order := createLimitOrder(models.OrderSideBuy, 10, 100.0)
+
+	engine.submitOrder(order)
+	engine.drainQueues()
+
+	require.Len(t, engine.GetOpenOrders(), 1)
+	assert.Equal(t, models.OrderStatusPending, engine.GetOpenOrders()[0].Status)
+	assert.Empty(t, engine.portfolio.Positions)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.5))
+	engine.drainQueues()
+	assert.Len(t, engine.GetOpenOrders(), 1)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 99.0))
+	engine.drainQueues()
+
+	assert.Empty(t, engine.GetOpenOrders())
+	assert.Empty(t, engine.book.orders)
+	require.Len(t, engine.portfolio.TradeHistory, 1)
+	assert.True(t, decimal.NewFromFloat(100.0).Equal(engine.portfolio.TradeHistory[0].Price))
+	assert.True(t, decimal.NewFromInt(10).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+}
+
+func TestTradingEngine_MarketableLimitOrderFillsImmediately(t *testing.T) {
+	engine := createTestEngine()
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 10, 100.0), createTestStrategyConfig())
+	<-engine.tradeQueue
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 105.0))
+
+	engine.submitOrder(createLimitOrder(models.OrderSideSell, 10, 104.0))
+	engine.drainQueues()
+
+	assert.Empty(t, engine.GetOpenOrders())
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+}
+
+func TestTradingEngine_CancelRestingLimitOrder(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 101.0))
+	order := createLimitOrder(models.OrderSideBuy, 10, 100.0)
+	engine.submitOrder(order)
+	engine.drainQueues()
+
+	require.NoError(t, engine.CancelOrder(order.ID))
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 99.0))
+	engine.drainQueues()
+
+	assert.Empty(t, engine.GetOpenOrders())
+	assert.Empty(t, engine.portfolio.TradeHistory)
+	assert.Equal(t, models.OrderStatusCancelled, engine.portfolio.OrderHistory[0].Status)
+}
+
+func TestTradingEngine_GridStrategyHarvestsOscillation(t *testing.T) {
+	engine := NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	engine.SetIntervals(Intervals{})
+	config := createTestStrategyConfig()
+	config.ID = "grid"
+	config.CommissionRate = decimal.Zero
+	config.Grid = &models.GridConfig{
+		Symbol:        "AAPL",
+		Lower:         decimal.NewFromFloat(96.0),
+		Upper:         decimal.NewFromFloat(102.0),
+		Step:          decimal.NewFromFloat(2.0),
+		LevelQuantity: decimal.NewFromInt(10),
+	}
+	engine.AddStrategy(strategies.NewGridStrategy(config))
+
+	path := []float64{101}
+	cycles := 5
+	for i := 0; i < cycles; i++ {
+		path = append(path, 99, 97, 99, 101, 103, 101)
+	}
+
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	for i, price := range path {
+		timestamp := start.Add(time.Duration(i) * time.Minute)
+		engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(price), Timestamp: timestamp})
+		engine.Advance(context.Background(), timestamp)
+	}
+
+	portfolio := engine.GetPortfolio()
+	roundTrips := analytics.MatchRoundTrips(portfolio.TradeHistory)
+	require.Len(t, roundTrips, 2*cycles)
+	realized := decimal.Zero
+	for _, trade := range portfolio.TradeHistory {
+		realized = realized.Add(trade.RealizedPnL)
+	}
+	assert.True(t, decimal.NewFromInt(int64(40*cycles)).Equal(realized), "realized pnl %s", realized)
+	assert.NotContains(t, portfolio.Positions, "AAPL")
+
+	var buys []string
+	for _, order := range engine.GetOpenOrders() {
+		assert.Equal(t, models.OrderSideBuy, order.Side)
+		buys = append(buys, order.Price.String())
+	}
+	assert.ElementsMatch(t, []string{"96", "98", "100"}, buys)
+}
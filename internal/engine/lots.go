@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+func (e *TradingEngine) SetCostBasisMethod(method models.CostBasisMethod) error {
+	switch method {
+	case models.CostBasisAverage, models.CostBasisFIFO, models.CostBasisLIFO:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownCostBasis, method)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.costBasis = method
+	return nil
+}
+
+func (e *TradingEngine) GetOpenLots(symbol string) []models.Lot {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	position, exists := e.portfolio.Positions[symbol]
+	if !exists {
+		return nil
+	}
+	return copyLots(position.Lots)
+}
+
+func copyLots(lots []models.Lot) []models.Lot {
+	if lots == nil {
+		return nil
+	}
+	copied := make([]models.Lot, len(lots))
+	copy(copied, lots)
+	return copied
+}
+
+func syncLots(position *models.Position) {
+	quantity := decimal.Zero
+	for _, lot := range position.Lots {
+		quantity = quantity.Add(lot.Quantity)
+	}
+	if quantity.Equal(position.Quantity) {
+		return
+	}
+
+	position.Lots = nil
+	if !position.Quantity.IsZero() {
+		position.Lots = []models.Lot{{Quantity: position.Quantity, Price: position.AveragePrice, Timestamp: position.LastUpdated}}
+	}
+}
+
+func (e *TradingEngine) consumeLots(position *models.Position, closedQuantity, price, commission decimal.Decimal) []models.ClosedLot {
+	remaining := closedQuantity.Abs()
+	remainingCommission := commission
+	var closed []models.ClosedLot
+
+	for remaining.IsPositive() && len(position.Lots) > 0 {
+		index := 0
+		if e.costBasis == models.CostBasisLIFO {
+			index = len(position.Lots) - 1
+		}
+		lot := &position.Lots[index]
+
+		taken := decimal.Min(remaining, lot.Quantity.Abs())
+		signed := taken
+		if lot.Quantity.IsNegative() {
+			signed = taken.Neg()
+		}
+
+		lotCommission := remainingCommission
+		if taken.LessThan(remaining) {
+			lotCommission = commission.Mul(taken).Div(closedQuantity.Abs())
+		}
+		remainingCommission = remainingCommission.Sub(lotCommission)
+
+		entryCommission := lot.Commission
+		if taken.LessThan(lot.Quantity.Abs()) {
+			entryCommission = lot.Commission.Mul(taken).Div(lot.Quantity.Abs())
+		}
+		lot.Commission = lot.Commission.Sub(entryCommission)
+
+		costBasis := lot.Price
+		if e.costBasis == models.CostBasisAverage {
+			costBasis = position.AveragePrice
+		}
+
+		closed = append(closed, models.ClosedLot{
+			Quantity:    signed,
+			OpenPrice:   lot.Price,
+			CostBasis:   costBasis,
+			OpenedAt:    lot.Timestamp,
+			RealizedPnL: price.Sub(costBasis).Mul(signed).Sub(lotCommission).Sub(entryCommission),
+		})
+
+		lot.Quantity = lot.Quantity.Sub(signed)
+		remaining = remaining.Sub(taken)
+		if lot.Quantity.IsZero() {
+			position.Lots = append(position.Lots[:index], position.Lots[index+1:]...)
+		}
+	}
+
+	return closed
+}
+
+func lotsAveragePrice(lots []models.Lot) decimal.Decimal {
+	quantity := decimal.Zero
+	cost := decimal.Zero
+	for _, lot := range lots {
+		quantity = quantity.Add(lot.Quantity.Abs())
+		cost = cost.Add(lot.Price.Mul(lot.Quantity.Abs()))
+	}
+	if quantity.IsZero() {
+		return decimal.Zero
+	}
+	return cost.Div(quantity)
+}
@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createLotEngine(t *testing.T, method models.CostBasisMethod) (*TradingEngine, *models.StrategyConfig) {
+	t.Helper()
+	engine := createTestEngine()
+	require.NoError(t, engine.SetCostBasisMethod(method))
+	config := createTestStrategyConfig()
+	config.CommissionRate = decimal.Zero
+	return engine, config
+}
+
+func fill(engine *TradingEngine, config *models.StrategyConfig, side models.OrderSide, quantity int64, price float64) *models.Trade {
+	engine.executeOrder(createTestOrder(side, quantity, price), config)
+	return <-engine.tradeQueue
+}
+
+func TestTradingEngine_CostBasis_SellSpanningLots(t *testing.T) {
+	tests := []struct {
+		method        models.CostBasisMethod
+		realized      float64
+		remaining     float64
+		averagePrice  float64
+		consumedPrice []float64
+	}{
+		{models.CostBasisFIFO, 250.0, 110.0, 110.0, []float64{100.0, 110.0}},
+		{models.CostBasisLIFO, 200.0, 100.0, 100.0, []float64{110.0, 100.0}},
+		{models.CostBasisAverage, 225.0, 110.0, 105.0, []float64{100.0, 110.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			engine, config := createLotEngine(t, tt.method)
+			fill(engine, config, models.OrderSideBuy, 10, 100.0)
+			fill(engine, config, models.OrderSideBuy, 10, 110.0)
+
+			sell := fill(engine, config, models.OrderSideSell, 15, 120.0)
+
+			assert.True(t, decimal.NewFromFloat(tt.realized).Equal(sell.RealizedPnL), sell.RealizedPnL.String())
+			require.Len(t, sell.ClosedLots, 2)
+			for i, price := range tt.consumedPrice {
+				assert.True(t, decimal.NewFromFloat(price).Equal(sell.ClosedLots[i].OpenPrice))
+			}
+			assert.True(t, decimal.NewFromInt(15).Equal(sell.ClosedLots[0].Quantity.Add(sell.ClosedLots[1].Quantity)))
+
+			lots := engine.GetOpenLots("AAPL")
+			require.Len(t, lots, 1)
+			assert.True(t, decimal.NewFromInt(5).Equal(lots[0].Quantity))
+			assert.True(t, decimal.NewFromFloat(tt.remaining).Equal(lots[0].Price))
+			assert.True(t, decimal.NewFromFloat(tt.averagePrice).Equal(engine.portfolio.Positions["AAPL"].AveragePrice))
+		})
+	}
+}
+
+func TestTradingEngine_CostBasis_SellEmptiesLastLot(t *testing.T) {
+	engine, config := createLotEngine(t, models.CostBasisFIFO)
+	fill(engine, config, models.OrderSideBuy, 10, 100.0)
+	fill(engine, config, models.OrderSideBuy, 5, 110.0)
+
+	sell := fill(engine, config, models.OrderSideSell, 15, 120.0)
+
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+	assert.Nil(t, engine.GetOpenLots("AAPL"))
+	assert.True(t, decimal.NewFromFloat(250.0).Equal(sell.RealizedPnL), sell.RealizedPnL.String())
+	assert.True(t, decimal.NewFromFloat(250.0).Equal(engine.portfolio.RealizedPnL))
+	require.Len(t, sell.ClosedLots, 2)
+	assert.True(t, decimal.NewFromFloat(200.0).Equal(sell.ClosedLots[0].RealizedPnL))
+	assert.True(t, decimal.NewFromFloat(50.0).Equal(sell.ClosedLots[1].RealizedPnL))
+}
+
+func TestTradingEngine_CostBasis_ShortLots(t *testing.T) {
+	engine, config := createLotEngine(t, models.CostBasisFIFO)
+	fill(engine, config, models.OrderSideSell, 10, 100.0)
+	fill(engine, config, models.OrderSideSell, 10, 90.0)
+
+	cover := fill(engine, config, models.OrderSideBuy, 15, 80.0)
+
+	assert.True(t, decimal.NewFromFloat(250.0).Equal(cover.RealizedPnL), cover.RealizedPnL.String())
+	lots := engine.GetOpenLots("AAPL")
+	require.Len(t, lots, 1)
+	assert.True(t, decimal.NewFromInt(-5).Equal(lots[0].Quantity))
+	assert.True(t, decimal.NewFromFloat(90.0).Equal(lots[0].Price))
+}
+
+func TestTradingEngine_CostBasis_FlipOpensNewLot(t *testing.T) {
+	engine, config := createLotEngine(t, models.CostBasisLIFO)
+	fill(engine, config, models.OrderSideBuy, 10, 100.0)
+
+	sell := fill(engine, config, models.OrderSideSell, 15, 90.0)
+
+	assert.True(t, decimal.NewFromFloat(-100.0).Equal(sell.RealizedPnL))
+	lots := engine.GetOpenLots("AAPL")
+	require.Len(t, lots, 1)
+	assert.True(t, decimal.NewFromInt(-5).Equal(lots[0].Quantity))
+	assert.True(t, decimal.NewFromFloat(90.0).Equal(lots[0].Price))
+}
+
+func TestTradingEngine_CostBasis_CommissionSplitAcrossLots(t *testing.T) {
+	engine := createTestEngine()
+	require.NoError(t, engine.SetCostBasisMethod(models.CostBasisFIFO))
+	config := createTestStrategyConfig()
+	fill(engine, config, models.OrderSideBuy, 10, 100.0)
+	fill(engine, config, models.OrderSideBuy, 20, 110.0)
+
+	sell := fill(engine, config, models.OrderSideSell, 30, 120.0)
+
+	total := decimal.Zero
+	for _, lot := range sell.ClosedLots {
+		total = total.Add(lot.RealizedPnL)
+	}
+	assert.True(t, total.Equal(sell.RealizedPnL))
+	assert.True(t, decimal.NewFromFloat(393.2).Equal(sell.RealizedPnL), sell.RealizedPnL.String())
+}
+
+func TestTradingEngine_CostBasis_RebuildsLotsForRestoredPositions(t *testing.T) {
+	engine, config := createLotEngine(t, models.CostBasisFIFO)
+	engine.portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(10), AveragePrice: decimal.NewFromFloat(100.0)}
+	fill(engine, config, models.OrderSideBuy, 10, 120.0)
+
+	sell := fill(engine, config, models.OrderSideSell, 10, 130.0)
+
+	assert.True(t, decimal.NewFromFloat(300.0).Equal(sell.RealizedPnL), sell.RealizedPnL.String())
+}
+
+func TestTradingEngine_SetCostBasisMethod_Unknown(t *testing.T) {
+	engine := createTestEngine()
+
+	assert.ErrorIs(t, engine.SetCostBasisMethod("hifo"), ErrUnknownCostBasis)
+	assert.Equal(t, models.CostBasisAverage, engine.costBasis)
+}
+
+func TestTradingEngine_GetOpenLots_ReturnsCopy(t *testing.T) {
+	engine, config := createLotEngine(t, models.CostBasisFIFO)
+	fill(engine, config, models.OrderSideBuy, 10, 100.0)
+
+	lots := engine.GetOpenLots("AAPL")
+	lots[0].Quantity = decimal.NewFromInt(99)
+
+	assert.True(t, decimal.NewFromInt(10).Equal(engine.GetOpenLots("AAPL")[0].Quantity))
+	assert.True(t, decimal.NewFromInt(10).Equal(engine.GetPortfolio().Positions["AAPL"].Lots[0].Quantity))
+}
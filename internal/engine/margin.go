@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const interestDaysPerYear = 365
+
+type MarginConfig struct {
+	InitialRate     decimal.Decimal
+	MaintenanceRate decimal.Decimal
+	InterestRate    decimal.Decimal
+}
+
+func (e *TradingEngine) SetMargin(config MarginConfig) error {
+	if !config.InitialRate.IsPositive() || config.InitialRate.GreaterThan(decimal.NewFromInt(1)) ||
+		!config.MaintenanceRate.IsPositive() || config.MaintenanceRate.GreaterThan(config.InitialRate) ||
+		config.InterestRate.IsNegative() {
+		return fmt.Errorf("%w: need 0 < maintenance rate <= initial rate <= 1 and a non-negative interest rate", ErrInvalidMargin)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	margin := &models.MarginAccount{
+		InitialRate:     config.InitialRate,
+		MaintenanceRate: config.MaintenanceRate,
+		InterestRate:    config.InterestRate,
+	}
+	if e.portfolio.Margin != nil {
+		margin.LastAccrual = e.portfolio.Margin.LastAccrual
+	}
+	e.portfolio.Margin = margin
+	updateMargin(e.portfolio)
+	return nil
+}
+
+func updateMargin(portfolio *models.Portfolio) {
+	margin := portfolio.Margin
+	if margin == nil {
+		return
+	}
+
+	equity := portfolio.Cash
+	for _, position := range portfolio.Positions {
+		equity = equity.Add(position.MarketValue)
+	}
+	gross := grossExposure(portfolio)
+	margin.BuyingPower = decimal.Max(equity.Div(margin.InitialRate).Sub(gross), decimal.Zero)
+	margin.MaintenanceMargin = gross.Mul(margin.MaintenanceRate)
+}
+
+func (e *TradingEngine) accrueInterest() {
+	margin := e.portfolio.Margin
+	if margin == nil {
+		return
+	}
+
+	now := e.now()
+	if margin.LastAccrual.IsZero() || now.Before(margin.LastAccrual) {
+		margin.LastAccrual = now
+		return
+	}
+	days := int64(now.Sub(margin.LastAccrual) / (24 * time.Hour))
+	if days <= 0 {
+		return
+	}
+	margin.LastAccrual = margin.LastAccrual.Add(time.Duration(days) * 24 * time.Hour)
+
+	borrowed := e.portfolio.Cash.Neg()
+	if !borrowed.IsPositive() || !margin.InterestRate.IsPositive() {
+		return
+	}
+	interest := borrowed.Mul(margin.InterestRate).Mul(decimal.NewFromInt(days)).Div(decimal.NewFromInt(interestDaysPerYear))
+	e.portfolio.Cash = e.portfolio.Cash.Sub(interest)
+	e.portfolio.InterestExpense = e.portfolio.InterestExpense.Add(interest)
+	e.audit(audit.Record{Type: audit.EventMarginInterest, Interest: &interest})
+
+	e.logger.Debug("Margin interest accrued",
+		zap.String("borrowed", borrowed.String()),
+		zap.Int64("days", days),
+		zap.String("interest", interest.String()))
+}
+
+func (e *TradingEngine) liquidateMarginCall() []*models.Order {
+	margin := e.portfolio.Margin
+	if margin == nil || e.hasPendingMarginCall() {
+		return nil
+	}
+
+	equity := e.portfolio.Cash
+	gross := decimal.Zero
+	prices := make(map[string]decimal.Decimal, len(e.portfolio.Positions))
+	positions := make([]*models.Position, 0, len(e.portfolio.Positions))
+	for symbol, position := range e.portfolio.Positions {
+		price := position.CurrentPrice
+		if data, exists := e.marketData[symbol]; exists {
+			price = data.Price
+		}
+		value := price.Mul(position.Quantity)
+		equity = equity.Add(value)
+		gross = gross.Add(value.Abs())
+		prices[symbol] = price
+		positions = append(positions, position)
+	}
+
+	required := gross.Mul(margin.MaintenanceRate)
+	if equity.GreaterThanOrEqual(required) {
+		return nil
+	}
+
+	unrealized := func(position *models.Position) decimal.Decimal {
+		return prices[position.Symbol].Sub(position.AveragePrice).Mul(position.Quantity)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		left, right := unrealized(positions[i]), unrealized(positions[j])
+		if !left.Equal(right) {
+			return left.LessThan(right)
+		}
+		return positions[i].Symbol < positions[j].Symbol
+	})
+
+	e.logger.Warn("Margin call, liquidating positions",
+		zap.String("equity", equity.String()),
+		zap.String("maintenance_margin", required.String()))
+
+	excess := gross.Sub(decimal.Max(equity, decimal.Zero).Div(margin.MaintenanceRate))
+	var orders []*models.Order
+	for _, position := range positions {
+		if !excess.IsPositive() {
+			break
+		}
+		price := prices[position.Symbol]
+		if position.Quantity.IsZero() || !price.IsPositive() || e.hasPendingExit(position.Symbol) {
+			continue
+		}
+
+		quantity := position.Quantity.Abs()
+		if price.Mul(quantity).GreaterThan(excess) {
+			lotSize := e.symbols.LotSize(position.Symbol)
+			quantity = decimal.Min(excess.Div(price).Div(lotSize).Ceil().Mul(lotSize), quantity)
+		}
+		excess = excess.Sub(price.Mul(quantity))
+
+		order := e.liquidationOrder(position, price, quantity, models.ExitReasonMarginCall)
+		e.openOrders[order.ID] = order
+		e.publishOrder(order)
+		e.auditOrder(audit.EventOrderCreated, order, nil)
+		orders = append(orders, order)
+
+		e.recordRiskEvent(models.RiskEvent{
+			Type:       models.RiskEventMarginCall,
+			Symbol:     position.Symbol,
+			StrategyID: position.StrategyID,
+			Threshold:  required,
+			Quantity:   quantity,
+			OrderID:    order.ID,
+			Timestamp:  e.now(),
+		})
+	}
+	return orders
+}
+
+func (e *TradingEngine) hasPendingMarginCall() bool {
+	for _, order := range e.openOrders {
+		if order.ExitReason == models.ExitReasonMarginCall {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMarginEngine(t *testing.T, maintenance, interest float64) (*TradingEngine, *models.StrategyConfig) {
+	engine := createTestEngine()
+	require.NoError(t, engine.SetMargin(MarginConfig{
+		InitialRate:     decimal.NewFromFloat(0.5),
+		MaintenanceRate: decimal.NewFromFloat(maintenance),
+		InterestRate:    decimal.NewFromFloat(interest),
+	}))
+	config := engine.strategies["test_strategy"].GetConfig()
+	config.CommissionRate = decimal.Zero
+	return engine, config
+}
+
+func buyOnMargin(engine *TradingEngine, config *models.StrategyConfig, symbol string, quantity int64, price float64) {
+	order := createTestOrder(models.OrderSideBuy, quantity, price)
+	order.Symbol = symbol
+	engine.UpdateMarketData(symbol, createTestMarketData(symbol, price))
+	engine.executeOrder(order, config)
+	<-engine.tradeQueue
+}
+
+func TestTradingEngine_SetMargin_BuyingPower(t *testing.T) {
+	engine, config := createMarginEngine(t, 0.3, 0)
+	assert.True(t, decimal.NewFromFloat(200000.0).Equal(engine.portfolio.Margin.BuyingPower))
+
+	buyOnMargin(engine, config, "AAPL", 1500, 100.0)
+
+	margin := engine.GetPortfolio().Margin
+	require.NotNil(t, margin)
+	assert.True(t, decimal.NewFromFloat(-50000.0).Equal(engine.portfolio.Cash))
+	assert.True(t, decimal.NewFromFloat(50000.0).Equal(margin.BuyingPower), margin.BuyingPower.String())
+	assert.True(t, decimal.NewFromFloat(45000.0).Equal(margin.MaintenanceMargin), margin.MaintenanceMargin.String())
+}
+
+func TestTradingEngine_ManageRisk_MarginCallLiquidatesLeveragedLong(t *testing.T) {
+	engine, config := createMarginEngine(t, 0.3, 0)
+	buyOnMargin(engine, config, "AAPL", 2000, 100.0)
+
+	markPrice(engine, 80.0)
+	engine.manageRisk()
+	assert.Empty(t, engine.orderQueue)
+
+	markPrice(engine, 70.0)
+	engine.manageRisk()
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, models.ExitReasonMarginCall, order.ExitReason)
+	assert.Equal(t, models.OrderSideSell, order.Side)
+	assert.True(t, decimal.NewFromInt(96).Equal(order.Quantity), order.Quantity.String())
+
+	events := engine.GetRiskEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, models.RiskEventMarginCall, events[0].Type)
+	assert.Equal(t, order.ID, events[0].OrderID)
+	assert.True(t, decimal.NewFromFloat(42000.0).Equal(events[0].Threshold), events[0].Threshold.String())
+
+	engine.manageRisk()
+	assert.Empty(t, engine.orderQueue, "pending margin call is not duplicated")
+
+	engine.processOrder(order)
+	<-engine.tradeQueue
+	engine.updatePortfolio()
+	engine.manageRisk()
+
+	assert.Empty(t, engine.orderQueue)
+	assert.True(t, decimal.NewFromInt(1904).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+	assert.True(t, engine.portfolio.TotalValue.GreaterThanOrEqual(engine.portfolio.Margin.MaintenanceMargin))
+}
+
+func TestTradingEngine_ManageRisk_MarginCallLiquidatesLargestLoserFirst(t *testing.T) {
+	engine, config := createMarginEngine(t, 0.3, 0)
+	buyOnMargin(engine, config, "AAPL", 1000, 100.0)
+	buyOnMargin(engine, config, "MSFT", 1000, 100.0)
+
+	engine.UpdateMarketData("MSFT", createTestMarketData("MSFT", 95.0))
+	markPrice(engine, 40.0)
+	engine.manageRisk()
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, "AAPL", order.Symbol)
+	assert.True(t, decimal.NewFromInt(459).Equal(order.Quantity), order.Quantity.String())
+}
+
+func TestTradingEngine_UpdatePortfolio_AccruesDailyMarginInterest(t *testing.T) {
+	engine, config := createMarginEngine(t, 0.25, 0.0365)
+	buyOnMargin(engine, config, "AAPL", 1500, 100.0)
+
+	start := time.Date(2024, 3, 4, 16, 0, 0, 0, time.UTC)
+	engine.clock.current = start
+	engine.updatePortfolio()
+	engine.clock.current = start.Add(3*24*time.Hour + time.Hour)
+	engine.updatePortfolio()
+
+	assert.True(t, decimal.NewFromFloat(15.0).Equal(engine.portfolio.InterestExpense), engine.portfolio.InterestExpense.String())
+	assert.True(t, decimal.NewFromFloat(-50015.0).Equal(engine.portfolio.Cash), engine.portfolio.Cash.String())
+
+	engine.clock.current = start.Add(3*24*time.Hour + 2*time.Hour)
+	engine.updatePortfolio()
+	assert.True(t, decimal.NewFromFloat(15.0).Equal(engine.portfolio.InterestExpense))
+}
+
+func TestTradingEngine_SetMargin_Validates(t *testing.T) {
+	engine := createTestEngine()
+
+	tests := []MarginConfig{
+		{InitialRate: decimal.Zero, MaintenanceRate: decimal.NewFromFloat(0.25)},
+		{InitialRate: decimal.NewFromFloat(1.5), MaintenanceRate: decimal.NewFromFloat(0.25)},
+		{InitialRate: decimal.NewFromFloat(0.5), MaintenanceRate: decimal.NewFromFloat(0.6)},
+		{InitialRate: decimal.NewFromFloat(0.5), MaintenanceRate: decimal.NewFromFloat(0.25), InterestRate: decimal.NewFromFloat(-0.01)},
+	}
+	for _, config := range tests {
+		assert.ErrorIs(t, engine.SetMargin(config), ErrInvalidMargin)
+	}
+	assert.Nil(t, engine.portfolio.Margin)
+}
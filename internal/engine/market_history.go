@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type marketHistory struct {
+	mu       sync.RWMutex
+	capacity int
+	buffers  map[string]*ringBuffer
+}
+
+type ringBuffer struct {
+	data  []*models.MarketData
+	start int
+	size  int
+}
+
+func newMarketHistory(capacity int) *marketHistory {
+	return &marketHistory{
+		capacity: capacity,
+		buffers:  make(map[string]*ringBuffer),
+	}
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ringBuffer{data: make([]*models.MarketData, capacity)}
+}
+
+func (b *ringBuffer) push(data *models.MarketData) {
+	capacity := len(b.data)
+	if b.size < capacity {
+		b.data[(b.start+b.size)%capacity] = data
+		b.size++
+		return
+	}
+	b.data[b.start] = data
+	b.start = (b.start + 1) % capacity
+}
+
+func (b *ringBuffer) last(n int) []*models.MarketData {
+	if n <= 0 || n > b.size {
+		n = b.size
+	}
+
+	result := make([]*models.MarketData, n)
+	offset := b.size - n
+	for i := 0; i < n; i++ {
+		result[i] = b.data[(b.start+offset+i)%len(b.data)]
+	}
+	return result
+}
+
+func (h *marketHistory) add(symbol string, data *models.MarketData) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buffer, exists := h.buffers[symbol]
+	if !exists {
+		buffer = newRingBuffer(h.capacity)
+		h.buffers[symbol] = buffer
+	}
+	buffer.push(data)
+}
+
+func (h *marketHistory) get(symbol string, n int) []*models.MarketData {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	buffer, exists := h.buffers[symbol]
+	if !exists {
+		return []*models.MarketData{}
+	}
+	return buffer.last(n)
+}
+
+func (h *marketHistory) snapshot() map[string][]*models.MarketData {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	history := make(map[string][]*models.MarketData, len(h.buffers))
+	for symbol, buffer := range h.buffers {
+		history[symbol] = buffer.last(0)
+	}
+	return history
+}
+
+func (h *marketHistory) split(symbol string, ratio decimal.Decimal) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buffer, exists := h.buffers[symbol]
+	if !exists {
+		return
+	}
+	for i := 0; i < buffer.size; i++ {
+		index := (buffer.start + i) % len(buffer.data)
+		buffer.data[index] = buffer.data[index].SplitAdjusted(ratio)
+	}
+}
+
+func (h *marketHistory) resize(capacity int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.capacity = capacity
+	for symbol, buffer := range h.buffers {
+		resized := newRingBuffer(capacity)
+		for _, data := range buffer.last(capacity) {
+			resized.push(data)
+		}
+		h.buffers[symbol] = resized
+	}
+}
+
+func (h *marketHistory) size() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.capacity
+}
@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketHistory_EvictsOldestEntries(t *testing.T) {
+	history := newMarketHistory(3)
+
+	for i := 1; i <= 5; i++ {
+		history.add("AAPL", createTestMarketData("AAPL", float64(i)))
+	}
+
+	prices := historyPrices(history.get("AAPL", 0))
+	assert.Equal(t, []float64{3, 4, 5}, prices)
+}
+
+func TestMarketHistory_GetLastN(t *testing.T) {
+	history := newMarketHistory(5)
+	for i := 1; i <= 4; i++ {
+		history.add("AAPL", createTestMarketData("AAPL", float64(i)))
+	}
+
+	assert.Equal(t, []float64{3, 4}, historyPrices(history.get("AAPL", 2)))
+	assert.Equal(t, []float64{1, 2, 3, 4}, historyPrices(history.get("AAPL", 10)))
+	assert.Empty(t, history.get("MSFT", 2))
+}
+
+func TestMarketHistory_ResizeKeepsNewest(t *testing.T) {
+	history := newMarketHistory(5)
+	for i := 1; i <= 5; i++ {
+		history.add("AAPL", createTestMarketData("AAPL", float64(i)))
+	}
+
+	history.resize(2)
+	assert.Equal(t, []float64{4, 5}, historyPrices(history.get("AAPL", 0)))
+
+	history.resize(4)
+	history.add("AAPL", createTestMarketData("AAPL", 6))
+	history.add("AAPL", createTestMarketData("AAPL", 7))
+	history.add("AAPL", createTestMarketData("AAPL", 8))
+	assert.Equal(t, []float64{5, 6, 7, 8}, historyPrices(history.get("AAPL", 0)))
+}
+
+func TestTradingEngine_GetMarketDataHistory(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetMarketHistorySize(3)
+
+	for i := 1; i <= 4; i++ {
+		engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", float64(i)))
+	}
+
+	assert.Equal(t, []float64{3, 4}, historyPrices(engine.GetMarketDataHistory("AAPL", 2)))
+	assert.Equal(t, []float64{2, 3, 4}, historyPrices(engine.GetMarketDataHistory("AAPL", 0)))
+}
+
+func TestTradingEngine_AddStrategy_GrowsHistory(t *testing.T) {
+	engine := createTestEngine()
+	assert.Equal(t, 30, engine.history.size())
+
+	config := createTestStrategyConfig()
+	config.ID = "wide_window"
+	config.MarketDataWindow = 200
+	engine.AddStrategy(&stubStrategy{config: config})
+
+	assert.Equal(t, 200, engine.history.size())
+}
+
+func TestTradingEngine_ExecuteStrategies_UsesHistory(t *testing.T) {
+	engine := createTestEngine()
+
+	for i := 0; i < 31; i++ {
+		engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0+float64(i)))
+	}
+	engine.executeStrategies(context.Background())
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, "AAPL", order.Symbol)
+	assert.Equal(t, models.OrderSideBuy, order.Side)
+}
+
+func TestTradingEngine_SeedMarketHistory_SignalsOnFirstLiveTick(t *testing.T) {
+	engine := createTestEngine()
+	require.Equal(t, 30, engine.RequiredHistory())
+
+	history := make([]*models.MarketData, engine.RequiredHistory())
+	for i := range history {
+		history[i] = createTestMarketData("AAPL", 150.0+float64(i))
+	}
+	require.NoError(t, engine.SeedMarketHistory("AAPL", history))
+	engine.executeStrategies(context.Background())
+	assert.Empty(t, engine.orderQueue)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 180.0))
+	engine.executeStrategies(context.Background())
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, "AAPL", order.Symbol)
+	assert.Equal(t, models.OrderSideBuy, order.Side)
+	assert.Len(t, engine.GetMarketDataHistory("AAPL", 0), 30)
+}
+
+func TestTradingEngine_SeedMarketHistory_RejectsInvalidHistory(t *testing.T) {
+	engine := createTestEngine()
+	later := createTestMarketData("AAPL", 151.0)
+	earlier := createTestMarketData("AAPL", 150.0)
+	earlier.Timestamp = later.Timestamp.Add(-time.Minute)
+
+	assert.ErrorIs(t, engine.SeedMarketHistory("AAPL", []*models.MarketData{createTestMarketData("MSFT", 300.0)}), ErrInvalidMarketData)
+	assert.ErrorIs(t, engine.SeedMarketHistory("AAPL", []*models.MarketData{later, earlier}), ErrInvalidMarketData)
+	assert.ErrorIs(t, engine.SeedMarketHistory("AAPL", []*models.MarketData{nil}), ErrInvalidMarketData)
+	assert.Empty(t, engine.GetMarketDataHistory("AAPL", 0))
+}
+
+func TestTradingEngine_MarketHistory_ConcurrentAccess(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetMarketHistorySize(16)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0+float64(i%10)))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			assert.LessOrEqual(t, len(engine.GetMarketDataHistory("AAPL", 0)), 16)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			for _, data := range engine.history.snapshot()["AAPL"] {
+				_ = data.Price
+			}
+		}
+	}()
+	wg.Wait()
+
+	assert.Len(t, engine.GetMarketDataHistory("AAPL", 0), 16)
+}
+
+type stubStrategy struct {
+	config *models.StrategyConfig
+	result *models.AlgorithmResult
+	calls  atomic.Int32
+}
+
+func (s *stubStrategy) ID() string   { return s.config.ID }
+func (s *stubStrategy) Name() string { return s.config.Name }
+func (s *stubStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	s.calls.Add(1)
+	return s.result, nil
+}
+func (s *stubStrategy) ValidateOrder(order *models.Order, portfolio *models.Portfolio) error {
+	return nil
+}
+func (s *stubStrategy) CalculateRisk(order *models.Order, portfolio *models.Portfolio) (*models.RiskMetrics, error) {
+	return &models.RiskMetrics{}, nil
+}
+func (s *stubStrategy) UpdateConfig(config *models.StrategyConfig) error {
+	s.config = config
+	return nil
+}
+func (s *stubStrategy) GetConfig() *models.StrategyConfig { return s.config }
+func (s *stubStrategy) IsEnabled() bool                   { return s.config.Enabled }
+
+func createTestMarketData(symbol string, price float64) *models.MarketData {
+	return &models.MarketData{Symbol: symbol, Price: decimal.NewFromFloat(price), Timestamp: time.Now()}
+}
+
+func historyPrices(history []*models.MarketData) []float64 {
+	prices := make([]float64, len(history))
+	for i, data := range history {
+		prices[i] = data.Price.InexactFloat64()
+	}
+	return prices
+}
@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/metrics"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrapeMetrics(m *metrics.Metrics) string {
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	return recorder.Body.String()
+}
+
+func TestTradingEngine_Metrics(t *testing.T) {
+	m := metrics.New()
+	engine := createTestEngine()
+	engine.SetMetrics(m)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 10, 150.0))
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 100000, 150.0))
+	engine.drainQueues()
+	engine.updatePortfolio()
+
+	body := scrapeMetrics(m)
+	assert.Contains(t, body, `trade_algo_orders_total{side="buy",status="filled"} 1`)
+	assert.Contains(t, body, `trade_algo_orders_total{side="buy",status="rejected"} 1`)
+	assert.Contains(t, body, "trade_algo_trades_executed_total 1")
+	assert.Contains(t, body, "trade_algo_order_processing_seconds_count 2")
+	assert.Contains(t, body, "trade_algo_open_positions 1")
+}
@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"go.uber.org/zap"
+)
+
+func (e *TradingEngine) ProcessNews(event models.NewsEvent) {
+	e.mu.RLock()
+	var listeners []newsAware
+	for _, strategy := range e.strategies {
+		if aware, ok := strategy.(newsAware); ok && strategy.IsEnabled() {
+			listeners = append(listeners, aware)
+		}
+	}
+	ctx, tickDriven, ticks := e.runCtx, e.running && e.tickDriven, e.ticks
+	e.mu.RUnlock()
+
+	e.logger.Debug("News received",
+		zap.String("symbol", event.Symbol),
+		zap.String("headline", event.Headline),
+		zap.String("sentiment", event.Sentiment.String()),
+		zap.Int("strategies", len(listeners)))
+	for _, listener := range listeners {
+		listener.OnNews(event)
+	}
+	if tickDriven && ctx.Err() == nil && len(listeners) > 0 {
+		ticks.enqueue(event.Symbol)
+	}
+}
@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_ProcessNews_DrivesSentimentStrategy(t *testing.T) {
+	fake := clock.NewFake(clockStart)
+	engine := createTestEngine(WithClock(fake))
+	delete(engine.strategies, "test_strategy")
+	config := createTestStrategyConfig()
+	config.ID = "sentiment"
+	strategy := strategies.NewSentimentStrategy(config)
+	strategy.SetHoldTicks(3)
+	engine.AddStrategy(strategy)
+
+	var sides []models.OrderSide
+	for tick := 1; tick <= 6; tick++ {
+		fake.Advance(time.Second)
+		engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(100), Timestamp: fake.Now()})
+		if tick == 1 {
+			engine.ProcessNews(models.NewsEvent{Symbol: "AAPL", Headline: "AAPL beats earnings estimates", Sentiment: decimal.NewFromFloat(0.9), Severity: models.NewsSeverityHigh, Timestamp: fake.Now()})
+			engine.ProcessNews(models.NewsEvent{Symbol: "AAPL", Headline: "AAPL misses earnings estimates", Sentiment: decimal.NewFromFloat(-0.9), Severity: models.NewsSeverityHigh, Timestamp: fake.Now()})
+		}
+		engine.executeStrategiesOnTick(context.Background(), "AAPL")
+		engine.drainQueues()
+		for _, trade := range engine.GetPortfolio().TradeHistory[len(sides):] {
+			sides = append(sides, trade.Side)
+		}
+		if tick == 2 {
+			assert.Equal(t, []models.OrderSide{models.OrderSideBuy}, sides)
+		}
+	}
+
+	require.Equal(t, []models.OrderSide{models.OrderSideBuy, models.OrderSideSell}, sides)
+	trades := engine.GetPortfolio().TradeHistory
+	assert.Equal(t, clockStart.Add(4*time.Second), trades[1].Timestamp)
+	assert.NotContains(t, engine.GetPortfolio().Positions, "AAPL")
+}
@@ -0,0 +1,229 @@
+package engine
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type restingOrder struct {
+	order *models.Order
+	seq   uint64
+	queue *priceQueue
+	index int
+}
+
+type priceQueue struct {
+	orders      []*restingOrder
+	highFirst   bool
+	triggerOnly bool
+}
+
+func (q *priceQueue) price(resting *restingOrder) decimal.Decimal {
+	if q.triggerOnly {
+		return resting.order.StopPrice
+	}
+	return resting.order.Price
+}
+
+func (q *priceQueue) Len() int { return len(q.orders) }
+
+func (q *priceQueue) Less(i, j int) bool {
+	left, right := q.price(q.orders[i]), q.price(q.orders[j])
+	if !left.Equal(right) {
+		return left.GreaterThan(right) == q.highFirst
+	}
+	return q.orders[i].seq < q.orders[j].seq
+}
+
+func (q *priceQueue) Swap(i, j int) {
+	q.orders[i], q.orders[j] = q.orders[j], q.orders[i]
+	q.orders[i].index = i
+	q.orders[j].index = j
+}
+
+func (q *priceQueue) Push(x interface{}) {
+	resting := x.(*restingOrder)
+	resting.index = len(q.orders)
+	q.orders = append(q.orders, resting)
+}
+
+func (q *priceQueue) Pop() interface{} {
+	last := len(q.orders) - 1
+	resting := q.orders[last]
+	q.orders[last] = nil
+	q.orders = q.orders[:last]
+	return resting
+}
+
+func (q *priceQueue) peek() *models.Order {
+	if len(q.orders) == 0 {
+		return nil
+	}
+	return q.orders[0].order
+}
+
+func (q *priceQueue) sorted() []*models.Order {
+	ranked := make([]*restingOrder, len(q.orders))
+	copy(ranked, q.orders)
+	view := &priceQueue{orders: ranked, highFirst: q.highFirst, triggerOnly: q.triggerOnly}
+	sort.Slice(ranked, view.Less)
+
+	orders := make([]*models.Order, len(ranked))
+	for i, resting := range ranked {
+		orderCopy := *resting.order
+		orders[i] = &orderCopy
+	}
+	return orders
+}
+
+type symbolBook struct {
+	bids      *priceQueue
+	asks      *priceQueue
+	buyStops  *priceQueue
+	sellStops *priceQueue
+}
+
+func newSymbolBook() *symbolBook {
+	return &symbolBook{
+		bids:      &priceQueue{highFirst: true},
+		asks:      &priceQueue{},
+		buyStops:  &priceQueue{triggerOnly: true},
+		sellStops: &priceQueue{highFirst: true, triggerOnly: true},
+	}
+}
+
+func (b *symbolBook) queueFor(order *models.Order) *priceQueue {
+	switch {
+	case order.Type == models.OrderTypeStop && order.Side == models.OrderSideBuy:
+		return b.buyStops
+	case order.Type == models.OrderTypeStop:
+		return b.sellStops
+	case order.Side == models.OrderSideBuy:
+		return b.bids
+	default:
+		return b.asks
+	}
+}
+
+type restingBook struct {
+	orders    map[string]*restingOrder
+	symbols   map[string]*symbolBook
+	triggered map[string]bool
+	seq       uint64
+}
+
+func newRestingBook() *restingBook {
+	return &restingBook{
+		orders:    make(map[string]*restingOrder),
+		symbols:   make(map[string]*symbolBook),
+		triggered: make(map[string]bool),
+	}
+}
+
+func (b *restingBook) add(order *models.Order) {
+	b.remove(order.ID)
+	book, exists := b.symbols[order.Symbol]
+	if !exists {
+		book = newSymbolBook()
+		b.symbols[order.Symbol] = book
+	}
+	b.seq++
+	resting := &restingOrder{order: order, seq: b.seq, queue: book.queueFor(order)}
+	heap.Push(resting.queue, resting)
+	b.orders[order.ID] = resting
+}
+
+func (b *restingBook) remove(orderID string) *models.Order {
+	delete(b.triggered, orderID)
+	resting, exists := b.orders[orderID]
+	if !exists {
+		return nil
+	}
+	heap.Remove(resting.queue, resting.index)
+	delete(b.orders, orderID)
+	return resting.order
+}
+
+func (b *restingBook) consumeTrigger(orderID string) bool {
+	if !b.triggered[orderID] {
+		return false
+	}
+	delete(b.triggered, orderID)
+	return true
+}
+
+func (b *restingBook) match(symbol string, data *models.MarketData) []*models.Order {
+	book, exists := b.symbols[symbol]
+	if !exists {
+		return nil
+	}
+
+	var crossed []*models.Order
+	take := func(queue *priceQueue, hit func(*models.Order) bool) {
+		for order := queue.peek(); order != nil && hit(order); order = queue.peek() {
+			resting := heap.Pop(queue).(*restingOrder)
+			delete(b.orders, order.ID)
+			crossed = append(crossed, resting.order)
+		}
+	}
+	marketable := func(order *models.Order) bool { return limitMarketable(order, data) }
+	take(book.bids, marketable)
+	take(book.asks, marketable)
+
+	stops := len(crossed)
+	take(book.buyStops, func(order *models.Order) bool { return stopTriggered(order, data) })
+	take(book.sellStops, func(order *models.Order) bool { return stopTriggered(order, data) })
+	for _, order := range crossed[stops:] {
+		b.triggered[order.ID] = true
+	}
+
+	sort.Slice(crossed, func(i, j int) bool {
+		if !crossed[i].Timestamp.Equal(crossed[j].Timestamp) {
+			return crossed[i].Timestamp.Before(crossed[j].Timestamp)
+		}
+		return crossed[i].ID < crossed[j].ID
+	})
+	return crossed
+}
+
+func (b *restingBook) all() []*models.Order {
+	orders := make([]*models.Order, 0, len(b.orders))
+	for _, resting := range b.orders {
+		orders = append(orders, resting.order)
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ID < orders[j].ID })
+	return orders
+}
+
+func (b *restingBook) reprice(symbol string, update func(order *models.Order)) {
+	book, exists := b.symbols[symbol]
+	if !exists {
+		return
+	}
+	for _, queue := range []*priceQueue{book.bids, book.asks, book.buyStops, book.sellStops} {
+		for _, resting := range queue.orders {
+			update(resting.order)
+		}
+		heap.Init(queue)
+	}
+}
+
+func (b *restingBook) snapshot(symbol string) models.OrderBook {
+	snapshot := models.OrderBook{Symbol: symbol}
+	if book, exists := b.symbols[symbol]; exists {
+		snapshot.Bids = book.bids.sorted()
+		snapshot.Asks = book.asks.sorted()
+		snapshot.BuyStops = book.buyStops.sorted()
+		snapshot.SellStops = book.sellStops.sorted()
+	}
+	return snapshot
+}
+
+func (e *TradingEngine) GetOrderBook(symbol string) models.OrderBook {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.book.snapshot(symbol)
+}
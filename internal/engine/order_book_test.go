@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createStopOrder(side models.OrderSide, quantity int64, price, stop float64) *models.Order {
+	order := createTestOrder(side, quantity, price)
+	order.Type = models.OrderTypeStop
+	order.StopPrice = decimal.NewFromFloat(stop)
+	order.TimeInForce = models.TimeInForceGTC
+	return order
+}
+
+func bookPrices(orders []*models.Order, stops bool) []string {
+	prices := make([]string, len(orders))
+	for i, order := range orders {
+		prices[i] = order.Price.String()
+		if stops {
+			prices[i] = order.StopPrice.String()
+		}
+	}
+	return prices
+}
+
+func TestTradingEngine_GetOrderBook_SortsEachSideByPriority(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+
+	for _, price := range []float64{95, 97, 96} {
+		engine.submitOrder(createLimitOrder(models.OrderSideBuy, 10, price))
+	}
+	for _, price := range []float64{105, 103} {
+		engine.submitOrder(createLimitOrder(models.OrderSideSell, 10, price))
+	}
+	engine.submitOrder(createStopOrder(models.OrderSideBuy, 10, 100, 104))
+	engine.submitOrder(createStopOrder(models.OrderSideBuy, 10, 100, 102))
+	engine.submitOrder(createStopOrder(models.OrderSideSell, 10, 100, 98))
+	engine.drainQueues()
+
+	book := engine.GetOrderBook("AAPL")
+	assert.Equal(t, "AAPL", book.Symbol)
+	assert.Equal(t, []string{"97", "96", "95"}, bookPrices(book.Bids, false))
+	assert.Equal(t, []string{"103", "105"}, bookPrices(book.Asks, false))
+	assert.Equal(t, []string{"102", "104"}, bookPrices(book.BuyStops, true))
+	assert.Equal(t, []string{"98"}, bookPrices(book.SellStops, true))
+	assert.Empty(t, engine.GetOrderBook("MSFT").Bids)
+}
+
+func TestTradingEngine_OrderBook_MatchesOnlyCrossedOrders(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	for _, price := range []float64{95, 97, 96} {
+		engine.submitOrder(createLimitOrder(models.OrderSideBuy, 10, price))
+	}
+	engine.drainQueues()
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 96.0))
+	engine.drainQueues()
+
+	assert.Equal(t, []string{"95"}, bookPrices(engine.GetOrderBook("AAPL").Bids, false))
+	require.Len(t, engine.portfolio.TradeHistory, 2)
+	assert.True(t, decimal.NewFromInt(20).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+}
+
+func TestTradingEngine_OrderBook_StopOrdersFillAtMarketOnceTriggered(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 10, 100.0))
+	engine.drainQueues()
+
+	stop := createStopOrder(models.OrderSideSell, 10, 100, 98)
+	engine.submitOrder(stop)
+	engine.drainQueues()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 99.0))
+	engine.drainQueues()
+	assert.Equal(t, models.OrderStatusPending, stop.Status)
+	assert.Len(t, engine.GetOrderBook("AAPL").SellStops, 1)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 97.5))
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusFilled, stop.Status)
+	assert.Empty(t, engine.GetOrderBook("AAPL").SellStops)
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+	require.Len(t, engine.portfolio.TradeHistory, 2)
+	assert.True(t, decimal.NewFromFloat(97.5).Equal(engine.portfolio.TradeHistory[1].Price), engine.portfolio.TradeHistory[1].Price.String())
+}
+
+func TestTradingEngine_OrderBook_CancelRemovesRestingOrder(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	orders := []*models.Order{
+		createLimitOrder(models.OrderSideBuy, 10, 97),
+		createLimitOrder(models.OrderSideBuy, 10, 96),
+		createLimitOrder(models.OrderSideBuy, 10, 95),
+	}
+	for _, order := range orders {
+		engine.submitOrder(order)
+	}
+	engine.drainQueues()
+
+	require.NoError(t, engine.CancelOrder(orders[1].ID))
+	assert.Equal(t, []string{"97", "95"}, bookPrices(engine.GetOrderBook("AAPL").Bids, false))
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 94.0))
+	engine.drainQueues()
+	assert.Empty(t, engine.GetOrderBook("AAPL").Bids)
+	assert.Empty(t, engine.book.orders)
+}
+
+func createBookedEngine(b *testing.B, resting int) *TradingEngine {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	for i := 0; i < resting/2; i++ {
+		offset := float64(i+1) * 0.01
+		engine.book.add(createLimitOrder(models.OrderSideBuy, 1, 90-offset))
+		engine.book.add(createLimitOrder(models.OrderSideSell, 1, 110+offset))
+	}
+	require.Len(b, engine.book.orders, resting)
+	return engine
+}
+
+func BenchmarkRestingBook_Match(b *testing.B) {
+	for _, resting := range []int{100, 1000} {
+		b.Run(fmt.Sprintf("resting=%d", resting), func(b *testing.B) {
+			engine := createBookedEngine(b, resting)
+			ticks := []*models.MarketData{
+				{Symbol: "AAPL", Price: decimal.NewFromFloat(99.5), Timestamp: time.Now()},
+				{Symbol: "AAPL", Price: decimal.NewFromFloat(100.5), Timestamp: time.Now()},
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				engine.book.match("AAPL", ticks[i%2])
+			}
+		})
+	}
+}
+
+func BenchmarkRestingBook_MatchAndRequeue(b *testing.B) {
+	engine := createBookedEngine(b, 1000)
+	crossing := &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(89.99), Timestamp: time.Now()}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, order := range engine.book.match("AAPL", crossing) {
+			engine.book.add(order)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"go.uber.org/zap"
+)
+
+type dailyOrderCount struct {
+	day   time.Time
+	count int
+}
+
+func (e *TradingEngine) reserveOrRejectDailyOrder(order *models.Order, config *models.StrategyConfig) bool {
+	if err := e.reserveDailyOrder(order, config); err != nil {
+		e.rejectOrder(order, err)
+		e.logger.Warn("Order rate limit reached", zap.String("order_id", order.ID), zap.String("strategy_id", order.StrategyID), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+func (e *TradingEngine) reserveDailyOrder(order *models.Order, config *models.StrategyConfig) error {
+	if config.MaxOrdersPerDay <= 0 {
+		return nil
+	}
+
+	today := e.bookDay(order.Timestamp)
+	counter, exists := e.dailyOrders[order.StrategyID]
+	if !exists || !counter.day.Equal(today) {
+		counter = &dailyOrderCount{day: today}
+		e.dailyOrders[order.StrategyID] = counter
+	}
+
+	if counter.count >= config.MaxOrdersPerDay {
+		return strategies.ErrMaxOrdersPerDayReached
+	}
+	counter.count++
+	return nil
+}
+
+func (e *TradingEngine) releaseDailyOrder(order *models.Order, config *models.StrategyConfig) {
+	if config.MaxOrdersPerDay <= 0 {
+		return
+	}
+	if counter, exists := e.dailyOrders[order.StrategyID]; exists && counter.day.Equal(e.bookDay(order.Timestamp)) && counter.count > 0 {
+		counter.count--
+	}
+}
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_MaxOrdersPerDay(t *testing.T) {
+	engine := createTestEngine()
+	engine.strategies["test_strategy"].GetConfig().MaxOrdersPerDay = 50
+	day := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+
+	orders := submitTestOrders(engine, day, 51)
+
+	for _, order := range orders[:50] {
+		assert.Equal(t, models.OrderStatusFilled, order.Status)
+	}
+	assert.Equal(t, models.OrderStatusRejected, orders[50].Status)
+	require.Len(t, engine.portfolio.OrderHistory, 51)
+	assert.Equal(t, orders[50].ID, engine.portfolio.OrderHistory[50].ID)
+	assert.Equal(t, ErrOrderNotCancellable, engine.CancelOrder(orders[50].ID))
+}
+
+func TestTradingEngine_MaxOrdersPerDay_ResetsAtDayBoundary(t *testing.T) {
+	engine := createTestEngine()
+	engine.strategies["test_strategy"].GetConfig().MaxOrdersPerDay = 50
+	first := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+	second := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	orders := append(submitTestOrders(engine, first, 50), submitTestOrders(engine, second, 50)...)
+
+	for _, order := range orders {
+		assert.Equal(t, models.OrderStatusFilled, order.Status)
+	}
+
+	extra := submitTestOrders(engine, second.Add(time.Hour), 1)
+	assert.Equal(t, models.OrderStatusRejected, extra[0].Status)
+}
+
+func TestTradingEngine_MaxOrdersPerDay_ExitOrdersExempt(t *testing.T) {
+	engine := createTestEngine()
+	engine.strategies["test_strategy"].GetConfig().MaxOrdersPerDay = 1
+	day := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+	submitTestOrders(engine, day, 1)
+
+	exit := createTestOrder(models.OrderSideSell, 1, 150.0)
+	exit.Timestamp = day.Add(time.Minute)
+	exit.ExitReason = models.ExitReasonStopLoss
+	engine.openOrders[exit.ID] = exit
+	engine.processOrder(exit)
+
+	assert.Equal(t, models.OrderStatusFilled, exit.Status)
+}
+
+func TestTradingEngine_MaxOrdersPerDay_RejectedOrdersKeepSlot(t *testing.T) {
+	engine := createTestEngine()
+	engine.strategies["test_strategy"].GetConfig().MaxOrdersPerDay = 1
+	day := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+
+	tooSmall := createTestOrder(models.OrderSideBuy, 0, 150.0)
+	tooSmall.Quantity = tooSmall.Quantity.Add(decimal.NewFromFloat(0.1))
+	tooSmall.Timestamp = day
+	engine.openOrders[tooSmall.ID] = tooSmall
+	engine.processOrder(tooSmall)
+	require.Equal(t, models.OrderStatusRejected, tooSmall.Status)
+
+	orders := submitTestOrders(engine, day.Add(time.Minute), 2)
+	assert.Equal(t, models.OrderStatusFilled, orders[0].Status)
+	assert.Equal(t, models.OrderStatusRejected, orders[1].Status)
+}
+
+func submitTestOrders(engine *TradingEngine, day time.Time, count int) []*models.Order {
+	orders := make([]*models.Order, count)
+	for i := range orders {
+		order := createTestOrder(models.OrderSideBuy, 1, 150.0)
+		order.Timestamp = day.Add(time.Duration(i) * time.Second)
+		engine.openOrders[order.ID] = order
+		engine.processOrder(order)
+		orders[i] = order
+	}
+	for len(engine.tradeQueue) > 0 {
+		<-engine.tradeQueue
+	}
+	return orders
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"math"
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/shopspring/decimal"
+)
+
+func (e *TradingEngine) updateRiskMetrics() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	symbols := make([]string, 0, len(e.portfolio.Positions))
+	gross := 0.0
+	for symbol, position := range e.portfolio.Positions {
+		if position.MarketValue.IsZero() {
+			continue
+		}
+		symbols = append(symbols, symbol)
+		gross += math.Abs(position.MarketValue.InexactFloat64())
+	}
+	sort.Strings(symbols)
+
+	if gross == 0 {
+		e.portfolio.RiskMetrics = models.PortfolioRiskMetrics{}
+		return
+	}
+
+	histories := make([][]*models.MarketData, len(symbols))
+	positionVaR := make([]float64, len(symbols))
+	exposures := make([]float64, 0, len(symbols))
+	modelledGross := 0.0
+	modelled := make([][]*models.MarketData, 0, len(symbols))
+	weights := make([]float64, len(symbols))
+	beta := 0.0
+
+	for i, symbol := range symbols {
+		position := e.portfolio.Positions[symbol]
+		exposure := position.MarketValue.InexactFloat64()
+		histories[i] = e.history.get(symbol, 0)
+		weights[i] = math.Abs(exposure) / gross
+
+		positionBeta := e.positionBeta(symbol, histories[i])
+		position.RiskMetrics.Beta = decimal.NewFromFloat(positionBeta)
+		beta += exposure / gross * positionBeta
+
+		returns := risk.Returns(risk.Prices(histories[i]))
+		if len(returns) < risk.MinObservations {
+			continue
+		}
+
+		volatility := risk.StdDev(returns)
+		positionVaR[i] = math.Copysign(e.varModel.VaR(exposure, returns), exposure)
+		exposures = append(exposures, exposure)
+		modelledGross += math.Abs(exposure)
+		modelled = append(modelled, histories[i])
+		position.RiskMetrics.Volatility = decimal.NewFromFloat(volatility)
+		position.RiskMetrics.VaR95 = decimal.NewFromFloat(math.Abs(positionVaR[i]))
+		position.RiskMetrics.ExpectedShortfall = position.RiskMetrics.VaR95.Mul(decimal.NewFromFloat(risk.ExpectedShortfallRatio))
+	}
+
+	correlation := make([][]float64, len(symbols))
+	for i := range correlation {
+		correlation[i] = make([]float64, len(symbols))
+		correlation[i][i] = 1
+	}
+
+	correlationSum, pairs := 0.0, 0
+	for i := range symbols {
+		for j := i + 1; j < len(symbols); j++ {
+			pricesA, pricesB := risk.AlignPrices(histories[i], histories[j])
+			value, ok := risk.Correlation(risk.Returns(pricesA), risk.Returns(pricesB))
+			if !ok {
+				value = 1
+			} else {
+				correlationSum += value
+				pairs++
+			}
+			correlation[i][j] = value
+			correlation[j][i] = value
+		}
+	}
+
+	totalVaR := risk.AggregateVaR(positionVaR, correlation)
+	if _, parametric := e.varModel.(risk.ParametricModel); !parametric && len(exposures) > 0 {
+		totalVaR = e.varModel.VaR(modelledGross, risk.PortfolioReturns(modelled, exposures))
+	}
+	metrics := models.PortfolioRiskMetrics{
+		TotalVaR95:      decimal.NewFromFloat(totalVaR),
+		TotalES:         decimal.NewFromFloat(totalVaR * risk.ExpectedShortfallRatio),
+		PortfolioBeta:   decimal.NewFromFloat(beta),
+		Diversification: decimal.NewFromFloat(1 - risk.Herfindahl(weights)),
+	}
+	if pairs > 0 {
+		metrics.Correlation = decimal.NewFromFloat(correlationSum / float64(pairs))
+	}
+	e.portfolio.RiskMetrics = metrics
+}
+
+func (e *TradingEngine) positionBeta(symbol string, history []*models.MarketData) float64 {
+	if e.benchmark == "" || symbol == e.benchmark {
+		return 1
+	}
+
+	window := 0
+	if e.betaLookback > 0 {
+		window = e.betaLookback + 1
+		if window < len(history) {
+			history = history[len(history)-window:]
+		}
+	}
+	beta, ok := risk.HistoryBeta(history, e.history.get(e.benchmark, window))
+	if !ok {
+		return 1
+	}
+	return beta
+}
+
+func grossExposure(portfolio *models.Portfolio) decimal.Decimal {
+	exposure := decimal.Zero
+	for _, position := range portfolio.Positions {
+		exposure = exposure.Add(position.MarketValue.Abs())
+	}
+	return exposure
+}
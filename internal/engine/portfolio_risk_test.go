@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_UpdateRiskMetrics(t *testing.T) {
+	engine := createTestEngine()
+	config := createTestStrategyConfig()
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 100.0), config)
+	msft := createTestOrder(models.OrderSideBuy, 100, 100.0)
+	msft.Symbol = "MSFT"
+	engine.executeOrder(msft, config)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 20; i++ {
+		move := float64(i%2*2 - 1)
+		timestamp := start.Add(time.Duration(i) * time.Minute)
+		engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(100 + move), Timestamp: timestamp})
+		engine.UpdateMarketData("MSFT", &models.MarketData{Symbol: "MSFT", Price: decimal.NewFromFloat(100 + 2*move), Timestamp: timestamp})
+	}
+	engine.updatePortfolio()
+	engine.updateRiskMetrics()
+
+	metrics := engine.portfolio.RiskMetrics
+	aapl := engine.portfolio.Positions["AAPL"].RiskMetrics
+	msftRisk := engine.portfolio.Positions["MSFT"].RiskMetrics
+
+	assert.InDelta(t, 1.0, metrics.Correlation.InexactFloat64(), 1e-9)
+	assert.InDelta(t, 0.5, metrics.Diversification.InexactFloat64(), 1e-3)
+	assert.InDelta(t, 1.0, metrics.PortfolioBeta.InexactFloat64(), 1e-9)
+	assert.True(t, aapl.VaR95.IsPositive())
+	assert.True(t, msftRisk.Volatility.GreaterThan(aapl.Volatility))
+	assert.InDelta(t, aapl.VaR95.Add(msftRisk.VaR95).InexactFloat64(), metrics.TotalVaR95.InexactFloat64(), 1e-6)
+	assert.InDelta(t, metrics.TotalVaR95.InexactFloat64()*1.25, metrics.TotalES.InexactFloat64(), 1e-6)
+}
+
+func TestTradingEngine_UpdateRiskMetrics_HistoricalVaR(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetVaRModel(risk.HistoricalModel{})
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 100.0), createTestStrategyConfig())
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	price := 100.0
+	for i := 0; i < 20; i++ {
+		engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(price), Timestamp: start.Add(time.Duration(i) * time.Minute)})
+		if i == 0 {
+			price *= 0.9
+		} else {
+			price *= 1.01
+		}
+	}
+	engine.updatePortfolio()
+	engine.updateRiskMetrics()
+
+	exposure := engine.portfolio.Positions["AAPL"].MarketValue.InexactFloat64()
+	metrics := engine.portfolio.RiskMetrics
+	assert.InDelta(t, exposure*0.1, engine.portfolio.Positions["AAPL"].RiskMetrics.VaR95.InexactFloat64(), 1e-6)
+	assert.InDelta(t, exposure*0.1, metrics.TotalVaR95.InexactFloat64(), 1e-6)
+	assert.InDelta(t, exposure*0.1*1.25, metrics.TotalES.InexactFloat64(), 1e-6)
+}
+
+func TestTradingEngine_UpdateRiskMetrics_SkipsShortHistory(t *testing.T) {
+	engine := createTestEngine()
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 100.0), createTestStrategyConfig())
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 101.0))
+	engine.updatePortfolio()
+	engine.updateRiskMetrics()
+
+	metrics := engine.portfolio.RiskMetrics
+	assert.True(t, metrics.TotalVaR95.IsZero())
+	assert.True(t, metrics.Correlation.IsZero())
+	assert.True(t, metrics.Diversification.IsZero())
+	assert.InDelta(t, 1.0, metrics.PortfolioBeta.InexactFloat64(), 1e-9)
+}
+
+func TestTradingEngine_UpdatePortfolio_TotalRisk(t *testing.T) {
+	engine := createTestEngine()
+	config := createTestStrategyConfig()
+	config.CommissionRate = decimal.Zero
+	config.AllowShort = true
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 100.0), config)
+	msft := createTestOrder(models.OrderSideSell, 100, 100.0)
+	msft.Symbol = "MSFT"
+	engine.executeOrder(msft, config)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	engine.UpdateMarketData("MSFT", createTestMarketData("MSFT", 100.0))
+
+	engine.updatePortfolio()
+
+	require.True(t, decimal.NewFromFloat(100000.0).Equal(engine.portfolio.TotalValue))
+	assert.True(t, decimal.NewFromFloat(0.2).Equal(engine.portfolio.TotalRisk), engine.portfolio.TotalRisk.String())
+}
+
+func TestTradingEngine_SetBenchmark(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetBenchmark("SPY", 40)
+	assert.Equal(t, 41, engine.history.size())
+
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 150.0), createTestStrategyConfig())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	spy, aapl := 400.0, 150.0
+	for i := 0; i < 21; i++ {
+		timestamp := start.Add(time.Duration(i) * time.Minute)
+		engine.UpdateMarketData("SPY", &models.MarketData{Symbol: "SPY", Price: decimal.NewFromFloat(spy), Timestamp: timestamp})
+		engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(aapl), Timestamp: timestamp})
+		change := 0.01
+		if i%2 == 1 {
+			change = -0.01
+		}
+		spy *= 1 + change
+		aapl *= 1 + 2*change
+	}
+	engine.updatePortfolio()
+	engine.updateRiskMetrics()
+
+	assert.InDelta(t, 2.0, engine.portfolio.Positions["AAPL"].RiskMetrics.Beta.InexactFloat64(), 1e-6)
+	assert.InDelta(t, 2.0, engine.portfolio.RiskMetrics.PortfolioBeta.InexactFloat64(), 1e-6)
+
+	order := createTestOrder(models.OrderSideBuy, 10, aapl)
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	assert.InDelta(t, 2.0, order.RiskMetrics.Beta.InexactFloat64(), 1e-6)
+}
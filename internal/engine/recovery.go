@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"go.uber.org/zap"
+)
+
+func (e *TradingEngine) SetSnapshotting(path string, interval time.Duration) error {
+	if path == "" || interval < 0 {
+		return fmt.Errorf("%w: need a path and a non-negative interval", ErrInvalidSnapshot)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.snapshotPath = path
+	e.snapshotEvery = interval
+	return nil
+}
+
+func RecoverTradingEngine(statePath, auditPath string, logger *zap.Logger, opts ...Option) (*TradingEngine, error) {
+	engine, document, err := loadTradingEngine(statePath, logger, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if document.AuditSequence == nil || auditPath == "" {
+		engine.resubmit(engine.restoreOpenOrders())
+		return engine, nil
+	}
+
+	records, err := audit.Read(auditPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", auditPath, err)
+	}
+	newer := sort.Search(len(records), func(i int) bool { return records[i].Sequence > *document.AuditSequence })
+	if err := engine.ReplayAudit(records[newer:]); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Recovered trading engine",
+		zap.String("state_file", statePath),
+		zap.Uint64("snapshot_sequence", *document.AuditSequence),
+		zap.Int("replayed_records", len(records)-newer),
+		zap.Int("open_orders", len(engine.GetOpenOrders())))
+	return engine, nil
+}
+
+func (e *TradingEngine) snapshotWriter(ctx context.Context, stop <-chan struct{}, path string, interval time.Duration) {
+	ticker := e.wallClock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			e.writeSnapshot(path)
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (e *TradingEngine) writeSnapshot(path string) {
+	start := time.Now()
+	if err := e.SaveState(path); err != nil {
+		e.logger.Error("Failed to write portfolio snapshot", zap.String("state_file", path), zap.Error(err))
+		return
+	}
+	e.logger.Debug("Portfolio snapshot written", zap.String("state_file", path), zap.Duration("duration", time.Since(start)))
+}
+
+func (e *TradingEngine) replayOpenOrder(record audit.Record) {
+	if record.Order == nil {
+		return
+	}
+	if record.ReplacesID != "" {
+		delete(e.openOrders, record.ReplacesID)
+	}
+	switch record.Order.Status {
+	case models.OrderStatusPending, models.OrderStatusPartiallyFilled:
+		e.openOrders[record.Order.ID] = copyOrder(record.Order)
+	default:
+		delete(e.openOrders, record.Order.ID)
+	}
+}
+
+func (e *TradingEngine) restoreOpenOrders() []*models.Order {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	orders := make([]*models.Order, 0, len(e.openOrders))
+	sliced := make(map[string]bool)
+	for _, order := range e.openOrders {
+		orders = append(orders, order)
+		if order.ParentID != "" {
+			sliced[order.ParentID] = true
+		}
+	}
+	sortOrders(orders)
+
+	var queued []*models.Order
+	for _, order := range orders {
+		e.book.remove(order.ID)
+		if order.ParentID != "" || sliced[order.ID] {
+			delete(e.openOrders, order.ID)
+			e.cancelOrder(order, models.CancelReasonRecovery)
+			continue
+		}
+		if !e.restOrder(order) {
+			queued = append(queued, order)
+		}
+	}
+	return queued
+}
+
+func (e *TradingEngine) resubmit(orders []*models.Order) {
+	for _, order := range orders {
+		e.enqueueOrder(order)
+	}
+}
+
+func sortOrders(orders []*models.Order) {
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].Timestamp.Equal(orders[j].Timestamp) {
+			return orders[i].Timestamp.Before(orders[j].Timestamp)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+}
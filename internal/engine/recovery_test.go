@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRecoverTradingEngine_ReplaysAuditAfterSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	statePath, auditPath := filepath.Join(dir, "state.json"), filepath.Join(dir, "audit.jsonl")
+	writer, err := audit.NewWriter(auditPath, audit.WithUnbuffered())
+	require.NoError(t, err)
+	defer writer.Close()
+
+	fake := clock.NewFake(clockStart)
+	engine := createTestEngine(WithClock(fake))
+	engine.SetAuditLog(writer)
+	tick := func(price float64) {
+		fake.Advance(time.Second)
+		engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(price), Timestamp: fake.Now()})
+		engine.drainQueues()
+	}
+	rest := func(side models.OrderSide, quantity int64, price float64) *models.Order {
+		order := createLimitOrder(side, quantity, price)
+		order.Timestamp = fake.Now()
+		engine.submitOrder(order)
+		engine.drainQueues()
+		return order
+	}
+
+	tick(150.0)
+	auditSignal(engine, "buy", 101, 150.0)
+	filledLater := rest(models.OrderSideBuy, 10, 140.0)
+	engine.writeSnapshot(statePath)
+
+	tick(152.0)
+	auditSignal(engine, "sell", 30, 152.0)
+	tick(139.0)
+	stillOpen := rest(models.OrderSideSell, 20, 160.0)
+	auditSignal(engine, "buy", 40, 139.0)
+	require.Len(t, engine.GetPortfolio().TradeHistory, 4)
+
+	recovered, err := RecoverTradingEngine(statePath, auditPath, zap.NewNop(), WithClock(fake))
+	require.NoError(t, err)
+
+	for _, e := range []*TradingEngine{engine, recovered} {
+		e.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(145), Timestamp: fake.Now()})
+		e.updatePortfolio()
+	}
+	original, rebuilt := engine.GetPortfolio(), recovered.GetPortfolio()
+	for _, portfolio := range []*models.Portfolio{original, rebuilt} {
+		for _, position := range portfolio.Positions {
+			position.PeakPrice, position.TroughPrice = decimal.Zero, decimal.Zero
+		}
+	}
+	assert.True(t, original.Cash.Equal(rebuilt.Cash), "cash %s != %s", original.Cash, rebuilt.Cash)
+	assert.True(t, original.TotalValue.Equal(rebuilt.TotalValue))
+	assert.True(t, original.RealizedPnL.Equal(rebuilt.RealizedPnL))
+	assertSameJSON(t, original.Positions, rebuilt.Positions)
+	assertSameJSON(t, original.TradeHistory, rebuilt.TradeHistory)
+	assertSameJSON(t, original.OrderHistory, rebuilt.OrderHistory)
+	assertSameJSON(t, engine.GetStrategyPerformance(), recovered.GetStrategyPerformance())
+
+	assert.Equal(t, models.OrderStatusFilled, rebuilt.OrderHistory[2].Status)
+	assert.Equal(t, filledLater.ID, rebuilt.OrderHistory[2].ID)
+	openOrders := recovered.GetOpenOrders()
+	require.Len(t, openOrders, 1)
+	assert.Equal(t, stillOpen.ID, openOrders[0].ID)
+	assert.Len(t, recovered.GetOrderBook("AAPL").Asks, 1)
+}
+
+func TestTradingEngine_SetSnapshotting_WritesPeriodically(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	fake := clock.NewFake(clockStart)
+	engine := createTestEngine(WithClock(fake))
+	engine.SetIntervals(Intervals{Strategy: time.Hour, Risk: time.Hour, Portfolio: time.Hour})
+	require.NoError(t, engine.SetSnapshotting(path, time.Minute))
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+
+	require.NoError(t, engine.Start(context.Background()))
+	defer engine.Stop()
+	fake.BlockUntil(4)
+	resting := createLimitOrder(models.OrderSideBuy, 10, 140.0)
+	engine.submitOrder(resting)
+	require.Eventually(t, func() bool { return len(engine.GetOrderBook("AAPL").Bids) == 1 }, time.Second, time.Millisecond)
+
+	fake.Advance(time.Minute)
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	restored, err := NewTradingEngineFromState(path, zap.NewNop())
+	require.NoError(t, err)
+	openOrders := restored.GetOpenOrders()
+	require.Len(t, openOrders, 1)
+	assert.Equal(t, resting.ID, openOrders[0].ID)
+	assert.Len(t, restored.GetOrderBook("AAPL").Bids, 1)
+}
+
+func TestTradingEngine_SetSnapshotting_Invalid(t *testing.T) {
+	engine := createTestEngine()
+
+	assert.ErrorIs(t, engine.SetSnapshotting("", time.Minute), ErrInvalidSnapshot)
+	assert.ErrorIs(t, engine.SetSnapshotting("state.json", -time.Minute), ErrInvalidSnapshot)
+}
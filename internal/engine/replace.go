@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func (e *TradingEngine) ModifyOrder(orderID string, price, quantity decimal.Decimal) error {
+	replacement, err := e.replaceOrder(orderID, price, quantity)
+	if err != nil {
+		return err
+	}
+	e.enqueueOrder(replacement)
+	return nil
+}
+
+func (e *TradingEngine) replaceOrder(orderID string, price, quantity decimal.Decimal) (*models.Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	order, exists := e.openOrders[orderID]
+	if !exists {
+		return nil, e.cancelError(orderID)
+	}
+	if _, sliced := e.slicedOrders[orderID]; sliced || order.ExitReason != "" {
+		return nil, fmt.Errorf("%w: %s is managed by the engine", ErrOrderNotModifiable, orderID)
+	}
+	strategy, exists := e.strategies[order.StrategyID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrStrategyNotFound, order.StrategyID)
+	}
+
+	if price.IsZero() {
+		price = order.Price
+	}
+	if quantity.IsZero() {
+		quantity = order.Quantity.Sub(order.FilledQuantity)
+	}
+	replacement := &models.Order{
+		ID:            e.newOrderID(),
+		Symbol:        order.Symbol,
+		Side:          order.Side,
+		Type:          order.Type,
+		Quantity:      quantity,
+		Price:         price,
+		StopPrice:     order.StopPrice,
+		DecisionPrice: order.DecisionPrice,
+		TimeInForce:   order.TimeInForce,
+		Status:        models.OrderStatusPending,
+		Timestamp:     e.now(),
+		StrategyID:    order.StrategyID,
+	}
+	if err := strategy.ValidateOrder(replacement, e.portfolioFor(order.StrategyID, e.portfolio)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOrderNotModifiable, err)
+	}
+
+	delete(e.openOrders, orderID)
+	e.book.remove(orderID)
+	order.ReplacedByID = replacement.ID
+	e.cancelOrder(order, models.CancelReasonReplaced)
+
+	e.openOrders[replacement.ID] = replacement
+	e.publishOrder(replacement)
+	e.audit(audit.Record{Type: audit.EventOrderModified, Order: replacement, ReplacesID: orderID})
+	e.logger.Info("Order replaced",
+		zap.String("order_id", orderID),
+		zap.String("replacement_id", replacement.ID),
+		zap.String("price", price.String()),
+		zap.String("quantity", quantity.String()),
+	)
+	return replacement, nil
+}
@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func restTestLimitOrder(t *testing.T, engine *TradingEngine) *models.Order {
+	t.Helper()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 101.0))
+	order := createLimitOrder(models.OrderSideBuy, 10, 100.0)
+	engine.submitOrder(order)
+	engine.drainQueues()
+	require.Contains(t, engine.book.orders, order.ID)
+	return order
+}
+
+func tradesFor(engine *TradingEngine, orderID string) int {
+	count := 0
+	for _, trade := range engine.GetPortfolio().TradeHistory {
+		if trade.OrderID == orderID {
+			count++
+		}
+	}
+	return count
+}
+
+func TestTradingEngine_ModifyOrder_ReplacesRestingOrder(t *testing.T) {
+	engine := createTestEngine()
+	order := restTestLimitOrder(t, engine)
+
+	require.NoError(t, engine.ModifyOrder(order.ID, decimal.NewFromFloat(100.5), decimal.NewFromInt(5)))
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusCancelled, order.Status)
+	assert.Equal(t, models.CancelReasonReplaced, order.CancelReason)
+	require.NotEmpty(t, order.ReplacedByID)
+
+	open := engine.GetOpenOrders()
+	require.Len(t, open, 1)
+	replacement := open[0]
+	assert.Equal(t, order.ReplacedByID, replacement.ID)
+	assert.Equal(t, order.StrategyID, replacement.StrategyID)
+	assert.Equal(t, order.TimeInForce, replacement.TimeInForce)
+	assert.True(t, decimal.NewFromFloat(100.5).Equal(replacement.Price))
+	assert.True(t, decimal.NewFromInt(5).Equal(replacement.Quantity))
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.5))
+	engine.drainQueues()
+	assert.Equal(t, 1, tradesFor(engine, replacement.ID))
+	assert.Zero(t, tradesFor(engine, order.ID))
+}
+
+func TestTradingEngine_ModifyOrder_KeepsUnchangedValuesAndReplacesRemainder(t *testing.T) {
+	engine := createTestEngine()
+	order := restTestLimitOrder(t, engine)
+	order.FilledQuantity = decimal.NewFromInt(4)
+
+	require.NoError(t, engine.ModifyOrder(order.ID, decimal.NewFromFloat(99.5), decimal.Zero))
+	engine.drainQueues()
+
+	replacement := engine.GetOpenOrders()[0]
+	assert.True(t, decimal.NewFromFloat(99.5).Equal(replacement.Price))
+	assert.True(t, decimal.NewFromInt(6).Equal(replacement.Quantity))
+}
+
+func TestTradingEngine_ModifyOrder_InvalidValuesKeepOriginal(t *testing.T) {
+	engine := createTestEngine()
+	order := restTestLimitOrder(t, engine)
+
+	err := engine.ModifyOrder(order.ID, decimal.Zero, decimal.NewFromInt(1000))
+	assert.ErrorIs(t, err, ErrOrderNotModifiable)
+	assert.ErrorIs(t, err, strategies.ErrOrderTooLarge)
+
+	assert.Equal(t, models.OrderStatusPending, order.Status)
+	assert.Empty(t, order.ReplacedByID)
+	assert.Contains(t, engine.book.orders, order.ID)
+}
+
+func TestTradingEngine_ModifyOrder_UnknownAndFilledOrders(t *testing.T) {
+	engine := createTestEngine()
+	assert.ErrorIs(t, engine.ModifyOrder("missing", decimal.Zero, decimal.NewFromInt(1)), ErrOrderNotFound)
+
+	order := restTestLimitOrder(t, engine)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 99.0))
+	engine.drainQueues()
+
+	assert.ErrorIs(t, engine.ModifyOrder(order.ID, decimal.NewFromFloat(98.0), decimal.Zero), ErrOrderAlreadyFilled)
+}
+
+func TestTradingEngine_ModifyOrder_RaceWithFillExecutesOnce(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		engine := createTestEngine()
+		order := restTestLimitOrder(t, engine)
+
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		var modifyErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			<-start
+			engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 99.0))
+			engine.drainQueues()
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+			modifyErr = engine.ModifyOrder(order.ID, decimal.NewFromFloat(98.0), decimal.Zero)
+		}()
+		close(start)
+		wg.Wait()
+		engine.drainQueues()
+
+		if modifyErr == nil {
+			assert.Equal(t, models.OrderStatusCancelled, order.Status)
+			assert.Zero(t, tradesFor(engine, order.ID))
+		} else {
+			assert.ErrorIs(t, modifyErr, ErrOrderAlreadyFilled)
+			assert.Equal(t, models.OrderStatusFilled, order.Status)
+			assert.Equal(t, 1, tradesFor(engine, order.ID))
+		}
+		assert.LessOrEqual(t, len(engine.GetPortfolio().TradeHistory), 1)
+	}
+}
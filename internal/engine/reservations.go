@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type reservation struct {
+	symbol     string
+	strategyID string
+	cash       decimal.Decimal
+	shares     decimal.Decimal
+	bought     decimal.Decimal
+}
+
+func (e *TradingEngine) reserve(order *models.Order, config *models.StrategyConfig) {
+	if order.ExitReason != "" {
+		return
+	}
+
+	held := reservation{symbol: order.Symbol, strategyID: order.StrategyID}
+	quantity := fillQuantity(order)
+	if order.Side == models.OrderSideBuy {
+		worstCase := *order
+		worstCase.Quantity = quantity
+		worstCase.Price = execution.FillPrice(order.Side, order.Price, config.SlippageTolerance)
+		held.cash = worstCase.Price.Mul(quantity).Add(e.commissionFor(&worstCase, config))
+		held.bought = quantity
+	} else {
+		held.shares = quantity
+	}
+	e.reservations[order.ID] = held
+}
+
+func (e *TradingEngine) release(orderID string) {
+	delete(e.reservations, orderID)
+}
+
+func (e *TradingEngine) unreserved(order *models.Order, portfolio *models.Portfolio) *models.Portfolio {
+	_, sleeved := e.allocations[order.StrategyID]
+	currency := e.currencyOf(order.Symbol)
+	converted := portfolio == e.portfolio && e.foreign(currency)
+	cash, shares := decimal.Zero, decimal.Zero
+	for _, held := range e.reservations {
+		if sleeved && held.strategyID != order.StrategyID {
+			continue
+		}
+		if portfolio == e.portfolio && e.currencyOf(held.symbol) != currency {
+			continue
+		}
+		cash = cash.Add(held.cash)
+		if held.symbol == order.Symbol {
+			shares = shares.Add(held.shares)
+		}
+	}
+	if cash.IsZero() && shares.IsZero() && !converted {
+		return portfolio
+	}
+
+	view := *portfolio
+	view.Cash = portfolio.Cash.Sub(cash)
+	if converted {
+		view.Cash = e.currencyCash(portfolio, currency).Sub(cash)
+	}
+	if portfolio.Margin != nil {
+		margin := *portfolio.Margin
+		margin.BuyingPower = decimal.Max(margin.BuyingPower.Sub(cash), decimal.Zero)
+		view.Margin = &margin
+	}
+	if position, exists := portfolio.Positions[order.Symbol]; exists && shares.IsPositive() {
+		view.Positions = make(map[string]*models.Position, len(portfolio.Positions))
+		for symbol, held := range portfolio.Positions {
+			view.Positions[symbol] = held
+		}
+		remaining := *position
+		remaining.Quantity = position.Quantity.Sub(shares)
+		view.Positions[order.Symbol] = &remaining
+	}
+	return &view
+}
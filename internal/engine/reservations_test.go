@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createReservingEngine() *TradingEngine {
+	engine := createTestEngine()
+	engine.SetLatencyModel(execution.FixedLatency{Delay: 4 * time.Second})
+	engine.portfolio.Cash = decimal.NewFromInt(80000)
+	tickRamp(engine, 0)
+	return engine
+}
+
+func TestTradingEngine_Reservations_OnlyOneOfTwoBuysAccepted(t *testing.T) {
+	engine := createReservingEngine()
+
+	first := createTestOrder(models.OrderSideBuy, 450, rampPrice(0))
+	second := createTestOrder(models.OrderSideBuy, 450, rampPrice(0))
+	engine.submitOrder(first)
+	engine.submitOrder(second)
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusPending, first.Status)
+	assert.Equal(t, models.OrderStatusRejected, second.Status)
+	require.Len(t, engine.GetOpenOrders(), 1)
+
+	tickRamp(engine, 4)
+	assert.Equal(t, models.OrderStatusFilled, first.Status)
+	assert.True(t, engine.portfolio.Cash.IsPositive(), engine.portfolio.Cash.String())
+	assert.Empty(t, engine.reservations)
+}
+
+func TestTradingEngine_Reservations_SellsCannotExceedUndeliveredShares(t *testing.T) {
+	engine := createTestEngine()
+	tickRamp(engine, 0)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, rampPrice(0)), engine.strategies["test_strategy"].GetConfig())
+	engine.drainQueues()
+	engine.SetLatencyModel(execution.FixedLatency{Delay: 4 * time.Second})
+
+	first := createTestOrder(models.OrderSideSell, 60, rampPrice(0))
+	second := createTestOrder(models.OrderSideSell, 60, rampPrice(0))
+	engine.submitOrder(first)
+	engine.submitOrder(second)
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusPending, first.Status)
+	assert.Equal(t, models.OrderStatusRejected, second.Status)
+
+	tickRamp(engine, 4)
+	assert.True(t, decimal.NewFromInt(40).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+}
+
+func TestTradingEngine_Reservations_ReleasedOnCancel(t *testing.T) {
+	engine := createReservingEngine()
+
+	first := createTestOrder(models.OrderSideBuy, 450, rampPrice(0))
+	engine.submitOrder(first)
+	engine.drainQueues()
+	require.Len(t, engine.reservations, 1)
+	require.NoError(t, engine.CancelOrder(first.ID))
+	assert.Empty(t, engine.reservations)
+
+	second := createTestOrder(models.OrderSideBuy, 450, rampPrice(0))
+	engine.submitOrder(second)
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusPending, second.Status)
+	tickRamp(engine, 4)
+	assert.Equal(t, models.OrderStatusFilled, second.Status)
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_RestartAfterStop(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+
+	require.NoError(t, engine.Start(context.Background()))
+	engine.Stop()
+	require.NoError(t, engine.Start(context.Background()))
+	defer engine.Stop()
+
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 10, 150.0))
+
+	assert.Eventually(t, func() bool {
+		portfolio := engine.GetPortfolioSummary()
+		return portfolio.OrderCount == 1 && portfolio.TradeCount == 1
+	}, time.Second, time.Millisecond)
+}
+
+func TestTradingEngine_RestartKeepsStrategiesRunning(t *testing.T) {
+	fake := clock.NewFake(clockStart)
+	engine, strategy := createCountingEngine(Intervals{Strategy: time.Second, Risk: time.Hour, Portfolio: time.Hour}, WithClock(fake))
+
+	require.NoError(t, engine.Start(context.Background()))
+	fake.BlockUntil(3)
+	fake.Advance(time.Second)
+	require.Eventually(t, func() bool { return strategy.calls.Load() == 1 }, time.Second, time.Millisecond)
+	engine.Stop()
+
+	require.NoError(t, engine.Start(context.Background()))
+	defer engine.Stop()
+	fake.BlockUntil(3)
+
+	for calls := int32(2); calls <= 4; calls++ {
+		fake.Advance(time.Second)
+		require.Eventually(t, func() bool { return strategy.calls.Load() == calls }, time.Second, time.Millisecond)
+	}
+}
+
+func TestTradingEngine_RestartReopensJournal(t *testing.T) {
+	store, err := storage.NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	defer store.Close()
+
+	engine := createTestEngine()
+	engine.SetTradeStore(store, 0)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+
+	require.NoError(t, engine.Start(context.Background()))
+	engine.Stop()
+	require.NoError(t, engine.Start(context.Background()))
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 10, 150.0))
+	engine.Stop()
+
+	trades, err := store.ListTradesBySymbol(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.Len(t, trades, 1)
+}
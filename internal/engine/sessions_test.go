@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/calendar"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var sessionClose = time.Date(2024, 3, 5, 16, 0, 0, 0, time.UTC)
+
+func createTestCalendar(t *testing.T) *calendar.Calendar {
+	t.Helper()
+	session, err := calendar.ParseSession("09:30-16:00", time.UTC)
+	require.NoError(t, err)
+	cal := calendar.NewCalendar(session)
+	cal.SetSession("BTCUSDT", calendar.AlwaysOpen())
+	return cal
+}
+
+func createTestSignalEngine(t *testing.T, symbol string) *TradingEngine {
+	t.Helper()
+	engine := NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	engine.SetCalendar(createTestCalendar(t))
+	engine.AddStrategy(&stubStrategy{
+		config: &models.StrategyConfig{ID: "signal", Name: "Signal", Enabled: true},
+		result: &models.AlgorithmResult{StrategyID: "signal", Symbol: symbol, Action: "buy", Quantity: decimal.NewFromInt(1), Price: decimal.NewFromFloat(100.0)},
+	})
+	return engine
+}
+
+func TestTradingEngine_SkipsSignalsWhileMarketClosed(t *testing.T) {
+	engine := createTestSignalEngine(t, "AAPL")
+	ctx := context.Background()
+
+	engine.Advance(ctx, sessionClose.Add(-time.Minute))
+	require.Len(t, engine.GetPortfolio().OrderHistory, 1)
+
+	for now := sessionClose; now.Before(sessionClose.Add(time.Hour)); now = now.Add(strategyInterval) {
+		engine.Advance(ctx, now)
+	}
+	assert.Len(t, engine.GetPortfolio().OrderHistory, 1)
+	assert.Len(t, engine.GetPortfolio().TradeHistory, 1)
+
+	engine.Advance(ctx, sessionClose.Add(17*time.Hour+30*time.Minute))
+	assert.Len(t, engine.GetPortfolio().OrderHistory, 2)
+}
+
+func TestTradingEngine_AlwaysOpenSymbolTradesAfterClose(t *testing.T) {
+	engine := createTestSignalEngine(t, "BTCUSDT")
+
+	engine.Advance(context.Background(), sessionClose.Add(time.Hour))
+
+	orders := engine.GetPortfolio().OrderHistory
+	require.Len(t, orders, 1)
+	assert.Equal(t, models.OrderStatusFilled, orders[0].Status)
+}
+
+func TestTradingEngine_ExpiresDayOrdersAfterClose(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetCalendar(createTestCalendar(t))
+
+	dayOrder := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	dayOrder.TimeInForce = models.TimeInForceDay
+	dayOrder.Timestamp = sessionClose.Add(-time.Second)
+	gtcOrder := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	gtcOrder.TimeInForce = models.TimeInForceGTC
+	gtcOrder.Timestamp = sessionClose.Add(-time.Second)
+	engine.openOrders[dayOrder.ID] = dayOrder
+	engine.openOrders[gtcOrder.ID] = gtcOrder
+
+	engine.clock.current = sessionClose.Add(time.Second)
+	engine.processOrder(dayOrder)
+	engine.processOrder(gtcOrder)
+
+	assert.Equal(t, models.OrderStatusCancelled, dayOrder.Status)
+	assert.Equal(t, models.CancelReasonDayExpired, dayOrder.CancelReason)
+	assert.Equal(t, models.OrderStatusFilled, gtcOrder.Status)
+	assert.Len(t, engine.tradeQueue, 1)
+	assert.Empty(t, engine.GetOpenOrders())
+}
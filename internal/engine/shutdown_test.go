@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_Stop_DrainsPendingOrders(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, engine.Start(ctx))
+	cancel()
+
+	for i := 0; i < 100; i++ {
+		engine.submitOrder(createTestOrder(models.OrderSideBuy, 1, 150.0))
+	}
+	engine.Stop()
+
+	portfolio := engine.GetPortfolio()
+	assert.Len(t, portfolio.OrderHistory, 100)
+	assert.Len(t, portfolio.TradeHistory, 100)
+	require.Contains(t, portfolio.Positions, "AAPL")
+	assert.True(t, decimal.NewFromInt(100).Equal(portfolio.Positions["AAPL"].Quantity))
+	assert.Empty(t, engine.orderQueue)
+	assert.Empty(t, engine.tradeQueue)
+	assert.Empty(t, engine.GetOpenOrders())
+}
+
+func TestTradingEngine_Stop_UpdatesPortfolioAfterDrain(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	engine.submitOrder(createTestOrder(models.OrderSideBuy, 10, 150.0))
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 160.0))
+
+	engine.Stop()
+
+	position := engine.GetPortfolio().Positions["AAPL"]
+	require.NotNil(t, position)
+	assert.Equal(t, "160", position.CurrentPrice.String())
+}
+
+func TestTradingEngine_StopAndDrain_Timeout(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	for i := 0; i < 10; i++ {
+		engine.submitOrder(createTestOrder(models.OrderSideBuy, 1, 150.0))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := engine.StopAndDrain(ctx)
+
+	assert.ErrorIs(t, err, ErrDrainTimeout)
+	assert.Len(t, engine.orderQueue, 10)
+}
+
+func TestTradingEngine_Stop_Twice(t *testing.T) {
+	engine := createTestEngine()
+	require.NoError(t, engine.Start(context.Background()))
+
+	engine.Stop()
+	assert.NotPanics(t, engine.Stop)
+}
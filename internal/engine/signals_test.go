@@ -0,0 +1,174 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type multiSignalStrategy struct {
+	stubStrategy
+	results []*models.AlgorithmResult
+}
+
+func (s *multiSignalStrategy) ExecuteAll(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) ([]*models.AlgorithmResult, error) {
+	s.calls.Add(1)
+	return s.results, nil
+}
+
+func createMultiSignalStrategy(symbols ...string) *multiSignalStrategy {
+	strategy := &multiSignalStrategy{stubStrategy: stubStrategy{config: &models.StrategyConfig{ID: "multi", Name: "multi", Enabled: true}}}
+	for i, symbol := range symbols {
+		strategy.results = append(strategy.results, &models.AlgorithmResult{
+			StrategyID: "multi",
+			Symbol:     symbol,
+			Action:     "buy",
+			Quantity:   decimal.NewFromInt(10),
+			Price:      decimal.NewFromFloat(100.0),
+			Confidence: decimal.NewFromFloat(0.9 - 0.1*float64(i)),
+		})
+	}
+	strategy.result = strategy.results[0]
+	return strategy
+}
+
+func queuedSymbols(engine *TradingEngine) []string {
+	var symbols []string
+	for len(engine.orderQueue) > 0 {
+		symbols = append(symbols, (<-engine.orderQueue).Symbol)
+	}
+	return symbols
+}
+
+func TestTradingEngine_MultipleSignalsBecomeOrders(t *testing.T) {
+	engine := createTestEngine()
+	delete(engine.strategies, "test_strategy")
+	strategy := createMultiSignalStrategy("AAPL", "MSFT")
+	engine.AddStrategy(strategy)
+
+	engine.executeStrategies(context.Background())
+
+	assert.Equal(t, []string{"AAPL", "MSFT"}, queuedSymbols(engine))
+	assert.Len(t, engine.GetOpenOrders(), 2)
+}
+
+func TestTradingEngine_MaxSignalsPerRun(t *testing.T) {
+	engine := createTestEngine()
+	delete(engine.strategies, "test_strategy")
+	strategy := createMultiSignalStrategy("AAPL", "MSFT", "GOOGL")
+	strategy.config.MaxSignalsPerRun = 2
+	engine.AddStrategy(strategy)
+
+	engine.executeStrategies(context.Background())
+
+	assert.Equal(t, []string{"AAPL", "MSFT"}, queuedSymbols(engine))
+}
+
+func TestTradingEngine_SingleSignalStrategiesStillTrade(t *testing.T) {
+	engine := createTestEngine()
+	delete(engine.strategies, "test_strategy")
+	engine.AddStrategy(&stubStrategy{
+		config: &models.StrategyConfig{ID: "single", Name: "single", Enabled: true},
+		result: &models.AlgorithmResult{StrategyID: "single", Symbol: "AAPL", Action: "sell", Quantity: decimal.NewFromInt(5), Price: decimal.NewFromFloat(100.0)},
+	})
+
+	engine.executeStrategies(context.Background())
+
+	require.Len(t, engine.orderQueue, 1)
+	order := <-engine.orderQueue
+	assert.Equal(t, models.OrderSideSell, order.Side)
+	assert.True(t, decimal.NewFromInt(5).Equal(order.Quantity))
+}
+
+func createActionEngine(action models.Action) (*TradingEngine, *stubStrategy) {
+	engine := createTestEngine()
+	delete(engine.strategies, "test_strategy")
+	strategy := &stubStrategy{
+		config: &models.StrategyConfig{ID: "signal", Name: "signal", Enabled: true},
+		result: &models.AlgorithmResult{StrategyID: "signal", Symbol: "AAPL", Action: action, Quantity: decimal.NewFromInt(10), Price: decimal.NewFromFloat(100.0), Reason: "test signal"},
+	}
+	engine.AddStrategy(strategy)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	return engine, strategy
+}
+
+func TestTradingEngine_SignalActions(t *testing.T) {
+	for action, side := range map[models.Action]models.OrderSide{
+		models.ActionBuy:  models.OrderSideBuy,
+		models.ActionSell: models.OrderSideSell,
+	} {
+		engine, _ := createActionEngine(action)
+
+		engine.executeStrategies(context.Background())
+
+		require.Len(t, engine.orderQueue, 1, action)
+		order := <-engine.orderQueue
+		assert.Equal(t, side, order.Side, action)
+		assert.True(t, decimal.NewFromInt(10).Equal(order.Quantity), action)
+		assert.Equal(t, "test signal", order.Reason, action)
+	}
+}
+
+func TestTradingEngine_SignalActions_HoldAndUnknownDoNotTrade(t *testing.T) {
+	for _, action := range []models.Action{models.ActionHold, "", "short", "Buy"} {
+		engine, _ := createActionEngine(action)
+
+		engine.executeStrategies(context.Background())
+		engine.drainQueues()
+
+		assert.Empty(t, engine.GetOpenOrders(), action)
+		assert.Empty(t, engine.GetPortfolio().TradeHistory, action)
+		assert.Empty(t, engine.GetPortfolio().OrderHistory, action)
+	}
+}
+
+func TestTradingEngine_SignalActions_CloseSellsPositionAtExecution(t *testing.T) {
+	engine, strategy := createActionEngine(models.ActionBuy)
+	engine.executeStrategies(context.Background())
+	engine.drainQueues()
+	require.True(t, decimal.NewFromInt(10).Equal(engine.GetPortfolio().Positions["AAPL"].Quantity))
+
+	strategy.result = &models.AlgorithmResult{StrategyID: "signal", Symbol: "AAPL", Action: models.ActionClose, Price: decimal.NewFromFloat(100.0), Reason: "flatten"}
+	engine.executeStrategies(context.Background())
+	engine.submitOrder(&models.Order{
+		ID:         testOrderID(),
+		Symbol:     "AAPL",
+		Side:       models.OrderSideBuy,
+		Type:       models.OrderTypeMarket,
+		Quantity:   decimal.NewFromInt(5),
+		Price:      decimal.NewFromFloat(100.0),
+		Status:     models.OrderStatusPending,
+		Timestamp:  time.Now(),
+		StrategyID: "signal",
+	})
+	close := <-engine.orderQueue
+	add := <-engine.orderQueue
+	engine.processOrder(add)
+	engine.processOrder(close)
+	engine.drainQueues()
+
+	assert.NotContains(t, engine.GetPortfolio().Positions, "AAPL")
+	trades := engine.GetPortfolio().TradeHistory
+	require.Len(t, trades, 3)
+	assert.Equal(t, models.OrderSideSell, trades[2].Side)
+	assert.True(t, decimal.NewFromInt(15).Equal(trades[2].Quantity))
+	assert.Equal(t, models.ExitReasonClose, trades[2].ExitReason)
+	assert.Equal(t, "flatten", trades[2].Reason)
+}
+
+func TestTradingEngine_SignalActions_CloseWithoutPositionIsRejected(t *testing.T) {
+	engine, _ := createActionEngine(models.ActionClose)
+
+	engine.executeStrategies(context.Background())
+	engine.drainQueues()
+
+	assert.Empty(t, engine.GetPortfolio().TradeHistory)
+	history := engine.GetPortfolio().OrderHistory
+	require.Len(t, history, 1)
+	assert.Equal(t, models.OrderStatusRejected, history[0].Status)
+}
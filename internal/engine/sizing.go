@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+)
+
+func sizeByConfidence(result *models.AlgorithmResult, config *models.StrategyConfig, registry *symbols.Registry) (decimal.Decimal, bool) {
+	confidence := decimal.Min(decimal.Max(result.Confidence, decimal.Zero), decimal.NewFromInt(1))
+	if confidence.LessThan(config.MinConfidence) {
+		return decimal.Zero, false
+	}
+
+	var scale decimal.Decimal
+	switch config.ConfidenceScaling {
+	case models.ConfidenceScalingLinear:
+		scale = confidence
+	case models.ConfidenceScalingSquare:
+		scale = confidence.Mul(confidence)
+	default:
+		return result.Quantity, true
+	}
+
+	quantity := result.Quantity.Mul(scale)
+	if result.Price.IsPositive() && config.MaxOrderSize.IsPositive() {
+		quantity = decimal.Min(quantity, config.MaxOrderSize.Div(result.Price))
+	}
+	quantity = registry.RoundDown(result.Symbol, quantity)
+	if !quantity.IsPositive() || result.Price.Mul(quantity).LessThan(config.MinOrderSize) {
+		return decimal.Zero, false
+	}
+	return quantity, true
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeByConfidence(t *testing.T) {
+	tests := []struct {
+		name       string
+		scaling    models.ConfidenceScaling
+		confidence float64
+		quantity   int64
+		placed     bool
+	}{
+		{"unscaled keeps full size", models.ConfidenceScalingNone, 0.3, 100, true},
+		{"unscaled below floor", models.ConfidenceScalingNone, 0.1, 0, false},
+		{"linear strong", models.ConfidenceScalingLinear, 0.95, 95, true},
+		{"linear medium", models.ConfidenceScalingLinear, 0.5, 50, true},
+		{"linear at floor", models.ConfidenceScalingLinear, 0.2, 20, true},
+		{"linear below floor", models.ConfidenceScalingLinear, 0.05, 0, false},
+		{"square strong", models.ConfidenceScalingSquare, 0.9, 81, true},
+		{"square medium", models.ConfidenceScalingSquare, 0.5, 25, true},
+		{"square below min order size", models.ConfidenceScalingSquare, 0.3, 0, false},
+		{"confidence above one is capped by max order size", models.ConfidenceScalingLinear, 1.5, 100, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &models.StrategyConfig{
+				MinConfidence:     decimal.NewFromFloat(0.2),
+				ConfidenceScaling: tt.scaling,
+				MinOrderSize:      decimal.NewFromFloat(1000.0),
+				MaxOrderSize:      decimal.NewFromFloat(10000.0),
+			}
+			result := &models.AlgorithmResult{Quantity: decimal.NewFromInt(100), Price: decimal.NewFromFloat(100.0), Confidence: decimal.NewFromFloat(tt.confidence)}
+
+			quantity, placed := sizeByConfidence(result, config, nil)
+
+			assert.Equal(t, tt.placed, placed)
+			assert.True(t, decimal.NewFromInt(tt.quantity).Equal(quantity), "quantity %s", quantity)
+		})
+	}
+}
+
+func TestSizeByConfidence_CapsAtMaxOrderSize(t *testing.T) {
+	config := &models.StrategyConfig{ConfidenceScaling: models.ConfidenceScalingLinear, MaxOrderSize: decimal.NewFromFloat(5000.0)}
+	result := &models.AlgorithmResult{Quantity: decimal.NewFromInt(100), Price: decimal.NewFromFloat(100.0), Confidence: decimal.NewFromFloat(0.8)}
+
+	quantity, placed := sizeByConfidence(result, config, nil)
+
+	assert.True(t, placed)
+	assert.True(t, decimal.NewFromInt(50).Equal(quantity))
+}
+
+func TestSizeByConfidence_RoundsToLotSize(t *testing.T) {
+	registry := symbols.NewRegistry()
+	require.NoError(t, registry.Register(models.Instrument{Symbol: "BTCUSDT", LotSize: decimal.NewFromFloat(0.001)}))
+	config := &models.StrategyConfig{ConfidenceScaling: models.ConfidenceScalingSquare, MinOrderSize: decimal.NewFromFloat(10.0)}
+	result := &models.AlgorithmResult{Symbol: "BTCUSDT", Quantity: decimal.RequireFromString("0.25"), Price: decimal.NewFromFloat(40000.0), Confidence: decimal.NewFromFloat(0.7)}
+
+	quantity, placed := sizeByConfidence(result, config, registry)
+
+	assert.True(t, placed)
+	assert.True(t, decimal.RequireFromString("0.122").Equal(quantity), quantity.String())
+}
+
+func TestTradingEngine_ConfidenceSizingSkipsWeakSignals(t *testing.T) {
+	engine := createTestEngine()
+	delete(engine.strategies, "test_strategy")
+	strategy := createMultiSignalStrategy("AAPL", "MSFT", "GOOGL")
+	strategy.results[0].Confidence = decimal.NewFromFloat(0.8)
+	strategy.results[1].Confidence = decimal.NewFromFloat(0.1)
+	strategy.results[2].Confidence = decimal.NewFromFloat(0.5)
+	strategy.config.MinConfidence = decimal.NewFromFloat(0.2)
+	strategy.config.ConfidenceScaling = models.ConfidenceScalingLinear
+	engine.AddStrategy(strategy)
+
+	engine.executeStrategies(context.Background())
+
+	assert.Len(t, engine.orderQueue, 2)
+	assert.True(t, decimal.NewFromInt(8).Equal((<-engine.orderQueue).Quantity))
+	order := <-engine.orderQueue
+	assert.Equal(t, "GOOGL", order.Symbol)
+	assert.True(t, decimal.NewFromInt(5).Equal(order.Quantity))
+}
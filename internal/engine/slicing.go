@@ -0,0 +1,227 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type SlicingConfig struct {
+	Algorithm string
+	Threshold decimal.Decimal
+	Slices    int
+	Interval  time.Duration
+}
+
+type slicedOrder struct {
+	parent   *models.Order
+	children []*models.Order
+	released int
+	done     int
+	filled   decimal.Decimal
+}
+
+func (e *TradingEngine) SetOrderSlicing(config SlicingConfig) error {
+	switch config.Algorithm {
+	case "", execution.SliceTWAP, execution.SliceVWAP:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownSliceAlgorithm, config.Algorithm)
+	}
+	if config.Algorithm != "" && (config.Slices < 2 || config.Interval < 0 || config.Threshold.IsNegative()) {
+		return fmt.Errorf("%w: need at least 2 slices, a non-negative interval and a non-negative threshold", ErrInvalidSlicing)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.slicing = config
+	return nil
+}
+
+func (e *TradingEngine) GetChildOrders(parentID string) []*models.Order {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var children []*models.Order
+	if sliced, exists := e.slicedOrders[parentID]; exists {
+		for _, child := range sliced.children {
+			childCopy := *child
+			children = append(children, &childCopy)
+		}
+		return children
+	}
+	for _, order := range e.portfolio.OrderHistory {
+		if order.ParentID == parentID {
+			childCopy := *order
+			children = append(children, &childCopy)
+		}
+	}
+	return children
+}
+
+func (e *TradingEngine) sliceable(order *models.Order) bool {
+	config := e.slicing
+	if config.Algorithm == "" || order.Type != models.OrderTypeMarket || order.ExitReason != "" {
+		return false
+	}
+	return order.Price.Mul(order.Quantity).GreaterThan(config.Threshold)
+}
+
+func (e *TradingEngine) sliceOrder(order *models.Order) bool {
+	if !e.sliceable(order) {
+		return false
+	}
+	config := e.slicing
+
+	lotSize := e.symbols.LotSize(order.Symbol)
+	lots := order.Quantity.Div(lotSize).IntPart()
+	sizes := execution.TWAPSlices(lots, config.Slices)
+	if config.Algorithm == execution.SliceVWAP {
+		sizes = execution.VWAPSlices(lots, e.volumeProfile(order.Symbol, config.Slices))
+	}
+
+	sliced := &slicedOrder{parent: order}
+	for i, size := range sizes {
+		if size <= 0 {
+			continue
+		}
+		sliced.children = append(sliced.children, &models.Order{
+			ID:              e.newOrderID(),
+			Symbol:          order.Symbol,
+			Side:            order.Side,
+			Type:            order.Type,
+			Quantity:        lotSize.Mul(decimal.NewFromInt(size)),
+			Price:           order.Price,
+			StopPrice:       order.StopPrice,
+			DecisionPrice:   order.DecisionPrice,
+			SubmissionPrice: order.SubmissionPrice,
+			TimeInForce:     order.TimeInForce,
+			Status:          models.OrderStatusPending,
+			Timestamp:       order.Timestamp.Add(time.Duration(i) * config.Interval),
+			StrategyID:      order.StrategyID,
+			ParentID:        order.ID,
+		})
+	}
+	if len(sliced.children) < 2 {
+		return false
+	}
+	last := sliced.children[len(sliced.children)-1]
+	last.Quantity = last.Quantity.Add(order.Quantity.Sub(lotSize.Mul(decimal.NewFromInt(lots))))
+
+	e.slicedOrders[order.ID] = sliced
+	e.openOrders[order.ID] = order
+	for _, child := range sliced.children {
+		e.openOrders[child.ID] = child
+		e.publishOrder(child)
+		e.auditOrder(audit.EventOrderCreated, child, nil)
+	}
+
+	e.logger.Info("Order sliced",
+		zap.String("order_id", order.ID),
+		zap.String("symbol", order.Symbol),
+		zap.String("algorithm", config.Algorithm),
+		zap.String("quantity", order.Quantity.String()),
+		zap.Int("children", len(sliced.children)),
+		zap.Duration("interval", config.Interval),
+	)
+	return true
+}
+
+func (e *TradingEngine) volumeProfile(symbol string, slices int) []int64 {
+	history := e.history.get(symbol, slices)
+	if len(history) < slices {
+		return make([]int64, slices)
+	}
+
+	volumes := make([]int64, slices)
+	for i, data := range history {
+		volumes[i] = data.Volume
+	}
+	return volumes
+}
+
+func (e *TradingEngine) releaseSlices() []*models.Order {
+	now := e.now()
+	var released []*models.Order
+	for _, sliced := range e.slicedOrders {
+		for sliced.released < len(sliced.children) && !sliced.children[sliced.released].Timestamp.After(now) {
+			child := sliced.children[sliced.released]
+			sliced.released++
+			if _, open := e.openOrders[child.ID]; !open {
+				continue
+			}
+			if data, exists := e.marketData[child.Symbol]; exists && data.Price.IsPositive() {
+				child.Price = data.Price
+			}
+			released = append(released, child)
+		}
+	}
+
+	sort.Slice(released, func(i, j int) bool {
+		if !released[i].Timestamp.Equal(released[j].Timestamp) {
+			return released[i].Timestamp.Before(released[j].Timestamp)
+		}
+		return released[i].ID < released[j].ID
+	})
+	return released
+}
+
+func (e *TradingEngine) completeSlice(child *models.Order) {
+	sliced, exists := e.slicedOrders[child.ParentID]
+	if !exists {
+		return
+	}
+
+	sliced.done++
+	if child.Status == models.OrderStatusFilled {
+		sliced.filled = sliced.filled.Add(child.Quantity)
+	}
+	if sliced.done < len(sliced.children) {
+		return
+	}
+
+	parent := sliced.parent
+	delete(e.slicedOrders, parent.ID)
+	delete(e.openOrders, parent.ID)
+	parent.FilledQuantity = sliced.filled
+	switch {
+	case sliced.filled.Equal(parent.Quantity):
+		parent.Status = models.OrderStatusFilled
+	case sliced.filled.IsZero():
+		parent.Status = models.OrderStatusRejected
+	default:
+		parent.Status = models.OrderStatusPartiallyFilled
+	}
+	e.recordOrder(parent)
+	e.auditOrder(audit.EventOrderCompleted, parent, nil)
+	e.logger.Info("Sliced order completed",
+		zap.String("order_id", parent.ID),
+		zap.String("status", string(parent.Status)),
+		zap.String("filled_quantity", parent.FilledQuantity.String()),
+	)
+}
+
+func (e *TradingEngine) cancelSlices(parentID string) {
+	sliced, exists := e.slicedOrders[parentID]
+	if !exists {
+		return
+	}
+
+	delete(e.slicedOrders, parentID)
+	for _, child := range sliced.children {
+		if _, open := e.openOrders[child.ID]; !open {
+			continue
+		}
+		child.Status = models.OrderStatusCancelled
+		delete(e.openOrders, child.ID)
+		e.release(child.ID)
+		e.recordOrder(child)
+		e.auditOrder(audit.EventOrderCancelled, child, nil)
+	}
+	sliced.parent.FilledQuantity = sliced.filled
+}
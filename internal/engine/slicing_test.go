@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sliceStart = time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+func createSlicingEngine(t *testing.T, algorithm string) *TradingEngine {
+	engine := createTestEngine()
+	require.NoError(t, engine.SetOrderSlicing(SlicingConfig{
+		Algorithm: algorithm,
+		Threshold: decimal.NewFromFloat(10000.0),
+		Slices:    10,
+		Interval:  30 * time.Second,
+	}))
+	engine.clock.current = sliceStart
+	return engine
+}
+
+func submitSlicedOrder(engine *TradingEngine, quantity int64, price float64) *models.Order {
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", price))
+	order := createTestOrder(models.OrderSideBuy, quantity, price)
+	order.Timestamp = sliceStart
+	engine.submitOrder(order)
+	engine.drainQueues()
+	return order
+}
+
+func runSlice(engine *TradingEngine, slice int, price float64) {
+	engine.clock.current = sliceStart.Add(time.Duration(slice) * 30 * time.Second)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", price))
+	engine.drainQueues()
+}
+
+func TestTradingEngine_TWAPSlicesLargeOrder(t *testing.T) {
+	engine := createSlicingEngine(t, execution.SliceTWAP)
+	parent := submitSlicedOrder(engine, 1000, 90.0)
+
+	children := engine.GetChildOrders(parent.ID)
+	require.Len(t, children, 10)
+	for i, child := range children {
+		assert.True(t, decimal.NewFromInt(100).Equal(child.Quantity))
+		assert.Equal(t, parent.ID, child.ParentID)
+		assert.Equal(t, sliceStart.Add(time.Duration(i)*30*time.Second), child.Timestamp)
+	}
+	assert.Empty(t, engine.portfolio.Positions)
+	assert.Len(t, engine.GetOpenOrders(), 11)
+
+	for i := 0; i < 10; i++ {
+		price := 90.0 + float64(i)
+		if i == 4 {
+			price = 600.0
+		}
+		runSlice(engine, i, price)
+
+		if i == 5 {
+			assert.True(t, decimal.NewFromInt(500).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+			assert.Equal(t, models.OrderStatusPending, parent.Status)
+			assert.Len(t, engine.GetOpenOrders(), 5)
+		}
+	}
+
+	position := engine.portfolio.Positions["AAPL"]
+	require.NotNil(t, position)
+	assert.True(t, decimal.NewFromInt(900).Equal(position.Quantity))
+	assert.InDelta(t, (945.0-94.0)/9, position.AveragePrice.InexactFloat64(), 1e-9)
+
+	assert.Equal(t, models.OrderStatusPartiallyFilled, parent.Status)
+	assert.True(t, decimal.NewFromInt(900).Equal(parent.FilledQuantity))
+	assert.Empty(t, engine.GetOpenOrders())
+
+	statuses := make(map[models.OrderStatus]int)
+	for _, child := range engine.GetChildOrders(parent.ID) {
+		statuses[child.Status]++
+	}
+	assert.Equal(t, map[models.OrderStatus]int{models.OrderStatusFilled: 9, models.OrderStatusRejected: 1}, statuses)
+}
+
+func TestTradingEngine_SlicedOrderFillsParent(t *testing.T) {
+	engine := createSlicingEngine(t, execution.SliceTWAP)
+	parent := submitSlicedOrder(engine, 1000, 90.0)
+
+	for i := 0; i < 10; i++ {
+		runSlice(engine, i, 90.0)
+	}
+
+	assert.Equal(t, models.OrderStatusFilled, parent.Status)
+	assert.True(t, decimal.NewFromInt(1000).Equal(parent.FilledQuantity))
+	assert.True(t, decimal.NewFromInt(1000).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+}
+
+func TestTradingEngine_CancelSlicedParentCancelsChildren(t *testing.T) {
+	engine := createSlicingEngine(t, execution.SliceTWAP)
+	parent := submitSlicedOrder(engine, 1000, 90.0)
+
+	for i := 0; i < 3; i++ {
+		runSlice(engine, i, 90.0)
+	}
+	require.NoError(t, engine.CancelOrder(parent.ID))
+
+	assert.Equal(t, models.OrderStatusCancelled, parent.Status)
+	assert.True(t, decimal.NewFromInt(300).Equal(parent.FilledQuantity))
+	assert.Empty(t, engine.GetOpenOrders())
+
+	for i := 3; i < 10; i++ {
+		runSlice(engine, i, 90.0)
+	}
+	assert.True(t, decimal.NewFromInt(300).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+	assert.ErrorIs(t, engine.CancelOrder(parent.ID), ErrOrderNotCancellable)
+}
+
+func TestTradingEngine_SmallOrderIsNotSliced(t *testing.T) {
+	engine := createSlicingEngine(t, execution.SliceTWAP)
+	order := submitSlicedOrder(engine, 100, 90.0)
+
+	assert.Equal(t, models.OrderStatusFilled, order.Status)
+	assert.Empty(t, engine.GetChildOrders(order.ID))
+	assert.True(t, decimal.NewFromInt(100).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+}
+
+func TestTradingEngine_VWAPSlicesFollowVolumeProfile(t *testing.T) {
+	engine := createSlicingEngine(t, execution.SliceVWAP)
+	for i := 0; i < 10; i++ {
+		data := createTestMarketData("AAPL", 90.0)
+		data.Volume = int64(i+1) * 1000
+		engine.UpdateMarketData("AAPL", data)
+	}
+
+	order := createTestOrder(models.OrderSideBuy, 1100, 90.0)
+	order.Timestamp = sliceStart
+	engine.submitOrder(order)
+	engine.drainQueues()
+
+	var sizes []int64
+	for _, child := range engine.GetChildOrders(order.ID) {
+		sizes = append(sizes, child.Quantity.IntPart())
+	}
+	assert.Equal(t, []int64{20, 40, 60, 80, 100, 120, 140, 160, 180, 200}, sizes)
+}
+
+func TestTradingEngine_SetOrderSlicing_Validates(t *testing.T) {
+	engine := createTestEngine()
+
+	assert.ErrorIs(t, engine.SetOrderSlicing(SlicingConfig{Algorithm: "iceberg", Slices: 10}), ErrUnknownSliceAlgorithm)
+	assert.ErrorIs(t, engine.SetOrderSlicing(SlicingConfig{Algorithm: execution.SliceTWAP, Slices: 1}), ErrInvalidSlicing)
+	assert.NoError(t, engine.SetOrderSlicing(SlicingConfig{}))
+}
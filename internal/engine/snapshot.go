@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const strategyHistoryWindow = 200
+
+func (e *TradingEngine) PortfolioSnapshot() *models.Portfolio {
+	return e.GetPortfolio()
+}
+
+func (e *TradingEngine) GetPortfolioSummary() *models.PortfolioSummary {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return &models.PortfolioSummary{
+		ID:              e.portfolio.ID,
+		BaseCurrency:    e.currency.base,
+		Cash:            e.portfolio.Cash,
+		CashBalances:    copyBalances(e.portfolio.CashBalances),
+		Positions:       copyPositions(e.portfolio.Positions),
+		TotalValue:      e.portfolio.TotalValue,
+		UnrealizedPnL:   e.portfolio.UnrealizedPnL,
+		RealizedPnL:     e.portfolio.RealizedPnL,
+		TotalRisk:       e.portfolio.TotalRisk,
+		RiskMetrics:     e.portfolio.RiskMetrics,
+		Margin:          copyMargin(e.portfolio.Margin),
+		InterestExpense: e.portfolio.InterestExpense,
+		Drawdown:        e.portfolio.Drawdown,
+		MaxDrawdown:     e.portfolio.MaxDrawdown,
+		TradeCount:      len(e.portfolio.TradeHistory),
+		OrderCount:      len(e.portfolio.OrderHistory),
+		Allocations:     e.allocationSummaries(),
+		UpdatedAt:       e.portfolio.UpdatedAt,
+	}
+}
+
+func copyPortfolio(portfolio *models.Portfolio) *models.Portfolio {
+	snapshot := *portfolio
+	snapshot.Positions = copyPositions(portfolio.Positions)
+	snapshot.CashBalances = copyBalances(portfolio.CashBalances)
+	snapshot.TradeHistory = make([]*models.Trade, len(portfolio.TradeHistory))
+	for i, trade := range portfolio.TradeHistory {
+		tradeCopy := *trade
+		snapshot.TradeHistory[i] = &tradeCopy
+	}
+	snapshot.RiskEvents = append([]models.RiskEvent(nil), portfolio.RiskEvents...)
+	snapshot.Margin = copyMargin(portfolio.Margin)
+	snapshot.OrderHistory = make([]*models.Order, len(portfolio.OrderHistory))
+	for i, order := range portfolio.OrderHistory {
+		orderCopy := *order
+		snapshot.OrderHistory[i] = &orderCopy
+	}
+	return &snapshot
+}
+
+func strategyPortfolio(portfolio *models.Portfolio, strategies int) *models.Portfolio {
+	snapshot := *portfolio
+	snapshot.Positions = copyPositions(portfolio.Positions)
+	snapshot.CashBalances = copyBalances(portfolio.CashBalances)
+	snapshot.TradeHistory = recentHistory(portfolio.TradeHistory, strategies, func(trade *models.Trade) string { return trade.StrategyID })
+	snapshot.RiskEvents = append([]models.RiskEvent(nil), portfolio.RiskEvents...)
+	snapshot.Margin = copyMargin(portfolio.Margin)
+	snapshot.OrderHistory = recentHistory(portfolio.OrderHistory, strategies, func(order *models.Order) string { return order.StrategyID })
+	return &snapshot
+}
+
+func recentHistory[T any](history []*T, strategies int, strategyID func(*T) string) []*T {
+	recent := []*T{}
+	counts := make(map[string]int, strategies)
+	for i := len(history) - 1; i >= 0 && len(recent) < strategies*strategyHistoryWindow; i-- {
+		id := strategyID(history[i])
+		if counts[id] == strategyHistoryWindow {
+			continue
+		}
+		counts[id]++
+		entry := *history[i]
+		recent = append(recent, &entry)
+	}
+	slices.Reverse(recent)
+	return recent
+}
+
+func copyMargin(margin *models.MarginAccount) *models.MarginAccount {
+	if margin == nil {
+		return nil
+	}
+	marginCopy := *margin
+	return &marginCopy
+}
+
+func copyPositions(positions map[string]*models.Position) map[string]*models.Position {
+	copied := make(map[string]*models.Position, len(positions))
+	for symbol, position := range positions {
+		positionCopy := *position
+		positionCopy.Lots = copyLots(position.Lots)
+		copied[symbol] = &positionCopy
+	}
+	return copied
+}
+
+func copyBalances(balances map[string]decimal.Decimal) map[string]decimal.Decimal {
+	if balances == nil {
+		return nil
+	}
+	copied := make(map[string]decimal.Decimal, len(balances))
+	for currency, balance := range balances {
+		copied[currency] = balance
+	}
+	return copied
+}
+
+func copyMarketData(marketData map[string]*models.MarketData) map[string]*models.MarketData {
+	copied := make(map[string]*models.MarketData, len(marketData))
+	for symbol, data := range marketData {
+		copied[symbol] = data
+	}
+	return copied
+}
+
+func (e *TradingEngine) GetLatestMarketData(symbol string) (*models.MarketData, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	data, exists := e.marketData[symbol]
+	if !exists {
+		return nil, false
+	}
+	dataCopy := *data
+	return &dataCopy, true
+}
+
+func (e *TradingEngine) GetStrategyConfigs() []*models.StrategyConfig {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	configs := make([]*models.StrategyConfig, 0, len(e.strategies))
+	for _, strategy := range e.strategies {
+		config := *strategy.GetConfig()
+		configs = append(configs, &config)
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].ID < configs[j].ID
+	})
+	return configs
+}
+
+func (e *TradingEngine) UpdateStrategyConfig(strategyID string, config *models.StrategyConfig) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	strategy, exists := e.strategies[strategyID]
+	if !exists {
+		return ErrStrategyNotFound
+	}
+
+	updated := *config
+	updated.ID = strategyID
+	updated.CreatedAt = strategy.GetConfig().CreatedAt
+	if err := strategy.UpdateConfig(&updated); err != nil {
+		return err
+	}
+
+	e.logger.Info("Strategy config updated", zap.String("strategy_id", strategyID), zap.Bool("enabled", updated.Enabled))
+	return nil
+}
+
+func (e *TradingEngine) SetStrategyEnabled(strategyID string, enabled bool) (*models.StrategyConfig, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	strategy, exists := e.strategies[strategyID]
+	if !exists {
+		return nil, ErrStrategyNotFound
+	}
+
+	config := *strategy.GetConfig()
+	config.Enabled = enabled
+	if err := strategy.UpdateConfig(&config); err != nil {
+		return nil, err
+	}
+
+	e.logger.Info("Strategy toggled", zap.String("strategy_id", strategyID), zap.Bool("enabled", enabled))
+	updated := *strategy.GetConfig()
+	return &updated, nil
+}
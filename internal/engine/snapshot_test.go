@@ -0,0 +1,265 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_PortfolioSnapshot_IsIndependent(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	engine.drainQueues()
+
+	snapshot := engine.PortfolioSnapshot()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 160.0))
+	engine.updatePortfolio()
+
+	require.Contains(t, snapshot.Positions, "AAPL")
+	assert.NotSame(t, engine.portfolio.Positions["AAPL"], snapshot.Positions["AAPL"])
+	assert.False(t, snapshot.Positions["AAPL"].CurrentPrice.Equal(engine.portfolio.Positions["AAPL"].CurrentPrice))
+	require.Len(t, snapshot.TradeHistory, 1)
+	require.Len(t, snapshot.OrderHistory, 1)
+}
+
+func TestTradingEngine_GetPortfolio_ReturnsCopy(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	engine.drainQueues()
+
+	portfolio := engine.GetPortfolio()
+	portfolio.Cash = decimal.Zero
+	portfolio.Positions["AAPL"].Quantity = decimal.NewFromInt(0)
+	delete(portfolio.Positions, "AAPL")
+	portfolio.TradeHistory[0].Quantity = decimal.NewFromInt(0)
+
+	assert.False(t, engine.portfolio.Cash.IsZero())
+	require.Contains(t, engine.portfolio.Positions, "AAPL")
+	assert.True(t, decimal.NewFromInt(10).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+	assert.True(t, decimal.NewFromInt(10).Equal(engine.portfolio.TradeHistory[0].Quantity))
+}
+
+func TestTradingEngine_GetPortfolioSummary(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	engine.drainQueues()
+
+	summary := engine.GetPortfolioSummary()
+
+	assert.Equal(t, engine.portfolio.ID, summary.ID)
+	assert.True(t, engine.portfolio.Cash.Equal(summary.Cash))
+	assert.Equal(t, 1, summary.TradeCount)
+	assert.Equal(t, 1, summary.OrderCount)
+	require.Contains(t, summary.Positions, "AAPL")
+	assert.NotSame(t, engine.portfolio.Positions["AAPL"], summary.Positions["AAPL"])
+}
+
+type mutatingStrategy struct {
+	stubStrategy
+}
+
+func (s *mutatingStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	portfolio.Cash = decimal.Zero
+	for symbol := range portfolio.Positions {
+		delete(portfolio.Positions, symbol)
+	}
+	return nil, nil
+}
+
+func TestTradingEngine_StrategiesGetPortfolioSnapshot(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	engine.drainQueues()
+	engine.AddStrategy(&mutatingStrategy{stubStrategy{config: &models.StrategyConfig{ID: "mutating", Name: "Mutating", Enabled: true}}})
+
+	engine.executeStrategies(context.Background())
+
+	assert.False(t, engine.portfolio.Cash.IsZero())
+	assert.Contains(t, engine.portfolio.Positions, "AAPL")
+}
+
+func TestTradingEngine_GetPortfolio_ConcurrentWithTrades(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetIntervals(Intervals{Strategy: time.Hour, Risk: time.Millisecond, Portfolio: time.Millisecond})
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, engine.Start(ctx))
+	defer engine.Stop()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			portfolio := engine.GetPortfolio()
+			for _, position := range portfolio.Positions {
+				_ = position.MarketValue.String()
+			}
+			for _, trade := range portfolio.TradeHistory {
+				_ = trade.Price.String()
+			}
+			_ = engine.GetPortfolioSummary().TotalValue.String()
+		}
+	}()
+
+	for i := 0; i < 200; i++ {
+		side := models.OrderSideBuy
+		if i%2 == 1 {
+			side = models.OrderSideSell
+		}
+		engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0+float64(i%5)))
+		engine.submitOrder(createTestOrder(side, 1, 150.0))
+	}
+
+	assert.Eventually(t, func() bool { return engine.GetPortfolioSummary().OrderCount == 200 }, 5*time.Second, time.Millisecond)
+	close(done)
+	wg.Wait()
+}
+
+type recordingStrategy struct {
+	stubStrategy
+	mu         sync.Mutex
+	portfolios []*models.Portfolio
+	markets    []*models.MarketSnapshot
+}
+
+func (s *recordingStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.portfolios = append(s.portfolios, portfolio)
+	s.markets = append(s.markets, market)
+	for _, position := range portfolio.Positions {
+		_ = position.MarketValue.String()
+	}
+	for _, trade := range portfolio.TradeHistory {
+		_ = trade.Price.String()
+	}
+	for symbol, data := range market.Latest {
+		_ = data.Price.String()
+		_ = market.Prices(symbol)
+	}
+	return s.result, nil
+}
+
+func createRecordingStrategy(id string, result *models.AlgorithmResult) *recordingStrategy {
+	return &recordingStrategy{stubStrategy: stubStrategy{config: &models.StrategyConfig{ID: id, Name: id, Enabled: true}, result: result}}
+}
+
+func TestTradingEngine_StrategiesShareOneSnapshotPerRun(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	first := createRecordingStrategy("first", nil)
+	second := createRecordingStrategy("second", nil)
+	engine.AddStrategy(first)
+	engine.AddStrategy(second)
+
+	engine.executeStrategies(context.Background())
+	engine.executeStrategies(context.Background())
+
+	require.Len(t, first.portfolios, 2)
+	require.Len(t, second.portfolios, 2)
+	for i := range first.portfolios {
+		assert.Same(t, first.portfolios[i], second.portfolios[i])
+		assert.Same(t, first.markets[i], second.markets[i])
+		assert.NotSame(t, engine.portfolio, first.portfolios[i])
+	}
+	assert.NotSame(t, first.portfolios[0], first.portfolios[1])
+
+	first.markets[0].Latest["MSFT"] = createTestMarketData("MSFT", 300.0)
+	assert.NotContains(t, engine.marketData, "MSFT")
+	assert.NotContains(t, engine.GetMarketData(), "MSFT")
+}
+
+func TestTradingEngine_StrategiesGetRecentHistoryPerStrategy(t *testing.T) {
+	engine := createTestEngine()
+	recorder := createRecordingStrategy("recorder", nil)
+	engine.AddStrategy(recorder)
+	for i := 0; i < strategyHistoryWindow+100; i++ {
+		engine.portfolio.TradeHistory = append(engine.portfolio.TradeHistory, &models.Trade{ID: fmt.Sprintf("bulk-%d", i), StrategyID: "test_strategy"})
+		engine.portfolio.OrderHistory = append(engine.portfolio.OrderHistory, &models.Order{ID: fmt.Sprintf("bulk-%d", i), StrategyID: "test_strategy"})
+	}
+	for i := 0; i < 10; i++ {
+		engine.portfolio.TradeHistory = append(engine.portfolio.TradeHistory, &models.Trade{ID: fmt.Sprintf("recorder-%d", i), StrategyID: "recorder"})
+	}
+
+	engine.executeStrategies(context.Background())
+
+	require.Len(t, recorder.portfolios, 1)
+	view := recorder.portfolios[0]
+	require.Len(t, view.TradeHistory, strategyHistoryWindow+10)
+	assert.Equal(t, "bulk-100", view.TradeHistory[0].ID)
+	assert.Equal(t, "recorder-9", view.TradeHistory[len(view.TradeHistory)-1].ID)
+	assert.NotSame(t, engine.portfolio.TradeHistory[len(engine.portfolio.TradeHistory)-1], view.TradeHistory[len(view.TradeHistory)-1])
+	require.Len(t, view.OrderHistory, strategyHistoryWindow)
+	assert.Equal(t, "bulk-100", view.OrderHistory[0].ID)
+	assert.Len(t, engine.GetPortfolio().TradeHistory, strategyHistoryWindow+110)
+}
+
+func TestTradingEngine_StrategiesConcurrentWithUpdates(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetIntervals(Intervals{Strategy: time.Millisecond, Risk: time.Millisecond, Portfolio: time.Millisecond})
+	buy := &models.AlgorithmResult{StrategyID: "buyer", Symbol: "AAPL", Action: "buy", Quantity: decimal.NewFromInt(1), Price: decimal.NewFromFloat(150.0)}
+	sell := &models.AlgorithmResult{StrategyID: "seller", Symbol: "AAPL", Action: "sell", Quantity: decimal.NewFromInt(1), Price: decimal.NewFromFloat(150.0)}
+	buyer := createRecordingStrategy("buyer", buy)
+	seller := createRecordingStrategy("seller", sell)
+	engine.AddStrategy(buyer)
+	engine.AddStrategy(seller)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, engine.Start(ctx))
+	defer engine.Stop()
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for i := 0; time.Now().Before(deadline); i++ {
+		symbol := []string{"AAPL", "MSFT"}[i%2]
+		engine.UpdateMarketData(symbol, createTestMarketData(symbol, 150.0+float64(i%10)))
+	}
+
+	buyer.mu.Lock()
+	runs := len(buyer.portfolios)
+	buyer.mu.Unlock()
+	assert.Positive(t, runs)
+	assert.Eventually(t, func() bool { return engine.GetPortfolioSummary().TradeCount > 0 }, time.Second, time.Millisecond)
+}
+
+func TestTradingEngine_SetStrategyEnabled(t *testing.T) {
+	engine := createTestEngine()
+
+	config, err := engine.SetStrategyEnabled("test_strategy", false)
+
+	require.NoError(t, err)
+	assert.False(t, config.Enabled)
+	assert.False(t, engine.strategies["test_strategy"].IsEnabled())
+	assert.False(t, engine.GetStrategyConfigs()[0].Enabled)
+
+	_, err = engine.SetStrategyEnabled("missing", true)
+	assert.ErrorIs(t, err, ErrStrategyNotFound)
+}
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type StalePriceAction string
+
+const (
+	StalePriceReprice StalePriceAction = "reprice"
+	StalePriceReject  StalePriceAction = "reject"
+)
+
+type StalePriceConfig struct {
+	MaxAge       time.Duration
+	MaxDeviation decimal.Decimal
+	Action       StalePriceAction
+}
+
+func (c StalePriceConfig) enabled() bool {
+	return c.MaxAge > 0 || c.MaxDeviation.IsPositive()
+}
+
+func (e *TradingEngine) SetStalePriceCheck(config StalePriceConfig) error {
+	switch config.Action {
+	case "":
+		config.Action = StalePriceReprice
+	case StalePriceReprice, StalePriceReject:
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidStalePrice, config.Action)
+	}
+	if config.MaxAge < 0 || config.MaxDeviation.IsNegative() {
+		return fmt.Errorf("%w: max age and max deviation must not be negative", ErrInvalidStalePrice)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stalePrices = config
+	return nil
+}
+
+func (e *TradingEngine) checkSignalPrice(order *models.Order) error {
+	config := e.stalePrices
+	if !config.enabled() || order.Type != models.OrderTypeMarket {
+		return nil
+	}
+
+	data, exists := e.marketData[order.Symbol]
+	if !exists || !data.Price.IsPositive() {
+		return fmt.Errorf("%w: no market data for %s", ErrStalePrice, order.Symbol)
+	}
+	if age := e.now().Sub(data.Timestamp); config.MaxAge > 0 && age > config.MaxAge {
+		return fmt.Errorf("%w: %s market data is %s old", ErrStalePrice, order.Symbol, age)
+	}
+
+	var stale error
+	deviation := order.Price.Sub(data.Price).Abs().Div(data.Price)
+	if lag := data.Timestamp.Sub(order.Timestamp); config.MaxAge > 0 && lag > config.MaxAge {
+		stale = fmt.Errorf("%w: signal is %s behind %s market data", ErrStalePrice, lag, order.Symbol)
+	} else if config.MaxDeviation.IsPositive() && deviation.GreaterThan(config.MaxDeviation) {
+		stale = fmt.Errorf("%w: %s deviates %s from %s market price %s", ErrStalePrice, order.Price, deviation, order.Symbol, data.Price)
+	}
+	if stale == nil || config.Action == StalePriceReject {
+		return stale
+	}
+
+	e.logger.Info("Order repriced to current market",
+		zap.String("order_id", order.ID),
+		zap.String("signal_price", order.Price.String()),
+		zap.String("market_price", data.Price.String()),
+		zap.String("reason", stale.Error()))
+	order.Price = data.Price
+	return nil
+}
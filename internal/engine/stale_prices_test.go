@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createStalePriceEngine(t *testing.T, config StalePriceConfig) (*TradingEngine, *clock.Fake) {
+	t.Helper()
+	fake := clock.NewFake(clockStart)
+	engine := createTestEngine(WithClock(fake))
+	require.NoError(t, engine.SetStalePriceCheck(config))
+	return engine, fake
+}
+
+func createSignalOrder(side models.OrderSide, price float64, timestamp time.Time) *models.Order {
+	order := createTestOrder(side, 10, price)
+	order.Timestamp = timestamp
+	return order
+}
+
+func submitSignal(engine *TradingEngine, order *models.Order) {
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+}
+
+func TestTradingEngine_SetStalePriceCheck_Validates(t *testing.T) {
+	engine := createTestEngine()
+
+	assert.ErrorIs(t, engine.SetStalePriceCheck(StalePriceConfig{MaxAge: time.Second, Action: "ignore"}), ErrInvalidStalePrice)
+	assert.ErrorIs(t, engine.SetStalePriceCheck(StalePriceConfig{MaxAge: -time.Second}), ErrInvalidStalePrice)
+	assert.ErrorIs(t, engine.SetStalePriceCheck(StalePriceConfig{MaxDeviation: decimal.NewFromFloat(-0.01)}), ErrInvalidStalePrice)
+
+	require.NoError(t, engine.SetStalePriceCheck(StalePriceConfig{MaxAge: time.Second}))
+	assert.Equal(t, StalePriceReprice, engine.stalePrices.Action)
+}
+
+func TestTradingEngine_ProcessOrder_RepricesDeviatingSignal(t *testing.T) {
+	engine, _ := createStalePriceEngine(t, StalePriceConfig{MaxDeviation: decimal.NewFromFloat(0.01)})
+	engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(150), Timestamp: clockStart})
+
+	submitSignal(engine, createSignalOrder(models.OrderSideBuy, 140.0, clockStart))
+
+	require.Len(t, engine.tradeQueue, 1)
+	trade := <-engine.tradeQueue
+	assert.True(t, decimal.NewFromInt(150).Equal(trade.Price), trade.Price.String())
+	assert.True(t, decimal.NewFromInt(150).Equal(trade.RequestedPrice))
+}
+
+func TestTradingEngine_ProcessOrder_RejectsDeviatingSignal(t *testing.T) {
+	engine, _ := createStalePriceEngine(t, StalePriceConfig{MaxDeviation: decimal.NewFromFloat(0.01), Action: StalePriceReject})
+	engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(150), Timestamp: clockStart})
+
+	order := createSignalOrder(models.OrderSideBuy, 140.0, clockStart)
+	assert.ErrorIs(t, engine.checkSignalPrice(order), ErrStalePrice)
+	submitSignal(engine, order)
+
+	assert.Equal(t, models.OrderStatusRejected, order.Status)
+	assert.Empty(t, engine.tradeQueue)
+
+	within := createSignalOrder(models.OrderSideBuy, 149.0, clockStart)
+	submitSignal(engine, within)
+	assert.Equal(t, models.OrderStatusFilled, within.Status)
+}
+
+func TestTradingEngine_ProcessOrder_SignalBehindMarketData(t *testing.T) {
+	tests := []struct {
+		action StalePriceAction
+		status models.OrderStatus
+	}{
+		{StalePriceReprice, models.OrderStatusFilled},
+		{StalePriceReject, models.OrderStatusRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			engine, fake := createStalePriceEngine(t, StalePriceConfig{MaxAge: 5 * time.Second, Action: tt.action})
+			fake.Advance(10 * time.Second)
+			engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(155), Timestamp: fake.Now()})
+
+			order := createSignalOrder(models.OrderSideBuy, 150.0, clockStart)
+			submitSignal(engine, order)
+
+			assert.Equal(t, tt.status, order.Status)
+			if tt.status == models.OrderStatusFilled {
+				assert.True(t, decimal.NewFromInt(155).Equal(order.Price))
+			}
+		})
+	}
+}
+
+func TestTradingEngine_ProcessOrder_RejectsOnOldMarketData(t *testing.T) {
+	engine, fake := createStalePriceEngine(t, StalePriceConfig{MaxAge: 5 * time.Second})
+	engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(150), Timestamp: clockStart})
+	fake.Advance(time.Minute)
+
+	order := createSignalOrder(models.OrderSideBuy, 150.0, fake.Now())
+	submitSignal(engine, order)
+
+	assert.Equal(t, models.OrderStatusRejected, order.Status)
+}
+
+func TestTradingEngine_ProcessOrder_RejectsWithoutMarketData(t *testing.T) {
+	for _, action := range []StalePriceAction{StalePriceReprice, StalePriceReject} {
+		t.Run(string(action), func(t *testing.T) {
+			engine, _ := createStalePriceEngine(t, StalePriceConfig{MaxAge: 5 * time.Second, Action: action})
+
+			order := createSignalOrder(models.OrderSideBuy, 150.0, clockStart)
+			assert.ErrorIs(t, engine.checkSignalPrice(order), ErrStalePrice)
+			submitSignal(engine, order)
+
+			assert.Equal(t, models.OrderStatusRejected, order.Status)
+			assert.Empty(t, engine.tradeQueue)
+		})
+	}
+}
+
+func TestTradingEngine_ProcessOrder_StalePriceCheckSkipsLimitOrders(t *testing.T) {
+	engine, _ := createStalePriceEngine(t, StalePriceConfig{MaxDeviation: decimal.NewFromFloat(0.01), Action: StalePriceReject})
+	engine.UpdateMarketData("AAPL", &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(150), Timestamp: clockStart})
+
+	order := createSignalOrder(models.OrderSideBuy, 140.0, clockStart)
+	order.Type = models.OrderTypeLimit
+	submitSignal(engine, order)
+
+	assert.Contains(t, engine.book.orders, order.ID)
+}
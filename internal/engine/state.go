@@ -0,0 +1,201 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const stateVersion = 1
+
+type stateDocument struct {
+	Version       int                        `json:"version"`
+	SavedAt       time.Time                  `json:"saved_at"`
+	AuditSequence *uint64                    `json:"audit_sequence,omitempty"`
+	Portfolio     *models.Portfolio          `json:"portfolio"`
+	OpenOrders    []*models.Order            `json:"open_orders,omitempty"`
+	EquityCurve   []models.EquityPoint       `json:"equity_curve,omitempty"`
+	Attribution   map[string]*strategyLedger `json:"attribution,omitempty"`
+	Strategies    map[string]strategyState   `json:"strategies,omitempty"`
+	Statements    []models.DailyStatement    `json:"daily_statements,omitempty"`
+	OpenStatement *models.DailyStatement     `json:"open_statement,omitempty"`
+}
+
+type strategyState struct {
+	RealizedPnL decimal.Decimal `json:"realized_pnl"`
+	PeakPnL     decimal.Decimal `json:"peak_pnl"`
+	OrderDay    time.Time       `json:"order_day,omitempty"`
+	OrdersToday int             `json:"orders_today,omitempty"`
+}
+
+func (e *TradingEngine) SaveState(path string) error {
+	data, err := json.MarshalIndent(e.stateSnapshot(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (e *TradingEngine) stateSnapshot() stateDocument {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	document := stateDocument{
+		Version:     stateVersion,
+		SavedAt:     e.now(),
+		Portfolio:   copyPortfolio(e.portfolio),
+		EquityCurve: e.equityCurve.snapshot(),
+		Attribution: make(map[string]*strategyLedger, len(e.attribution)),
+		Strategies:  make(map[string]strategyState),
+		Statements:  append([]models.DailyStatement(nil), e.books.statements...),
+	}
+	document.OpenStatement = e.currentStatement()
+	if e.auditLog != nil {
+		sequence := e.auditLog.Sequence()
+		document.AuditSequence = &sequence
+	}
+	for _, order := range e.openOrders {
+		document.OpenOrders = append(document.OpenOrders, copyOrder(order))
+	}
+	sortOrders(document.OpenOrders)
+	for strategyID, ledger := range e.attribution {
+		ledgerCopy := *ledger
+		ledgerCopy.Holdings = make(map[string]decimal.Decimal, len(ledger.Holdings))
+		for symbol, quantity := range ledger.Holdings {
+			ledgerCopy.Holdings[symbol] = quantity
+		}
+		document.Attribution[strategyID] = &ledgerCopy
+	}
+	for strategyID, drawdown := range e.drawdowns {
+		document.Strategies[strategyID] = strategyState{RealizedPnL: drawdown.realized, PeakPnL: drawdown.peak}
+	}
+	for strategyID, counter := range e.dailyOrders {
+		state := document.Strategies[strategyID]
+		state.OrderDay, state.OrdersToday = counter.day, counter.count
+		document.Strategies[strategyID] = state
+	}
+	return document
+}
+
+func NewTradingEngineFromState(path string, logger *zap.Logger, opts ...Option) (*TradingEngine, error) {
+	engine, _, err := loadTradingEngine(path, logger, opts...)
+	if err != nil {
+		return nil, err
+	}
+	engine.resubmit(engine.restoreOpenOrders())
+	return engine, nil
+}
+
+func loadTradingEngine(path string, logger *zap.Logger, opts ...Option) (*TradingEngine, *stateDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	document, err := decodeState(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	engine := NewTradingEngine(document.Portfolio.Cash, logger, opts...)
+	engine.portfolio = document.Portfolio
+	for _, point := range document.EquityCurve {
+		engine.equityCurve.add(point)
+	}
+	if document.Attribution != nil {
+		engine.attribution = document.Attribution
+	} else {
+		engine.seedAttribution()
+	}
+	for _, order := range document.OpenOrders {
+		engine.openOrders[order.ID] = order
+	}
+	for strategyID, state := range document.Strategies {
+		engine.drawdowns[strategyID] = &strategyDrawdown{realized: state.RealizedPnL, peak: state.PeakPnL}
+		if state.OrdersToday > 0 {
+			engine.dailyOrders[strategyID] = &dailyOrderCount{day: state.OrderDay, count: state.OrdersToday}
+		}
+	}
+	engine.restoreBooks(document.Statements, document.OpenStatement)
+	return engine, document, nil
+}
+
+func decodeState(data []byte) (*stateDocument, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var document stateDocument
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidState, err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: trailing data", ErrInvalidState)
+	}
+	if document.Version != stateVersion {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrStateVersion, document.Version, stateVersion)
+	}
+
+	portfolio := document.Portfolio
+	if portfolio == nil || portfolio.ID == "" {
+		return nil, fmt.Errorf("%w: missing portfolio", ErrInvalidState)
+	}
+
+	if portfolio.Positions == nil {
+		portfolio.Positions = make(map[string]*models.Position)
+	}
+	for symbol, position := range portfolio.Positions {
+		if position == nil || position.Symbol != symbol {
+			return nil, fmt.Errorf("%w: position %q", ErrInvalidState, symbol)
+		}
+	}
+	for _, trade := range portfolio.TradeHistory {
+		if trade == nil {
+			return nil, fmt.Errorf("%w: empty trade", ErrInvalidState)
+		}
+	}
+	for _, order := range portfolio.OrderHistory {
+		if order == nil {
+			return nil, fmt.Errorf("%w: empty order", ErrInvalidState)
+		}
+	}
+	for _, order := range document.OpenOrders {
+		if order == nil || order.ID == "" {
+			return nil, fmt.Errorf("%w: empty open order", ErrInvalidState)
+		}
+	}
+	if portfolio.TradeHistory == nil {
+		portfolio.TradeHistory = []*models.Trade{}
+	}
+	if portfolio.OrderHistory == nil {
+		portfolio.OrderHistory = []*models.Order{}
+	}
+	for i := 1; i < len(document.EquityCurve); i++ {
+		if !document.EquityCurve[i].Timestamp.After(document.EquityCurve[i-1].Timestamp) {
+			return nil, fmt.Errorf("%w: equity curve out of order at %d", ErrInvalidState, i)
+		}
+	}
+
+	return &document, nil
+}
@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTradingEngine_SaveState_RoundTrip(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	engine.drainQueues()
+	engine.portfolio.Cash = engine.portfolio.Cash.Add(decimal.RequireFromString("0.000000000123456789"))
+	engine.updatePortfolio()
+	engine.clock.current = time.Now().Add(time.Minute)
+	engine.updatePortfolio()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, engine.SaveState(path))
+
+	restored, err := NewTradingEngineFromState(path, zap.NewNop())
+	require.NoError(t, err)
+
+	original, loaded := engine.GetPortfolio(), restored.GetPortfolio()
+	assert.Equal(t, original.ID, loaded.ID)
+	assert.Equal(t, original.Cash.String(), loaded.Cash.String())
+	assert.True(t, original.TotalValue.Equal(loaded.TotalValue))
+	assert.True(t, original.CreatedAt.Equal(loaded.CreatedAt))
+	require.Len(t, loaded.Positions, 1)
+	assert.Equal(t, original.Positions["AAPL"].Quantity, loaded.Positions["AAPL"].Quantity)
+	assert.True(t, original.Positions["AAPL"].AveragePrice.Equal(loaded.Positions["AAPL"].AveragePrice))
+	assert.NotSame(t, original.Positions["AAPL"], loaded.Positions["AAPL"])
+	require.Len(t, loaded.TradeHistory, 1)
+	assert.True(t, original.TradeHistory[0].Commission.Equal(loaded.TradeHistory[0].Commission))
+	assert.True(t, original.TradeHistory[0].Timestamp.Equal(loaded.TradeHistory[0].Timestamp))
+	require.Len(t, loaded.OrderHistory, 1)
+	assert.Equal(t, models.OrderStatusFilled, loaded.OrderHistory[0].Status)
+
+	curve := restored.GetEquityCurve()
+	require.Len(t, curve, 2)
+	assert.True(t, engine.GetEquityCurve()[1].Timestamp.Equal(curve[1].Timestamp))
+	assert.True(t, original.TotalValue.Equal(curve[1].Value))
+}
+
+func TestNewTradingEngineFromState_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		err      error
+	}{
+		{"corrupted", `{"version": 1, "portfolio": {"id": "PORT-1", "cash": "100`, ErrInvalidState},
+		{"version mismatch", `{"version": 2, "portfolio": {"id": "PORT-1"}}`, ErrStateVersion},
+		{"missing portfolio", `{"version": 1}`, ErrInvalidState},
+		{"unknown field", `{"version": 1, "portfolio": {"id": "PORT-1", "bogus": true}}`, ErrInvalidState},
+		{"mismatched position", `{"version": 1, "portfolio": {"id": "PORT-1", "positions": {"AAPL": {"symbol": "MSFT"}}}}`, ErrInvalidState},
+		{"null position", `{"version": 1, "portfolio": {"id": "PORT-1", "positions": {"AAPL": null}}}`, ErrInvalidState},
+		{"trailing data", `{"version": 1, "portfolio": {"id": "PORT-1"}} {}`, ErrInvalidState},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.contents), 0o644))
+
+			engine, err := NewTradingEngineFromState(path, zap.NewNop())
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Nil(t, engine)
+		})
+	}
+}
+
+func TestNewTradingEngineFromState_EmptyCollections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 1, "saved_at": "2024-03-04T09:30:00Z", "portfolio": {"id": "PORT-1", "cash": "5000.25"}}`), 0o644))
+
+	engine, err := NewTradingEngineFromState(path, zap.NewNop())
+
+	require.NoError(t, err)
+	portfolio := engine.GetPortfolio()
+	assert.Equal(t, "5000.25", portfolio.Cash.String())
+	assert.NotNil(t, portfolio.Positions)
+	assert.NotNil(t, portfolio.TradeHistory)
+	assert.NotNil(t, portfolio.OrderHistory)
+}
@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/alerts"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultExecuteTimeout = 2 * time.Second
+	defaultMaxFailures    = 3
+
+	failurePanic   = "panic"
+	failureTimeout = "timeout"
+	failureError   = "error"
+)
+
+type strategyHealth struct {
+	running  atomic.Bool
+	failures int
+}
+
+type strategyRun struct {
+	results []*models.AlgorithmResult
+	err     error
+}
+
+func (e *TradingEngine) executeGuarded(ctx context.Context, strategy strategies.Strategy, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot, health *strategyHealth) ([]*models.AlgorithmResult, error) {
+	timeout := strategy.GetConfig().ExecuteTimeout
+	if timeout <= 0 {
+		timeout = defaultExecuteTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan strategyRun, 1)
+	health.running.Store(true)
+	go func() {
+		defer health.running.Store(false)
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				e.logger.Error("Strategy panicked",
+					zap.String("strategy_id", strategy.ID()),
+					zap.Any("panic", recovered),
+					zap.ByteString("stack", debug.Stack()))
+				done <- strategyRun{err: fmt.Errorf("%w: %v", ErrStrategyPanic, recovered)}
+			}
+		}()
+		results, err := executeStrategy(ctx, strategy, symbol, portfolio, market)
+		done <- strategyRun{results: results, err: err}
+	}()
+
+	var run strategyRun
+	select {
+	case run = <-done:
+	case <-ctx.Done():
+		run.err = ctx.Err()
+	}
+	if run.err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w after %s", ErrStrategyTimeout, timeout)
+	}
+	return run.results, run.err
+}
+
+func (e *TradingEngine) strategySucceeded(strategyID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if health, exists := e.health[strategyID]; exists {
+		health.failures = 0
+	}
+}
+
+func (e *TradingEngine) strategyFailed(strategy strategies.Strategy, err error) {
+	reason := failureError
+	switch {
+	case errors.Is(err, ErrStrategyPanic):
+		reason = failurePanic
+	case errors.Is(err, ErrStrategyTimeout):
+		reason = failureTimeout
+	}
+	e.metrics.StrategyFailed(strategy.ID(), reason)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	health, exists := e.health[strategy.ID()]
+	if !exists {
+		return
+	}
+	health.failures++
+	limit := strategy.GetConfig().MaxFailures
+	if limit <= 0 {
+		limit = defaultMaxFailures
+	}
+	if health.failures < limit {
+		return
+	}
+
+	config := *strategy.GetConfig()
+	config.Enabled = false
+	if updateErr := strategy.UpdateConfig(&config); updateErr != nil {
+		e.logger.Error("Failed to disable failing strategy", zap.String("strategy_id", strategy.ID()), zap.Error(updateErr))
+		return
+	}
+	health.failures = 0
+	e.alert(alerts.Alert{
+		Type:       alerts.TypeStrategyDisabled,
+		StrategyID: strategy.ID(),
+		Message:    "Strategy disabled after consecutive execution failures",
+		Values: map[string]string{
+			"failures":   strconv.Itoa(limit),
+			"reason":     reason,
+			"last_error": err.Error(),
+		},
+	})
+	e.logger.Warn("Strategy disabled after consecutive execution failures",
+		zap.String("strategy_id", strategy.ID()),
+		zap.Int("failures", limit),
+		zap.String("reason", reason),
+		zap.Error(err))
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/alerts"
+	"github.com/1cbyc/trade-algo-go/internal/metrics"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type faultyStrategy struct {
+	stubStrategy
+	fault func()
+}
+
+func (s *faultyStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	s.calls.Add(1)
+	s.fault()
+	return s.result, nil
+}
+
+func buySignal(strategyID string) *models.AlgorithmResult {
+	return &models.AlgorithmResult{StrategyID: strategyID, Symbol: "AAPL", Action: "buy", Quantity: decimal.NewFromInt(1), Price: decimal.NewFromFloat(100.0)}
+}
+
+func strategyOrders(engine *TradingEngine, strategyID string) int {
+	count := 0
+	for _, order := range engine.GetPortfolio().OrderHistory {
+		if order.StrategyID == strategyID {
+			count++
+		}
+	}
+	return count
+}
+
+func TestTradingEngine_ExecuteStrategies_IsolatesFailingStrategies(t *testing.T) {
+	engine := createTestEngine()
+	delete(engine.strategies, "test_strategy")
+	m := metrics.New()
+	engine.SetMetrics(m)
+	publisher := &recordingPublisher{}
+	require.NoError(t, engine.SetAlerts(publisher, AlertConfig{}))
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+
+	release := make(chan struct{})
+	healthy := &stubStrategy{
+		config: &models.StrategyConfig{ID: "healthy", Name: "Healthy", Enabled: true},
+		result: buySignal("healthy"),
+	}
+	panicking := &faultyStrategy{
+		stubStrategy: stubStrategy{config: &models.StrategyConfig{ID: "panicking", Name: "Panicking", Enabled: true, MaxFailures: 3}},
+		fault:        func() { panic("index out of range") },
+	}
+	sleeping := &faultyStrategy{
+		stubStrategy: stubStrategy{
+			config: &models.StrategyConfig{ID: "sleeping", Name: "Sleeping", Enabled: true, ExecuteTimeout: 20 * time.Millisecond, MaxFailures: 1},
+			result: buySignal("sleeping"),
+		},
+		fault: func() { <-release },
+	}
+	engine.AddStrategy(healthy)
+	engine.AddStrategy(panicking)
+	engine.AddStrategy(sleeping)
+
+	for run := 1; run <= 5; run++ {
+		engine.executeStrategies(context.Background())
+		engine.drainQueues()
+		assert.Equal(t, run, strategyOrders(engine, "healthy"))
+	}
+
+	assert.EqualValues(t, 5, healthy.calls.Load())
+	assert.EqualValues(t, 3, panicking.calls.Load())
+	assert.False(t, panicking.IsEnabled())
+	assert.EqualValues(t, 1, sleeping.calls.Load())
+	assert.False(t, sleeping.IsEnabled())
+
+	close(release)
+	assert.Eventually(t, func() bool { return !engine.health["sleeping"].running.Load() }, time.Second, time.Millisecond)
+	engine.drainQueues()
+	assert.Zero(t, strategyOrders(engine, "sleeping"))
+	assert.Empty(t, engine.GetOpenOrders())
+
+	published := publisher.published()
+	require.Len(t, published, 2)
+	for i, strategyID := range []string{"sleeping", "panicking"} {
+		assert.Equal(t, alerts.TypeStrategyDisabled, published[i].Type)
+		assert.Equal(t, strategyID, published[i].StrategyID)
+	}
+	assert.Equal(t, "timeout", published[0].Values["reason"])
+	assert.Equal(t, "panic", published[1].Values["reason"])
+	assert.Equal(t, "3", published[1].Values["failures"])
+
+	scraped := scrapeMetrics(m)
+	assert.True(t, strings.Contains(scraped, `trade_algo_strategy_failures_total{reason="panic",strategy_id="panicking"} 3`), scraped)
+	assert.True(t, strings.Contains(scraped, `trade_algo_strategy_failures_total{reason="timeout",strategy_id="sleeping"} 1`), scraped)
+}
+
+func TestTradingEngine_ExecuteStrategies_ResetsFailuresOnSuccess(t *testing.T) {
+	engine := createTestEngine()
+	delete(engine.strategies, "test_strategy")
+	fail := true
+	flaky := &faultyStrategy{
+		stubStrategy: stubStrategy{config: &models.StrategyConfig{ID: "flaky", Name: "Flaky", Enabled: true, MaxFailures: 2}},
+		fault: func() {
+			if fail {
+				panic("flaky")
+			}
+		},
+	}
+	engine.AddStrategy(flaky)
+
+	for _, failing := range []bool{true, false, true, false, true} {
+		fail = failing
+		engine.executeStrategies(context.Background())
+	}
+
+	assert.True(t, flaky.IsEnabled())
+	assert.Equal(t, 1, engine.health["flaky"].failures)
+
+	engine.executeStrategies(context.Background())
+	assert.False(t, flaky.IsEnabled())
+}
@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/stress"
+	"github.com/shopspring/decimal"
+)
+
+func (e *TradingEngine) SetStressScenarios(scenarios []stress.Scenario) error {
+	names := make(map[string]bool, len(scenarios))
+	for _, scenario := range scenarios {
+		if err := scenario.Validate(); err != nil {
+			return err
+		}
+		if names[scenario.Name] {
+			return fmt.Errorf("%w: duplicate scenario %q", stress.ErrInvalidScenario, scenario.Name)
+		}
+		names[scenario.Name] = true
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stressScenarios = append([]stress.Scenario(nil), scenarios...)
+	return nil
+}
+
+func (e *TradingEngine) StressScenarios() []stress.Scenario {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]stress.Scenario(nil), e.stressScenarios...)
+}
+
+func (e *TradingEngine) RunStressTests() []*stress.Result {
+	scenarios := e.StressScenarios()
+	results := make([]*stress.Result, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		result, err := e.RunStressTest(scenario)
+		if err != nil {
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (e *TradingEngine) RunStressTest(scenario stress.Scenario) (*stress.Result, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	symbols := make([]string, 0, len(e.portfolio.Positions))
+	for symbol := range e.portfolio.Positions {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	result := &stress.Result{
+		Scenario:      scenario.Name,
+		CurrentValue:  e.portfolio.Cash,
+		StressedValue: e.portfolio.Cash,
+		Positions:     make([]stress.PositionImpact, 0, len(symbols)),
+	}
+	gross := decimal.Zero
+	for _, symbol := range symbols {
+		position := e.portfolio.Positions[symbol]
+		price := position.CurrentPrice
+		if data, exists := e.marketData[symbol]; exists {
+			price = data.Price
+		}
+		shocked, volatility := scenario.Apply(symbol, price)
+
+		impact := stress.PositionImpact{
+			Symbol:        symbol,
+			StrategyID:    position.StrategyID,
+			Quantity:      position.Quantity,
+			CurrentPrice:  price,
+			StressedPrice: shocked,
+			CurrentValue:  price.Mul(position.Quantity),
+			StressedValue: shocked.Mul(position.Quantity),
+			Exit:          e.stressedExit(position, shocked),
+		}
+		impact.PnL = impact.StressedValue.Sub(impact.CurrentValue)
+		if !impact.CurrentValue.IsZero() {
+			impact.StressedVaR95 = position.RiskMetrics.VaR95.Mul(volatility).Mul(impact.StressedValue.Abs()).Div(impact.CurrentValue.Abs())
+		}
+
+		result.CurrentValue = result.CurrentValue.Add(impact.CurrentValue)
+		result.StressedValue = result.StressedValue.Add(impact.StressedValue)
+		gross = gross.Add(impact.StressedValue.Abs())
+		result.Positions = append(result.Positions, impact)
+	}
+	result.PnL = result.StressedValue.Sub(result.CurrentValue)
+
+	peak := decimal.Max(e.portfolio.PeakValue, result.CurrentValue)
+	if peak.IsPositive() && result.StressedValue.LessThan(peak) {
+		result.Drawdown = peak.Sub(result.StressedValue).Div(peak)
+	}
+	result.DrawdownHalt = e.maxDrawdown.IsPositive() && result.Drawdown.GreaterThan(e.maxDrawdown)
+
+	if margin := e.portfolio.Margin; margin != nil {
+		result.MaintenanceMargin = gross.Mul(margin.MaintenanceRate)
+		result.MarginCall = result.StressedValue.LessThan(result.MaintenanceMargin)
+	}
+	return result, nil
+}
+
+func (e *TradingEngine) stressedExit(position *models.Position, price decimal.Decimal) models.ExitReason {
+	strategy, exists := e.strategies[position.StrategyID]
+	if !exists || position.Quantity.IsZero() || position.AveragePrice.IsZero() {
+		return ""
+	}
+
+	stressed := *position
+	trackExtremes(&stressed, price)
+	config := strategy.GetConfig()
+	if reason := exitReason(&stressed, price, config); reason != "" {
+		return reason
+	}
+	if config.MaxDrawdown.IsPositive() && positionDrawdown(&stressed, price).GreaterThan(config.MaxDrawdown) {
+		return models.ExitReasonDrawdown
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/1cbyc/trade-algo-go/internal/stress"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func createStressEngine(t *testing.T) *TradingEngine {
+	config := createTestStrategyConfig()
+	config.StopLossPercent = decimal.NewFromFloat(0.15)
+	engine := NewTradingEngine(decimal.NewFromInt(100000), zap.NewNop())
+	engine.AddStrategy(strategies.NewMovingAverageStrategy(config))
+	require.NoError(t, engine.SetMargin(MarginConfig{InitialRate: decimal.NewFromFloat(0.5), MaintenanceRate: decimal.NewFromFloat(0.25)}))
+
+	engine.portfolio.Cash = decimal.NewFromInt(70000)
+	engine.portfolio.PeakValue = decimal.NewFromInt(100000)
+	engine.portfolio.Positions = map[string]*models.Position{
+		"AAPL": {Symbol: "AAPL", StrategyID: "test_strategy", Quantity: decimal.NewFromInt(100), AveragePrice: decimal.NewFromInt(130), CurrentPrice: decimal.NewFromInt(150), PeakPrice: decimal.NewFromInt(150)},
+		"MSFT": {Symbol: "MSFT", StrategyID: "test_strategy", Quantity: decimal.NewFromInt(50), AveragePrice: decimal.NewFromInt(300), CurrentPrice: decimal.NewFromInt(300), PeakPrice: decimal.NewFromInt(300)},
+	}
+	engine.marketData["AAPL"] = createTestMarketData("AAPL", 150)
+	engine.marketData["MSFT"] = createTestMarketData("MSFT", 300)
+	return engine
+}
+
+func TestTradingEngine_RunStressTest_MarketCrash(t *testing.T) {
+	engine := createStressEngine(t)
+	before := engine.GetPortfolio()
+
+	result, err := engine.RunStressTest(stress.Scenario{Name: "crash", Shocks: []stress.Shock{{PriceChange: decimal.NewFromFloat(-0.2)}}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "crash", result.Scenario)
+	assert.True(t, decimal.NewFromInt(100000).Equal(result.CurrentValue))
+	assert.True(t, decimal.NewFromInt(94000).Equal(result.StressedValue))
+	assert.True(t, decimal.NewFromInt(-6000).Equal(result.PnL))
+	assert.True(t, decimal.NewFromFloat(0.06).Equal(result.Drawdown))
+	assert.True(t, decimal.NewFromInt(6000).Equal(result.MaintenanceMargin))
+	assert.False(t, result.MarginCall)
+
+	require.Len(t, result.Positions, 2)
+	aapl, msft := result.Positions[0], result.Positions[1]
+	assert.Equal(t, "AAPL", aapl.Symbol)
+	assert.True(t, decimal.NewFromInt(120).Equal(aapl.StressedPrice))
+	assert.True(t, decimal.NewFromInt(12000).Equal(aapl.StressedValue))
+	assert.True(t, decimal.NewFromInt(-3000).Equal(aapl.PnL))
+	assert.Empty(t, aapl.Exit)
+	assert.Equal(t, "MSFT", msft.Symbol)
+	assert.True(t, decimal.NewFromInt(240).Equal(msft.StressedPrice))
+	assert.True(t, decimal.NewFromInt(-3000).Equal(msft.PnL))
+	assert.Equal(t, models.ExitReasonStopLoss, msft.Exit)
+
+	assert.Equal(t, before, engine.GetPortfolio())
+	assert.Empty(t, engine.GetOpenOrders())
+}
+
+func TestTradingEngine_RunStressTest_SingleNameWithVolatility(t *testing.T) {
+	engine := createStressEngine(t)
+	engine.portfolio.Positions["AAPL"].RiskMetrics.VaR95 = decimal.NewFromInt(500)
+
+	result, err := engine.RunStressTest(stress.Scenario{Name: "aapl", Shocks: []stress.Shock{
+		{Symbols: []string{"AAPL"}, PriceChange: decimal.NewFromFloat(-0.5), VolatilityMultiplier: decimal.NewFromInt(3)},
+	}})
+	require.NoError(t, err)
+
+	aapl, msft := result.Positions[0], result.Positions[1]
+	assert.True(t, decimal.NewFromInt(75).Equal(aapl.StressedPrice))
+	assert.True(t, decimal.NewFromInt(750).Equal(aapl.StressedVaR95))
+	assert.Equal(t, models.ExitReasonStopLoss, aapl.Exit)
+	assert.True(t, decimal.NewFromInt(300).Equal(msft.StressedPrice))
+	assert.True(t, msft.PnL.IsZero())
+	assert.True(t, decimal.NewFromInt(92500).Equal(result.StressedValue))
+}
+
+func TestTradingEngine_RunStressTest_MarginCall(t *testing.T) {
+	engine := createStressEngine(t)
+	engine.portfolio.Cash = decimal.NewFromInt(-20000)
+
+	result, err := engine.RunStressTest(stress.Scenario{Name: "crash", Shocks: []stress.Shock{{PriceChange: decimal.NewFromFloat(-0.4)}}})
+	require.NoError(t, err)
+
+	assert.True(t, decimal.NewFromInt(-2000).Equal(result.StressedValue))
+	assert.True(t, result.MarginCall)
+}
+
+func TestTradingEngine_SetStressScenarios_Validates(t *testing.T) {
+	engine := createTestEngine()
+	crash := stress.Scenario{Name: "crash", Shocks: []stress.Shock{{PriceChange: decimal.NewFromFloat(-0.2)}}}
+
+	assert.ErrorIs(t, engine.SetStressScenarios([]stress.Scenario{crash, crash}), stress.ErrInvalidScenario)
+	assert.ErrorIs(t, engine.SetStressScenarios([]stress.Scenario{{Name: "empty"}}), stress.ErrInvalidScenario)
+	assert.ErrorIs(t, engine.SetStressScenarios([]stress.Scenario{{Name: "wipeout", Shocks: []stress.Shock{{PriceChange: decimal.NewFromInt(-1)}}}}), stress.ErrInvalidScenario)
+
+	require.NoError(t, engine.SetStressScenarios([]stress.Scenario{crash}))
+	assert.Equal(t, []stress.Scenario{crash}, engine.StressScenarios())
+	require.Len(t, engine.RunStressTests(), 1)
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+var basisPoints = decimal.NewFromInt(10000)
+
+func (e *TradingEngine) marketPrice(symbol string) decimal.Decimal {
+	if data, exists := e.marketData[symbol]; exists && data.Price.IsPositive() {
+		return data.Price
+	}
+	return decimal.Zero
+}
+
+func decisionPrice(result *models.AlgorithmResult, market *models.MarketSnapshot) decimal.Decimal {
+	if market != nil {
+		if data, exists := market.Latest[result.Symbol]; exists && data.Price.IsPositive() {
+			return data.Price
+		}
+	}
+	return result.Price
+}
+
+func (e *TradingEngine) stampSubmission(order *models.Order) {
+	if !order.SubmissionPrice.IsZero() {
+		return
+	}
+	order.SubmissionPrice = e.marketPrice(order.Symbol)
+	if order.DecisionPrice.IsZero() {
+		order.DecisionPrice = order.SubmissionPrice
+	}
+}
+
+func transactionCost(side models.OrderSide, decision, market, quote, fill decimal.Decimal) models.TransactionCost {
+	if !decision.IsPositive() {
+		return models.TransactionCost{}
+	}
+	if !market.IsPositive() {
+		market = quote
+	}
+
+	bps := func(from, to decimal.Decimal) decimal.Decimal {
+		cost := to.Sub(from).Div(decision).Mul(basisPoints)
+		if side == models.OrderSideSell {
+			return cost.Neg()
+		}
+		return cost
+	}
+	return models.TransactionCost{
+		DelayBps:     bps(decision, market),
+		SpreadBps:    bps(market, quote),
+		ImpactBps:    bps(quote, fill),
+		ShortfallBps: bps(decision, fill),
+	}
+}
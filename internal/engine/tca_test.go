@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradingEngine_TransactionCosts_SplitShortfallAcrossPriceMove(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetSlippageModel(execution.MaxSlippage{})
+	engine.strategies["test_strategy"].GetConfig().SlippageTolerance = decimal.NewFromFloat(0.001)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+
+	engine.createOrderFromResult(&models.AlgorithmResult{
+		StrategyID: "test_strategy",
+		Symbol:     "AAPL",
+		Action:     models.ActionBuy,
+		Quantity:   decimal.NewFromInt(100),
+		Price:      decimal.NewFromFloat(100.0),
+		Confidence: decimal.NewFromFloat(0.8),
+	}, engine.strategies["test_strategy"], nil)
+	moved := createTestMarketData("AAPL", 101.0)
+	moved.Bid, moved.Ask = decimal.NewFromFloat(100.9), decimal.NewFromFloat(101.1)
+	engine.UpdateMarketData("AAPL", moved)
+	engine.drainQueues()
+
+	require.Len(t, engine.portfolio.TradeHistory, 1)
+	trade := engine.portfolio.TradeHistory[0]
+	assert.True(t, decimal.NewFromInt(100).Equal(trade.DecisionPrice), trade.DecisionPrice.String())
+	assert.True(t, decimal.NewFromInt(101).Equal(trade.SubmissionPrice), trade.SubmissionPrice.String())
+	assert.True(t, decimal.NewFromFloat(101.2011).Equal(trade.Price), trade.Price.String())
+	assert.True(t, decimal.NewFromInt(100).Equal(trade.Costs.DelayBps), trade.Costs.DelayBps.String())
+	assert.True(t, decimal.NewFromInt(10).Equal(trade.Costs.SpreadBps), trade.Costs.SpreadBps.String())
+	assert.True(t, decimal.NewFromFloat(10.11).Equal(trade.Costs.ImpactBps), trade.Costs.ImpactBps.String())
+	assert.True(t, decimal.NewFromFloat(120.11).Equal(trade.Costs.ShortfallBps), trade.Costs.ShortfallBps.String())
+}
+
+type tickingStrategy struct {
+	stubStrategy
+	engine *TradingEngine
+	tick   *models.MarketData
+}
+
+func (s *tickingStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	s.engine.UpdateMarketData(s.tick.Symbol, s.tick)
+	return s.result, nil
+}
+
+func TestTradingEngine_TransactionCosts_DecisionPriceIsWhatStrategySaw(t *testing.T) {
+	engine := createTestEngine()
+	delete(engine.strategies, "test_strategy")
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 100.0))
+	engine.AddStrategy(&tickingStrategy{
+		stubStrategy: stubStrategy{
+			config: &models.StrategyConfig{ID: "ticking", Name: "Ticking", Enabled: true, MaxPositionSize: decimal.NewFromFloat(0.5), MaxOrderSize: decimal.NewFromInt(50000)},
+			result: &models.AlgorithmResult{StrategyID: "ticking", Symbol: "AAPL", Action: models.ActionBuy, Quantity: decimal.NewFromInt(100), Price: decimal.NewFromFloat(100.0), Confidence: decimal.NewFromInt(1)},
+		},
+		engine: engine,
+		tick:   createTestMarketData("AAPL", 101.0),
+	})
+
+	engine.executeStrategies(context.Background())
+	engine.drainQueues()
+
+	require.Len(t, engine.portfolio.TradeHistory, 1)
+	trade := engine.portfolio.TradeHistory[0]
+	assert.True(t, decimal.NewFromInt(100).Equal(trade.DecisionPrice), trade.DecisionPrice.String())
+	assert.True(t, decimal.NewFromInt(101).Equal(trade.SubmissionPrice), trade.SubmissionPrice.String())
+	assert.True(t, decimal.NewFromInt(100).Equal(trade.Costs.DelayBps), trade.Costs.DelayBps.String())
+}
+
+func TestTransactionCost_SellsGainWhenPriceRises(t *testing.T) {
+	costs := transactionCost(models.OrderSideSell, decimal.NewFromInt(100), decimal.NewFromInt(101), decimal.NewFromFloat(100.9), decimal.NewFromFloat(100.8))
+
+	assert.True(t, decimal.NewFromInt(-100).Equal(costs.DelayBps), costs.DelayBps.String())
+	assert.True(t, decimal.NewFromInt(10).Equal(costs.SpreadBps), costs.SpreadBps.String())
+	assert.True(t, decimal.NewFromInt(10).Equal(costs.ImpactBps), costs.ImpactBps.String())
+	assert.True(t, decimal.NewFromInt(-80).Equal(costs.ShortfallBps), costs.ShortfallBps.String())
+}
+
+func TestTransactionCost_ZeroWithoutDecisionPrice(t *testing.T) {
+	costs := transactionCost(models.OrderSideBuy, decimal.Zero, decimal.NewFromInt(101), decimal.NewFromInt(101), decimal.NewFromInt(102))
+
+	assert.Equal(t, models.TransactionCost{}, costs)
+}
@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const tickQueueSize = 1000
+
+type tickQueue struct {
+	mu      sync.Mutex
+	pending map[string]bool
+	lastRun map[string]time.Time
+	symbols chan string
+}
+
+func newTickQueue() *tickQueue {
+	return &tickQueue{
+		pending: make(map[string]bool),
+		lastRun: make(map[string]time.Time),
+		symbols: make(chan string, tickQueueSize),
+	}
+}
+
+func (q *tickQueue) enqueue(symbol string) {
+	q.mu.Lock()
+	if q.pending[symbol] {
+		q.mu.Unlock()
+		return
+	}
+	q.pending[symbol] = true
+	q.mu.Unlock()
+
+	q.symbols <- symbol
+}
+
+func (q *tickQueue) take(symbol string, debounce time.Duration, now time.Time) time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if last, ran := q.lastRun[symbol]; ran && debounce > 0 {
+		if wait := debounce - now.Sub(last); wait > 0 {
+			return wait
+		}
+	}
+	q.pending[symbol] = false
+	q.lastRun[symbol] = now
+	return 0
+}
+
+func (e *TradingEngine) tickExecutor(ctx context.Context, stop <-chan struct{}, ticks *tickQueue, intervals Intervals) {
+	for {
+		select {
+		case symbol := <-ticks.symbols:
+			if wait := ticks.take(symbol, intervals.Debounce, e.wallClock.Now()); wait > 0 {
+				go ticks.requeue(ctx, stop, symbol, e.wallClock.After(wait))
+				continue
+			}
+			e.runEventDriven(ctx, intervals, symbol)
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (q *tickQueue) requeue(ctx context.Context, stop <-chan struct{}, symbol string, after <-chan time.Time) {
+	select {
+	case <-after:
+	case <-ctx.Done():
+		return
+	case <-stop:
+		return
+	}
+	select {
+	case q.symbols <- symbol:
+	case <-ctx.Done():
+	case <-stop:
+	}
+}
+
+func (e *TradingEngine) runEventDriven(ctx context.Context, intervals Intervals, symbol string) {
+	if intervals.Portfolio <= 0 {
+		e.updatePortfolio()
+	}
+	if intervals.Strategy <= 0 {
+		e.executeStrategiesOnTick(ctx, symbol)
+	}
+	if intervals.Risk <= 0 {
+		e.manageRisk()
+		e.updateRiskMetrics()
+	}
+}
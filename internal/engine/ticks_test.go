@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var tickDrivenIntervals = Intervals{Strategy: 0, Risk: time.Hour, Portfolio: time.Hour}
+
+type tickStrategy struct {
+	stubStrategy
+	mu      sync.Mutex
+	symbols []string
+}
+
+func (s *tickStrategy) ExecuteOnTick(ctx context.Context, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.symbols = append(s.symbols, symbol)
+	return nil, nil
+}
+
+func (s *tickStrategy) triggered() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.symbols...)
+}
+
+func startEngine(t *testing.T, engine *TradingEngine) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, engine.Start(ctx))
+	t.Cleanup(func() {
+		engine.Stop()
+		cancel()
+	})
+}
+
+func TestTradingEngine_TickDrivenStrategies(t *testing.T) {
+	engine, strategy := createCountingEngine(tickDrivenIntervals)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	assert.Equal(t, int32(0), strategy.calls.Load())
+
+	startEngine(t, engine)
+	for _, symbol := range []string{"AAPL", "MSFT", "GOOGL"} {
+		engine.UpdateMarketData(symbol, createTestMarketData(symbol, 150.0))
+	}
+
+	assert.Eventually(t, func() bool { return strategy.calls.Load() == 3 }, time.Second, time.Millisecond)
+}
+
+func TestTradingEngine_TickAwareStrategyGetsSymbol(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetIntervals(tickDrivenIntervals)
+	strategy := &tickStrategy{stubStrategy: stubStrategy{config: &models.StrategyConfig{ID: "tick", Name: "Tick", Enabled: true}}}
+	engine.AddStrategy(strategy)
+	startEngine(t, engine)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	require.Eventually(t, func() bool { return len(strategy.triggered()) == 1 }, time.Second, time.Millisecond)
+	engine.UpdateMarketData("MSFT", createTestMarketData("MSFT", 300.0))
+	require.Eventually(t, func() bool { return len(strategy.triggered()) == 2 }, time.Second, time.Millisecond)
+
+	assert.Equal(t, []string{"AAPL", "MSFT"}, strategy.triggered())
+	assert.Equal(t, int32(0), strategy.calls.Load())
+}
+
+func TestTradingEngine_DebounceCoalescesBurst(t *testing.T) {
+	intervals := tickDrivenIntervals
+	intervals.Debounce = 50 * time.Millisecond
+	fake := clock.NewFake(clockStart)
+	engine, strategy := createCountingEngine(intervals, WithClock(fake))
+	startEngine(t, engine)
+
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+	require.Eventually(t, func() bool { return strategy.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	for i := 0; i < 100; i++ {
+		engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0+float64(i)))
+	}
+	fake.BlockUntil(3)
+	assert.Equal(t, int32(1), strategy.calls.Load())
+
+	fake.Advance(intervals.Debounce)
+	require.Eventually(t, func() bool { return strategy.calls.Load() == 2 }, time.Second, time.Millisecond)
+	fake.Advance(2 * intervals.Debounce)
+	assert.Empty(t, engine.ticks.symbols)
+	assert.Equal(t, int32(2), strategy.calls.Load())
+}
+
+func TestTickQueue_Take(t *testing.T) {
+	queue := newTickQueue()
+	start := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+
+	queue.enqueue("AAPL")
+	queue.enqueue("AAPL")
+	assert.Len(t, queue.symbols, 1)
+
+	assert.Zero(t, queue.take(<-queue.symbols, time.Second, start))
+	queue.enqueue("AAPL")
+	assert.Equal(t, 600*time.Millisecond, queue.take(<-queue.symbols, time.Second, start.Add(400*time.Millisecond)))
+
+	queue.enqueue("AAPL")
+	assert.Empty(t, queue.symbols)
+	assert.Zero(t, queue.take("AAPL", time.Second, start.Add(time.Second)))
+	assert.Zero(t, queue.take("MSFT", time.Second, start))
+}
+
+func BenchmarkTradingEngine_TickDriven(b *testing.B) {
+	engine := NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop())
+	engine.SetIntervals(tickDrivenIntervals)
+	engine.AddStrategy(strategies.NewMovingAverageStrategy(createTestStrategyConfig()))
+
+	symbols := make([]string, 8)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("SYM%d", i)
+		for j := 0; j < 100; j++ {
+			engine.UpdateMarketData(symbols[i], createTestMarketData(symbols[i], 100.0+float64(j%7)))
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := engine.Start(ctx); err != nil {
+		b.Fatal(err)
+	}
+	defer engine.Stop()
+
+	maxDepth := 0
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		symbol := symbols[i%len(symbols)]
+		engine.UpdateMarketData(symbol, createTestMarketData(symbol, 100.0+float64(i%7)))
+		if depth := len(engine.ticks.symbols); depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+	for len(engine.ticks.symbols) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "updates/s")
+	b.ReportMetric(float64(maxDepth), "max_queue")
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type dayCutoff struct {
+	offset   time.Duration
+	location *time.Location
+}
+
+func (e *TradingEngine) SetDayOrderCutoff(offset time.Duration, location *time.Location) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.dayCutoff = &dayCutoff{offset: offset, location: location}
+}
+
+func (c *dayCutoff) deadline(placed time.Time) time.Time {
+	local := placed.In(c.location)
+	deadline := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.location).Add(c.offset)
+	if !local.Before(deadline) {
+		deadline = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, c.location).Add(c.offset)
+	}
+	return deadline
+}
+
+func (e *TradingEngine) dayOrderExpired(order *models.Order) bool {
+	if order.TimeInForce != models.TimeInForceDay {
+		return false
+	}
+	if e.calendar != nil {
+		return !e.calendar.SameSession(order.Symbol, order.Timestamp, e.now())
+	}
+	if e.dayCutoff == nil {
+		return false
+	}
+	return !e.now().Before(e.dayCutoff.deadline(order.Timestamp))
+}
+
+func (e *TradingEngine) sweepExpiredOrders() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expireDayOrders()
+}
+
+func (e *TradingEngine) expireDayOrders() {
+	var expired []*models.Order
+	for _, order := range e.book.all() {
+		if e.dayOrderExpired(order) {
+			expired = append(expired, order)
+		}
+	}
+
+	for _, order := range expired {
+		e.book.remove(order.ID)
+		delete(e.openOrders, order.ID)
+		e.cancelOrder(order, models.CancelReasonDayExpired)
+	}
+}
+
+func (e *TradingEngine) fillImmediately(order *models.Order) bool {
+	quantity := e.immediateQuantity(order)
+	if !quantity.IsPositive() {
+		e.cancelOrder(order, models.CancelReasonIOC)
+		return false
+	}
+	if quantity.LessThan(order.Quantity) {
+		order.FilledQuantity = quantity
+		order.CancelReason = models.CancelReasonIOC
+	}
+	return true
+}
+
+func (e *TradingEngine) immediateQuantity(order *models.Order) decimal.Decimal {
+	data := e.marketData[order.Symbol]
+	if order.Type == models.OrderTypeLimit && !limitMarketable(order, data) {
+		return decimal.Zero
+	}
+	if data == nil {
+		return order.Quantity
+	}
+	size := data.AskSize
+	if order.Side == models.OrderSideSell {
+		size = data.BidSize
+	}
+	if size <= 0 {
+		return order.Quantity
+	}
+	return decimal.Min(order.Quantity, e.symbols.RoundDown(order.Symbol, decimal.NewFromInt(size)))
+}
+
+func fillQuantity(order *models.Order) decimal.Decimal {
+	if order.FilledQuantity.IsPositive() && order.FilledQuantity.LessThan(order.Quantity) {
+		return order.FilledQuantity
+	}
+	return order.Quantity
+}
+
+func (e *TradingEngine) cancelOrder(order *models.Order, reason models.CancelReason) {
+	e.release(order.ID)
+	order.Status = models.OrderStatusCancelled
+	order.CancelReason = reason
+	e.recordOrder(order)
+	e.auditOrder(audit.EventOrderCancelled, order, nil)
+	e.logger.Info("Order cancelled",
+		zap.String("order_id", order.ID),
+		zap.String("symbol", order.Symbol),
+		zap.String("reason", string(reason)),
+	)
+}
@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createQuote(symbol string, bid, ask float64, size int64) *models.MarketData {
+	data := createTestMarketData(symbol, (bid+ask)/2)
+	data.Bid, data.Ask = decimal.NewFromFloat(bid), decimal.NewFromFloat(ask)
+	data.BidSize, data.AskSize = size, size
+	return data
+}
+
+func TestTradingEngine_Advance_CancelsUnfilledDayOrderAtSessionEnd(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetCalendar(createTestCalendar(t))
+	engine.clock.current = sessionClose.Add(-time.Hour)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 101.0))
+
+	dayOrder := createLimitOrder(models.OrderSideBuy, 10, 100.0)
+	dayOrder.TimeInForce = models.TimeInForceDay
+	dayOrder.Timestamp = engine.now()
+	gtcOrder := createLimitOrder(models.OrderSideBuy, 10, 100.0)
+	gtcOrder.Timestamp = engine.now()
+	engine.submitOrder(dayOrder)
+	engine.submitOrder(gtcOrder)
+	engine.drainQueues()
+	require.Len(t, engine.book.orders, 2)
+
+	engine.Advance(context.Background(), sessionClose.Add(-time.Minute))
+	assert.Len(t, engine.book.orders, 2)
+
+	engine.Advance(context.Background(), sessionClose.Add(time.Minute))
+
+	assert.Equal(t, models.OrderStatusCancelled, dayOrder.Status)
+	assert.Equal(t, models.CancelReasonDayExpired, dayOrder.CancelReason)
+	assert.Equal(t, models.OrderStatusPending, gtcOrder.Status)
+	assert.Contains(t, engine.book.orders, gtcOrder.ID)
+	assert.NotContains(t, engine.openOrders, dayOrder.ID)
+	assert.Empty(t, engine.portfolio.TradeHistory)
+}
+
+func TestTradingEngine_SetDayOrderCutoff_CancelsWithoutCalendar(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetDayOrderCutoff(16*time.Hour, time.UTC)
+	engine.clock.current = sessionClose.Add(-time.Hour)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 101.0))
+
+	order := createLimitOrder(models.OrderSideBuy, 10, 100.0)
+	order.TimeInForce = models.TimeInForceDay
+	order.Timestamp = engine.now()
+	engine.submitOrder(order)
+	engine.drainQueues()
+
+	engine.clock.current = sessionClose.Add(-time.Second)
+	engine.sweepExpiredOrders()
+	assert.Equal(t, models.OrderStatusPending, order.Status)
+
+	engine.clock.current = sessionClose
+	engine.sweepExpiredOrders()
+	assert.Equal(t, models.OrderStatusCancelled, order.Status)
+	assert.Equal(t, models.CancelReasonDayExpired, order.CancelReason)
+	assert.Empty(t, engine.GetOpenOrders())
+}
+
+func TestTradingEngine_ProcessOrder_IOCFillsAvailableSizeAndCancelsRest(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createQuote("AAPL", 99.9, 100.0, 40))
+
+	order := createLimitOrder(models.OrderSideBuy, 100, 100.5)
+	order.TimeInForce = models.TimeInForceIOC
+	engine.submitOrder(order)
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusPartiallyFilled, order.Status)
+	assert.Equal(t, models.CancelReasonIOC, order.CancelReason)
+	assert.True(t, decimal.NewFromInt(40).Equal(order.FilledQuantity), order.FilledQuantity.String())
+	require.Len(t, engine.portfolio.TradeHistory, 1)
+	assert.True(t, decimal.NewFromInt(40).Equal(engine.portfolio.TradeHistory[0].Quantity))
+	assert.True(t, decimal.NewFromInt(40).Equal(engine.portfolio.Positions["AAPL"].Quantity))
+	assert.Empty(t, engine.GetOpenOrders())
+}
+
+func TestTradingEngine_ProcessOrder_IOCCancelsUnmarketableLimit(t *testing.T) {
+	engine := createTestEngine()
+	engine.UpdateMarketData("AAPL", createQuote("AAPL", 99.9, 100.0, 40))
+
+	order := createLimitOrder(models.OrderSideBuy, 100, 99.0)
+	order.TimeInForce = models.TimeInForceIOC
+	engine.submitOrder(order)
+	engine.drainQueues()
+
+	assert.Equal(t, models.OrderStatusCancelled, order.Status)
+	assert.Equal(t, models.CancelReasonIOC, order.CancelReason)
+	assert.Empty(t, engine.book.orders)
+	assert.Empty(t, engine.GetOpenOrders())
+	assert.Empty(t, engine.portfolio.TradeHistory)
+}
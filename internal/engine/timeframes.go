@@ -0,0 +1,224 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const defaultTimeframeBars = 200
+
+type timeframeBars struct {
+	mu     sync.RWMutex
+	series map[time.Duration]*barSeries
+}
+
+type barSeries struct {
+	capacity int
+	forming  map[string]*models.MarketData
+	closed   map[string]*ringBuffer
+}
+
+func newTimeframeBars() *timeframeBars {
+	return &timeframeBars{series: make(map[time.Duration]*barSeries)}
+}
+
+func (e *TradingEngine) SetTimeframes(timeframes []time.Duration) error {
+	sorted := append([]time.Duration(nil), timeframes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i, timeframe := range sorted {
+		if timeframe <= 0 {
+			return fmt.Errorf("%w: %s is not positive", ErrInvalidTimeframes, timeframe)
+		}
+		if i > 0 && timeframe%sorted[i-1] != 0 {
+			return fmt.Errorf("%w: %s is not a multiple of %s", ErrInvalidTimeframes, timeframe, sorted[i-1])
+		}
+	}
+
+	for _, timeframe := range sorted {
+		e.bars.ensure(timeframe, defaultTimeframeBars)
+	}
+	return nil
+}
+
+func (e *TradingEngine) Timeframes() []time.Duration {
+	return e.bars.timeframes()
+}
+
+func (e *TradingEngine) GetBars(symbol string, timeframe time.Duration, n int) []*models.MarketData {
+	e.bars.mu.RLock()
+	defer e.bars.mu.RUnlock()
+
+	series, exists := e.bars.series[timeframe]
+	if !exists || series.closed[symbol] == nil {
+		return []*models.MarketData{}
+	}
+	return series.closed[symbol].last(n)
+}
+
+func (b *timeframeBars) ensure(timeframe time.Duration, capacity int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	series, exists := b.series[timeframe]
+	if !exists {
+		b.series[timeframe] = &barSeries{
+			capacity: capacity,
+			forming:  make(map[string]*models.MarketData),
+			closed:   make(map[string]*ringBuffer),
+		}
+		return
+	}
+	if capacity <= series.capacity {
+		return
+	}
+	series.capacity = capacity
+	for symbol, buffer := range series.closed {
+		resized := newRingBuffer(capacity)
+		for _, bar := range buffer.last(0) {
+			resized.push(bar)
+		}
+		series.closed[symbol] = resized
+	}
+}
+
+func (b *timeframeBars) timeframes() []time.Duration {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	timeframes := make([]time.Duration, 0, len(b.series))
+	for timeframe := range b.series {
+		timeframes = append(timeframes, timeframe)
+	}
+	sort.Slice(timeframes, func(i, j int) bool { return timeframes[i] < timeframes[j] })
+	return timeframes
+}
+
+func (b *timeframeBars) add(data *models.MarketData) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for timeframe, series := range b.series {
+		if data.Kind == models.MarketDataKindBar && data.Interval > timeframe {
+			continue
+		}
+		start := data.Timestamp.Truncate(timeframe)
+		bar, exists := series.forming[data.Symbol]
+		switch {
+		case exists && bar.Timestamp.Equal(start):
+			mergeBar(bar, data)
+			continue
+		case exists && start.Before(bar.Timestamp):
+			continue
+		case exists:
+			series.close(data.Symbol, bar)
+		}
+		series.forming[data.Symbol] = openBar(data, start, timeframe)
+	}
+}
+
+func (b *timeframeBars) advance(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for timeframe, series := range b.series {
+		for symbol, bar := range series.forming {
+			if !now.Before(bar.Timestamp.Add(timeframe)) {
+				series.close(symbol, bar)
+				delete(series.forming, symbol)
+			}
+		}
+	}
+}
+
+func (s *barSeries) close(symbol string, bar *models.MarketData) {
+	buffer, exists := s.closed[symbol]
+	if !exists {
+		buffer = newRingBuffer(s.capacity)
+		s.closed[symbol] = buffer
+	}
+	buffer.push(bar)
+}
+
+func (b *timeframeBars) snapshot() map[string]map[time.Duration][]*models.MarketData {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bars := make(map[string]map[time.Duration][]*models.MarketData)
+	for timeframe, series := range b.series {
+		for symbol, buffer := range series.closed {
+			if bars[symbol] == nil {
+				bars[symbol] = make(map[time.Duration][]*models.MarketData, len(b.series))
+			}
+			bars[symbol][timeframe] = buffer.last(0)
+		}
+	}
+	return bars
+}
+
+func (b *timeframeBars) split(symbol string, ratio decimal.Decimal) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, series := range b.series {
+		if bar, exists := series.forming[symbol]; exists {
+			series.forming[symbol] = bar.SplitAdjusted(ratio)
+		}
+		buffer, exists := series.closed[symbol]
+		if !exists {
+			continue
+		}
+		for i := 0; i < buffer.size; i++ {
+			index := (buffer.start + i) % len(buffer.data)
+			buffer.data[index] = buffer.data[index].SplitAdjusted(ratio)
+		}
+	}
+}
+
+func ohlc(data *models.MarketData) (decimal.Decimal, decimal.Decimal, decimal.Decimal, decimal.Decimal) {
+	if data.Kind != models.MarketDataKindBar {
+		return data.Price, data.Price, data.Price, data.Price
+	}
+	closePrice := indicators.Close(data)
+	open := data.Open
+	if !open.IsPositive() {
+		open = closePrice
+	}
+	return open, indicators.High(data), indicators.Low(data), closePrice
+}
+
+func openBar(data *models.MarketData, start time.Time, timeframe time.Duration) *models.MarketData {
+	open, high, low, closePrice := ohlc(data)
+	return &models.MarketData{
+		Symbol:    data.Symbol,
+		Kind:      models.MarketDataKindBar,
+		Price:     closePrice,
+		Bid:       data.Bid,
+		Ask:       data.Ask,
+		BidSize:   data.BidSize,
+		AskSize:   data.AskSize,
+		Volume:    data.Volume,
+		High:      high,
+		Low:       low,
+		Open:      open,
+		Close:     closePrice,
+		Interval:  timeframe,
+		Timestamp: start,
+	}
+}
+
+func mergeBar(bar, data *models.MarketData) {
+	_, high, low, closePrice := ohlc(data)
+	bar.High = decimal.Max(bar.High, high)
+	bar.Low = decimal.Min(bar.Low, low)
+	bar.Close = closePrice
+	bar.Price = closePrice
+	bar.Bid, bar.Ask = data.Bid, data.Ask
+	bar.BidSize, bar.AskSize = data.BidSize, data.AskSize
+	bar.Volume += data.Volume
+}
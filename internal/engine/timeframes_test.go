@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var barStart = time.Date(2024, 3, 4, 14, 30, 0, 0, time.UTC)
+
+func tickAt(offset time.Duration, price float64) *models.MarketData {
+	return &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(price), Volume: 10, Timestamp: barStart.Add(offset)}
+}
+
+func assertBar(t *testing.T, bar *models.MarketData, start time.Time, open, high, low, closePrice float64) {
+	t.Helper()
+	assert.Equal(t, start, bar.Timestamp)
+	assert.Equal(t, []float64{open, high, low, closePrice},
+		[]float64{bar.Open.InexactFloat64(), bar.High.InexactFloat64(), bar.Low.InexactFloat64(), bar.Close.InexactFloat64()})
+}
+
+func TestTradingEngine_SetTimeframes_BuildsAlignedBars(t *testing.T) {
+	engine := createTestEngine()
+	require.NoError(t, engine.SetTimeframes([]time.Duration{5 * time.Minute, 10 * time.Second, time.Minute}))
+	assert.Equal(t, []time.Duration{10 * time.Second, time.Minute, 5 * time.Minute}, engine.Timeframes())
+
+	for i := 0; i < 30; i++ {
+		offset := time.Duration(i) * 10 * time.Second
+		price := 100.0 + float64(i%6)
+		if i == 13 {
+			price = 90.0
+		}
+		engine.UpdateMarketData("AAPL", tickAt(offset, price))
+		engine.Advance(context.Background(), barStart.Add(offset))
+	}
+
+	assert.Len(t, engine.GetBars("AAPL", 10*time.Second, 0), 29)
+	assert.Len(t, engine.GetBars("AAPL", time.Minute, 0), 4)
+	assert.Empty(t, engine.GetBars("AAPL", 5*time.Minute, 0))
+
+	engine.Advance(context.Background(), barStart.Add(5*time.Minute))
+
+	minutes := engine.GetBars("AAPL", time.Minute, 0)
+	require.Len(t, minutes, 5)
+	assertBar(t, minutes[0], barStart, 100, 105, 100, 105)
+	assertBar(t, minutes[2], barStart.Add(2*time.Minute), 100, 105, 90, 105)
+	assert.EqualValues(t, 60, minutes[0].Volume)
+	assert.Equal(t, time.Minute, minutes[0].Interval)
+
+	fiveMinutes := engine.GetBars("AAPL", 5*time.Minute, 0)
+	require.Len(t, fiveMinutes, 1)
+	assertBar(t, fiveMinutes[0], barStart, 100, 105, 90, 105)
+	assert.EqualValues(t, 300, fiveMinutes[0].Volume)
+
+	last := engine.GetBars("AAPL", 10*time.Second, 2)
+	require.Len(t, last, 2)
+	assertBar(t, last[1], barStart.Add(290*time.Second), 105, 105, 105, 105)
+
+	snapshot := &models.MarketSnapshot{Bars: engine.bars.snapshot()}
+	assertSameJSON(t, minutes[3:], snapshot.GetBars("AAPL", time.Minute, 2))
+	assert.Empty(t, snapshot.GetBars("MSFT", time.Minute, 2))
+}
+
+func TestTimeframeBars_Add_BoundsMemoryAndIgnoresLateTicks(t *testing.T) {
+	bars := newTimeframeBars()
+	bars.ensure(time.Minute, 3)
+
+	for i := 0; i < 10; i++ {
+		bars.add(tickAt(time.Duration(i)*time.Minute, 100.0+float64(i)))
+	}
+	bars.add(tickAt(2*time.Minute, 50.0))
+
+	closed := bars.snapshot()["AAPL"][time.Minute]
+	require.Len(t, closed, 3)
+	for i, bar := range closed {
+		assertBar(t, bar, barStart.Add(time.Duration(6+i)*time.Minute), 106+float64(i), 106+float64(i), 106+float64(i), 106+float64(i))
+	}
+
+	bars.ensure(time.Minute, 5)
+	bars.add(tickAt(10*time.Minute, 110.0))
+	assert.Len(t, bars.snapshot()["AAPL"][time.Minute], 4)
+}
+
+func TestTradingEngine_SetTimeframes_RejectsMisalignedTimeframes(t *testing.T) {
+	engine := createTestEngine()
+
+	for _, timeframes := range [][]time.Duration{
+		{time.Minute, 90 * time.Second},
+		{0, time.Minute},
+		{-time.Second},
+	} {
+		err := engine.SetTimeframes(timeframes)
+		assert.ErrorIs(t, err, ErrInvalidTimeframes, timeframes)
+	}
+	assert.Empty(t, engine.Timeframes())
+}
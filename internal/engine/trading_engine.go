@@ -3,72 +3,389 @@ package engine
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/calendar"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/events"
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/metrics"
 	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
 	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/1cbyc/trade-algo-go/internal/stress"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
 type TradingEngine struct {
-	portfolio  *models.Portfolio
-	strategies map[string]strategies.Strategy
-	marketData map[string]*models.MarketData
-	orderQueue chan *models.Order
-	tradeQueue chan *models.Trade
-	logger     *zap.Logger
-	mu         sync.RWMutex
-	running    bool
-	stopChan   chan struct{}
-}
-
-func NewTradingEngine(initialCash decimal.Decimal, logger *zap.Logger) *TradingEngine {
-	return &TradingEngine{
+	portfolio       *models.Portfolio
+	strategies      map[string]strategies.Strategy
+	allocations     map[string]*allocation
+	drawdowns       map[string]*strategyDrawdown
+	attribution     map[string]*strategyLedger
+	health          map[string]*strategyHealth
+	marketData      map[string]*models.MarketData
+	history         *marketHistory
+	bars            *timeframeBars
+	currency        *currencyBook
+	equityCurve     equitySeries
+	benchmarkCurve  equitySeries
+	events          *events.Bus
+	metrics         *metrics.Metrics
+	journal         *tradeJournal
+	archive         *tradeJournal
+	historyLimit    int
+	openOrders      map[string]*models.Order
+	book            *restingBook
+	slicedOrders    map[string]*slicedOrder
+	slicing         SlicingConfig
+	pendingFills    []*pendingFill
+	reservations    map[string]reservation
+	hooks           map[string]*hookQueue
+	latencyModel    execution.LatencyModel
+	dailyOrders     map[string]*dailyOrderCount
+	books           dailyBooks
+	statementSink   StatementSink
+	orderQueue      chan *models.Order
+	tradeQueue      chan *models.Trade
+	queues          QueueConfig
+	orderWatch      *queueWatch
+	tradeWatch      *queueWatch
+	slippageModel   execution.SlippageModel
+	commissionModel execution.CommissionModel
+	impactModel     execution.ImpactModel
+	costBasis       models.CostBasisMethod
+	symbols         *symbols.Registry
+	roundToGrid     bool
+	varModel        risk.VaRModel
+	benchmark       string
+	betaLookback    int
+	calendar        *calendar.Calendar
+	dayCutoff       *dayCutoff
+	maxDrawdown     decimal.Decimal
+	drawdownHalted  bool
+	breaker         *circuitBreaker
+	stalePrices     StalePriceConfig
+	exposure        *models.ExposureLimits
+	alerting        *alerting
+	auditLog        AuditSink
+	snapshotPath    string
+	snapshotEvery   time.Duration
+	stressScenarios []stress.Scenario
+	intervals       Intervals
+	ticks           *tickQueue
+	clock           engineClock
+	orderSequence   uint64
+	tradeSequence   uint64
+	wallClock       clock.Clock
+	logger          *zap.Logger
+	mu              sync.RWMutex
+	running         bool
+	runCtx          context.Context
+	tickDriven      bool
+	stopChan        chan struct{}
+	workers         *sync.WaitGroup
+}
+
+type commissionAware interface {
+	SetCommissionModel(model execution.CommissionModel)
+}
+
+type historyRequirer interface {
+	RequiredHistory() int
+}
+
+type timeframeRequirer interface {
+	RequiredBars() map[time.Duration]int
+}
+
+type historyAware interface {
+	SetMarketHistory(history strategies.MarketHistory)
+}
+
+type orderBookAware interface {
+	SetOrderBook(book strategies.OrderBook)
+}
+
+type benchmarkAware interface {
+	SetBenchmark(symbol string, lookback int)
+}
+
+type symbolAware interface {
+	SetSymbols(registry *symbols.Registry)
+}
+
+type multiSignal interface {
+	ExecuteAll(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) ([]*models.AlgorithmResult, error)
+}
+
+type tickAware interface {
+	ExecuteOnTick(ctx context.Context, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error)
+}
+
+type newsAware interface {
+	OnNews(event models.NewsEvent)
+}
+
+func NewTradingEngine(initialCash decimal.Decimal, logger *zap.Logger, opts ...Option) *TradingEngine {
+	e := &TradingEngine{
 		portfolio: &models.Portfolio{
-			ID:             generatePortfolioID(),
-			Cash:           initialCash,
-			Positions:      make(map[string]*models.Position),
-			TotalValue:     initialCash,
-			UnrealizedPnL:  decimal.Zero,
-			RealizedPnL:    decimal.Zero,
-			TotalRisk:      decimal.Zero,
-			RiskMetrics:    models.PortfolioRiskMetrics{},
-			TradeHistory:   []*models.Trade{},
-			OrderHistory:   []*models.Order{},
-			LastRebalanced: time.Now(),
-			CreatedAt:      time.Now(),
-			UpdatedAt:      time.Now(),
+			ID:            generatePortfolioID(),
+			Cash:          initialCash,
+			Positions:     make(map[string]*models.Position),
+			TotalValue:    initialCash,
+			UnrealizedPnL: decimal.Zero,
+			RealizedPnL:   decimal.Zero,
+			TotalRisk:     decimal.Zero,
+			RiskMetrics:   models.PortfolioRiskMetrics{},
+			TradeHistory:  []*models.Trade{},
+			OrderHistory:  []*models.Order{},
 		},
-		strategies: make(map[string]strategies.Strategy),
-		marketData: make(map[string]*models.MarketData),
-		orderQueue: make(chan *models.Order, 1000),
-		tradeQueue: make(chan *models.Trade, 1000),
-		logger:     logger,
-		stopChan:   make(chan struct{}),
+		strategies:    make(map[string]strategies.Strategy),
+		allocations:   make(map[string]*allocation),
+		drawdowns:     make(map[string]*strategyDrawdown),
+		attribution:   make(map[string]*strategyLedger),
+		health:        make(map[string]*strategyHealth),
+		marketData:    make(map[string]*models.MarketData),
+		history:       newMarketHistory(0),
+		bars:          newTimeframeBars(),
+		currency:      newCurrencyBook(),
+		openOrders:    make(map[string]*models.Order),
+		book:          newRestingBook(),
+		slicedOrders:  make(map[string]*slicedOrder),
+		reservations:  make(map[string]reservation),
+		hooks:         make(map[string]*hookQueue),
+		dailyOrders:   make(map[string]*dailyOrderCount),
+		orderQueue:    make(chan *models.Order, defaultQueueSize),
+		tradeQueue:    make(chan *models.Trade, defaultQueueSize),
+		queues:        DefaultQueueConfig(),
+		orderWatch:    &queueWatch{name: orderQueueName},
+		tradeWatch:    &queueWatch{name: tradeQueueName},
+		slippageModel: execution.UniformSlippage{},
+		costBasis:     models.CostBasisAverage,
+		varModel:      risk.ParametricModel{},
+		intervals:     DefaultIntervals(),
+		historyLimit:  defaultHistoryLimit,
+		ticks:         newTickQueue(),
+		events:        events.NewBus(),
+		wallClock:     clock.New(),
+		logger:        logger,
+	}
+	for _, opt := range opts {
+		opt(e)
 	}
+
+	now := e.wallClock.Now()
+	e.portfolio.LastRebalanced = now
+	e.portfolio.CreatedAt = now
+	e.portfolio.UpdatedAt = now
+	return e
+}
+
+func (e *TradingEngine) SetSlippageModel(model execution.SlippageModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.slippageModel = model
+}
+
+func (e *TradingEngine) SetImpactModel(model execution.ImpactModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.impactModel = model
+}
+
+func (e *TradingEngine) Symbols() *symbols.Registry {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.symbols
+}
+
+func (e *TradingEngine) SetSymbols(registry *symbols.Registry) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.symbols = registry
+	for _, strategy := range e.strategies {
+		if aware, ok := strategy.(symbolAware); ok {
+			aware.SetSymbols(registry)
+		}
+	}
+}
+
+func (e *TradingEngine) SetCommissionModel(model execution.CommissionModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.commissionModel = model
+	for _, strategy := range e.strategies {
+		if aware, ok := strategy.(commissionAware); ok {
+			aware.SetCommissionModel(model)
+		}
+	}
+}
+
+func (e *TradingEngine) SetVaRModel(model risk.VaRModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.varModel = model
+}
+
+func (e *TradingEngine) SetMetrics(m *metrics.Metrics) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.metrics = m
+}
+
+func (e *TradingEngine) SetCalendar(cal *calendar.Calendar) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calendar = cal
+}
+
+func (e *TradingEngine) commissionFor(order *models.Order, config *models.StrategyConfig) decimal.Decimal {
+	if e.commissionModel != nil {
+		return e.commissionModel.Calculate(order)
+	}
+	return execution.NewPercentageCommission(config.CommissionRate).Calculate(order)
 }
 
 func (e *TradingEngine) AddStrategy(strategy strategies.Strategy) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	e.addStrategy(strategy)
+}
+
+func (e *TradingEngine) addStrategy(strategy strategies.Strategy) {
 	e.strategies[strategy.ID()] = strategy
+	e.health[strategy.ID()] = &strategyHealth{}
+	if aware, ok := strategy.(commissionAware); ok && e.commissionModel != nil {
+		aware.SetCommissionModel(e.commissionModel)
+	}
+	if aware, ok := strategy.(historyAware); ok {
+		aware.SetMarketHistory(e)
+	}
+	if aware, ok := strategy.(benchmarkAware); ok && e.benchmark != "" {
+		aware.SetBenchmark(e.benchmark, e.betaLookback)
+	}
+	if aware, ok := strategy.(orderBookAware); ok {
+		aware.SetOrderBook(e)
+	}
+	if aware, ok := strategy.(symbolAware); ok && e.symbols != nil {
+		aware.SetSymbols(e.symbols)
+	}
+
+	if required := requiredHistory(strategy); required > e.history.size() {
+		e.history.resize(required)
+	}
+	if requirer, ok := strategy.(timeframeRequirer); ok {
+		for timeframe, bars := range requirer.RequiredBars() {
+			e.bars.ensure(timeframe, max(bars, defaultTimeframeBars))
+		}
+	}
+
 	e.logger.Info("Strategy added", zap.String("strategy_id", strategy.ID()), zap.String("name", strategy.Name()))
 }
 
+func requiredHistory(strategy strategies.Strategy) int {
+	if requirer, ok := strategy.(historyRequirer); ok {
+		return requirer.RequiredHistory()
+	}
+	return strategy.GetConfig().MarketDataWindow
+}
+
 func (e *TradingEngine) RemoveStrategy(strategyID string) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	delete(e.strategies, strategyID)
+	delete(e.allocations, strategyID)
+	delete(e.health, strategyID)
 	e.logger.Info("Strategy removed", zap.String("strategy_id", strategyID))
 }
 
-func (e *TradingEngine) UpdateMarketData(symbol string, data *models.MarketData) {
+func (e *TradingEngine) SetBenchmark(symbol string, lookback int) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	e.benchmark = symbol
+	e.betaLookback = lookback
+	if lookback+1 > e.history.size() {
+		e.history.resize(lookback + 1)
+	}
+	for _, strategy := range e.strategies {
+		if aware, ok := strategy.(benchmarkAware); ok {
+			aware.SetBenchmark(symbol, lookback)
+		}
+	}
+}
+
+func (e *TradingEngine) SetMarketHistorySize(size int) {
+	e.history.resize(size)
+}
+
+func (e *TradingEngine) RequiredHistory() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	required := 0
+	for _, strategy := range e.strategies {
+		required = max(required, requiredHistory(strategy))
+	}
+	return required
+}
+
+func (e *TradingEngine) SeedMarketHistory(symbol string, history []*models.MarketData) error {
+	for i, data := range history {
+		if data == nil || data.Symbol != symbol {
+			return fmt.Errorf("%w: history entry %d is not for %s", ErrInvalidMarketData, i, symbol)
+		}
+		if i > 0 && data.Timestamp.Before(history[i-1].Timestamp) {
+			return fmt.Errorf("%w: history entry %d is out of order", ErrInvalidMarketData, i)
+		}
+	}
+
+	for _, data := range history {
+		e.history.add(symbol, data)
+		e.bars.add(data)
+	}
+	e.logger.Info("Market history seeded", zap.String("symbol", symbol), zap.Int("bars", len(history)))
+	return nil
+}
+
+func (e *TradingEngine) GetMarketDataHistory(symbol string, n int) []*models.MarketData {
+	return e.history.get(symbol, n)
+}
+
+func (e *TradingEngine) UpdateMarketData(symbol string, data *models.MarketData) {
+	e.mu.Lock()
 	e.marketData[symbol] = data
+	e.history.add(symbol, data)
+	e.bars.add(data)
+	e.publishMarketData(data)
+	e.executeDueFills()
+	exit := e.checkExit(symbol, data.Price)
+	if exit != nil {
+		e.openOrders[exit.ID] = exit
+		e.publishOrder(exit)
+		e.auditOrder(audit.EventOrderCreated, exit, nil)
+	}
+	e.expireDayOrders()
+	triggered := append(e.triggerRestingOrders(symbol, data), e.releaseSlices()...)
+	ctx, tickDriven, ticks := e.runCtx, e.running && e.tickDriven, e.ticks
+	e.mu.Unlock()
+
 	e.logger.Debug("Market data updated", zap.String("symbol", symbol), zap.String("price", data.Price.String()))
+	if exit != nil {
+		e.enqueueOrder(exit)
+	}
+	for _, order := range triggered {
+		e.enqueueOrder(order)
+	}
+	if tickDriven && ctx.Err() == nil {
+		ticks.enqueue(symbol)
+	}
 }
 
 func (e *TradingEngine) Start(ctx context.Context) error {
@@ -78,274 +395,736 @@ func (e *TradingEngine) Start(ctx context.Context) error {
 		return fmt.Errorf("trading engine already running")
 	}
 	e.running = true
+	e.runCtx = ctx
+	intervals := e.intervals
+	snapshotPath, snapshotEvery := e.snapshotPath, e.snapshotEvery
+	tickDriven := intervals.eventDriven()
+	e.tickDriven = tickDriven
+	stop, workers, ticks := make(chan struct{}), &sync.WaitGroup{}, newTickQueue()
+	e.stopChan, e.workers, e.ticks = stop, workers, ticks
+	e.journal = e.journal.reopen()
+	e.archive = e.archive.reopen()
 	e.mu.Unlock()
 
-	e.logger.Info("Starting trading engine")
+	e.logger.Info("Starting trading engine",
+		zap.Duration("strategy_interval", intervals.Strategy),
+		zap.Duration("risk_interval", intervals.Risk),
+		zap.Duration("portfolio_interval", intervals.Portfolio),
+		zap.Duration("debounce", intervals.Debounce),
+	)
 
-	go e.orderProcessor(ctx)
-	go e.tradeProcessor(ctx)
-	go e.strategyExecutor(ctx)
-	go e.riskManager(ctx)
-	go e.portfolioUpdater(ctx)
+	spawn(workers, func() { e.orderProcessor(ctx, stop) })
+	spawn(workers, func() { e.tradeProcessor(ctx, stop) })
+	if intervals.Strategy > 0 {
+		spawn(workers, func() { e.strategyExecutor(ctx, stop, intervals.Strategy) })
+	}
+	if intervals.Risk > 0 {
+		spawn(workers, func() { e.riskManager(ctx, stop, intervals.Risk) })
+	}
+	if intervals.Portfolio > 0 {
+		spawn(workers, func() { e.portfolioUpdater(ctx, stop, intervals.Portfolio) })
+	}
+	if tickDriven {
+		spawn(workers, func() { e.tickExecutor(ctx, stop, ticks, intervals) })
+	}
+	if snapshotEvery > 0 {
+		spawn(workers, func() { e.snapshotWriter(ctx, stop, snapshotPath, snapshotEvery) })
+	}
 
 	return nil
 }
 
+func spawn(workers *sync.WaitGroup, worker func()) {
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		worker()
+	}()
+}
+
 func (e *TradingEngine) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := e.StopAndDrain(ctx); err != nil {
+		e.logger.Warn("Trading engine stopped before queues drained", zap.Error(err))
+	}
+}
+
+func (e *TradingEngine) StopAndDrain(ctx context.Context) error {
 	e.mu.Lock()
-	defer e.mu.Unlock()
-	if !e.running {
-		return
+	if e.running {
+		e.running = false
+		close(e.stopChan)
 	}
+	workers := e.workers
+	e.mu.Unlock()
+
+	defer func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.journal.close()
+		e.archive.close()
+		e.flushAuditLog()
+	}()
 
-	e.running = false
-	close(e.stopChan)
-	e.logger.Info("Trading engine stopped")
+	if workers != nil {
+		workersDone := make(chan struct{})
+		go func() {
+			workers.Wait()
+			close(workersDone)
+		}()
+		select {
+		case <-workersDone:
+		case <-ctx.Done():
+			return fmt.Errorf("%w: waiting for workers: %v", ErrDrainTimeout, ctx.Err())
+		}
+	}
+
+	pendingOrders, pendingTrades := len(e.orderQueue), len(e.tradeQueue)
+	if err := e.drain(ctx); err != nil {
+		return fmt.Errorf("%w: %d orders and %d trades left: %v", ErrDrainTimeout, len(e.orderQueue), len(e.tradeQueue), err)
+	}
+	if err := e.waitForHooks(ctx); err != nil {
+		return fmt.Errorf("%w: waiting for strategy hooks: %v", ErrDrainTimeout, err)
+	}
+	e.updatePortfolio()
+	e.CloseBooks()
+
+	e.logger.Info("Trading engine stopped", zap.Int("drained_orders", pendingOrders), zap.Int("drained_trades", pendingTrades))
+	return nil
 }
 
-func (e *TradingEngine) orderProcessor(ctx context.Context) {
+func (e *TradingEngine) orderProcessor(ctx context.Context, stop <-chan struct{}) {
 	for {
 		select {
 		case order := <-e.orderQueue:
 			e.processOrder(order)
 		case <-ctx.Done():
 			return
-		case <-e.stopChan:
+		case <-stop:
 			return
 		}
 	}
 }
 
-func (e *TradingEngine) tradeProcessor(ctx context.Context) {
+func (e *TradingEngine) tradeProcessor(ctx context.Context, stop <-chan struct{}) {
 	for {
 		select {
 		case trade := <-e.tradeQueue:
 			e.processTrade(trade)
 		case <-ctx.Done():
 			return
-		case <-e.stopChan:
+		case <-stop:
 			return
 		}
 	}
 }
 
-func (e *TradingEngine) strategyExecutor(ctx context.Context) {
-	ticker := time.NewTicker(5 * time.Second)
+func (e *TradingEngine) strategyExecutor(ctx context.Context, stop <-chan struct{}, interval time.Duration) {
+	ticker := e.wallClock.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
 			e.executeStrategies(ctx)
 		case <-ctx.Done():
 			return
-		case <-e.stopChan:
+		case <-stop:
 			return
 		}
 	}
 }
 
-func (e *TradingEngine) riskManager(ctx context.Context) {
-	ticker := time.NewTicker(10 * time.Second)
+func (e *TradingEngine) riskManager(ctx context.Context, stop <-chan struct{}, interval time.Duration) {
+	ticker := e.wallClock.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
+			e.sweepExpiredOrders()
 			e.manageRisk()
+			e.updateRiskMetrics()
 		case <-ctx.Done():
 			return
-		case <-e.stopChan:
+		case <-stop:
 			return
 		}
 	}
 }
 
-func (e *TradingEngine) portfolioUpdater(ctx context.Context) {
-	ticker := time.NewTicker(1 * time.Second)
+func (e *TradingEngine) portfolioUpdater(ctx context.Context, stop <-chan struct{}, interval time.Duration) {
+	ticker := e.wallClock.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
 			e.updatePortfolio()
 		case <-ctx.Done():
 			return
-		case <-e.stopChan:
+		case <-stop:
 			return
 		}
 	}
 }
 
 func (e *TradingEngine) executeStrategies(ctx context.Context) {
+	e.runStrategies(ctx, "")
+}
+
+func (e *TradingEngine) executeStrategiesOnTick(ctx context.Context, symbol string) {
+	e.runStrategies(ctx, symbol)
+}
+
+func (e *TradingEngine) runStrategies(ctx context.Context, symbol string) {
 	e.mu.RLock()
 	strategies := make([]strategies.Strategy, 0, len(e.strategies))
+	health := make(map[string]*strategyHealth, len(e.strategies))
 	for _, strategy := range e.strategies {
-		strategies = append(strategies, strategy)
+		if !e.tradingHalted(strategy.ID()) {
+			strategies = append(strategies, strategy)
+			health[strategy.ID()] = e.health[strategy.ID()]
+		}
+	}
+	sort.Slice(strategies, func(i, j int) bool { return strategies[i].ID() < strategies[j].ID() })
+	portfolio := strategyPortfolio(e.portfolio, len(e.strategies))
+	views := make(map[string]*models.Portfolio, len(e.allocations))
+	for strategyID := range e.allocations {
+		view := e.portfolioFor(strategyID, portfolio)
+		view.Positions = copyPositions(view.Positions)
+		views[strategyID] = view
+	}
+	observer := e.metrics
+	tradingCalendar := e.calendar
+	now := e.now()
+	market := &models.MarketSnapshot{
+		Latest:  copyMarketData(e.marketData),
+		History: e.history.snapshot(),
+		Bars:    e.bars.snapshot(),
 	}
-	portfolio := e.portfolio
-	marketData := e.marketData
 	e.mu.RUnlock()
 
 	for _, strategy := range strategies {
 		if !strategy.IsEnabled() {
 			continue
 		}
+		if health[strategy.ID()].running.Load() {
+			e.logger.Warn("Strategy still running past its timeout, skipping", zap.String("strategy_id", strategy.ID()))
+			continue
+		}
 
-		result, err := strategy.Execute(ctx, portfolio, marketData)
+		start := time.Now()
+		view, allocated := views[strategy.ID()]
+		if !allocated {
+			view = portfolio
+		}
+		results, err := e.executeGuarded(ctx, strategy, symbol, view, market, health[strategy.ID()])
+		if ctx.Err() != nil {
+			return
+		}
+		observer.ObserveStrategy(strategy.ID(), time.Since(start), err)
 		if err != nil {
 			e.logger.Error("Strategy execution failed", zap.String("strategy_id", strategy.ID()), zap.Error(err))
+			e.strategyFailed(strategy, err)
 			continue
 		}
+		e.strategySucceeded(strategy.ID())
+
+		e.createOrdersFromResults(results, strategy, market, tradingCalendar, now)
+	}
+}
+
+func executeStrategy(ctx context.Context, strategy strategies.Strategy, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot) ([]*models.AlgorithmResult, error) {
+	if aware, ok := strategy.(tickAware); ok && symbol != "" {
+		result, err := aware.ExecuteOnTick(ctx, symbol, portfolio, market)
+		return singleResult(result), err
+	}
+	if multi, ok := strategy.(multiSignal); ok {
+		return multi.ExecuteAll(ctx, portfolio, market)
+	}
+	result, err := strategy.Execute(ctx, portfolio, market)
+	return singleResult(result), err
+}
 
-		if result != nil {
-			e.createOrderFromResult(result, strategy)
+func singleResult(result *models.AlgorithmResult) []*models.AlgorithmResult {
+	if result == nil {
+		return nil
+	}
+	return []*models.AlgorithmResult{result}
+}
+
+func (e *TradingEngine) createOrdersFromResults(results []*models.AlgorithmResult, strategy strategies.Strategy, market *models.MarketSnapshot, tradingCalendar *calendar.Calendar, now time.Time) {
+	if limit := strategy.GetConfig().MaxSignalsPerRun; limit > 0 && len(results) > limit {
+		e.logger.Warn("Signal cap reached, dropping lower-ranked signals",
+			zap.String("strategy_id", strategy.ID()),
+			zap.Int("signals", len(results)),
+			zap.Int("max_signals_per_run", limit))
+		results = results[:limit]
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if !tradingCalendar.IsOpen(result.Symbol, now) {
+			e.logger.Debug("Market closed, skipping signal", zap.String("strategy_id", strategy.ID()), zap.String("symbol", result.Symbol))
+			continue
 		}
+		e.createOrderFromResult(result, strategy, market)
 	}
 }
 
-func (e *TradingEngine) createOrderFromResult(result *models.AlgorithmResult, strategy strategies.Strategy) {
+func (e *TradingEngine) createOrderFromResult(result *models.AlgorithmResult, strategy strategies.Strategy, market *models.MarketSnapshot) {
 	var side models.OrderSide
-	if result.Action == "buy" {
+	switch result.Action {
+	case models.ActionBuy:
 		side = models.OrderSideBuy
-	} else {
+	case models.ActionSell:
 		side = models.OrderSideSell
+	case models.ActionHold:
+		return
+	case models.ActionClose:
+		e.createCloseOrder(result, market)
+		return
+	default:
+		e.logger.Error("Rejecting signal with unknown action",
+			zap.String("strategy_id", strategy.ID()),
+			zap.String("symbol", result.Symbol),
+			zap.Error(fmt.Errorf("%w: %q", ErrUnknownAction, result.Action)))
+		return
+	}
+
+	quantity, ok := sizeByConfidence(result, strategy.GetConfig(), e.symbols)
+	if !ok {
+		e.logger.Debug("Signal below sizing threshold, skipping",
+			zap.String("strategy_id", strategy.ID()),
+			zap.String("symbol", result.Symbol),
+			zap.String("confidence", result.Confidence.String()))
+		return
+	}
+
+	orderType, timeInForce := models.OrderTypeMarket, models.TimeInForceDay
+	if result.OrderType == models.OrderTypeLimit || result.OrderType == models.OrderTypeStop {
+		orderType, timeInForce = result.OrderType, models.TimeInForceGTC
+	}
+	if result.TimeInForce != "" {
+		timeInForce = result.TimeInForce
 	}
 
 	order := &models.Order{
-		ID:          generateOrderID(),
-		Symbol:      result.Symbol,
-		Side:        side,
-		Type:        models.OrderTypeMarket,
-		Quantity:    result.Quantity,
-		Price:       result.Price,
-		Status:      models.OrderStatusPending,
-		Timestamp:   time.Now(),
-		StrategyID:  result.StrategyID,
+		ID:            e.newOrderID(),
+		Symbol:        result.Symbol,
+		Side:          side,
+		Type:          orderType,
+		Quantity:      quantity,
+		Price:         result.Price,
+		StopPrice:     result.StopPrice,
+		DecisionPrice: decisionPrice(result, market),
+		TimeInForce:   timeInForce,
+		Status:        models.OrderStatusPending,
+		Timestamp:     e.now(),
+		StrategyID:    result.StrategyID,
+		Reason:        result.Reason,
 	}
 
-	e.orderQueue <- order
+	e.submitSignalOrder(order, result)
+}
+
+func (e *TradingEngine) createCloseOrder(result *models.AlgorithmResult, market *models.MarketSnapshot) {
+	e.mu.RLock()
+	quantity := decimal.Zero
+	if position, exists := e.portfolio.Positions[result.Symbol]; exists {
+		quantity = position.Quantity
+	}
+	e.mu.RUnlock()
+
+	side := models.OrderSideSell
+	if quantity.IsNegative() {
+		side = models.OrderSideBuy
+	}
+	order := &models.Order{
+		ID:            e.newOrderID(),
+		Symbol:        result.Symbol,
+		Side:          side,
+		Type:          models.OrderTypeMarket,
+		Quantity:      quantity.Abs(),
+		Price:         result.Price,
+		DecisionPrice: decisionPrice(result, market),
+		TimeInForce:   models.TimeInForceDay,
+		Status:        models.OrderStatusPending,
+		Timestamp:     e.now(),
+		StrategyID:    result.StrategyID,
+		ExitReason:    models.ExitReasonClose,
+		Reason:        result.Reason,
+	}
+
+	e.submitSignalOrder(order, result)
+}
+
+func (e *TradingEngine) submitOrder(order *models.Order) {
+	e.submitSignalOrder(order, nil)
+}
+
+func (e *TradingEngine) submitSignalOrder(order *models.Order, result *models.AlgorithmResult) {
+	e.mu.Lock()
+	e.openOrders[order.ID] = order
+	e.publishOrder(order)
+	record := audit.Record{Type: audit.EventOrderCreated, Order: order}
+	if result != nil {
+		signal := *result
+		record.Signal = &signal
+	}
+	e.audit(record)
+	e.mu.Unlock()
+
+	e.enqueueOrder(order)
+}
+
+func (e *TradingEngine) CancelOrder(orderID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	order, exists := e.openOrders[orderID]
+	if !exists {
+		return e.cancelError(orderID)
+	}
+
+	e.cancelSlices(orderID)
+	delete(e.openOrders, orderID)
+	e.book.remove(orderID)
+	e.cancelOrder(order, models.CancelReasonRequested)
+
+	return nil
+}
+
+func (e *TradingEngine) cancelError(orderID string) error {
+	for i := len(e.portfolio.OrderHistory) - 1; i >= 0; i-- {
+		order := e.portfolio.OrderHistory[i]
+		if order.ID != orderID {
+			continue
+		}
+		if order.Status == models.OrderStatusFilled {
+			return ErrOrderAlreadyFilled
+		}
+		return ErrOrderNotCancellable
+	}
+	return ErrOrderNotFound
+}
+
+func (e *TradingEngine) GetOpenOrders() []*models.Order {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	orders := make([]*models.Order, 0, len(e.openOrders))
+	for _, order := range e.openOrders {
+		orderCopy := *order
+		orders = append(orders, &orderCopy)
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Timestamp.Before(orders[j].Timestamp)
+	})
+
+	return orders
 }
 
 func (e *TradingEngine) processOrder(order *models.Order) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	if _, open := e.openOrders[order.ID]; !open {
+		return
+	}
+	e.stampSubmission(order)
+	if e.tradingHalted(order.StrategyID) && !e.protectiveOrder(order) {
+		delete(e.openOrders, order.ID)
+		e.rejectOrder(order, fmt.Errorf("%w: %s", ErrTradingHalted, order.StrategyID))
+		e.logger.Warn("Trading halted, rejecting order", zap.String("order_id", order.ID), zap.String("strategy_id", order.StrategyID))
+		return
+	}
+	if err := e.conformToGrid(order); err != nil {
+		delete(e.openOrders, order.ID)
+		e.rejectOrder(order, err)
+		e.logger.Warn("Order price is off the tick grid", zap.String("order_id", order.ID), zap.String("symbol", order.Symbol), zap.Error(err))
+		return
+	}
+	if !e.dayOrderExpired(order) && e.restOrder(order) {
+		return
+	}
+	delete(e.openOrders, order.ID)
+
+	start := time.Now()
+	defer func() { e.metrics.ObserveOrder(order, time.Since(start)) }()
+
+	if e.dayOrderExpired(order) {
+		e.cancelOrder(order, models.CancelReasonDayExpired)
+		return
+	}
+
 	strategy, exists := e.strategies[order.StrategyID]
 	if !exists {
-		order.Status = models.OrderStatusRejected
+		e.rejectOrder(order, fmt.Errorf("%w: %s", ErrStrategyNotFound, order.StrategyID))
 		e.logger.Error("Strategy not found", zap.String("strategy_id", order.StrategyID))
 		return
 	}
 
-	if err := strategy.ValidateOrder(order, e.portfolio); err != nil {
-		order.Status = models.OrderStatusRejected
+	if order.ExitReason != "" {
+		if !e.prepareExit(order) {
+			e.rejectOrder(order, fmt.Errorf("%w: %s", ErrNoPositionToExit, order.Symbol))
+			e.logger.Warn("Exit order has no position to close", zap.String("order_id", order.ID), zap.String("symbol", order.Symbol))
+			return
+		}
+		e.notifyAccepted(order)
+		e.fillOrder(order, strategy.GetConfig())
+		return
+	}
+
+	if order.ParentID == "" {
+		if err := e.checkSignalPrice(order); err != nil {
+			e.rejectOrder(order, err)
+			e.logger.Warn("Stale order price", zap.String("order_id", order.ID), zap.String("symbol", order.Symbol), zap.Error(err))
+			return
+		}
+		if e.sliceable(order) {
+			if !e.reserveOrRejectDailyOrder(order, strategy.GetConfig()) {
+				return
+			}
+			if e.sliceOrder(order) {
+				return
+			}
+			e.releaseDailyOrder(order, strategy.GetConfig())
+		}
+	}
+
+	if err := e.checkFXRate(order); err != nil {
+		e.rejectOrder(order, err)
+		e.logger.Warn("No exchange rate for order currency", zap.String("order_id", order.ID), zap.String("symbol", order.Symbol), zap.Error(err))
+		return
+	}
+	portfolio := e.portfolioFor(order.StrategyID, e.portfolio)
+	if err := strategy.ValidateOrder(order, e.unreserved(order, portfolio)); err != nil {
+		e.rejectOrder(order, err)
 		e.logger.Error("Order validation failed", zap.String("order_id", order.ID), zap.Error(err))
 		return
 	}
+	if err := e.checkExposure(order, strategy.GetConfig()); err != nil {
+		e.rejectOrder(order, err)
+		e.logger.Warn("Order exceeds exposure limits", zap.String("order_id", order.ID), zap.String("symbol", order.Symbol), zap.Error(err))
+		return
+	}
+	e.auditOrder(audit.EventOrderValidated, order, nil)
 
-	riskMetrics, err := strategy.CalculateRisk(order, e.portfolio)
+	riskMetrics, err := strategy.CalculateRisk(order, portfolio)
 	if err != nil {
-		order.Status = models.OrderStatusRejected
+		e.rejectOrder(order, err)
 		e.logger.Error("Risk calculation failed", zap.String("order_id", order.ID), zap.Error(err))
 		return
 	}
+	if order.ParentID == "" && !e.reserveOrRejectDailyOrder(order, strategy.GetConfig()) {
+		return
+	}
 
 	order.RiskMetrics = *riskMetrics
-	order.Status = models.OrderStatusFilled
+	e.audit(audit.Record{Type: audit.EventRiskCalculated, OrderID: order.ID, RiskMetrics: riskMetrics})
+	e.notifyAccepted(order)
+	if order.TimeInForce == models.TimeInForceIOC && !e.fillImmediately(order) {
+		return
+	}
+	e.fillOrder(order, strategy.GetConfig())
+}
 
-	e.executeOrder(order)
-	e.portfolio.OrderHistory = append(e.portfolio.OrderHistory, order)
+func (e *TradingEngine) rejectOrder(order *models.Order, err error) {
+	e.release(order.ID)
+	order.Status = models.OrderStatusRejected
+	e.recordOrder(order)
+	e.auditOrder(audit.EventOrderRejected, order, err)
+	e.alertRejection(order)
+	e.notifyRejected(order, err)
 }
 
-func (e *TradingEngine) executeOrder(order *models.Order) {
-	orderValue := order.Price.Mul(decimal.NewFromInt(order.Quantity))
-	commission := orderValue.Mul(decimal.NewFromFloat(0.001))
+func (e *TradingEngine) executeOrder(order *models.Order, config *models.StrategyConfig) {
+	e.executeOrderAt(order, config, order.Price)
+}
+
+func (e *TradingEngine) executeOrderAt(order *models.Order, config *models.StrategyConfig, reference decimal.Decimal) {
+	basePrice := order.Price
+	if data, exists := e.marketData[order.Symbol]; exists && order.Type == models.OrderTypeStop {
+		reference = data.Price
+	}
+	if order.Type == models.OrderTypeMarket || order.Type == models.OrderTypeStop {
+		basePrice = execution.QuotePrice(order.Side, reference, e.marketData[order.Symbol])
+	}
+
+	filledOrder := *order
+	filledOrder.Quantity = fillQuantity(order)
+	slippage := e.slippageModel.Slippage(&filledOrder, config.SlippageTolerance)
+	impacted := false
+	if e.impactModel != nil {
+		if impact, ok := e.impactModel.Impact(&filledOrder, e.marketData[order.Symbol]); ok {
+			slippage, impacted = impact, true
+		}
+	}
+	fillPrice := e.aggressivePrice(order.Side, order.Symbol, execution.FillPrice(order.Side, basePrice, slippage))
+	filledOrder.Price = fillPrice
+	commission := e.commissionFor(&filledOrder, config)
 
 	trade := &models.Trade{
-		ID:          generateTradeID(),
-		OrderID:     order.ID,
-		Symbol:      order.Symbol,
-		Side:        order.Side,
-		Quantity:    order.Quantity,
-		Price:       order.Price,
-		Commission:  commission,
-		Timestamp:   time.Now(),
-		StrategyID:  order.StrategyID,
-		RiskMetrics: order.RiskMetrics,
-	}
-
-	if order.Side == models.OrderSideBuy {
-		e.portfolio.Cash = e.portfolio.Cash.Sub(orderValue).Sub(commission)
-		e.updatePosition(order.Symbol, order.Quantity, order.Price)
-	} else {
-		e.portfolio.Cash = e.portfolio.Cash.Add(orderValue).Sub(commission)
-		e.updatePosition(order.Symbol, -order.Quantity, order.Price)
+		ID:              e.newTradeID(),
+		OrderID:         order.ID,
+		Symbol:          order.Symbol,
+		Side:            order.Side,
+		Quantity:        filledOrder.Quantity,
+		Price:           fillPrice,
+		RequestedPrice:  order.Price,
+		DecisionPrice:   order.DecisionPrice,
+		SubmissionPrice: order.SubmissionPrice,
+		Commission:      commission,
+		Costs:           transactionCost(order.Side, order.DecisionPrice, e.marketPrice(order.Symbol), basePrice, fillPrice),
+		Timestamp:       e.now(),
+		StrategyID:      order.StrategyID,
+		ExitReason:      order.ExitReason,
+		Reason:          order.Reason,
+		RiskMetrics:     order.RiskMetrics,
+	}
+	if impacted {
+		trade.ImpactCost = fillPrice.Sub(basePrice).Abs().Mul(filledOrder.Quantity)
 	}
+	e.bookFill(trade)
 
-	e.tradeQueue <- trade
+	quantity := filledOrder.Quantity
+	if order.Side == models.OrderSideSell {
+		quantity = quantity.Neg()
+	}
+	previous := decimal.Zero
+	position, held := e.portfolio.Positions[order.Symbol]
+	if held {
+		previous = position.Quantity
+	}
+	trade.RealizedPnL, trade.ClosedLots = e.applyFill(e.portfolio, order.Symbol, order.StrategyID, quantity, fillPrice, commission)
+	e.attachStop(order, previous)
+	e.recordStrategyPnL(order.StrategyID, e.toBase(e.currencyOf(order.Symbol), trade.RealizedPnL))
+	e.allocateFill(order, quantity, fillPrice, commission)
+	updateMargin(e.portfolio)
+
+	priceSlippage := fillPrice.Sub(basePrice)
+	e.audit(audit.Record{Type: audit.EventOrderFilled, Order: order, Trade: trade, Slippage: &priceSlippage})
+	e.notifyFill(trade)
+	if _, open := e.portfolio.Positions[order.Symbol]; held && !open {
+		e.notifyPositionClosed(order.StrategyID, order.Symbol, position.RealizedPnL)
+	}
+	e.enqueueTrade(trade)
 }
 
 func (e *TradingEngine) processTrade(trade *models.Trade) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	e.recordExecutedTrade(trade)
+}
 
-	e.portfolio.TradeHistory = append(e.portfolio.TradeHistory, trade)
+func (e *TradingEngine) recordExecutedTrade(trade *models.Trade) {
+	e.recordTrade(trade)
+	if trade.Reason != fxConversionReason {
+		e.attributeTrade(trade)
+		e.alertLargeLoss(trade)
+	}
+	e.metrics.ObserveTrade()
 	e.logger.Info("Trade executed",
 		zap.String("trade_id", trade.ID),
 		zap.String("symbol", trade.Symbol),
 		zap.String("side", string(trade.Side)),
-		zap.Int64("quantity", trade.Quantity),
+		zap.String("quantity", trade.Quantity.String()),
 		zap.String("price", trade.Price.String()),
 	)
 }
 
-func (e *TradingEngine) updatePosition(symbol string, quantity int64, price decimal.Decimal) {
-	position, exists := e.portfolio.Positions[symbol]
+func (e *TradingEngine) applyFill(portfolio *models.Portfolio, symbol, strategyID string, quantity, price, commission decimal.Decimal) (decimal.Decimal, []models.ClosedLot) {
+	e.settleFill(portfolio, symbol, strategyID, price.Mul(quantity).Add(commission).Neg())
+	return e.updatePosition(portfolio, symbol, strategyID, quantity, price, commission)
+}
+
+func (e *TradingEngine) updatePosition(portfolio *models.Portfolio, symbol, strategyID string, quantity, price, commission decimal.Decimal) (decimal.Decimal, []models.ClosedLot) {
+	position, exists := portfolio.Positions[symbol]
 	if !exists {
 		position = &models.Position{
 			Symbol:        symbol,
-			Quantity:      0,
+			StrategyID:    strategyID,
+			Quantity:      decimal.Zero,
 			AveragePrice:  decimal.Zero,
 			CurrentPrice:  price,
 			UnrealizedPnL: decimal.Zero,
 			RealizedPnL:   decimal.Zero,
 			MarketValue:   decimal.Zero,
 			RiskMetrics:   models.RiskMetrics{},
-			LastUpdated:   time.Now(),
+			LastUpdated:   e.now(),
 		}
-		e.portfolio.Positions[symbol] = position
+		portfolio.Positions[symbol] = position
 	}
+	syncLots(position)
+	e.bookEntryRate(position, quantity, price)
 
-	if quantity > 0 {
-		totalCost := position.AveragePrice.Mul(decimal.NewFromInt(position.Quantity)).Add(price.Mul(decimal.NewFromInt(quantity)))
-		totalQuantity := position.Quantity + quantity
-		position.AveragePrice = totalCost.Div(decimal.NewFromInt(totalQuantity))
+	realizedPnL := decimal.Zero
+	var closedLots []models.ClosedLot
+	if position.Quantity.IsZero() || position.Quantity.IsPositive() == quantity.IsPositive() {
+		totalCost := position.AveragePrice.Mul(position.Quantity.Abs()).Add(price.Mul(quantity.Abs()))
+		totalQuantity := position.Quantity.Add(quantity)
+		if position.Quantity.IsZero() {
+			resetExtremes(position, price)
+		}
+		position.AveragePrice = totalCost.Div(totalQuantity.Abs())
 		position.Quantity = totalQuantity
+		position.Lots = append(position.Lots, models.Lot{Quantity: quantity, Price: price, Commission: commission, Timestamp: e.now()})
 	} else {
-		position.Quantity += quantity
-		if position.Quantity <= 0 {
-			delete(e.portfolio.Positions, symbol)
+		closedQuantity := decimal.Min(quantity.Abs(), position.Quantity.Abs())
+		if position.Quantity.IsNegative() {
+			closedQuantity = closedQuantity.Neg()
+		}
+		closedCommission := commission.Mul(closedQuantity.Abs()).Div(quantity.Abs())
+		closedLots = e.consumeLots(position, closedQuantity, price, closedCommission)
+		for _, lot := range closedLots {
+			realizedPnL = realizedPnL.Add(lot.RealizedPnL)
+		}
+		position.RealizedPnL = position.RealizedPnL.Add(realizedPnL)
+		portfolio.RealizedPnL = portfolio.RealizedPnL.Add(e.toBase(e.currencyOf(symbol), realizedPnL))
+
+		previousQuantity := position.Quantity
+		position.Quantity = position.Quantity.Add(quantity)
+		if position.Quantity.IsZero() {
+			delete(portfolio.Positions, symbol)
+			return realizedPnL, closedLots
+		}
+		if position.Quantity.IsPositive() != previousQuantity.IsPositive() {
+			position.AveragePrice = price
+			position.Lots = []models.Lot{{Quantity: position.Quantity, Price: price, Commission: commission.Sub(closedCommission), Timestamp: e.now()}}
+			resetExtremes(position, price)
+		} else if e.costBasis != models.CostBasisAverage {
+			position.AveragePrice = lotsAveragePrice(position.Lots)
 		}
 	}
 
 	position.CurrentPrice = price
-	position.LastUpdated = time.Now()
+	e.markPosition(position, price)
+	position.LastUpdated = e.now()
+	return realizedPnL, closedLots
 }
 
 func (e *TradingEngine) updatePortfolio() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	totalValue := e.portfolio.Cash
+	e.rollBooks()
+	e.accrueInterest()
+	cash := e.cashValue(e.portfolio)
+	totalValue := cash
 	unrealizedPnL := decimal.Zero
 
 	for symbol, position := range e.portfolio.Positions {
 		marketData, exists := e.marketData[symbol]
 		if exists {
 			position.CurrentPrice = marketData.Price
-			position.MarketValue = position.CurrentPrice.Mul(decimal.NewFromInt(position.Quantity))
-			position.UnrealizedPnL = position.CurrentPrice.Sub(position.AveragePrice).Mul(decimal.NewFromInt(position.Quantity))
+			trackExtremes(position, marketData.Price)
+			e.markPosition(position, marketData.Price)
 			totalValue = totalValue.Add(position.MarketValue)
 			unrealizedPnL = unrealizedPnL.Add(position.UnrealizedPnL)
 		}
@@ -353,45 +1132,58 @@ func (e *TradingEngine) updatePortfolio() {
 
 	e.portfolio.TotalValue = totalValue
 	e.portfolio.UnrealizedPnL = unrealizedPnL
-	e.portfolio.UpdatedAt = time.Now()
+	e.portfolio.TotalRisk = decimal.Zero
+	if totalValue.IsPositive() {
+		e.portfolio.TotalRisk = grossExposure(e.portfolio).Div(totalValue)
+	}
+	updateMargin(e.portfolio)
+	e.rebalanceAllocations()
+	e.portfolio.UpdatedAt = e.now()
+	e.recordEquity(models.EquityPoint{
+		Timestamp:     e.portfolio.UpdatedAt,
+		Value:         totalValue,
+		Cash:          cash,
+		UnrealizedPnL: unrealizedPnL,
+	})
+	e.markBooks(totalValue)
+	e.metrics.ObservePortfolio(e.portfolio)
+	e.publishPortfolio()
 }
 
 func (e *TradingEngine) manageRisk() {
 	e.mu.Lock()
-	defer e.mu.Unlock()
-
-	for symbol, position := range e.portfolio.Positions {
-		if position.Quantity <= 0 {
-			continue
-		}
+	liquidations := e.liquidateDrawdowns()
+	liquidations = append(liquidations, e.liquidateMarginCall()...)
+	e.disableDrawdownStrategies()
+	e.haltOnPortfolioDrawdown()
+	e.checkCircuitBreakers()
+	e.mu.Unlock()
 
-		drawdown := position.UnrealizedPnL.Div(position.MarketValue).Abs()
-		if drawdown.GreaterThan(decimal.NewFromFloat(0.1)) {
-			e.logger.Warn("Position drawdown exceeded", zap.String("symbol", symbol), zap.String("drawdown", drawdown.String()))
-		}
+	for _, order := range liquidations {
+		e.enqueueOrder(order)
 	}
 }
 
 func (e *TradingEngine) GetPortfolio() *models.Portfolio {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return e.portfolio
+	return copyPortfolio(e.portfolio)
 }
 
 func (e *TradingEngine) GetMarketData() map[string]*models.MarketData {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return e.marketData
+	return copyMarketData(e.marketData)
 }
 
 func generatePortfolioID() string {
 	return fmt.Sprintf("PORT-%d", time.Now().UnixNano())
 }
 
-func generateOrderID() string {
-	return fmt.Sprintf("ORD-%d", time.Now().UnixNano())
+func (e *TradingEngine) newOrderID() string {
+	return fmt.Sprintf("ORD-%d-%d", e.now().UnixNano(), atomic.AddUint64(&e.orderSequence, 1))
 }
 
-func generateTradeID() string {
-	return fmt.Sprintf("TRD-%d", time.Now().UnixNano())
+func (e *TradingEngine) newTradeID() string {
+	return fmt.Sprintf("TRD-%d-%d", e.now().UnixNano(), atomic.AddUint64(&e.tradeSequence, 1))
 }
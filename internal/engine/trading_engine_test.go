@@ -0,0 +1,423 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTradingEngine_CancelOrder(t *testing.T) {
+	engine := createTestEngine()
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+
+	err := engine.CancelOrder(order.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusCancelled, order.Status)
+	assert.Empty(t, engine.GetOpenOrders())
+	require.Len(t, engine.portfolio.OrderHistory, 1)
+	assert.Equal(t, order.ID, engine.portfolio.OrderHistory[0].ID)
+}
+
+func TestTradingEngine_CancelOrder_NotFound(t *testing.T) {
+	engine := createTestEngine()
+
+	err := engine.CancelOrder("ORD-missing")
+
+	assert.Equal(t, ErrOrderNotFound, err)
+}
+
+func TestTradingEngine_CancelOrder_AlreadyFilled(t *testing.T) {
+	engine := createTestEngine()
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+
+	engine.processOrder(order)
+	err := engine.CancelOrder(order.ID)
+
+	assert.Equal(t, ErrOrderAlreadyFilled, err)
+	assert.Equal(t, models.OrderStatusFilled, order.Status)
+}
+
+func TestTradingEngine_CancelOrder_AlreadyCancelled(t *testing.T) {
+	engine := createTestEngine()
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+
+	require.NoError(t, engine.CancelOrder(order.ID))
+	engine.processOrder(order)
+
+	assert.Equal(t, ErrOrderNotCancellable, engine.CancelOrder(order.ID))
+	assert.Equal(t, models.OrderStatusCancelled, order.Status)
+	assert.Len(t, engine.portfolio.OrderHistory, 1)
+	assert.Empty(t, engine.portfolio.Positions)
+}
+
+func TestTradingEngine_CancelOrder_ConcurrentWithFill(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		engine := createTestEngine()
+		order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+		engine.openOrders[order.ID] = order
+
+		var wg sync.WaitGroup
+		var cancelErr error
+		start := make(chan struct{})
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			<-start
+			engine.processOrder(order)
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+			cancelErr = engine.CancelOrder(order.ID)
+		}()
+		close(start)
+		wg.Wait()
+
+		require.Len(t, engine.portfolio.OrderHistory, 1)
+		switch order.Status {
+		case models.OrderStatusFilled:
+			assert.Equal(t, ErrOrderAlreadyFilled, cancelErr)
+			assert.Contains(t, engine.portfolio.Positions, "AAPL")
+		case models.OrderStatusCancelled:
+			assert.NoError(t, cancelErr)
+			assert.Empty(t, engine.portfolio.Positions)
+		default:
+			t.Fatalf("unexpected order status %s", order.Status)
+		}
+	}
+}
+
+func TestTradingEngine_GetOpenOrders(t *testing.T) {
+	engine := createTestEngine()
+	first := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	second := createTestOrder(models.OrderSideBuy, 20, 150.0)
+	second.Timestamp = first.Timestamp.Add(time.Second)
+	engine.openOrders[second.ID] = second
+	engine.openOrders[first.ID] = first
+
+	orders := engine.GetOpenOrders()
+
+	require.Len(t, orders, 2)
+	assert.Equal(t, first.ID, orders[0].ID)
+	assert.Equal(t, second.ID, orders[1].ID)
+
+	orders[0].Status = models.OrderStatusCancelled
+	assert.Equal(t, models.OrderStatusPending, first.Status)
+}
+
+func TestTradingEngine_ExecuteOrder_SlippageDirection(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetSlippageModel(execution.MaxSlippage{})
+	config := createTestStrategyConfig()
+	config.SlippageTolerance = decimal.NewFromFloat(0.01)
+
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 10, 150.0), config)
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 10, 150.0), config)
+
+	buy := <-engine.tradeQueue
+	sell := <-engine.tradeQueue
+
+	assert.True(t, decimal.NewFromFloat(150.0).Equal(buy.RequestedPrice))
+	assert.True(t, decimal.NewFromFloat(151.5).Equal(buy.Price))
+	assert.True(t, decimal.NewFromFloat(150.0).Equal(sell.RequestedPrice))
+	assert.True(t, decimal.NewFromFloat(148.5).Equal(sell.Price))
+}
+
+func TestTradingEngine_ExecuteOrder_FillsAtTheSpread(t *testing.T) {
+	engine := createTestEngine()
+	config := createTestStrategyConfig()
+	quote := createTestMarketData("AAPL", 150.0)
+	quote.Bid = decimal.NewFromFloat(149.9)
+	quote.Ask = decimal.NewFromFloat(150.1)
+	engine.UpdateMarketData("AAPL", quote)
+
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 10, 150.0), config)
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 10, 150.0), config)
+
+	buy := <-engine.tradeQueue
+	sell := <-engine.tradeQueue
+
+	assert.True(t, decimal.NewFromFloat(150.1).Equal(buy.Price))
+	assert.True(t, decimal.NewFromFloat(149.9).Equal(sell.Price))
+	assert.True(t, buy.Price.GreaterThanOrEqual(quote.Price))
+	assert.True(t, quote.Price.GreaterThanOrEqual(sell.Price))
+	assert.True(t, decimal.NewFromFloat(150.0).Equal(buy.RequestedPrice))
+}
+
+func TestTradingEngine_ExecuteOrder_MarketImpact(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetSlippageModel(execution.MaxSlippage{})
+	engine.SetImpactModel(execution.NewSquareRootImpact(decimal.NewFromFloat(0.1)))
+	config := createTestStrategyConfig()
+	config.SlippageTolerance = decimal.NewFromFloat(0.001)
+	quote := createTestMarketData("AAPL", 100.0)
+	quote.Bid = decimal.NewFromFloat(99.9)
+	quote.Ask = decimal.NewFromFloat(100.1)
+	quote.Volume = 10000
+	engine.UpdateMarketData("AAPL", quote)
+
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 100.0), config)
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 100, 100.0), config)
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 400, 100.0), config)
+
+	small, sell, large := <-engine.tradeQueue, <-engine.tradeQueue, <-engine.tradeQueue
+	assert.InDelta(t, 100.1*1.01, small.Price.InexactFloat64(), 1e-9)
+	assert.InDelta(t, 99.9*0.99, sell.Price.InexactFloat64(), 1e-9)
+	assert.InDelta(t, 100.1*1.02, large.Price.InexactFloat64(), 1e-9)
+	assert.InDelta(t, 100.1*0.01*100, small.ImpactCost.InexactFloat64(), 1e-9)
+	assert.True(t, large.ImpactCost.GreaterThan(small.ImpactCost))
+}
+
+func TestTradingEngine_ExecuteOrder_ImpactFallsBackWithoutVolume(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetSlippageModel(execution.MaxSlippage{})
+	engine.SetImpactModel(execution.NewSquareRootImpact(decimal.NewFromFloat(0.1)))
+	config := createTestStrategyConfig()
+	config.SlippageTolerance = decimal.NewFromFloat(0.01)
+	engine.UpdateMarketData("AAPL", createTestMarketData("AAPL", 150.0))
+
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 10, 150.0), config)
+
+	trade := <-engine.tradeQueue
+	assert.True(t, decimal.NewFromFloat(151.5).Equal(trade.Price))
+	assert.True(t, trade.ImpactCost.IsZero())
+}
+
+func TestTradingEngine_ExecuteOrder_ClampsCrossedSpread(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetSlippageModel(execution.MaxSlippage{})
+	config := createTestStrategyConfig()
+	config.SlippageTolerance = decimal.NewFromFloat(0.01)
+	quote := createTestMarketData("AAPL", 150.0)
+	quote.Bid = decimal.NewFromFloat(150.5)
+	quote.Ask = decimal.NewFromFloat(149.5)
+	engine.UpdateMarketData("AAPL", quote)
+
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 10, 150.0), config)
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 10, 150.0), config)
+
+	buy := <-engine.tradeQueue
+	sell := <-engine.tradeQueue
+
+	assert.True(t, decimal.NewFromFloat(151.5).Equal(buy.Price))
+	assert.True(t, decimal.NewFromFloat(148.5).Equal(sell.Price))
+}
+
+func TestTradingEngine_ExecuteOrder_SlippageWithinTolerance(t *testing.T) {
+	engine := createTestEngine()
+	config := createTestStrategyConfig()
+	config.SlippageTolerance = decimal.NewFromFloat(0.002)
+	requested := decimal.NewFromFloat(150.0)
+
+	for i := 0; i < 100; i++ {
+		engine.executeOrder(createTestOrder(models.OrderSideBuy, 1, 150.0), config)
+		trade := <-engine.tradeQueue
+		assert.True(t, trade.Price.GreaterThanOrEqual(requested))
+		assert.True(t, trade.Price.LessThanOrEqual(decimal.NewFromFloat(150.3)))
+	}
+}
+
+func TestTradingEngine_ExecuteOrder_DefaultCommissionUsesStrategyRate(t *testing.T) {
+	engine := createTestEngine()
+	config := createTestStrategyConfig()
+	config.CommissionRate = decimal.NewFromFloat(0.002)
+
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 10, 150.0), config)
+	trade := <-engine.tradeQueue
+
+	assert.True(t, decimal.NewFromFloat(3.0).Equal(trade.Commission))
+	assert.True(t, decimal.NewFromFloat(98497.0).Equal(engine.portfolio.Cash))
+}
+
+func TestTradingEngine_SetCommissionModel(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetCommissionModel(execution.NewFixedCommission(decimal.NewFromFloat(5.0)))
+
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+	trade := <-engine.tradeQueue
+
+	assert.True(t, decimal.NewFromFloat(5.0).Equal(trade.Commission))
+	assert.True(t, decimal.NewFromFloat(98495.0).Equal(engine.portfolio.Cash))
+}
+
+func TestTradingEngine_SetCommissionModel_AppliesToValidation(t *testing.T) {
+	engine := createTestEngine()
+	engine.SetCommissionModel(execution.NewFixedCommission(decimal.NewFromFloat(100.0)))
+	engine.portfolio.Cash = decimal.NewFromFloat(1550.0)
+
+	order := createTestOrder(models.OrderSideBuy, 10, 150.0)
+	engine.openOrders[order.ID] = order
+	engine.processOrder(order)
+
+	assert.Equal(t, models.OrderStatusRejected, order.Status)
+	assert.True(t, decimal.NewFromFloat(1550.0).Equal(engine.portfolio.Cash))
+}
+
+func TestTradingEngine_ShortSelling(t *testing.T) {
+	config := createTestStrategyConfig()
+	config.AllowShort = true
+
+	t.Run("Open Short", func(t *testing.T) {
+		engine := createTestEngine()
+		engine.executeOrder(createTestOrder(models.OrderSideSell, 100, 150.0), config)
+
+		position := engine.portfolio.Positions["AAPL"]
+		require.NotNil(t, position)
+		assert.True(t, decimal.NewFromInt(-100).Equal(position.Quantity))
+		assert.True(t, decimal.NewFromFloat(150.0).Equal(position.AveragePrice))
+
+		engine.marketData["AAPL"] = &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(140.0)}
+		engine.updatePortfolio()
+
+		assert.True(t, decimal.NewFromFloat(-14000.0).Equal(position.MarketValue))
+		assert.True(t, decimal.NewFromFloat(1000.0).Equal(position.UnrealizedPnL))
+	})
+
+	t.Run("Partial Cover", func(t *testing.T) {
+		engine := createTestEngine()
+		engine.executeOrder(createTestOrder(models.OrderSideSell, 100, 150.0), config)
+		engine.executeOrder(createTestOrder(models.OrderSideBuy, 40, 140.0), config)
+
+		position := engine.portfolio.Positions["AAPL"]
+		require.NotNil(t, position)
+		assert.True(t, decimal.NewFromInt(-60).Equal(position.Quantity))
+		assert.True(t, decimal.NewFromFloat(150.0).Equal(position.AveragePrice))
+		assert.True(t, decimal.NewFromFloat(388.4).Equal(engine.portfolio.RealizedPnL))
+	})
+
+	t.Run("Full Cover", func(t *testing.T) {
+		engine := createTestEngine()
+		engine.executeOrder(createTestOrder(models.OrderSideSell, 100, 150.0), config)
+		engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 160.0), config)
+
+		assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+		assert.True(t, decimal.NewFromFloat(-1031.0).Equal(engine.portfolio.RealizedPnL))
+	})
+
+	t.Run("Long To Short Flip", func(t *testing.T) {
+		engine := createTestEngine()
+		engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 150.0), config)
+		engine.executeOrder(createTestOrder(models.OrderSideSell, 150, 160.0), config)
+
+		position := engine.portfolio.Positions["AAPL"]
+		require.NotNil(t, position)
+		assert.True(t, decimal.NewFromInt(-50).Equal(position.Quantity))
+		assert.True(t, decimal.NewFromFloat(160.0).Equal(position.AveragePrice))
+		assert.True(t, decimal.NewFromFloat(969.0).Equal(engine.portfolio.RealizedPnL))
+	})
+}
+
+func TestTradingEngine_RealizedPnL(t *testing.T) {
+	engine := createTestEngine()
+	config := createTestStrategyConfig()
+
+	startingCash := engine.portfolio.Cash
+	engine.executeOrder(createTestOrder(models.OrderSideBuy, 100, 150.0), config)
+	buy := <-engine.tradeQueue
+	assert.True(t, buy.RealizedPnL.IsZero())
+	assert.True(t, engine.portfolio.RealizedPnL.IsZero())
+
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 60, 160.0), config)
+	firstSell := <-engine.tradeQueue
+	position := engine.portfolio.Positions["AAPL"]
+	require.NotNil(t, position)
+	assert.True(t, decimal.NewFromInt(40).Equal(position.Quantity))
+	assert.True(t, decimal.NewFromFloat(581.4).Equal(firstSell.RealizedPnL), firstSell.RealizedPnL.String())
+	assert.True(t, decimal.NewFromFloat(581.4).Equal(position.RealizedPnL))
+	assert.True(t, decimal.NewFromFloat(581.4).Equal(engine.portfolio.RealizedPnL))
+
+	engine.executeOrder(createTestOrder(models.OrderSideSell, 40, 140.0), config)
+	secondSell := <-engine.tradeQueue
+	assert.NotContains(t, engine.portfolio.Positions, "AAPL")
+	assert.True(t, decimal.NewFromFloat(-411.6).Equal(secondSell.RealizedPnL), secondSell.RealizedPnL.String())
+	assert.True(t, decimal.NewFromFloat(169.8).Equal(engine.portfolio.RealizedPnL), engine.portfolio.RealizedPnL.String())
+	assert.True(t, engine.portfolio.Cash.Sub(startingCash).Equal(engine.portfolio.RealizedPnL), engine.portfolio.Cash.String())
+}
+
+func TestTradingEngine_ProcessOrder_FractionalQuantity(t *testing.T) {
+	registry := symbols.NewRegistry()
+	require.NoError(t, registry.Register(models.Instrument{Symbol: "BTCUSDT", LotSize: decimal.NewFromFloat(0.001)}))
+	engine := createTestEngine()
+	engine.SetSymbols(registry)
+	engine.UpdateMarketData("BTCUSDT", createTestMarketData("BTCUSDT", 40000.0))
+
+	buy := createTestOrder(models.OrderSideBuy, 0, 40000.0)
+	buy.Symbol, buy.Quantity = "BTCUSDT", decimal.RequireFromString("0.125")
+	engine.openOrders[buy.ID] = buy
+	engine.processOrder(buy)
+	trade := <-engine.tradeQueue
+
+	assert.Equal(t, models.OrderStatusFilled, buy.Status)
+	assert.True(t, decimal.RequireFromString("0.125").Equal(trade.Quantity))
+	assert.True(t, decimal.NewFromFloat(94995.0).Equal(engine.portfolio.Cash), engine.portfolio.Cash.String())
+
+	sell := createTestOrder(models.OrderSideSell, 0, 40000.0)
+	sell.Symbol, sell.Quantity = "BTCUSDT", decimal.RequireFromString("0.05")
+	engine.openOrders[sell.ID] = sell
+	engine.processOrder(sell)
+	<-engine.tradeQueue
+	assert.True(t, decimal.RequireFromString("0.075").Equal(engine.portfolio.Positions["BTCUSDT"].Quantity))
+
+	partial := createTestOrder(models.OrderSideBuy, 0, 40000.0)
+	partial.Symbol, partial.Quantity = "BTCUSDT", decimal.RequireFromString("0.0125")
+	engine.openOrders[partial.ID] = partial
+	engine.processOrder(partial)
+	assert.Equal(t, models.OrderStatusRejected, partial.Status)
+}
+
+func createTestEngine(opts ...Option) *TradingEngine {
+	engine := NewTradingEngine(decimal.NewFromFloat(100000.0), zap.NewNop(), opts...)
+	engine.AddStrategy(strategies.NewMovingAverageStrategy(createTestStrategyConfig()))
+	return engine
+}
+
+func createTestStrategyConfig() *models.StrategyConfig {
+	return &models.StrategyConfig{
+		ID:               "test_strategy",
+		Name:             "Test Strategy",
+		MaxPositionSize:  decimal.NewFromFloat(0.5),
+		MaxPortfolioRisk: decimal.NewFromFloat(1.0),
+		MinOrderSize:     decimal.NewFromFloat(100.0),
+		MaxOrderSize:     decimal.NewFromFloat(50000.0),
+		CommissionRate:   decimal.NewFromFloat(0.001),
+		Enabled:          true,
+	}
+}
+
+var testOrderSequence uint64
+
+func testOrderID() string {
+	return fmt.Sprintf("ORD-%d-%d", time.Now().UnixNano(), atomic.AddUint64(&testOrderSequence, 1))
+}
+
+func createTestOrder(side models.OrderSide, quantity int64, price float64) *models.Order {
+	return &models.Order{
+		ID:         testOrderID(),
+		Symbol:     "AAPL",
+		Side:       side,
+		Type:       models.OrderTypeMarket,
+		Quantity:   decimal.NewFromInt(quantity),
+		Price:      decimal.NewFromFloat(price),
+		Status:     models.OrderStatusPending,
+		Timestamp:  time.Now(),
+		StrategyID: "test_strategy",
+	}
+}
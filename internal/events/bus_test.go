@@ -0,0 +1,47 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus_PublishDeliversInOrder(t *testing.T) {
+	bus := NewBus()
+	sub := bus.Subscribe(10)
+	defer sub.Close()
+
+	bus.Publish(Event{Type: EventTypeOrder, Symbol: "AAPL"})
+	bus.Publish(Event{Type: EventTypeTrade, Symbol: "AAPL"})
+
+	assert.Equal(t, EventTypeOrder, (<-sub.Events()).Type)
+	assert.Equal(t, EventTypeTrade, (<-sub.Events()).Type)
+}
+
+func TestBus_SlowSubscriberDropsEvents(t *testing.T) {
+	bus := NewBus()
+	slow := bus.Subscribe(1)
+	fast := bus.Subscribe(10)
+
+	for i := 0; i < 3; i++ {
+		bus.Publish(Event{Type: EventTypeMarketData})
+	}
+
+	assert.Equal(t, uint64(2), slow.Dropped())
+	assert.Zero(t, fast.Dropped())
+	assert.Len(t, fast.Events(), 3)
+}
+
+func TestBus_CloseUnsubscribes(t *testing.T) {
+	bus := NewBus()
+	sub := bus.Subscribe(1)
+
+	sub.Close()
+	sub.Close()
+	bus.Publish(Event{Type: EventTypeTrade})
+
+	_, open := <-sub.Events()
+	require.False(t, open)
+	assert.False(t, bus.HasSubscribers())
+}
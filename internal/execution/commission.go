@@ -0,0 +1,51 @@
+package execution
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type CommissionModel interface {
+	Calculate(order *models.Order) decimal.Decimal
+}
+
+type PercentageCommission struct {
+	rate decimal.Decimal
+}
+
+func NewPercentageCommission(rate decimal.Decimal) *PercentageCommission {
+	return &PercentageCommission{rate: rate}
+}
+
+func (c *PercentageCommission) Calculate(order *models.Order) decimal.Decimal {
+	return order.Price.Mul(order.Quantity).Mul(c.rate)
+}
+
+type FixedCommission struct {
+	fee decimal.Decimal
+}
+
+func NewFixedCommission(fee decimal.Decimal) *FixedCommission {
+	return &FixedCommission{fee: fee}
+}
+
+func (c *FixedCommission) Calculate(order *models.Order) decimal.Decimal {
+	return c.fee
+}
+
+type PerShareCommission struct {
+	perShare decimal.Decimal
+	minimum  decimal.Decimal
+}
+
+func NewPerShareCommission(perShare, minimum decimal.Decimal) *PerShareCommission {
+	return &PerShareCommission{perShare: perShare, minimum: minimum}
+}
+
+func (c *PerShareCommission) Calculate(order *models.Order) decimal.Decimal {
+	commission := c.perShare.Mul(order.Quantity)
+	if commission.LessThan(c.minimum) {
+		return c.minimum
+	}
+	return commission
+}
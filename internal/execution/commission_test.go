@@ -0,0 +1,56 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommissionModels(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    CommissionModel
+		quantity int64
+		price    float64
+		expected float64
+	}{
+		{
+			name:     "Percentage",
+			model:    NewPercentageCommission(decimal.NewFromFloat(0.001)),
+			quantity: 100,
+			price:    150.0,
+			expected: 15.0,
+		},
+		{
+			name:     "Fixed",
+			model:    NewFixedCommission(decimal.NewFromFloat(4.95)),
+			quantity: 100,
+			price:    150.0,
+			expected: 4.95,
+		},
+		{
+			name:     "Per Share Above Minimum",
+			model:    NewPerShareCommission(decimal.NewFromFloat(0.005), decimal.NewFromFloat(1.0)),
+			quantity: 1000,
+			price:    150.0,
+			expected: 5.0,
+		},
+		{
+			name:     "Per Share Minimum Applied",
+			model:    NewPerShareCommission(decimal.NewFromFloat(0.005), decimal.NewFromFloat(1.0)),
+			quantity: 10,
+			price:    150.0,
+			expected: 1.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &models.Order{Quantity: decimal.NewFromInt(tt.quantity), Price: decimal.NewFromFloat(tt.price)}
+			commission := tt.model.Calculate(order)
+			assert.True(t, decimal.NewFromFloat(tt.expected).Equal(commission), "got %s", commission)
+		})
+	}
+}
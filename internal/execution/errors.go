@@ -0,0 +1,8 @@
+package execution
+
+import "errors"
+
+var (
+	ErrUnknownLatencyModel = errors.New("unknown latency model")
+	ErrInvalidLatency      = errors.New("invalid latency configuration")
+)
@@ -0,0 +1,28 @@
+package execution
+
+import (
+	"math"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type ImpactModel interface {
+	Impact(order *models.Order, quote *models.MarketData) (decimal.Decimal, bool)
+}
+
+type SquareRootImpact struct {
+	K decimal.Decimal
+}
+
+func NewSquareRootImpact(k decimal.Decimal) SquareRootImpact {
+	return SquareRootImpact{K: k}
+}
+
+func (m SquareRootImpact) Impact(order *models.Order, quote *models.MarketData) (decimal.Decimal, bool) {
+	if quote == nil || quote.Volume <= 0 || !order.Quantity.IsPositive() || !m.K.IsPositive() {
+		return decimal.Zero, false
+	}
+	participation := math.Sqrt(order.Quantity.InexactFloat64() / float64(quote.Volume))
+	return m.K.Mul(decimal.NewFromFloat(participation)), true
+}
@@ -0,0 +1,50 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSquareRootImpact_IncreasesWithOrderSize(t *testing.T) {
+	model := NewSquareRootImpact(decimal.NewFromFloat(0.1))
+	quote := &models.MarketData{Price: decimal.NewFromFloat(100.0), Volume: 10000}
+
+	previous := decimal.Zero
+	for _, quantity := range []int64{10, 100, 1000, 10000, 100000} {
+		impact, ok := model.Impact(&models.Order{Side: models.OrderSideBuy, Quantity: decimal.NewFromInt(quantity)}, quote)
+		require.True(t, ok)
+		assert.True(t, impact.GreaterThan(previous), "quantity %d", quantity)
+		previous = impact
+	}
+
+	impact, _ := model.Impact(&models.Order{Quantity: decimal.NewFromInt(100)}, quote)
+	assert.InDelta(t, 0.01, impact.InexactFloat64(), 1e-12)
+}
+
+func TestSquareRootImpact_SymmetricAcrossSides(t *testing.T) {
+	model := NewSquareRootImpact(decimal.NewFromFloat(0.1))
+	quote := &models.MarketData{Price: decimal.NewFromFloat(100.0), Volume: 10000}
+	price := decimal.NewFromFloat(100.0)
+
+	buyImpact, _ := model.Impact(&models.Order{Side: models.OrderSideBuy, Quantity: decimal.NewFromInt(400)}, quote)
+	sellImpact, _ := model.Impact(&models.Order{Side: models.OrderSideSell, Quantity: decimal.NewFromInt(400)}, quote)
+	require.True(t, buyImpact.Equal(sellImpact))
+
+	buy := FillPrice(models.OrderSideBuy, price, buyImpact).Sub(price)
+	sell := price.Sub(FillPrice(models.OrderSideSell, price, sellImpact))
+	assert.True(t, buy.Equal(sell))
+	assert.True(t, buy.Equal(decimal.NewFromFloat(2.0)))
+}
+
+func TestSquareRootImpact_NoVolume(t *testing.T) {
+	model := NewSquareRootImpact(decimal.NewFromFloat(0.1))
+
+	_, ok := model.Impact(&models.Order{Quantity: decimal.NewFromInt(100)}, &models.MarketData{Price: decimal.NewFromFloat(100.0)})
+	assert.False(t, ok)
+	_, ok = model.Impact(&models.Order{Quantity: decimal.NewFromInt(100)}, nil)
+	assert.False(t, ok)
+}
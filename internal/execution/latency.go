@@ -0,0 +1,94 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	LatencyFixed     = "fixed"
+	LatencyUniform   = "uniform"
+	LatencyLognormal = "lognormal"
+)
+
+type LatencyModel interface {
+	Latency() time.Duration
+}
+
+type LatencyConfig struct {
+	Model   string
+	Latency time.Duration
+	Jitter  time.Duration
+	Sigma   float64
+	Seed    int64
+}
+
+func NewLatencyModel(config LatencyConfig) (LatencyModel, error) {
+	if config.Latency < 0 || config.Jitter < 0 || config.Sigma < 0 {
+		return nil, fmt.Errorf("%w: latency, jitter and sigma must not be negative", ErrInvalidLatency)
+	}
+
+	switch config.Model {
+	case "":
+		return nil, nil
+	case LatencyFixed:
+		return FixedLatency{Delay: config.Latency}, nil
+	case LatencyUniform:
+		if config.Jitter > config.Latency {
+			return nil, fmt.Errorf("%w: jitter %s exceeds latency %s", ErrInvalidLatency, config.Jitter, config.Latency)
+		}
+		return NewUniformLatency(config.Latency-config.Jitter, config.Latency+config.Jitter, config.Seed), nil
+	case LatencyLognormal:
+		return NewLognormalLatency(config.Latency, config.Sigma, config.Seed), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownLatencyModel, config.Model)
+	}
+}
+
+type FixedLatency struct {
+	Delay time.Duration
+}
+
+func (l FixedLatency) Latency() time.Duration {
+	return l.Delay
+}
+
+type UniformLatency struct {
+	min time.Duration
+	max time.Duration
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func NewUniformLatency(min, max time.Duration, seed int64) *UniformLatency {
+	return &UniformLatency{min: min, max: max, rng: rand.New(rand.NewSource(seed))}
+}
+
+func (l *UniformLatency) Latency() time.Duration {
+	if l.max <= l.min {
+		return l.min
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.min + time.Duration(l.rng.Int63n(int64(l.max-l.min)+1))
+}
+
+type LognormalLatency struct {
+	median time.Duration
+	sigma  float64
+	mu     sync.Mutex
+	rng    *rand.Rand
+}
+
+func NewLognormalLatency(median time.Duration, sigma float64, seed int64) *LognormalLatency {
+	return &LognormalLatency{median: median, sigma: sigma, rng: rand.New(rand.NewSource(seed))}
+}
+
+func (l *LognormalLatency) Latency() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return time.Duration(float64(l.median) * math.Exp(l.sigma*l.rng.NormFloat64()))
+}
@@ -0,0 +1,57 @@
+package execution
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLatencyModel(t *testing.T) {
+	model, err := NewLatencyModel(LatencyConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, model)
+
+	model, err = NewLatencyModel(LatencyConfig{Model: LatencyFixed, Latency: 50 * time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Millisecond, model.Latency())
+
+	_, err = NewLatencyModel(LatencyConfig{Model: "gamma"})
+	assert.ErrorIs(t, err, ErrUnknownLatencyModel)
+
+	_, err = NewLatencyModel(LatencyConfig{Model: LatencyUniform, Latency: time.Millisecond, Jitter: 2 * time.Millisecond})
+	assert.ErrorIs(t, err, ErrInvalidLatency)
+
+	_, err = NewLatencyModel(LatencyConfig{Model: LatencyFixed, Latency: -time.Millisecond})
+	assert.ErrorIs(t, err, ErrInvalidLatency)
+}
+
+func TestUniformLatency_StaysInRange(t *testing.T) {
+	model := NewUniformLatency(10*time.Millisecond, 20*time.Millisecond, 1)
+
+	for i := 0; i < 1000; i++ {
+		latency := model.Latency()
+		assert.GreaterOrEqual(t, latency, 10*time.Millisecond)
+		assert.LessOrEqual(t, latency, 20*time.Millisecond)
+	}
+}
+
+func TestLognormalLatency_MedianAndSeed(t *testing.T) {
+	draw := func() []time.Duration {
+		model := NewLognormalLatency(100*time.Millisecond, 0.5, 7)
+		latencies := make([]time.Duration, 2001)
+		for i := range latencies {
+			latencies[i] = model.Latency()
+		}
+		return latencies
+	}
+
+	latencies := draw()
+	assert.Equal(t, latencies, draw())
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	assert.InDelta(t, float64(100*time.Millisecond), float64(latencies[len(latencies)/2]), float64(10*time.Millisecond))
+	assert.Positive(t, latencies[0])
+}
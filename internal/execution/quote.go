@@ -0,0 +1,22 @@
+package execution
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+func QuotePrice(side models.OrderSide, price decimal.Decimal, quote *models.MarketData) decimal.Decimal {
+	if quote == nil || !quote.Bid.IsPositive() || !quote.Ask.IsPositive() || quote.Bid.GreaterThan(quote.Ask) {
+		return price
+	}
+
+	mid := quote.Price
+	if !mid.IsPositive() {
+		mid = quote.Bid.Add(quote.Ask).Div(decimal.NewFromInt(2))
+	}
+
+	if side == models.OrderSideBuy {
+		return decimal.Max(quote.Ask, mid)
+	}
+	return decimal.Min(quote.Bid, mid)
+}
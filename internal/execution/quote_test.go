@@ -0,0 +1,55 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestQuote(mid, bid, ask float64) *models.MarketData {
+	return &models.MarketData{
+		Symbol: "AAPL",
+		Price:  decimal.NewFromFloat(mid),
+		Bid:    decimal.NewFromFloat(bid),
+		Ask:    decimal.NewFromFloat(ask),
+	}
+}
+
+func TestQuotePrice_FillsAtTheSpread(t *testing.T) {
+	quote := createTestQuote(100.0, 99.95, 100.05)
+
+	assert.True(t, decimal.NewFromFloat(100.05).Equal(QuotePrice(models.OrderSideBuy, quote.Price, quote)))
+	assert.True(t, decimal.NewFromFloat(99.95).Equal(QuotePrice(models.OrderSideSell, quote.Price, quote)))
+}
+
+func TestQuotePrice_ClampsBadQuotes(t *testing.T) {
+	mid := decimal.NewFromFloat(100.0)
+	quotes := map[string]*models.MarketData{
+		"no quote":       createTestQuote(100.0, 0, 0),
+		"missing ask":    createTestQuote(100.0, 99.95, 0),
+		"crossed":        createTestQuote(100.0, 100.10, 99.90),
+		"zero width":     createTestQuote(100.0, 100.0, 100.0),
+		"negative bid":   createTestQuote(100.0, -1.0, 100.05),
+		"outside of mid": createTestQuote(100.0, 100.02, 100.05),
+	}
+
+	for name, quote := range quotes {
+		buy := QuotePrice(models.OrderSideBuy, quote.Price, quote)
+		sell := QuotePrice(models.OrderSideSell, quote.Price, quote)
+		assert.True(t, buy.GreaterThanOrEqual(mid), name)
+		assert.True(t, sell.LessThanOrEqual(mid), name)
+	}
+
+	assert.True(t, mid.Equal(QuotePrice(models.OrderSideBuy, mid, quotes["crossed"])))
+	assert.True(t, mid.Equal(QuotePrice(models.OrderSideSell, mid, quotes["crossed"])))
+	assert.True(t, mid.Equal(QuotePrice(models.OrderSideBuy, mid, nil)))
+}
+
+func TestQuotePrice_DerivesMidFromQuote(t *testing.T) {
+	quote := createTestQuote(0, 99.0, 101.0)
+
+	assert.True(t, decimal.NewFromFloat(101.0).Equal(QuotePrice(models.OrderSideBuy, quote.Price, quote)))
+	assert.True(t, decimal.NewFromFloat(99.0).Equal(QuotePrice(models.OrderSideSell, quote.Price, quote)))
+}
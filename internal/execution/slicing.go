@@ -0,0 +1,58 @@
+package execution
+
+const (
+	SliceTWAP = "twap"
+	SliceVWAP = "vwap"
+)
+
+func TWAPSlices(quantity int64, slices int) []int64 {
+	if quantity <= 0 || slices <= 0 {
+		return nil
+	}
+
+	sizes := make([]int64, slices)
+	base, remainder := quantity/int64(slices), quantity%int64(slices)
+	for i := range sizes {
+		sizes[i] = base
+		if int64(i) < remainder {
+			sizes[i]++
+		}
+	}
+	return sizes
+}
+
+func VWAPSlices(quantity int64, volumes []int64) []int64 {
+	total := int64(0)
+	for _, volume := range volumes {
+		if volume > 0 {
+			total += volume
+		}
+	}
+	if quantity <= 0 || total == 0 {
+		return TWAPSlices(quantity, len(volumes))
+	}
+
+	sizes := make([]int64, len(volumes))
+	remainders := make([]int64, len(volumes))
+	allocated := int64(0)
+	for i, volume := range volumes {
+		if volume <= 0 {
+			continue
+		}
+		sizes[i] = quantity * volume / total
+		remainders[i] = quantity * volume % total
+		allocated += sizes[i]
+	}
+
+	for ; allocated < quantity; allocated++ {
+		largest := 0
+		for i := range remainders {
+			if remainders[i] > remainders[largest] {
+				largest = i
+			}
+		}
+		sizes[largest]++
+		remainders[largest] = -1
+	}
+	return sizes
+}
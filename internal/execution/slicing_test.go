@@ -0,0 +1,24 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTWAPSlices(t *testing.T) {
+	assert.Equal(t, []int64{100, 100, 100, 100, 100, 100, 100, 100, 100, 100}, TWAPSlices(1000, 10))
+	assert.Equal(t, []int64{4, 4, 3}, TWAPSlices(11, 3))
+	assert.Equal(t, []int64{1, 1, 0}, TWAPSlices(2, 3))
+	assert.Nil(t, TWAPSlices(0, 3))
+}
+
+func TestVWAPSlices_WeightsByVolume(t *testing.T) {
+	assert.Equal(t, []int64{100, 300, 600}, VWAPSlices(1000, []int64{1000, 3000, 6000}))
+	assert.Equal(t, []int64{34, 33, 33}, VWAPSlices(100, []int64{1, 1, 1}))
+	assert.Equal(t, []int64{0, 10}, VWAPSlices(10, []int64{0, 5}))
+}
+
+func TestVWAPSlices_FallsBackToTWAPWithoutVolume(t *testing.T) {
+	assert.Equal(t, []int64{5, 5}, VWAPSlices(10, []int64{0, 0}))
+}
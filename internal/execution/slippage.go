@@ -0,0 +1,56 @@
+package execution
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type SlippageModel interface {
+	Slippage(order *models.Order, tolerance decimal.Decimal) decimal.Decimal
+}
+
+type UniformSlippage struct{}
+
+func (UniformSlippage) Slippage(order *models.Order, tolerance decimal.Decimal) decimal.Decimal {
+	if !tolerance.IsPositive() {
+		return decimal.Zero
+	}
+	return tolerance.Mul(decimal.NewFromFloat(rand.Float64()))
+}
+
+type SeededSlippage struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func NewSeededSlippage(seed int64) *SeededSlippage {
+	return &SeededSlippage{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (s *SeededSlippage) Slippage(order *models.Order, tolerance decimal.Decimal) decimal.Decimal {
+	if !tolerance.IsPositive() {
+		return decimal.Zero
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return tolerance.Mul(decimal.NewFromFloat(s.rng.Float64()))
+}
+
+type MaxSlippage struct{}
+
+func (MaxSlippage) Slippage(order *models.Order, tolerance decimal.Decimal) decimal.Decimal {
+	if !tolerance.IsPositive() {
+		return decimal.Zero
+	}
+	return tolerance
+}
+
+func FillPrice(side models.OrderSide, price, slippage decimal.Decimal) decimal.Decimal {
+	if side == models.OrderSideBuy {
+		return price.Mul(decimal.NewFromInt(1).Add(slippage))
+	}
+	return price.Mul(decimal.NewFromInt(1).Sub(slippage))
+}
@@ -0,0 +1,46 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniformSlippage_WithinTolerance(t *testing.T) {
+	model := UniformSlippage{}
+	tolerance := decimal.NewFromFloat(0.002)
+
+	for i := 0; i < 1000; i++ {
+		slippage := model.Slippage(&models.Order{}, tolerance)
+		assert.True(t, slippage.GreaterThanOrEqual(decimal.Zero))
+		assert.True(t, slippage.LessThanOrEqual(tolerance))
+	}
+}
+
+func TestUniformSlippage_ZeroTolerance(t *testing.T) {
+	slippage := UniformSlippage{}.Slippage(&models.Order{}, decimal.Zero)
+
+	assert.True(t, slippage.IsZero())
+}
+
+func TestSeededSlippage_SameSeedSameDraws(t *testing.T) {
+	first, second := NewSeededSlippage(42), NewSeededSlippage(42)
+	tolerance := decimal.NewFromFloat(0.002)
+
+	for i := 0; i < 100; i++ {
+		slippage := first.Slippage(&models.Order{}, tolerance)
+		assert.True(t, slippage.Equal(second.Slippage(&models.Order{}, tolerance)))
+		assert.True(t, slippage.LessThanOrEqual(tolerance))
+	}
+	assert.True(t, first.Slippage(&models.Order{}, decimal.Zero).IsZero())
+}
+
+func TestFillPrice(t *testing.T) {
+	price := decimal.NewFromFloat(100.0)
+	slippage := decimal.NewFromFloat(0.01)
+
+	assert.True(t, decimal.NewFromFloat(101.0).Equal(FillPrice(models.OrderSideBuy, price, slippage)))
+	assert.True(t, decimal.NewFromFloat(99.0).Equal(FillPrice(models.OrderSideSell, price, slippage)))
+}
@@ -0,0 +1,218 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+)
+
+var riskMetricsHeader = []string{"var_95", "expected_shortfall", "sharpe_ratio", "max_drawdown", "volatility", "beta"}
+
+var tradeHeader = append([]string{
+	"id", "order_id", "symbol", "side", "quantity", "price", "requested_price",
+	"decision_price", "submission_price", "commission", "impact_cost",
+	"delay_bps", "spread_bps", "impact_bps", "shortfall_bps",
+	"realized_pnl", "timestamp", "strategy_id", "exit_reason", "reason",
+}, riskMetricsHeader...)
+
+var orderHeader = append([]string{
+	"id", "symbol", "side", "type", "quantity", "price", "stop_price",
+	"decision_price", "submission_price", "status", "timestamp", "strategy_id", "exit_reason", "reason",
+}, riskMetricsHeader...)
+
+var positionHeader = append([]string{
+	"symbol", "strategy_id", "quantity", "average_price", "current_price", "peak_price",
+	"trough_price", "market_value", "unrealized_pnl", "realized_pnl", "last_updated",
+}, append(riskMetricsHeader, fxHeader...)...)
+
+var fxHeader = []string{"currency", "fx_rate", "native_market_value", "native_unrealized_pnl", "price_pnl", "fx_pnl"}
+
+var equityHeader = []string{"timestamp", "value", "cash", "unrealized_pnl"}
+
+var lotHeader = []string{
+	"symbol", "status", "trade_id", "quantity", "open_price", "cost_basis",
+	"opened_at", "close_price", "closed_at", "realized_pnl",
+}
+
+type Option func(*formatter)
+
+func WithSymbols(registry *symbols.Registry) Option {
+	return func(f *formatter) {
+		f.symbols = registry
+	}
+}
+
+type formatter struct {
+	symbols *symbols.Registry
+}
+
+func newFormatter(opts []Option) *formatter {
+	f := &formatter{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func (f *formatter) price(symbol string, value decimal.Decimal) string {
+	return f.symbols.FormatPrice(symbol, value)
+}
+
+func WriteTradesCSV(w io.Writer, trades []*models.Trade, opts ...Option) error {
+	format := newFormatter(opts)
+	records := make([][]string, len(trades))
+	for i, trade := range trades {
+		records[i] = append([]string{
+			trade.ID,
+			trade.OrderID,
+			trade.Symbol,
+			string(trade.Side),
+			formatDecimal(trade.Quantity),
+			format.price(trade.Symbol, trade.Price),
+			format.price(trade.Symbol, trade.RequestedPrice),
+			format.price(trade.Symbol, trade.DecisionPrice),
+			format.price(trade.Symbol, trade.SubmissionPrice),
+			formatDecimal(trade.Commission),
+			formatDecimal(trade.ImpactCost),
+			formatDecimal(trade.Costs.DelayBps),
+			formatDecimal(trade.Costs.SpreadBps),
+			formatDecimal(trade.Costs.ImpactBps),
+			formatDecimal(trade.Costs.ShortfallBps),
+			formatDecimal(trade.RealizedPnL),
+			formatTime(trade.Timestamp),
+			trade.StrategyID,
+			string(trade.ExitReason),
+			trade.Reason,
+		}, riskMetricsRecord(trade.RiskMetrics)...)
+	}
+	return writeCSV(w, tradeHeader, records)
+}
+
+func WriteOrdersCSV(w io.Writer, orders []*models.Order, opts ...Option) error {
+	format := newFormatter(opts)
+	records := make([][]string, len(orders))
+	for i, order := range orders {
+		records[i] = append([]string{
+			order.ID,
+			order.Symbol,
+			string(order.Side),
+			string(order.Type),
+			formatDecimal(order.Quantity),
+			format.price(order.Symbol, order.Price),
+			format.price(order.Symbol, order.StopPrice),
+			format.price(order.Symbol, order.DecisionPrice),
+			format.price(order.Symbol, order.SubmissionPrice),
+			string(order.Status),
+			formatTime(order.Timestamp),
+			order.StrategyID,
+			string(order.ExitReason),
+			order.Reason,
+		}, riskMetricsRecord(order.RiskMetrics)...)
+	}
+	return writeCSV(w, orderHeader, records)
+}
+
+func WritePositionsCSV(w io.Writer, positions []*models.Position, opts ...Option) error {
+	format := newFormatter(opts)
+	records := make([][]string, len(positions))
+	for i, position := range positions {
+		records[i] = append([]string{
+			position.Symbol,
+			position.StrategyID,
+			formatDecimal(position.Quantity),
+			format.price(position.Symbol, position.AveragePrice),
+			format.price(position.Symbol, position.CurrentPrice),
+			format.price(position.Symbol, position.PeakPrice),
+			format.price(position.Symbol, position.TroughPrice),
+			formatDecimal(position.MarketValue),
+			formatDecimal(position.UnrealizedPnL),
+			formatDecimal(position.RealizedPnL),
+			formatTime(position.LastUpdated),
+		}, append(riskMetricsRecord(position.RiskMetrics), fxRecord(position.FX)...)...)
+	}
+	return writeCSV(w, positionHeader, records)
+}
+
+func WriteLotsCSV(w io.Writer, lots []LotRecord, opts ...Option) error {
+	format := newFormatter(opts)
+	records := make([][]string, len(lots))
+	for i, lot := range lots {
+		records[i] = []string{
+			lot.Symbol,
+			lot.Status,
+			lot.TradeID,
+			formatDecimal(lot.Quantity),
+			format.price(lot.Symbol, lot.OpenPrice),
+			formatDecimal(lot.CostBasis),
+			formatTime(lot.OpenedAt),
+			format.price(lot.Symbol, lot.ClosePrice),
+			formatTime(lot.ClosedAt),
+			formatDecimal(lot.RealizedPnL),
+		}
+	}
+	return writeCSV(w, lotHeader, records)
+}
+
+func WriteEquityCSV(w io.Writer, curve []models.EquityPoint) error {
+	records := make([][]string, len(curve))
+	for i, point := range curve {
+		records[i] = []string{
+			formatTime(point.Timestamp),
+			formatDecimal(point.Value),
+			formatDecimal(point.Cash),
+			formatDecimal(point.UnrealizedPnL),
+		}
+	}
+	return writeCSV(w, equityHeader, records)
+}
+
+func writeCSV(w io.Writer, header []string, records [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func riskMetricsRecord(metrics models.RiskMetrics) []string {
+	return []string{
+		formatDecimal(metrics.VaR95),
+		formatDecimal(metrics.ExpectedShortfall),
+		formatDecimal(metrics.SharpeRatio),
+		formatDecimal(metrics.MaxDrawdown),
+		formatDecimal(metrics.Volatility),
+		formatDecimal(metrics.Beta),
+	}
+}
+
+func fxRecord(fx *models.PositionFX) []string {
+	if fx == nil {
+		return make([]string, len(fxHeader))
+	}
+	return []string{
+		fx.Currency,
+		formatDecimal(fx.Rate),
+		formatDecimal(fx.MarketValue),
+		formatDecimal(fx.UnrealizedPnL),
+		formatDecimal(fx.PricePnL),
+		formatDecimal(fx.FXPnL),
+	}
+}
+
+func formatDecimal(value decimal.Decimal) string {
+	return value.String()
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
@@ -0,0 +1,68 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+)
+
+func WriteDirectory(dir string, portfolio *models.Portfolio, equityCurve []models.EquityPoint, opts ...Option) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	positions := portfolio.SortedPositions()
+	lots := LotRecords(portfolio)
+	files := []struct {
+		name     string
+		writeCSV func(io.Writer) error
+		document interface{}
+	}{
+		{"trades", func(w io.Writer) error { return WriteTradesCSV(w, portfolio.TradeHistory, opts...) }, portfolio.TradeHistory},
+		{"orders", func(w io.Writer) error { return WriteOrdersCSV(w, portfolio.OrderHistory, opts...) }, portfolio.OrderHistory},
+		{"positions", func(w io.Writer) error { return WritePositionsCSV(w, positions, opts...) }, positions},
+		{"lots", func(w io.Writer) error { return WriteLotsCSV(w, lots, opts...) }, lots},
+		{"equity", func(w io.Writer) error { return WriteEquityCSV(w, equityCurve) }, equityCurve},
+	}
+
+	var paths []string
+	for _, file := range files {
+		base := filepath.Join(dir, fmt.Sprintf("%s_%s", file.name, portfolio.ID))
+
+		if err := writeFile(base+".csv", file.writeCSV); err != nil {
+			return paths, err
+		}
+		paths = append(paths, base+".csv")
+
+		document := file.document
+		if err := writeFile(base+".json", func(w io.Writer) error { return writeJSON(w, document) }); err != nil {
+			return paths, err
+		}
+		paths = append(paths, base+".json")
+	}
+
+	return paths, nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return file.Close()
+}
+
+func writeJSON(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
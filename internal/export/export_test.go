@@ -0,0 +1,225 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exportTime = time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+
+func createTestPortfolio() *models.Portfolio {
+	return &models.Portfolio{
+		ID: "portfolio_1",
+		Positions: map[string]*models.Position{
+			"MSFT": {Symbol: "MSFT", StrategyID: "ma", Quantity: decimal.NewFromInt(-5), AveragePrice: decimal.NewFromFloat(300.0), LastUpdated: exportTime},
+			"AAPL": {Symbol: "AAPL", StrategyID: "ma", Quantity: decimal.NewFromInt(10), AveragePrice: decimal.NewFromFloat(150.25), LastUpdated: exportTime},
+		},
+		TradeHistory: []*models.Trade{
+			{
+				ID:          "trade_1",
+				OrderID:     "order_1",
+				Symbol:      "AAPL",
+				Side:        models.OrderSideBuy,
+				Quantity:    decimal.NewFromInt(10),
+				Price:       decimal.NewFromFloat(150.25),
+				Commission:  decimal.NewFromFloat(0.0000001),
+				Timestamp:   exportTime,
+				StrategyID:  "ma",
+				RiskMetrics: models.RiskMetrics{VaR95: decimal.NewFromFloat(12.5), Beta: decimal.NewFromFloat(1.1)},
+			},
+		},
+		OrderHistory: []*models.Order{
+			{ID: "order_1", Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: decimal.NewFromInt(10), Price: decimal.NewFromFloat(150.0), Status: models.OrderStatusFilled, Timestamp: exportTime, StrategyID: "ma"},
+			{ID: "order_2", Symbol: "MSFT", Side: models.OrderSideSell, Type: models.OrderTypeMarket, Quantity: decimal.NewFromInt(5), Price: decimal.NewFromFloat(300.0), Status: models.OrderStatusRejected, Timestamp: exportTime, StrategyID: "ma"},
+		},
+	}
+}
+
+func readCSV(t *testing.T, data []byte) []map[string]string {
+	t.Helper()
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+
+	rows := make([]map[string]string, len(records)-1)
+	for i, record := range records[1:] {
+		rows[i] = make(map[string]string)
+		for j, column := range records[0] {
+			rows[i][column] = record[j]
+		}
+	}
+	return rows
+}
+
+func TestWriteTradesCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteTradesCSV(&buf, createTestPortfolio().TradeHistory))
+
+	header, err := csv.NewReader(bytes.NewReader(buf.Bytes())).Read()
+	require.NoError(t, err)
+	assert.Equal(t, tradeHeader, header)
+
+	rows := readCSV(t, buf.Bytes())
+	require.Len(t, rows, 1)
+	assert.Equal(t, "150.25", rows[0]["price"])
+	assert.Equal(t, "0.0000001", rows[0]["commission"])
+	assert.Equal(t, "2024-03-04T09:30:00Z", rows[0]["timestamp"])
+	assert.Equal(t, "12.5", rows[0]["var_95"])
+	assert.Equal(t, "1.1", rows[0]["beta"])
+}
+
+func TestWriteOrdersCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteOrdersCSV(&buf, createTestPortfolio().OrderHistory))
+
+	rows := readCSV(t, buf.Bytes())
+	require.Len(t, rows, 2)
+	assert.Equal(t, "filled", rows[0]["status"])
+	assert.Equal(t, "rejected", rows[1]["status"])
+	assert.Equal(t, "0", rows[1]["var_95"])
+}
+
+func TestWriteTradesCSV_FormatsPricesWithInstrumentPrecision(t *testing.T) {
+	registry := symbols.NewRegistry()
+	require.NoError(t, registry.Register(models.Instrument{Symbol: "AAPL", TickSize: decimal.NewFromFloat(0.05), LotSize: decimal.NewFromInt(1), PricePrecision: 3}))
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteTradesCSV(&buf, createTestPortfolio().TradeHistory, WithSymbols(registry)))
+
+	rows := readCSV(t, buf.Bytes())
+	require.Len(t, rows, 1)
+	assert.Equal(t, "150.250", rows[0]["price"])
+	assert.Equal(t, "0.000", rows[0]["requested_price"])
+	assert.Equal(t, "0.0000001", rows[0]["commission"])
+}
+
+func TestWriteDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "export")
+
+	curve := []models.EquityPoint{{Timestamp: exportTime, Value: decimal.NewFromFloat(101500.0), Cash: decimal.NewFromFloat(100000.0), UnrealizedPnL: decimal.NewFromFloat(1500.0)}}
+	paths, err := WriteDirectory(dir, createTestPortfolio(), curve)
+
+	require.NoError(t, err)
+	assert.Len(t, paths, 10)
+	for _, name := range []string{"trades", "orders", "positions", "lots", "equity"} {
+		assert.FileExists(t, filepath.Join(dir, name+"_portfolio_1.csv"))
+		assert.FileExists(t, filepath.Join(dir, name+"_portfolio_1.json"))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "positions_portfolio_1.csv"))
+	require.NoError(t, err)
+	rows := readCSV(t, data)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "AAPL", rows[0]["symbol"])
+	assert.Equal(t, "-5", rows[1]["quantity"])
+
+	data, err = os.ReadFile(filepath.Join(dir, "equity_portfolio_1.csv"))
+	require.NoError(t, err)
+	rows = readCSV(t, data)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "2024-03-04T09:30:00Z", rows[0]["timestamp"])
+	assert.Equal(t, "101500", rows[0]["value"])
+	assert.Equal(t, "1500", rows[0]["unrealized_pnl"])
+
+	data, err = os.ReadFile(filepath.Join(dir, "trades_portfolio_1.json"))
+	require.NoError(t, err)
+	var trades []*models.Trade
+	require.NoError(t, json.Unmarshal(data, &trades))
+	require.Len(t, trades, 1)
+	assert.True(t, decimal.NewFromFloat(150.25).Equal(trades[0].Price))
+}
+
+func TestWritePositionsCSV_ForeignCurrency(t *testing.T) {
+	positions := []*models.Position{
+		{Symbol: "AAPL", Quantity: decimal.NewFromInt(10)},
+		{Symbol: "SAP", Quantity: decimal.NewFromInt(10), MarketValue: decimal.NewFromFloat(1320), FX: &models.PositionFX{
+			Currency:      "EUR",
+			Rate:          decimal.NewFromFloat(1.1),
+			MarketValue:   decimal.NewFromInt(1200),
+			UnrealizedPnL: decimal.NewFromInt(100),
+			PricePnL:      decimal.NewFromInt(110),
+			FXPnL:         decimal.NewFromInt(-11),
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePositionsCSV(&buf, positions))
+	rows := readCSV(t, buf.Bytes())
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, "", rows[0]["currency"])
+	assert.Equal(t, "", rows[0]["fx_pnl"])
+	assert.Equal(t, "EUR", rows[1]["currency"])
+	assert.Equal(t, "1320", rows[1]["market_value"])
+	assert.Equal(t, "1.1", rows[1]["fx_rate"])
+	assert.Equal(t, "1200", rows[1]["native_market_value"])
+	assert.Equal(t, "100", rows[1]["native_unrealized_pnl"])
+	assert.Equal(t, "110", rows[1]["price_pnl"])
+	assert.Equal(t, "-11", rows[1]["fx_pnl"])
+}
+
+func TestWriteLotsCSV(t *testing.T) {
+	portfolio := createTestPortfolio()
+	portfolio.Positions["AAPL"].Lots = []models.Lot{
+		{Quantity: decimal.NewFromInt(4), Price: decimal.NewFromFloat(150.0), Timestamp: exportTime},
+		{Quantity: decimal.NewFromInt(6), Price: decimal.NewFromFloat(151.0), Timestamp: exportTime.Add(time.Minute)},
+	}
+	portfolio.TradeHistory = append(portfolio.TradeHistory, &models.Trade{
+		ID:        "trade_2",
+		Symbol:    "TSLA",
+		Side:      models.OrderSideSell,
+		Quantity:  decimal.NewFromInt(3),
+		Price:     decimal.NewFromFloat(210.0),
+		Timestamp: exportTime.Add(time.Hour),
+		ClosedLots: []models.ClosedLot{
+			{Quantity: decimal.NewFromInt(3), OpenPrice: decimal.NewFromFloat(200.0), CostBasis: decimal.NewFromFloat(200.0), OpenedAt: exportTime, RealizedPnL: decimal.NewFromFloat(30.0)},
+		},
+	})
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteLotsCSV(&buf, LotRecords(portfolio)))
+
+	rows := readCSV(t, buf.Bytes())
+	require.Len(t, rows, 3)
+	assert.Equal(t, "AAPL", rows[0]["symbol"])
+	assert.Equal(t, LotStatusOpen, rows[0]["status"])
+	assert.Equal(t, "151", rows[1]["open_price"])
+	assert.Equal(t, "", rows[1]["closed_at"])
+	assert.Equal(t, LotStatusClosed, rows[2]["status"])
+	assert.Equal(t, "trade_2", rows[2]["trade_id"])
+	assert.Equal(t, "210", rows[2]["close_price"])
+	assert.Equal(t, "30", rows[2]["realized_pnl"])
+	assert.Equal(t, "2024-03-04T10:30:00Z", rows[2]["closed_at"])
+}
+
+func TestStatementWriter_WriteStatement(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "export")
+	statement := models.DailyStatement{
+		Date:           time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		StartingEquity: decimal.NewFromInt(100000),
+		EndingEquity:   decimal.NewFromInt(101000),
+		Trades:         2,
+	}
+
+	require.NoError(t, NewStatementWriter(dir, "portfolio_1").WriteStatement(statement))
+
+	data, err := os.ReadFile(filepath.Join(dir, "statement_portfolio_1_2024-03-04.json"))
+	require.NoError(t, err)
+	var written models.DailyStatement
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, 2, written.Trades)
+	assert.True(t, statement.EndingEquity.Equal(written.EndingEquity))
+}
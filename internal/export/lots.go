@@ -0,0 +1,60 @@
+package export
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	LotStatusOpen   = "open"
+	LotStatusClosed = "closed"
+)
+
+type LotRecord struct {
+	Symbol      string          `json:"symbol"`
+	Status      string          `json:"status"`
+	TradeID     string          `json:"trade_id,omitempty"`
+	Quantity    decimal.Decimal `json:"quantity"`
+	OpenPrice   decimal.Decimal `json:"open_price"`
+	CostBasis   decimal.Decimal `json:"cost_basis"`
+	OpenedAt    time.Time       `json:"opened_at"`
+	ClosePrice  decimal.Decimal `json:"close_price"`
+	ClosedAt    time.Time       `json:"closed_at"`
+	RealizedPnL decimal.Decimal `json:"realized_pnl"`
+}
+
+func LotRecords(portfolio *models.Portfolio) []LotRecord {
+	var records []LotRecord
+	for _, position := range portfolio.SortedPositions() {
+		for _, lot := range position.Lots {
+			records = append(records, LotRecord{
+				Symbol:    position.Symbol,
+				Status:    LotStatusOpen,
+				Quantity:  lot.Quantity,
+				OpenPrice: lot.Price,
+				CostBasis: lot.Price,
+				OpenedAt:  lot.Timestamp,
+			})
+		}
+	}
+
+	for _, trade := range portfolio.TradeHistory {
+		for _, lot := range trade.ClosedLots {
+			records = append(records, LotRecord{
+				Symbol:      trade.Symbol,
+				Status:      LotStatusClosed,
+				TradeID:     trade.ID,
+				Quantity:    lot.Quantity,
+				OpenPrice:   lot.OpenPrice,
+				CostBasis:   lot.CostBasis,
+				OpenedAt:    lot.OpenedAt,
+				ClosePrice:  trade.Price,
+				ClosedAt:    trade.Timestamp,
+				RealizedPnL: lot.RealizedPnL,
+			})
+		}
+	}
+	return records
+}
@@ -0,0 +1,27 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+)
+
+type StatementWriter struct {
+	dir         string
+	portfolioID string
+}
+
+func NewStatementWriter(dir, portfolioID string) *StatementWriter {
+	return &StatementWriter{dir: dir, portfolioID: portfolioID}
+}
+
+func (w *StatementWriter) WriteStatement(statement models.DailyStatement) error {
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(w.dir, fmt.Sprintf("statement_%s_%s.json", w.portfolioID, statement.Date.Format("2006-01-02")))
+	return writeFile(path, func(out io.Writer) error { return writeJSON(out, statement) })
+}
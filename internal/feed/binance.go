@@ -0,0 +1,352 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/metrics"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/simulator"
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultBinanceURL = "wss://stream.binance.com:9443"
+
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = 30 * time.Second
+	updateBufferSize  = 1000
+	barCheckInterval  = time.Second
+)
+
+type binanceEnvelope struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type BinanceFeed struct {
+	baseURL    string
+	symbols    []string
+	logger     *zap.Logger
+	updates    chan *models.MarketData
+	coalescer  *simulator.Coalescer
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mu         sync.RWMutex
+	status     Status
+	running    bool
+	cancel     context.CancelFunc
+	done       chan struct{}
+	aggregator *simulator.BarAggregator
+	metrics    *metrics.Metrics
+}
+
+func NewBinanceFeed(baseURL string, symbols []string, logger *zap.Logger) *BinanceFeed {
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		if symbol = strings.ToUpper(strings.TrimSpace(symbol)); symbol != "" {
+			normalized = append(normalized, symbol)
+		}
+	}
+
+	updates := make(chan *models.MarketData, updateBufferSize)
+	return &BinanceFeed{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		symbols:    normalized,
+		logger:     logger,
+		updates:    updates,
+		coalescer:  simulator.NewCoalescer(updates),
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		status:     Status{State: StateDisconnected},
+	}
+}
+
+func (f *BinanceFeed) SetBackoff(min, max time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.minBackoff = min
+	f.maxBackoff = max
+}
+
+func (f *BinanceFeed) SetBarInterval(interval time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if interval <= 0 {
+		f.aggregator = nil
+		return
+	}
+	f.aggregator = simulator.NewBarAggregator(interval)
+}
+
+func (f *BinanceFeed) SetMetrics(m *metrics.Metrics) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.metrics = m
+}
+
+func (f *BinanceFeed) Start(ctx context.Context) error {
+	if len(f.symbols) == 0 {
+		return ErrNoSymbols
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.running {
+		return ErrFeedRunning
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	f.running = true
+	f.cancel = cancel
+	f.done = make(chan struct{})
+
+	go f.run(ctx, f.done)
+	if f.aggregator != nil {
+		go f.advanceBars(ctx, f.aggregator)
+	}
+
+	f.logger.Info("Binance feed started", zap.Strings("symbols", f.symbols))
+	return nil
+}
+
+func (f *BinanceFeed) Stop() {
+	f.mu.Lock()
+	if !f.running {
+		f.mu.Unlock()
+		return
+	}
+	f.running = false
+	cancel, done := f.cancel, f.done
+	f.mu.Unlock()
+
+	cancel()
+	<-done
+	f.logger.Info("Binance feed stopped")
+}
+
+func (f *BinanceFeed) Updates() <-chan *models.MarketData {
+	return f.updates
+}
+
+func (f *BinanceFeed) Symbols() []string {
+	symbols := make([]string, len(f.symbols))
+	copy(symbols, f.symbols)
+	return symbols
+}
+
+func (f *BinanceFeed) Status() Status {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.status
+}
+
+func (f *BinanceFeed) streamURL() string {
+	streams := make([]string, len(f.symbols))
+	for i, symbol := range f.symbols {
+		streams[i] = strings.ToLower(symbol) + "@trade"
+	}
+	return fmt.Sprintf("%s/stream?streams=%s", f.baseURL, strings.Join(streams, "/"))
+}
+
+func (f *BinanceFeed) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	defer f.setState(StateDisconnected, nil)
+
+	f.mu.RLock()
+	backoff, maxBackoff := f.minBackoff, f.maxBackoff
+	f.mu.RUnlock()
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			f.mu.Lock()
+			f.status.Reconnects++
+			f.mu.Unlock()
+		}
+
+		f.setState(StateConnecting, nil)
+		received, err := f.connect(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if received {
+			f.mu.RLock()
+			backoff = f.minBackoff
+			f.mu.RUnlock()
+		} else if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+
+		f.setState(StateDisconnected, err)
+		f.logger.Warn("Binance feed disconnected, reconnecting", zap.Error(err), zap.Duration("backoff", backoff))
+	}
+}
+
+func (f *BinanceFeed) connect(ctx context.Context) (bool, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, f.streamURL(), nil)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	closed := make(chan struct{})
+	defer close(closed)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-closed:
+		}
+	}()
+
+	f.setState(StateConnected, nil)
+	f.logger.Info("Binance feed connected", zap.String("url", f.streamURL()))
+
+	received := false
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		marketData, err := parseBinanceMessage(message)
+		if err != nil {
+			f.logger.Warn("Failed to parse Binance message", zap.Error(err))
+			continue
+		}
+		if marketData == nil {
+			continue
+		}
+
+		f.mu.Lock()
+		f.status.LastMessage = time.Now()
+		f.mu.Unlock()
+		f.handle(marketData)
+	}
+}
+
+func (f *BinanceFeed) handle(tick *models.MarketData) {
+	f.mu.RLock()
+	aggregator, m := f.aggregator, f.metrics
+	f.mu.RUnlock()
+
+	m.ObservePrice(tick.Symbol, tick.Price)
+	f.publish(tick)
+	if aggregator != nil {
+		if bar := aggregator.Add(tick); bar != nil {
+			f.publish(bar)
+		}
+	}
+}
+
+func (f *BinanceFeed) advanceBars(ctx context.Context, aggregator *simulator.BarAggregator) {
+	ticker := time.NewTicker(barCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			for _, bar := range aggregator.Advance(now) {
+				f.publish(bar)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (f *BinanceFeed) publish(marketData *models.MarketData) {
+	delivered, dropped := f.coalescer.Publish(marketData)
+	if delivered {
+		return
+	}
+	f.mu.RLock()
+	m := f.metrics
+	f.mu.RUnlock()
+	for i := 0; i < dropped; i++ {
+		m.MarketDataDropped()
+	}
+	f.logger.Debug("Update channel full, coalescing market data",
+		zap.String("symbol", marketData.Symbol),
+		zap.String("kind", string(marketData.Kind)),
+		zap.Int("dropped", dropped))
+}
+
+func (f *BinanceFeed) setState(state ConnectionState, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status.State = state
+	if err != nil {
+		f.status.LastError = err.Error()
+	}
+}
+
+func parseBinanceMessage(message []byte) (*models.MarketData, error) {
+	var envelope binanceEnvelope
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return nil, err
+	}
+	if len(envelope.Data) == 0 {
+		return nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(envelope.Data, &fields); err != nil {
+		return nil, err
+	}
+
+	var eventType string
+	if err := decodeField(fields, "e", &eventType); err != nil || eventType != "trade" {
+		return nil, err
+	}
+
+	var symbol string
+	var price, quantity decimal.Decimal
+	var tradeTime int64
+	for key, target := range map[string]interface{}{"s": &symbol, "p": &price, "q": &quantity, "T": &tradeTime} {
+		if err := decodeField(fields, key, target); err != nil {
+			return nil, err
+		}
+	}
+	if symbol == "" || !price.IsPositive() {
+		return nil, fmt.Errorf("invalid trade for %q at price %s", symbol, price)
+	}
+
+	return &models.MarketData{
+		Symbol:    symbol,
+		Kind:      models.MarketDataKindTick,
+		Price:     price,
+		Volume:    quantity.Ceil().IntPart(),
+		High:      price,
+		Low:       price,
+		Open:      price,
+		Close:     price,
+		Timestamp: time.UnixMilli(tradeTime),
+	}, nil
+}
+
+func decodeField(fields map[string]json.RawMessage, key string, target interface{}) error {
+	raw, exists := fields[key]
+	if !exists {
+		return nil
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("field %q: %w", key, err)
+	}
+	return nil
+}
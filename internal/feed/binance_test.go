@@ -0,0 +1,173 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newMockBinanceServer(t *testing.T, handle func(connection int, conn *websocket.Conn, r *http.Request)) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(int(atomic.AddInt32(&connections, 1)), conn, r)
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func waitForOpen(conn *websocket.Conn) {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	conn.ReadMessage()
+}
+
+func receiveUpdate(t *testing.T, feed *BinanceFeed) *models.MarketData {
+	t.Helper()
+	select {
+	case update := <-feed.Updates():
+		return update
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for market data")
+		return nil
+	}
+}
+
+func tradeMessage(symbol, price, quantity string, tradeTime int64) string {
+	return `{"stream":"` + strings.ToLower(symbol) + `@trade","data":{"e":"trade","E":1,"s":"` + symbol + `","t":1,"p":"` + price + `","q":"` + quantity + `","T":` + decimal.NewFromInt(tradeTime).String() + `,"m":true}}`
+}
+
+func TestBinanceFeed_TranslatesTrades(t *testing.T) {
+	streams := make(chan string, 1)
+	url := newMockBinanceServer(t, func(connection int, conn *websocket.Conn, r *http.Request) {
+		streams <- r.URL.Query().Get("streams")
+		conn.WriteMessage(websocket.TextMessage, []byte(tradeMessage("BTCUSDT", "65000.50", "0.25", 1700000000000)))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade","s":"BTCUSDT","p":"1"}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`not json`))
+		conn.WriteMessage(websocket.TextMessage, []byte(tradeMessage("ETHUSDT", "3200", "3", 1700000001000)))
+		waitForOpen(conn)
+	})
+
+	feed := NewBinanceFeed(url, []string{"btcusdt", " ETHUSDT "}, zap.NewNop())
+	require.NoError(t, feed.Start(context.Background()))
+	defer feed.Stop()
+
+	assert.Equal(t, "btcusdt@trade/ethusdt@trade", <-streams)
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, feed.Symbols())
+
+	btc := receiveUpdate(t, feed)
+	assert.Equal(t, "BTCUSDT", btc.Symbol)
+	assert.Equal(t, models.MarketDataKindTick, btc.Kind)
+	assert.True(t, btc.Price.Equal(decimal.RequireFromString("65000.50")))
+	assert.Equal(t, int64(1), btc.Volume)
+	assert.Equal(t, time.UnixMilli(1700000000000), btc.Timestamp)
+
+	eth := receiveUpdate(t, feed)
+	assert.Equal(t, "ETHUSDT", eth.Symbol)
+	assert.True(t, eth.Price.Equal(decimal.NewFromInt(3200)))
+	assert.Equal(t, int64(3), eth.Volume)
+
+	status := feed.Status()
+	assert.Equal(t, StateConnected, status.State)
+	assert.False(t, status.LastMessage.IsZero())
+}
+
+func TestBinanceFeed_ReconnectsAfterDisconnect(t *testing.T) {
+	url := newMockBinanceServer(t, func(connection int, conn *websocket.Conn, r *http.Request) {
+		if connection == 1 {
+			conn.WriteMessage(websocket.TextMessage, []byte(tradeMessage("BTCUSDT", "65000", "1", 1700000000000)))
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(tradeMessage("BTCUSDT", "65100", "1", 1700000001000)))
+		waitForOpen(conn)
+	})
+
+	feed := NewBinanceFeed(url, []string{"BTCUSDT"}, zap.NewNop())
+	feed.SetBackoff(10*time.Millisecond, 50*time.Millisecond)
+	require.NoError(t, feed.Start(context.Background()))
+	defer feed.Stop()
+
+	assert.True(t, receiveUpdate(t, feed).Price.Equal(decimal.NewFromInt(65000)))
+	assert.True(t, receiveUpdate(t, feed).Price.Equal(decimal.NewFromInt(65100)))
+
+	status := feed.Status()
+	assert.Equal(t, StateConnected, status.State)
+	assert.Equal(t, 1, status.Reconnects)
+	assert.NotEmpty(t, status.LastError)
+}
+
+func TestBinanceFeed_RetriesUntilServerAvailable(t *testing.T) {
+	var available atomic.Bool
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte(tradeMessage("BTCUSDT", "65000", "1", 1700000000000)))
+		waitForOpen(conn)
+	}))
+	defer server.Close()
+
+	feed := NewBinanceFeed("ws"+strings.TrimPrefix(server.URL, "http"), []string{"BTCUSDT"}, zap.NewNop())
+	feed.SetBackoff(5*time.Millisecond, 20*time.Millisecond)
+	require.NoError(t, feed.Start(context.Background()))
+	defer feed.Stop()
+
+	require.Eventually(t, func() bool { return feed.Status().Reconnects >= 2 }, 2*time.Second, 5*time.Millisecond)
+	assert.NotEqual(t, StateConnected, feed.Status().State)
+
+	available.Store(true)
+	assert.Equal(t, "BTCUSDT", receiveUpdate(t, feed).Symbol)
+	assert.Equal(t, StateConnected, feed.Status().State)
+}
+
+func TestBinanceFeed_StopDisconnects(t *testing.T) {
+	url := newMockBinanceServer(t, func(connection int, conn *websocket.Conn, r *http.Request) {
+		waitForOpen(conn)
+	})
+
+	feed := NewBinanceFeed(url, []string{"BTCUSDT"}, zap.NewNop())
+	require.NoError(t, feed.Start(context.Background()))
+	assert.ErrorIs(t, feed.Start(context.Background()), ErrFeedRunning)
+	require.Eventually(t, func() bool { return feed.Status().State == StateConnected }, 2*time.Second, 5*time.Millisecond)
+
+	feed.Stop()
+	assert.Equal(t, StateDisconnected, feed.Status().State)
+	feed.Stop()
+}
+
+func TestBinanceFeed_StartWithoutSymbols(t *testing.T) {
+	feed := NewBinanceFeed(DefaultBinanceURL, []string{" "}, zap.NewNop())
+	assert.ErrorIs(t, feed.Start(context.Background()), ErrNoSymbols)
+}
+
+func TestParseBinanceMessage_RejectsInvalidPrice(t *testing.T) {
+	_, err := parseBinanceMessage([]byte(tradeMessage("BTCUSDT", "0", "1", 1700000000000)))
+	assert.Error(t, err)
+
+	marketData, err := parseBinanceMessage([]byte(`{"result":null,"id":1}`))
+	assert.NoError(t, err)
+	assert.Nil(t, marketData)
+}
@@ -0,0 +1,9 @@
+package feed
+
+import "errors"
+
+var (
+	ErrFeedRunning    = errors.New("feed already running")
+	ErrNoSymbols      = errors.New("feed has no symbols")
+	ErrEmptyRecording = errors.New("recording has no market data")
+)
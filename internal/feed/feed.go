@@ -0,0 +1,42 @@
+package feed
+
+import (
+	"context"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/simulator"
+)
+
+const (
+	TypeSimulator = "sim"
+	TypeBinance   = "binance"
+)
+
+type MarketDataFeed interface {
+	Start(ctx context.Context) error
+	Stop()
+	Updates() <-chan *models.MarketData
+	Symbols() []string
+}
+
+var _ MarketDataFeed = (*simulator.MarketSimulator)(nil)
+
+type ConnectionState string
+
+const (
+	StateDisconnected ConnectionState = "disconnected"
+	StateConnecting   ConnectionState = "connecting"
+	StateConnected    ConnectionState = "connected"
+)
+
+type Status struct {
+	State       ConnectionState `json:"state"`
+	Reconnects  int             `json:"reconnects"`
+	LastMessage time.Time       `json:"last_message"`
+	LastError   string          `json:"last_error,omitempty"`
+}
+
+type StatusReporter interface {
+	Status() Status
+}
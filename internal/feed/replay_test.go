@@ -0,0 +1,134 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/backtest"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/export"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/simulator"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var recordingStart = time.Date(2024, 1, 2, 14, 30, 0, 0, time.UTC)
+
+func createRecordingEngine(t *testing.T) *engine.TradingEngine {
+	t.Helper()
+	tradingEngine := engine.NewTradingEngine(decimal.NewFromInt(100000), zap.NewNop())
+	tradingEngine.SetSlippageModel(execution.NewSeededSlippage(42))
+	tradingEngine.SetIntervals(engine.Intervals{})
+	tradingEngine.AddStrategy(strategies.NewMovingAverageStrategy(&models.StrategyConfig{
+		ID:                "replayed",
+		Name:              "Replayed",
+		MaxPositionSize:   decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk:  decimal.NewFromFloat(0.15),
+		StopLossPercent:   decimal.NewFromFloat(0.05),
+		TakeProfitPercent: decimal.NewFromFloat(0.1),
+		MinOrderSize:      decimal.NewFromFloat(1000.0),
+		MaxOrderSize:      decimal.NewFromFloat(10000.0),
+		MaxOrdersPerDay:   100,
+		CommissionRate:    decimal.NewFromFloat(0.001),
+		SlippageTolerance: decimal.NewFromFloat(0.002),
+		Enabled:           true,
+	}))
+	return tradingEngine
+}
+
+func runRecordingEngine(t *testing.T, marketFeed MarketDataFeed, warmUp func(symbol string) []*models.MarketData) string {
+	t.Helper()
+	tradingEngine := createRecordingEngine(t)
+	for _, symbol := range marketFeed.Symbols() {
+		require.NoError(t, tradingEngine.SeedMarketHistory(symbol, warmUp(symbol)))
+	}
+
+	require.NoError(t, marketFeed.Start(context.Background()))
+	portfolio, err := backtest.NewRunner(tradingEngine, zap.NewNop()).Run(context.Background(), marketFeed.Updates())
+	require.NoError(t, err)
+	marketFeed.Stop()
+	tradingEngine.Stop()
+
+	var trades bytes.Buffer
+	require.NoError(t, export.WriteTradesCSV(&trades, portfolio.TradeHistory))
+	return trades.String()
+}
+
+func recordSeededRun(t *testing.T, path string, ticks int) string {
+	t.Helper()
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	fake := clock.NewFake(recordingStart)
+	sim := simulator.NewMarketSimulator(zap.NewNop(), simulator.WithSeed(42), simulator.WithClock(fake), simulator.WithRecorder(file))
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.5))
+	sim.AddSymbol("MSFT", decimal.NewFromFloat(300.0), decimal.NewFromFloat(0.8))
+	require.NoError(t, sim.ScheduleNews(models.NewsEvent{Symbol: "AAPL", Headline: "Guidance raised", Sentiment: decimal.NewFromFloat(0.8), Severity: models.NewsSeverityHigh, Timestamp: recordingStart.Add(30 * time.Second)}))
+
+	return runRecordingEngine(t, simulator.NewSteppedFeed(sim, fake, ticks), func(symbol string) []*models.MarketData {
+		history, err := sim.PreloadHistory(symbol, 30)
+		require.NoError(t, err)
+		return history
+	})
+}
+
+func TestReplayFeed_ReproducesRecordedTrades(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.jsonl")
+	recorded := recordSeededRun(t, path, 120)
+
+	replayFeed, err := NewReplayFeed(path, zap.NewNop())
+	require.NoError(t, err)
+	replayed := runRecordingEngine(t, replayFeed, replayFeed.WarmUp)
+
+	assert.Greater(t, bytes.Count([]byte(recorded), []byte("\n")), 1, "the seeded run should trade")
+	assert.Equal(t, recorded, replayed)
+}
+
+func TestReplayFeed_ReplaysSequenceWithTimeShift(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.jsonl")
+	recordSeededRun(t, path, 10)
+
+	original, err := NewReplayFeed(path, zap.NewNop())
+	require.NoError(t, err)
+	shifted, err := NewReplayFeed(path, zap.NewNop())
+	require.NoError(t, err)
+	shifted.SetTimeShift(time.Hour)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, shifted.Symbols())
+	require.Len(t, shifted.WarmUp("AAPL"), 30)
+	assert.Equal(t, original.WarmUp("AAPL")[0].Timestamp.Add(time.Hour), shifted.WarmUp("AAPL")[0].Timestamp)
+
+	require.NoError(t, original.Start(context.Background()))
+	require.NoError(t, shifted.Start(context.Background()))
+	assert.ErrorIs(t, shifted.Start(context.Background()), ErrFeedRunning)
+	count := 0
+	for data := range original.Updates() {
+		replayed := <-shifted.Updates()
+		assert.Equal(t, data.Symbol, replayed.Symbol)
+		assert.True(t, data.Price.Equal(replayed.Price))
+		assert.Equal(t, data.Timestamp.Add(time.Hour), replayed.Timestamp)
+		count++
+	}
+	_, open := <-shifted.Updates()
+	assert.False(t, open)
+	assert.Equal(t, 20, count)
+}
+
+func TestNewReplayFeed_RejectsEmptyRecording(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.jsonl")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+
+	_, err := NewReplayFeed(path, zap.NewNop())
+
+	assert.ErrorIs(t, err, ErrEmptyRecording)
+}
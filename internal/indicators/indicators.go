@@ -0,0 +1,69 @@
+package indicators
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+func High(bar *models.MarketData) decimal.Decimal {
+	if bar.High.IsPositive() {
+		return bar.High
+	}
+	return Close(bar)
+}
+
+func Low(bar *models.MarketData) decimal.Decimal {
+	if bar.Low.IsPositive() {
+		return bar.Low
+	}
+	return Close(bar)
+}
+
+func Close(bar *models.MarketData) decimal.Decimal {
+	if bar.Close.IsPositive() {
+		return bar.Close
+	}
+	return bar.Price
+}
+
+func Donchian(bars []*models.MarketData, period int) (decimal.Decimal, decimal.Decimal, bool) {
+	if period <= 0 || len(bars) < period {
+		return decimal.Zero, decimal.Zero, false
+	}
+
+	window := bars[len(bars)-period:]
+	high, low := High(window[0]), Low(window[0])
+	for _, bar := range window[1:] {
+		high = decimal.Max(high, High(bar))
+		low = decimal.Min(low, Low(bar))
+	}
+	return high, low, true
+}
+
+func TrueRange(bar, previous *models.MarketData) decimal.Decimal {
+	high, low := High(bar), Low(bar)
+	rangeValue := high.Sub(low)
+	if previous == nil {
+		return rangeValue
+	}
+
+	previousClose := Close(previous)
+	return decimal.Max(rangeValue, high.Sub(previousClose).Abs(), low.Sub(previousClose).Abs())
+}
+
+func ATR(bars []*models.MarketData, period int) (decimal.Decimal, bool) {
+	if period <= 0 || len(bars) < period+1 {
+		return decimal.Zero, false
+	}
+
+	sum := decimal.Zero
+	for i := 1; i <= period; i++ {
+		sum = sum.Add(TrueRange(bars[i], bars[i-1]))
+	}
+	periods := decimal.NewFromInt(int64(period))
+	atr := sum.Div(periods)
+	for i := period + 1; i < len(bars); i++ {
+		atr = wilder(atr, TrueRange(bars[i], bars[i-1]), periods)
+	}
+	return atr, true
+}
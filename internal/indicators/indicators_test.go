@@ -0,0 +1,68 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bar(high, low, close float64) *models.MarketData {
+	return &models.MarketData{
+		Price: decimal.NewFromFloat(close),
+		High:  decimal.NewFromFloat(high),
+		Low:   decimal.NewFromFloat(low),
+		Close: decimal.NewFromFloat(close),
+	}
+}
+
+func TestDonchian(t *testing.T) {
+	bars := []*models.MarketData{bar(12, 8, 10), bar(15, 9, 14), bar(13, 11, 12), bar(14, 10, 13)}
+
+	high, low, ok := Donchian(bars, 3)
+	require.True(t, ok)
+	assert.True(t, high.Equal(decimal.NewFromInt(15)))
+	assert.True(t, low.Equal(decimal.NewFromInt(9)))
+
+	_, _, ok = Donchian(bars, 5)
+	assert.False(t, ok)
+}
+
+func TestDonchian_FallsBackToPrice(t *testing.T) {
+	bars := []*models.MarketData{{Price: decimal.NewFromInt(10)}, {Price: decimal.NewFromInt(12)}, {Price: decimal.NewFromInt(11)}}
+
+	high, low, ok := Donchian(bars, 3)
+	require.True(t, ok)
+	assert.True(t, high.Equal(decimal.NewFromInt(12)))
+	assert.True(t, low.Equal(decimal.NewFromInt(10)))
+}
+
+func TestTrueRange_UsesPreviousClose(t *testing.T) {
+	assert.True(t, TrueRange(bar(12, 10, 11), nil).Equal(decimal.NewFromInt(2)))
+	assert.True(t, TrueRange(bar(12, 10, 11), bar(9, 7, 8)).Equal(decimal.NewFromInt(4)))
+	assert.True(t, TrueRange(bar(12, 10, 11), bar(16, 14, 15)).Equal(decimal.NewFromInt(5)))
+}
+
+func TestATR_WilderSmoothing(t *testing.T) {
+	bars := []*models.MarketData{
+		bar(10, 8, 9),
+		bar(11, 9, 10),
+		bar(12, 9, 11),
+		bar(14, 11, 13),
+		bar(13, 9, 10),
+	}
+
+	atr, ok := ATR(bars[:4], 3)
+	require.True(t, ok)
+	assert.InDelta(t, 8.0/3, atr.InexactFloat64(), 1e-9)
+
+	atr, ok = ATR(bars, 3)
+	require.True(t, ok)
+	expected := (8.0/3*2 + 4) / 3
+	assert.InDelta(t, expected, atr.InexactFloat64(), 1e-9)
+
+	_, ok = ATR(bars[:3], 3)
+	assert.False(t, ok)
+}
@@ -0,0 +1,253 @@
+package indicators
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type RollingSMA struct {
+	period int
+	window []decimal.Decimal
+	next   int
+	count  int
+	sum    decimal.Decimal
+}
+
+func NewRollingSMA(period int) *RollingSMA {
+	return &RollingSMA{period: period, window: make([]decimal.Decimal, max(period, 0))}
+}
+
+func (s *RollingSMA) Add(value decimal.Decimal) {
+	if s.period <= 0 {
+		return
+	}
+	if s.count == s.period {
+		s.sum = s.sum.Sub(s.window[s.next])
+	} else {
+		s.count++
+	}
+	s.window[s.next] = value
+	s.sum = s.sum.Add(value)
+	s.next = (s.next + 1) % s.period
+}
+
+func (s *RollingSMA) Period() int {
+	return s.period
+}
+
+func (s *RollingSMA) Value() (decimal.Decimal, bool) {
+	if s.period <= 0 || s.count < s.period {
+		return decimal.Zero, false
+	}
+	return s.sum.Div(decimal.NewFromInt(int64(s.period))), true
+}
+
+type RollingEMA struct {
+	period int
+	alpha  decimal.Decimal
+	count  int
+	value  decimal.Decimal
+}
+
+func NewRollingEMA(period int) *RollingEMA {
+	return &RollingEMA{period: period, alpha: decimal.NewFromInt(2).Div(decimal.NewFromInt(int64(period + 1)))}
+}
+
+func (e *RollingEMA) Add(value decimal.Decimal) {
+	if e.period <= 0 {
+		return
+	}
+	e.count++
+	switch {
+	case e.count < e.period:
+		e.value = e.value.Add(value)
+	case e.count == e.period:
+		e.value = e.value.Add(value).Div(decimal.NewFromInt(int64(e.period)))
+	default:
+		e.value = value.Sub(e.value).Mul(e.alpha).Add(e.value)
+	}
+}
+
+func (e *RollingEMA) Value() (decimal.Decimal, bool) {
+	if e.period <= 0 || e.count < e.period {
+		return decimal.Zero, false
+	}
+	return e.value, true
+}
+
+type RollingRSI struct {
+	period      int
+	previous    decimal.Decimal
+	prices      int
+	averageGain decimal.Decimal
+	averageLoss decimal.Decimal
+}
+
+func NewRollingRSI(period int) *RollingRSI {
+	return &RollingRSI{period: period}
+}
+
+func (r *RollingRSI) Add(price decimal.Decimal) {
+	if r.period <= 0 {
+		return
+	}
+	r.prices++
+	previous := r.previous
+	r.previous = price
+	if r.prices == 1 {
+		return
+	}
+
+	gain, loss := priceChange(previous, price)
+	periods := decimal.NewFromInt(int64(r.period))
+	switch changes := r.prices - 1; {
+	case changes < r.period:
+		r.averageGain = r.averageGain.Add(gain)
+		r.averageLoss = r.averageLoss.Add(loss)
+	case changes == r.period:
+		r.averageGain = r.averageGain.Add(gain).Div(periods)
+		r.averageLoss = r.averageLoss.Add(loss).Div(periods)
+	default:
+		r.averageGain = wilder(r.averageGain, gain, periods)
+		r.averageLoss = wilder(r.averageLoss, loss, periods)
+	}
+}
+
+func (r *RollingRSI) Value() (decimal.Decimal, bool) {
+	if r.period <= 0 || r.prices <= r.period {
+		return decimal.Zero, false
+	}
+	return relativeStrength(r.averageGain, r.averageLoss)
+}
+
+type RollingMACD struct {
+	fast   *RollingEMA
+	slow   *RollingEMA
+	signal *RollingEMA
+	macd   decimal.Decimal
+}
+
+func NewRollingMACD(fastPeriod, slowPeriod, signalPeriod int) *RollingMACD {
+	return &RollingMACD{
+		fast:   NewRollingEMA(fastPeriod),
+		slow:   NewRollingEMA(slowPeriod),
+		signal: NewRollingEMA(signalPeriod),
+	}
+}
+
+func (m *RollingMACD) Add(price decimal.Decimal) {
+	if m.fast.period > m.slow.period {
+		return
+	}
+	m.fast.Add(price)
+	m.slow.Add(price)
+
+	slow, ok := m.slow.Value()
+	if !ok {
+		return
+	}
+	fast, _ := m.fast.Value()
+	m.macd = fast.Sub(slow)
+	m.signal.Add(m.macd)
+}
+
+func (m *RollingMACD) Value() (decimal.Decimal, decimal.Decimal, bool) {
+	signal, ok := m.signal.Value()
+	if !ok {
+		return decimal.Zero, decimal.Zero, false
+	}
+	return m.macd, signal, true
+}
+
+type RollingStdDev struct {
+	mean    *RollingSMA
+	squares decimal.Decimal
+}
+
+func NewRollingStdDev(period int) *RollingStdDev {
+	return &RollingStdDev{mean: NewRollingSMA(period)}
+}
+
+func (s *RollingStdDev) Add(value decimal.Decimal) {
+	if s.mean.period <= 0 {
+		return
+	}
+	if s.mean.count == s.mean.period {
+		evicted := s.mean.window[s.mean.next]
+		s.squares = s.squares.Sub(evicted.Mul(evicted))
+	}
+	s.squares = s.squares.Add(value.Mul(value))
+	s.mean.Add(value)
+}
+
+func (s *RollingStdDev) Value() (decimal.Decimal, bool) {
+	mean, ok := s.mean.Value()
+	if !ok {
+		return decimal.Zero, false
+	}
+	variance := s.squares.Div(decimal.NewFromInt(int64(s.mean.period))).Sub(mean.Mul(mean))
+	return sqrt(variance), true
+}
+
+type RollingBollinger struct {
+	deviation *RollingStdDev
+	width     decimal.Decimal
+}
+
+func NewRollingBollinger(period int, width decimal.Decimal) *RollingBollinger {
+	return &RollingBollinger{deviation: NewRollingStdDev(period), width: width}
+}
+
+func (b *RollingBollinger) Add(value decimal.Decimal) {
+	b.deviation.Add(value)
+}
+
+func (b *RollingBollinger) Value() (Bands, bool) {
+	middle, ok := b.deviation.mean.Value()
+	if !ok {
+		return Bands{}, false
+	}
+	deviation, _ := b.deviation.Value()
+	return bands(middle, deviation, b.width), true
+}
+
+type RollingATR struct {
+	period   int
+	previous *models.MarketData
+	ranges   int
+	value    decimal.Decimal
+}
+
+func NewRollingATR(period int) *RollingATR {
+	return &RollingATR{period: period}
+}
+
+func (a *RollingATR) Add(bar *models.MarketData) {
+	if a.period <= 0 {
+		return
+	}
+	previous := a.previous
+	a.previous = bar
+	if previous == nil {
+		return
+	}
+
+	trueRange := TrueRange(bar, previous)
+	periods := decimal.NewFromInt(int64(a.period))
+	a.ranges++
+	switch {
+	case a.ranges < a.period:
+		a.value = a.value.Add(trueRange)
+	case a.ranges == a.period:
+		a.value = a.value.Add(trueRange).Div(periods)
+	default:
+		a.value = wilder(a.value, trueRange, periods)
+	}
+}
+
+func (a *RollingATR) Value() (decimal.Decimal, bool) {
+	if a.period <= 0 || a.ranges < a.period {
+		return decimal.Zero, false
+	}
+	return a.value, true
+}
@@ -0,0 +1,202 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func feed(add func(decimal.Decimal), values ...int64) {
+	for _, value := range values {
+		add(decimal.NewFromInt(value))
+	}
+}
+
+func TestRollingSMA_SlidesWindow(t *testing.T) {
+	sma := NewRollingSMA(3)
+	feed(sma.Add, 1, 2)
+	_, ok := sma.Value()
+	assert.False(t, ok)
+
+	feed(sma.Add, 3)
+	value, ok := sma.Value()
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromInt(2).Equal(value))
+
+	feed(sma.Add, 10)
+	value, _ = sma.Value()
+	assert.True(t, decimal.NewFromInt(5).Equal(value))
+}
+
+func TestRollingEMA_SeedsWithSMA(t *testing.T) {
+	ema := NewRollingEMA(3)
+	feed(ema.Add, 1, 2)
+	_, ok := ema.Value()
+	assert.False(t, ok)
+
+	feed(ema.Add, 3)
+	value, ok := ema.Value()
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromInt(2).Equal(value))
+
+	feed(ema.Add, 4, 5)
+	value, _ = ema.Value()
+	assert.True(t, decimal.NewFromInt(4).Equal(value))
+}
+
+func TestRollingRSI_WilderSmoothing(t *testing.T) {
+	rsi := NewRollingRSI(2)
+	feed(rsi.Add, 10, 12)
+	_, ok := rsi.Value()
+	assert.False(t, ok)
+
+	feed(rsi.Add, 11)
+	value, ok := rsi.Value()
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromFloat(66.6667).Equal(value.Round(4)))
+
+	feed(rsi.Add, 13)
+	value, _ = rsi.Value()
+	assert.True(t, decimal.NewFromFloat(85.7143).Equal(value.Round(4)))
+}
+
+func TestRollingRSI_OnlyGainsIsHundred(t *testing.T) {
+	rsi := NewRollingRSI(2)
+	feed(rsi.Add, 1, 2, 3)
+	value, ok := rsi.Value()
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromInt(100).Equal(value))
+
+	flat := NewRollingRSI(2)
+	feed(flat.Add, 5, 5, 5)
+	_, ok = flat.Value()
+	assert.False(t, ok)
+}
+
+func TestRollingMACD_WarmsUpToBatchValue(t *testing.T) {
+	macd := NewRollingMACD(2, 3, 2)
+	feed(macd.Add, 1, 2, 3)
+	_, _, ok := macd.Value()
+	assert.False(t, ok)
+
+	feed(macd.Add, 4, 5, 8)
+	value, signal, ok := macd.Value()
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromFloat(0.8333).Equal(value.Round(4)))
+	assert.True(t, decimal.NewFromFloat(0.7222).Equal(signal.Round(4)))
+}
+
+func TestRollingStdDev_SlidesWindow(t *testing.T) {
+	deviation := NewRollingStdDev(3)
+	feed(deviation.Add, 2, 4)
+	_, ok := deviation.Value()
+	assert.False(t, ok)
+
+	feed(deviation.Add, 4, 4)
+	value, ok := deviation.Value()
+	require.True(t, ok)
+	assert.True(t, value.IsZero())
+
+	feed(deviation.Add, 5, 5, 7, 9)
+	value, _ = deviation.Value()
+	assert.True(t, decimal.NewFromFloat(1.63299).Equal(value.Round(5)))
+}
+
+func TestRollingBollinger_Bands(t *testing.T) {
+	bollinger := NewRollingBollinger(8, decimal.NewFromInt(2))
+	feed(bollinger.Add, 2, 4, 4, 4, 5, 5, 7)
+	_, ok := bollinger.Value()
+	assert.False(t, ok)
+
+	feed(bollinger.Add, 9)
+	bands, ok := bollinger.Value()
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromInt(9).Equal(bands.Upper))
+	assert.True(t, decimal.NewFromInt(5).Equal(bands.Middle))
+	assert.True(t, decimal.NewFromInt(1).Equal(bands.Lower))
+}
+
+func TestRollingATR_WilderSmoothing(t *testing.T) {
+	atr := NewRollingATR(3)
+	for _, data := range []*models.MarketData{bar(10, 8, 9), bar(11, 9, 10), bar(12, 9, 11)} {
+		atr.Add(data)
+	}
+	_, ok := atr.Value()
+	assert.False(t, ok)
+
+	atr.Add(bar(14, 11, 13))
+	value, ok := atr.Value()
+	require.True(t, ok)
+	assert.InDelta(t, 8.0/3, value.InexactFloat64(), 1e-9)
+
+	atr.Add(bar(13, 9, 10))
+	value, _ = atr.Value()
+	assert.InDelta(t, (8.0/3*2+4)/3, value.InexactFloat64(), 1e-9)
+}
+
+func syntheticBars(count int) []*models.MarketData {
+	bars := make([]*models.MarketData, count)
+	for i := range bars {
+		price := 100 + 10*math.Sin(float64(i)/15) + 3*math.Sin(float64(i)/4) + float64(i%7)/10
+		spread := 0.5 + float64(i%5)/10
+		bars[i] = bar(price+spread, price-spread, price)
+		bars[i].Close = bars[i].Close.Round(2)
+		bars[i].Price = bars[i].Close
+	}
+	return bars
+}
+
+func TestRollingIndicators_MatchBatch(t *testing.T) {
+	bars := syntheticBars(300)
+	sma := NewRollingSMA(20)
+	ema := NewRollingEMA(12)
+	rsi := NewRollingRSI(14)
+	macd := NewRollingMACD(12, 26, 9)
+	deviation := NewRollingStdDev(20)
+	bollinger := NewRollingBollinger(20, decimal.NewFromInt(2))
+	atr := NewRollingATR(14)
+	prices := make([]decimal.Decimal, 0, len(bars))
+
+	for i, data := range bars {
+		price := data.Close
+		prices = append(prices, price)
+		for _, add := range []func(decimal.Decimal){sma.Add, ema.Add, rsi.Add, macd.Add, deviation.Add, bollinger.Add} {
+			add(price)
+		}
+		atr.Add(data)
+
+		assertSame(t, i, "sma", sma.Value)(SMA(prices, 20))
+		assertSame(t, i, "ema", ema.Value)(EMA(prices, 12))
+		assertSame(t, i, "rsi", rsi.Value)(RSI(prices, 14))
+		assertSame(t, i, "atr", atr.Value)(ATR(bars[:i+1], 14))
+
+		expectedMACD, expectedSignal, expectedOK := MACD(prices, 12, 26, 9)
+		value, signal, ok := macd.Value()
+		require.Equal(t, expectedOK, ok, "tick %d macd", i)
+		assert.True(t, expectedMACD.Equal(value), "tick %d macd", i)
+		assert.True(t, expectedSignal.Equal(signal), "tick %d signal", i)
+
+		expectedDeviation, expectedOK := StdDev(prices, 20)
+		value, ok = deviation.Value()
+		require.Equal(t, expectedOK, ok, "tick %d stddev", i)
+		assert.InDelta(t, expectedDeviation.InexactFloat64(), value.InexactFloat64(), 1e-9, "tick %d stddev", i)
+
+		expectedBands, expectedOK := Bollinger(prices, 20, decimal.NewFromInt(2))
+		bands, ok := bollinger.Value()
+		require.Equal(t, expectedOK, ok, "tick %d bollinger", i)
+		assert.True(t, expectedBands.Middle.Equal(bands.Middle), "tick %d bollinger", i)
+		assert.InDelta(t, expectedBands.Upper.InexactFloat64(), bands.Upper.InexactFloat64(), 1e-9, "tick %d bollinger", i)
+	}
+}
+
+func assertSame(t *testing.T, tick int, name string, rolling func() (decimal.Decimal, bool)) func(decimal.Decimal, bool) {
+	return func(expected decimal.Decimal, expectedOK bool) {
+		value, ok := rolling()
+		require.Equal(t, expectedOK, ok, "tick %d %s", tick, name)
+		assert.True(t, expected.Equal(value), "tick %d %s: %s != %s", tick, name, value, expected)
+	}
+}
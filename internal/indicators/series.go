@@ -0,0 +1,163 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+type Bands struct {
+	Upper  decimal.Decimal
+	Middle decimal.Decimal
+	Lower  decimal.Decimal
+}
+
+func SMA(values []decimal.Decimal, period int) (decimal.Decimal, bool) {
+	if period <= 0 || len(values) < period {
+		return decimal.Zero, false
+	}
+
+	sum := decimal.Zero
+	for _, value := range values[len(values)-period:] {
+		sum = sum.Add(value)
+	}
+	return sum.Div(decimal.NewFromInt(int64(period))), true
+}
+
+func EMA(values []decimal.Decimal, period int) (decimal.Decimal, bool) {
+	series := EMASeries(values, period)
+	if len(series) == 0 {
+		return decimal.Zero, false
+	}
+	return series[len(series)-1], true
+}
+
+func EMASeries(values []decimal.Decimal, period int) []decimal.Decimal {
+	if period <= 0 || len(values) < period {
+		return nil
+	}
+
+	seed := decimal.Zero
+	for _, value := range values[:period] {
+		seed = seed.Add(value)
+	}
+	seed = seed.Div(decimal.NewFromInt(int64(period)))
+
+	alpha := decimal.NewFromInt(2).Div(decimal.NewFromInt(int64(period + 1)))
+	series := make([]decimal.Decimal, 0, len(values)-period+1)
+	series = append(series, seed)
+
+	ema := seed
+	for _, value := range values[period:] {
+		ema = value.Sub(ema).Mul(alpha).Add(ema)
+		series = append(series, ema)
+	}
+
+	return series
+}
+
+func RSI(prices []decimal.Decimal, period int) (decimal.Decimal, bool) {
+	if period <= 0 || len(prices) < period+1 {
+		return decimal.Zero, false
+	}
+
+	periods := decimal.NewFromInt(int64(period))
+	averageGain := decimal.Zero
+	averageLoss := decimal.Zero
+
+	for i := 1; i <= period; i++ {
+		gain, loss := priceChange(prices[i-1], prices[i])
+		averageGain = averageGain.Add(gain)
+		averageLoss = averageLoss.Add(loss)
+	}
+	averageGain = averageGain.Div(periods)
+	averageLoss = averageLoss.Div(periods)
+
+	for i := period + 1; i < len(prices); i++ {
+		gain, loss := priceChange(prices[i-1], prices[i])
+		averageGain = wilder(averageGain, gain, periods)
+		averageLoss = wilder(averageLoss, loss, periods)
+	}
+
+	return relativeStrength(averageGain, averageLoss)
+}
+
+func MACD(prices []decimal.Decimal, fastPeriod, slowPeriod, signalPeriod int) (decimal.Decimal, decimal.Decimal, bool) {
+	fast := EMASeries(prices, fastPeriod)
+	slow := EMASeries(prices, slowPeriod)
+	if len(slow) == 0 || len(fast) < len(slow) {
+		return decimal.Zero, decimal.Zero, false
+	}
+
+	offset := len(fast) - len(slow)
+	macdSeries := make([]decimal.Decimal, len(slow))
+	for i := range slow {
+		macdSeries[i] = fast[i+offset].Sub(slow[i])
+	}
+
+	signal, ok := EMA(macdSeries, signalPeriod)
+	if !ok {
+		return decimal.Zero, decimal.Zero, false
+	}
+
+	return macdSeries[len(macdSeries)-1], signal, true
+}
+
+func StdDev(values []decimal.Decimal, period int) (decimal.Decimal, bool) {
+	mean, ok := SMA(values, period)
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	sum := decimal.Zero
+	for _, value := range values[len(values)-period:] {
+		deviation := value.Sub(mean)
+		sum = sum.Add(deviation.Mul(deviation))
+	}
+	return sqrt(sum.Div(decimal.NewFromInt(int64(period)))), true
+}
+
+func Bollinger(values []decimal.Decimal, period int, width decimal.Decimal) (Bands, bool) {
+	middle, ok := SMA(values, period)
+	if !ok {
+		return Bands{}, false
+	}
+	deviation, _ := StdDev(values, period)
+	return bands(middle, deviation, width), true
+}
+
+func bands(middle, deviation, width decimal.Decimal) Bands {
+	offset := deviation.Mul(width)
+	return Bands{Upper: middle.Add(offset), Middle: middle, Lower: middle.Sub(offset)}
+}
+
+func priceChange(previous, current decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	change := current.Sub(previous)
+	if change.IsPositive() {
+		return change, decimal.Zero
+	}
+	return decimal.Zero, change.Neg()
+}
+
+func wilder(average, value, periods decimal.Decimal) decimal.Decimal {
+	return average.Mul(periods.Sub(decimal.NewFromInt(1))).Add(value).Div(periods)
+}
+
+func relativeStrength(averageGain, averageLoss decimal.Decimal) (decimal.Decimal, bool) {
+	if averageGain.IsZero() && averageLoss.IsZero() {
+		return decimal.Zero, false
+	}
+
+	hundred := decimal.NewFromInt(100)
+	if averageLoss.IsZero() {
+		return hundred, true
+	}
+	return hundred.Sub(hundred.Div(decimal.NewFromInt(1).Add(averageGain.Div(averageLoss)))), true
+}
+
+func sqrt(value decimal.Decimal) decimal.Decimal {
+	if !value.IsPositive() {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat(math.Sqrt(value.InexactFloat64()))
+}
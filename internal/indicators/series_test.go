@@ -0,0 +1,177 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decimals(values ...float64) []decimal.Decimal {
+	result := make([]decimal.Decimal, len(values))
+	for i, value := range values {
+		result[i] = decimal.NewFromFloat(value)
+	}
+	return result
+}
+
+func TestSMA(t *testing.T) {
+	prices := decimals(150, 152, 155)
+
+	sma, ok := SMA(prices, 3)
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromFloat(152.3333).Equal(sma.Round(4)))
+
+	sma, ok = SMA(prices, 2)
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromFloat(153.5).Equal(sma))
+
+	_, ok = SMA(prices, 10)
+	assert.False(t, ok)
+}
+
+func TestEMASeries(t *testing.T) {
+	values := decimals(1, 2, 3, 4, 5)
+
+	series := EMASeries(values, 3)
+
+	require.Len(t, series, 3)
+	assert.True(t, decimal.NewFromInt(2).Equal(series[0]))
+	assert.True(t, decimal.NewFromInt(3).Equal(series[1]))
+	assert.True(t, decimal.NewFromInt(4).Equal(series[2]))
+	assert.Nil(t, EMASeries(values[:2], 3))
+
+	ema, ok := EMA(values, 3)
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromInt(4).Equal(ema))
+	_, ok = EMA(values[:2], 3)
+	assert.False(t, ok)
+}
+
+func TestRSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		prices   []float64
+		period   int
+		expected float64
+		ok       bool
+	}{
+		{
+			name:   "Flat Series",
+			prices: []float64{100, 100, 100, 100, 100},
+			period: 4,
+			ok:     false,
+		},
+		{
+			name:     "All Up",
+			prices:   []float64{100, 101, 102, 103, 104, 105},
+			period:   4,
+			expected: 100,
+			ok:       true,
+		},
+		{
+			name:     "All Down",
+			prices:   []float64{105, 104, 103, 102, 101},
+			period:   4,
+			expected: 0,
+			ok:       true,
+		},
+		{
+			name:     "Balanced Gains And Losses",
+			prices:   []float64{100, 101, 100, 101, 100},
+			period:   4,
+			expected: 50,
+			ok:       true,
+		},
+		{
+			name:     "Gains Twice Losses",
+			prices:   []float64{100, 102, 101, 103, 102},
+			period:   4,
+			expected: 66.6667,
+			ok:       true,
+		},
+		{
+			name:     "Wilder Smoothing",
+			prices:   []float64{100, 102, 101, 103, 102, 104},
+			period:   4,
+			expected: 76.9231,
+			ok:       true,
+		},
+		{
+			name:   "Insufficient Data",
+			prices: []float64{100, 101, 102},
+			period: 4,
+			ok:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prices := make([]decimal.Decimal, len(tt.prices))
+			for i, price := range tt.prices {
+				prices[i] = decimal.NewFromFloat(price)
+			}
+
+			rsi, ok := RSI(prices, tt.period)
+
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.True(t, decimal.NewFromFloat(tt.expected).Equal(rsi.Round(4)), "got %s", rsi)
+			}
+		})
+	}
+}
+
+func TestMACD(t *testing.T) {
+	macd, signal, ok := MACD(decimals(1, 2, 3, 4, 5, 8), 2, 3, 2)
+
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromFloat(0.8333).Equal(macd.Round(4)), "got %s", macd)
+	assert.True(t, decimal.NewFromFloat(0.7222).Equal(signal.Round(4)), "got %s", signal)
+
+	_, _, ok = MACD(decimals(1, 2, 3), 2, 3, 2)
+	assert.False(t, ok)
+}
+
+func TestMACD_InsufficientData(t *testing.T) {
+	prices := make([]decimal.Decimal, 30)
+	for i := range prices {
+		prices[i] = decimal.NewFromInt(100)
+	}
+
+	_, _, ok := MACD(prices, 12, 26, 9)
+
+	assert.False(t, ok)
+}
+
+func TestStdDev(t *testing.T) {
+	values := decimals(2, 4, 4, 4, 5, 5, 7, 9)
+
+	deviation, ok := StdDev(values, 8)
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromInt(2).Equal(deviation))
+
+	deviation, ok = StdDev(values, 3)
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromFloat(1.63299).Equal(deviation.Round(5)), "got %s", deviation)
+
+	deviation, ok = StdDev(decimals(3, 3, 3), 3)
+	require.True(t, ok)
+	assert.True(t, deviation.IsZero())
+
+	_, ok = StdDev(values, 9)
+	assert.False(t, ok)
+}
+
+func TestBollinger(t *testing.T) {
+	bands, ok := Bollinger(decimals(2, 4, 4, 4, 5, 5, 7, 9), 8, decimal.NewFromInt(2))
+
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromInt(9).Equal(bands.Upper))
+	assert.True(t, decimal.NewFromInt(5).Equal(bands.Middle))
+	assert.True(t, decimal.NewFromInt(1).Equal(bands.Lower))
+
+	_, ok = Bollinger(decimals(1, 2), 8, decimal.NewFromInt(2))
+	assert.False(t, ok)
+}
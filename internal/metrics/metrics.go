@@ -0,0 +1,233 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/shopspring/decimal"
+)
+
+const namespace = "trade_algo"
+
+type Metrics struct {
+	registry          *prometheus.Registry
+	orders            *prometheus.CounterVec
+	exposureRejects   *prometheus.CounterVec
+	trades            prometheus.Counter
+	strategyErrors    *prometheus.CounterVec
+	strategyFailures  *prometheus.CounterVec
+	droppedMarketData prometheus.Counter
+	queueDepth        *prometheus.GaugeVec
+	queueHighMarks    *prometheus.CounterVec
+	queueRejects      *prometheus.CounterVec
+	portfolioValue    prometheus.Gauge
+	cash              prometheus.Gauge
+	unrealizedPnL     prometheus.Gauge
+	openPositions     prometheus.Gauge
+	symbolPrice       *prometheus.GaugeVec
+	orderLatency      prometheus.Histogram
+	strategyDuration  *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		orders: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_total",
+			Help:      "Orders processed, by final status and side.",
+		}, []string{"status", "side"}),
+		exposureRejects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "exposure_rejections_total",
+			Help:      "Orders rejected for breaching an exposure limit, by limit.",
+		}, []string{"limit"}),
+		trades: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "trades_executed_total",
+			Help:      "Trades executed.",
+		}),
+		strategyErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "strategy_errors_total",
+			Help:      "Strategy executions that returned an error.",
+		}, []string{"strategy_id"}),
+		strategyFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "strategy_failures_total",
+			Help:      "Strategy executions that panicked, timed out or returned an error, by reason.",
+		}, []string{"strategy_id", "reason"}),
+		droppedMarketData: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "market_data_dropped_total",
+			Help:      "Market data updates dropped or superseded by a newer tick because the consumer fell behind.",
+		}),
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "queue_usage_ratio",
+			Help:      "Share of an engine queue's capacity in use, by queue.",
+		}, []string{"queue"}),
+		queueHighMarks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "queue_high_watermark_total",
+			Help:      "Times an engine queue rose above 80% of its capacity, by queue.",
+		}, []string{"queue"}),
+		queueRejects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "queue_rejections_total",
+			Help:      "Orders rejected because an engine queue stayed full, by queue.",
+		}, []string{"queue"}),
+		portfolioValue: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "portfolio_total_value",
+			Help:      "Portfolio total value.",
+		}),
+		cash: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "portfolio_cash",
+			Help:      "Portfolio cash.",
+		}),
+		unrealizedPnL: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "portfolio_unrealized_pnl",
+			Help:      "Portfolio unrealized profit and loss.",
+		}),
+		openPositions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "open_positions",
+			Help:      "Number of open positions.",
+		}),
+		symbolPrice: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "symbol_price",
+			Help:      "Current price per symbol.",
+		}, []string{"symbol"}),
+		orderLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "order_processing_seconds",
+			Help:      "Time spent processing an order.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 10),
+		}),
+		strategyDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "strategy_execute_seconds",
+			Help:      "Time spent in a strategy's Execute call.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 10),
+		}, []string{"strategy_id"}),
+	}
+
+	m.registry.MustRegister(
+		m.orders,
+		m.exposureRejects,
+		m.trades,
+		m.strategyErrors,
+		m.strategyFailures,
+		m.droppedMarketData,
+		m.queueDepth,
+		m.queueHighMarks,
+		m.queueRejects,
+		m.portfolioValue,
+		m.cash,
+		m.unrealizedPnL,
+		m.openPositions,
+		m.symbolPrice,
+		m.orderLatency,
+		m.strategyDuration,
+	)
+	return m
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) ObserveOrder(order *models.Order, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.orders.WithLabelValues(string(order.Status), string(order.Side)).Inc()
+	m.orderLatency.Observe(duration.Seconds())
+}
+
+func (m *Metrics) ExposureRejected(limit string) {
+	if m == nil {
+		return
+	}
+	m.exposureRejects.WithLabelValues(limit).Inc()
+}
+
+func (m *Metrics) ObserveTrade() {
+	if m == nil {
+		return
+	}
+	m.trades.Inc()
+}
+
+func (m *Metrics) ObserveStrategy(strategyID string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.strategyDuration.WithLabelValues(strategyID).Observe(duration.Seconds())
+	if err != nil {
+		m.strategyErrors.WithLabelValues(strategyID).Inc()
+	}
+}
+
+func (m *Metrics) StrategyFailed(strategyID, reason string) {
+	if m == nil {
+		return
+	}
+	m.strategyFailures.WithLabelValues(strategyID, reason).Inc()
+}
+
+func (m *Metrics) ObservePortfolio(portfolio *models.Portfolio) {
+	if m == nil {
+		return
+	}
+	m.portfolioValue.Set(portfolio.TotalValue.InexactFloat64())
+	m.cash.Set(portfolio.Cash.InexactFloat64())
+	m.unrealizedPnL.Set(portfolio.UnrealizedPnL.InexactFloat64())
+	m.openPositions.Set(float64(len(portfolio.Positions)))
+}
+
+func (m *Metrics) ObservePrice(symbol string, price decimal.Decimal) {
+	if m == nil {
+		return
+	}
+	m.symbolPrice.WithLabelValues(symbol).Set(price.InexactFloat64())
+}
+
+func (m *Metrics) MarketDataDropped() {
+	if m == nil {
+		return
+	}
+	m.droppedMarketData.Inc()
+}
+
+func (m *Metrics) ObserveQueue(queue string, depth, capacity int) {
+	if m == nil || capacity <= 0 {
+		return
+	}
+	m.queueDepth.WithLabelValues(queue).Set(float64(depth) / float64(capacity))
+}
+
+func (m *Metrics) QueueHighWatermark(queue string) {
+	if m == nil {
+		return
+	}
+	m.queueHighMarks.WithLabelValues(queue).Inc()
+}
+
+func (m *Metrics) QueueRejected(queue string) {
+	if m == nil {
+		return
+	}
+	m.queueRejects.WithLabelValues(queue).Inc()
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_Observe(t *testing.T) {
+	m := New()
+
+	m.ObserveOrder(&models.Order{Status: models.OrderStatusFilled, Side: models.OrderSideBuy}, time.Millisecond)
+	m.ObserveOrder(&models.Order{Status: models.OrderStatusRejected, Side: models.OrderSideSell}, time.Millisecond)
+	m.ObserveTrade()
+	m.ExposureRejected("symbol")
+	m.ObserveStrategy("ma", time.Millisecond, errors.New("boom"))
+	m.ObserveStrategy("ma", time.Millisecond, nil)
+	m.StrategyFailed("ma", "timeout")
+	m.MarketDataDropped()
+	m.ObserveQueue("orders", 900, 1000)
+	m.QueueHighWatermark("orders")
+	m.QueueRejected("orders")
+	m.ObservePrice("AAPL", decimal.NewFromFloat(150.5))
+	m.ObservePortfolio(&models.Portfolio{
+		TotalValue:    decimal.NewFromFloat(101000.0),
+		Cash:          decimal.NewFromFloat(50000.0),
+		UnrealizedPnL: decimal.NewFromFloat(1000.0),
+		Positions:     map[string]*models.Position{"AAPL": {}},
+	})
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.orders.WithLabelValues("filled", "buy")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.orders.WithLabelValues("rejected", "sell")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.trades))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.exposureRejects.WithLabelValues("symbol")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.strategyErrors.WithLabelValues("ma")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.strategyFailures.WithLabelValues("ma", "timeout")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.droppedMarketData))
+	assert.Equal(t, 0.9, testutil.ToFloat64(m.queueDepth.WithLabelValues("orders")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.queueHighMarks.WithLabelValues("orders")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.queueRejects.WithLabelValues("orders")))
+	assert.Equal(t, 150.5, testutil.ToFloat64(m.symbolPrice.WithLabelValues("AAPL")))
+	assert.Equal(t, 101000.0, testutil.ToFloat64(m.portfolioValue))
+	assert.Equal(t, 50000.0, testutil.ToFloat64(m.cash))
+	assert.Equal(t, 1000.0, testutil.ToFloat64(m.unrealizedPnL))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.openPositions))
+	assert.Equal(t, uint64(2), histogramCount(t, m, "trade_algo_order_processing_seconds"))
+	assert.Equal(t, uint64(2), histogramCount(t, m, "trade_algo_strategy_execute_seconds"))
+}
+
+func histogramCount(t *testing.T, m *Metrics, name string) uint64 {
+	t.Helper()
+	families, err := m.Registry().Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == name {
+			var count uint64
+			for _, metric := range family.GetMetric() {
+				count += metric.GetHistogram().GetSampleCount()
+			}
+			return count
+		}
+	}
+	t.Fatalf("metric %s not found", name)
+	return 0
+}
+
+func TestMetrics_NilIsNoop(t *testing.T) {
+	var m *Metrics
+
+	assert.NotPanics(t, func() {
+		m.ObserveOrder(&models.Order{}, time.Millisecond)
+		m.ObserveTrade()
+		m.ObserveStrategy("ma", time.Millisecond, nil)
+		m.ObservePortfolio(&models.Portfolio{})
+		m.ObservePrice("AAPL", decimal.Zero)
+		m.MarketDataDropped()
+		m.ObserveQueue("orders", 1, 10)
+		m.QueueRejected("orders")
+	})
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New()
+	m.ObserveTrade()
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(body), "trade_algo_trades_executed_total 1"))
+	assert.False(t, strings.Contains(string(body), "go_goroutines"))
+}
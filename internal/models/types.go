@@ -1,6 +1,7 @@
 package models
 
 import (
+	"sort"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -24,78 +25,396 @@ const (
 type OrderStatus string
 
 const (
-	OrderStatusPending   OrderStatus = "pending"
-	OrderStatusFilled    OrderStatus = "filled"
-	OrderStatusCancelled OrderStatus = "cancelled"
-	OrderStatusRejected  OrderStatus = "rejected"
+	OrderStatusPending         OrderStatus = "pending"
+	OrderStatusFilled          OrderStatus = "filled"
+	OrderStatusCancelled       OrderStatus = "cancelled"
+	OrderStatusRejected        OrderStatus = "rejected"
+	OrderStatusPartiallyFilled OrderStatus = "partially_filled"
+)
+
+type TimeInForce string
+
+const (
+	TimeInForceGTC TimeInForce = "gtc"
+	TimeInForceDay TimeInForce = "day"
+	TimeInForceIOC TimeInForce = "ioc"
+)
+
+type CancelReason string
+
+const (
+	CancelReasonRequested  CancelReason = "requested"
+	CancelReasonReplaced   CancelReason = "replaced"
+	CancelReasonDayExpired CancelReason = "day_expired"
+	CancelReasonIOC        CancelReason = "ioc_unfilled"
+	CancelReasonHalted     CancelReason = "trading_halted"
+	CancelReasonRecovery   CancelReason = "slice_lost_on_recovery"
+)
+
+type CostBasisMethod string
+
+const (
+	CostBasisAverage CostBasisMethod = "average"
+	CostBasisFIFO    CostBasisMethod = "fifo"
+	CostBasisLIFO    CostBasisMethod = "lifo"
+)
+
+type SessionType string
+
+const (
+	SessionTypeRegular    SessionType = "regular"
+	SessionTypeContinuous SessionType = "continuous"
+)
+
+type Action string
+
+const (
+	ActionBuy   Action = "buy"
+	ActionSell  Action = "sell"
+	ActionHold  Action = "hold"
+	ActionClose Action = "close"
+)
+
+type ConfidenceScaling string
+
+const (
+	ConfidenceScalingNone   ConfidenceScaling = ""
+	ConfidenceScalingLinear ConfidenceScaling = "linear"
+	ConfidenceScalingSquare ConfidenceScaling = "square"
+)
+
+type ExitReason string
+
+const (
+	ExitReasonStopLoss     ExitReason = "stop_loss"
+	ExitReasonTakeProfit   ExitReason = "take_profit"
+	ExitReasonTrailingStop ExitReason = "trailing_stop"
+	ExitReasonDrawdown     ExitReason = "drawdown"
+	ExitReasonMarginCall   ExitReason = "margin_call"
+	ExitReasonCashInLieu   ExitReason = "cash_in_lieu"
+	ExitReasonClose        ExitReason = "close"
+)
+
+type CorporateActionType string
+
+const (
+	CorporateActionDividend CorporateActionType = "dividend"
+	CorporateActionSplit    CorporateActionType = "split"
+)
+
+type NewsSeverity string
+
+const (
+	NewsSeverityLow    NewsSeverity = "low"
+	NewsSeverityMedium NewsSeverity = "medium"
+	NewsSeverityHigh   NewsSeverity = "high"
+)
+
+type RiskEventType string
+
+const (
+	RiskEventDrawdownLiquidation RiskEventType = "drawdown_liquidation"
+	RiskEventStrategyDisabled    RiskEventType = "strategy_disabled"
+	RiskEventMarginCall          RiskEventType = "margin_call"
+	RiskEventPortfolioDrawdown   RiskEventType = "portfolio_drawdown"
+	RiskEventCircuitBreaker      RiskEventType = "circuit_breaker"
+	RiskEventTradingResumed      RiskEventType = "trading_resumed"
+)
+
+type MarketDataKind string
+
+const (
+	MarketDataKindTick            MarketDataKind = "tick"
+	MarketDataKindBar             MarketDataKind = "bar"
+	MarketDataKindCorporateAction MarketDataKind = "corporate_action"
+	MarketDataKindNews            MarketDataKind = "news"
 )
 
 type Trade struct {
-	ID          string          `json:"id"`
-	OrderID     string          `json:"order_id"`
-	Symbol      string          `json:"symbol"`
-	Side        OrderSide       `json:"side"`
-	Quantity    int64           `json:"quantity"`
-	Price       decimal.Decimal `json:"price"`
-	Commission  decimal.Decimal `json:"commission"`
-	Timestamp   time.Time       `json:"timestamp"`
-	StrategyID  string          `json:"strategy_id"`
-	RiskMetrics RiskMetrics     `json:"risk_metrics"`
+	ID              string          `json:"id"`
+	OrderID         string          `json:"order_id"`
+	Symbol          string          `json:"symbol"`
+	Side            OrderSide       `json:"side"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	Price           decimal.Decimal `json:"price"`
+	RequestedPrice  decimal.Decimal `json:"requested_price"`
+	DecisionPrice   decimal.Decimal `json:"decision_price"`
+	SubmissionPrice decimal.Decimal `json:"submission_price"`
+	Commission      decimal.Decimal `json:"commission"`
+	ImpactCost      decimal.Decimal `json:"impact_cost"`
+	Costs           TransactionCost `json:"costs"`
+	RealizedPnL     decimal.Decimal `json:"realized_pnl"`
+	Timestamp       time.Time       `json:"timestamp"`
+	StrategyID      string          `json:"strategy_id"`
+	ExitReason      ExitReason      `json:"exit_reason,omitempty"`
+	Reason          string          `json:"reason,omitempty"`
+	RiskMetrics     RiskMetrics     `json:"risk_metrics"`
+	ClosedLots      []ClosedLot     `json:"closed_lots,omitempty"`
+}
+
+type TransactionCost struct {
+	DelayBps     decimal.Decimal `json:"delay_bps"`
+	SpreadBps    decimal.Decimal `json:"spread_bps"`
+	ImpactBps    decimal.Decimal `json:"impact_bps"`
+	ShortfallBps decimal.Decimal `json:"shortfall_bps"`
+}
+
+type Lot struct {
+	Quantity   decimal.Decimal `json:"quantity"`
+	Price      decimal.Decimal `json:"price"`
+	Commission decimal.Decimal `json:"commission"`
+	Timestamp  time.Time       `json:"timestamp"`
+}
+
+type ClosedLot struct {
+	Quantity    decimal.Decimal `json:"quantity"`
+	OpenPrice   decimal.Decimal `json:"open_price"`
+	CostBasis   decimal.Decimal `json:"cost_basis"`
+	OpenedAt    time.Time       `json:"opened_at"`
+	RealizedPnL decimal.Decimal `json:"realized_pnl"`
 }
 
 type Order struct {
-	ID          string          `json:"id"`
-	Symbol      string          `json:"symbol"`
-	Side        OrderSide       `json:"side"`
-	Type        OrderType       `json:"type"`
-	Quantity    int64           `json:"quantity"`
-	Price       decimal.Decimal `json:"price"`
-	StopPrice   decimal.Decimal `json:"stop_price"`
-	Status      OrderStatus     `json:"status"`
-	Timestamp   time.Time       `json:"timestamp"`
-	StrategyID  string          `json:"strategy_id"`
-	RiskMetrics RiskMetrics     `json:"risk_metrics"`
+	ID              string          `json:"id"`
+	Symbol          string          `json:"symbol"`
+	Side            OrderSide       `json:"side"`
+	Type            OrderType       `json:"type"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	Price           decimal.Decimal `json:"price"`
+	StopPrice       decimal.Decimal `json:"stop_price"`
+	DecisionPrice   decimal.Decimal `json:"decision_price"`
+	SubmissionPrice decimal.Decimal `json:"submission_price"`
+	TimeInForce     TimeInForce     `json:"time_in_force,omitempty"`
+	Status          OrderStatus     `json:"status"`
+	Timestamp       time.Time       `json:"timestamp"`
+	StrategyID      string          `json:"strategy_id"`
+	ParentID        string          `json:"parent_id,omitempty"`
+	FilledQuantity  decimal.Decimal `json:"filled_quantity"`
+	ExitReason      ExitReason      `json:"exit_reason,omitempty"`
+	Reason          string          `json:"reason,omitempty"`
+	CancelReason    CancelReason    `json:"cancel_reason,omitempty"`
+	ReplacedByID    string          `json:"replaced_by_id,omitempty"`
+	RiskMetrics     RiskMetrics     `json:"risk_metrics"`
 }
 
 type Position struct {
 	Symbol        string          `json:"symbol"`
-	Quantity      int64           `json:"quantity"`
+	StrategyID    string          `json:"strategy_id"`
+	Quantity      decimal.Decimal `json:"quantity"`
 	AveragePrice  decimal.Decimal `json:"average_price"`
 	CurrentPrice  decimal.Decimal `json:"current_price"`
+	PeakPrice     decimal.Decimal `json:"peak_price"`
+	TroughPrice   decimal.Decimal `json:"trough_price"`
+	StopPrice     decimal.Decimal `json:"stop_price"`
 	UnrealizedPnL decimal.Decimal `json:"unrealized_pnl"`
 	RealizedPnL   decimal.Decimal `json:"realized_pnl"`
 	MarketValue   decimal.Decimal `json:"market_value"`
 	RiskMetrics   RiskMetrics     `json:"risk_metrics"`
 	LastUpdated   time.Time       `json:"last_updated"`
+	Lots          []Lot           `json:"lots,omitempty"`
+	FX            *PositionFX     `json:"fx,omitempty"`
+}
+
+type PositionFX struct {
+	Currency      string          `json:"currency"`
+	Rate          decimal.Decimal `json:"rate"`
+	EntryRate     decimal.Decimal `json:"entry_rate"`
+	MarketValue   decimal.Decimal `json:"market_value"`
+	UnrealizedPnL decimal.Decimal `json:"unrealized_pnl"`
+	PricePnL      decimal.Decimal `json:"price_pnl"`
+	FXPnL         decimal.Decimal `json:"fx_pnl"`
+}
+
+type Instrument struct {
+	Symbol         string          `json:"symbol"`
+	TickSize       decimal.Decimal `json:"tick_size"`
+	LotSize        decimal.Decimal `json:"lot_size"`
+	PricePrecision int32           `json:"price_precision"`
+	Currency       string          `json:"currency"`
+	SessionType    SessionType     `json:"session_type"`
 }
 
 type Portfolio struct {
-	ID             string               `json:"id"`
-	Cash           decimal.Decimal      `json:"cash"`
-	Positions      map[string]*Position `json:"positions"`
-	TotalValue     decimal.Decimal      `json:"total_value"`
-	UnrealizedPnL  decimal.Decimal      `json:"unrealized_pnl"`
-	RealizedPnL    decimal.Decimal      `json:"realized_pnl"`
-	TotalRisk      decimal.Decimal      `json:"total_risk"`
-	RiskMetrics    PortfolioRiskMetrics `json:"risk_metrics"`
-	TradeHistory   []*Trade             `json:"trade_history"`
-	OrderHistory   []*Order             `json:"order_history"`
-	LastRebalanced time.Time            `json:"last_rebalanced"`
-	CreatedAt      time.Time            `json:"created_at"`
-	UpdatedAt      time.Time            `json:"updated_at"`
+	ID               string                     `json:"id"`
+	BaseCurrency     string                     `json:"base_currency,omitempty"`
+	Cash             decimal.Decimal            `json:"cash"`
+	CashBalances     map[string]decimal.Decimal `json:"cash_balances,omitempty"`
+	Positions        map[string]*Position       `json:"positions"`
+	TotalValue       decimal.Decimal            `json:"total_value"`
+	UnrealizedPnL    decimal.Decimal            `json:"unrealized_pnl"`
+	RealizedPnL      decimal.Decimal            `json:"realized_pnl"`
+	TotalRisk        decimal.Decimal            `json:"total_risk"`
+	RiskMetrics      PortfolioRiskMetrics       `json:"risk_metrics"`
+	TradeHistory     []*Trade                   `json:"trade_history"`
+	OrderHistory     []*Order                   `json:"order_history"`
+	RiskEvents       []RiskEvent                `json:"risk_events,omitempty"`
+	Margin           *MarginAccount             `json:"margin,omitempty"`
+	InterestExpense  decimal.Decimal            `json:"interest_expense"`
+	DividendIncome   decimal.Decimal            `json:"dividend_income"`
+	CorporateActions []CorporateAction          `json:"corporate_actions,omitempty"`
+	PeakValue        decimal.Decimal            `json:"peak_value"`
+	Drawdown         decimal.Decimal            `json:"drawdown"`
+	MaxDrawdown      decimal.Decimal            `json:"max_drawdown"`
+	LastRebalanced   time.Time                  `json:"last_rebalanced"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
 }
 
-type MarketData struct {
+type MarginAccount struct {
+	InitialRate       decimal.Decimal `json:"initial_rate"`
+	MaintenanceRate   decimal.Decimal `json:"maintenance_rate"`
+	InterestRate      decimal.Decimal `json:"interest_rate"`
+	BuyingPower       decimal.Decimal `json:"buying_power"`
+	MaintenanceMargin decimal.Decimal `json:"maintenance_margin"`
+	LastAccrual       time.Time       `json:"last_accrual"`
+}
+
+type RiskEvent struct {
+	Type       RiskEventType   `json:"type"`
+	Symbol     string          `json:"symbol,omitempty"`
+	StrategyID string          `json:"strategy_id"`
+	Drawdown   decimal.Decimal `json:"drawdown"`
+	Threshold  decimal.Decimal `json:"threshold"`
+	Quantity   decimal.Decimal `json:"quantity"`
+	OrderID    string          `json:"order_id,omitempty"`
+	Timestamp  time.Time       `json:"timestamp"`
+}
+
+type AdjustmentKind string
+
+const (
+	AdjustmentVolatility AdjustmentKind = "volatility"
+	AdjustmentTrend      AdjustmentKind = "trend"
+	AdjustmentEvent      AdjustmentKind = "event"
+)
+
+type SimulatorAdjustment struct {
+	Symbol    string          `json:"symbol"`
+	Kind      AdjustmentKind  `json:"kind"`
+	EventType string          `json:"event_type,omitempty"`
+	Value     decimal.Decimal `json:"value"`
+	Previous  decimal.Decimal `json:"previous"`
+	At        time.Time       `json:"at"`
+}
+
+type TradingHalt struct {
+	StrategyID string          `json:"strategy_id,omitempty"`
+	Reason     string          `json:"reason"`
+	Loss       decimal.Decimal `json:"loss"`
+	Threshold  decimal.Decimal `json:"threshold"`
+	HaltedAt   time.Time       `json:"halted_at"`
+	ResumeAt   time.Time       `json:"resume_at"`
+}
+
+type TradingStatus struct {
+	Halted     bool          `json:"halted"`
+	Halt       *TradingHalt  `json:"halt,omitempty"`
+	Strategies []TradingHalt `json:"halted_strategies,omitempty"`
+}
+
+type CorporateAction struct {
+	Symbol    string              `json:"symbol"`
+	Type      CorporateActionType `json:"type"`
+	Amount    decimal.Decimal     `json:"amount"`
+	Ratio     decimal.Decimal     `json:"ratio"`
+	Price     decimal.Decimal     `json:"price"`
+	Quantity  decimal.Decimal     `json:"quantity"`
+	Cash      decimal.Decimal     `json:"cash"`
+	Timestamp time.Time           `json:"timestamp"`
+}
+
+type NewsEvent struct {
 	Symbol    string          `json:"symbol"`
-	Price     decimal.Decimal `json:"price"`
-	Volume    int64           `json:"volume"`
-	High      decimal.Decimal `json:"high"`
-	Low       decimal.Decimal `json:"low"`
-	Open      decimal.Decimal `json:"open"`
-	Close     decimal.Decimal `json:"close"`
+	Headline  string          `json:"headline"`
+	Sentiment decimal.Decimal `json:"sentiment"`
+	Severity  NewsSeverity    `json:"severity"`
 	Timestamp time.Time       `json:"timestamp"`
 }
 
+type PortfolioSummary struct {
+	ID              string                     `json:"id"`
+	BaseCurrency    string                     `json:"base_currency,omitempty"`
+	Cash            decimal.Decimal            `json:"cash"`
+	CashBalances    map[string]decimal.Decimal `json:"cash_balances,omitempty"`
+	Positions       map[string]*Position       `json:"positions"`
+	TotalValue      decimal.Decimal            `json:"total_value"`
+	UnrealizedPnL   decimal.Decimal            `json:"unrealized_pnl"`
+	RealizedPnL     decimal.Decimal            `json:"realized_pnl"`
+	TotalRisk       decimal.Decimal            `json:"total_risk"`
+	RiskMetrics     PortfolioRiskMetrics       `json:"risk_metrics"`
+	Margin          *MarginAccount             `json:"margin,omitempty"`
+	InterestExpense decimal.Decimal            `json:"interest_expense"`
+	Drawdown        decimal.Decimal            `json:"drawdown"`
+	MaxDrawdown     decimal.Decimal            `json:"max_drawdown"`
+	TradeCount      int                        `json:"trade_count"`
+	OrderCount      int                        `json:"order_count"`
+	Allocations     []StrategyAllocation       `json:"allocations,omitempty"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+}
+
+type StrategyAllocation struct {
+	StrategyID    string               `json:"strategy_id"`
+	Weight        decimal.Decimal      `json:"weight"`
+	Cash          decimal.Decimal      `json:"cash"`
+	Positions     map[string]*Position `json:"positions"`
+	TotalValue    decimal.Decimal      `json:"total_value"`
+	UnrealizedPnL decimal.Decimal      `json:"unrealized_pnl"`
+	RealizedPnL   decimal.Decimal      `json:"realized_pnl"`
+	TotalRisk     decimal.Decimal      `json:"total_risk"`
+}
+
+type StrategyPerformance struct {
+	StrategyID    string          `json:"strategy_id"`
+	RealizedPnL   decimal.Decimal `json:"realized_pnl"`
+	UnrealizedPnL decimal.Decimal `json:"unrealized_pnl"`
+	TotalPnL      decimal.Decimal `json:"total_pnl"`
+	Commission    decimal.Decimal `json:"commission"`
+	Dividends     decimal.Decimal `json:"dividends"`
+	Trades        int             `json:"trades"`
+	WinningTrades int             `json:"winning_trades"`
+	LosingTrades  int             `json:"losing_trades"`
+	WinRate       decimal.Decimal `json:"win_rate"`
+}
+
+type MarketData struct {
+	Symbol          string           `json:"symbol"`
+	Kind            MarketDataKind   `json:"kind"`
+	Price           decimal.Decimal  `json:"price"`
+	Bid             decimal.Decimal  `json:"bid"`
+	Ask             decimal.Decimal  `json:"ask"`
+	BidSize         int64            `json:"bid_size"`
+	AskSize         int64            `json:"ask_size"`
+	Volume          int64            `json:"volume"`
+	High            decimal.Decimal  `json:"high"`
+	Low             decimal.Decimal  `json:"low"`
+	Open            decimal.Decimal  `json:"open"`
+	Close           decimal.Decimal  `json:"close"`
+	Interval        time.Duration    `json:"interval,omitempty"`
+	Timestamp       time.Time        `json:"timestamp"`
+	CorporateAction *CorporateAction `json:"corporate_action,omitempty"`
+	News            *NewsEvent       `json:"news,omitempty"`
+}
+
+type DailyStatement struct {
+	Date             time.Time       `json:"date"`
+	Partial          bool            `json:"partial"`
+	StartingEquity   decimal.Decimal `json:"starting_equity"`
+	EndingEquity     decimal.Decimal `json:"ending_equity"`
+	Return           decimal.Decimal `json:"return"`
+	RealizedPnL      decimal.Decimal `json:"realized_pnl"`
+	UnrealizedChange decimal.Decimal `json:"unrealized_pnl_change"`
+	Commission       decimal.Decimal `json:"commission"`
+	Trades           int             `json:"trades"`
+	MaxDrawdown      decimal.Decimal `json:"max_drawdown"`
+}
+
+type EquityPoint struct {
+	Timestamp     time.Time       `json:"timestamp"`
+	Value         decimal.Decimal `json:"value"`
+	Cash          decimal.Decimal `json:"cash"`
+	UnrealizedPnL decimal.Decimal `json:"unrealized_pnl"`
+}
+
 type RiskMetrics struct {
 	VaR95             decimal.Decimal `json:"var_95"`
 	ExpectedShortfall decimal.Decimal `json:"expected_shortfall"`
@@ -114,37 +433,132 @@ type PortfolioRiskMetrics struct {
 }
 
 type StrategyConfig struct {
-	ID                  string          `json:"id"`
-	Name                string          `json:"name"`
-	MaxPositionSize     decimal.Decimal `json:"max_position_size"`
-	MaxPortfolioRisk    decimal.Decimal `json:"max_portfolio_risk"`
-	MaxDrawdown         decimal.Decimal `json:"max_drawdown"`
-	StopLossPercent     decimal.Decimal `json:"stop_loss_percent"`
-	TakeProfitPercent   decimal.Decimal `json:"take_profit_percent"`
-	TrailingStopPercent decimal.Decimal `json:"trailing_stop_percent"`
-	RebalanceThreshold  decimal.Decimal `json:"rebalance_threshold"`
-	MaxOrdersPerDay     int             `json:"max_orders_per_day"`
-	MinOrderSize        decimal.Decimal `json:"min_order_size"`
-	MaxOrderSize        decimal.Decimal `json:"max_order_size"`
-	CommissionRate      decimal.Decimal `json:"commission_rate"`
-	SlippageTolerance   decimal.Decimal `json:"slippage_tolerance"`
-	RiskFreeRate        decimal.Decimal `json:"risk_free_rate"`
-	MarketDataWindow    int             `json:"market_data_window"`
-	TechnicalIndicators []string        `json:"technical_indicators"`
-	Enabled             bool            `json:"enabled"`
-	CreatedAt           time.Time       `json:"created_at"`
-	UpdatedAt           time.Time       `json:"updated_at"`
+	ID                  string            `json:"id"`
+	Name                string            `json:"name"`
+	MaxPositionSize     decimal.Decimal   `json:"max_position_size"`
+	MaxPortfolioRisk    decimal.Decimal   `json:"max_portfolio_risk"`
+	MaxDrawdown         decimal.Decimal   `json:"max_drawdown"`
+	LiquidationFraction decimal.Decimal   `json:"liquidation_fraction"`
+	DisableOnDrawdown   bool              `json:"disable_on_drawdown"`
+	StopLossPercent     decimal.Decimal   `json:"stop_loss_percent"`
+	TakeProfitPercent   decimal.Decimal   `json:"take_profit_percent"`
+	TrailingStopPercent decimal.Decimal   `json:"trailing_stop_percent"`
+	RebalanceThreshold  decimal.Decimal   `json:"rebalance_threshold"`
+	MaxOrdersPerDay     int               `json:"max_orders_per_day"`
+	MinOrderSize        decimal.Decimal   `json:"min_order_size"`
+	MaxOrderSize        decimal.Decimal   `json:"max_order_size"`
+	CommissionRate      decimal.Decimal   `json:"commission_rate"`
+	SlippageTolerance   decimal.Decimal   `json:"slippage_tolerance"`
+	RiskFreeRate        decimal.Decimal   `json:"risk_free_rate"`
+	MarketDataWindow    int               `json:"market_data_window"`
+	MaxSignalsPerRun    int               `json:"max_signals_per_run"`
+	ExecuteTimeout      time.Duration     `json:"execute_timeout"`
+	MaxFailures         int               `json:"max_failures"`
+	MinConfidence       decimal.Decimal   `json:"min_confidence"`
+	ConfidenceScaling   ConfidenceScaling `json:"confidence_scaling"`
+	PositionSizing      string            `json:"position_sizing"`
+	SizingFraction      decimal.Decimal   `json:"sizing_fraction"`
+	KellyFraction       decimal.Decimal   `json:"kelly_fraction"`
+	VaRMethod           string            `json:"var_method"`
+	VaRHoldingPeriod    int               `json:"var_holding_period"`
+	Grid                *GridConfig       `json:"grid,omitempty"`
+	TechnicalIndicators []string          `json:"technical_indicators"`
+	AllowShort          bool              `json:"allow_short"`
+	Enabled             bool              `json:"enabled"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+}
+
+type GridConfig struct {
+	Symbol        string          `json:"symbol"`
+	Lower         decimal.Decimal `json:"lower"`
+	Upper         decimal.Decimal `json:"upper"`
+	Step          decimal.Decimal `json:"step"`
+	LevelQuantity decimal.Decimal `json:"level_quantity"`
+	Lookback      int             `json:"lookback"`
+}
+
+type ExposureLimits struct {
+	MaxSymbolWeight decimal.Decimal          `json:"max_symbol_weight"`
+	MaxPositions    int                      `json:"max_positions"`
+	Groups          map[string]ExposureGroup `json:"groups"`
+}
+
+type ExposureGroup struct {
+	Symbols   []string        `json:"symbols"`
+	MaxWeight decimal.Decimal `json:"max_weight"`
 }
 
 type AlgorithmResult struct {
 	StrategyID     string          `json:"strategy_id"`
 	Symbol         string          `json:"symbol"`
-	Action         string          `json:"action"`
-	Quantity       int64           `json:"quantity"`
+	Action         Action          `json:"action"`
+	Quantity       decimal.Decimal `json:"quantity"`
 	Price          decimal.Decimal `json:"price"`
+	OrderType      OrderType       `json:"order_type,omitempty"`
+	TimeInForce    TimeInForce     `json:"time_in_force,omitempty"`
+	StopPrice      decimal.Decimal `json:"stop_price"`
 	Confidence     decimal.Decimal `json:"confidence"`
 	Signal         string          `json:"signal"`
+	Reason         string          `json:"reason,omitempty"`
 	Timestamp      time.Time       `json:"timestamp"`
 	RiskScore      decimal.Decimal `json:"risk_score"`
 	ExpectedReturn decimal.Decimal `json:"expected_return"`
 }
+
+type OrderBook struct {
+	Symbol    string   `json:"symbol"`
+	Bids      []*Order `json:"bids"`
+	Asks      []*Order `json:"asks"`
+	BuyStops  []*Order `json:"buy_stops"`
+	SellStops []*Order `json:"sell_stops"`
+}
+
+type MarketSnapshot struct {
+	Latest  map[string]*MarketData                     `json:"latest"`
+	History map[string][]*MarketData                   `json:"history"`
+	Bars    map[string]map[time.Duration][]*MarketData `json:"bars,omitempty"`
+}
+
+func (s *MarketSnapshot) GetBars(symbol string, timeframe time.Duration, n int) []*MarketData {
+	bars := s.Bars[symbol][timeframe]
+	if n > 0 && n < len(bars) {
+		bars = bars[len(bars)-n:]
+	}
+	return bars
+}
+
+func (s *MarketSnapshot) Prices(symbol string) []decimal.Decimal {
+	history := s.History[symbol]
+	prices := make([]decimal.Decimal, len(history))
+	for i, data := range history {
+		prices[i] = data.Price
+	}
+	return prices
+}
+
+func (p *Portfolio) SortedPositions() []*Position {
+	positions := make([]*Position, 0, len(p.Positions))
+	for _, position := range p.Positions {
+		positions = append(positions, position)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].Symbol < positions[j].Symbol
+	})
+	return positions
+}
+
+func (d *MarketData) SplitAdjusted(ratio decimal.Decimal) *MarketData {
+	adjusted := *d
+	adjusted.Price = d.Price.Div(ratio)
+	adjusted.Bid = d.Bid.Div(ratio)
+	adjusted.Ask = d.Ask.Div(ratio)
+	adjusted.High = d.High.Div(ratio)
+	adjusted.Low = d.Low.Div(ratio)
+	adjusted.Open = d.Open.Div(ratio)
+	adjusted.Close = d.Close.Div(ratio)
+	adjusted.BidSize = decimal.NewFromInt(d.BidSize).Mul(ratio).IntPart()
+	adjusted.AskSize = decimal.NewFromInt(d.AskSize).Mul(ratio).IntPart()
+	adjusted.Volume = decimal.NewFromInt(d.Volume).Mul(ratio).IntPart()
+	return &adjusted
+}
@@ -0,0 +1,8 @@
+package montecarlo
+
+import "errors"
+
+var (
+	ErrInvalidRuns  = errors.New("monte carlo needs at least one run")
+	ErrInvalidTicks = errors.New("monte carlo needs at least one tick per run")
+)
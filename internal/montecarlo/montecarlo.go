@@ -0,0 +1,164 @@
+package montecarlo
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/analytics"
+	"github.com/1cbyc/trade-algo-go/internal/backtest"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/simulator"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var defaultStart = time.Date(2024, 1, 2, 14, 30, 0, 0, time.UTC)
+
+type SimulatorBuilder func(opts ...simulator.Option) (*simulator.MarketSimulator, error)
+
+type EngineBuilder func() (*engine.TradingEngine, error)
+
+type Runner struct {
+	simulator SimulatorBuilder
+	engine    EngineBuilder
+	ticks     int
+	start     time.Time
+	workers   int
+	logger    *zap.Logger
+}
+
+type Option func(*Runner)
+
+func WithWorkers(workers int) Option {
+	return func(r *Runner) {
+		if workers > 0 {
+			r.workers = workers
+		}
+	}
+}
+
+func WithStart(start time.Time) Option {
+	return func(r *Runner) {
+		r.start = start
+	}
+}
+
+type RunResult struct {
+	Run         int             `json:"run"`
+	Seed        int64           `json:"seed"`
+	TotalReturn decimal.Decimal `json:"total_return"`
+	MaxDrawdown decimal.Decimal `json:"max_drawdown"`
+	SharpeRatio decimal.Decimal `json:"sharpe_ratio"`
+	TotalTrades int             `json:"total_trades"`
+	FinalValue  decimal.Decimal `json:"final_value"`
+}
+
+type Study struct {
+	Seed    int64       `json:"seed"`
+	Ticks   int         `json:"ticks"`
+	Summary Summary     `json:"summary"`
+	Runs    []RunResult `json:"runs"`
+}
+
+func NewRunner(simulator SimulatorBuilder, engine EngineBuilder, ticks int, logger *zap.Logger, opts ...Option) *Runner {
+	r := &Runner{
+		simulator: simulator,
+		engine:    engine,
+		ticks:     ticks,
+		start:     defaultStart,
+		workers:   runtime.NumCPU(),
+		logger:    logger,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Runner) Run(ctx context.Context, runs int, seed int64) (*Study, error) {
+	if runs <= 0 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidRuns, runs)
+	}
+	if r.ticks <= 0 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidTicks, r.ticks)
+	}
+
+	seeds := make([]int64, runs)
+	rng := rand.New(rand.NewSource(seed))
+	for i := range seeds {
+		seeds[i] = rng.Int63()
+	}
+
+	results := make([]RunResult, runs)
+	errs := make([]error, runs)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(r.workers, runs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = r.simulate(ctx, i+1, seeds[i])
+			}
+		}()
+	}
+	for i := range seeds {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("run %d (seed %d): %w", i+1, seeds[i], err)
+		}
+	}
+
+	study := &Study{Seed: seed, Ticks: r.ticks, Summary: Summarize(results), Runs: results}
+	r.logger.Info("Monte Carlo study completed",
+		zap.Int("runs", runs),
+		zap.Int64("seed", seed),
+		zap.String("mean_return", study.Summary.MeanReturn.String()),
+		zap.String("probability_of_loss", study.Summary.ProbabilityOfLoss.String()))
+	return study, nil
+}
+
+func (r *Runner) simulate(ctx context.Context, run int, seed int64) (RunResult, error) {
+	fake := clock.NewFake(r.start)
+	sim, err := r.simulator(simulator.WithSeed(seed), simulator.WithClock(fake))
+	if err != nil {
+		return RunResult{}, err
+	}
+	tradingEngine, err := r.engine()
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	stepped := simulator.NewSteppedFeed(sim, fake, r.ticks)
+	if err := stepped.Start(ctx); err != nil {
+		return RunResult{}, err
+	}
+	portfolio, err := backtest.NewRunner(tradingEngine, zap.NewNop()).Run(ctx, stepped.Updates())
+	stepped.Stop()
+	tradingEngine.Stop()
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	report := analytics.GeneratePerformanceReport(portfolio, tradingEngine.GetEquityCurve())
+	report.AddDailyStatements(tradingEngine.GetDailyStatements())
+	return RunResult{
+		Run:         run,
+		Seed:        seed,
+		TotalReturn: report.TotalReturn,
+		MaxDrawdown: report.MaxDrawdown,
+		SharpeRatio: report.SharpeRatio,
+		TotalTrades: report.TotalTrades,
+		FinalValue:  report.FinalValue,
+	}, nil
+}
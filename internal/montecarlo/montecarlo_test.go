@@ -0,0 +1,118 @@
+package montecarlo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/simulator"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func createTestRunner(opts ...Option) *Runner {
+	newSimulator := func(options ...simulator.Option) (*simulator.MarketSimulator, error) {
+		sim := simulator.NewMarketSimulator(zap.NewNop(), options...)
+		sim.AddSymbol("AAPL", decimal.NewFromInt(150), decimal.NewFromFloat(0.5))
+		sim.AddSymbol("MSFT", decimal.NewFromInt(300), decimal.NewFromFloat(0.8))
+		return sim, nil
+	}
+	newEngine := func() (*engine.TradingEngine, error) {
+		tradingEngine := engine.NewTradingEngine(decimal.NewFromInt(100000), zap.NewNop())
+		strategy := strategies.NewMovingAverageStrategy(&models.StrategyConfig{
+			ID:               "ma",
+			MaxPositionSize:  decimal.NewFromFloat(0.2),
+			MaxPortfolioRisk: decimal.NewFromFloat(1.0),
+			MinOrderSize:     decimal.NewFromInt(100),
+			MaxOrderSize:     decimal.NewFromInt(10000),
+			Enabled:          true,
+		})
+		strategy.SetPeriods(3, 8, 3)
+		tradingEngine.AddStrategy(strategy)
+		return tradingEngine, nil
+	}
+	return NewRunner(newSimulator, newEngine, 120, zap.NewNop(), opts...)
+}
+
+func TestRunner_Run_IsDeterministic(t *testing.T) {
+	serial, err := createTestRunner(WithWorkers(1)).Run(context.Background(), 4, 42)
+	require.NoError(t, err)
+	parallel, err := createTestRunner(WithWorkers(4)).Run(context.Background(), 4, 42)
+	require.NoError(t, err)
+
+	assert.Equal(t, serial, parallel)
+	require.Len(t, serial.Runs, 4)
+	assert.Equal(t, 4, serial.Summary.Runs)
+	seeds := make(map[int64]bool)
+	for i, run := range serial.Runs {
+		assert.Equal(t, i+1, run.Run)
+		seeds[run.Seed] = true
+	}
+	assert.Len(t, seeds, 4)
+	assert.NotEqual(t, serial.Runs[0].FinalValue, serial.Runs[1].FinalValue)
+
+	other, err := createTestRunner().Run(context.Background(), 4, 7)
+	require.NoError(t, err)
+	assert.NotEqual(t, serial.Runs[0].Seed, other.Runs[0].Seed)
+}
+
+func TestRunner_Run_Invalid(t *testing.T) {
+	_, err := createTestRunner().Run(context.Background(), 0, 42)
+	assert.ErrorIs(t, err, ErrInvalidRuns)
+
+	runner := createTestRunner()
+	runner.ticks = 0
+	_, err = runner.Run(context.Background(), 2, 42)
+	assert.ErrorIs(t, err, ErrInvalidTicks)
+}
+
+func TestSummarize(t *testing.T) {
+	returns := []float64{0.1, -0.05, 0.02, 0.03}
+	results := make([]RunResult, len(returns))
+	for i, value := range returns {
+		results[i] = RunResult{
+			Run:         i + 1,
+			TotalReturn: decimal.NewFromFloat(value),
+			MaxDrawdown: decimal.NewFromFloat(0.01 * float64(i+1)),
+			SharpeRatio: decimal.NewFromInt(int64(i)),
+			TotalTrades: 2 * i,
+		}
+	}
+
+	summary := Summarize(results)
+
+	assert.Equal(t, 4, summary.Runs)
+	assert.True(t, decimal.NewFromFloat(0.025).Equal(summary.MeanReturn))
+	assert.True(t, decimal.NewFromFloat(0.025).Equal(summary.MedianReturn))
+	assert.InDelta(t, 0.053151, summary.StdDevReturn.InexactFloat64(), 1e-6)
+	assert.True(t, decimal.NewFromFloat(-0.05).Equal(summary.Percentile5))
+	assert.True(t, decimal.NewFromFloat(0.1).Equal(summary.Percentile95))
+	assert.True(t, decimal.NewFromFloat(0.25).Equal(summary.ProbabilityOfLoss))
+	assert.True(t, decimal.NewFromFloat(0.04).Equal(summary.WorstDrawdown))
+	assert.True(t, decimal.NewFromFloat(1.5).Equal(summary.MeanSharpe))
+	assert.True(t, decimal.NewFromInt(3).Equal(summary.MeanTrades))
+}
+
+func TestWriteStudy(t *testing.T) {
+	dir := t.TempDir()
+	study := &Study{Seed: 42, Ticks: 10, Runs: []RunResult{{Run: 1, Seed: 9, TotalReturn: decimal.NewFromFloat(0.1), TotalTrades: 3}}}
+	study.Summary = Summarize(study.Runs)
+
+	paths, err := WriteStudy(dir, study)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "monte_carlo.csv"), filepath.Join(dir, "monte_carlo.json")}, paths)
+
+	table, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, "run,seed,total_return,max_drawdown,sharpe_ratio,total_trades,final_value\n1,9,0.1,0,0,3,0\n", string(table))
+
+	document, err := os.ReadFile(paths[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(document), `"probability_of_loss": "0"`)
+}
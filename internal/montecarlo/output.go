@@ -0,0 +1,62 @@
+package montecarlo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+var runHeader = []string{"run", "seed", "total_return", "max_drawdown", "sharpe_ratio", "total_trades", "final_value"}
+
+func WriteStudy(dir string, study *Study) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	base := filepath.Join(dir, "monte_carlo")
+	if err := writeRuns(base+".csv", study.Runs); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(base + ".json")
+	if err != nil {
+		return []string{base + ".csv"}, err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(study); err != nil {
+		file.Close()
+		return []string{base + ".csv"}, fmt.Errorf("%s: %w", base+".json", err)
+	}
+	return []string{base + ".csv", base + ".json"}, file.Close()
+}
+
+func writeRuns(path string, runs []RunResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(file)
+	writer.Write(runHeader)
+	for _, run := range runs {
+		writer.Write([]string{
+			strconv.Itoa(run.Run),
+			strconv.FormatInt(run.Seed, 10),
+			run.TotalReturn.String(),
+			run.MaxDrawdown.String(),
+			run.SharpeRatio.String(),
+			strconv.Itoa(run.TotalTrades),
+			run.FinalValue.String(),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		file.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,60 @@
+package montecarlo
+
+import (
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/shopspring/decimal"
+)
+
+type Summary struct {
+	Runs              int             `json:"runs"`
+	MeanReturn        decimal.Decimal `json:"mean_return"`
+	MedianReturn      decimal.Decimal `json:"median_return"`
+	StdDevReturn      decimal.Decimal `json:"stddev_return"`
+	Percentile5       decimal.Decimal `json:"percentile_5_return"`
+	Percentile95      decimal.Decimal `json:"percentile_95_return"`
+	ProbabilityOfLoss decimal.Decimal `json:"probability_of_loss"`
+	WorstDrawdown     decimal.Decimal `json:"worst_drawdown"`
+	MeanSharpe        decimal.Decimal `json:"mean_sharpe"`
+	MeanTrades        decimal.Decimal `json:"mean_trades"`
+}
+
+func Summarize(results []RunResult) Summary {
+	summary := Summary{Runs: len(results)}
+	if len(results) == 0 {
+		return summary
+	}
+
+	count := decimal.NewFromInt(int64(len(results)))
+	returns := make([]decimal.Decimal, len(results))
+	floats := make([]float64, len(results))
+	totalReturn, totalSharpe, losses, trades := decimal.Zero, decimal.Zero, 0, 0
+	for i, result := range results {
+		returns[i] = result.TotalReturn
+		floats[i] = result.TotalReturn.InexactFloat64()
+		totalReturn = totalReturn.Add(result.TotalReturn)
+		totalSharpe = totalSharpe.Add(result.SharpeRatio)
+		trades += result.TotalTrades
+		if result.TotalReturn.IsNegative() {
+			losses++
+		}
+		summary.WorstDrawdown = decimal.Max(summary.WorstDrawdown, result.MaxDrawdown)
+	}
+
+	sort.Slice(returns, func(i, j int) bool { return returns[i].LessThan(returns[j]) })
+	middle := len(returns) / 2
+	summary.MedianReturn = returns[middle]
+	if len(returns)%2 == 0 {
+		summary.MedianReturn = returns[middle-1].Add(returns[middle]).Div(decimal.NewFromInt(2))
+	}
+
+	summary.MeanReturn = totalReturn.Div(count)
+	summary.StdDevReturn = decimal.NewFromFloat(risk.StdDev(floats))
+	summary.Percentile5 = decimal.NewFromFloat(risk.Quantile(floats, 0.05))
+	summary.Percentile95 = decimal.NewFromFloat(risk.Quantile(floats, 0.95))
+	summary.ProbabilityOfLoss = decimal.NewFromInt(int64(losses)).Div(count)
+	summary.MeanSharpe = totalSharpe.Div(count)
+	summary.MeanTrades = decimal.NewFromInt(int64(trades)).Div(count)
+	return summary
+}
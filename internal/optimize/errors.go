@@ -0,0 +1,10 @@
+package optimize
+
+import "errors"
+
+var (
+	ErrInvalidGrid      = errors.New("invalid parameter grid")
+	ErrInvalidObjective = errors.New("invalid objective")
+	ErrInvalidWindow    = errors.New("invalid walk-forward window")
+	ErrNotTunable       = errors.New("strategy has no tunable parameters")
+)
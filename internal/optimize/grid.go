@@ -0,0 +1,88 @@
+package optimize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type Params map[string]decimal.Decimal
+
+type Grid map[string][]decimal.Decimal
+
+func ParseGrid(spec string) (Grid, error) {
+	grid := make(Grid)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, list, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("%w: %q must be name=value,value", ErrInvalidGrid, entry)
+		}
+		if _, exists := grid[name]; exists {
+			return nil, fmt.Errorf("%w: %s is listed twice", ErrInvalidGrid, name)
+		}
+
+		var values []decimal.Decimal
+		for _, field := range strings.Split(list, ",") {
+			value, err := decimal.NewFromString(strings.TrimSpace(field))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s value %q: %v", ErrInvalidGrid, name, field, err)
+			}
+			values = append(values, value)
+		}
+		grid[name] = values
+	}
+	if len(grid) == 0 {
+		return nil, fmt.Errorf("%w: no parameters", ErrInvalidGrid)
+	}
+	return grid, nil
+}
+
+func (g Grid) Names() []string {
+	names := make([]string, 0, len(g))
+	for name := range g {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (g Grid) Combinations() []Params {
+	names := g.Names()
+	combinations := []Params{{}}
+	for _, name := range names {
+		next := make([]Params, 0, len(combinations)*len(g[name]))
+		for _, combination := range combinations {
+			for _, value := range g[name] {
+				params := make(Params, len(combination)+1)
+				for key, existing := range combination {
+					params[key] = existing
+				}
+				params[name] = value
+				next = append(next, params)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+func (p Params) String() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]string, len(names))
+	for i, name := range names {
+		fields[i] = name + "=" + p[name].String()
+	}
+	return strings.Join(fields, " ")
+}
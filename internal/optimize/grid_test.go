@@ -0,0 +1,37 @@
+package optimize
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGrid(t *testing.T) {
+	grid, err := ParseGrid("short_period=5,10,20; long_period=30,50,100;")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"long_period", "short_period"}, grid.Names())
+	require.Len(t, grid["short_period"], 3)
+	assert.True(t, decimal.NewFromInt(20).Equal(grid["short_period"][2]))
+}
+
+func TestParseGrid_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "short_period", "=5", "short_period=5,x", "short_period=5;short_period=10"} {
+		_, err := ParseGrid(spec)
+		assert.ErrorIs(t, err, ErrInvalidGrid, spec)
+	}
+}
+
+func TestGrid_Combinations(t *testing.T) {
+	grid, err := ParseGrid("short_period=5,10;long_period=30,50,100")
+	require.NoError(t, err)
+
+	combinations := grid.Combinations()
+
+	require.Len(t, combinations, 6)
+	assert.Equal(t, "long_period=30 short_period=5", combinations[0].String())
+	assert.Equal(t, "long_period=30 short_period=10", combinations[1].String())
+	assert.Equal(t, "long_period=100 short_period=10", combinations[5].String())
+}
@@ -0,0 +1,38 @@
+package optimize
+
+import (
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/analytics"
+	"github.com/shopspring/decimal"
+)
+
+type Objective string
+
+const (
+	ObjectiveSharpe         Objective = "sharpe"
+	ObjectiveTotalReturn    Objective = "total_return"
+	ObjectiveReturnDrawdown Objective = "return_drawdown"
+)
+
+var drawdownFloor = decimal.NewFromFloat(0.01)
+
+func ParseObjective(name string) (Objective, error) {
+	switch objective := Objective(name); objective {
+	case ObjectiveSharpe, ObjectiveTotalReturn, ObjectiveReturnDrawdown:
+		return objective, nil
+	}
+	return "", fmt.Errorf("%w: %q (expected %s, %s or %s)", ErrInvalidObjective, name,
+		ObjectiveSharpe, ObjectiveTotalReturn, ObjectiveReturnDrawdown)
+}
+
+func (o Objective) Score(report *analytics.PerformanceReport) decimal.Decimal {
+	switch o {
+	case ObjectiveTotalReturn:
+		return report.TotalReturn
+	case ObjectiveReturnDrawdown:
+		return report.TotalReturn.Div(decimal.Max(report.MaxDrawdown, drawdownFloor))
+	default:
+		return report.SharpeRatio
+	}
+}
@@ -0,0 +1,23 @@
+package optimize
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjective_Score(t *testing.T) {
+	objective, err := ParseObjective("return_drawdown")
+	require.NoError(t, err)
+
+	report := createTestReport(0.2, 0.1, 1.5)
+	assert.True(t, decimal.NewFromInt(2).Equal(objective.Score(report)))
+	assert.True(t, decimal.NewFromInt(20).Equal(objective.Score(createTestReport(0.2, 0, 1.5))))
+	assert.True(t, decimal.NewFromFloat(1.5).Equal(ObjectiveSharpe.Score(report)))
+	assert.True(t, decimal.NewFromFloat(0.2).Equal(ObjectiveTotalReturn.Score(report)))
+
+	_, err = ParseObjective("sortino")
+	assert.ErrorIs(t, err, ErrInvalidObjective)
+}
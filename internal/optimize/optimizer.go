@@ -0,0 +1,184 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/analytics"
+	"github.com/1cbyc/trade-algo-go/internal/backtest"
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type StrategyBuilder func() (strategies.Strategy, error)
+
+type EngineBuilder func() (*engine.TradingEngine, error)
+
+type Optimizer struct {
+	data      []*models.MarketData
+	strategy  StrategyBuilder
+	engine    EngineBuilder
+	objective Objective
+	workers   int
+	logger    *zap.Logger
+}
+
+type Option func(*Optimizer)
+
+func WithEngine(builder EngineBuilder) Option {
+	return func(o *Optimizer) {
+		o.engine = builder
+	}
+}
+
+func WithObjective(objective Objective) Option {
+	return func(o *Optimizer) {
+		o.objective = objective
+	}
+}
+
+func WithWorkers(workers int) Option {
+	return func(o *Optimizer) {
+		if workers > 0 {
+			o.workers = workers
+		}
+	}
+}
+
+type Result struct {
+	Rank        int             `json:"rank"`
+	Params      Params          `json:"params"`
+	Score       decimal.Decimal `json:"score"`
+	TotalReturn decimal.Decimal `json:"total_return"`
+	SharpeRatio decimal.Decimal `json:"sharpe_ratio"`
+	MaxDrawdown decimal.Decimal `json:"max_drawdown"`
+	TotalTrades int             `json:"total_trades"`
+	FinalValue  decimal.Decimal `json:"final_value"`
+}
+
+func NewOptimizer(data []*models.MarketData, strategy StrategyBuilder, initialCash decimal.Decimal, logger *zap.Logger, opts ...Option) *Optimizer {
+	o := &Optimizer{
+		data:     data,
+		strategy: strategy,
+		engine: func() (*engine.TradingEngine, error) {
+			return engine.NewTradingEngine(initialCash, zap.NewNop()), nil
+		},
+		objective: ObjectiveSharpe,
+		workers:   runtime.NumCPU(),
+		logger:    logger,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *Optimizer) Run(ctx context.Context, grid Grid) ([]Result, error) {
+	results, err := o.search(ctx, grid, nil, o.data)
+	if err != nil {
+		return nil, err
+	}
+	o.logger.Info("Parameter sweep completed",
+		zap.Int("combinations", len(results)),
+		zap.String("objective", string(o.objective)),
+		zap.String("best", results[0].Params.String()),
+		zap.String("score", results[0].Score.String()))
+	return results, nil
+}
+
+func (o *Optimizer) search(ctx context.Context, grid Grid, warmup, bars []*models.MarketData) ([]Result, error) {
+	combinations := grid.Combinations()
+	if len(combinations) == 0 {
+		return nil, fmt.Errorf("%w: a parameter has no values", ErrInvalidGrid)
+	}
+
+	results := make([]Result, len(combinations))
+	errs := make([]error, len(combinations))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(o.workers, len(combinations)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = o.evaluate(ctx, combinations[i], warmup, bars)
+			}
+		}()
+	}
+	for i := range combinations {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", combinations[i], err)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score.GreaterThan(results[j].Score)
+	})
+	for i := range results {
+		results[i].Rank = i + 1
+	}
+	return results, nil
+}
+
+func (o *Optimizer) evaluate(ctx context.Context, params Params, warmup, bars []*models.MarketData) (Result, error) {
+	tradingEngine, err := o.engine()
+	if err != nil {
+		return Result{}, err
+	}
+	strategy, err := o.strategy()
+	if err != nil {
+		return Result{}, err
+	}
+	if tunable, ok := strategy.(strategies.Tunable); ok {
+		if err := tunable.SetParameters(params); err != nil {
+			return Result{}, err
+		}
+	} else if len(params) > 0 {
+		return Result{}, fmt.Errorf("%w: %s", ErrNotTunable, strategy.ID())
+	}
+	tradingEngine.AddStrategy(strategy)
+
+	for _, data := range replay(warmup) {
+		tradingEngine.UpdateMarketData(data.Symbol, data)
+	}
+	replayer := backtest.NewReplayer(replay(bars), zap.NewNop())
+	replayer.Start(ctx)
+	portfolio, err := backtest.NewRunner(tradingEngine, zap.NewNop()).Run(ctx, replayer.GetUpdateChannel())
+	tradingEngine.Stop()
+	if err != nil {
+		return Result{}, err
+	}
+
+	report := analytics.GeneratePerformanceReport(portfolio, tradingEngine.GetEquityCurve())
+	report.AddDailyStatements(tradingEngine.GetDailyStatements())
+	return Result{
+		Params:      params,
+		Score:       o.objective.Score(report),
+		TotalReturn: report.TotalReturn,
+		SharpeRatio: report.SharpeRatio,
+		MaxDrawdown: report.MaxDrawdown,
+		TotalTrades: report.TotalTrades,
+		FinalValue:  report.FinalValue,
+	}, nil
+}
+
+func replay(bars []*models.MarketData) []*models.MarketData {
+	copies := make([]*models.MarketData, len(bars))
+	for i, bar := range bars {
+		copied := *bar
+		copies[i] = &copied
+	}
+	return copies
+}
@@ -0,0 +1,110 @@
+package optimize
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func createTestBars(days int) []*models.MarketData {
+	bars := make([]*models.MarketData, days)
+	for i := range bars {
+		price := decimal.NewFromFloat(100 + 15*math.Sin(float64(i)/9) + 0.1*float64(i)).Round(2)
+		bars[i] = &models.MarketData{
+			Symbol:    "AAPL",
+			Kind:      models.MarketDataKindBar,
+			Price:     price,
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Close:     price,
+			Volume:    100000,
+			Timestamp: testStart.Add(time.Duration(i) * 24 * time.Hour),
+		}
+	}
+	return bars
+}
+
+func createTestStrategy() (strategies.Strategy, error) {
+	return strategies.NewMovingAverageStrategy(&models.StrategyConfig{
+		ID:               "optimize_ma",
+		Name:             "Optimize MA",
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+		MinOrderSize:     decimal.NewFromFloat(1000.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+		CommissionRate:   decimal.NewFromFloat(0.001),
+		Enabled:          true,
+	}), nil
+}
+
+func createTestGrid() Grid {
+	return Grid{
+		"short_period": {decimal.NewFromInt(3), decimal.NewFromInt(5)},
+		"long_period":  {decimal.NewFromInt(10), decimal.NewFromInt(20)},
+	}
+}
+
+func createTestOptimizer(objective Objective, workers int) *Optimizer {
+	return NewOptimizer(createTestBars(240), createTestStrategy, decimal.NewFromInt(100000), zap.NewNop(),
+		WithObjective(objective), WithWorkers(workers))
+}
+
+func TestOptimizer_Run_RanksByObjective(t *testing.T) {
+	results, err := createTestOptimizer(ObjectiveTotalReturn, 2).Run(context.Background(), createTestGrid())
+
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	for i, result := range results {
+		assert.Equal(t, i+1, result.Rank)
+		assert.True(t, result.Score.Equal(result.TotalReturn))
+		assert.Positive(t, result.TotalTrades, result.Params.String())
+		if i > 0 {
+			assert.True(t, results[i-1].Score.GreaterThanOrEqual(result.Score))
+		}
+	}
+}
+
+func TestOptimizer_Run_Deterministic(t *testing.T) {
+	serial, err := createTestOptimizer(ObjectiveSharpe, 1).Run(context.Background(), createTestGrid())
+	require.NoError(t, err)
+	parallel, err := createTestOptimizer(ObjectiveSharpe, 4).Run(context.Background(), createTestGrid())
+	require.NoError(t, err)
+
+	assert.Equal(t, serial, parallel)
+}
+
+func TestOptimizer_Run_InvalidParameters(t *testing.T) {
+	grid := Grid{"short_period": {decimal.NewFromInt(30)}, "long_period": {decimal.NewFromInt(10)}}
+
+	_, err := createTestOptimizer(ObjectiveSharpe, 1).Run(context.Background(), grid)
+
+	assert.ErrorIs(t, err, strategies.ErrInvalidConfig)
+}
+
+func TestOptimizer_Run_NotTunable(t *testing.T) {
+	grid := func() (strategies.Strategy, error) {
+		return strategies.NewGridStrategy(&models.StrategyConfig{ID: "grid", Enabled: true}), nil
+	}
+	optimizer := NewOptimizer(createTestBars(10), grid, decimal.NewFromInt(100000), zap.NewNop())
+
+	_, err := optimizer.Run(context.Background(), createTestGrid())
+
+	assert.ErrorIs(t, err, ErrNotTunable)
+}
+
+func TestOptimizer_Run_EmptyGrid(t *testing.T) {
+	_, err := createTestOptimizer(ObjectiveSharpe, 1).Run(context.Background(), Grid{"short_period": nil})
+
+	assert.ErrorIs(t, err, ErrInvalidGrid)
+}
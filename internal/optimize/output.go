@@ -0,0 +1,136 @@
+package optimize
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var resultHeader = []string{"score", "total_return", "sharpe_ratio", "max_drawdown", "total_trades", "final_value"}
+
+func WriteResults(dir string, results []Result) ([]string, error) {
+	return writeFiles(dir, "optimize", func(w io.Writer) error { return WriteResultsCSV(w, results) }, results)
+}
+
+func WriteWalkForward(dir string, report *WalkForwardReport) ([]string, error) {
+	return writeFiles(dir, "walk_forward", func(w io.Writer) error { return WriteWalkForwardCSV(w, report.Windows) }, report)
+}
+
+func WriteResultsCSV(w io.Writer, results []Result) error {
+	params := make([]Params, len(results))
+	for i, result := range results {
+		params[i] = result.Params
+	}
+	names := paramNames(params)
+
+	records := make([][]string, len(results))
+	for i, result := range results {
+		records[i] = append(append([]string{strconv.Itoa(result.Rank)}, paramValues(result.Params, names)...), resultValues(result)...)
+	}
+	return writeCSV(w, append(append([]string{"rank"}, names...), resultHeader...), records)
+}
+
+func WriteWalkForwardCSV(w io.Writer, windows []WindowResult) error {
+	params := make([]Params, len(windows))
+	for i, window := range windows {
+		params[i] = window.Params
+	}
+	names := paramNames(params)
+
+	header := append([]string{"in_sample_start", "out_of_sample_start", "out_of_sample_end", "in_sample_score"}, names...)
+	records := make([][]string, len(windows))
+	for i, window := range windows {
+		records[i] = append(append([]string{
+			window.InSampleStart.Format(time.RFC3339),
+			window.OutOfSampleStart.Format(time.RFC3339),
+			window.OutOfSampleEnd.Format(time.RFC3339),
+			window.InSampleScore.String(),
+		}, paramValues(window.Params, names)...), resultValues(window.OutOfSample)...)
+	}
+	return writeCSV(w, append(header, resultHeader...), records)
+}
+
+func resultValues(result Result) []string {
+	return []string{
+		result.Score.String(),
+		result.TotalReturn.String(),
+		result.SharpeRatio.String(),
+		result.MaxDrawdown.String(),
+		strconv.Itoa(result.TotalTrades),
+		result.FinalValue.String(),
+	}
+}
+
+func paramNames(params []Params) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, set := range params {
+		for name := range set {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func paramValues(params Params, names []string) []string {
+	values := make([]string, len(names))
+	for i, name := range names {
+		if value, exists := params[name]; exists {
+			values[i] = value.String()
+		}
+	}
+	return values
+}
+
+func writeCSV(w io.Writer, header []string, records [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func writeFiles(dir, name string, writeTable func(io.Writer) error, document interface{}) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	base := filepath.Join(dir, name)
+	if err := writeFile(base+".csv", writeTable); err != nil {
+		return nil, err
+	}
+	if err := writeFile(base+".json", func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(document)
+	}); err != nil {
+		return []string{base + ".csv"}, err
+	}
+	return []string{base + ".csv", base + ".json"}, nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return file.Close()
+}
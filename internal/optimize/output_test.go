@@ -0,0 +1,56 @@
+package optimize
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/analytics"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestReport(totalReturn, maxDrawdown, sharpe float64) *analytics.PerformanceReport {
+	return &analytics.PerformanceReport{
+		TotalReturn: decimal.NewFromFloat(totalReturn),
+		MaxDrawdown: decimal.NewFromFloat(maxDrawdown),
+		SharpeRatio: decimal.NewFromFloat(sharpe),
+	}
+}
+
+func createTestResults() []Result {
+	return []Result{
+		{Rank: 1, Params: Params{"short_period": decimal.NewFromInt(5), "long_period": decimal.NewFromInt(30)}, Score: decimal.NewFromFloat(1.2), TotalTrades: 4},
+		{Rank: 2, Params: Params{"short_period": decimal.NewFromInt(10), "long_period": decimal.NewFromInt(30)}, Score: decimal.NewFromFloat(0.8), TotalTrades: 2},
+	}
+}
+
+func TestWriteResultsCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteResultsCSV(&buf, createTestResults()))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "rank,long_period,short_period,score,total_return,sharpe_ratio,max_drawdown,total_trades,final_value", lines[0])
+	assert.Equal(t, "1,30,5,1.2,0,0,0,4,0", lines[1])
+}
+
+func TestWriteResults(t *testing.T) {
+	dir := t.TempDir()
+
+	paths, err := WriteResults(dir, createTestResults())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "optimize.csv"), filepath.Join(dir, "optimize.json")}, paths)
+	content, err := os.ReadFile(paths[1])
+	require.NoError(t, err)
+	var decoded []Result
+	require.NoError(t, json.Unmarshal(content, &decoded))
+	require.Len(t, decoded, 2)
+	assert.True(t, decimal.NewFromInt(10).Equal(decoded[1].Params["short_period"]))
+}
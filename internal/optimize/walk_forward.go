@@ -0,0 +1,92 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type WalkForwardConfig struct {
+	InSample    time.Duration
+	OutOfSample time.Duration
+}
+
+type WindowResult struct {
+	InSampleStart    time.Time       `json:"in_sample_start"`
+	OutOfSampleStart time.Time       `json:"out_of_sample_start"`
+	OutOfSampleEnd   time.Time       `json:"out_of_sample_end"`
+	Params           Params          `json:"params"`
+	InSampleScore    decimal.Decimal `json:"in_sample_score"`
+	OutOfSample      Result          `json:"out_of_sample"`
+}
+
+type WalkForwardReport struct {
+	Objective   Objective       `json:"objective"`
+	Windows     []WindowResult  `json:"windows"`
+	TotalReturn decimal.Decimal `json:"total_return"`
+}
+
+func (o *Optimizer) WalkForward(ctx context.Context, grid Grid, config WalkForwardConfig) (*WalkForwardReport, error) {
+	if config.InSample <= 0 || config.OutOfSample <= 0 {
+		return nil, fmt.Errorf("%w: in-sample and out-of-sample lengths must be positive", ErrInvalidWindow)
+	}
+
+	if len(o.data) == 0 {
+		return nil, fmt.Errorf("%w: no market data", ErrInvalidWindow)
+	}
+
+	report := &WalkForwardReport{Objective: o.objective}
+	first, last := o.data[0].Timestamp, o.data[len(o.data)-1].Timestamp
+
+	growth := decimal.NewFromInt(1)
+	for start := first; !start.Add(config.InSample).After(last); start = start.Add(config.OutOfSample) {
+		split := start.Add(config.InSample)
+		end := split.Add(config.OutOfSample)
+		inSample, outOfSample := o.between(start, split), o.between(split, end)
+		if len(inSample) == 0 || len(outOfSample) == 0 {
+			continue
+		}
+
+		ranked, err := o.search(ctx, grid, nil, inSample)
+		if err != nil {
+			return nil, fmt.Errorf("window %s: %w", start.Format(time.RFC3339), err)
+		}
+		best := ranked[0]
+		result, err := o.evaluate(ctx, best.Params, inSample, outOfSample)
+		if err != nil {
+			return nil, fmt.Errorf("window %s: %w", start.Format(time.RFC3339), err)
+		}
+
+		report.Windows = append(report.Windows, WindowResult{
+			InSampleStart:    start,
+			OutOfSampleStart: split,
+			OutOfSampleEnd:   end,
+			Params:           best.Params,
+			InSampleScore:    best.Score,
+			OutOfSample:      result,
+		})
+		growth = growth.Mul(decimal.NewFromInt(1).Add(result.TotalReturn))
+	}
+	if len(report.Windows) == 0 {
+		return nil, fmt.Errorf("%w: data from %s to %s is shorter than one window", ErrInvalidWindow,
+			first.Format(time.RFC3339), last.Format(time.RFC3339))
+	}
+	report.TotalReturn = growth.Sub(decimal.NewFromInt(1))
+
+	o.logger.Info("Walk-forward optimization completed",
+		zap.Int("windows", len(report.Windows)),
+		zap.String("objective", string(o.objective)),
+		zap.String("out_of_sample_return", report.TotalReturn.String()))
+	return report, nil
+}
+
+func (o *Optimizer) between(start, end time.Time) []*models.MarketData {
+	from := sort.Search(len(o.data), func(i int) bool { return !o.data[i].Timestamp.Before(start) })
+	to := sort.Search(len(o.data), func(i int) bool { return !o.data[i].Timestamp.Before(end) })
+	return o.data[from:to]
+}
@@ -0,0 +1,48 @@
+package optimize
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOptimizer_WalkForward(t *testing.T) {
+	config := WalkForwardConfig{InSample: 90 * 24 * time.Hour, OutOfSample: 30 * 24 * time.Hour}
+
+	report, err := createTestOptimizer(ObjectiveTotalReturn, 2).WalkForward(context.Background(), createTestGrid(), config)
+
+	require.NoError(t, err)
+	require.Len(t, report.Windows, 5)
+	growth := decimal.NewFromInt(1)
+	for i, window := range report.Windows {
+		assert.Equal(t, testStart.Add(time.Duration(i)*config.OutOfSample), window.InSampleStart)
+		assert.Equal(t, window.InSampleStart.Add(config.InSample), window.OutOfSampleStart)
+		assert.Equal(t, window.OutOfSampleStart.Add(config.OutOfSample), window.OutOfSampleEnd)
+		assert.Len(t, window.Params, 2)
+		assert.True(t, window.OutOfSample.Score.Equal(window.OutOfSample.TotalReturn))
+		growth = growth.Mul(decimal.NewFromInt(1).Add(window.OutOfSample.TotalReturn))
+
+		bars := createTestBars(240)[i*30 : i*30+90]
+		inSample, err := NewOptimizer(bars, createTestStrategy, decimal.NewFromInt(100000), zap.NewNop(),
+			WithObjective(ObjectiveTotalReturn)).Run(context.Background(), createTestGrid())
+		require.NoError(t, err)
+		assert.Equal(t, inSample[0].Params, window.Params)
+		assert.True(t, inSample[0].Score.Equal(window.InSampleScore))
+	}
+	assert.True(t, growth.Sub(decimal.NewFromInt(1)).Equal(report.TotalReturn))
+}
+
+func TestOptimizer_WalkForward_InvalidWindow(t *testing.T) {
+	optimizer := createTestOptimizer(ObjectiveSharpe, 1)
+
+	_, err := optimizer.WalkForward(context.Background(), createTestGrid(), WalkForwardConfig{InSample: 24 * time.Hour})
+	assert.ErrorIs(t, err, ErrInvalidWindow)
+
+	_, err = optimizer.WalkForward(context.Background(), createTestGrid(), WalkForwardConfig{InSample: 365 * 24 * time.Hour, OutOfSample: 24 * time.Hour})
+	assert.ErrorIs(t, err, ErrInvalidWindow)
+}
@@ -0,0 +1,7 @@
+package risk
+
+import "errors"
+
+var (
+	ErrUnknownVaRMethod = errors.New("unknown VaR method")
+)
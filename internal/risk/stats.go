@@ -0,0 +1,220 @@
+package risk
+
+import (
+	"math"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ZScore95               = 1.645
+	ExpectedShortfallRatio = 1.25
+	MinObservations        = 10
+)
+
+func Prices(history []*models.MarketData) []decimal.Decimal {
+	prices := make([]decimal.Decimal, len(history))
+	for i, data := range history {
+		prices[i] = data.Price
+	}
+	return prices
+}
+
+func AlignPrices(a, b []*models.MarketData) ([]decimal.Decimal, []decimal.Decimal) {
+	var alignedA, alignedB []decimal.Decimal
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i].Timestamp.Before(b[j].Timestamp):
+			i++
+		case b[j].Timestamp.Before(a[i].Timestamp):
+			j++
+		default:
+			alignedA = append(alignedA, a[i].Price)
+			alignedB = append(alignedB, b[j].Price)
+			i++
+			j++
+		}
+	}
+	return alignedA, alignedB
+}
+
+func Returns(prices []decimal.Decimal) []float64 {
+	if len(prices) < 2 {
+		return nil
+	}
+
+	returns := make([]float64, 0, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1].IsZero() {
+			continue
+		}
+		returns = append(returns, prices[i].Sub(prices[i-1]).Div(prices[i-1]).InexactFloat64())
+	}
+	return returns
+}
+
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func Variance(values []float64) float64 {
+	return Covariance(values, values)
+}
+
+func StdDev(values []float64) float64 {
+	return math.Sqrt(Variance(values))
+}
+
+func Covariance(a, b []float64) float64 {
+	a, b = tail(a, b)
+	if len(a) == 0 {
+		return 0
+	}
+
+	meanA, meanB := Mean(a), Mean(b)
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - meanA) * (b[i] - meanB)
+	}
+	return sum / float64(len(a))
+}
+
+func Correlation(a, b []float64) (float64, bool) {
+	a, b = tail(a, b)
+	if len(a) < MinObservations {
+		return 0, false
+	}
+
+	deviation := StdDev(a) * StdDev(b)
+	if deviation == 0 {
+		return 0, false
+	}
+	return clamp(Covariance(a, b)/deviation, -1, 1), true
+}
+
+func Beta(returns, benchmark []float64) (float64, bool) {
+	returns, benchmark = tail(returns, benchmark)
+	if len(returns) < MinObservations {
+		return 0, false
+	}
+
+	variance := Variance(benchmark)
+	if variance == 0 {
+		return 0, false
+	}
+	return Covariance(returns, benchmark) / variance, true
+}
+
+func HistoryBeta(history, benchmark []*models.MarketData) (float64, bool) {
+	prices, benchmarkPrices := AlignPrices(history, benchmark)
+	return Beta(Returns(prices), Returns(benchmarkPrices))
+}
+
+func SharpeRatio(returns []float64, riskFreeRate float64, periodsPerYear int) (float64, bool) {
+	if len(returns) < 2 {
+		return 0, false
+	}
+
+	deviation := StdDev(returns)
+	if deviation == 0 {
+		return 0, false
+	}
+
+	if periodsPerYear <= 0 {
+		return (Mean(returns) - riskFreeRate) / deviation, true
+	}
+	periods := float64(periodsPerYear)
+	return (Mean(returns) - riskFreeRate/periods) / deviation * math.Sqrt(periods), true
+}
+
+func SortinoRatio(returns []float64, riskFreeRate float64, periodsPerYear int) (float64, bool) {
+	if len(returns) < 2 {
+		return 0, false
+	}
+
+	target := riskFreeRate
+	if periodsPerYear > 0 {
+		target = riskFreeRate / float64(periodsPerYear)
+	}
+
+	downside := 0.0
+	for _, r := range returns {
+		if r < target {
+			downside += (r - target) * (r - target)
+		}
+	}
+	deviation := math.Sqrt(downside / float64(len(returns)))
+	if deviation == 0 {
+		return 0, false
+	}
+
+	ratio := (Mean(returns) - target) / deviation
+	if periodsPerYear > 0 {
+		ratio *= math.Sqrt(float64(periodsPerYear))
+	}
+	return ratio, true
+}
+
+func MaxDrawdown(values []float64) float64 {
+	peak := 0.0
+	maxDrawdown := 0.0
+	for _, value := range values {
+		if value > peak {
+			peak = value
+		}
+		if peak > 0 {
+			if drawdown := (peak - value) / peak; drawdown > maxDrawdown {
+				maxDrawdown = drawdown
+			}
+		}
+	}
+	return maxDrawdown
+}
+
+func ParametricVaR(exposure, volatility float64) float64 {
+	return math.Abs(exposure) * volatility * ZScore95
+}
+
+func AggregateVaR(positionVaR []float64, correlation [][]float64) float64 {
+	total := 0.0
+	for i := range positionVaR {
+		for j := range positionVaR {
+			total += positionVaR[i] * positionVaR[j] * correlation[i][j]
+		}
+	}
+	if total <= 0 {
+		return 0
+	}
+	return math.Sqrt(total)
+}
+
+func Herfindahl(weights []float64) float64 {
+	sum := 0.0
+	for _, weight := range weights {
+		sum += weight * weight
+	}
+	return sum
+}
+
+func tail(a, b []float64) ([]float64, []float64) {
+	if len(a) > len(b) {
+		a = a[len(a)-len(b):]
+	} else if len(b) > len(a) {
+		b = b[len(b)-len(a):]
+	}
+	return a, b
+}
+
+func clamp(value, low, high float64) float64 {
+	return math.Max(low, math.Min(high, value))
+}
@@ -0,0 +1,211 @@
+package risk
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturns(t *testing.T) {
+	prices := []decimal.Decimal{
+		decimal.NewFromFloat(100),
+		decimal.NewFromFloat(110),
+		decimal.NewFromFloat(99),
+	}
+
+	returns := Returns(prices)
+
+	require.Len(t, returns, 2)
+	assert.InDelta(t, 0.1, returns[0], 1e-12)
+	assert.InDelta(t, -0.1, returns[1], 1e-12)
+	assert.Nil(t, Returns(prices[:1]))
+}
+
+func TestVarianceAndCovariance(t *testing.T) {
+	a := []float64{1, 2, 3, 4}
+	b := []float64{2, 4, 6, 8}
+
+	assert.InDelta(t, 1.25, Variance(a), 1e-12)
+	assert.InDelta(t, 2.5, Covariance(a, b), 1e-12)
+	assert.Zero(t, Variance(nil))
+}
+
+func TestCorrelation(t *testing.T) {
+	a := make([]float64, MinObservations)
+	b := make([]float64, MinObservations)
+	c := make([]float64, MinObservations)
+	for i := range a {
+		a[i] = float64(i%3) - 1
+		b[i] = 3 * a[i]
+		c[i] = -a[i]
+	}
+
+	positive, ok := Correlation(a, b)
+	require.True(t, ok)
+	assert.InDelta(t, 1.0, positive, 1e-12)
+
+	negative, ok := Correlation(a, c)
+	require.True(t, ok)
+	assert.InDelta(t, -1.0, negative, 1e-12)
+
+	_, ok = Correlation(a[:MinObservations-1], b[:MinObservations-1])
+	assert.False(t, ok)
+
+	_, ok = Correlation(a, make([]float64, MinObservations))
+	assert.False(t, ok)
+}
+
+func TestAggregateVaR(t *testing.T) {
+	positionVaR := []float64{300, 400}
+
+	perfect := AggregateVaR(positionVaR, [][]float64{{1, 1}, {1, 1}})
+	independent := AggregateVaR(positionVaR, [][]float64{{1, 0}, {0, 1}})
+	hedged := AggregateVaR([]float64{300, -300}, [][]float64{{1, 1}, {1, 1}})
+
+	assert.InDelta(t, 700, perfect, 1e-9)
+	assert.InDelta(t, 500, independent, 1e-9)
+	assert.Zero(t, hedged)
+}
+
+func TestParametricVaR(t *testing.T) {
+	assert.InDelta(t, 10000*0.02*ZScore95, ParametricVaR(-10000, 0.02), 1e-9)
+}
+
+func TestHerfindahl(t *testing.T) {
+	assert.InDelta(t, 1.0, Herfindahl([]float64{1}), 1e-12)
+	assert.InDelta(t, 0.25, Herfindahl([]float64{0.25, 0.25, 0.25, 0.25}), 1e-12)
+}
+
+func TestAlignPrices(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := []*models.MarketData{
+		{Price: decimal.NewFromInt(1), Timestamp: start},
+		{Price: decimal.NewFromInt(2), Timestamp: start.Add(time.Minute)},
+		{Price: decimal.NewFromInt(3), Timestamp: start.Add(2 * time.Minute)},
+	}
+	b := []*models.MarketData{
+		{Price: decimal.NewFromInt(20), Timestamp: start.Add(time.Minute)},
+		{Price: decimal.NewFromInt(30), Timestamp: start.Add(2 * time.Minute)},
+		{Price: decimal.NewFromInt(40), Timestamp: start.Add(3 * time.Minute)},
+	}
+
+	alignedA, alignedB := AlignPrices(a, b)
+
+	require.Len(t, alignedA, 2)
+	require.Len(t, alignedB, 2)
+	assert.True(t, decimal.NewFromInt(2).Equal(alignedA[0]))
+	assert.True(t, decimal.NewFromInt(30).Equal(alignedB[1]))
+	assert.False(t, math.IsNaN(StdDev(Returns(alignedA))))
+}
+
+func TestBeta(t *testing.T) {
+	benchmark := alternatingReturns(20, 0.01)
+	doubled := make([]float64, len(benchmark))
+	for i, value := range benchmark {
+		doubled[i] = 2 * value
+	}
+
+	beta, ok := Beta(doubled, benchmark)
+	require.True(t, ok)
+	assert.InDelta(t, 2.0, beta, 1e-12)
+
+	_, ok = Beta(doubled[:MinObservations-1], benchmark[:MinObservations-1])
+	assert.False(t, ok)
+	_, ok = Beta(doubled, make([]float64, len(doubled)))
+	assert.False(t, ok)
+}
+
+func TestHistoryBeta(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	benchmarkReturns := alternatingReturns(20, 0.01)
+	doubledReturns := make([]float64, len(benchmarkReturns))
+	orthogonalReturns := make([]float64, len(benchmarkReturns))
+	for i, value := range benchmarkReturns {
+		doubledReturns[i] = 2 * value
+		orthogonalReturns[i] = 0.01
+		if i%4 >= 2 {
+			orthogonalReturns[i] = -0.01
+		}
+	}
+	benchmark := historyFromReturns(start, 100, benchmarkReturns)
+
+	two, ok := HistoryBeta(historyFromReturns(start, 50, doubledReturns), benchmark)
+	require.True(t, ok)
+	assert.InDelta(t, 2.0, two, 1e-9)
+
+	zero, ok := HistoryBeta(historyFromReturns(start, 50, orthogonalReturns), benchmark)
+	require.True(t, ok)
+	assert.InDelta(t, 0.0, zero, 1e-9)
+
+	_, ok = HistoryBeta(historyFromReturns(start.Add(time.Hour), 50, doubledReturns), benchmark)
+	assert.False(t, ok)
+}
+
+func alternatingReturns(n int, size float64) []float64 {
+	returns := make([]float64, n)
+	for i := range returns {
+		returns[i] = size
+		if i%2 == 1 {
+			returns[i] = -size
+		}
+	}
+	return returns
+}
+
+func historyFromReturns(start time.Time, base float64, returns []float64) []*models.MarketData {
+	price := decimal.NewFromFloat(base)
+	history := []*models.MarketData{{Price: price, Timestamp: start}}
+	for i, value := range returns {
+		price = price.Mul(decimal.NewFromFloat(1 + value))
+		history = append(history, &models.MarketData{Price: price, Timestamp: start.Add(time.Duration(i+1) * time.Minute)})
+	}
+	return history
+}
+
+func TestSharpeRatio(t *testing.T) {
+	returns := make([]float64, 20)
+	for i := range returns {
+		if i%2 == 0 {
+			returns[i] = 0.02
+		}
+	}
+
+	perPeriod, ok := SharpeRatio(returns, 0, 0)
+	require.True(t, ok)
+	assert.InDelta(t, 1.0, perPeriod, 1e-12)
+
+	annualized, ok := SharpeRatio(returns, 0.252, 252)
+	require.True(t, ok)
+	assert.InDelta(t, 0.9*math.Sqrt(252), annualized, 1e-9)
+
+	_, ok = SharpeRatio([]float64{0.01, 0.01, 0.01}, 0, 252)
+	assert.False(t, ok)
+	_, ok = SharpeRatio([]float64{0.01}, 0, 252)
+	assert.False(t, ok)
+}
+
+func TestSortinoRatio(t *testing.T) {
+	returns := []float64{0.02, -0.01, 0.02, -0.01}
+
+	ratio, ok := SortinoRatio(returns, 0, 0)
+	require.True(t, ok)
+	assert.InDelta(t, 0.005/math.Sqrt(0.00005), ratio, 1e-9)
+
+	annualized, ok := SortinoRatio(returns, 0, 252)
+	require.True(t, ok)
+	assert.InDelta(t, ratio*math.Sqrt(252), annualized, 1e-9)
+
+	_, ok = SortinoRatio([]float64{0.01, 0.02}, 0, 252)
+	assert.False(t, ok)
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	assert.InDelta(t, 0.1, MaxDrawdown([]float64{100, 110, 99, 105, 120, 114}), 1e-12)
+	assert.Zero(t, MaxDrawdown([]float64{100, 101, 102}))
+	assert.Zero(t, MaxDrawdown(nil))
+}
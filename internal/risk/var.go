@@ -0,0 +1,169 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	VaRParametric = "parametric"
+	VaRHistorical = "historical"
+	VaRMonteCarlo = "monte_carlo"
+
+	VaRTail                = 0.05
+	DefaultMonteCarloPaths = 10000
+	DefaultMonteCarloSeed  = 1
+)
+
+type VaRModel interface {
+	VaR(exposure float64, returns []float64) float64
+}
+
+type ParametricModel struct {
+	HoldingPeriod int
+}
+
+func (m ParametricModel) VaR(exposure float64, returns []float64) float64 {
+	return ParametricVaR(exposure, StdDev(returns)) * horizonScale(m.HoldingPeriod)
+}
+
+type HistoricalModel struct {
+	HoldingPeriod int
+}
+
+func (m HistoricalModel) VaR(exposure float64, returns []float64) float64 {
+	if len(returns) == 0 || exposure == 0 {
+		return 0
+	}
+
+	direction := math.Copysign(1, exposure)
+	pnl := make([]float64, len(returns))
+	for i, r := range returns {
+		pnl[i] = direction * r
+	}
+	return tailLoss(pnl, exposure) * horizonScale(m.HoldingPeriod)
+}
+
+type MonteCarloModel struct {
+	HoldingPeriod int
+	Paths         int
+	Seed          int64
+}
+
+func (m MonteCarloModel) VaR(exposure float64, returns []float64) float64 {
+	if len(returns) == 0 || exposure == 0 {
+		return 0
+	}
+
+	paths := m.Paths
+	if paths <= 0 {
+		paths = DefaultMonteCarloPaths
+	}
+	periods := max(m.HoldingPeriod, 1)
+	sigma := StdDev(returns)
+	direction := math.Copysign(1, exposure)
+	rng := rand.New(rand.NewSource(m.Seed))
+
+	pnl := make([]float64, paths)
+	for i := range pnl {
+		path := 0.0
+		for p := 0; p < periods; p++ {
+			path += rng.NormFloat64() * sigma
+		}
+		pnl[i] = direction * path
+	}
+	return tailLoss(pnl, exposure)
+}
+
+func NewVaRModel(method string, holdingPeriod int) (VaRModel, error) {
+	switch method {
+	case "", VaRParametric:
+		return ParametricModel{HoldingPeriod: holdingPeriod}, nil
+	case VaRHistorical:
+		return HistoricalModel{HoldingPeriod: holdingPeriod}, nil
+	case VaRMonteCarlo:
+		return MonteCarloModel{HoldingPeriod: holdingPeriod, Paths: DefaultMonteCarloPaths, Seed: DefaultMonteCarloSeed}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownVaRMethod, method)
+}
+
+func Quantile(values []float64, q float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+func PortfolioReturns(histories [][]*models.MarketData, exposures []float64) []float64 {
+	gross := 0.0
+	for _, exposure := range exposures {
+		gross += math.Abs(exposure)
+	}
+	if len(histories) == 0 || gross == 0 {
+		return nil
+	}
+
+	aligned := AlignHistories(histories...)
+	series := make([][]float64, len(aligned))
+	for i, prices := range aligned {
+		series[i] = Returns(prices)
+	}
+
+	length := len(series[0])
+	for _, r := range series {
+		length = min(length, len(r))
+	}
+
+	returns := make([]float64, length)
+	for i, r := range series {
+		r = r[len(r)-length:]
+		for t := range returns {
+			returns[t] += exposures[i] / gross * r[t]
+		}
+	}
+	return returns
+}
+
+func AlignHistories(histories ...[]*models.MarketData) [][]decimal.Decimal {
+	counts := make(map[int64]int)
+	for _, history := range histories {
+		seen := make(map[int64]bool, len(history))
+		for _, data := range history {
+			if !seen[data.Timestamp.UnixNano()] {
+				seen[data.Timestamp.UnixNano()] = true
+				counts[data.Timestamp.UnixNano()]++
+			}
+		}
+	}
+
+	aligned := make([][]decimal.Decimal, len(histories))
+	for i, history := range histories {
+		seen := make(map[int64]bool, len(history))
+		for _, data := range history {
+			if counts[data.Timestamp.UnixNano()] == len(histories) && !seen[data.Timestamp.UnixNano()] {
+				seen[data.Timestamp.UnixNano()] = true
+				aligned[i] = append(aligned[i], data.Price)
+			}
+		}
+	}
+	return aligned
+}
+
+func tailLoss(pnl []float64, exposure float64) float64 {
+	return math.Max(-Quantile(pnl, VaRTail), 0) * math.Abs(exposure)
+}
+
+func horizonScale(holdingPeriod int) float64 {
+	return math.Sqrt(float64(max(holdingPeriod, 1)))
+}
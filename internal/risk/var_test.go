@@ -0,0 +1,102 @@
+package risk
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantile(t *testing.T) {
+	values := []float64{5, 1, 4, 2, 3}
+
+	assert.Equal(t, 1.0, Quantile(values, 0.05))
+	assert.Equal(t, 3.0, Quantile(values, 0.5))
+	assert.Equal(t, 5.0, Quantile(values, 1))
+	assert.Equal(t, []float64{5, 1, 4, 2, 3}, values)
+	assert.Zero(t, Quantile(nil, 0.05))
+}
+
+func TestHistoricalModel_VaR(t *testing.T) {
+	returns := make([]float64, 100)
+	for i := range returns {
+		returns[len(returns)-1-i] = float64(i-20) / 1000
+	}
+
+	assert.InDelta(t, 160, HistoricalModel{}.VaR(10000, returns), 1e-9)
+	assert.InDelta(t, 320, HistoricalModel{HoldingPeriod: 4}.VaR(10000, returns), 1e-9)
+	assert.InDelta(t, 750, HistoricalModel{}.VaR(-10000, returns), 1e-9)
+	assert.Zero(t, HistoricalModel{}.VaR(10000, nil))
+}
+
+func TestHistoricalModel_VaR_NoLossesIsZero(t *testing.T) {
+	returns := []float64{0.01, 0.02, 0.03, 0.04, 0.05}
+
+	assert.Zero(t, HistoricalModel{}.VaR(10000, returns))
+}
+
+func TestMonteCarloModel_VaR_ConvergesToParametric(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	returns := make([]float64, 500)
+	for i := range returns {
+		returns[i] = rng.NormFloat64() * 0.02
+	}
+
+	parametric := ParametricModel{}.VaR(10000, returns)
+	monteCarlo := MonteCarloModel{Paths: 50000, Seed: 1}.VaR(10000, returns)
+	assert.InDelta(t, parametric, monteCarlo, parametric*0.02)
+
+	parametric = ParametricModel{HoldingPeriod: 4}.VaR(-10000, returns)
+	monteCarlo = MonteCarloModel{HoldingPeriod: 4, Paths: 50000, Seed: 1}.VaR(-10000, returns)
+	assert.InDelta(t, parametric, monteCarlo, parametric*0.02)
+}
+
+func TestMonteCarloModel_VaR_IsSeeded(t *testing.T) {
+	returns := []float64{0.01, -0.02, 0.015, -0.005, 0.02, -0.01}
+	model := MonteCarloModel{Paths: 1000, Seed: 42}
+
+	assert.Equal(t, model.VaR(10000, returns), model.VaR(10000, returns))
+	assert.NotEqual(t, model.VaR(10000, returns), MonteCarloModel{Paths: 1000, Seed: 43}.VaR(10000, returns))
+}
+
+func TestNewVaRModel(t *testing.T) {
+	model, err := NewVaRModel("", 2)
+	require.NoError(t, err)
+	assert.Equal(t, ParametricModel{HoldingPeriod: 2}, model)
+
+	model, err = NewVaRModel(VaRHistorical, 0)
+	require.NoError(t, err)
+	assert.Equal(t, HistoricalModel{}, model)
+
+	model, err = NewVaRModel(VaRMonteCarlo, 1)
+	require.NoError(t, err)
+	assert.Equal(t, MonteCarloModel{HoldingPeriod: 1, Paths: DefaultMonteCarloPaths, Seed: DefaultMonteCarloSeed}, model)
+
+	_, err = NewVaRModel("cornish_fisher", 1)
+	assert.ErrorIs(t, err, ErrUnknownVaRMethod)
+}
+
+func TestPortfolioReturns(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := func(offset int, prices ...float64) []*models.MarketData {
+		data := make([]*models.MarketData, len(prices))
+		for i, price := range prices {
+			data[i] = &models.MarketData{Price: decimal.NewFromFloat(price), Timestamp: start.Add(time.Duration(i+offset) * time.Minute)}
+		}
+		return data
+	}
+
+	a := history(0, 100, 110, 99, 99)
+	b := history(1, 50, 55, 55)
+
+	returns := PortfolioReturns([][]*models.MarketData{a, b}, []float64{3000, -1000})
+
+	require.Len(t, returns, 2)
+	assert.InDelta(t, 0.75*-0.1-0.25*0.1, returns[0], 1e-12)
+	assert.InDelta(t, 0, returns[1], 1e-12)
+	assert.Nil(t, PortfolioReturns(nil, nil))
+}
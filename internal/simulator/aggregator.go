@@ -0,0 +1,97 @@
+package simulator
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type BarAggregator struct {
+	interval time.Duration
+	mu       sync.Mutex
+	bars     map[string]*models.MarketData
+}
+
+func NewBarAggregator(interval time.Duration) *BarAggregator {
+	return &BarAggregator{
+		interval: interval,
+		bars:     make(map[string]*models.MarketData),
+	}
+}
+
+func (a *BarAggregator) Interval() time.Duration {
+	return a.interval
+}
+
+func (a *BarAggregator) Add(tick *models.MarketData) *models.MarketData {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	start := tick.Timestamp.Truncate(a.interval)
+	bar, exists := a.bars[tick.Symbol]
+	if exists && bar.Timestamp.Equal(start) {
+		if tick.Price.GreaterThan(bar.High) {
+			bar.High = tick.Price
+		}
+		if tick.Price.LessThan(bar.Low) {
+			bar.Low = tick.Price
+		}
+		bar.Close = tick.Price
+		bar.Price = tick.Price
+		bar.Bid, bar.Ask = tick.Bid, tick.Ask
+		bar.BidSize, bar.AskSize = tick.BidSize, tick.AskSize
+		bar.Volume += tick.Volume
+		return nil
+	}
+
+	a.bars[tick.Symbol] = &models.MarketData{
+		Symbol:    tick.Symbol,
+		Kind:      models.MarketDataKindBar,
+		Price:     tick.Price,
+		Bid:       tick.Bid,
+		Ask:       tick.Ask,
+		BidSize:   tick.BidSize,
+		AskSize:   tick.AskSize,
+		Volume:    tick.Volume,
+		High:      tick.Price,
+		Low:       tick.Price,
+		Open:      tick.Price,
+		Close:     tick.Price,
+		Interval:  a.interval,
+		Timestamp: start,
+	}
+	if exists {
+		return bar
+	}
+	return nil
+}
+
+func (a *BarAggregator) Split(symbol string, ratio decimal.Decimal) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if bar, exists := a.bars[symbol]; exists {
+		a.bars[symbol] = bar.SplitAdjusted(ratio)
+	}
+}
+
+func (a *BarAggregator) Advance(now time.Time) []*models.MarketData {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var completed []*models.MarketData
+	for symbol, bar := range a.bars {
+		if !now.Before(bar.Timestamp.Add(a.interval)) {
+			completed = append(completed, bar)
+			delete(a.bars, symbol)
+		}
+	}
+
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].Symbol < completed[j].Symbol
+	})
+	return completed
+}
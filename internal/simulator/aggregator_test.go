@@ -0,0 +1,125 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBarAggregator_AggregatesTicks(t *testing.T) {
+	aggregator := NewBarAggregator(time.Minute)
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+
+	ticks := []struct {
+		offset time.Duration
+		price  float64
+		volume int64
+	}{
+		{0, 100, 10},
+		{15 * time.Second, 102, 5},
+		{30 * time.Second, 99, 7},
+		{59 * time.Second, 101, 3},
+		{time.Minute + 10*time.Second, 101.5, 4},
+	}
+
+	var completed []*models.MarketData
+	for _, tick := range ticks {
+		if bar := aggregator.Add(createTestTick("AAPL", start.Add(tick.offset), tick.price, tick.volume)); bar != nil {
+			completed = append(completed, bar)
+		}
+	}
+
+	require.Len(t, completed, 1)
+	assertBar(t, completed[0], start, 100, 102, 99, 101, 25)
+	assert.Equal(t, models.MarketDataKindBar, completed[0].Kind)
+	assert.Equal(t, time.Minute, completed[0].Interval)
+}
+
+func TestBarAggregator_SingleTickBar(t *testing.T) {
+	aggregator := NewBarAggregator(time.Minute)
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+
+	assert.Nil(t, aggregator.Add(createTestTick("AAPL", start.Add(20*time.Second), 150, 12)))
+	bars := aggregator.Advance(start.Add(time.Minute))
+
+	require.Len(t, bars, 1)
+	assertBar(t, bars[0], start, 150, 150, 150, 150, 12)
+}
+
+func TestBarAggregator_PriceGapBetweenBars(t *testing.T) {
+	aggregator := NewBarAggregator(5 * time.Minute)
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+
+	aggregator.Add(createTestTick("AAPL", start, 100, 1))
+	aggregator.Add(createTestTick("AAPL", start.Add(time.Minute), 101, 1))
+	first := aggregator.Add(createTestTick("AAPL", start.Add(5*time.Minute), 110, 2))
+	aggregator.Add(createTestTick("AAPL", start.Add(6*time.Minute), 108, 2))
+	second := aggregator.Advance(start.Add(10 * time.Minute))
+
+	require.NotNil(t, first)
+	assertBar(t, first, start, 100, 101, 100, 101, 2)
+	require.Len(t, second, 1)
+	assertBar(t, second[0], start.Add(5*time.Minute), 110, 110, 108, 108, 4)
+}
+
+func TestBarAggregator_GapWithinBar(t *testing.T) {
+	aggregator := NewBarAggregator(time.Second)
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+
+	aggregator.Add(createTestTick("AAPL", start, 100, 1))
+	aggregator.Add(createTestTick("AAPL", start.Add(500*time.Millisecond), 90, 1))
+	bars := aggregator.Advance(start.Add(time.Second))
+
+	require.Len(t, bars, 1)
+	assertBar(t, bars[0], start, 100, 100, 90, 90, 2)
+}
+
+func TestBarAggregator_AdvanceResetsState(t *testing.T) {
+	aggregator := NewBarAggregator(time.Minute)
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+
+	aggregator.Add(createTestTick("MSFT", start, 300, 1))
+	aggregator.Add(createTestTick("AAPL", start, 150, 1))
+
+	assert.Empty(t, aggregator.Advance(start.Add(59*time.Second)))
+	bars := aggregator.Advance(start.Add(time.Minute))
+	require.Len(t, bars, 2)
+	assert.Equal(t, "AAPL", bars[0].Symbol)
+	assert.Equal(t, "MSFT", bars[1].Symbol)
+	assert.Empty(t, aggregator.Advance(start.Add(2*time.Minute)))
+
+	assert.Nil(t, aggregator.Add(createTestTick("AAPL", start.Add(2*time.Minute), 155, 1)))
+	bars = aggregator.Advance(start.Add(3 * time.Minute))
+	require.Len(t, bars, 1)
+	assertBar(t, bars[0], start.Add(2*time.Minute), 155, 155, 155, 155, 1)
+}
+
+func createTestTick(symbol string, timestamp time.Time, price float64, volume int64) *models.MarketData {
+	value := decimal.NewFromFloat(price)
+	return &models.MarketData{
+		Symbol:    symbol,
+		Kind:      models.MarketDataKindTick,
+		Price:     value,
+		Volume:    volume,
+		High:      value,
+		Low:       value,
+		Open:      value,
+		Close:     value,
+		Timestamp: timestamp,
+	}
+}
+
+func assertBar(t *testing.T, bar *models.MarketData, start time.Time, open, high, low, close float64, volume int64) {
+	t.Helper()
+	assert.Equal(t, start, bar.Timestamp)
+	assert.True(t, decimal.NewFromFloat(open).Equal(bar.Open), "open %s", bar.Open)
+	assert.True(t, decimal.NewFromFloat(high).Equal(bar.High), "high %s", bar.High)
+	assert.True(t, decimal.NewFromFloat(low).Equal(bar.Low), "low %s", bar.Low)
+	assert.True(t, decimal.NewFromFloat(close).Equal(bar.Close), "close %s", bar.Close)
+	assert.True(t, bar.Close.Equal(bar.Price))
+	assert.Equal(t, volume, bar.Volume)
+}
@@ -0,0 +1,77 @@
+package simulator
+
+import (
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+)
+
+type Coalescer struct {
+	mu      sync.Mutex
+	out     chan<- *models.MarketData
+	backlog []*models.MarketData
+	limit   int
+}
+
+func NewCoalescer(out chan<- *models.MarketData) *Coalescer {
+	return &Coalescer{out: out, limit: max(cap(out), 1)}
+}
+
+func (c *Coalescer) Publish(data *models.MarketData) (delivered bool, dropped int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.flush()
+	if len(c.backlog) == 0 {
+		select {
+		case c.out <- data:
+			return true, 0
+		default:
+		}
+	}
+
+	if coalescable(data) {
+		for i, pending := range c.backlog {
+			if coalescable(pending) && pending.Symbol == data.Symbol {
+				c.backlog = append(c.backlog[:i], c.backlog[i+1:]...)
+				dropped++
+				break
+			}
+		}
+	}
+	c.backlog = append(c.backlog, data)
+	if len(c.backlog) > c.limit {
+		c.backlog = c.backlog[1:]
+		dropped++
+	}
+	return false, dropped
+}
+
+func (c *Coalescer) Flush() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.flush()
+	return len(c.backlog)
+}
+
+func (c *Coalescer) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.backlog)
+}
+
+func (c *Coalescer) flush() {
+	for len(c.backlog) > 0 {
+		select {
+		case c.out <- c.backlog[0]:
+			c.backlog[0] = nil
+			c.backlog = c.backlog[1:]
+		default:
+			return
+		}
+	}
+}
+
+func coalescable(data *models.MarketData) bool {
+	return data.Kind == "" || data.Kind == models.MarketDataKindTick
+}
@@ -0,0 +1,68 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func coalescerUpdate(symbol string, kind models.MarketDataKind, price int64) *models.MarketData {
+	return &models.MarketData{Symbol: symbol, Kind: kind, Price: decimal.NewFromInt(price)}
+}
+
+func drainUpdates(updates chan *models.MarketData) []string {
+	var drained []string
+	for len(updates) > 0 {
+		data := <-updates
+		drained = append(drained, data.Symbol+":"+string(data.Kind)+":"+data.Price.String())
+	}
+	return drained
+}
+
+func TestCoalescer_Publish_KeepsLatestTickPerSymbol(t *testing.T) {
+	updates := make(chan *models.MarketData, 3)
+	coalescer := NewCoalescer(updates)
+
+	for price := int64(1); price <= 3; price++ {
+		delivered, dropped := coalescer.Publish(coalescerUpdate("AAPL", models.MarketDataKindTick, price))
+		assert.True(t, delivered)
+		assert.Zero(t, dropped)
+	}
+	total := 0
+	for _, update := range []*models.MarketData{
+		coalescerUpdate("AAPL", models.MarketDataKindTick, 4),
+		coalescerUpdate("MSFT", models.MarketDataKindTick, 10),
+		coalescerUpdate("AAPL", models.MarketDataKindBar, 5),
+		coalescerUpdate("AAPL", models.MarketDataKindTick, 6),
+		coalescerUpdate("MSFT", models.MarketDataKindTick, 11),
+	} {
+		delivered, dropped := coalescer.Publish(update)
+		assert.False(t, delivered)
+		total += dropped
+	}
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 3, coalescer.Pending())
+
+	assert.Equal(t, []string{"AAPL:tick:1", "AAPL:tick:2", "AAPL:tick:3"}, drainUpdates(updates))
+	assert.Zero(t, coalescer.Flush())
+	assert.Equal(t, []string{"AAPL:bar:5", "AAPL:tick:6", "MSFT:tick:11"}, drainUpdates(updates))
+}
+
+func TestCoalescer_Publish_BoundsBacklog(t *testing.T) {
+	updates := make(chan *models.MarketData, 1)
+	coalescer := NewCoalescer(updates)
+	coalescer.Publish(coalescerUpdate("AAPL", models.MarketDataKindTick, 1))
+
+	_, dropped := coalescer.Publish(coalescerUpdate("AAPL", models.MarketDataKindNews, 2))
+	assert.Zero(t, dropped)
+	_, dropped = coalescer.Publish(coalescerUpdate("AAPL", models.MarketDataKindNews, 3))
+	assert.Equal(t, 1, dropped)
+
+	require.Equal(t, 1, coalescer.Pending())
+	<-updates
+	coalescer.Flush()
+	assert.Equal(t, []string{"AAPL:news:3"}, drainUpdates(updates))
+}
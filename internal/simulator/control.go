@@ -0,0 +1,119 @@
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const AllSymbols = "*"
+
+var (
+	maxVolatility  = decimal.NewFromInt(5)
+	maxTrend       = decimal.NewFromInt(1)
+	maxPriceShock  = decimal.NewFromInt(1)
+	maxSpikeImpact = decimal.NewFromInt(10)
+)
+
+func (s *MarketSimulator) Adjust(adjustment models.SimulatorAdjustment) ([]models.SimulatorAdjustment, error) {
+	if err := validateAdjustment(adjustment); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	symbols := []string{adjustment.Symbol}
+	if adjustment.Symbol == AllSymbols {
+		symbols = s.sortedSymbols()
+	} else if _, exists := s.symbols[adjustment.Symbol]; !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, adjustment.Symbol)
+	}
+
+	now := s.clock.Now()
+	applied := make([]models.SimulatorAdjustment, 0, len(symbols))
+	for _, symbol := range symbols {
+		data := s.symbols[symbol]
+		change := adjustment
+		change.Symbol, change.At = symbol, now
+		switch adjustment.Kind {
+		case models.AdjustmentVolatility:
+			change.Previous = data.Volatility
+			data.Volatility = adjustment.Value
+			data.spike = nil
+		case models.AdjustmentTrend:
+			change.Previous = data.Trend
+			data.Trend = adjustment.Value
+		case models.AdjustmentEvent:
+			change.Previous = data.CurrentPrice
+			switch adjustment.EventType {
+			case EventVolatilitySpike:
+				change.Previous = data.Volatility
+			case EventTrendChange:
+				change.Previous = data.Trend
+			}
+			s.applyEvent(MarketEvent{Symbol: symbol, Type: adjustment.EventType, Impact: adjustment.Value, At: now})
+		}
+		applied = append(applied, change)
+
+		s.adjustments = append(s.adjustments, change)
+		if len(s.adjustments) > maxEventLog {
+			s.adjustments = s.adjustments[len(s.adjustments)-maxEventLog:]
+		}
+		s.logger.Info("Simulator adjusted",
+			zap.String("symbol", symbol),
+			zap.String("kind", string(change.Kind)),
+			zap.String("event_type", change.EventType),
+			zap.String("value", change.Value.String()),
+			zap.String("previous", change.Previous.String()))
+	}
+	return applied, nil
+}
+
+func (s *MarketSimulator) Adjustments() []models.SimulatorAdjustment {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	adjustments := make([]models.SimulatorAdjustment, len(s.adjustments))
+	copy(adjustments, s.adjustments)
+	return adjustments
+}
+
+func validateAdjustment(adjustment models.SimulatorAdjustment) error {
+	value := adjustment.Value
+	switch adjustment.Kind {
+	case models.AdjustmentVolatility:
+		if !value.IsPositive() || value.GreaterThan(maxVolatility) {
+			return fmt.Errorf("%w: volatility must be greater than 0 and at most %s", ErrInvalidAdjustment, maxVolatility)
+		}
+	case models.AdjustmentTrend:
+		if value.Abs().GreaterThan(maxTrend) {
+			return fmt.Errorf("%w: trend must be between -%s and %s", ErrInvalidAdjustment, maxTrend, maxTrend)
+		}
+	case models.AdjustmentEvent:
+		switch adjustment.EventType {
+		case EventPriceShock:
+			if value.LessThanOrEqual(maxPriceShock.Neg()) || value.GreaterThan(maxPriceShock) {
+				return fmt.Errorf("%w: price shock must be greater than -%s and at most %s", ErrInvalidAdjustment, maxPriceShock, maxPriceShock)
+			}
+		case EventVolatilitySpike:
+			if !value.IsPositive() || value.GreaterThan(maxSpikeImpact) {
+				return fmt.Errorf("%w: volatility spike must be greater than 0 and at most %s", ErrInvalidAdjustment, maxSpikeImpact)
+			}
+		case EventTrendChange:
+			if value.Abs().GreaterThan(maxTrend) {
+				return fmt.Errorf("%w: trend change must be between -%s and %s", ErrInvalidAdjustment, maxTrend, maxTrend)
+			}
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownEventType, adjustment.EventType)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidAdjustment, adjustment.Kind)
+	}
+	if adjustment.Symbol == "" {
+		return fmt.Errorf("%w: symbol is required", ErrInvalidAdjustment)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketSimulator_Adjust_ShockChangesPricePath(t *testing.T) {
+	baseline := runPrices(createEventSimulator(), 20)
+
+	sim := createEventSimulator()
+	runPrices(sim, 10)
+	applied, err := sim.Adjust(models.SimulatorAdjustment{Symbol: "AAPL", Kind: models.AdjustmentEvent, EventType: EventPriceShock, Value: decimal.NewFromFloat(-0.1)})
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	assert.InDelta(t, baseline[9], applied[0].Previous.InexactFloat64(), 1e-9)
+	assert.InDelta(t, baseline[9]*0.9, sim.GetSymbolData("AAPL").CurrentPrice.InexactFloat64(), 1e-9)
+
+	shocked := runPrices(sim, 10)
+	for i, price := range shocked {
+		assert.InDelta(t, 0.9, price/baseline[10+i], 1e-9, "tick %d", i+11)
+	}
+	assert.Equal(t, eventClockStart.Add(10*time.Second), applied[0].At)
+	assert.Equal(t, applied, sim.Adjustments())
+}
+
+func TestMarketSimulator_Adjust_SetsVolatilityAndTrendForAllSymbols(t *testing.T) {
+	sim := createEventSimulator()
+	sim.AddSymbol("MSFT", decimal.NewFromFloat(300.0), decimal.NewFromFloat(0.8))
+
+	applied, err := sim.Adjust(models.SimulatorAdjustment{Symbol: AllSymbols, Kind: models.AdjustmentVolatility, Value: decimal.NewFromFloat(1.5)})
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, []string{applied[0].Symbol, applied[1].Symbol})
+	assert.True(t, applied[1].Previous.Equal(decimal.NewFromFloat(0.8)))
+	assert.True(t, sim.GetSymbolData("MSFT").Volatility.Equal(decimal.NewFromFloat(1.5)))
+
+	_, err = sim.Adjust(models.SimulatorAdjustment{Symbol: "AAPL", Kind: models.AdjustmentTrend, Value: decimal.NewFromFloat(-0.2)})
+	require.NoError(t, err)
+	assert.True(t, sim.GetSymbolData("AAPL").Trend.Equal(decimal.NewFromFloat(-0.2)))
+	assert.Len(t, sim.Adjustments(), 3)
+}
+
+func TestMarketSimulator_Adjust_RejectsInvalidAdjustments(t *testing.T) {
+	sim := createEventSimulator()
+	before := *sim.GetSymbolData("AAPL")
+
+	tests := []struct {
+		name       string
+		adjustment models.SimulatorAdjustment
+		err        error
+	}{
+		{"unknown symbol", models.SimulatorAdjustment{Symbol: "TSLA", Kind: models.AdjustmentTrend, Value: decimal.NewFromFloat(0.1)}, ErrUnknownSymbol},
+		{"unknown event", models.SimulatorAdjustment{Symbol: "AAPL", Kind: models.AdjustmentEvent, EventType: "halt", Value: decimal.NewFromFloat(0.1)}, ErrUnknownEventType},
+		{"wiping shock", models.SimulatorAdjustment{Symbol: "AAPL", Kind: models.AdjustmentEvent, EventType: EventPriceShock, Value: decimal.NewFromInt(-1)}, ErrInvalidAdjustment},
+		{"oversized spike", models.SimulatorAdjustment{Symbol: "AAPL", Kind: models.AdjustmentEvent, EventType: EventVolatilitySpike, Value: decimal.NewFromInt(11)}, ErrInvalidAdjustment},
+		{"zero volatility", models.SimulatorAdjustment{Symbol: "AAPL", Kind: models.AdjustmentVolatility}, ErrInvalidAdjustment},
+		{"steep trend", models.SimulatorAdjustment{Symbol: "AAPL", Kind: models.AdjustmentTrend, Value: decimal.NewFromFloat(1.5)}, ErrInvalidAdjustment},
+		{"unknown kind", models.SimulatorAdjustment{Symbol: "AAPL", Kind: "drift", Value: decimal.NewFromFloat(0.1)}, ErrInvalidAdjustment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sim.Adjust(tt.adjustment)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	after := sim.GetSymbolData("AAPL")
+	assert.True(t, before.CurrentPrice.Equal(after.CurrentPrice))
+	assert.True(t, before.Volatility.Equal(after.Volatility))
+	assert.True(t, before.Trend.Equal(after.Trend))
+	assert.Empty(t, sim.Adjustments())
+}
@@ -0,0 +1,86 @@
+package simulator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func (s *MarketSimulator) ScheduleDividend(symbol string, amount decimal.Decimal, exDate time.Time) error {
+	if !amount.IsPositive() {
+		return fmt.Errorf("%w: %s dividend of %s per share", ErrInvalidCorporateAction, symbol, amount)
+	}
+	return s.scheduleCorporateAction(models.CorporateAction{Symbol: symbol, Type: models.CorporateActionDividend, Amount: amount, Timestamp: exDate})
+}
+
+func (s *MarketSimulator) ScheduleSplit(symbol string, ratio decimal.Decimal, effective time.Time) error {
+	if !ratio.IsPositive() {
+		return fmt.Errorf("%w: %s split ratio %s", ErrInvalidCorporateAction, symbol, ratio)
+	}
+	return s.scheduleCorporateAction(models.CorporateAction{Symbol: symbol, Type: models.CorporateActionSplit, Ratio: ratio, Timestamp: effective})
+}
+
+func (s *MarketSimulator) scheduleCorporateAction(action models.CorporateAction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.symbols[action.Symbol]; !exists {
+		return fmt.Errorf("%w: unknown symbol %q", ErrInvalidCorporateAction, action.Symbol)
+	}
+	s.corporateActions = append(s.corporateActions, action)
+	sort.SliceStable(s.corporateActions, func(i, j int) bool {
+		return s.corporateActions[i].Timestamp.Before(s.corporateActions[j].Timestamp)
+	})
+	return nil
+}
+
+func (s *MarketSimulator) fireCorporateActions(now time.Time) {
+	fired := 0
+	for _, action := range s.corporateActions {
+		if action.Timestamp.After(now) {
+			break
+		}
+		fired++
+
+		data, exists := s.symbols[action.Symbol]
+		if !exists {
+			continue
+		}
+		switch action.Type {
+		case models.CorporateActionDividend:
+			data.CurrentPrice = decimal.Max(data.CurrentPrice.Sub(action.Amount), minPrice)
+		case models.CorporateActionSplit:
+			data.BasePrice = data.BasePrice.Div(action.Ratio)
+			data.CurrentPrice = data.CurrentPrice.Div(action.Ratio)
+			data.High = data.High.Div(action.Ratio)
+			data.Low = data.Low.Div(action.Ratio)
+			data.Open = data.Open.Div(action.Ratio)
+			data.Close = data.Close.Div(action.Ratio)
+			data.Volume = decimal.NewFromInt(data.Volume).Mul(action.Ratio).IntPart()
+			if s.aggregator != nil {
+				s.aggregator.Split(action.Symbol, action.Ratio)
+			}
+		}
+
+		applied := action
+		applied.Timestamp = now
+		s.logger.Info("Corporate action",
+			zap.String("symbol", action.Symbol),
+			zap.String("type", string(action.Type)),
+			zap.String("amount", action.Amount.String()),
+			zap.String("ratio", action.Ratio.String()),
+			zap.String("price", data.CurrentPrice.String()))
+		s.publish(&models.MarketData{
+			Symbol:          action.Symbol,
+			Kind:            models.MarketDataKindCorporateAction,
+			Price:           data.CurrentPrice,
+			Timestamp:       now,
+			CorporateAction: &applied,
+		})
+	}
+	s.corporateActions = s.corporateActions[fired:]
+}
@@ -0,0 +1,72 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runUpdates(sim *eventSimulator, ticks int) ([]float64, []*models.MarketData) {
+	var prices []float64
+	var actions []*models.MarketData
+	for i := 0; i < ticks; i++ {
+		sim.fake.Advance(time.Second)
+		sim.updatePrices()
+		for len(sim.Updates()) > 0 {
+			update := <-sim.Updates()
+			if update.Kind == models.MarketDataKindCorporateAction {
+				actions = append(actions, update)
+				continue
+			}
+			prices = append(prices, update.Price.InexactFloat64())
+		}
+	}
+	return prices, actions
+}
+
+func TestMarketSimulator_ScheduledSplitAdjustsPrice(t *testing.T) {
+	baseline, _ := runUpdates(createEventSimulator(), 100)
+
+	sim := createEventSimulator()
+	require.NoError(t, sim.ScheduleSplit("AAPL", decimal.NewFromInt(2), eventClockStart.Add(50*time.Second)))
+	split, actions := runUpdates(sim, 100)
+
+	require.Len(t, split, len(baseline))
+	for i := range baseline {
+		if i < 49 {
+			assert.InDelta(t, 1.0, split[i]/baseline[i], 1e-9, "tick %d", i+1)
+		} else {
+			assert.InDelta(t, 0.5, split[i]/baseline[i], 1e-9, "tick %d", i+1)
+		}
+	}
+
+	require.Len(t, actions, 1)
+	action := actions[0].CorporateAction
+	require.NotNil(t, action)
+	assert.Equal(t, models.CorporateActionSplit, action.Type)
+	assert.True(t, decimal.NewFromInt(2).Equal(action.Ratio))
+	assert.Equal(t, eventClockStart.Add(50*time.Second), action.Timestamp)
+	assert.InDelta(t, baseline[48]/2, actions[0].Price.InexactFloat64(), 1e-9)
+}
+
+func TestMarketSimulator_ScheduledDividendDropsPrice(t *testing.T) {
+	sim := createEventSimulator()
+	require.NoError(t, sim.ScheduleDividend("AAPL", decimal.NewFromInt(5), eventClockStart.Add(10*time.Second)))
+	prices, actions := runUpdates(sim, 20)
+
+	require.Len(t, actions, 1)
+	assert.Equal(t, models.CorporateActionDividend, actions[0].CorporateAction.Type)
+	assert.InDelta(t, prices[8]-5, actions[0].Price.InexactFloat64(), 1e-9)
+}
+
+func TestMarketSimulator_ScheduleCorporateAction_Invalid(t *testing.T) {
+	sim := createEventSimulator()
+
+	assert.ErrorIs(t, sim.ScheduleSplit("AAPL", decimal.Zero, eventClockStart), ErrInvalidCorporateAction)
+	assert.ErrorIs(t, sim.ScheduleDividend("AAPL", decimal.NewFromInt(-1), eventClockStart), ErrInvalidCorporateAction)
+	assert.ErrorIs(t, sim.ScheduleSplit("MSFT", decimal.NewFromInt(2), eventClockStart), ErrInvalidCorporateAction)
+}
@@ -0,0 +1,117 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+type correlation struct {
+	index map[string]int
+	lower [][]float64
+}
+
+func newCorrelation(correlations map[string]map[string]float64) (*correlation, error) {
+	seen := make(map[string]bool)
+	for a, row := range correlations {
+		seen[a] = true
+		for b := range row {
+			seen[b] = true
+		}
+	}
+	symbols := make([]string, 0, len(seen))
+	for symbol := range seen {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	index := make(map[string]int, len(symbols))
+	for i, symbol := range symbols {
+		index[symbol] = i
+	}
+
+	matrix := make([][]float64, len(symbols))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(symbols))
+		matrix[i][i] = 1
+	}
+	set := make([][]bool, len(symbols))
+	for i := range set {
+		set[i] = make([]bool, len(symbols))
+	}
+
+	for a, row := range correlations {
+		for b, rho := range row {
+			i, j := index[a], index[b]
+			switch {
+			case rho < -1 || rho > 1 || math.IsNaN(rho):
+				return nil, fmt.Errorf("%w: %s/%s correlation %v is outside [-1, 1]", ErrInvalidCorrelation, a, b, rho)
+			case i == j && rho != 1:
+				return nil, fmt.Errorf("%w: %s must have correlation 1 with itself", ErrInvalidCorrelation, a)
+			case set[i][j] && matrix[i][j] != rho:
+				return nil, fmt.Errorf("%w: %s/%s given as both %v and %v", ErrInvalidCorrelation, a, b, matrix[i][j], rho)
+			}
+			matrix[i][j], matrix[j][i] = rho, rho
+			set[i][j], set[j][i] = true, true
+		}
+	}
+
+	lower, err := cholesky(matrix)
+	if err != nil {
+		return nil, err
+	}
+	return &correlation{index: index, lower: lower}, nil
+}
+
+func cholesky(matrix [][]float64) ([][]float64, error) {
+	n := len(matrix)
+	lower := make([][]float64, n)
+	for i := range lower {
+		lower[i] = make([]float64, i+1)
+		for j := 0; j <= i; j++ {
+			sum := matrix[i][j]
+			for k := 0; k < j; k++ {
+				sum -= lower[i][k] * lower[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return nil, fmt.Errorf("%w: matrix is not positive definite", ErrInvalidCorrelation)
+				}
+				lower[i][i] = math.Sqrt(sum)
+			} else {
+				lower[i][j] = sum / lower[j][j]
+			}
+		}
+	}
+	return lower, nil
+}
+
+type shockDraw struct {
+	correlation *correlation
+	rng         *rand.Rand
+	independent []float64
+}
+
+func (c *correlation) draw(rng *rand.Rand) *shockDraw {
+	return &shockDraw{correlation: c, rng: rng}
+}
+
+func (d *shockDraw) shock(symbol string) float64 {
+	if d.correlation == nil {
+		return d.rng.NormFloat64()
+	}
+	i, exists := d.correlation.index[symbol]
+	if !exists {
+		return d.rng.NormFloat64()
+	}
+
+	for len(d.independent) <= i {
+		d.independent = append(d.independent, d.rng.NormFloat64())
+	}
+	shock := 0.0
+	for j, weight := range d.correlation.lower[i] {
+		shock += weight * d.independent[j]
+	}
+	return shock
+}
@@ -0,0 +1,63 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMarketSimulator_RealizedCorrelation(t *testing.T) {
+	sim := NewMarketSimulator(zap.NewNop(), WithSeed(42), WithPriceModel(GBMPriceModel{}))
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.3))
+	sim.AddSymbol("MSFT", decimal.NewFromFloat(300.0), decimal.NewFromFloat(0.25))
+	sim.AddSymbol("TSLA", decimal.NewFromFloat(800.0), decimal.NewFromFloat(0.6))
+	sim.AddSymbol("SPY", decimal.NewFromFloat(450.0), decimal.NewFromFloat(0.15))
+	require.NoError(t, sim.SetCorrelations(map[string]map[string]float64{
+		"AAPL": {"MSFT": 0.8, "TSLA": 0.3},
+		"MSFT": {"TSLA": 0.2},
+	}))
+
+	prices := make(map[string][]float64)
+	for _, symbol := range sim.Symbols() {
+		prices[symbol] = []float64{sim.GetSymbolData(symbol).CurrentPrice.InexactFloat64()}
+	}
+	for i := 0; i < 5000; i++ {
+		sim.updatePrices()
+		for len(sim.updateChan) > 0 {
+			tick := <-sim.updateChan
+			prices[tick.Symbol] = append(prices[tick.Symbol], tick.Price.InexactFloat64())
+		}
+	}
+
+	realized := func(a, b string) float64 {
+		rho, ok := risk.Correlation(logReturns(prices[a]), logReturns(prices[b]))
+		require.True(t, ok)
+		return rho
+	}
+	assert.InDelta(t, 0.8, realized("AAPL", "MSFT"), 0.1)
+	assert.InDelta(t, 0.3, realized("AAPL", "TSLA"), 0.1)
+	assert.InDelta(t, 0.2, realized("MSFT", "TSLA"), 0.1)
+	assert.InDelta(t, 0.0, realized("AAPL", "SPY"), 0.1)
+}
+
+func TestMarketSimulator_SetCorrelations_Invalid(t *testing.T) {
+	sim := NewMarketSimulator(zap.NewNop())
+
+	for name, correlations := range map[string]map[string]map[string]float64{
+		"out of range":    {"AAPL": {"MSFT": 1.2}},
+		"self":            {"AAPL": {"AAPL": 0.5}},
+		"conflicting":     {"AAPL": {"MSFT": 0.5}, "MSFT": {"AAPL": 0.6}},
+		"not positive":    {"AAPL": {"MSFT": 0.9, "TSLA": -0.9}, "MSFT": {"TSLA": 0.9}},
+		"perfectly equal": {"AAPL": {"MSFT": 1.0}},
+	} {
+		assert.ErrorIs(t, sim.SetCorrelations(correlations), ErrInvalidCorrelation, name)
+	}
+
+	assert.NoError(t, sim.SetCorrelations(map[string]map[string]float64{"AAPL": {"MSFT": 0.5}, "MSFT": {"AAPL": 0.5}}))
+	assert.NoError(t, sim.SetCorrelations(nil))
+	assert.Nil(t, sim.correlation)
+}
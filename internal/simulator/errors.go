@@ -0,0 +1,14 @@
+package simulator
+
+import "errors"
+
+var (
+	ErrUnknownPriceModel      = errors.New("unknown price model")
+	ErrInvalidCorrelation     = errors.New("invalid correlation")
+	ErrUnknownEventType       = errors.New("unknown market event type")
+	ErrInvalidCorporateAction = errors.New("invalid corporate action")
+	ErrInvalidNews            = errors.New("invalid news event")
+	ErrUnknownSymbol          = errors.New("unknown symbol")
+	ErrInvalidRecording       = errors.New("invalid market data recording")
+	ErrInvalidAdjustment      = errors.New("invalid simulator adjustment")
+)
@@ -0,0 +1,193 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	EventPriceShock      = "price_shock"
+	EventVolatilitySpike = "volatility_spike"
+	EventTrendChange     = "trend_change"
+
+	defaultSpikeDecayTicks = 30
+	randomSpikeScale       = 10
+	maxEventLog            = 1000
+)
+
+type MarketEvent struct {
+	Symbol string          `json:"symbol"`
+	Type   string          `json:"type"`
+	Impact decimal.Decimal `json:"impact"`
+	At     time.Time       `json:"at"`
+	Random bool            `json:"random,omitempty"`
+}
+
+type MagnitudeDist func(rng *rand.Rand) decimal.Decimal
+
+func UniformMagnitude(min, max float64) MagnitudeDist {
+	return func(rng *rand.Rand) decimal.Decimal {
+		return decimal.NewFromFloat(min + rng.Float64()*(max-min))
+	}
+}
+
+type volatilitySpike struct {
+	base      decimal.Decimal
+	peak      decimal.Decimal
+	ticks     int
+	remaining int
+}
+
+type randomEvents struct {
+	rate      float64
+	magnitude MagnitudeDist
+}
+
+func WithSpikeDecay(ticks int) Option {
+	return func(s *MarketSimulator) {
+		if ticks > 0 {
+			s.spikeDecayTicks = ticks
+		}
+	}
+}
+
+func (s *MarketSimulator) ScheduleEvent(symbol, eventType string, impact decimal.Decimal, at time.Time) error {
+	if !validEventType(eventType) {
+		return fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scheduleEvent(MarketEvent{Symbol: symbol, Type: eventType, Impact: impact, At: at})
+	return nil
+}
+
+func (s *MarketSimulator) scheduleEvent(event MarketEvent) {
+	s.scheduled = append(s.scheduled, event)
+	sort.SliceStable(s.scheduled, func(i, j int) bool { return s.scheduled[i].At.Before(s.scheduled[j].At) })
+}
+
+func (s *MarketSimulator) EnableRandomEvents(rate float64, magnitudeDist MagnitudeDist) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rate <= 0 || magnitudeDist == nil {
+		s.random = nil
+		return
+	}
+	s.random = &randomEvents{rate: rate, magnitude: magnitudeDist}
+}
+
+func (s *MarketSimulator) Events() []MarketEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]MarketEvent, len(s.eventLog))
+	copy(events, s.eventLog)
+	return events
+}
+
+func validEventType(eventType string) bool {
+	switch eventType {
+	case EventPriceShock, EventVolatilitySpike, EventTrendChange:
+		return true
+	}
+	return false
+}
+
+func (s *MarketSimulator) fireScheduledEvents(now time.Time) {
+	fired := 0
+	for _, event := range s.scheduled {
+		if event.At.After(now) {
+			break
+		}
+		event.At = now
+		s.applyEvent(event)
+		fired++
+	}
+	s.scheduled = s.scheduled[fired:]
+}
+
+func (s *MarketSimulator) fireRandomEvents(now time.Time) {
+	if s.random == nil {
+		return
+	}
+
+	probability := 1 - math.Exp(-s.random.rate*s.tickInterval.Hours())
+	for _, symbol := range s.sortedSymbols() {
+		if s.rng.Float64() >= probability {
+			continue
+		}
+
+		magnitude := s.random.magnitude(s.rng).Abs()
+		event := MarketEvent{Symbol: symbol, Type: EventPriceShock, Impact: magnitude, At: now, Random: true}
+		if s.rng.Intn(2) == 0 {
+			event.Type = EventVolatilitySpike
+			event.Impact = magnitude.Mul(decimal.NewFromInt(randomSpikeScale))
+		} else if s.rng.Intn(2) == 0 {
+			event.Impact = magnitude.Neg()
+		}
+		s.applyEvent(event)
+	}
+}
+
+func (s *MarketSimulator) applyEvent(event MarketEvent) {
+	data, exists := s.symbols[event.Symbol]
+	if !exists {
+		return
+	}
+
+	switch event.Type {
+	case EventPriceShock:
+		data.CurrentPrice = data.CurrentPrice.Mul(decimal.NewFromFloat(1.0).Add(event.Impact))
+		if data.CurrentPrice.LessThan(minPrice) {
+			data.CurrentPrice = minPrice
+		}
+	case EventVolatilitySpike:
+		base := data.Volatility
+		if data.spike != nil {
+			base = data.spike.base
+		}
+		peak := data.Volatility.Mul(decimal.NewFromFloat(1.0).Add(event.Impact))
+		data.Volatility = peak
+		data.spike = &volatilitySpike{base: base, peak: peak, ticks: s.spikeDecayTicks, remaining: s.spikeDecayTicks}
+	case EventTrendChange:
+		data.Trend = data.Trend.Add(event.Impact)
+	default:
+		return
+	}
+
+	s.eventLog = append(s.eventLog, event)
+	if len(s.eventLog) > maxEventLog {
+		s.eventLog = s.eventLog[len(s.eventLog)-maxEventLog:]
+	}
+
+	s.logger.Info("Market event applied",
+		zap.String("symbol", event.Symbol),
+		zap.String("event_type", event.Type),
+		zap.String("impact", event.Impact.String()),
+		zap.Time("at", event.At))
+}
+
+func (data *SymbolData) decaySpike() {
+	spike := data.spike
+	if spike == nil {
+		return
+	}
+
+	spike.remaining--
+	if spike.remaining <= 0 {
+		data.Volatility = spike.base
+		data.spike = nil
+		return
+	}
+	fraction := decimal.NewFromInt(int64(spike.remaining)).Div(decimal.NewFromInt(int64(spike.ticks)))
+	data.Volatility = spike.base.Add(spike.peak.Sub(spike.base).Mul(fraction))
+}
@@ -0,0 +1,126 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var eventClockStart = time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+
+type eventSimulator struct {
+	*MarketSimulator
+	fake *clock.Fake
+}
+
+func createEventSimulator(opts ...Option) *eventSimulator {
+	sim := &eventSimulator{fake: clock.NewFake(eventClockStart)}
+	opts = append(opts, WithSeed(42), WithPriceModel(GBMPriceModel{}), WithClock(sim.fake))
+	sim.MarketSimulator = NewMarketSimulator(zap.NewNop(), opts...)
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.5))
+	return sim
+}
+
+func runPrices(sim *eventSimulator, ticks int) []float64 {
+	prices := make([]float64, 0, ticks)
+	for i := 0; i < ticks; i++ {
+		sim.fake.Advance(time.Second)
+		sim.updatePrices()
+		prices = append(prices, (<-sim.Updates()).Price.InexactFloat64())
+	}
+	return prices
+}
+
+func TestMarketSimulator_ScheduledShockFiresOnce(t *testing.T) {
+	baseline := runPrices(createEventSimulator(), 100)
+
+	sim := createEventSimulator()
+	require.NoError(t, sim.ScheduleEvent("AAPL", EventPriceShock, decimal.NewFromFloat(-0.10), eventClockStart.Add(50*time.Second)))
+	shocked := runPrices(sim, 100)
+
+	for i := range baseline {
+		ratio := shocked[i] / baseline[i]
+		if i < 49 {
+			assert.InDelta(t, 1.0, ratio, 1e-9, "tick %d", i+1)
+		} else {
+			assert.InDelta(t, 0.9, ratio, 1e-9, "tick %d", i+1)
+		}
+	}
+
+	events := sim.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, EventPriceShock, events[0].Type)
+	assert.Equal(t, eventClockStart.Add(50*time.Second), events[0].At)
+	assert.False(t, events[0].Random)
+}
+
+func TestMarketSimulator_ScheduleEventRejectsUnknownType(t *testing.T) {
+	sim := createEventSimulator()
+
+	err := sim.ScheduleEvent("AAPL", "halt", decimal.NewFromFloat(1.0), eventClockStart)
+	assert.ErrorIs(t, err, ErrUnknownEventType)
+}
+
+func TestMarketSimulator_VolatilitySpikeDecays(t *testing.T) {
+	sim := createEventSimulator(WithSpikeDecay(10))
+	base := decimal.NewFromFloat(0.5)
+
+	sim.AddMarketEvent("AAPL", EventVolatilitySpike, decimal.NewFromFloat(1.0))
+	assert.True(t, sim.GetSymbolData("AAPL").Volatility.Equal(decimal.NewFromFloat(1.0)))
+
+	sim.AddMarketEvent("AAPL", EventVolatilitySpike, decimal.NewFromFloat(1.0))
+	assert.True(t, sim.GetSymbolData("AAPL").Volatility.Equal(decimal.NewFromFloat(2.0)))
+
+	previous := sim.GetSymbolData("AAPL").Volatility
+	for i := 0; i < 9; i++ {
+		runPrices(sim, 1)
+		current := sim.GetSymbolData("AAPL").Volatility
+		assert.True(t, current.LessThan(previous), "tick %d", i+1)
+		assert.True(t, current.GreaterThan(base), "tick %d", i+1)
+		previous = current
+	}
+
+	runPrices(sim, 1)
+	assert.True(t, sim.GetSymbolData("AAPL").Volatility.Equal(base))
+}
+
+func TestMarketSimulator_RandomEventsAreSeeded(t *testing.T) {
+	run := func() ([]float64, []MarketEvent) {
+		sim := createEventSimulator()
+		sim.EnableRandomEvents(60, UniformMagnitude(0.05, 0.15))
+		return runPrices(sim, 600), sim.Events()
+	}
+
+	firstPrices, firstEvents := run()
+	secondPrices, secondEvents := run()
+
+	assert.Equal(t, firstPrices, secondPrices)
+	assert.Equal(t, firstEvents, secondEvents)
+	require.NotEmpty(t, firstEvents)
+	for _, event := range firstEvents {
+		assert.True(t, event.Random)
+		switch event.Type {
+		case EventPriceShock:
+			assert.True(t, event.Impact.Abs().GreaterThanOrEqual(decimal.NewFromFloat(0.05)))
+			assert.True(t, event.Impact.Abs().LessThanOrEqual(decimal.NewFromFloat(0.15)))
+		case EventVolatilitySpike:
+			assert.True(t, event.Impact.IsPositive())
+		default:
+			t.Fatalf("unexpected random event type %q", event.Type)
+		}
+	}
+}
+
+func TestMarketSimulator_RandomEventsDisabled(t *testing.T) {
+	sim := createEventSimulator()
+	sim.EnableRandomEvents(60, UniformMagnitude(0.05, 0.15))
+	sim.EnableRandomEvents(0, nil)
+
+	runPrices(sim, 600)
+	assert.Empty(t, sim.Events())
+}
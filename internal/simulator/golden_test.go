@@ -0,0 +1,121 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files")
+
+const goldenTicks = 300
+
+func createSeededSimulator(seed int64) *MarketSimulator {
+	sim := NewMarketSimulator(zap.NewNop(), WithSeed(seed), WithClock(clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))))
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.5))
+	sim.AddSymbol("MSFT", decimal.NewFromFloat(300.0), decimal.NewFromFloat(0.8))
+	return sim
+}
+
+func runSeededTicks(t *testing.T, sim *MarketSimulator, ticks int) []*models.MarketData {
+	t.Helper()
+	var emitted []*models.MarketData
+	for i := 0; i < ticks; i++ {
+		sim.clock.(*clock.Fake).Advance(time.Second)
+		sim.updatePrices()
+		if i%5 == 4 {
+			sim.updateVolumes()
+		}
+		if i%30 == 29 {
+			sim.updateTrends()
+		}
+		for len(sim.updateChan) > 0 {
+			emitted = append(emitted, <-sim.updateChan)
+		}
+	}
+	return emitted
+}
+
+func assertGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, actual, 0o644))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestMarketSimulator_SeededRunsAreReproducible(t *testing.T) {
+	first, err := json.Marshal(runSeededTicks(t, createSeededSimulator(42), goldenTicks))
+	require.NoError(t, err)
+	second, err := json.Marshal(runSeededTicks(t, createSeededSimulator(42), goldenTicks))
+	require.NoError(t, err)
+	other, err := json.Marshal(runSeededTicks(t, createSeededSimulator(7), goldenTicks))
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+}
+
+func TestMovingAverageStrategy_GoldenSeededPath(t *testing.T) {
+	strategy := strategies.NewMovingAverageStrategy(&models.StrategyConfig{
+		ID:               "golden",
+		Name:             "Golden",
+		MaxPositionSize:  decimal.NewFromFloat(1.0),
+		MaxPortfolioRisk: decimal.NewFromFloat(1.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+		Enabled:          true,
+	})
+	portfolio := &models.Portfolio{
+		Cash:       decimal.NewFromFloat(100000.0),
+		TotalValue: decimal.NewFromFloat(100000.0),
+		Positions:  make(map[string]*models.Position),
+	}
+	market := &models.MarketSnapshot{
+		Latest:  make(map[string]*models.MarketData),
+		History: make(map[string][]*models.MarketData),
+	}
+
+	var signals strings.Builder
+	for i, tick := range runSeededTicks(t, createSeededSimulator(42), goldenTicks) {
+		market.Latest[tick.Symbol] = tick
+		market.History[tick.Symbol] = append(market.History[tick.Symbol], tick)
+		if tick.Symbol != "MSFT" {
+			continue
+		}
+
+		result, err := strategy.Execute(context.Background(), portfolio, market)
+		require.NoError(t, err)
+		if result == nil {
+			continue
+		}
+
+		fmt.Fprintf(&signals, "%03d %s %s %s @ %s (%s)\n", i/2, result.Symbol, result.Action, result.Quantity, result.Price.StringFixed(4), result.Signal)
+		if result.Action == "buy" {
+			portfolio.Positions[result.Symbol] = &models.Position{Symbol: result.Symbol, Quantity: result.Quantity, AveragePrice: result.Price}
+		} else {
+			delete(portfolio.Positions, result.Symbol)
+		}
+	}
+
+	require.NotZero(t, signals.Len())
+	assertGolden(t, "moving_average_seed42.golden", []byte(signals.String()))
+}
@@ -0,0 +1,54 @@
+package simulator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+func (s *MarketSimulator) PreloadHistory(symbol string, n int) ([]*models.MarketData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, exists := s.symbols[symbol]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	if n <= 0 {
+		return []*models.MarketData{}, nil
+	}
+
+	walk := *data
+	walk.CurrentPrice = data.BasePrice
+	prices := make([]decimal.Decimal, n)
+	for i := range prices {
+		walk.CurrentPrice = s.priceModel.NextPrice(&walk, s.tickInterval, s.correlation.draw(s.rng).shock(symbol))
+		prices[i] = walk.CurrentPrice
+	}
+
+	scale := data.CurrentPrice.Div(prices[n-1])
+	start := s.clock.Now().Add(-time.Duration(n) * s.tickInterval)
+	history := make([]*models.MarketData, n)
+	for i, price := range prices {
+		price, bid, ask := s.gridQuote(symbol, price.Mul(scale), data.Volatility)
+		history[i] = &models.MarketData{
+			Symbol:    symbol,
+			Kind:      models.MarketDataKindTick,
+			Price:     price,
+			Bid:       bid,
+			Ask:       ask,
+			BidSize:   minQuoteSize + s.rng.Int63n(maxQuoteSize-minQuoteSize),
+			AskSize:   minQuoteSize + s.rng.Int63n(maxQuoteSize-minQuoteSize),
+			Volume:    data.Volume,
+			High:      price,
+			Low:       price,
+			Open:      price,
+			Close:     price,
+			Timestamp: start.Add(time.Duration(i) * s.tickInterval),
+		}
+		s.record(history[i], true)
+	}
+	return history, nil
+}
@@ -0,0 +1,46 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketSimulator_PreloadHistory_BackDatesTicks(t *testing.T) {
+	sim := createSeededSimulator(42)
+	now := sim.clock.Now()
+
+	history, err := sim.PreloadHistory("AAPL", 30)
+	require.NoError(t, err)
+	require.Len(t, history, 30)
+
+	for i, data := range history {
+		assert.Equal(t, "AAPL", data.Symbol)
+		assert.Equal(t, now.Add(-time.Duration(30-i)*time.Second), data.Timestamp)
+		assert.True(t, data.Bid.LessThan(data.Price))
+		assert.True(t, data.Ask.GreaterThan(data.Price))
+	}
+	assert.True(t, decimal.NewFromFloat(150.0).Equal(history[29].Price.Round(8)))
+	assert.False(t, history[0].Price.Equal(history[29].Price))
+	assert.True(t, decimal.NewFromFloat(150.0).Equal(sim.GetSymbolData("AAPL").CurrentPrice))
+}
+
+func TestMarketSimulator_PreloadHistory_Deterministic(t *testing.T) {
+	first, err := createSeededSimulator(7).PreloadHistory("MSFT", 50)
+	require.NoError(t, err)
+	second, err := createSeededSimulator(7).PreloadHistory("MSFT", 50)
+	require.NoError(t, err)
+
+	for i := range first {
+		assert.True(t, first[i].Price.Equal(second[i].Price), "tick %d", i)
+	}
+}
+
+func TestMarketSimulator_PreloadHistory_UnknownSymbol(t *testing.T) {
+	_, err := createSeededSimulator(1).PreloadHistory("TSLA", 10)
+
+	assert.ErrorIs(t, err, ErrUnknownSymbol)
+}
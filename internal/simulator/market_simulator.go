@@ -1,22 +1,113 @@
 package simulator
 
 import (
+	"context"
+	"encoding/json"
+	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/1cbyc/trade-algo-go/internal/calendar"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/metrics"
 	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
+const (
+	defaultTickInterval = time.Second
+	defaultBufferSize   = 1000
+	volumeInterval      = 5 * time.Second
+	trendInterval       = 30 * time.Second
+	spreadFactor        = 0.05
+	minQuoteSize        = 100
+	maxQuoteSize        = 1000
+)
+
+var minHalfSpread = decimal.NewFromFloat(0.005)
+
 type MarketSimulator struct {
-	symbols    map[string]*SymbolData
-	logger     *zap.Logger
-	mu         sync.RWMutex
-	running    bool
-	stopChan   chan struct{}
-	updateChan chan *models.MarketData
+	symbols          map[string]*SymbolData
+	instruments      *symbols.Registry
+	logger           *zap.Logger
+	mu               sync.RWMutex
+	running          bool
+	stopChan         chan struct{}
+	updateChan       chan *models.MarketData
+	coalescer        *Coalescer
+	aggregator       *BarAggregator
+	metrics          *metrics.Metrics
+	rng              *rand.Rand
+	clock            clock.Clock
+	priceModel       PriceModel
+	calendar         *calendar.Calendar
+	openingGap       bool
+	correlation      *correlation
+	tickInterval     time.Duration
+	spikeDecayTicks  int
+	scheduled        []MarketEvent
+	random           *randomEvents
+	eventLog         []MarketEvent
+	adjustments      []models.SimulatorAdjustment
+	corporateActions []models.CorporateAction
+	newsLag          time.Duration
+	news             []models.NewsEvent
+	randomNews       float64
+	newsLog          []models.NewsEvent
+	stepped          time.Duration
+	recorder         *json.Encoder
+}
+
+type Option func(*MarketSimulator)
+
+func WithSeed(seed int64) Option {
+	return func(s *MarketSimulator) {
+		s.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+func WithPriceModel(model PriceModel) Option {
+	return func(s *MarketSimulator) {
+		s.priceModel = model
+	}
+}
+
+func WithCalendar(cal *calendar.Calendar) Option {
+	return func(s *MarketSimulator) {
+		s.calendar = cal
+	}
+}
+
+func WithOpeningGap() Option {
+	return func(s *MarketSimulator) {
+		s.openingGap = true
+	}
+}
+
+func WithTickInterval(interval time.Duration) Option {
+	return func(s *MarketSimulator) {
+		if interval > 0 {
+			s.tickInterval = interval
+		}
+	}
+}
+
+func WithClock(c clock.Clock) Option {
+	return func(s *MarketSimulator) {
+		s.clock = c
+	}
+}
+
+func WithSymbols(registry *symbols.Registry) Option {
+	return func(s *MarketSimulator) {
+		if registry != nil {
+			s.instruments = registry
+		}
+	}
 }
 
 type SymbolData struct {
@@ -31,15 +122,28 @@ type SymbolData struct {
 	Open         decimal.Decimal
 	Close        decimal.Decimal
 	LastUpdate   time.Time
+	closedSince  time.Time
+	spike        *volatilitySpike
 }
 
-func NewMarketSimulator(logger *zap.Logger) *MarketSimulator {
-	return &MarketSimulator{
-		symbols:    make(map[string]*SymbolData),
-		logger:     logger,
-		stopChan:   make(chan struct{}),
-		updateChan: make(chan *models.MarketData, 1000),
+func NewMarketSimulator(logger *zap.Logger, opts ...Option) *MarketSimulator {
+	s := &MarketSimulator{
+		symbols:         make(map[string]*SymbolData),
+		instruments:     symbols.NewRegistry(),
+		logger:          logger,
+		updateChan:      make(chan *models.MarketData, defaultBufferSize),
+		rng:             rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:           clock.New(),
+		priceModel:      LegacyPriceModel{},
+		tickInterval:    defaultTickInterval,
+		spikeDecayTicks: defaultSpikeDecayTicks,
+		newsLag:         defaultNewsLag,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.coalescer = NewCoalescer(s.updateChan)
+	return s
 }
 
 func (s *MarketSimulator) AddSymbol(symbol string, basePrice decimal.Decimal, volatility decimal.Decimal) {
@@ -52,37 +156,66 @@ func (s *MarketSimulator) AddSymbol(symbol string, basePrice decimal.Decimal, vo
 		CurrentPrice: basePrice,
 		Volatility:   volatility,
 		Trend:        decimal.Zero,
-		Volume:       rand.Int63n(1000000) + 100000,
+		Volume:       s.rng.Int63n(1000000) + 100000,
 		High:         basePrice,
 		Low:          basePrice,
 		Open:         basePrice,
 		Close:        basePrice,
-		LastUpdate:   time.Now(),
+		LastUpdate:   s.clock.Now(),
 	}
 
 	s.logger.Info("Symbol added to simulator", zap.String("symbol", symbol), zap.String("base_price", basePrice.String()))
 }
 
-func (s *MarketSimulator) Start() {
+func (s *MarketSimulator) AddInstrument(instrument models.Instrument, basePrice decimal.Decimal, volatility decimal.Decimal) error {
+	if err := s.instruments.Register(instrument); err != nil {
+		return err
+	}
+	s.AddSymbol(instrument.Symbol, basePrice, volatility)
+	return nil
+}
+
+func (s *MarketSimulator) Instruments() *symbols.Registry {
+	return s.instruments
+}
+
+func (s *MarketSimulator) Start(ctx context.Context) error {
 	s.mu.Lock()
 	if s.running {
 		s.mu.Unlock()
-		return
+		return nil
 	}
 	s.running = true
+	stop := make(chan struct{})
+	s.stopChan = stop
 	s.mu.Unlock()
 
 	s.logger.Info("Market simulator started")
 
-	go s.priceGenerator()
-	go s.volumeGenerator()
-	go s.trendGenerator()
+	go s.priceGenerator(stop)
+	go s.volumeGenerator(stop)
+	go s.trendGenerator(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.stop(stop)
+		case <-stop:
+		}
+	}()
+	return nil
 }
 
 func (s *MarketSimulator) Stop() {
+	s.mu.RLock()
+	stop := s.stopChan
+	s.mu.RUnlock()
+	s.stop(stop)
+}
+
+func (s *MarketSimulator) stop(generation chan struct{}) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if !s.running {
+	if !s.running || s.stopChan != generation {
 		return
 	}
 
@@ -91,68 +224,137 @@ func (s *MarketSimulator) Stop() {
 	s.logger.Info("Market simulator stopped")
 }
 
-func (s *MarketSimulator) GetUpdateChannel() <-chan *models.MarketData {
+func (s *MarketSimulator) Updates() <-chan *models.MarketData {
 	return s.updateChan
 }
 
-func (s *MarketSimulator) priceGenerator() {
-	ticker := time.NewTicker(1 * time.Second)
+func (s *MarketSimulator) SetBarInterval(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if interval <= 0 {
+		s.aggregator = nil
+		return
+	}
+	s.aggregator = NewBarAggregator(interval)
+}
+
+func (s *MarketSimulator) SetCorrelations(correlations map[string]map[string]float64) error {
+	var structure *correlation
+	if len(correlations) > 0 {
+		var err error
+		if structure, err = newCorrelation(correlations); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.correlation = structure
+	return nil
+}
+
+func (s *MarketSimulator) SetMetrics(m *metrics.Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics = m
+}
+
+func (s *MarketSimulator) priceGenerator(stop <-chan struct{}) {
+	ticker := s.clock.NewTicker(s.tickInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
 			s.updatePrices()
-		case <-s.stopChan:
+		case <-stop:
 			return
 		}
 	}
 }
 
-func (s *MarketSimulator) volumeGenerator() {
-	ticker := time.NewTicker(5 * time.Second)
+func (s *MarketSimulator) volumeGenerator(stop <-chan struct{}) {
+	ticker := s.clock.NewTicker(volumeInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
 			s.updateVolumes()
-		case <-s.stopChan:
+		case <-stop:
 			return
 		}
 	}
 }
 
-func (s *MarketSimulator) trendGenerator() {
-	ticker := time.NewTicker(30 * time.Second)
+func (s *MarketSimulator) trendGenerator(stop <-chan struct{}) {
+	ticker := s.clock.NewTicker(trendInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
 			s.updateTrends()
-		case <-s.stopChan:
+		case <-stop:
 			return
 		}
 	}
 }
 
+func (s *MarketSimulator) TickInterval() time.Duration {
+	return s.tickInterval
+}
+
+func (s *MarketSimulator) Step() {
+	s.updatePrices()
+	s.stepped += s.tickInterval
+	if s.stepped%volumeInterval < s.tickInterval {
+		s.updateVolumes()
+	}
+	if s.stepped%trendInterval < s.tickInterval {
+		s.updateTrends()
+	}
+}
+
 func (s *MarketSimulator) updatePrices() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for symbol, data := range s.symbols {
-		priceChange := s.calculatePriceChange(data)
-		newPrice := data.CurrentPrice.Add(priceChange)
+	now := s.clock.Now()
+	if s.aggregator != nil {
+		for _, bar := range s.aggregator.Advance(now) {
+			s.publish(bar)
+		}
+	}
 
-		if newPrice.LessThanOrEqual(decimal.Zero) {
-			newPrice = decimal.NewFromFloat(0.01)
+	s.fireNews(now)
+	s.fireScheduledEvents(now)
+	s.fireCorporateActions(now)
+	s.fireRandomEvents(now)
+
+	shocks := s.correlation.draw(s.rng)
+	for _, symbol := range s.sortedSymbols() {
+		data := s.symbols[symbol]
+		if !s.calendar.IsOpen(symbol, now) {
+			if data.closedSince.IsZero() {
+				data.closedSince = now
+			}
+			continue
+		}
+		if !data.closedSince.IsZero() {
+			if s.openingGap {
+				s.applyOpeningGap(data, now.Sub(data.closedSince))
+			}
+			data.closedSince = time.Time{}
 		}
 
+		newPrice := s.priceModel.NextPrice(data, s.tickInterval, shocks.shock(symbol))
+
 		data.Open = data.CurrentPrice
 		data.CurrentPrice = newPrice
 		data.Close = newPrice
-		data.LastUpdate = time.Now()
+		data.LastUpdate = now
 
 		if newPrice.GreaterThan(data.High) {
 			data.High = newPrice
@@ -161,51 +363,101 @@ func (s *MarketSimulator) updatePrices() {
 			data.Low = newPrice
 		}
 
-		marketData := &models.MarketData{
+		price, bid, ask := s.gridQuote(symbol, newPrice, data.Volatility)
+		tick := &models.MarketData{
 			Symbol:    symbol,
-			Price:     newPrice,
+			Kind:      models.MarketDataKindTick,
+			Price:     price,
+			Bid:       bid,
+			Ask:       ask,
+			BidSize:   minQuoteSize + s.rng.Int63n(maxQuoteSize-minQuoteSize),
+			AskSize:   minQuoteSize + s.rng.Int63n(maxQuoteSize-minQuoteSize),
 			Volume:    data.Volume,
-			High:      data.High,
-			Low:       data.Low,
-			Open:      data.Open,
-			Close:     data.Close,
-			Timestamp: time.Now(),
+			High:      price,
+			Low:       price,
+			Open:      price,
+			Close:     price,
+			Timestamp: now,
 		}
 
-		select {
-		case s.updateChan <- marketData:
-		default:
-			s.logger.Warn("Update channel full, dropping market data", zap.String("symbol", symbol))
+		data.decaySpike()
+
+		s.metrics.ObservePrice(symbol, price)
+		s.publish(tick)
+		if s.aggregator != nil {
+			if bar := s.aggregator.Add(tick); bar != nil {
+				s.publish(bar)
+			}
 		}
 	}
 }
 
-func (s *MarketSimulator) calculatePriceChange(data *SymbolData) decimal.Decimal {
-	randomFactor := decimal.NewFromFloat(rand.NormFloat64())
-	volatilityImpact := data.Volatility.Mul(randomFactor)
-	trendImpact := data.Trend.Mul(decimal.NewFromFloat(0.1))
+func (s *MarketSimulator) publish(marketData *models.MarketData) {
+	s.record(marketData, false)
+	delivered, dropped := s.coalescer.Publish(marketData)
+	if delivered {
+		return
+	}
+	for i := 0; i < dropped; i++ {
+		s.metrics.MarketDataDropped()
+	}
+	s.logger.Debug("Update channel full, coalescing market data",
+		zap.String("symbol", marketData.Symbol),
+		zap.String("kind", string(marketData.Kind)),
+		zap.Int("pending", s.coalescer.Pending()),
+		zap.Int("dropped", dropped))
+}
 
-	priceChange := volatilityImpact.Add(trendImpact)
+func (s *MarketSimulator) applyOpeningGap(data *SymbolData, closed time.Duration) {
+	years := closed.Seconds() / secondsPerYear
+	gapped := decimal.NewFromFloat(data.CurrentPrice.InexactFloat64() * math.Exp(data.Trend.InexactFloat64()*years))
+	if gapped.LessThan(minPrice) {
+		gapped = minPrice
+	}
 
-	priceChangePercent := priceChange.Div(data.CurrentPrice)
+	s.logger.Debug("Opening gap", zap.String("symbol", data.Symbol), zap.String("from", data.CurrentPrice.String()), zap.String("to", gapped.String()))
+	data.CurrentPrice = gapped
+}
 
-	if priceChangePercent.Abs().GreaterThan(decimal.NewFromFloat(0.1)) {
-		if priceChangePercent.IsPositive() {
-			priceChangePercent = decimal.NewFromFloat(0.1)
-		} else {
-			priceChangePercent = decimal.NewFromFloat(-0.1)
-		}
+func (s *MarketSimulator) quote(mid, volatility decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	halfSpread := mid.Mul(volatility.Abs()).Mul(decimal.NewFromFloat(spreadFactor))
+	if halfSpread.LessThan(minHalfSpread) {
+		halfSpread = minHalfSpread
 	}
 
-	return data.CurrentPrice.Mul(priceChangePercent)
+	bid := mid.Sub(halfSpread)
+	if !bid.IsPositive() {
+		bid = mid.Div(decimal.NewFromInt(2))
+	}
+	return bid, mid.Add(halfSpread)
+}
+
+func (s *MarketSimulator) gridQuote(symbol string, mid, volatility decimal.Decimal) (decimal.Decimal, decimal.Decimal, decimal.Decimal) {
+	price := s.instruments.RoundPrice(symbol, mid)
+	if !price.IsPositive() {
+		price = s.instruments.RoundPriceUp(symbol, mid)
+	}
+	bid, ask := s.quote(price, volatility)
+
+	tickSize := s.instruments.TickSize(symbol)
+	if !tickSize.IsPositive() {
+		return price, bid, ask
+	}
+	bid = decimal.Min(s.instruments.RoundPriceDown(symbol, bid), price.Sub(tickSize))
+	if !bid.IsPositive() {
+		bid = price
+	}
+	ask = decimal.Max(s.instruments.RoundPriceUp(symbol, ask), price.Add(tickSize))
+	return price, bid, ask
 }
 
 func (s *MarketSimulator) updateVolumes() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for _, data := range s.symbols {
-		volumeChange := rand.Int63n(100000) - 50000
+	for _, symbol := range s.sortedSymbols() {
+		data := s.symbols[symbol]
+		volumeChange := s.rng.Int63n(100000) - 50000
 		newVolume := data.Volume + volumeChange
 
 		if newVolume < 10000 {
@@ -220,8 +472,9 @@ func (s *MarketSimulator) updateTrends() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for _, data := range s.symbols {
-		trendChange := decimal.NewFromFloat(rand.NormFloat64() * 0.01)
+	for _, symbol := range s.sortedSymbols() {
+		data := s.symbols[symbol]
+		trendChange := decimal.NewFromFloat(s.rng.NormFloat64() * 0.01)
 		data.Trend = data.Trend.Add(trendChange)
 
 		if data.Trend.Abs().GreaterThan(decimal.NewFromFloat(0.05)) {
@@ -244,14 +497,18 @@ func (s *MarketSimulator) GetSymbolData(symbol string) *SymbolData {
 	return nil
 }
 
-func (s *MarketSimulator) GetAllSymbols() []string {
+func (s *MarketSimulator) Symbols() []string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.sortedSymbols()
+}
 
+func (s *MarketSimulator) sortedSymbols() []string {
 	symbols := make([]string, 0, len(s.symbols))
 	for symbol := range s.symbols {
 		symbols = append(symbols, symbol)
 	}
+	sort.Strings(symbols)
 	return symbols
 }
 
@@ -261,6 +518,7 @@ func (s *MarketSimulator) SetVolatility(symbol string, volatility decimal.Decima
 
 	if data, exists := s.symbols[symbol]; exists {
 		data.Volatility = volatility
+		data.spike = nil
 		s.logger.Info("Volatility updated", zap.String("symbol", symbol), zap.String("volatility", volatility.String()))
 	}
 }
@@ -279,19 +537,5 @@ func (s *MarketSimulator) AddMarketEvent(symbol string, eventType string, impact
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if data, exists := s.symbols[symbol]; exists {
-		switch eventType {
-		case "price_shock":
-			data.CurrentPrice = data.CurrentPrice.Mul(decimal.NewFromFloat(1.0).Add(impact))
-		case "volatility_spike":
-			data.Volatility = data.Volatility.Mul(decimal.NewFromFloat(1.0).Add(impact))
-		case "trend_change":
-			data.Trend = data.Trend.Add(impact)
-		}
-
-		s.logger.Info("Market event applied",
-			zap.String("symbol", symbol),
-			zap.String("event_type", eventType),
-			zap.String("impact", impact.String()))
-	}
+	s.applyEvent(MarketEvent{Symbol: symbol, Type: eventType, Impact: impact, At: s.clock.Now()})
 }
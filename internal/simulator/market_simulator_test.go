@@ -0,0 +1,153 @@
+package simulator
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/metrics"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMarketSimulator_Metrics(t *testing.T) {
+	m := metrics.New()
+	sim := NewMarketSimulator(zap.NewNop())
+	sim.SetMetrics(m)
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.02))
+
+	for i := 0; i < cap(sim.updateChan)+3; i++ {
+		sim.updatePrices()
+	}
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+	assert.Contains(t, body, "trade_algo_market_data_dropped_total 2")
+	assert.Equal(t, 1, sim.coalescer.Pending())
+	assert.Contains(t, body, `trade_algo_symbol_price{symbol="AAPL"}`)
+}
+
+func TestMarketSimulator_QuotesWidenWithVolatility(t *testing.T) {
+	sim := NewMarketSimulator(zap.NewNop())
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.02))
+
+	sim.updatePrices()
+	calm := <-sim.Updates()
+	assert.True(t, calm.Bid.LessThan(calm.Price))
+	assert.True(t, calm.Ask.GreaterThan(calm.Price))
+	assert.True(t, calm.Price.Sub(calm.Bid).Equal(calm.Ask.Sub(calm.Price)))
+	assert.Positive(t, calm.BidSize)
+	assert.Positive(t, calm.AskSize)
+
+	sim.AddMarketEvent("AAPL", "volatility_spike", decimal.NewFromFloat(2.0))
+	sim.updatePrices()
+	spike := <-sim.Updates()
+
+	calmSpread := calm.Ask.Sub(calm.Bid).Div(calm.Price)
+	spikeSpread := spike.Ask.Sub(spike.Bid).Div(spike.Price)
+	assert.True(t, spikeSpread.GreaterThan(calmSpread))
+}
+
+func TestMarketSimulator_TickInterval(t *testing.T) {
+	start := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	sim := NewMarketSimulator(zap.NewNop(), WithSeed(42), WithTickInterval(10*time.Millisecond), WithClock(fake))
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.02))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, sim.Start(ctx))
+	defer sim.Stop()
+	fake.BlockUntil(3)
+
+	fake.Advance(9 * time.Millisecond)
+	assert.Empty(t, sim.Updates())
+	for i := 1; i <= 5; i++ {
+		fake.Advance(time.Millisecond)
+		tick := <-sim.Updates()
+		assert.Equal(t, start.Add(time.Duration(i)*10*time.Millisecond), tick.Timestamp)
+		fake.Advance(9 * time.Millisecond)
+	}
+}
+
+func TestMarketSimulator_Step_MatchesTickerSchedule(t *testing.T) {
+	expected := runSeededTicks(t, createSeededSimulator(42), 90)
+
+	sim := createSeededSimulator(42)
+	var stepped []*models.MarketData
+	for i := 0; i < 90; i++ {
+		sim.clock.(*clock.Fake).Advance(sim.TickInterval())
+		sim.Step()
+		for len(sim.Updates()) > 0 {
+			stepped = append(stepped, <-sim.Updates())
+		}
+	}
+
+	assert.Equal(t, expected, stepped)
+}
+
+func TestMarketSimulator_DefaultTickInterval(t *testing.T) {
+	assert.Equal(t, time.Second, NewMarketSimulator(zap.NewNop()).tickInterval)
+	assert.Equal(t, time.Second, NewMarketSimulator(zap.NewNop(), WithTickInterval(0)).tickInterval)
+}
+
+func TestMarketSimulator_RestartAfterStop(t *testing.T) {
+	sim := NewMarketSimulator(zap.NewNop(), WithSeed(42), WithTickInterval(5*time.Millisecond))
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.02))
+
+	require.NoError(t, sim.Start(context.Background()))
+	first := sim.stopChan
+	sim.Stop()
+	for len(sim.Updates()) > 0 {
+		<-sim.Updates()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, sim.Start(ctx))
+	defer sim.Stop()
+
+	sim.stop(first)
+	select {
+	case <-sim.Updates():
+	case <-time.After(time.Second):
+		t.Fatal("no ticks after restart")
+	}
+	assert.True(t, sim.running)
+}
+
+func TestMarketSimulator_AddInstrument_EmitsOnTickGrid(t *testing.T) {
+	tickSize := decimal.NewFromFloat(0.05)
+	sim := NewMarketSimulator(zap.NewNop(), WithSeed(3), WithClock(clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))))
+	require.NoError(t, sim.AddInstrument(models.Instrument{Symbol: "ES", TickSize: tickSize, LotSize: decimal.NewFromInt(1)}, decimal.NewFromFloat(100.03), decimal.NewFromFloat(0.3)))
+
+	history, err := sim.PreloadHistory("ES", 50)
+	require.NoError(t, err)
+	emitted := append(history, runSeededTicks(t, sim, 500)...)
+
+	offGrid := decimal.NewFromFloat(100.03)
+	for _, data := range emitted {
+		for _, price := range []decimal.Decimal{data.Price, data.Bid, data.Ask} {
+			assert.True(t, price.Mod(tickSize).IsZero(), price.String())
+			assert.False(t, price.Equal(offGrid))
+		}
+		assert.True(t, data.Bid.LessThan(data.Price))
+		assert.True(t, data.Ask.GreaterThan(data.Price))
+	}
+}
+
+func TestMarketSimulator_AddInstrument_RejectsInvalidMetadata(t *testing.T) {
+	sim := NewMarketSimulator(zap.NewNop())
+
+	err := sim.AddInstrument(models.Instrument{Symbol: "ES", TickSize: decimal.NewFromFloat(-0.25), LotSize: decimal.NewFromInt(1)}, decimal.NewFromInt(100), decimal.Zero)
+
+	assert.ErrorIs(t, err, symbols.ErrInvalidTickSize)
+	assert.Nil(t, sim.GetSymbolData("ES"))
+}
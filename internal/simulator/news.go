@@ -0,0 +1,147 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultNewsLag = 5 * time.Second
+	maxNewsLog     = 1000
+)
+
+var newsImpact = map[models.NewsSeverity]decimal.Decimal{
+	models.NewsSeverityLow:    decimal.NewFromFloat(0.01),
+	models.NewsSeverityMedium: decimal.NewFromFloat(0.03),
+	models.NewsSeverityHigh:   decimal.NewFromFloat(0.08),
+}
+
+var newsSeverities = []models.NewsSeverity{models.NewsSeverityLow, models.NewsSeverityMedium, models.NewsSeverityHigh}
+
+var (
+	positiveHeadlines = []string{"%s beats earnings estimates", "%s announces share buyback", "Analysts upgrade %s", "%s wins major contract"}
+	negativeHeadlines = []string{"%s misses earnings estimates", "%s faces regulatory probe", "Analysts downgrade %s", "%s recalls flagship product"}
+)
+
+func WithNewsLag(lag time.Duration) Option {
+	return func(s *MarketSimulator) {
+		if lag >= 0 {
+			s.newsLag = lag
+		}
+	}
+}
+
+func WithRandomNews(rate float64) Option {
+	return func(s *MarketSimulator) {
+		s.randomNews = max(rate, 0)
+	}
+}
+
+func (s *MarketSimulator) ScheduleNews(event models.NewsEvent) error {
+	if event.Sentiment.LessThan(decimal.NewFromInt(-1)) || event.Sentiment.GreaterThan(decimal.NewFromInt(1)) {
+		return fmt.Errorf("%w: %s sentiment %s outside -1..1", ErrInvalidNews, event.Symbol, event.Sentiment)
+	}
+	if _, exists := newsImpact[event.Severity]; !exists {
+		return fmt.Errorf("%w: %s severity %q", ErrInvalidNews, event.Symbol, event.Severity)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.symbols[event.Symbol]; !exists {
+		return fmt.Errorf("%w: unknown symbol %q", ErrInvalidNews, event.Symbol)
+	}
+	s.news = append(s.news, event)
+	sort.SliceStable(s.news, func(i, j int) bool { return s.news[i].Timestamp.Before(s.news[j].Timestamp) })
+	return nil
+}
+
+func (s *MarketSimulator) EnableRandomNews(rate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.randomNews = max(rate, 0)
+}
+
+func (s *MarketSimulator) News() []models.NewsEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	news := make([]models.NewsEvent, len(s.newsLog))
+	copy(news, s.newsLog)
+	return news
+}
+
+func (s *MarketSimulator) fireNews(now time.Time) {
+	fired := 0
+	for _, event := range s.news {
+		if event.Timestamp.After(now) {
+			break
+		}
+		event.Timestamp = now
+		s.emitNews(event)
+		fired++
+	}
+	s.news = s.news[fired:]
+
+	if s.randomNews <= 0 {
+		return
+	}
+	probability := 1 - math.Exp(-s.randomNews*s.tickInterval.Hours())
+	for _, symbol := range s.sortedSymbols() {
+		if s.rng.Float64() >= probability {
+			continue
+		}
+
+		sentiment := decimal.NewFromFloat(s.rng.Float64()*2 - 1).Round(2)
+		headlines := positiveHeadlines
+		if sentiment.IsNegative() {
+			headlines = negativeHeadlines
+		}
+		s.emitNews(models.NewsEvent{
+			Symbol:    symbol,
+			Headline:  fmt.Sprintf(headlines[s.rng.Intn(len(headlines))], symbol),
+			Sentiment: sentiment,
+			Severity:  newsSeverities[s.rng.Intn(len(newsSeverities))],
+			Timestamp: now,
+		})
+	}
+}
+
+func (s *MarketSimulator) emitNews(event models.NewsEvent) {
+	data, exists := s.symbols[event.Symbol]
+	if !exists {
+		return
+	}
+
+	magnitude := newsImpact[event.Severity]
+	at := event.Timestamp.Add(s.newsLag)
+	if !event.Sentiment.IsZero() {
+		s.scheduleEvent(MarketEvent{Symbol: event.Symbol, Type: EventPriceShock, Impact: event.Sentiment.Mul(magnitude), At: at})
+	}
+	s.scheduleEvent(MarketEvent{Symbol: event.Symbol, Type: EventVolatilitySpike, Impact: magnitude.Mul(decimal.NewFromInt(randomSpikeScale)), At: at})
+
+	s.newsLog = append(s.newsLog, event)
+	if len(s.newsLog) > maxNewsLog {
+		s.newsLog = s.newsLog[len(s.newsLog)-maxNewsLog:]
+	}
+
+	s.logger.Info("News published",
+		zap.String("symbol", event.Symbol),
+		zap.String("headline", event.Headline),
+		zap.String("sentiment", event.Sentiment.String()),
+		zap.String("severity", string(event.Severity)),
+		zap.Time("at", event.Timestamp))
+	s.publish(&models.MarketData{
+		Symbol:    event.Symbol,
+		Kind:      models.MarketDataKindNews,
+		Price:     data.CurrentPrice,
+		Timestamp: event.Timestamp,
+		News:      &event,
+	})
+}
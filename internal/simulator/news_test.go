@@ -0,0 +1,89 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runWithNews(sim *eventSimulator, ticks int) ([]float64, []*models.MarketData) {
+	prices := make([]float64, 0, ticks)
+	var news []*models.MarketData
+	for i := 0; i < ticks; i++ {
+		sim.fake.Advance(time.Second)
+		sim.updatePrices()
+		for len(sim.Updates()) > 0 {
+			update := <-sim.Updates()
+			if update.Kind == models.MarketDataKindNews {
+				news = append(news, update)
+				continue
+			}
+			prices = append(prices, update.Price.InexactFloat64())
+		}
+	}
+	return prices, news
+}
+
+func TestMarketSimulator_NewsImpactFollowsLag(t *testing.T) {
+	baseline, _ := runWithNews(createEventSimulator(), 30)
+
+	sim := createEventSimulator(WithNewsLag(3 * time.Second))
+	require.NoError(t, sim.ScheduleNews(models.NewsEvent{
+		Symbol:    "AAPL",
+		Headline:  "AAPL beats earnings estimates",
+		Sentiment: decimal.NewFromFloat(0.5),
+		Severity:  models.NewsSeverityHigh,
+		Timestamp: eventClockStart.Add(10 * time.Second),
+	}))
+	prices, news := runWithNews(sim, 30)
+
+	require.Len(t, news, 1)
+	assert.Equal(t, eventClockStart.Add(10*time.Second), news[0].Timestamp)
+	assert.Equal(t, "AAPL beats earnings estimates", news[0].News.Headline)
+
+	for i := 0; i < 12; i++ {
+		assert.InDelta(t, baseline[i], prices[i], 1e-9, "tick %d", i+1)
+	}
+	assert.NotEqual(t, baseline[12], prices[12])
+
+	events := sim.Events()
+	require.Len(t, events, 2)
+	for _, event := range events {
+		assert.Equal(t, news[0].Timestamp.Add(3*time.Second), event.At)
+	}
+	assert.Equal(t, EventPriceShock, events[0].Type)
+	assert.True(t, decimal.NewFromFloat(0.04).Equal(events[0].Impact))
+	assert.Equal(t, EventVolatilitySpike, events[1].Type)
+}
+
+func TestMarketSimulator_RandomNewsIsSeeded(t *testing.T) {
+	run := func() []models.NewsEvent {
+		sim := createEventSimulator()
+		sim.EnableRandomNews(600)
+		runWithNews(sim, 60)
+		return sim.News()
+	}
+
+	first := run()
+	require.NotEmpty(t, first)
+	assert.Equal(t, first, run())
+	for _, event := range first {
+		assert.Equal(t, "AAPL", event.Symbol)
+		assert.Contains(t, event.Headline, "AAPL")
+		assert.True(t, event.Sentiment.Abs().LessThanOrEqual(decimal.NewFromInt(1)))
+		assert.Contains(t, newsSeverities, event.Severity)
+	}
+}
+
+func TestMarketSimulator_ScheduleNews_Invalid(t *testing.T) {
+	sim := createEventSimulator()
+	at := eventClockStart.Add(time.Second)
+
+	assert.ErrorIs(t, sim.ScheduleNews(models.NewsEvent{Symbol: "AAPL", Sentiment: decimal.NewFromFloat(1.5), Severity: models.NewsSeverityLow, Timestamp: at}), ErrInvalidNews)
+	assert.ErrorIs(t, sim.ScheduleNews(models.NewsEvent{Symbol: "AAPL", Sentiment: decimal.NewFromFloat(0.5), Severity: "extreme", Timestamp: at}), ErrInvalidNews)
+	assert.ErrorIs(t, sim.ScheduleNews(models.NewsEvent{Symbol: "MSFT", Sentiment: decimal.NewFromFloat(0.5), Severity: models.NewsSeverityLow, Timestamp: at}), ErrInvalidNews)
+}
@@ -0,0 +1,73 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	PriceModelLegacy = "legacy"
+	PriceModelGBM    = "gbm"
+
+	secondsPerYear = 365 * 24 * 60 * 60
+)
+
+var minPrice = decimal.NewFromFloat(0.01)
+
+type PriceModel interface {
+	NextPrice(data *SymbolData, dt time.Duration, shock float64) decimal.Decimal
+}
+
+func NewPriceModel(name string) (PriceModel, error) {
+	switch name {
+	case PriceModelLegacy:
+		return LegacyPriceModel{}, nil
+	case PriceModelGBM:
+		return GBMPriceModel{}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownPriceModel, name)
+}
+
+type LegacyPriceModel struct{}
+
+func (LegacyPriceModel) NextPrice(data *SymbolData, dt time.Duration, shock float64) decimal.Decimal {
+	randomFactor := decimal.NewFromFloat(shock)
+	volatilityImpact := data.Volatility.Mul(randomFactor)
+	trendImpact := data.Trend.Mul(decimal.NewFromFloat(0.1))
+
+	priceChange := volatilityImpact.Add(trendImpact)
+
+	priceChangePercent := priceChange.Div(data.CurrentPrice)
+
+	if priceChangePercent.Abs().GreaterThan(decimal.NewFromFloat(0.1)) {
+		if priceChangePercent.IsPositive() {
+			priceChangePercent = decimal.NewFromFloat(0.1)
+		} else {
+			priceChangePercent = decimal.NewFromFloat(-0.1)
+		}
+	}
+
+	newPrice := data.CurrentPrice.Add(data.CurrentPrice.Mul(priceChangePercent))
+	if newPrice.LessThanOrEqual(decimal.Zero) {
+		return minPrice
+	}
+	return newPrice
+}
+
+type GBMPriceModel struct{}
+
+func (GBMPriceModel) NextPrice(data *SymbolData, dt time.Duration, shock float64) decimal.Decimal {
+	years := dt.Seconds() / secondsPerYear
+	mu := data.Trend.InexactFloat64()
+	sigma := data.Volatility.InexactFloat64()
+
+	logReturn := (mu-sigma*sigma/2)*years + sigma*math.Sqrt(years)*shock
+	newPrice := decimal.NewFromFloat(data.CurrentPrice.InexactFloat64() * math.Exp(logReturn))
+	if newPrice.LessThan(minPrice) {
+		return minPrice
+	}
+	return newPrice
+}
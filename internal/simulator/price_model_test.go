@@ -0,0 +1,89 @@
+package simulator
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func logReturns(prices []float64) []float64 {
+	returns := make([]float64, 0, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		returns = append(returns, math.Log(prices[i]/prices[i-1]))
+	}
+	return returns
+}
+
+func simulateGBM(data *SymbolData, dt time.Duration, steps int, rng *rand.Rand) []float64 {
+	model := GBMPriceModel{}
+	prices := []float64{data.CurrentPrice.InexactFloat64()}
+	for i := 0; i < steps; i++ {
+		data.CurrentPrice = model.NextPrice(data, dt, rng.NormFloat64())
+		prices = append(prices, data.CurrentPrice.InexactFloat64())
+	}
+	return prices
+}
+
+func TestGBMPriceModel_RealizedVolatilityMatchesSigma(t *testing.T) {
+	data := &SymbolData{Symbol: "AAPL", CurrentPrice: decimal.NewFromFloat(150.0), Volatility: decimal.NewFromFloat(0.3)}
+	returns := logReturns(simulateGBM(data, time.Second, 50000, rand.New(rand.NewSource(42))))
+
+	realized := risk.StdDev(returns) / math.Sqrt(1.0/secondsPerYear)
+	assert.InDelta(t, 0.3, realized, 0.3*0.03)
+}
+
+func TestGBMPriceModel_ScaleInvariant(t *testing.T) {
+	cheap := &SymbolData{CurrentPrice: decimal.NewFromFloat(3.0), Volatility: decimal.NewFromFloat(0.5)}
+	expensive := &SymbolData{CurrentPrice: decimal.NewFromFloat(3000.0), Volatility: decimal.NewFromFloat(0.5)}
+
+	cheapVol := risk.StdDev(logReturns(simulateGBM(cheap, time.Minute, 20000, rand.New(rand.NewSource(7)))))
+	expensiveVol := risk.StdDev(logReturns(simulateGBM(expensive, time.Minute, 20000, rand.New(rand.NewSource(7)))))
+	assert.InDelta(t, cheapVol, expensiveVol, cheapVol*0.01)
+}
+
+func TestGBMPriceModel_DriftFollowsTrend(t *testing.T) {
+	data := &SymbolData{CurrentPrice: decimal.NewFromFloat(100.0), Volatility: decimal.NewFromFloat(0.2), Trend: decimal.NewFromFloat(0.5)}
+	returns := logReturns(simulateGBM(data, 24*time.Hour, 20000, rand.New(rand.NewSource(42))))
+
+	years := (24 * time.Hour).Seconds() / secondsPerYear
+	assert.InDelta(t, 0.5-0.2*0.2/2, risk.Mean(returns)/years, 0.1)
+}
+
+func TestMarketSimulator_GBMFollowsVolatilitySpike(t *testing.T) {
+	sim := NewMarketSimulator(zap.NewNop(), WithSeed(42), WithPriceModel(GBMPriceModel{}))
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.4))
+
+	realizedVolatility := func(ticks int) float64 {
+		prices := []float64{sim.GetSymbolData("AAPL").CurrentPrice.InexactFloat64()}
+		for i := 0; i < ticks; i++ {
+			sim.updatePrices()
+			prices = append(prices, (<-sim.Updates()).Price.InexactFloat64())
+		}
+		return risk.StdDev(logReturns(prices)) / math.Sqrt(defaultTickInterval.Seconds()/secondsPerYear)
+	}
+
+	assert.InDelta(t, 0.4, realizedVolatility(20000), 0.4*0.05)
+
+	sim.SetVolatility("AAPL", decimal.NewFromFloat(0.8))
+	assert.InDelta(t, 0.8, realizedVolatility(20000), 0.8*0.05)
+}
+
+func TestNewPriceModel(t *testing.T) {
+	model, err := NewPriceModel(PriceModelGBM)
+	require.NoError(t, err)
+	assert.IsType(t, GBMPriceModel{}, model)
+
+	model, err = NewPriceModel(PriceModelLegacy)
+	require.NoError(t, err)
+	assert.IsType(t, LegacyPriceModel{}, model)
+
+	_, err = NewPriceModel("random_walk")
+	assert.ErrorIs(t, err, ErrUnknownPriceModel)
+}
@@ -0,0 +1,53 @@
+package simulator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"go.uber.org/zap"
+)
+
+const maxRecordedLine = 1024 * 1024
+
+type RecordedData struct {
+	WarmUp bool `json:"warm_up,omitempty"`
+	*models.MarketData
+}
+
+func WithRecorder(w io.Writer) Option {
+	return func(s *MarketSimulator) {
+		s.recorder = json.NewEncoder(w)
+	}
+}
+
+func (s *MarketSimulator) record(data *models.MarketData, warmUp bool) {
+	if s.recorder == nil {
+		return
+	}
+	if err := s.recorder.Encode(RecordedData{WarmUp: warmUp, MarketData: data}); err != nil {
+		s.logger.Warn("Failed to record market data", zap.String("symbol", data.Symbol), zap.Error(err))
+	}
+}
+
+func ReadRecording(r io.Reader) ([]RecordedData, error) {
+	var recorded []RecordedData
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordedLine)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var data RecordedData
+		if err := json.Unmarshal(scanner.Bytes(), &data); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidRecording, line, err)
+		}
+		if data.MarketData == nil || data.Symbol == "" {
+			return nil, fmt.Errorf("%w: line %d has no symbol", ErrInvalidRecording, line)
+		}
+		recorded = append(recorded, data)
+	}
+	return recorded, scanner.Err()
+}
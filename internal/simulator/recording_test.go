@@ -0,0 +1,58 @@
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMarketSimulator_WithRecorder_WritesWarmUpAndEmittedData(t *testing.T) {
+	var recording bytes.Buffer
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	sim := NewMarketSimulator(zap.NewNop(), WithSeed(42), WithClock(fake), WithRecorder(&recording))
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.5))
+	sim.SetBarInterval(5 * time.Second)
+
+	history, err := sim.PreloadHistory("AAPL", 3)
+	require.NoError(t, err)
+	stepped := NewSteppedFeed(sim, fake, 10)
+	require.NoError(t, stepped.Start(context.Background()))
+	var emitted []*models.MarketData
+	for data := range stepped.Updates() {
+		emitted = append(emitted, data)
+	}
+	stepped.Stop()
+
+	recorded, err := ReadRecording(&recording)
+	require.NoError(t, err)
+	require.Len(t, recorded, len(history)+len(emitted))
+	for i, data := range history {
+		assert.True(t, recorded[i].WarmUp)
+		assert.True(t, data.Price.Equal(recorded[i].Price))
+	}
+	for i, data := range recorded[len(history):] {
+		assert.False(t, data.WarmUp)
+		assert.Equal(t, emitted[i].Kind, data.Kind)
+		assert.True(t, emitted[i].Price.Equal(data.Price))
+		assert.True(t, emitted[i].Timestamp.Equal(data.Timestamp))
+	}
+	assert.Len(t, emitted, 12)
+}
+
+func TestReadRecording_RejectsMalformedLines(t *testing.T) {
+	_, err := ReadRecording(strings.NewReader(`{"symbol":"AAPL","price":"1"}` + "\n{not json}\n"))
+	assert.ErrorIs(t, err, ErrInvalidRecording)
+	assert.Contains(t, err.Error(), "line 2")
+
+	_, err = ReadRecording(strings.NewReader(`{"price":"1"}`))
+	assert.ErrorIs(t, err, ErrInvalidRecording)
+}
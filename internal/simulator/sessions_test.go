@@ -0,0 +1,81 @@
+package simulator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/calendar"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func createSessionSimulator(t *testing.T, fake *clock.Fake, opts ...Option) *MarketSimulator {
+	t.Helper()
+	session, err := calendar.ParseSession("09:30-16:00", time.UTC)
+	require.NoError(t, err)
+	cal := calendar.NewCalendar(session)
+	cal.SetSession("BTCUSDT", calendar.AlwaysOpen())
+
+	opts = append(opts, WithSeed(1), WithCalendar(cal), WithClock(fake))
+	return NewMarketSimulator(zap.NewNop(), opts...)
+}
+
+func drainTicks(sim *MarketSimulator) map[string][]*models.MarketData {
+	ticks := make(map[string][]*models.MarketData)
+	for len(sim.updateChan) > 0 {
+		tick := <-sim.updateChan
+		ticks[tick.Symbol] = append(ticks[tick.Symbol], tick)
+	}
+	return ticks
+}
+
+func TestMarketSimulator_NoTicksOutsideSession(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 3, 5, 15, 58, 0, 0, time.UTC))
+	sim := createSessionSimulator(t, fake)
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.NewFromFloat(0.02))
+	sim.AddSymbol("BTCUSDT", decimal.NewFromFloat(65000.0), decimal.NewFromFloat(50.0))
+
+	for i := 0; i < 6; i++ {
+		sim.updatePrices()
+		fake.Advance(time.Minute)
+	}
+
+	ticks := drainTicks(sim)
+	require.Len(t, ticks["AAPL"], 2)
+	assert.True(t, ticks["AAPL"][1].Timestamp.Before(time.Date(2024, 3, 5, 16, 0, 0, 0, time.UTC)))
+	assert.Len(t, ticks["BTCUSDT"], 6)
+
+	fake.Set(time.Date(2024, 3, 6, 9, 30, 0, 0, time.UTC))
+	sim.updatePrices()
+	ticks = drainTicks(sim)
+	assert.Len(t, ticks["AAPL"], 1)
+	assert.Len(t, ticks["BTCUSDT"], 1)
+}
+
+func TestMarketSimulator_OpeningGapFollowsOvernightTrend(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 3, 5, 15, 59, 0, 0, time.UTC))
+	sim := createSessionSimulator(t, fake, WithPriceModel(GBMPriceModel{}), WithOpeningGap())
+	sim.AddSymbol("AAPL", decimal.NewFromFloat(150.0), decimal.Zero)
+	sim.SetTrend("AAPL", decimal.NewFromFloat(2.0))
+
+	sim.updatePrices()
+	closing := (<-sim.Updates()).Price
+
+	fake.Set(time.Date(2024, 3, 5, 16, 0, 0, 0, time.UTC))
+	sim.updatePrices()
+	require.Empty(t, sim.updateChan)
+
+	fake.Set(time.Date(2024, 3, 6, 9, 30, 0, 0, time.UTC))
+	sim.updatePrices()
+	opening := (<-sim.Updates()).Price
+
+	overnight := (17*time.Hour + 30*time.Minute).Seconds() / secondsPerYear
+	expected := closing.InexactFloat64() * math.Exp(2.0*overnight)
+	assert.InDelta(t, expected, opening.InexactFloat64(), 0.01)
+	assert.True(t, opening.GreaterThan(closing))
+}
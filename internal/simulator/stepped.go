@@ -0,0 +1,87 @@
+package simulator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+)
+
+type SteppedFeed struct {
+	sim     *MarketSimulator
+	clock   *clock.Fake
+	ticks   int
+	updates chan *models.MarketData
+
+	mu      sync.Mutex
+	started bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func NewSteppedFeed(sim *MarketSimulator, fake *clock.Fake, ticks int) *SteppedFeed {
+	return &SteppedFeed{
+		sim:     sim,
+		clock:   fake,
+		ticks:   ticks,
+		updates: make(chan *models.MarketData, cap(sim.updateChan)),
+	}
+}
+
+func (f *SteppedFeed) Start(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.started {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	f.started = true
+	f.cancel = cancel
+	f.done = make(chan struct{})
+	go f.run(ctx, f.done)
+	return nil
+}
+
+func (f *SteppedFeed) Stop() {
+	f.mu.Lock()
+	if !f.started {
+		f.mu.Unlock()
+		return
+	}
+	cancel, done := f.cancel, f.done
+	f.mu.Unlock()
+
+	cancel()
+	<-done
+}
+
+func (f *SteppedFeed) Updates() <-chan *models.MarketData {
+	return f.updates
+}
+
+func (f *SteppedFeed) Symbols() []string {
+	return f.sim.Symbols()
+}
+
+func (f *SteppedFeed) PreloadHistory(symbol string, n int) ([]*models.MarketData, error) {
+	return f.sim.PreloadHistory(symbol, n)
+}
+
+func (f *SteppedFeed) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	defer close(f.updates)
+
+	for i := 0; i < f.ticks; i++ {
+		f.clock.Advance(f.sim.TickInterval())
+		f.sim.Step()
+		for f.sim.coalescer.Flush(); len(f.sim.updateChan) > 0; f.sim.coalescer.Flush() {
+			select {
+			case f.updates <- <-f.sim.updateChan:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
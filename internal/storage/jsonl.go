@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+)
+
+const (
+	archiveTradesFile = "trades.jsonl"
+	archiveOrdersFile = "orders.jsonl"
+)
+
+type JSONLArchive struct {
+	dir    string
+	mu     sync.Mutex
+	trades *os.File
+	orders *os.File
+}
+
+func NewJSONLArchive(dir string) *JSONLArchive {
+	return &JSONLArchive{dir: dir}
+}
+
+func (a *JSONLArchive) SaveTrade(ctx context.Context, trade *models.Trade) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.append(&a.trades, archiveTradesFile, trade)
+}
+
+func (a *JSONLArchive) SaveOrder(ctx context.Context, order *models.Order) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.append(&a.orders, archiveOrdersFile, order)
+}
+
+func (a *JSONLArchive) append(file **os.File, name string, value interface{}) error {
+	if *file == nil {
+		if err := os.MkdirAll(a.dir, 0o755); err != nil {
+			return err
+		}
+		opened, err := os.OpenFile(filepath.Join(a.dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		*file = opened
+	}
+
+	line, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = (*file).Write(append(line, '\n'))
+	return err
+}
+
+func (a *JSONLArchive) LoadTrades() ([]*models.Trade, error) {
+	var trades []*models.Trade
+	err := a.load(archiveTradesFile, func(line []byte) error {
+		var trade models.Trade
+		if err := json.Unmarshal(line, &trade); err != nil {
+			return err
+		}
+		trades = append(trades, &trade)
+		return nil
+	})
+	return trades, err
+}
+
+func (a *JSONLArchive) LoadOrders() ([]*models.Order, error) {
+	var orders []*models.Order
+	err := a.load(archiveOrdersFile, func(line []byte) error {
+		var order models.Order
+		if err := json.Unmarshal(line, &order); err != nil {
+			return err
+		}
+		orders = append(orders, &order)
+		return nil
+	})
+	return orders, err
+}
+
+func (a *JSONLArchive) load(name string, decode func(line []byte) error) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	file, err := os.Open(filepath.Join(a.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if err := decode(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (a *JSONLArchive) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var errs []error
+	for _, file := range []**os.File{&a.trades, &a.orders} {
+		if *file != nil {
+			errs = append(errs, (*file).Close())
+			*file = nil
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLArchive_RoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "archive")
+	archive := NewJSONLArchive(dir)
+	ctx := context.Background()
+
+	first := createTestTrade("t1", "AAPL", "ma", "150.123456789", storeStart)
+	second := createTestTrade("t2", "MSFT", "rsi", "301.5", storeStart.Add(1))
+	require.NoError(t, archive.SaveTrade(ctx, first))
+	require.NoError(t, archive.SaveTrade(ctx, second))
+	require.NoError(t, archive.SaveOrder(ctx, &models.Order{ID: "o1", Symbol: "AAPL", Status: models.OrderStatusFilled}))
+
+	trades, err := archive.LoadTrades()
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+	assert.Equal(t, "t1", trades[0].ID)
+	assert.Equal(t, first.Price.String(), trades[0].Price.String())
+	assert.Equal(t, "t2", trades[1].ID)
+
+	orders, err := archive.LoadOrders()
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, models.OrderStatusFilled, orders[0].Status)
+
+	require.NoError(t, archive.Close())
+	reopened := NewJSONLArchive(dir)
+	require.NoError(t, reopened.SaveTrade(ctx, createTestTrade("t3", "AAPL", "ma", "151", storeStart.Add(2))))
+	defer reopened.Close()
+	trades, err = reopened.LoadTrades()
+	require.NoError(t, err)
+	assert.Len(t, trades, 3)
+}
+
+func TestJSONLArchive_CreatesFilesLazily(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "archive")
+	archive := NewJSONLArchive(dir)
+	defer archive.Close()
+
+	trades, err := archive.LoadTrades()
+	require.NoError(t, err)
+	assert.Empty(t, trades)
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+}
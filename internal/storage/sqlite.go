@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	_ "modernc.org/sqlite"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS trades (
+	id              TEXT PRIMARY KEY,
+	order_id        TEXT NOT NULL,
+	symbol          TEXT NOT NULL,
+	side            TEXT NOT NULL,
+	quantity        TEXT NOT NULL,
+	price           TEXT NOT NULL,
+	requested_price TEXT NOT NULL,
+	commission      TEXT NOT NULL,
+	realized_pnl    TEXT NOT NULL,
+	timestamp       INTEGER NOT NULL,
+	strategy_id     TEXT NOT NULL,
+	exit_reason     TEXT NOT NULL,
+	risk_metrics    TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS trades_symbol ON trades (symbol, timestamp);
+CREATE INDEX IF NOT EXISTS trades_strategy ON trades (strategy_id, timestamp);
+CREATE INDEX IF NOT EXISTS trades_timestamp ON trades (timestamp);
+
+CREATE TABLE IF NOT EXISTS orders (
+	id           TEXT PRIMARY KEY,
+	symbol       TEXT NOT NULL,
+	side         TEXT NOT NULL,
+	type         TEXT NOT NULL,
+	quantity     TEXT NOT NULL,
+	price        TEXT NOT NULL,
+	stop_price   TEXT NOT NULL,
+	status       TEXT NOT NULL,
+	timestamp    INTEGER NOT NULL,
+	strategy_id  TEXT NOT NULL,
+	exit_reason  TEXT NOT NULL,
+	risk_metrics TEXT NOT NULL
+);
+`
+
+const tradeColumns = `id, order_id, symbol, side, quantity, price, requested_price, commission, realized_pnl, timestamp, strategy_id, exit_reason, risk_metrics`
+
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+func NewSQLiteStore(dsn string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) SaveTrade(ctx context.Context, trade *models.Trade) error {
+	riskMetrics, err := json.Marshal(trade.RiskMetrics)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO trades (`+tradeColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		trade.ID,
+		trade.OrderID,
+		trade.Symbol,
+		string(trade.Side),
+		trade.Quantity.String(),
+		trade.Price.String(),
+		trade.RequestedPrice.String(),
+		trade.Commission.String(),
+		trade.RealizedPnL.String(),
+		trade.Timestamp.UnixNano(),
+		trade.StrategyID,
+		string(trade.ExitReason),
+		string(riskMetrics),
+	)
+	return err
+}
+
+func (s *SQLiteStore) SaveOrder(ctx context.Context, order *models.Order) error {
+	riskMetrics, err := json.Marshal(order.RiskMetrics)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT OR REPLACE INTO orders (id, symbol, side, type, quantity, price, stop_price, status, timestamp, strategy_id, exit_reason, risk_metrics) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		order.ID,
+		order.Symbol,
+		string(order.Side),
+		string(order.Type),
+		order.Quantity.String(),
+		order.Price.String(),
+		order.StopPrice.String(),
+		string(order.Status),
+		order.Timestamp.UnixNano(),
+		order.StrategyID,
+		string(order.ExitReason),
+		string(riskMetrics),
+	)
+	return err
+}
+
+func (s *SQLiteStore) ListTradesBySymbol(ctx context.Context, symbol string) ([]*models.Trade, error) {
+	return s.queryTrades(ctx, `WHERE symbol = ?`, symbol)
+}
+
+func (s *SQLiteStore) ListTradesByStrategy(ctx context.Context, strategyID string) ([]*models.Trade, error) {
+	return s.queryTrades(ctx, `WHERE strategy_id = ?`, strategyID)
+}
+
+func (s *SQLiteStore) ListTradesBetween(ctx context.Context, start, end time.Time) ([]*models.Trade, error) {
+	return s.queryTrades(ctx, `WHERE timestamp >= ? AND timestamp < ?`, start.UnixNano(), end.UnixNano())
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) queryTrades(ctx context.Context, where string, args ...interface{}) ([]*models.Trade, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+tradeColumns+` FROM trades `+where+` ORDER BY timestamp, rowid`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []*models.Trade
+	for rows.Next() {
+		trade, err := scanTrade(rows)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, trade)
+	}
+	return trades, rows.Err()
+}
+
+func scanTrade(rows *sql.Rows) (*models.Trade, error) {
+	var (
+		trade                                                    models.Trade
+		side, exitReason, riskMetrics                            string
+		quantity, price, requestedPrice, commission, realizedPnL string
+		timestamp                                                int64
+	)
+
+	if err := rows.Scan(&trade.ID, &trade.OrderID, &trade.Symbol, &side, &quantity, &price, &requestedPrice, &commission, &realizedPnL, &timestamp, &trade.StrategyID, &exitReason, &riskMetrics); err != nil {
+		return nil, err
+	}
+
+	decimals := []struct {
+		text  string
+		value *decimal.Decimal
+	}{
+		{quantity, &trade.Quantity},
+		{price, &trade.Price},
+		{requestedPrice, &trade.RequestedPrice},
+		{commission, &trade.Commission},
+		{realizedPnL, &trade.RealizedPnL},
+	}
+	for _, d := range decimals {
+		value, err := decimal.NewFromString(d.text)
+		if err != nil {
+			return nil, err
+		}
+		*d.value = value
+	}
+
+	if err := json.Unmarshal([]byte(riskMetrics), &trade.RiskMetrics); err != nil {
+		return nil, err
+	}
+
+	trade.Side = models.OrderSide(side)
+	trade.ExitReason = models.ExitReason(exitReason)
+	trade.Timestamp = time.Unix(0, timestamp).UTC()
+	return &trade, nil
+}
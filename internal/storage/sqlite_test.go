@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var storeStart = time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func createTestTrade(id, symbol, strategyID string, price string, timestamp time.Time) *models.Trade {
+	return &models.Trade{
+		ID:             id,
+		OrderID:        "order_" + id,
+		Symbol:         symbol,
+		Side:           models.OrderSideBuy,
+		Quantity:       decimal.NewFromInt(10),
+		Price:          decimal.RequireFromString(price),
+		RequestedPrice: decimal.RequireFromString(price),
+		Commission:     decimal.RequireFromString("0.15025"),
+		Timestamp:      timestamp,
+		StrategyID:     strategyID,
+		RiskMetrics:    models.RiskMetrics{VaR95: decimal.RequireFromString("12.5"), Beta: decimal.RequireFromString("1.1")},
+	}
+}
+
+func TestSQLiteStore_SaveTrade_RoundTrip(t *testing.T) {
+	store := createTestStore(t)
+	ctx := context.Background()
+	trade := createTestTrade("t1", "AAPL", "ma", "150.123456789012345678", storeStart)
+	trade.ExitReason = models.ExitReasonStopLoss
+	trade.RealizedPnL = decimal.RequireFromString("-0.000000001")
+
+	require.NoError(t, store.SaveTrade(ctx, trade))
+
+	var price string
+	require.NoError(t, store.db.QueryRow(`SELECT price FROM trades WHERE id = ?`, "t1").Scan(&price))
+	assert.Equal(t, "150.123456789012345678", price)
+
+	trades, err := store.ListTradesBySymbol(ctx, "AAPL")
+	require.NoError(t, err)
+	require.Len(t, trades, 1)
+	loaded := trades[0]
+	assert.Equal(t, trade.Price.String(), loaded.Price.String())
+	assert.Equal(t, trade.Commission.String(), loaded.Commission.String())
+	assert.Equal(t, trade.RealizedPnL.String(), loaded.RealizedPnL.String())
+	assert.True(t, trade.Timestamp.Equal(loaded.Timestamp))
+	assert.Equal(t, models.ExitReasonStopLoss, loaded.ExitReason)
+	assert.True(t, trade.RiskMetrics.VaR95.Equal(loaded.RiskMetrics.VaR95))
+	assert.Error(t, store.SaveTrade(ctx, trade))
+}
+
+func TestSQLiteStore_ListTrades(t *testing.T) {
+	store := createTestStore(t)
+	ctx := context.Background()
+	for _, trade := range []*models.Trade{
+		createTestTrade("t1", "AAPL", "ma", "150", storeStart),
+		createTestTrade("t2", "MSFT", "ma", "300", storeStart.Add(time.Minute)),
+		createTestTrade("t3", "AAPL", "rsi", "151", storeStart.Add(2*time.Minute)),
+		createTestTrade("t4", "AAPL", "ma", "152", storeStart.Add(3*time.Minute)),
+	} {
+		require.NoError(t, store.SaveTrade(ctx, trade))
+	}
+
+	bySymbol, err := store.ListTradesBySymbol(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1", "t3", "t4"}, tradeIDs(bySymbol))
+
+	byStrategy, err := store.ListTradesByStrategy(ctx, "ma")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1", "t2", "t4"}, tradeIDs(byStrategy))
+
+	between, err := store.ListTradesBetween(ctx, storeStart.Add(time.Minute), storeStart.Add(3*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t2", "t3"}, tradeIDs(between))
+
+	none, err := store.ListTradesBySymbol(ctx, "TSLA")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestSQLiteStore_SaveOrder_Upserts(t *testing.T) {
+	store := createTestStore(t)
+	ctx := context.Background()
+	order := &models.Order{ID: "o1", Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: decimal.NewFromInt(10), Price: decimal.RequireFromString("150.25"), Status: models.OrderStatusPending, Timestamp: storeStart}
+
+	require.NoError(t, store.SaveOrder(ctx, order))
+	order.Status = models.OrderStatusFilled
+	require.NoError(t, store.SaveOrder(ctx, order))
+
+	var count int
+	var status, price string
+	require.NoError(t, store.db.QueryRow(`SELECT COUNT(*), MAX(status), MAX(price) FROM orders`).Scan(&count, &status, &price))
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "filled", status)
+	assert.Equal(t, "150.25", price)
+}
+
+func tradeIDs(trades []*models.Trade) []string {
+	ids := make([]string, len(trades))
+	for i, trade := range trades {
+		ids[i] = trade.ID
+	}
+	return ids
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+)
+
+type TradeSink interface {
+	SaveTrade(ctx context.Context, trade *models.Trade) error
+	SaveOrder(ctx context.Context, order *models.Order) error
+}
+
+type TradeStore interface {
+	TradeSink
+	ListTradesBySymbol(ctx context.Context, symbol string) ([]*models.Trade, error)
+	ListTradesByStrategy(ctx context.Context, strategyID string) ([]*models.Trade, error)
+	ListTradesBetween(ctx context.Context, start, end time.Time) ([]*models.Trade, error)
+	Close() error
+}
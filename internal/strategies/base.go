@@ -2,17 +2,22 @@ package strategies
 
 import (
 	"context"
-	"math"
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
 	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
 	"github.com/shopspring/decimal"
 )
 
 type Strategy interface {
 	ID() string
 	Name() string
-	Execute(ctx context.Context, portfolio *models.Portfolio, marketData map[string]*models.MarketData) (*models.AlgorithmResult, error)
+	Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error)
 	ValidateOrder(order *models.Order, portfolio *models.Portfolio) error
 	CalculateRisk(order *models.Order, portfolio *models.Portfolio) (*models.RiskMetrics, error)
 	UpdateConfig(config *models.StrategyConfig) error
@@ -20,8 +25,27 @@ type Strategy interface {
 	IsEnabled() bool
 }
 
+type MarketHistory interface {
+	GetMarketDataHistory(symbol string, n int) []*models.MarketData
+}
+
+const (
+	defaultPriceHistorySize = 100
+	defaultBetaLookback     = 60
+	tradingPeriodsPerYear   = 252
+)
+
 type BaseStrategy struct {
-	config *models.StrategyConfig
+	configMu        sync.RWMutex
+	config          *models.StrategyConfig
+	commissionModel execution.CommissionModel
+	historyMu       sync.RWMutex
+	minHistory      int
+	marketHistory   MarketHistory
+	benchmark       string
+	betaLookback    int
+	symbols         *symbols.Registry
+	sizer           PositionSizer
 }
 
 func NewBaseStrategy(config *models.StrategyConfig) *BaseStrategy {
@@ -31,58 +55,127 @@ func NewBaseStrategy(config *models.StrategyConfig) *BaseStrategy {
 }
 
 func (s *BaseStrategy) ID() string {
-	return s.config.ID
+	return s.GetConfig().ID
 }
 
 func (s *BaseStrategy) Name() string {
-	return s.config.Name
+	return s.GetConfig().Name
 }
 
 func (s *BaseStrategy) GetConfig() *models.StrategyConfig {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
 	return s.config
 }
 
 func (s *BaseStrategy) UpdateConfig(config *models.StrategyConfig) error {
+	config.UpdatedAt = time.Now()
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
 	s.config = config
-	s.config.UpdatedAt = time.Now()
 	return nil
 }
 
 func (s *BaseStrategy) IsEnabled() bool {
-	return s.config.Enabled
+	return s.GetConfig().Enabled
+}
+
+func (s *BaseStrategy) SetCommissionModel(model execution.CommissionModel) {
+	s.commissionModel = model
+}
+
+func (s *BaseStrategy) CommissionModel() execution.CommissionModel {
+	if s.commissionModel != nil {
+		return s.commissionModel
+	}
+	return execution.NewPercentageCommission(s.GetConfig().CommissionRate)
+}
+
+func (s *BaseStrategy) SetMarketHistory(history MarketHistory) {
+	s.historyMu.Lock()
+	defer s.historyMu.Unlock()
+	s.marketHistory = history
+}
+
+func (s *BaseStrategy) SetBenchmark(symbol string, lookback int) {
+	s.historyMu.Lock()
+	defer s.historyMu.Unlock()
+	s.benchmark = symbol
+	s.betaLookback = lookback
+}
+
+func (s *BaseStrategy) SetSymbols(registry *symbols.Registry) {
+	s.historyMu.Lock()
+	defer s.historyMu.Unlock()
+	s.symbols = registry
+}
+
+func (s *BaseStrategy) symbolRegistry() *symbols.Registry {
+	s.historyMu.RLock()
+	defer s.historyMu.RUnlock()
+	return s.symbols
 }
 
 func (s *BaseStrategy) ValidateOrder(order *models.Order, portfolio *models.Portfolio) error {
-	if order.Quantity <= 0 {
+	if !order.Quantity.IsPositive() {
 		return ErrInvalidQuantity
 	}
+	if err := s.symbolRegistry().Validate(order.Symbol, order.Quantity); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidQuantity, err)
+	}
+
+	config := s.GetConfig()
 
-	orderValue := order.Price.Mul(decimal.NewFromInt(order.Quantity))
+	orderValue := order.Price.Mul(order.Quantity)
 
-	if orderValue.LessThan(s.config.MinOrderSize) {
+	if orderValue.LessThan(config.MinOrderSize) {
 		return ErrOrderTooSmall
 	}
 
-	if orderValue.GreaterThan(s.config.MaxOrderSize) {
+	if orderValue.GreaterThan(config.MaxOrderSize) {
 		return ErrOrderTooLarge
 	}
 
-	if order.Side == models.OrderSideBuy {
-		if portfolio.Cash.LessThan(orderValue) {
+	held := decimal.Zero
+	if position, exists := portfolio.Positions[order.Symbol]; exists {
+		held = position.Quantity
+	}
+
+	switch {
+	case order.Side == models.OrderSideBuy:
+		if portfolio.Margin != nil && reducesPosition(order, portfolio) {
+			break
+		}
+		worstCaseOrder := *order
+		worstCaseOrder.Price = execution.FillPrice(order.Side, order.Price, config.SlippageTolerance)
+		worstCaseValue := worstCaseOrder.Price.Mul(order.Quantity)
+		commission := s.CommissionModel().Calculate(&worstCaseOrder)
+		if availableFunds(portfolio).LessThan(worstCaseValue.Add(commission)) {
 			return ErrInsufficientFunds
 		}
-	} else {
-		position, exists := portfolio.Positions[order.Symbol]
-		if !exists || position.Quantity < order.Quantity {
+	case !config.AllowShort:
+		if held.LessThan(order.Quantity) {
 			return ErrInsufficientPosition
 		}
+	case portfolio.Margin != nil:
+		opening := order.Quantity.Sub(decimal.Max(held, decimal.Zero))
+		if opening.IsPositive() && portfolio.Margin.BuyingPower.LessThan(order.Price.Mul(opening)) {
+			return ErrInsufficientFunds
+		}
 	}
 
 	return nil
 }
 
+func availableFunds(portfolio *models.Portfolio) decimal.Decimal {
+	if portfolio.Margin != nil {
+		return portfolio.Margin.BuyingPower
+	}
+	return portfolio.Cash
+}
+
 func (s *BaseStrategy) CalculateRisk(order *models.Order, portfolio *models.Portfolio) (*models.RiskMetrics, error) {
-	orderValue := order.Price.Mul(decimal.NewFromInt(order.Quantity))
+	orderValue := order.Price.Mul(order.Quantity)
 	portfolioValue := portfolio.TotalValue
 
 	if portfolioValue.IsZero() {
@@ -90,111 +183,192 @@ func (s *BaseStrategy) CalculateRisk(order *models.Order, portfolio *models.Port
 	}
 
 	positionRisk := orderValue.Div(portfolioValue)
+	config := s.GetConfig()
 
-	if positionRisk.GreaterThan(s.config.MaxPositionSize) {
-		return nil, ErrPositionTooLarge
-	}
+	if !reducesPosition(order, portfolio) {
+		if positionRisk.GreaterThan(config.MaxPositionSize) {
+			return nil, ErrPositionTooLarge
+		}
 
-	totalRisk := portfolio.TotalRisk.Add(positionRisk)
-	if totalRisk.GreaterThan(s.config.MaxPortfolioRisk) {
-		return nil, ErrPortfolioRiskExceeded
+		totalRisk := portfolio.TotalRisk.Add(positionRisk)
+		if totalRisk.GreaterThan(config.MaxPortfolioRisk) {
+			return nil, ErrPortfolioRiskExceeded
+		}
 	}
 
-	volatility := s.calculateVolatility(order.Symbol, portfolio)
+	returns := s.returnSeries(order.Symbol, portfolio)
+	volatility := s.calculateVolatility(returns)
 	beta := s.calculateBeta(order.Symbol, portfolio)
-	var95 := s.calculateVaR(orderValue, volatility)
+	var95 := s.calculateVaR(order, orderValue, returns)
 	expectedShortfall := s.calculateExpectedShortfall(var95, volatility)
-	sharpeRatio := s.calculateSharpeRatio(orderValue, volatility)
-	maxDrawdown := s.calculateMaxDrawdown(portfolio)
+	sharpeRatio := s.calculateSharpeRatio(returns)
 
 	return &models.RiskMetrics{
 		VaR95:             var95,
 		ExpectedShortfall: expectedShortfall,
 		SharpeRatio:       sharpeRatio,
-		MaxDrawdown:       maxDrawdown,
+		MaxDrawdown:       portfolio.MaxDrawdown,
 		Volatility:        volatility,
 		Beta:              beta,
 	}, nil
 }
 
-func (s *BaseStrategy) calculateVolatility(symbol string, portfolio *models.Portfolio) decimal.Decimal {
-	if len(portfolio.TradeHistory) < 2 {
-		return decimal.Zero
+func reducesPosition(order *models.Order, portfolio *models.Portfolio) bool {
+	position, exists := portfolio.Positions[order.Symbol]
+	if !exists {
+		return false
 	}
+	if order.Side == models.OrderSideSell {
+		return position.Quantity.IsPositive() && order.Quantity.LessThanOrEqual(position.Quantity)
+	}
+	return position.Quantity.IsNegative() && order.Quantity.LessThanOrEqual(position.Quantity.Neg())
+}
 
-	var returns []decimal.Decimal
-	for i := 1; i < len(portfolio.TradeHistory); i++ {
-		if portfolio.TradeHistory[i].Symbol == symbol {
-			prevPrice := portfolio.TradeHistory[i-1].Price
-			currPrice := portfolio.TradeHistory[i].Price
-			if !prevPrice.IsZero() {
-				returns = append(returns, currPrice.Sub(prevPrice).Div(prevPrice))
-			}
-		}
+func (s *BaseStrategy) calculateOptimalQuantity(symbol string, price decimal.Decimal, portfolio *models.Portfolio) decimal.Decimal {
+	if !price.IsPositive() {
+		return decimal.Zero
 	}
 
-	if len(returns) == 0 {
+	availableCash := portfolio.Cash.Mul(decimal.NewFromFloat(cashSizerFraction))
+	maxQuantity := availableCash.Div(price)
+
+	if !maxQuantity.IsPositive() {
 		return decimal.Zero
 	}
 
-	mean := decimal.Zero
-	for _, ret := range returns {
-		mean = mean.Add(ret)
+	signal := &models.AlgorithmResult{StrategyID: s.ID(), Symbol: symbol, Price: price}
+	maxQuantity = decimal.Min(maxQuantity, s.positionSizer().Size(signal, portfolio, s.strategyTrades(portfolio)))
+
+	config := s.GetConfig()
+	maxQuantity = decimal.Min(maxQuantity, config.MaxOrderSize.Div(price))
+
+	return decimal.Max(s.symbolRegistry().RoundDown(symbol, maxQuantity), decimal.Zero)
+}
+
+func (s *BaseStrategy) calculatePositionRisk(symbol string, action models.Action, quantity decimal.Decimal, price decimal.Decimal, portfolio *models.Portfolio) (*models.RiskMetrics, error) {
+	side := models.OrderSideBuy
+	if action == models.ActionSell {
+		side = models.OrderSideSell
+	}
+
+	order := &models.Order{
+		Symbol:   symbol,
+		Side:     side,
+		Quantity: quantity,
+		Price:    price,
 	}
-	mean = mean.Div(decimal.NewFromInt(int64(len(returns))))
 
-	variance := decimal.Zero
-	for _, ret := range returns {
-		diff := ret.Sub(mean)
-		variance = variance.Add(diff.Mul(diff))
+	return s.CalculateRisk(order, portfolio)
+}
+
+func (s *BaseStrategy) calculateRiskScore(riskMetrics *models.RiskMetrics) decimal.Decimal {
+	if riskMetrics == nil {
+		return decimal.Zero
 	}
-	variance = variance.Div(decimal.NewFromInt(int64(len(returns))))
 
-	volatility := decimal.NewFromFloat(0.0)
-	if variance.GreaterThan(decimal.Zero) {
-		volatility = decimal.NewFromFloat(math.Sqrt(variance.InexactFloat64()))
+	volatilityScore := decimal.NewFromFloat(1.0).Sub(riskMetrics.Volatility)
+	varScore := decimal.NewFromFloat(1.0).Sub(riskMetrics.VaR95.Div(decimal.NewFromFloat(100)))
+	sharpeScore := riskMetrics.SharpeRatio.Div(decimal.NewFromFloat(2.0))
+
+	if sharpeScore.GreaterThan(decimal.NewFromFloat(1.0)) {
+		sharpeScore = decimal.NewFromFloat(1.0)
 	}
-	return volatility
+
+	return volatilityScore.Add(varScore).Add(sharpeScore).Div(decimal.NewFromFloat(3.0))
 }
 
-func (s *BaseStrategy) calculateBeta(symbol string, portfolio *models.Portfolio) decimal.Decimal {
-	return decimal.NewFromFloat(1.0)
+func (s *BaseStrategy) requireHistory(size int) {
+	s.historyMu.Lock()
+	defer s.historyMu.Unlock()
+	if size > s.minHistory {
+		s.minHistory = size
+	}
 }
 
-func (s *BaseStrategy) calculateVaR(orderValue, volatility decimal.Decimal) decimal.Decimal {
-	zScore := decimal.NewFromFloat(1.645)
-	return orderValue.Mul(volatility).Mul(zScore)
+func (s *BaseStrategy) RequiredHistory() int {
+	s.historyMu.RLock()
+	defer s.historyMu.RUnlock()
+
+	size := s.GetConfig().MarketDataWindow
+	if size < s.minHistory {
+		size = s.minHistory
+	}
+	if size <= 0 {
+		size = defaultPriceHistorySize
+	}
+	return size
 }
 
-func (s *BaseStrategy) calculateExpectedShortfall(var95, volatility decimal.Decimal) decimal.Decimal {
-	return var95.Mul(decimal.NewFromFloat(1.25))
+func (s *BaseStrategy) returnSeries(symbol string, portfolio *models.Portfolio) []float64 {
+	s.historyMu.RLock()
+	history := s.marketHistory
+	s.historyMu.RUnlock()
+
+	if history != nil {
+		if returns := risk.Returns(risk.Prices(history.GetMarketDataHistory(symbol, s.RequiredHistory()))); len(returns) > 0 {
+			return returns
+		}
+	}
+
+	var prices []decimal.Decimal
+	for _, trade := range portfolio.TradeHistory {
+		if trade.Symbol == symbol {
+			prices = append(prices, trade.Price)
+		}
+	}
+	return risk.Returns(prices)
 }
 
-func (s *BaseStrategy) calculateSharpeRatio(orderValue, volatility decimal.Decimal) decimal.Decimal {
-	if volatility.IsZero() {
-		return decimal.Zero
+func (s *BaseStrategy) calculateVolatility(returns []float64) decimal.Decimal {
+	values := make([]decimal.Decimal, len(returns))
+	for i, value := range returns {
+		values[i] = decimal.NewFromFloat(value)
 	}
-	excessReturn := orderValue.Sub(s.config.RiskFreeRate)
-	return excessReturn.Div(volatility)
+	volatility, _ := indicators.StdDev(values, len(values))
+	return volatility
 }
 
-func (s *BaseStrategy) calculateMaxDrawdown(portfolio *models.Portfolio) decimal.Decimal {
-	if len(portfolio.TradeHistory) == 0 {
-		return decimal.Zero
+func (s *BaseStrategy) calculateBeta(symbol string, portfolio *models.Portfolio) decimal.Decimal {
+	s.historyMu.RLock()
+	history, benchmark, lookback := s.marketHistory, s.benchmark, s.betaLookback
+	s.historyMu.RUnlock()
+
+	if history == nil || benchmark == "" || symbol == benchmark {
+		return decimal.NewFromFloat(1.0)
+	}
+	if lookback <= 0 {
+		lookback = defaultBetaLookback
 	}
 
-	peak := portfolio.TradeHistory[0].Price
-	maxDrawdown := decimal.Zero
+	beta, ok := risk.HistoryBeta(history.GetMarketDataHistory(symbol, lookback+1), history.GetMarketDataHistory(benchmark, lookback+1))
+	if !ok {
+		return decimal.NewFromFloat(1.0)
+	}
+	return decimal.NewFromFloat(beta)
+}
 
-	for _, trade := range portfolio.TradeHistory {
-		if trade.Price.GreaterThan(peak) {
-			peak = trade.Price
-		}
-		drawdown := peak.Sub(trade.Price).Div(peak)
-		if drawdown.GreaterThan(maxDrawdown) {
-			maxDrawdown = drawdown
-		}
+func (s *BaseStrategy) calculateVaR(order *models.Order, orderValue decimal.Decimal, returns []float64) decimal.Decimal {
+	config := s.GetConfig()
+	model, err := risk.NewVaRModel(config.VaRMethod, config.VaRHoldingPeriod)
+	if err != nil {
+		model = risk.ParametricModel{HoldingPeriod: config.VaRHoldingPeriod}
+	}
+
+	exposure := orderValue.InexactFloat64()
+	if order.Side == models.OrderSideSell {
+		exposure = -exposure
 	}
+	return decimal.NewFromFloat(model.VaR(exposure, returns))
+}
+
+func (s *BaseStrategy) calculateExpectedShortfall(var95, volatility decimal.Decimal) decimal.Decimal {
+	return var95.Mul(decimal.NewFromFloat(1.25))
+}
 
-	return maxDrawdown
+func (s *BaseStrategy) calculateSharpeRatio(returns []float64) decimal.Decimal {
+	sharpe, ok := risk.SharpeRatio(returns, s.GetConfig().RiskFreeRate.InexactFloat64(), tradingPeriodsPerYear)
+	if !ok {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat(sharpe)
 }
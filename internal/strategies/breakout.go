@@ -0,0 +1,175 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type BreakoutStrategy struct {
+	*BaseStrategy
+	entryPeriod    int
+	exitPeriod     int
+	atrPeriod      int
+	stopMultiplier decimal.Decimal
+}
+
+func NewBreakoutStrategy(config *models.StrategyConfig) *BreakoutStrategy {
+	strategy := &BreakoutStrategy{
+		BaseStrategy:   NewBaseStrategy(config),
+		entryPeriod:    20,
+		exitPeriod:     10,
+		atrPeriod:      20,
+		stopMultiplier: decimal.NewFromInt(2),
+	}
+	strategy.requireHistory(strategy.warmup())
+	return strategy
+}
+
+func (s *BreakoutStrategy) SetPeriods(entry, exit int) {
+	s.entryPeriod = entry
+	s.exitPeriod = exit
+	s.requireHistory(s.warmup())
+}
+
+func (s *BreakoutStrategy) SetATRStop(period int, multiplier decimal.Decimal) {
+	s.atrPeriod = period
+	s.stopMultiplier = multiplier
+	s.requireHistory(s.warmup())
+}
+
+func (s *BreakoutStrategy) Parameters() []string {
+	return []string{"entry_period", "exit_period", "atr_period", "stop_multiplier"}
+}
+
+func (s *BreakoutStrategy) SetParameters(params map[string]decimal.Decimal) error {
+	if err := checkParameters(params, s.Parameters()...); err != nil {
+		return err
+	}
+	entry, err := intParameter(params, "entry_period", s.entryPeriod)
+	if err != nil {
+		return err
+	}
+	exit, err := intParameter(params, "exit_period", s.exitPeriod)
+	if err != nil {
+		return err
+	}
+	atr, err := intParameter(params, "atr_period", s.atrPeriod)
+	if err != nil {
+		return err
+	}
+	multiplier := decimalParameter(params, "stop_multiplier", s.stopMultiplier)
+	if !multiplier.IsPositive() {
+		return fmt.Errorf("%w: stop_multiplier must be positive", ErrInvalidConfig)
+	}
+	s.SetPeriods(entry, exit)
+	s.SetATRStop(atr, multiplier)
+	return nil
+}
+
+func (s *BreakoutStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	var bestSignal *models.AlgorithmResult
+	for symbol := range market.Latest {
+		signal := s.analyzeSymbol(symbol, market.History[symbol], portfolio)
+		if signal == nil {
+			continue
+		}
+		if bestSignal == nil || signal.Confidence.GreaterThan(bestSignal.Confidence) ||
+			(signal.Confidence.Equal(bestSignal.Confidence) && signal.Symbol < bestSignal.Symbol) {
+			bestSignal = signal
+		}
+	}
+	return bestSignal, nil
+}
+
+func (s *BreakoutStrategy) ExecuteOnTick(ctx context.Context, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+	return s.analyzeSymbol(symbol, market.History[symbol], portfolio), nil
+}
+
+func (s *BreakoutStrategy) analyzeSymbol(symbol string, bars []*models.MarketData, portfolio *models.Portfolio) *models.AlgorithmResult {
+	if len(bars) < s.warmup() {
+		return nil
+	}
+
+	bar, previous := bars[len(bars)-1], bars[:len(bars)-1]
+	entryHigh, entryLow, ok := indicators.Donchian(previous, s.entryPeriod)
+	if !ok {
+		return nil
+	}
+	exitHigh, exitLow, ok := indicators.Donchian(previous, s.exitPeriod)
+	if !ok {
+		return nil
+	}
+	atr, ok := indicators.ATR(bars, s.atrPeriod)
+	if !ok || !atr.IsPositive() {
+		return nil
+	}
+
+	held := decimal.Zero
+	if position, exists := portfolio.Positions[symbol]; exists {
+		held = position.Quantity
+	}
+
+	price := indicators.Close(bar)
+	stopDistance := atr.Mul(s.stopMultiplier)
+	config := s.GetConfig()
+
+	newHigh := price.GreaterThan(entryHigh)
+	newLow := indicators.Low(bar).LessThan(exitLow)
+	switch {
+	case newLow && held.IsPositive():
+		return s.buildResult(symbol, bar.Timestamp, models.ActionSell, "breakout_exit", held, price, decimal.Zero, decimal.NewFromInt(1))
+	case indicators.High(bar).GreaterThan(exitHigh) && held.IsNegative():
+		return s.buildResult(symbol, bar.Timestamp, models.ActionBuy, "breakout_cover", held.Neg(), price, decimal.Zero, decimal.NewFromInt(1))
+	case newLow && held.IsZero() && config.AllowShort && price.LessThan(entryLow):
+		quantity := s.calculateOptimalQuantity(symbol, price, portfolio)
+		if !quantity.IsPositive() {
+			return nil
+		}
+		return s.buildResult(symbol, bar.Timestamp, models.ActionSell, "breakout_short", quantity, price, price.Add(stopDistance), breakoutConfidence(entryLow.Sub(price), atr))
+	case newHigh && !newLow && held.IsZero():
+		quantity := s.calculateOptimalQuantity(symbol, price, portfolio)
+		if !quantity.IsPositive() {
+			return nil
+		}
+		return s.buildResult(symbol, bar.Timestamp, models.ActionBuy, "breakout_entry", quantity, price, price.Sub(stopDistance), breakoutConfidence(price.Sub(entryHigh), atr))
+	}
+	return nil
+}
+
+func (s *BreakoutStrategy) buildResult(symbol string, timestamp time.Time, action models.Action, signal string, quantity, price, stop, confidence decimal.Decimal) *models.AlgorithmResult {
+	if stop.IsNegative() {
+		stop = decimal.Zero
+	}
+	return &models.AlgorithmResult{
+		StrategyID: s.ID(),
+		Symbol:     symbol,
+		Action:     action,
+		Quantity:   quantity,
+		Price:      price,
+		StopPrice:  stop,
+		Confidence: confidence,
+		Signal:     signal,
+		Timestamp:  timestamp,
+	}
+}
+
+func (s *BreakoutStrategy) warmup() int {
+	return max(s.entryPeriod, s.exitPeriod, s.atrPeriod) + 1
+}
+
+func breakoutConfidence(excess, atr decimal.Decimal) decimal.Decimal {
+	confidence := decimal.NewFromFloat(0.5).Add(excess.Div(atr).Div(decimal.NewFromInt(2)))
+	return decimal.Min(confidence, decimal.NewFromInt(1))
+}
@@ -0,0 +1,133 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestBreakoutConfig() *models.StrategyConfig {
+	return &models.StrategyConfig{
+		ID:               "test_breakout",
+		Name:             "Test Breakout",
+		Enabled:          true,
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+		MinOrderSize:     decimal.NewFromFloat(100.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+	}
+}
+
+func createBreakoutMarket(flatBars int, last *models.MarketData) *models.MarketSnapshot {
+	market := &models.MarketSnapshot{
+		Latest:  map[string]*models.MarketData{},
+		History: map[string][]*models.MarketData{},
+	}
+	bars := make([]*models.MarketData, 0, flatBars+1)
+	for i := 0; i < flatBars; i++ {
+		bars = append(bars, breakoutBar(101, 99, 100))
+	}
+	if last != nil {
+		bars = append(bars, last)
+	}
+	market.History["AAPL"] = bars
+	market.Latest["AAPL"] = bars[len(bars)-1]
+	return market
+}
+
+func breakoutBar(high, low, close float64) *models.MarketData {
+	return &models.MarketData{
+		Symbol: "AAPL",
+		Price:  decimal.NewFromFloat(close),
+		High:   decimal.NewFromFloat(high),
+		Low:    decimal.NewFromFloat(low),
+		Close:  decimal.NewFromFloat(close),
+	}
+}
+
+func TestNewBreakoutStrategy(t *testing.T) {
+	strategy := NewBreakoutStrategy(createTestBreakoutConfig())
+
+	assert.Equal(t, 21, strategy.RequiredHistory())
+	strategy.SetPeriods(55, 20)
+	assert.Equal(t, 56, strategy.RequiredHistory())
+}
+
+func TestBreakoutStrategy_Execute_WaitsForWarmup(t *testing.T) {
+	strategy := NewBreakoutStrategy(createTestBreakoutConfig())
+
+	result, err := strategy.Execute(context.Background(), createTestPortfolio(), createBreakoutMarket(19, breakoutBar(106, 100, 105)))
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestBreakoutStrategy_Execute_EntersAboveChannelWithATRStop(t *testing.T) {
+	strategy := NewBreakoutStrategy(createTestBreakoutConfig())
+
+	result, err := strategy.Execute(context.Background(), createTestPortfolio(), createBreakoutMarket(20, breakoutBar(106, 100, 105)))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	assert.Equal(t, models.ActionBuy, result.Action)
+	assert.Equal(t, "breakout_entry", result.Signal)
+	assert.True(t, result.Quantity.IsPositive())
+	assert.True(t, result.Price.Equal(decimal.NewFromInt(105)))
+	assert.True(t, result.StopPrice.Equal(decimal.NewFromFloat(100.6)), result.StopPrice.String())
+}
+
+func TestBreakoutStrategy_Execute_NoEntryInsideChannel(t *testing.T) {
+	strategy := NewBreakoutStrategy(createTestBreakoutConfig())
+
+	result, err := strategy.Execute(context.Background(), createTestPortfolio(), createBreakoutMarket(20, breakoutBar(101, 99.5, 100.5)))
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestBreakoutStrategy_Execute_ExitsOnNewLow(t *testing.T) {
+	strategy := NewBreakoutStrategy(createTestBreakoutConfig())
+	portfolio := createTestPortfolio()
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(50)}
+
+	result, err := strategy.Execute(context.Background(), portfolio, createBreakoutMarket(20, breakoutBar(100, 98.5, 98.5)))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	assert.Equal(t, models.ActionSell, result.Action)
+	assert.Equal(t, "breakout_exit", result.Signal)
+	assert.True(t, decimal.NewFromInt(50).Equal(result.Quantity))
+	assert.True(t, result.StopPrice.IsZero())
+}
+
+func TestBreakoutStrategy_Execute_OutsideBarPrefersExit(t *testing.T) {
+	strategy := NewBreakoutStrategy(createTestBreakoutConfig())
+	outside := breakoutBar(106, 98, 105)
+
+	result, err := strategy.Execute(context.Background(), createTestPortfolio(), createBreakoutMarket(20, outside))
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	portfolio := createTestPortfolio()
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(50)}
+	result, err = strategy.Execute(context.Background(), portfolio, createBreakoutMarket(20, outside))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, "breakout_exit", result.Signal)
+}
+
+func TestBreakoutStrategy_Execute_ShortsBelowChannelWhenAllowed(t *testing.T) {
+	config := createTestBreakoutConfig()
+	config.AllowShort = true
+	strategy := NewBreakoutStrategy(config)
+
+	result, err := strategy.Execute(context.Background(), createTestPortfolio(), createBreakoutMarket(20, breakoutBar(100, 94, 95)))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	assert.Equal(t, models.ActionSell, result.Action)
+	assert.Equal(t, "breakout_short", result.Signal)
+	assert.True(t, result.StopPrice.GreaterThan(result.Price))
+}
@@ -16,4 +16,6 @@ var (
 	ErrInvalidConfig          = errors.New("invalid configuration")
 	ErrMaxDrawdownExceeded    = errors.New("maximum drawdown exceeded")
 	ErrMaxOrdersPerDayReached = errors.New("maximum orders per day reached")
+	ErrUnknownStrategyType    = errors.New("unknown strategy type")
+	ErrUnknownPositionSizer   = errors.New("unknown position sizer")
 )
@@ -0,0 +1,47 @@
+package strategies
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+)
+
+const (
+	TypeMovingAverage = "moving_average"
+	TypeRSI           = "rsi"
+	TypeMACD          = "macd"
+	TypeMomentum      = "momentum"
+	TypeGrid          = "grid"
+	TypeBreakout      = "breakout"
+	TypeSentiment     = "sentiment"
+	TypeTrendFiltered = "trend_filtered_ma"
+)
+
+var constructors = map[string]func(config *models.StrategyConfig) Strategy{
+	TypeMovingAverage: func(config *models.StrategyConfig) Strategy { return NewMovingAverageStrategy(config) },
+	TypeRSI:           func(config *models.StrategyConfig) Strategy { return NewRSIStrategy(config) },
+	TypeMACD:          func(config *models.StrategyConfig) Strategy { return NewMACDStrategy(config) },
+	TypeMomentum:      func(config *models.StrategyConfig) Strategy { return NewMomentumStrategy(config) },
+	TypeGrid:          func(config *models.StrategyConfig) Strategy { return NewGridStrategy(config) },
+	TypeBreakout:      func(config *models.StrategyConfig) Strategy { return NewBreakoutStrategy(config) },
+	TypeSentiment:     func(config *models.StrategyConfig) Strategy { return NewSentimentStrategy(config) },
+	TypeTrendFiltered: func(config *models.StrategyConfig) Strategy { return NewTrendFilteredMAStrategy(config) },
+}
+
+func New(strategyType string, config *models.StrategyConfig) (Strategy, error) {
+	constructor, exists := constructors[strategyType]
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategyType, strategyType)
+	}
+	return constructor(config), nil
+}
+
+func Types() []string {
+	types := make([]string, 0, len(constructors))
+	for strategyType := range constructors {
+		types = append(types, strategyType)
+	}
+	sort.Strings(types)
+	return types
+}
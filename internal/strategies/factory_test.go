@@ -0,0 +1,30 @@
+package strategies
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	config := &models.StrategyConfig{ID: "s1"}
+
+	for strategyType, expected := range map[string]interface{}{
+		TypeMovingAverage: &MovingAverageStrategy{},
+		TypeRSI:           &RSIStrategy{},
+		TypeMACD:          &MACDStrategy{},
+		TypeMomentum:      &MomentumStrategy{},
+		TypeSentiment:     &SentimentStrategy{},
+	} {
+		strategy, err := New(strategyType, config)
+		require.NoError(t, err)
+		assert.IsType(t, expected, strategy)
+		assert.Equal(t, "s1", strategy.ID())
+	}
+
+	_, err := New("martingale", config)
+	assert.ErrorIs(t, err, ErrUnknownStrategyType)
+	assert.Equal(t, []string{TypeBreakout, TypeGrid, TypeMACD, TypeMomentum, TypeMovingAverage, TypeRSI, TypeSentiment, TypeTrendFiltered}, Types())
+}
@@ -0,0 +1,255 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const defaultGridLookback = 50
+
+type OrderBook interface {
+	GetOpenOrders() []*models.Order
+}
+
+type GridStrategy struct {
+	*BaseStrategy
+	mu          sync.Mutex
+	orderBook   OrderBook
+	levels      []decimal.Decimal
+	holding     []bool
+	lastOrderID string
+}
+
+type gridOrderKey struct {
+	side  models.OrderSide
+	price string
+}
+
+func NewGridStrategy(config *models.StrategyConfig) *GridStrategy {
+	strategy := &GridStrategy{BaseStrategy: NewBaseStrategy(config)}
+	if grid := config.Grid; grid != nil && (grid.Lower.IsZero() || grid.Upper.IsZero()) {
+		strategy.requireHistory(gridLookback(grid))
+	}
+	return strategy
+}
+
+func (s *GridStrategy) SetOrderBook(book OrderBook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orderBook = book
+}
+
+func (s *GridStrategy) Levels() []decimal.Decimal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	levels := make([]decimal.Decimal, len(s.levels))
+	copy(levels, s.levels)
+	return levels
+}
+
+func (s *GridStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	results, err := s.ExecuteAll(ctx, portfolio, market)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (s *GridStrategy) ExecuteAll(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) ([]*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	config := s.GetConfig()
+	grid := config.Grid
+	if grid == nil || grid.Symbol == "" || !grid.Step.IsPositive() || !grid.LevelQuantity.IsPositive() {
+		return nil, fmt.Errorf("%w: grid requires a symbol, a positive step and a positive level quantity", ErrInvalidConfig)
+	}
+
+	data, exists := market.Latest[grid.Symbol]
+	if !exists || !data.Price.IsPositive() {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.buildLevels(grid, market.History[grid.Symbol]) {
+		return nil, nil
+	}
+	s.applyFills(grid, portfolio)
+	return s.placeOrders(config, data, portfolio), nil
+}
+
+func (s *GridStrategy) buildLevels(grid *models.GridConfig, history []*models.MarketData) bool {
+	if len(s.levels) > 0 {
+		return true
+	}
+
+	lower, upper := grid.Lower, grid.Upper
+	if lower.IsZero() || upper.IsZero() {
+		lookback := gridLookback(grid)
+		if len(history) < lookback {
+			return false
+		}
+		low, high := history[len(history)-lookback].Price, history[len(history)-lookback].Price
+		for _, data := range history[len(history)-lookback:] {
+			low = decimal.Min(low, data.Price)
+			high = decimal.Max(high, data.Price)
+		}
+		if lower.IsZero() {
+			lower = low
+		}
+		if upper.IsZero() {
+			upper = high
+		}
+	}
+
+	var levels []decimal.Decimal
+	for level := lower; level.LessThanOrEqual(upper); level = level.Add(grid.Step) {
+		levels = append(levels, level)
+	}
+	if len(levels) < 2 {
+		return false
+	}
+
+	s.levels = levels
+	s.holding = make([]bool, len(levels))
+	return true
+}
+
+func (s *GridStrategy) applyFills(grid *models.GridConfig, portfolio *models.Portfolio) {
+	history := portfolio.OrderHistory
+	start := 0
+	for i := len(history) - 1; i >= 0 && s.lastOrderID != ""; i-- {
+		if history[i].ID == s.lastOrderID {
+			start = i + 1
+			break
+		}
+	}
+
+	for _, order := range history[start:] {
+		if order.StrategyID != s.ID() || order.Symbol != grid.Symbol || order.Status != models.OrderStatusFilled {
+			continue
+		}
+		s.lastOrderID = order.ID
+		level := s.levelIndex(order.Price)
+		switch {
+		case level < 0 || order.Type != models.OrderTypeLimit:
+		case order.Side == models.OrderSideBuy:
+			s.holding[level] = true
+		case level > 0:
+			s.holding[level-1] = false
+		}
+	}
+
+	units := int64(0)
+	if position, exists := portfolio.Positions[grid.Symbol]; exists && position.Quantity.IsPositive() {
+		units = position.Quantity.Div(grid.LevelQuantity).IntPart()
+	}
+	held := int64(0)
+	for _, holding := range s.holding {
+		if holding {
+			held++
+		}
+	}
+	for i := len(s.holding) - 1; i >= 0 && held > units; i-- {
+		if s.holding[i] {
+			s.holding[i] = false
+			held--
+		}
+	}
+}
+
+func (s *GridStrategy) placeOrders(config *models.StrategyConfig, data *models.MarketData, portfolio *models.Portfolio) []*models.AlgorithmResult {
+	grid := config.Grid
+	price := data.Price
+	quantity := grid.LevelQuantity
+
+	open := make(map[gridOrderKey]bool)
+	committed := decimal.Zero
+	if s.orderBook != nil {
+		for _, order := range s.orderBook.GetOpenOrders() {
+			if order.StrategyID != s.ID() || order.Symbol != grid.Symbol {
+				continue
+			}
+			open[gridOrderKey{order.Side, order.Price.String()}] = true
+			if order.Side == models.OrderSideBuy {
+				committed = committed.Add(order.Price.Mul(order.Quantity))
+			}
+		}
+	}
+
+	exposure := committed
+	if position, exists := portfolio.Positions[grid.Symbol]; exists {
+		exposure = exposure.Add(price.Mul(position.Quantity).Abs())
+	}
+	maxExposure := portfolio.TotalValue.Mul(config.MaxPositionSize)
+	cash := portfolio.Cash.Sub(committed)
+	commission := decimal.NewFromInt(1).Add(config.CommissionRate)
+
+	var results []*models.AlgorithmResult
+	for i := len(s.levels) - 2; i >= 0; i-- {
+		if !s.holding[i] {
+			continue
+		}
+		target := s.levels[i+1]
+		if !open[gridOrderKey{models.OrderSideSell, target.String()}] {
+			results = append(results, s.gridResult(grid, models.ActionSell, target, data.Timestamp))
+		}
+	}
+
+	for i := len(s.levels) - 2; i >= 0; i-- {
+		level := s.levels[i]
+		if s.holding[i] || !level.LessThan(price) || open[gridOrderKey{models.OrderSideBuy, level.String()}] {
+			continue
+		}
+		cost := level.Mul(quantity)
+		if cash.LessThan(cost.Mul(commission)) {
+			break
+		}
+		if exposure.Add(cost).GreaterThan(maxExposure) {
+			break
+		}
+		cash = cash.Sub(cost.Mul(commission))
+		exposure = exposure.Add(cost)
+		results = append(results, s.gridResult(grid, models.ActionBuy, level, data.Timestamp))
+	}
+	return results
+}
+
+func (s *GridStrategy) gridResult(grid *models.GridConfig, action models.Action, level decimal.Decimal, timestamp time.Time) *models.AlgorithmResult {
+	return &models.AlgorithmResult{
+		StrategyID: s.ID(),
+		Symbol:     grid.Symbol,
+		Action:     action,
+		Quantity:   grid.LevelQuantity,
+		Price:      level,
+		OrderType:  models.OrderTypeLimit,
+		Confidence: decimal.NewFromInt(1),
+		Signal:     "grid_" + string(action),
+		Timestamp:  timestamp,
+	}
+}
+
+func (s *GridStrategy) levelIndex(price decimal.Decimal) int {
+	for i, level := range s.levels {
+		if level.Equal(price) {
+			return i
+		}
+	}
+	return -1
+}
+
+func gridLookback(grid *models.GridConfig) int {
+	if grid.Lookback > 0 {
+		return grid.Lookback
+	}
+	return defaultGridLookback
+}
@@ -0,0 +1,168 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOrderBook []*models.Order
+
+func (b testOrderBook) GetOpenOrders() []*models.Order {
+	return b
+}
+
+func createGridConfig() *models.StrategyConfig {
+	return &models.StrategyConfig{
+		ID:              "grid",
+		MaxPositionSize: decimal.NewFromFloat(0.5),
+		Enabled:         true,
+		Grid: &models.GridConfig{
+			Symbol:        "AAPL",
+			Lower:         decimal.NewFromFloat(96.0),
+			Upper:         decimal.NewFromFloat(104.0),
+			Step:          decimal.NewFromFloat(2.0),
+			LevelQuantity: decimal.NewFromInt(10),
+		},
+	}
+}
+
+func createGridMarket(price float64, history ...float64) *models.MarketSnapshot {
+	market := &models.MarketSnapshot{
+		Latest:  map[string]*models.MarketData{"AAPL": {Symbol: "AAPL", Price: decimal.NewFromFloat(price)}},
+		History: map[string][]*models.MarketData{},
+	}
+	for _, value := range history {
+		market.History["AAPL"] = append(market.History["AAPL"], &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(value)})
+	}
+	return market
+}
+
+func gridOrders(results []*models.AlgorithmResult) []string {
+	orders := make([]string, len(results))
+	for i, result := range results {
+		orders[i] = string(result.Action) + "@" + result.Price.String()
+	}
+	return orders
+}
+
+func filledGridOrder(id string, side models.OrderSide, price float64) *models.Order {
+	return &models.Order{
+		ID:         id,
+		Symbol:     "AAPL",
+		Side:       side,
+		Type:       models.OrderTypeLimit,
+		Quantity:   decimal.NewFromInt(10),
+		Price:      decimal.NewFromFloat(price),
+		Status:     models.OrderStatusFilled,
+		StrategyID: "grid",
+	}
+}
+
+func TestGridStrategy_PlacesBuysBelowPrice(t *testing.T) {
+	strategy := NewGridStrategy(createGridConfig())
+
+	results, err := strategy.ExecuteAll(context.Background(), createTestPortfolio(), createGridMarket(101.0))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"buy@100", "buy@98", "buy@96"}, gridOrders(results))
+	for _, result := range results {
+		assert.Equal(t, models.OrderTypeLimit, result.OrderType)
+		assert.True(t, decimal.NewFromInt(10).Equal(result.Quantity))
+	}
+}
+
+func TestGridStrategy_SkipsOpenOrders(t *testing.T) {
+	strategy := NewGridStrategy(createGridConfig())
+	open := filledGridOrder("open", models.OrderSideBuy, 100.0)
+	open.Status = models.OrderStatusPending
+	strategy.SetOrderBook(testOrderBook{open})
+
+	results, err := strategy.ExecuteAll(context.Background(), createTestPortfolio(), createGridMarket(101.0))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"buy@98", "buy@96"}, gridOrders(results))
+}
+
+func TestGridStrategy_RearmsAfterFills(t *testing.T) {
+	strategy := NewGridStrategy(createGridConfig())
+	portfolio := createTestPortfolio()
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(10), AveragePrice: decimal.NewFromFloat(98.0)}
+	portfolio.OrderHistory = []*models.Order{filledGridOrder("b1", models.OrderSideBuy, 98.0)}
+
+	results, err := strategy.ExecuteAll(context.Background(), portfolio, createGridMarket(99.0))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sell@100", "buy@96"}, gridOrders(results))
+
+	results, err = strategy.ExecuteAll(context.Background(), portfolio, createGridMarket(99.0))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sell@100", "buy@96"}, gridOrders(results))
+
+	delete(portfolio.Positions, "AAPL")
+	portfolio.OrderHistory = append(portfolio.OrderHistory, filledGridOrder("s1", models.OrderSideSell, 100.0))
+
+	results, err = strategy.ExecuteAll(context.Background(), portfolio, createGridMarket(101.0))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"buy@100", "buy@98", "buy@96"}, gridOrders(results))
+}
+
+func TestGridStrategy_DropsLevelsClosedOutsideTheGrid(t *testing.T) {
+	strategy := NewGridStrategy(createGridConfig())
+	portfolio := createTestPortfolio()
+	portfolio.OrderHistory = []*models.Order{filledGridOrder("b1", models.OrderSideBuy, 98.0)}
+
+	results, err := strategy.ExecuteAll(context.Background(), portfolio, createGridMarket(99.0))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"buy@98", "buy@96"}, gridOrders(results))
+}
+
+func TestGridStrategy_CapsExposureAndCash(t *testing.T) {
+	config := createGridConfig()
+	config.MaxPositionSize = decimal.NewFromFloat(0.02)
+	strategy := NewGridStrategy(config)
+
+	results, err := strategy.ExecuteAll(context.Background(), createTestPortfolio(), createGridMarket(101.0))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"buy@100", "buy@98"}, gridOrders(results))
+
+	strategy = NewGridStrategy(createGridConfig())
+	portfolio := createTestPortfolio()
+	portfolio.Cash = decimal.NewFromFloat(1500.0)
+
+	results, err = strategy.ExecuteAll(context.Background(), portfolio, createGridMarket(101.0))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"buy@100"}, gridOrders(results))
+}
+
+func TestGridStrategy_DerivesRangeFromHistory(t *testing.T) {
+	config := createGridConfig()
+	config.Grid.Lower = decimal.Zero
+	config.Grid.Upper = decimal.Zero
+	config.Grid.Lookback = 5
+	strategy := NewGridStrategy(config)
+	assert.Equal(t, 5, strategy.RequiredHistory())
+
+	results, err := strategy.ExecuteAll(context.Background(), createTestPortfolio(), createGridMarket(100.0, 97, 103))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Empty(t, strategy.Levels())
+
+	results, err = strategy.ExecuteAll(context.Background(), createTestPortfolio(), createGridMarket(100.0, 99, 97, 101, 103, 100))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"buy@99", "buy@97"}, gridOrders(results))
+	require.Len(t, strategy.Levels(), 4)
+	assert.True(t, decimal.NewFromFloat(103.0).Equal(strategy.Levels()[3]))
+}
+
+func TestGridStrategy_RequiresGridConfig(t *testing.T) {
+	strategy := NewGridStrategy(&models.StrategyConfig{ID: "grid", Enabled: true})
+
+	_, err := strategy.ExecuteAll(context.Background(), createTestPortfolio(), createGridMarket(100.0))
+
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
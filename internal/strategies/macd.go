@@ -0,0 +1,206 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type MACDStrategy struct {
+	*BaseStrategy
+	fastPeriod   int
+	slowPeriod   int
+	signalPeriod int
+	mu           sync.Mutex
+	state        map[string]*macdState
+}
+
+type macdState struct {
+	macd   decimal.Decimal
+	signal decimal.Decimal
+}
+
+func NewMACDStrategy(config *models.StrategyConfig) *MACDStrategy {
+	strategy := &MACDStrategy{
+		BaseStrategy: NewBaseStrategy(config),
+		fastPeriod:   12,
+		slowPeriod:   26,
+		signalPeriod: 9,
+		state:        make(map[string]*macdState),
+	}
+	strategy.requireHistory(strategy.slowPeriod + strategy.signalPeriod)
+	return strategy
+}
+
+func (s *MACDStrategy) SetPeriods(fast, slow, signal int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fastPeriod = fast
+	s.slowPeriod = slow
+	s.signalPeriod = signal
+	s.state = make(map[string]*macdState)
+	s.requireHistory(slow + signal)
+}
+
+func (s *MACDStrategy) Parameters() []string {
+	return []string{"fast_period", "slow_period", "signal_period"}
+}
+
+func (s *MACDStrategy) SetParameters(params map[string]decimal.Decimal) error {
+	if err := checkParameters(params, s.Parameters()...); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	fast, slow, signal := s.fastPeriod, s.slowPeriod, s.signalPeriod
+	s.mu.Unlock()
+
+	fast, err := intParameter(params, "fast_period", fast)
+	if err != nil {
+		return err
+	}
+	slow, err = intParameter(params, "slow_period", slow)
+	if err != nil {
+		return err
+	}
+	signal, err = intParameter(params, "signal_period", signal)
+	if err != nil {
+		return err
+	}
+	if fast >= slow {
+		return fmt.Errorf("%w: fast_period %d must be below slow_period %d", ErrInvalidConfig, fast, slow)
+	}
+	s.SetPeriods(fast, slow, signal)
+	return nil
+}
+
+func (s *MACDStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for symbol := range s.state {
+		if _, exists := market.Latest[symbol]; !exists {
+			delete(s.state, symbol)
+		}
+	}
+
+	var bestSignal *models.AlgorithmResult
+	maxConfidence := decimal.Zero
+
+	for symbol, data := range market.Latest {
+		signal, confidence, err := s.analyzeSymbol(symbol, data, market.Prices(symbol), portfolio)
+		if err != nil {
+			continue
+		}
+
+		if confidence.GreaterThan(maxConfidence) {
+			maxConfidence = confidence
+			bestSignal = signal
+		}
+	}
+
+	return bestSignal, nil
+}
+
+func (s *MACDStrategy) ExecuteOnTick(ctx context.Context, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	data, exists := market.Latest[symbol]
+	if !exists {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	signal, _, err := s.analyzeSymbol(symbol, data, market.Prices(symbol), portfolio)
+	if err != nil {
+		return nil, nil
+	}
+	return signal, nil
+}
+
+func (s *MACDStrategy) analyzeSymbol(symbol string, marketData *models.MarketData, prices []decimal.Decimal, portfolio *models.Portfolio) (*models.AlgorithmResult, decimal.Decimal, error) {
+	macd, signalLine, ok := indicators.MACD(prices, s.fastPeriod, s.slowPeriod, s.signalPeriod)
+	if !ok {
+		delete(s.state, symbol)
+		return nil, decimal.Zero, ErrInvalidMarketData
+	}
+
+	previous, hasPrevious := s.state[symbol]
+	s.state[symbol] = &macdState{macd: macd, signal: signalLine}
+	if !hasPrevious {
+		return nil, decimal.Zero, nil
+	}
+
+	currentPrice := marketData.Price
+	position, hasPosition := portfolio.Positions[symbol]
+	histogram := macd.Sub(signalLine)
+
+	var action models.Action
+	var signal string
+	var quantity decimal.Decimal
+
+	crossedAbove := previous.macd.LessThanOrEqual(previous.signal) && macd.GreaterThan(signalLine)
+	crossedBelow := previous.macd.GreaterThanOrEqual(previous.signal) && macd.LessThan(signalLine)
+
+	if crossedAbove {
+		if !hasPosition || !position.Quantity.IsPositive() {
+			action = models.ActionBuy
+			signal = "macd_bullish_cross"
+			quantity = s.calculateOptimalQuantity(symbol, currentPrice, portfolio)
+		}
+	} else if crossedBelow {
+		if hasPosition && position.Quantity.IsPositive() {
+			action = models.ActionSell
+			signal = "macd_bearish_cross"
+			quantity = position.Quantity
+		}
+	}
+
+	if action == "" || !quantity.IsPositive() {
+		return nil, decimal.Zero, nil
+	}
+
+	riskMetrics, err := s.calculatePositionRisk(symbol, action, quantity, currentPrice, portfolio)
+	if err != nil {
+		return nil, decimal.Zero, err
+	}
+
+	confidence := s.calculateConfidence(histogram, currentPrice)
+
+	return &models.AlgorithmResult{
+		StrategyID:     s.ID(),
+		Symbol:         symbol,
+		Action:         action,
+		Quantity:       quantity,
+		Price:          currentPrice,
+		Confidence:     confidence,
+		Signal:         signal,
+		Timestamp:      marketData.Timestamp,
+		RiskScore:      s.calculateRiskScore(riskMetrics),
+		ExpectedReturn: histogram.Div(currentPrice),
+	}, confidence, nil
+}
+
+func (s *MACDStrategy) calculateConfidence(histogram, price decimal.Decimal) decimal.Decimal {
+	if price.IsZero() {
+		return decimal.Zero
+	}
+
+	confidence := histogram.Abs().Div(price).Mul(decimal.NewFromInt(100))
+	if confidence.GreaterThan(decimal.NewFromFloat(1.0)) {
+		confidence = decimal.NewFromFloat(1.0)
+	}
+
+	return confidence
+}
@@ -0,0 +1,136 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMACDStrategy(t *testing.T) {
+	strategy := NewMACDStrategy(&models.StrategyConfig{ID: "test_macd", Name: "Test MACD"})
+
+	assert.NotNil(t, strategy)
+	assert.Equal(t, "test_macd", strategy.ID())
+	assert.Equal(t, 12, strategy.fastPeriod)
+	assert.Equal(t, 26, strategy.slowPeriod)
+	assert.Equal(t, 9, strategy.signalPeriod)
+}
+
+func TestMACDStrategy_Execute_BullishCross(t *testing.T) {
+	strategy := NewMACDStrategy(createTestMACDConfig())
+	strategy.SetPeriods(3, 6, 3)
+	history := linearPrices(120, -1, 20)
+	market := newTestMarket()
+	market.push("AAPL", history...)
+	path := []float64{99, 97, 94, 90, 92, 96, 101, 107, 114}
+
+	result, step := runMACDPath(t, strategy, createTestPortfolio(), market, path)
+
+	require.NotNil(t, result)
+	assert.Equal(t, expectedMACDCrossStep(t, history, path, true), step)
+	assert.Equal(t, models.ActionBuy, result.Action)
+	assert.Equal(t, "macd_bullish_cross", result.Signal)
+	assert.True(t, result.Confidence.GreaterThan(decimal.Zero))
+	assert.True(t, result.Confidence.LessThanOrEqual(decimal.NewFromFloat(1.0)))
+}
+
+func TestMACDStrategy_Execute_BearishCrossSellsPosition(t *testing.T) {
+	strategy := NewMACDStrategy(createTestMACDConfig())
+	strategy.SetPeriods(3, 6, 3)
+	history := linearPrices(80, 1, 20)
+	market := newTestMarket()
+	market.push("AAPL", history...)
+	portfolio := createTestPortfolio()
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(25)}
+	path := []float64{101, 103, 106, 110, 108, 104, 99, 93, 86}
+
+	result, step := runMACDPath(t, strategy, portfolio, market, path)
+
+	require.NotNil(t, result)
+	assert.Equal(t, expectedMACDCrossStep(t, history, path, false), step)
+	assert.Equal(t, models.ActionSell, result.Action)
+	assert.Equal(t, "macd_bearish_cross", result.Signal)
+	assert.True(t, decimal.NewFromInt(25).Equal(result.Quantity))
+}
+
+func TestMACDStrategy_Execute_ResetsStateForMissingSymbol(t *testing.T) {
+	strategy := NewMACDStrategy(createTestMACDConfig())
+	strategy.SetPeriods(3, 6, 3)
+	market := newTestMarket()
+	portfolio := createTestPortfolio()
+
+	_, err := strategy.Execute(context.Background(), portfolio, market.push("AAPL", append(linearPrices(120, -1, 20), 100.0)...))
+	require.NoError(t, err)
+	assert.Contains(t, strategy.state, "AAPL")
+
+	googl := &models.MarketSnapshot{Latest: map[string]*models.MarketData{
+		"GOOGL": {Symbol: "GOOGL", Price: decimal.NewFromFloat(2800.0), Timestamp: time.Now()},
+	}}
+	_, err = strategy.Execute(context.Background(), portfolio, googl)
+	require.NoError(t, err)
+	assert.NotContains(t, strategy.state, "AAPL")
+
+	result, err := strategy.Execute(context.Background(), portfolio, market.push("AAPL", 150.0))
+
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, strategy.state, "AAPL")
+}
+
+func runMACDPath(t *testing.T, strategy *MACDStrategy, portfolio *models.Portfolio, market *testMarket, path []float64) (*models.AlgorithmResult, int) {
+	for step, value := range path {
+		result, err := strategy.Execute(context.Background(), portfolio, market.push("AAPL", value))
+		require.NoError(t, err)
+		if result != nil {
+			return result, step
+		}
+	}
+
+	return nil, -1
+}
+
+func expectedMACDCrossStep(t *testing.T, history, path []float64, bullish bool) int {
+	prices := make([]decimal.Decimal, 0, len(history)+len(path))
+	for _, value := range history {
+		prices = append(prices, decimal.NewFromFloat(value))
+	}
+	var previous decimal.Decimal
+
+	for step, value := range path {
+		prices = append(prices, decimal.NewFromFloat(value))
+		macd, signal, ok := indicators.MACD(prices, 3, 6, 3)
+		require.True(t, ok)
+
+		histogram := macd.Sub(signal)
+		if step > 0 {
+			if bullish && !previous.IsPositive() && histogram.IsPositive() {
+				return step
+			}
+			if !bullish && !previous.IsNegative() && histogram.IsNegative() {
+				return step
+			}
+		}
+		previous = histogram
+	}
+
+	t.Fatal("price path never crosses")
+	return -1
+}
+
+func createTestMACDConfig() *models.StrategyConfig {
+	return &models.StrategyConfig{
+		ID:               "test_macd",
+		Name:             "Test MACD",
+		Enabled:          true,
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+		MinOrderSize:     decimal.NewFromFloat(100.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+	}
+}
@@ -0,0 +1,181 @@
+package strategies
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type MomentumStrategy struct {
+	*BaseStrategy
+	lookbackPeriod int
+	topK           int
+}
+
+type symbolMomentum struct {
+	symbol       string
+	rateOfChange decimal.Decimal
+	price        decimal.Decimal
+	timestamp    time.Time
+}
+
+func NewMomentumStrategy(config *models.StrategyConfig) *MomentumStrategy {
+	strategy := &MomentumStrategy{
+		BaseStrategy:   NewBaseStrategy(config),
+		lookbackPeriod: 20,
+		topK:           3,
+	}
+	strategy.requireHistory(strategy.lookbackPeriod + 1)
+	return strategy
+}
+
+func (s *MomentumStrategy) SetLookbackPeriod(period int) {
+	s.lookbackPeriod = period
+	s.requireHistory(period + 1)
+}
+
+func (s *MomentumStrategy) SetTopK(k int) {
+	s.topK = k
+}
+
+func (s *MomentumStrategy) Parameters() []string {
+	return []string{"lookback_period", "top_k"}
+}
+
+func (s *MomentumStrategy) SetParameters(params map[string]decimal.Decimal) error {
+	if err := checkParameters(params, s.Parameters()...); err != nil {
+		return err
+	}
+	period, err := intParameter(params, "lookback_period", s.lookbackPeriod)
+	if err != nil {
+		return err
+	}
+	k, err := intParameter(params, "top_k", s.topK)
+	if err != nil {
+		return err
+	}
+	s.SetLookbackPeriod(period)
+	s.SetTopK(k)
+	return nil
+}
+
+func (s *MomentumStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	results, err := s.ExecuteAll(ctx, portfolio, market)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (s *MomentumStrategy) ExecuteAll(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) ([]*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+	return s.rebalance(portfolio, market), nil
+}
+
+func (s *MomentumStrategy) rebalance(portfolio *models.Portfolio, market *models.MarketSnapshot) []*models.AlgorithmResult {
+	ranking := s.rankSymbols(market)
+	if len(ranking) == 0 {
+		return nil
+	}
+
+	topK := s.topK
+	if topK > len(ranking) {
+		topK = len(ranking)
+	}
+
+	selected := make(map[string]bool, topK)
+	for _, entry := range ranking[:topK] {
+		selected[entry.symbol] = true
+	}
+
+	var results []*models.AlgorithmResult
+	budget := *portfolio
+
+	for _, entry := range ranking {
+		position, hasPosition := portfolio.Positions[entry.symbol]
+		if selected[entry.symbol] || !hasPosition || !position.Quantity.IsPositive() {
+			continue
+		}
+		if result := s.buildResult(entry, models.ActionSell, "momentum_exit", position.Quantity, decimal.NewFromFloat(1.0), portfolio); result != nil {
+			results = append(results, result)
+			budget.Cash = budget.Cash.Add(position.Quantity.Mul(entry.price))
+		}
+	}
+
+	for rank, entry := range ranking[:topK] {
+		if position, hasPosition := portfolio.Positions[entry.symbol]; hasPosition && position.Quantity.IsPositive() {
+			continue
+		}
+		quantity := s.calculateOptimalQuantity(entry.symbol, entry.price, &budget)
+		confidence := decimal.NewFromInt(int64(topK - rank)).Div(decimal.NewFromInt(int64(topK)))
+		if result := s.buildResult(entry, models.ActionBuy, "momentum_entry", quantity, confidence, portfolio); result != nil {
+			results = append(results, result)
+			budget.Cash = budget.Cash.Sub(quantity.Mul(entry.price))
+		}
+	}
+
+	return results
+}
+
+func (s *MomentumStrategy) rankSymbols(market *models.MarketSnapshot) []symbolMomentum {
+	ranking := make([]symbolMomentum, 0, len(market.Latest))
+	for symbol, data := range market.Latest {
+		prices := market.Prices(symbol)
+		rateOfChange, ok := calculateRateOfChange(prices, s.lookbackPeriod)
+		if !ok {
+			continue
+		}
+		ranking = append(ranking, symbolMomentum{symbol: symbol, rateOfChange: rateOfChange, price: data.Price, timestamp: data.Timestamp})
+	}
+
+	sort.Slice(ranking, func(i, j int) bool {
+		if !ranking[i].rateOfChange.Equal(ranking[j].rateOfChange) {
+			return ranking[i].rateOfChange.GreaterThan(ranking[j].rateOfChange)
+		}
+		return ranking[i].symbol < ranking[j].symbol
+	})
+
+	return ranking
+}
+
+func (s *MomentumStrategy) buildResult(entry symbolMomentum, action models.Action, signal string, quantity, confidence decimal.Decimal, portfolio *models.Portfolio) *models.AlgorithmResult {
+	if !quantity.IsPositive() {
+		return nil
+	}
+
+	riskMetrics, err := s.calculatePositionRisk(entry.symbol, action, quantity, entry.price, portfolio)
+	if err != nil {
+		return nil
+	}
+
+	return &models.AlgorithmResult{
+		StrategyID:     s.ID(),
+		Symbol:         entry.symbol,
+		Action:         action,
+		Quantity:       quantity,
+		Price:          entry.price,
+		Confidence:     confidence,
+		Signal:         signal,
+		Timestamp:      entry.timestamp,
+		RiskScore:      s.calculateRiskScore(riskMetrics),
+		ExpectedReturn: entry.rateOfChange,
+	}
+}
+
+func calculateRateOfChange(prices []decimal.Decimal, period int) (decimal.Decimal, bool) {
+	if period <= 0 || len(prices) < period+1 {
+		return decimal.Zero, false
+	}
+
+	previous := prices[len(prices)-1-period]
+	if previous.IsZero() {
+		return decimal.Zero, false
+	}
+
+	return prices[len(prices)-1].Sub(previous).Div(previous), true
+}
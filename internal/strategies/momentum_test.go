@@ -0,0 +1,163 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMomentumStrategy(t *testing.T) {
+	strategy := NewMomentumStrategy(&models.StrategyConfig{ID: "test_momentum", Name: "Test Momentum"})
+
+	assert.NotNil(t, strategy)
+	assert.Equal(t, 20, strategy.lookbackPeriod)
+	assert.Equal(t, 3, strategy.topK)
+}
+
+func TestCalculateRateOfChange(t *testing.T) {
+	prices := []decimal.Decimal{
+		decimal.NewFromInt(100),
+		decimal.NewFromInt(105),
+		decimal.NewFromInt(110),
+	}
+
+	roc, ok := calculateRateOfChange(prices, 2)
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromFloat(0.1).Equal(roc))
+
+	_, ok = calculateRateOfChange(prices, 3)
+	assert.False(t, ok)
+}
+
+func TestMomentumStrategy_RankSymbols_TieBreakBySymbol(t *testing.T) {
+	strategy := NewMomentumStrategy(createTestMomentumConfig())
+	strategy.SetLookbackPeriod(1)
+	market := createTestMomentumMarket(map[string]float64{
+		"MSFT": 110,
+		"AAPL": 110,
+		"TSLA": 120,
+	})
+
+	ranking := strategy.rankSymbols(market)
+
+	require.Len(t, ranking, 3)
+	assert.Equal(t, "TSLA", ranking[0].symbol)
+	assert.Equal(t, "AAPL", ranking[1].symbol)
+	assert.Equal(t, "MSFT", ranking[2].symbol)
+}
+
+func TestMomentumStrategy_ExecuteAll_EmitsTopK(t *testing.T) {
+	strategy := NewMomentumStrategy(createTestMomentumConfig())
+	strategy.SetLookbackPeriod(1)
+	strategy.SetTopK(2)
+	portfolio := createTestPortfolio()
+	market := createTestMomentumMarket(map[string]float64{
+		"AAPL": 105,
+		"MSFT": 102,
+		"TSLA": 110,
+	})
+
+	results, err := strategy.ExecuteAll(context.Background(), portfolio, market)
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "TSLA", results[0].Symbol)
+	assert.Equal(t, models.ActionBuy, results[0].Action)
+	assert.Equal(t, "momentum_entry", results[0].Signal)
+	assert.Equal(t, market.Latest["TSLA"].Timestamp, results[0].Timestamp)
+	assert.Equal(t, "AAPL", results[1].Symbol)
+	assert.Equal(t, models.ActionBuy, results[1].Action)
+	assert.True(t, results[0].Confidence.GreaterThan(results[1].Confidence))
+
+	first, err := strategy.Execute(context.Background(), portfolio, market)
+	require.NoError(t, err)
+	assert.Equal(t, results[0], first)
+}
+
+func TestMomentumStrategy_ExecuteAll_ExitsDroppedSymbolsFirst(t *testing.T) {
+	strategy := NewMomentumStrategy(createTestMomentumConfig())
+	strategy.SetLookbackPeriod(1)
+	strategy.SetTopK(1)
+	portfolio := createTestPortfolio()
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(40)}
+	market := createTestMomentumMarket(map[string]float64{
+		"AAPL": 101,
+		"TSLA": 110,
+	})
+
+	results, err := strategy.ExecuteAll(context.Background(), portfolio, market)
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "AAPL", results[0].Symbol)
+	assert.Equal(t, models.ActionSell, results[0].Action)
+	assert.Equal(t, "momentum_exit", results[0].Signal)
+	assert.True(t, decimal.NewFromInt(40).Equal(results[0].Quantity))
+	assert.Equal(t, "TSLA", results[1].Symbol)
+	assert.Equal(t, models.ActionBuy, results[1].Action)
+}
+
+func TestMomentumStrategy_ExecuteAll_SizesEntriesFromRemainingCash(t *testing.T) {
+	strategy := NewMomentumStrategy(createTestMomentumConfig())
+	strategy.SetLookbackPeriod(1)
+	strategy.SetTopK(2)
+	portfolio := createTestPortfolio()
+	portfolio.Cash = decimal.NewFromInt(1000)
+	market := createTestMomentumMarket(map[string]float64{
+		"AAPL": 105,
+		"TSLA": 110,
+	})
+
+	results, err := strategy.ExecuteAll(context.Background(), portfolio, market)
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	spent := decimal.Zero
+	for _, result := range results {
+		spent = spent.Add(result.Quantity.Mul(result.Price))
+	}
+	assert.True(t, spent.LessThanOrEqual(portfolio.Cash), spent.String())
+	assert.True(t, results[1].Quantity.LessThan(results[0].Quantity))
+}
+
+func TestMomentumStrategy_ExecuteAll_SkipsHeldSymbols(t *testing.T) {
+	strategy := NewMomentumStrategy(createTestMomentumConfig())
+	strategy.SetLookbackPeriod(1)
+	strategy.SetTopK(2)
+	portfolio := createTestPortfolio()
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(10)}
+	portfolio.Positions["TSLA"] = &models.Position{Symbol: "TSLA", Quantity: decimal.NewFromInt(10)}
+	market := createTestMomentumMarket(map[string]float64{
+		"AAPL": 105,
+		"TSLA": 110,
+	})
+
+	results, err := strategy.ExecuteAll(context.Background(), portfolio, market)
+
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func createTestMomentumConfig() *models.StrategyConfig {
+	return &models.StrategyConfig{
+		ID:               "test_momentum",
+		Name:             "Test Momentum",
+		Enabled:          true,
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+		MinOrderSize:     decimal.NewFromFloat(100.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+	}
+}
+
+func createTestMomentumMarket(prices map[string]float64) *models.MarketSnapshot {
+	market := newTestMarket()
+	for symbol, price := range prices {
+		market.push(symbol, 100, price)
+	}
+	return market.snapshot
+}
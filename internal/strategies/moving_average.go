@@ -2,8 +2,12 @@ package strategies
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
 	"github.com/1cbyc/trade-algo-go/internal/models"
 	"github.com/shopspring/decimal"
 )
@@ -13,44 +17,168 @@ type MovingAverageStrategy struct {
 	shortPeriod  int
 	longPeriod   int
 	signalPeriod int
+	cooldown     int
+	mu           sync.Mutex
+	averages     map[string]*movingAverages
+	cooldowns    map[string]*cooldown
+}
+
+type cooldown struct {
+	remaining int
+	seen      time.Time
+	blocked   bool
+}
+
+type movingAverages struct {
+	last   *models.MarketData
+	price  decimal.Decimal
+	short  *indicators.RollingSMA
+	long   *indicators.RollingSMA
+	signal *indicators.RollingSMA
 }
 
 func NewMovingAverageStrategy(config *models.StrategyConfig) *MovingAverageStrategy {
-	return &MovingAverageStrategy{
+	strategy := &MovingAverageStrategy{
 		BaseStrategy: NewBaseStrategy(config),
 		shortPeriod:  10,
 		longPeriod:   30,
 		signalPeriod: 9,
+		averages:     make(map[string]*movingAverages),
+		cooldowns:    make(map[string]*cooldown),
+	}
+	strategy.requireHistory(strategy.longPeriod)
+	return strategy
+}
+
+func (s *MovingAverageStrategy) SetPeriods(short, long, signal int) {
+	s.shortPeriod = short
+	s.longPeriod = long
+	s.signalPeriod = signal
+	s.requireHistory(long)
+
+	s.mu.Lock()
+	s.averages = make(map[string]*movingAverages)
+	s.mu.Unlock()
+}
+
+func (s *MovingAverageStrategy) SetCooldown(ticks int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cooldown = ticks
+}
+
+func (s *MovingAverageStrategy) OnFill(trade *models.Trade) {
+	if trade.ExitReason != models.ExitReasonStopLoss && trade.ExitReason != models.ExitReasonTrailingStop {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cooldown > 0 {
+		s.cooldowns[trade.Symbol] = &cooldown{remaining: s.cooldown, seen: trade.Timestamp}
+	}
+}
+
+func (s *MovingAverageStrategy) coolingDown(symbol string, marketData *models.MarketData) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, exists := s.cooldowns[symbol]
+	if !exists {
+		return false
+	}
+	if marketData.Timestamp.After(state.seen) {
+		state.seen = marketData.Timestamp
+		state.blocked = state.remaining > 0
+		state.remaining--
 	}
+	if !state.blocked {
+		delete(s.cooldowns, symbol)
+	}
+	return state.blocked
 }
 
-func (s *MovingAverageStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, marketData map[string]*models.MarketData) (*models.AlgorithmResult, error) {
+func (s *MovingAverageStrategy) Parameters() []string {
+	return []string{"short_period", "long_period", "signal_period", "cooldown_ticks"}
+}
+
+func (s *MovingAverageStrategy) SetParameters(params map[string]decimal.Decimal) error {
+	if err := checkParameters(params, s.Parameters()...); err != nil {
+		return err
+	}
+	short, err := intParameter(params, "short_period", s.shortPeriod)
+	if err != nil {
+		return err
+	}
+	long, err := intParameter(params, "long_period", s.longPeriod)
+	if err != nil {
+		return err
+	}
+	signal, err := intParameter(params, "signal_period", s.signalPeriod)
+	if err != nil {
+		return err
+	}
+	cooldown, err := intParameter(params, "cooldown_ticks", s.cooldown)
+	if err != nil {
+		return err
+	}
+	if short >= long {
+		return fmt.Errorf("%w: short_period %d must be below long_period %d", ErrInvalidConfig, short, long)
+	}
+	s.SetPeriods(short, long, signal)
+	s.SetCooldown(cooldown)
+	return nil
+}
+
+func (s *MovingAverageStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	signals, err := s.ExecuteAll(ctx, portfolio, market)
+	if err != nil || len(signals) == 0 {
+		return nil, err
+	}
+	return signals[0], nil
+}
+
+func (s *MovingAverageStrategy) ExecuteAll(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) ([]*models.AlgorithmResult, error) {
 	if !s.IsEnabled() {
 		return nil, ErrStrategyDisabled
 	}
 
-	var bestSignal *models.AlgorithmResult
-	maxConfidence := decimal.Zero
-
-	for symbol, data := range marketData {
-		signal, confidence, err := s.analyzeSymbol(symbol, data, portfolio)
-		if err != nil {
+	var signals []*models.AlgorithmResult
+	for symbol, data := range market.Latest {
+		signal, confidence, err := s.analyzeSymbol(symbol, data, market.History[symbol], portfolio)
+		if err != nil || !confidence.IsPositive() {
 			continue
 		}
+		signals = append(signals, signal)
+	}
 
-		if confidence.GreaterThan(maxConfidence) {
-			maxConfidence = confidence
-			bestSignal = signal
+	sort.Slice(signals, func(i, j int) bool {
+		if !signals[i].Confidence.Equal(signals[j].Confidence) {
+			return signals[i].Confidence.GreaterThan(signals[j].Confidence)
 		}
+		return signals[i].Symbol < signals[j].Symbol
+	})
+	return signals, nil
+}
+
+func (s *MovingAverageStrategy) ExecuteOnTick(ctx context.Context, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	data, exists := market.Latest[symbol]
+	if !exists {
+		return nil, nil
 	}
 
-	return bestSignal, nil
+	signal, _, err := s.analyzeSymbol(symbol, data, market.History[symbol], portfolio)
+	if err != nil {
+		return nil, nil
+	}
+	return signal, nil
 }
 
-func (s *MovingAverageStrategy) analyzeSymbol(symbol string, marketData *models.MarketData, portfolio *models.Portfolio) (*models.AlgorithmResult, decimal.Decimal, error) {
-	shortMA := s.calculateSMA(symbol, s.shortPeriod, portfolio)
-	longMA := s.calculateSMA(symbol, s.longPeriod, portfolio)
-	signalMA := s.calculateSMA(symbol, s.signalPeriod, portfolio)
+func (s *MovingAverageStrategy) analyzeSymbol(symbol string, marketData *models.MarketData, history []*models.MarketData, portfolio *models.Portfolio) (*models.AlgorithmResult, decimal.Decimal, error) {
+	shortMA, longMA, signalMA := s.movingAverages(symbol, history)
 
 	if shortMA.IsZero() || longMA.IsZero() || signalMA.IsZero() {
 		return nil, decimal.Zero, ErrInvalidMarketData
@@ -58,30 +186,34 @@ func (s *MovingAverageStrategy) analyzeSymbol(symbol string, marketData *models.
 
 	currentPrice := marketData.Price
 	position, hasPosition := portfolio.Positions[symbol]
+	coolingDown := s.coolingDown(symbol, marketData)
 
-	var action string
-	var quantity int64
+	var action models.Action
+	var quantity decimal.Decimal
 	var confidence decimal.Decimal
+	var reason string
 
 	if shortMA.GreaterThan(longMA) && currentPrice.GreaterThan(signalMA) {
-		if !hasPosition || position.Quantity <= 0 {
-			action = "buy"
-			quantity = s.calculateOptimalQuantity(currentPrice, portfolio)
+		if (!hasPosition || !position.Quantity.IsPositive()) && !coolingDown {
+			action = models.ActionBuy
+			quantity = s.calculateOptimalQuantity(symbol, currentPrice, portfolio)
 			confidence = s.calculateConfidence(shortMA, longMA, currentPrice, signalMA)
+			reason = fmt.Sprintf("short SMA %s above long SMA %s and price %s above signal SMA %s", shortMA.StringFixed(2), longMA.StringFixed(2), currentPrice.StringFixed(2), signalMA.StringFixed(2))
 		}
 	} else if shortMA.LessThan(longMA) && currentPrice.LessThan(signalMA) {
-		if hasPosition && position.Quantity > 0 {
-			action = "sell"
+		if hasPosition && position.Quantity.IsPositive() {
+			action = models.ActionSell
 			quantity = position.Quantity
 			confidence = s.calculateConfidence(longMA, shortMA, signalMA, currentPrice)
+			reason = fmt.Sprintf("short SMA %s below long SMA %s and price %s below signal SMA %s", shortMA.StringFixed(2), longMA.StringFixed(2), currentPrice.StringFixed(2), signalMA.StringFixed(2))
 		}
 	}
 
-	if action == "" || quantity <= 0 {
+	if action == "" || !quantity.IsPositive() {
 		return nil, decimal.Zero, nil
 	}
 
-	riskMetrics, err := s.calculatePositionRisk(symbol, quantity, currentPrice, portfolio)
+	riskMetrics, err := s.calculatePositionRisk(symbol, action, quantity, currentPrice, portfolio)
 	if err != nil {
 		return nil, decimal.Zero, err
 	}
@@ -94,63 +226,55 @@ func (s *MovingAverageStrategy) analyzeSymbol(symbol string, marketData *models.
 		Price:          currentPrice,
 		Confidence:     confidence,
 		Signal:         s.generateSignal(shortMA, longMA, signalMA, currentPrice),
-		Timestamp:      time.Now(),
+		Reason:         reason,
+		Timestamp:      marketData.Timestamp,
 		RiskScore:      s.calculateRiskScore(riskMetrics),
 		ExpectedReturn: s.calculateExpectedReturn(shortMA, longMA, currentPrice),
 	}, confidence, nil
 }
 
-func (s *MovingAverageStrategy) calculateSMA(symbol string, period int, portfolio *models.Portfolio) decimal.Decimal {
-	if len(portfolio.TradeHistory) < period {
-		return decimal.Zero
-	}
-
-	var prices []decimal.Decimal
-	symbolTrades := s.getTradesForSymbol(symbol, portfolio.TradeHistory)
-
-	if len(symbolTrades) < period {
-		return decimal.Zero
-	}
-
-	for i := len(symbolTrades) - period; i < len(symbolTrades); i++ {
-		prices = append(prices, symbolTrades[i].Price)
-	}
-
-	sum := decimal.Zero
-	for _, price := range prices {
-		sum = sum.Add(price)
+func (s *MovingAverageStrategy) movingAverages(symbol string, history []*models.MarketData) (decimal.Decimal, decimal.Decimal, decimal.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	averages := s.averages[symbol]
+	start := -1
+	if averages != nil {
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i] == averages.last {
+				start = i
+				break
+			}
+		}
+		if start >= 0 && !history[start].Price.Equal(averages.price) {
+			start = -1
+		}
 	}
-
-	return sum.Div(decimal.NewFromInt(int64(len(prices))))
-}
-
-func (s *MovingAverageStrategy) getTradesForSymbol(symbol string, trades []*models.Trade) []*models.Trade {
-	var symbolTrades []*models.Trade
-	for _, trade := range trades {
-		if trade.Symbol == symbol {
-			symbolTrades = append(symbolTrades, trade)
+	if start < 0 {
+		averages = &movingAverages{
+			short:  indicators.NewRollingSMA(s.shortPeriod),
+			long:   indicators.NewRollingSMA(s.longPeriod),
+			signal: indicators.NewRollingSMA(s.signalPeriod),
 		}
+		s.averages[symbol] = averages
 	}
-	return symbolTrades
-}
 
-func (s *MovingAverageStrategy) calculateOptimalQuantity(price decimal.Decimal, portfolio *models.Portfolio) int64 {
-	availableCash := portfolio.Cash.Mul(decimal.NewFromFloat(0.95))
-	maxQuantity := availableCash.Div(price).IntPart()
-
-	if maxQuantity <= 0 {
-		return 0
+	for _, data := range history[start+1:] {
+		averages.short.Add(data.Price)
+		averages.long.Add(data.Price)
+		averages.signal.Add(data.Price)
+		averages.last, averages.price = data, data.Price
 	}
 
-	config := s.GetConfig()
-	maxOrderValue := config.MaxOrderSize
-	maxQuantityBySize := maxOrderValue.Div(price).IntPart()
+	return rollingValue(averages.short, len(history)), rollingValue(averages.long, len(history)), rollingValue(averages.signal, len(history))
+}
 
-	if maxQuantity > maxQuantityBySize {
-		maxQuantity = maxQuantityBySize
+func rollingValue(sma *indicators.RollingSMA, available int) decimal.Decimal {
+	value, ok := sma.Value()
+	if !ok || available < sma.Period() {
+		return decimal.Zero
 	}
-
-	return maxQuantity
+	return value
 }
 
 func (s *MovingAverageStrategy) calculateConfidence(shortMA, longMA, currentPrice, signalMA decimal.Decimal) decimal.Decimal {
@@ -166,32 +290,6 @@ func (s *MovingAverageStrategy) calculateConfidence(shortMA, longMA, currentPric
 	return confidence
 }
 
-func (s *MovingAverageStrategy) calculatePositionRisk(symbol string, quantity int64, price decimal.Decimal, portfolio *models.Portfolio) (*models.RiskMetrics, error) {
-	order := &models.Order{
-		Symbol:   symbol,
-		Quantity: quantity,
-		Price:    price,
-	}
-
-	return s.CalculateRisk(order, portfolio)
-}
-
-func (s *MovingAverageStrategy) calculateRiskScore(riskMetrics *models.RiskMetrics) decimal.Decimal {
-	if riskMetrics == nil {
-		return decimal.Zero
-	}
-
-	volatilityScore := decimal.NewFromFloat(1.0).Sub(riskMetrics.Volatility)
-	varScore := decimal.NewFromFloat(1.0).Sub(riskMetrics.VaR95.Div(decimal.NewFromFloat(100)))
-	sharpeScore := riskMetrics.SharpeRatio.Div(decimal.NewFromFloat(2.0))
-
-	if sharpeScore.GreaterThan(decimal.NewFromFloat(1.0)) {
-		sharpeScore = decimal.NewFromFloat(1.0)
-	}
-
-	return volatilityScore.Add(varScore).Add(sharpeScore).Div(decimal.NewFromFloat(3.0))
-}
-
 func (s *MovingAverageStrategy) calculateExpectedReturn(shortMA, longMA, currentPrice decimal.Decimal) decimal.Decimal {
 	maRatio := shortMA.Div(longMA)
 	priceRatio := currentPrice.Div(longMA)
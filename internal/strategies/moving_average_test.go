@@ -2,10 +2,15 @@ package strategies
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"testing"
 	"time"
 
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
 	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,9 +41,9 @@ func TestMovingAverageStrategy_Execute_Disabled(t *testing.T) {
 
 	strategy := NewMovingAverageStrategy(config)
 	portfolio := createTestPortfolio()
-	marketData := createTestMarketData()
+	market := &models.MarketSnapshot{Latest: createTestMarketData()}
 
-	result, err := strategy.Execute(context.Background(), portfolio, marketData)
+	result, err := strategy.Execute(context.Background(), portfolio, market)
 
 	assert.Nil(t, result)
 	assert.Equal(t, ErrStrategyDisabled, err)
@@ -53,43 +58,119 @@ func TestMovingAverageStrategy_Execute_NoMarketData(t *testing.T) {
 
 	strategy := NewMovingAverageStrategy(config)
 	portfolio := createTestPortfolio()
-	marketData := make(map[string]*models.MarketData)
+	market := &models.MarketSnapshot{
+		Latest:  make(map[string]*models.MarketData),
+		History: make(map[string][]*models.MarketData),
+	}
 
-	result, err := strategy.Execute(context.Background(), portfolio, marketData)
+	result, err := strategy.Execute(context.Background(), portfolio, market)
 
 	assert.Nil(t, result)
 	assert.NoError(t, err)
 }
 
-func TestMovingAverageStrategy_CalculateSMA(t *testing.T) {
+func TestMovingAverageStrategy_Execute_FreshPortfolioUsesMarketHistory(t *testing.T) {
 	config := &models.StrategyConfig{
-		ID:      "test_ma",
-		Name:    "Test Moving Average",
-		Enabled: true,
+		ID:               "test_ma",
+		Name:             "Test Moving Average",
+		Enabled:          true,
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+		MinOrderSize:     decimal.NewFromFloat(100.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+		MarketDataWindow: 30,
 	}
 
 	strategy := NewMovingAverageStrategy(config)
-	portfolio := createTestPortfolioWithHistory()
-
-	sma := strategy.calculateSMA("AAPL", 3, portfolio)
+	portfolio := createTestPortfolio()
+	market := newTestMarket()
+
+	var result *models.AlgorithmResult
+	for i := 0; i < 31; i++ {
+		var err error
+		result, err = strategy.Execute(context.Background(), portfolio, market.push("AAPL", 150.0+float64(i)))
+		require.NoError(t, err)
+		if i < 29 {
+			assert.Nil(t, result)
+		}
+	}
 
-	assert.False(t, sma.IsZero())
-	assert.True(t, sma.GreaterThan(decimal.Zero))
+	assert.Empty(t, portfolio.TradeHistory)
+	_, ok := indicators.SMA(market.snapshot.Prices("AAPL"), 30)
+	assert.True(t, ok)
+	require.NotNil(t, result)
+	assert.Equal(t, models.ActionBuy, result.Action)
+	assert.Equal(t, "strong_buy", result.Signal)
+	assert.Contains(t, result.Reason, "above long SMA")
+	assert.Equal(t, "AAPL", result.Symbol)
 }
 
-func TestMovingAverageStrategy_CalculateSMA_InsufficientData(t *testing.T) {
-	config := &models.StrategyConfig{
-		ID:      "test_ma",
-		Name:    "Test Moving Average",
-		Enabled: true,
+func TestMovingAverageStrategy_OnFill_CoolsDownAfterStopOut(t *testing.T) {
+	strategy := NewMovingAverageStrategy(&models.StrategyConfig{
+		ID:               "test_ma",
+		Enabled:          true,
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+		MinOrderSize:     decimal.NewFromFloat(100.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+		MarketDataWindow: 30,
+	})
+	strategy.SetCooldown(3)
+	portfolio := createTestPortfolio()
+	market := newTestMarket()
+	market.push("AAPL", linearPrices(150.0, 1.0, 30)...)
+
+	strategy.OnFill(&models.Trade{Symbol: "AAPL", Side: models.OrderSideSell, ExitReason: models.ExitReasonTakeProfit, Timestamp: market.clock})
+	result, err := strategy.Execute(context.Background(), portfolio, market.snapshot)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	strategy.OnFill(&models.Trade{Symbol: "AAPL", Side: models.OrderSideSell, ExitReason: models.ExitReasonStopLoss, Timestamp: market.clock})
+	for i := 0; i < 3; i++ {
+		snapshot := market.push("AAPL", 180.0+float64(i))
+		for repeat := 0; repeat < 2; repeat++ {
+			result, err := strategy.Execute(context.Background(), portfolio, snapshot)
+			require.NoError(t, err)
+			assert.Nil(t, result, "tick %d", i)
+		}
 	}
 
-	strategy := NewMovingAverageStrategy(config)
-	portfolio := createTestPortfolio()
+	result, err = strategy.Execute(context.Background(), portfolio, market.push("AAPL", 183.0))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, models.ActionBuy, result.Action)
+}
+
+func TestBaseStrategy_RequiredHistory(t *testing.T) {
+	assert.Equal(t, 30, NewMovingAverageStrategy(&models.StrategyConfig{MarketDataWindow: 20}).RequiredHistory())
+	assert.Equal(t, 50, NewMovingAverageStrategy(&models.StrategyConfig{MarketDataWindow: 50}).RequiredHistory())
+	assert.Equal(t, defaultPriceHistorySize, NewBaseStrategy(&models.StrategyConfig{}).RequiredHistory())
+}
 
-	sma := strategy.calculateSMA("AAPL", 10, portfolio)
+func TestMovingAverageStrategy_SetParameters(t *testing.T) {
+	strategy := NewMovingAverageStrategy(&models.StrategyConfig{})
+
+	require.NoError(t, strategy.SetParameters(map[string]decimal.Decimal{
+		"short_period":   decimal.NewFromInt(5),
+		"long_period":    decimal.NewFromInt(60),
+		"cooldown_ticks": decimal.NewFromInt(4),
+	}))
+	assert.Equal(t, 4, strategy.cooldown)
+	assert.Equal(t, 5, strategy.shortPeriod)
+	assert.Equal(t, 60, strategy.longPeriod)
+	assert.Equal(t, 9, strategy.signalPeriod)
+	assert.Equal(t, 60, strategy.RequiredHistory())
 
-	assert.True(t, sma.IsZero())
+	invalid := []map[string]decimal.Decimal{
+		{"short_period": decimal.NewFromInt(60)},
+		{"long_period": decimal.NewFromFloat(20.5)},
+		{"signal_period": decimal.NewFromInt(-1)},
+		{"lookback": decimal.NewFromInt(10)},
+	}
+	for _, params := range invalid {
+		assert.ErrorIs(t, strategy.SetParameters(params), ErrInvalidConfig, params)
+	}
+	assert.Equal(t, 5, strategy.shortPeriod)
 }
 
 func TestMovingAverageStrategy_CalculateOptimalQuantity(t *testing.T) {
@@ -104,12 +185,12 @@ func TestMovingAverageStrategy_CalculateOptimalQuantity(t *testing.T) {
 	portfolio := createTestPortfolio()
 	price := decimal.NewFromFloat(150.0)
 
-	quantity := strategy.calculateOptimalQuantity(price, portfolio)
+	quantity := strategy.calculateOptimalQuantity("AAPL", price, portfolio)
 
-	assert.Greater(t, quantity, int64(0))
+	assert.True(t, quantity.IsPositive())
 
-	maxQuantity := decimal.NewFromFloat(10000.0).Div(price).IntPart()
-	assert.LessOrEqual(t, quantity, maxQuantity)
+	maxQuantity := decimal.NewFromFloat(10000.0).Div(price).Floor()
+	assert.True(t, quantity.LessThanOrEqual(maxQuantity), quantity.String())
 }
 
 func TestMovingAverageStrategy_CalculateConfidence(t *testing.T) {
@@ -213,7 +294,7 @@ func TestMovingAverageStrategy_ValidateOrder(t *testing.T) {
 			order: &models.Order{
 				Symbol:   "AAPL",
 				Side:     models.OrderSideBuy,
-				Quantity: 10,
+				Quantity: decimal.NewFromInt(10),
 				Price:    decimal.NewFromFloat(150.0),
 			},
 			wantErr: nil,
@@ -223,7 +304,7 @@ func TestMovingAverageStrategy_ValidateOrder(t *testing.T) {
 			order: &models.Order{
 				Symbol:   "AAPL",
 				Side:     models.OrderSideBuy,
-				Quantity: 0,
+				Quantity: decimal.NewFromInt(0),
 				Price:    decimal.NewFromFloat(150.0),
 			},
 			wantErr: ErrInvalidQuantity,
@@ -233,7 +314,7 @@ func TestMovingAverageStrategy_ValidateOrder(t *testing.T) {
 			order: &models.Order{
 				Symbol:   "AAPL",
 				Side:     models.OrderSideBuy,
-				Quantity: 1,
+				Quantity: decimal.NewFromInt(1),
 				Price:    decimal.NewFromFloat(50.0),
 			},
 			wantErr: ErrOrderTooSmall,
@@ -243,7 +324,7 @@ func TestMovingAverageStrategy_ValidateOrder(t *testing.T) {
 			order: &models.Order{
 				Symbol:   "AAPL",
 				Side:     models.OrderSideBuy,
-				Quantity: 1000,
+				Quantity: decimal.NewFromInt(1000),
 				Price:    decimal.NewFromFloat(150.0),
 			},
 			wantErr: ErrOrderTooLarge,
@@ -258,6 +339,140 @@ func TestMovingAverageStrategy_ValidateOrder(t *testing.T) {
 	}
 }
 
+func TestMovingAverageStrategy_ValidateOrder_WorstCaseSlippage(t *testing.T) {
+	config := &models.StrategyConfig{
+		ID:                "test_ma",
+		Name:              "Test Moving Average",
+		Enabled:           true,
+		MinOrderSize:      decimal.NewFromFloat(100.0),
+		MaxOrderSize:      decimal.NewFromFloat(200000.0),
+		SlippageTolerance: decimal.NewFromFloat(0.01),
+	}
+
+	strategy := NewMovingAverageStrategy(config)
+	portfolio := createTestPortfolio()
+
+	order := &models.Order{
+		Symbol:   "AAPL",
+		Side:     models.OrderSideBuy,
+		Quantity: decimal.NewFromInt(1000),
+		Price:    decimal.NewFromFloat(100.0),
+	}
+
+	assert.Equal(t, ErrInsufficientFunds, strategy.ValidateOrder(order, portfolio))
+
+	order.Quantity = decimal.NewFromInt(990)
+	assert.NoError(t, strategy.ValidateOrder(order, portfolio))
+}
+
+func TestMovingAverageStrategy_ValidateOrder_LotSize(t *testing.T) {
+	config := &models.StrategyConfig{
+		ID:           "test_ma",
+		Name:         "Test Moving Average",
+		Enabled:      true,
+		MinOrderSize: decimal.NewFromFloat(10.0),
+		MaxOrderSize: decimal.NewFromFloat(50000.0),
+	}
+
+	registry := symbols.NewRegistry()
+	require.NoError(t, registry.Register(models.Instrument{Symbol: "BTCUSDT", LotSize: decimal.NewFromFloat(0.001)}))
+	strategy := NewMovingAverageStrategy(config)
+	strategy.SetSymbols(registry)
+	portfolio := createTestPortfolio()
+
+	order := &models.Order{
+		Symbol:   "BTCUSDT",
+		Side:     models.OrderSideBuy,
+		Quantity: decimal.RequireFromString("0.125"),
+		Price:    decimal.NewFromFloat(40000.0),
+	}
+	assert.NoError(t, strategy.ValidateOrder(order, portfolio))
+
+	order.Quantity = decimal.RequireFromString("0.1255")
+	err := strategy.ValidateOrder(order, portfolio)
+	assert.ErrorIs(t, err, ErrInvalidQuantity)
+	assert.ErrorIs(t, err, symbols.ErrNotLotMultiple)
+
+	order.Quantity = decimal.RequireFromString("0.0005")
+	err = strategy.ValidateOrder(order, portfolio)
+	assert.ErrorIs(t, err, ErrInvalidQuantity)
+	assert.ErrorIs(t, err, symbols.ErrBelowLotSize)
+}
+
+func TestMovingAverageStrategy_ValidateOrder_BuyingPower(t *testing.T) {
+	config := &models.StrategyConfig{
+		ID:           "test_ma",
+		Name:         "Test Moving Average",
+		Enabled:      true,
+		MinOrderSize: decimal.NewFromFloat(100.0),
+		MaxOrderSize: decimal.NewFromFloat(500000.0),
+	}
+
+	strategy := NewMovingAverageStrategy(config)
+	portfolio := createTestPortfolio()
+
+	buy := &models.Order{
+		Symbol:   "AAPL",
+		Side:     models.OrderSideBuy,
+		Quantity: decimal.NewFromInt(1500),
+		Price:    decimal.NewFromFloat(100.0),
+	}
+	assert.Equal(t, ErrInsufficientFunds, strategy.ValidateOrder(buy, portfolio))
+
+	portfolio.Margin = &models.MarginAccount{BuyingPower: decimal.NewFromFloat(200000.0)}
+	assert.NoError(t, strategy.ValidateOrder(buy, portfolio))
+
+	buy.Quantity = decimal.NewFromInt(2500)
+	assert.Equal(t, ErrInsufficientFunds, strategy.ValidateOrder(buy, portfolio))
+
+	config.AllowShort = true
+	short := &models.Order{
+		Symbol:   "AAPL",
+		Side:     models.OrderSideSell,
+		Quantity: decimal.NewFromInt(2500),
+		Price:    decimal.NewFromFloat(100.0),
+	}
+	assert.Equal(t, ErrInsufficientFunds, strategy.ValidateOrder(short, portfolio))
+
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(1000)}
+	assert.NoError(t, strategy.ValidateOrder(short, portfolio))
+
+	portfolio.Margin.BuyingPower = decimal.Zero
+	portfolio.Positions["AAPL"].Quantity = decimal.NewFromInt(-1000)
+	cover := &models.Order{
+		Symbol:   "AAPL",
+		Side:     models.OrderSideBuy,
+		Quantity: decimal.NewFromInt(1000),
+		Price:    decimal.NewFromFloat(100.0),
+	}
+	assert.NoError(t, strategy.ValidateOrder(cover, portfolio))
+}
+
+func TestMovingAverageStrategy_ValidateOrder_AllowShort(t *testing.T) {
+	config := &models.StrategyConfig{
+		ID:           "test_ma",
+		Name:         "Test Moving Average",
+		Enabled:      true,
+		MinOrderSize: decimal.NewFromFloat(100.0),
+		MaxOrderSize: decimal.NewFromFloat(10000.0),
+	}
+
+	strategy := NewMovingAverageStrategy(config)
+	portfolio := createTestPortfolio()
+
+	order := &models.Order{
+		Symbol:   "AAPL",
+		Side:     models.OrderSideSell,
+		Quantity: decimal.NewFromInt(10),
+		Price:    decimal.NewFromFloat(150.0),
+	}
+
+	assert.Equal(t, ErrInsufficientPosition, strategy.ValidateOrder(order, portfolio))
+
+	config.AllowShort = true
+	assert.NoError(t, strategy.ValidateOrder(order, portfolio))
+}
+
 func createTestPortfolio() *models.Portfolio {
 	return &models.Portfolio{
 		ID:             "test_portfolio",
@@ -342,7 +557,7 @@ func TestMovingAverageStrategy_CalculateRisk(t *testing.T) {
 
 	order := &models.Order{
 		Symbol:   "AAPL",
-		Quantity: 100,
+		Quantity: decimal.NewFromInt(90),
 		Price:    decimal.NewFromFloat(155.0),
 	}
 
@@ -354,3 +569,284 @@ func TestMovingAverageStrategy_CalculateRisk(t *testing.T) {
 	assert.True(t, riskMetrics.ExpectedShortfall.GreaterThanOrEqual(decimal.Zero))
 	assert.True(t, riskMetrics.Volatility.GreaterThanOrEqual(decimal.Zero))
 }
+
+func TestBaseStrategy_CalculateRisk_ReducingOrderSkipsLimits(t *testing.T) {
+	config := &models.StrategyConfig{
+		ID:               "test_ma",
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+	}
+	strategy := NewBaseStrategy(config)
+	portfolio := createTestPortfolio()
+	portfolio.TotalValue = decimal.NewFromFloat(100000.0)
+	portfolio.TotalRisk = decimal.NewFromFloat(0.3)
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(200)}
+
+	sell := &models.Order{Symbol: "AAPL", Side: models.OrderSideSell, Quantity: decimal.NewFromInt(200), Price: decimal.NewFromFloat(150.0)}
+	buy := &models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: decimal.NewFromInt(10), Price: decimal.NewFromFloat(150.0)}
+	oversell := &models.Order{Symbol: "AAPL", Side: models.OrderSideSell, Quantity: decimal.NewFromInt(201), Price: decimal.NewFromFloat(150.0)}
+
+	_, err := strategy.CalculateRisk(sell, portfolio)
+	assert.NoError(t, err)
+	_, err = strategy.CalculateRisk(buy, portfolio)
+	assert.Equal(t, ErrPortfolioRiskExceeded, err)
+	_, err = strategy.CalculateRisk(oversell, portfolio)
+	assert.Equal(t, ErrPositionTooLarge, err)
+}
+
+func TestBaseStrategy_CalculateBeta(t *testing.T) {
+	strategy := NewBaseStrategy(&models.StrategyConfig{})
+	start := time.Now().Add(-time.Hour)
+	history := testMarketHistory{}
+	spy, aapl := 400.0, 150.0
+	for i := 0; i < 21; i++ {
+		timestamp := start.Add(time.Duration(i) * time.Minute)
+		history["SPY"] = append(history["SPY"], &models.MarketData{Symbol: "SPY", Price: decimal.NewFromFloat(spy), Timestamp: timestamp})
+		history["AAPL"] = append(history["AAPL"], &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(aapl), Timestamp: timestamp})
+		change := 0.01
+		if i%2 == 1 {
+			change = -0.01
+		}
+		spy *= 1 + change
+		aapl *= 1 + 2*change
+	}
+
+	assert.True(t, decimal.NewFromFloat(1.0).Equal(strategy.calculateBeta("AAPL", nil)))
+
+	strategy.SetMarketHistory(history)
+	assert.True(t, decimal.NewFromFloat(1.0).Equal(strategy.calculateBeta("AAPL", nil)))
+
+	strategy.SetBenchmark("SPY", 60)
+	assert.InDelta(t, 2.0, strategy.calculateBeta("AAPL", nil).InexactFloat64(), 1e-6)
+	assert.True(t, decimal.NewFromFloat(1.0).Equal(strategy.calculateBeta("SPY", nil)))
+	assert.True(t, decimal.NewFromFloat(1.0).Equal(strategy.calculateBeta("MSFT", nil)))
+
+	strategy.SetBenchmark("SPY", 5)
+	assert.True(t, decimal.NewFromFloat(1.0).Equal(strategy.calculateBeta("AAPL", nil)))
+}
+
+func TestBaseStrategy_CalculateRisk_SharpeFromReturns(t *testing.T) {
+	config := &models.StrategyConfig{
+		MaxPositionSize:  decimal.NewFromFloat(1.0),
+		MaxPortfolioRisk: decimal.NewFromFloat(1.0),
+		RiskFreeRate:     decimal.NewFromFloat(0.02),
+	}
+	strategy := NewBaseStrategy(config)
+	start := time.Now().Add(-time.Hour)
+	history := testMarketHistory{}
+	price := 100.0
+	for i := 0; i < 21; i++ {
+		history["AAPL"] = append(history["AAPL"], &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(price), Timestamp: start.Add(time.Duration(i) * time.Minute)})
+		if i%2 == 0 {
+			price *= 1.02
+		}
+	}
+	strategy.SetMarketHistory(history)
+	order := &models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: decimal.NewFromInt(100), Price: decimal.NewFromFloat(150.0)}
+
+	metrics, err := strategy.CalculateRisk(order, createTestPortfolio())
+
+	require.NoError(t, err)
+	assert.InDelta(t, 0.01, metrics.Volatility.InexactFloat64(), 1e-9)
+	assert.InDelta(t, (0.01-0.02/252)/0.01*math.Sqrt(252), metrics.SharpeRatio.InexactFloat64(), 1e-6)
+}
+
+func TestBaseStrategy_CalculateRisk_VaRMethod(t *testing.T) {
+	config := &models.StrategyConfig{
+		MaxPositionSize:  decimal.NewFromFloat(1.0),
+		MaxPortfolioRisk: decimal.NewFromFloat(1.0),
+	}
+	strategy := NewBaseStrategy(config)
+	start := time.Now().Add(-time.Hour)
+	history := testMarketHistory{}
+	price := 100.0
+	for i := 0; i < 21; i++ {
+		history["AAPL"] = append(history["AAPL"], &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(price), Timestamp: start.Add(time.Duration(i) * time.Minute)})
+		if i%2 == 0 {
+			price *= 1.02
+		}
+	}
+	strategy.SetMarketHistory(history)
+	buy := &models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: decimal.NewFromInt(100), Price: decimal.NewFromFloat(150.0)}
+	sell := &models.Order{Symbol: "AAPL", Side: models.OrderSideSell, Quantity: decimal.NewFromInt(100), Price: decimal.NewFromFloat(150.0)}
+
+	metrics, err := strategy.CalculateRisk(buy, createTestPortfolio())
+	require.NoError(t, err)
+	assert.InDelta(t, 15000*0.01*1.645, metrics.VaR95.InexactFloat64(), 1e-6)
+
+	config.VaRMethod = risk.VaRHistorical
+	metrics, err = strategy.CalculateRisk(buy, createTestPortfolio())
+	require.NoError(t, err)
+	assert.InDelta(t, 0, metrics.VaR95.InexactFloat64(), 1e-6)
+
+	metrics, err = strategy.CalculateRisk(sell, createTestPortfolio())
+	require.NoError(t, err)
+	assert.InDelta(t, 300, metrics.VaR95.InexactFloat64(), 1e-6)
+
+	config.VaRHoldingPeriod = 4
+	metrics, err = strategy.CalculateRisk(sell, createTestPortfolio())
+	require.NoError(t, err)
+	assert.InDelta(t, 600, metrics.VaR95.InexactFloat64(), 1e-6)
+}
+
+func TestBaseStrategy_CalculateVolatility_IgnoresOtherSymbols(t *testing.T) {
+	strategy := NewBaseStrategy(&models.StrategyConfig{})
+	portfolio := createTestPortfolio()
+	portfolio.TradeHistory = []*models.Trade{
+		{Symbol: "AAPL", Price: decimal.NewFromFloat(100.0)},
+		{Symbol: "GOOGL", Price: decimal.NewFromFloat(2800.0)},
+		{Symbol: "AAPL", Price: decimal.NewFromFloat(110.0)},
+		{Symbol: "AAPL", Price: decimal.NewFromFloat(99.0)},
+	}
+
+	returns := strategy.returnSeries("AAPL", portfolio)
+
+	require.Len(t, returns, 2)
+	assert.InDelta(t, 0.1, strategy.calculateVolatility(returns).InexactFloat64(), 1e-9)
+}
+
+type testMarketHistory map[string][]*models.MarketData
+
+func (h testMarketHistory) GetMarketDataHistory(symbol string, n int) []*models.MarketData {
+	history := h[symbol]
+	if n > 0 && n < len(history) {
+		return history[len(history)-n:]
+	}
+	return history
+}
+
+type testMarket struct {
+	snapshot *models.MarketSnapshot
+	clock    time.Time
+}
+
+func newTestMarket() *testMarket {
+	return &testMarket{
+		snapshot: &models.MarketSnapshot{
+			Latest:  make(map[string]*models.MarketData),
+			History: make(map[string][]*models.MarketData),
+		},
+		clock: time.Now().Add(-time.Hour),
+	}
+}
+
+func (m *testMarket) push(symbol string, prices ...float64) *models.MarketSnapshot {
+	for _, price := range prices {
+		m.clock = m.clock.Add(time.Minute)
+		data := &models.MarketData{Symbol: symbol, Price: decimal.NewFromFloat(price), Timestamp: m.clock}
+		m.snapshot.History[symbol] = append(m.snapshot.History[symbol], data)
+		m.snapshot.Latest[symbol] = data
+	}
+	return m.snapshot
+}
+
+func linearPrices(start, step float64, count int) []float64 {
+	prices := make([]float64, count)
+	for i := range prices {
+		prices[i] = start + step*float64(i)
+	}
+	return prices
+}
+
+func TestMovingAverageStrategy_ExecuteAll_EmitsEverySignal(t *testing.T) {
+	config := &models.StrategyConfig{
+		ID:               "test_ma",
+		Name:             "Test Moving Average",
+		Enabled:          true,
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.5),
+		MinOrderSize:     decimal.NewFromFloat(100.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+	}
+	strategy := NewMovingAverageStrategy(config)
+	market := newTestMarket()
+	market.push("AAPL", linearPrices(150.0, 1.0, 31)...)
+	market.push("MSFT", linearPrices(300.0, 6.0, 31)...)
+	market.push("GOOGL", linearPrices(2800.0, 0.0, 31)...)
+
+	signals, err := strategy.ExecuteAll(context.Background(), createTestPortfolio(), market.snapshot)
+
+	require.NoError(t, err)
+	require.Len(t, signals, 2)
+	assert.Equal(t, "MSFT", signals[0].Symbol)
+	assert.Equal(t, "AAPL", signals[1].Symbol)
+	assert.True(t, signals[0].Confidence.GreaterThan(signals[1].Confidence))
+
+	best, err := strategy.Execute(context.Background(), createTestPortfolio(), market.snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, "MSFT", best.Symbol)
+}
+
+func syntheticSeries(count int) []*models.MarketData {
+	start := time.Now().Add(-time.Duration(count) * time.Minute)
+	series := make([]*models.MarketData, count)
+	for i := range series {
+		price := 100 + 10*math.Sin(float64(i)/15) + 3*math.Sin(float64(i)/4) + float64(i%7)/10
+		series[i] = &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromFloat(price).Round(2), Timestamp: start.Add(time.Duration(i) * time.Minute)}
+	}
+	return series
+}
+
+func TestMovingAverageStrategy_RollingAveragesMatchFullScan(t *testing.T) {
+	strategy := NewMovingAverageStrategy(&models.StrategyConfig{ID: "test_ma", Enabled: true})
+	strategy.SetPeriods(5, 20, 9)
+	series := syntheticSeries(2000)
+	window := 60
+
+	for i := 1; i <= len(series); i++ {
+		if i == 1200 {
+			for j, data := range series[:i] {
+				adjusted := *data
+				adjusted.Price = data.Price.Div(decimal.NewFromInt(2))
+				series[j] = &adjusted
+			}
+		}
+		history := series[max(i-window, 0):i]
+		prices := make([]decimal.Decimal, len(history))
+		for j, data := range history {
+			prices[j] = data.Price
+		}
+
+		sma := func(period int) decimal.Decimal {
+			value, _ := indicators.SMA(prices, period)
+			return value
+		}
+
+		shortMA, longMA, signalMA := strategy.movingAverages("AAPL", history)
+		assert.True(t, sma(5).Equal(shortMA), "tick %d short", i)
+		assert.True(t, sma(20).Equal(longMA), "tick %d long", i)
+		assert.True(t, sma(9).Equal(signalMA), "tick %d signal", i)
+	}
+}
+
+func BenchmarkMovingAverageStrategy_ExecuteOnTick(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("history=%d", size), func(b *testing.B) {
+			strategy := NewMovingAverageStrategy(&models.StrategyConfig{
+				ID:               "test_ma",
+				Enabled:          true,
+				MaxPositionSize:  decimal.NewFromFloat(0.2),
+				MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+				MinOrderSize:     decimal.NewFromFloat(100.0),
+				MaxOrderSize:     decimal.NewFromFloat(10000.0),
+				MarketDataWindow: size,
+			})
+			portfolio := createTestPortfolio()
+			series := syntheticSeries(size + b.N)
+			market := &models.MarketSnapshot{
+				Latest:  make(map[string]*models.MarketData),
+				History: make(map[string][]*models.MarketData),
+			}
+			ctx := context.Background()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				market.History["AAPL"] = series[i+1 : i+size+1]
+				market.Latest["AAPL"] = series[i+size]
+				if _, err := strategy.ExecuteOnTick(ctx, "AAPL", portfolio, market); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package strategies
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type Tunable interface {
+	Parameters() []string
+	SetParameters(params map[string]decimal.Decimal) error
+}
+
+func checkParameters(params map[string]decimal.Decimal, known ...string) error {
+	var unknown []string
+	for name := range params {
+		if !slices.Contains(known, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: unknown parameters %s (expected %s)", ErrInvalidConfig, strings.Join(unknown, ", "), strings.Join(known, ", "))
+	}
+	return nil
+}
+
+func intParameter(params map[string]decimal.Decimal, name string, current int) (int, error) {
+	value, exists := params[name]
+	if !exists {
+		return current, nil
+	}
+	if !value.IsInteger() || !value.IsPositive() {
+		return 0, fmt.Errorf("%w: %s must be a positive integer, got %s", ErrInvalidConfig, name, value)
+	}
+	return int(value.IntPart()), nil
+}
+
+func decimalParameter(params map[string]decimal.Decimal, name string, current decimal.Decimal) decimal.Decimal {
+	if value, exists := params[name]; exists {
+		return value
+	}
+	return current
+}
@@ -0,0 +1,153 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type RSIStrategy struct {
+	*BaseStrategy
+	period              int
+	oversoldThreshold   decimal.Decimal
+	overboughtThreshold decimal.Decimal
+}
+
+func NewRSIStrategy(config *models.StrategyConfig) *RSIStrategy {
+	strategy := &RSIStrategy{
+		BaseStrategy:        NewBaseStrategy(config),
+		period:              14,
+		oversoldThreshold:   decimal.NewFromInt(30),
+		overboughtThreshold: decimal.NewFromInt(70),
+	}
+	strategy.requireHistory(strategy.period + 1)
+	return strategy
+}
+
+func (s *RSIStrategy) SetPeriod(period int) {
+	s.period = period
+	s.requireHistory(period + 1)
+}
+
+func (s *RSIStrategy) SetThresholds(oversold, overbought decimal.Decimal) {
+	s.oversoldThreshold = oversold
+	s.overboughtThreshold = overbought
+}
+
+func (s *RSIStrategy) Parameters() []string {
+	return []string{"period", "oversold", "overbought"}
+}
+
+func (s *RSIStrategy) SetParameters(params map[string]decimal.Decimal) error {
+	if err := checkParameters(params, s.Parameters()...); err != nil {
+		return err
+	}
+	period, err := intParameter(params, "period", s.period)
+	if err != nil {
+		return err
+	}
+	oversold := decimalParameter(params, "oversold", s.oversoldThreshold)
+	overbought := decimalParameter(params, "overbought", s.overboughtThreshold)
+	if !oversold.IsPositive() || oversold.GreaterThanOrEqual(overbought) || overbought.GreaterThanOrEqual(decimal.NewFromInt(100)) {
+		return fmt.Errorf("%w: need 0 < oversold < overbought < 100", ErrInvalidConfig)
+	}
+	s.SetPeriod(period)
+	s.SetThresholds(oversold, overbought)
+	return nil
+}
+
+func (s *RSIStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	var bestSignal *models.AlgorithmResult
+	maxConfidence := decimal.Zero
+
+	for symbol, data := range market.Latest {
+		signal, confidence, err := s.analyzeSymbol(symbol, data, market.Prices(symbol), portfolio)
+		if err != nil {
+			continue
+		}
+
+		if confidence.GreaterThan(maxConfidence) {
+			maxConfidence = confidence
+			bestSignal = signal
+		}
+	}
+
+	return bestSignal, nil
+}
+
+func (s *RSIStrategy) ExecuteOnTick(ctx context.Context, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	data, exists := market.Latest[symbol]
+	if !exists {
+		return nil, nil
+	}
+
+	signal, _, err := s.analyzeSymbol(symbol, data, market.Prices(symbol), portfolio)
+	if err != nil {
+		return nil, nil
+	}
+	return signal, nil
+}
+
+func (s *RSIStrategy) analyzeSymbol(symbol string, marketData *models.MarketData, prices []decimal.Decimal, portfolio *models.Portfolio) (*models.AlgorithmResult, decimal.Decimal, error) {
+	rsi, ok := indicators.RSI(prices, s.period)
+	if !ok {
+		return nil, decimal.Zero, ErrInvalidMarketData
+	}
+
+	currentPrice := marketData.Price
+	position, hasPosition := portfolio.Positions[symbol]
+
+	var action models.Action
+	var signal string
+	var quantity decimal.Decimal
+	var confidence decimal.Decimal
+
+	if rsi.LessThan(s.oversoldThreshold) {
+		if !hasPosition || !position.Quantity.IsPositive() {
+			action = models.ActionBuy
+			signal = "oversold_buy"
+			quantity = s.calculateOptimalQuantity(symbol, currentPrice, portfolio)
+			confidence = s.oversoldThreshold.Sub(rsi).Div(s.oversoldThreshold)
+		}
+	} else if rsi.GreaterThan(s.overboughtThreshold) {
+		if hasPosition && position.Quantity.IsPositive() {
+			action = models.ActionSell
+			signal = "overbought_sell"
+			quantity = position.Quantity
+			confidence = rsi.Sub(s.overboughtThreshold).Div(decimal.NewFromInt(100).Sub(s.overboughtThreshold))
+		}
+	}
+
+	if action == "" || !quantity.IsPositive() {
+		return nil, decimal.Zero, nil
+	}
+
+	riskMetrics, err := s.calculatePositionRisk(symbol, action, quantity, currentPrice, portfolio)
+	if err != nil {
+		return nil, decimal.Zero, err
+	}
+
+	return &models.AlgorithmResult{
+		StrategyID:     s.ID(),
+		Symbol:         symbol,
+		Action:         action,
+		Quantity:       quantity,
+		Price:          currentPrice,
+		Confidence:     confidence,
+		Signal:         signal,
+		Timestamp:      marketData.Timestamp,
+		RiskScore:      s.calculateRiskScore(riskMetrics),
+		ExpectedReturn: decimal.NewFromInt(50).Sub(rsi).Div(decimal.NewFromInt(100)),
+	}, confidence, nil
+}
@@ -0,0 +1,126 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRSIStrategy(t *testing.T) {
+	config := &models.StrategyConfig{
+		ID:   "test_rsi",
+		Name: "Test RSI",
+	}
+
+	strategy := NewRSIStrategy(config)
+
+	assert.NotNil(t, strategy)
+	assert.Equal(t, "test_rsi", strategy.ID())
+	assert.Equal(t, 14, strategy.period)
+	assert.True(t, decimal.NewFromInt(30).Equal(strategy.oversoldThreshold))
+	assert.True(t, decimal.NewFromInt(70).Equal(strategy.overboughtThreshold))
+}
+
+func TestRSIStrategy_SetParameters(t *testing.T) {
+	strategy := NewRSIStrategy(&models.StrategyConfig{})
+
+	require.NoError(t, strategy.SetParameters(map[string]decimal.Decimal{
+		"period":   decimal.NewFromInt(7),
+		"oversold": decimal.NewFromInt(20),
+	}))
+	assert.Equal(t, 7, strategy.period)
+	assert.True(t, decimal.NewFromInt(20).Equal(strategy.oversoldThreshold))
+	assert.True(t, decimal.NewFromInt(70).Equal(strategy.overboughtThreshold))
+
+	err := strategy.SetParameters(map[string]decimal.Decimal{"oversold": decimal.NewFromInt(80)})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestRSIStrategy_Execute_Disabled(t *testing.T) {
+	strategy := NewRSIStrategy(&models.StrategyConfig{ID: "test_rsi", Enabled: false})
+
+	result, err := strategy.Execute(context.Background(), createTestPortfolio(), &models.MarketSnapshot{Latest: createTestMarketData()})
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrStrategyDisabled, err)
+}
+
+func TestRSIStrategy_Execute_OversoldBuy(t *testing.T) {
+	strategy := NewRSIStrategy(createTestRSIConfig())
+	market := newTestMarket().push("AAPL", append(linearPrices(115, -1, 15), 100.0)...)
+	portfolio := createTestPortfolio()
+
+	result, err := strategy.Execute(context.Background(), portfolio, market)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, models.ActionBuy, result.Action)
+	assert.Equal(t, "oversold_buy", result.Signal)
+	assert.True(t, decimal.NewFromInt(100).Equal(result.Quantity))
+	assert.True(t, decimal.NewFromInt(1).Equal(result.Confidence))
+}
+
+func TestRSIStrategy_Execute_OverboughtSell(t *testing.T) {
+	strategy := NewRSIStrategy(createTestRSIConfig())
+	market := newTestMarket().push("AAPL", append(linearPrices(85, 1, 15), 100.0)...)
+	portfolio := createTestPortfolio()
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(50)}
+
+	result, err := strategy.Execute(context.Background(), portfolio, market)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, models.ActionSell, result.Action)
+	assert.Equal(t, "overbought_sell", result.Signal)
+	assert.True(t, decimal.NewFromInt(50).Equal(result.Quantity))
+}
+
+func TestRSIStrategy_Execute_NeutralNoSignal(t *testing.T) {
+	strategy := NewRSIStrategy(createTestRSIConfig())
+	strategy.SetThresholds(decimal.NewFromInt(0), decimal.NewFromInt(100))
+	market := newTestMarket().push("AAPL", append(linearPrices(115, -1, 15), 100.0)...)
+	portfolio := createTestPortfolio()
+
+	result, err := strategy.Execute(context.Background(), portfolio, market)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestRSIStrategy_ExecuteOnTick_OnlyTriggeringSymbol(t *testing.T) {
+	strategy := NewRSIStrategy(createTestRSIConfig())
+	market := newTestMarket()
+	market.push("AAPL", append(linearPrices(115, -1, 15), 100.0)...)
+	snapshot := market.push("MSFT", linearPrices(100, 0.1, 16)...)
+	portfolio := createTestPortfolio()
+
+	result, err := strategy.ExecuteOnTick(context.Background(), "AAPL", portfolio, snapshot)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, "AAPL", result.Symbol)
+	assert.Equal(t, "oversold_buy", result.Signal)
+
+	result, err = strategy.ExecuteOnTick(context.Background(), "MSFT", portfolio, snapshot)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	result, err = strategy.ExecuteOnTick(context.Background(), "TSLA", portfolio, snapshot)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func createTestRSIConfig() *models.StrategyConfig {
+	return &models.StrategyConfig{
+		ID:               "test_rsi",
+		Name:             "Test RSI",
+		Enabled:          true,
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+		MinOrderSize:     decimal.NewFromFloat(100.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+	}
+}
@@ -0,0 +1,170 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type SentimentStrategy struct {
+	*BaseStrategy
+	threshold decimal.Decimal
+	holdTicks int
+	mu        sync.Mutex
+	pending   map[string]models.NewsEvent
+	entries   map[string]time.Time
+}
+
+func NewSentimentStrategy(config *models.StrategyConfig) *SentimentStrategy {
+	strategy := &SentimentStrategy{
+		BaseStrategy: NewBaseStrategy(config),
+		threshold:    decimal.NewFromFloat(0.6),
+		holdTicks:    10,
+		pending:      make(map[string]models.NewsEvent),
+		entries:      make(map[string]time.Time),
+	}
+	strategy.requireHistory(strategy.holdTicks + 1)
+	return strategy
+}
+
+func (s *SentimentStrategy) SetThreshold(threshold decimal.Decimal) {
+	s.threshold = threshold
+}
+
+func (s *SentimentStrategy) SetHoldTicks(ticks int) {
+	s.holdTicks = ticks
+	s.requireHistory(ticks + 1)
+}
+
+func (s *SentimentStrategy) Parameters() []string {
+	return []string{"sentiment_threshold", "hold_ticks"}
+}
+
+func (s *SentimentStrategy) SetParameters(params map[string]decimal.Decimal) error {
+	if err := checkParameters(params, s.Parameters()...); err != nil {
+		return err
+	}
+	threshold := decimalParameter(params, "sentiment_threshold", s.threshold)
+	if !threshold.IsPositive() || threshold.GreaterThan(decimal.NewFromInt(1)) {
+		return fmt.Errorf("%w: sentiment_threshold must be in (0, 1]", ErrInvalidConfig)
+	}
+	ticks, err := intParameter(params, "hold_ticks", s.holdTicks)
+	if err != nil {
+		return err
+	}
+	s.SetThreshold(threshold)
+	s.SetHoldTicks(ticks)
+	return nil
+}
+
+func (s *SentimentStrategy) OnNews(event models.NewsEvent) {
+	if event.Sentiment.LessThan(s.threshold) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[event.Symbol] = event
+}
+
+func (s *SentimentStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	symbols := make([]string, 0, len(market.Latest))
+	for symbol := range market.Latest {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entry *models.AlgorithmResult
+	for _, symbol := range symbols {
+		result := s.analyzeSymbol(symbol, market, portfolio)
+		if result != nil && result.Action == models.ActionSell {
+			return result, nil
+		}
+		if entry == nil {
+			entry = result
+		}
+	}
+	return entry, nil
+}
+
+func (s *SentimentStrategy) ExecuteOnTick(ctx context.Context, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.analyzeSymbol(symbol, market, portfolio), nil
+}
+
+func (s *SentimentStrategy) analyzeSymbol(symbol string, market *models.MarketSnapshot, portfolio *models.Portfolio) *models.AlgorithmResult {
+	latest, exists := market.Latest[symbol]
+	if !exists {
+		return nil
+	}
+
+	held := decimal.Zero
+	if position, exists := portfolio.Positions[symbol]; exists {
+		held = position.Quantity
+	}
+
+	if entered, exists := s.entries[symbol]; exists {
+		elapsed := ticksSince(market.History[symbol], entered)
+		if held.IsPositive() && elapsed >= s.holdTicks {
+			delete(s.entries, symbol)
+			return s.buildResult(symbol, latest.Timestamp, models.ActionSell, "sentiment_exit", held, latest.Price, decimal.NewFromInt(1))
+		}
+		if held.IsPositive() || elapsed < s.holdTicks {
+			return nil
+		}
+		delete(s.entries, symbol)
+	}
+
+	event, exists := s.pending[symbol]
+	if !exists {
+		return nil
+	}
+	delete(s.pending, symbol)
+	if !held.IsZero() || ticksSince(market.History[symbol], event.Timestamp) >= s.holdTicks {
+		return nil
+	}
+
+	quantity := s.calculateOptimalQuantity(symbol, latest.Price, portfolio)
+	if !quantity.IsPositive() {
+		return nil
+	}
+	s.entries[symbol] = event.Timestamp
+	return s.buildResult(symbol, latest.Timestamp, models.ActionBuy, "sentiment_entry", quantity, latest.Price, event.Sentiment)
+}
+
+func (s *SentimentStrategy) buildResult(symbol string, timestamp time.Time, action models.Action, signal string, quantity, price, confidence decimal.Decimal) *models.AlgorithmResult {
+	return &models.AlgorithmResult{
+		StrategyID: s.ID(),
+		Symbol:     symbol,
+		Action:     action,
+		Quantity:   quantity,
+		Price:      price,
+		Confidence: confidence,
+		Signal:     signal,
+		Timestamp:  timestamp,
+	}
+}
+
+func ticksSince(history []*models.MarketData, since time.Time) int {
+	ticks := 0
+	for i := len(history) - 1; i >= 0 && history[i].Timestamp.After(since); i-- {
+		ticks++
+	}
+	return ticks
+}
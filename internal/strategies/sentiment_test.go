@@ -0,0 +1,125 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sentimentStart = time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+
+type sentimentRun struct {
+	strategy  *SentimentStrategy
+	portfolio *models.Portfolio
+	market    *models.MarketSnapshot
+	ticks     int
+}
+
+func createSentimentRun() *sentimentRun {
+	strategy := NewSentimentStrategy(&models.StrategyConfig{
+		ID:               "test_sentiment",
+		Name:             "Test Sentiment",
+		Enabled:          true,
+		MaxPositionSize:  decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk: decimal.NewFromFloat(0.15),
+		MinOrderSize:     decimal.NewFromFloat(100.0),
+		MaxOrderSize:     decimal.NewFromFloat(10000.0),
+	})
+	strategy.SetHoldTicks(3)
+	return &sentimentRun{
+		strategy:  strategy,
+		portfolio: createTestPortfolio(),
+		market:    &models.MarketSnapshot{Latest: map[string]*models.MarketData{}, History: map[string][]*models.MarketData{}},
+	}
+}
+
+func (r *sentimentRun) now() time.Time {
+	return sentimentStart.Add(time.Duration(r.ticks) * time.Second)
+}
+
+func (r *sentimentRun) tick(t *testing.T) *models.AlgorithmResult {
+	r.ticks++
+	data := &models.MarketData{Symbol: "AAPL", Price: decimal.NewFromInt(100), Timestamp: r.now()}
+	r.market.Latest["AAPL"] = data
+	r.market.History["AAPL"] = append(r.market.History["AAPL"], data)
+
+	result, err := r.strategy.ExecuteOnTick(context.Background(), "AAPL", r.portfolio, r.market)
+	require.NoError(t, err)
+	return result
+}
+
+func (r *sentimentRun) news(sentiment float64) {
+	r.strategy.OnNews(models.NewsEvent{
+		Symbol:    "AAPL",
+		Headline:  "AAPL beats earnings estimates",
+		Sentiment: decimal.NewFromFloat(sentiment),
+		Severity:  models.NewsSeverityHigh,
+		Timestamp: r.now(),
+	})
+}
+
+func (r *sentimentRun) fill(quantity decimal.Decimal) {
+	r.portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: quantity, AveragePrice: decimal.NewFromInt(100)}
+}
+
+func TestSentimentStrategy_BuysOnPositiveNewsAndExitsAfterHold(t *testing.T) {
+	run := createSentimentRun()
+	assert.Nil(t, run.tick(t))
+
+	run.news(0.8)
+	entry := run.tick(t)
+	require.NotNil(t, entry)
+	assert.Equal(t, models.ActionBuy, entry.Action)
+	assert.Equal(t, "sentiment_entry", entry.Signal)
+	assert.True(t, entry.Confidence.Equal(decimal.NewFromFloat(0.8)))
+	assert.True(t, entry.Quantity.IsPositive())
+
+	run.fill(entry.Quantity)
+	assert.Nil(t, run.tick(t))
+
+	exit := run.tick(t)
+	require.NotNil(t, exit)
+	assert.Equal(t, models.ActionSell, exit.Action)
+	assert.Equal(t, "sentiment_exit", exit.Signal)
+	assert.True(t, exit.Quantity.Equal(entry.Quantity))
+
+	delete(run.portfolio.Positions, "AAPL")
+	assert.Nil(t, run.tick(t))
+}
+
+func TestSentimentStrategy_IgnoresWeakAndNegativeNews(t *testing.T) {
+	run := createSentimentRun()
+
+	run.news(0.3)
+	assert.Nil(t, run.tick(t))
+	run.news(-0.9)
+	assert.Nil(t, run.tick(t))
+}
+
+func TestSentimentStrategy_WaitsForEntryFill(t *testing.T) {
+	run := createSentimentRun()
+
+	run.news(0.9)
+	entry := run.tick(t)
+	require.NotNil(t, entry)
+
+	assert.Nil(t, run.tick(t))
+	run.fill(entry.Quantity)
+
+	exit := run.tick(t)
+	require.NotNil(t, exit)
+	assert.Equal(t, models.ActionSell, exit.Action)
+}
+
+func TestSentimentStrategy_SetParameters(t *testing.T) {
+	strategy := NewSentimentStrategy(&models.StrategyConfig{ID: "s1"})
+
+	require.NoError(t, strategy.SetParameters(map[string]decimal.Decimal{"hold_ticks": decimal.NewFromInt(20), "sentiment_threshold": decimal.NewFromFloat(0.5)}))
+	assert.Equal(t, 21, strategy.RequiredHistory())
+	assert.ErrorIs(t, strategy.SetParameters(map[string]decimal.Decimal{"sentiment_threshold": decimal.NewFromFloat(1.5)}), ErrInvalidConfig)
+}
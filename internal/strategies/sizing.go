@@ -0,0 +1,144 @@
+package strategies
+
+import (
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/analytics"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	SizerCash          = "cash"
+	SizerFixedFraction = "fixed_fraction"
+	SizerKelly         = "kelly"
+
+	cashSizerFraction     = 0.95
+	defaultSizingFraction = 0.1
+	defaultKellyFraction  = 0.5
+	minKellyRoundTrips    = 20
+)
+
+type PositionSizer interface {
+	Size(signal *models.AlgorithmResult, portfolio *models.Portfolio, history []*models.Trade) decimal.Decimal
+}
+
+type CashSizer struct {
+	Fraction decimal.Decimal
+}
+
+func (z CashSizer) Size(signal *models.AlgorithmResult, portfolio *models.Portfolio, history []*models.Trade) decimal.Decimal {
+	if !signal.Price.IsPositive() {
+		return decimal.Zero
+	}
+	return portfolio.Cash.Mul(z.Fraction).Div(signal.Price)
+}
+
+type FixedFractionSizer struct {
+	Fraction decimal.Decimal
+}
+
+func (z FixedFractionSizer) Size(signal *models.AlgorithmResult, portfolio *models.Portfolio, history []*models.Trade) decimal.Decimal {
+	if !signal.Price.IsPositive() {
+		return decimal.Zero
+	}
+	return portfolio.TotalValue.Mul(z.Fraction).Div(signal.Price)
+}
+
+type KellySizer struct {
+	Fraction      decimal.Decimal
+	MinRoundTrips int
+	Fallback      PositionSizer
+}
+
+func (z KellySizer) Size(signal *models.AlgorithmResult, portfolio *models.Portfolio, history []*models.Trade) decimal.Decimal {
+	kelly, ok := KellyFraction(history, z.MinRoundTrips)
+	if !ok {
+		return z.Fallback.Size(signal, portfolio, history)
+	}
+	if !kelly.IsPositive() || !signal.Price.IsPositive() {
+		return decimal.Zero
+	}
+	return portfolio.TotalValue.Mul(kelly).Mul(z.Fraction).Div(signal.Price)
+}
+
+func KellyFraction(history []*models.Trade, minRoundTrips int) (decimal.Decimal, bool) {
+	roundTrips := analytics.MatchRoundTrips(history)
+	if len(roundTrips) == 0 || len(roundTrips) < minRoundTrips {
+		return decimal.Zero, false
+	}
+
+	wins, grossWin, grossLoss := 0, decimal.Zero, decimal.Zero
+	for _, roundTrip := range roundTrips {
+		if roundTrip.PnL.IsPositive() {
+			wins++
+			grossWin = grossWin.Add(roundTrip.PnL)
+		} else {
+			grossLoss = grossLoss.Add(roundTrip.PnL.Neg())
+		}
+	}
+
+	losses := len(roundTrips) - wins
+	if wins == 0 {
+		return decimal.Zero, true
+	}
+	if losses == 0 || grossLoss.IsZero() {
+		return decimal.NewFromInt(1), true
+	}
+
+	winRate := decimal.NewFromInt(int64(wins)).Div(decimal.NewFromInt(int64(len(roundTrips))))
+	payoff := grossWin.Div(decimal.NewFromInt(int64(wins))).Div(grossLoss.Div(decimal.NewFromInt(int64(losses))))
+	return winRate.Sub(decimal.NewFromInt(1).Sub(winRate).Div(payoff)), true
+}
+
+func NewPositionSizer(config *models.StrategyConfig) (PositionSizer, error) {
+	fraction := config.SizingFraction
+	if !fraction.IsPositive() {
+		fraction = decimal.NewFromFloat(defaultSizingFraction)
+	}
+
+	switch config.PositionSizing {
+	case "", SizerCash:
+		return CashSizer{Fraction: decimal.NewFromFloat(cashSizerFraction)}, nil
+	case SizerFixedFraction:
+		return FixedFractionSizer{Fraction: fraction}, nil
+	case SizerKelly:
+		kellyFraction := config.KellyFraction
+		if !kellyFraction.IsPositive() {
+			kellyFraction = decimal.NewFromFloat(defaultKellyFraction)
+		}
+		return KellySizer{
+			Fraction:      kellyFraction,
+			MinRoundTrips: minKellyRoundTrips,
+			Fallback:      FixedFractionSizer{Fraction: fraction},
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownPositionSizer, config.PositionSizing)
+}
+
+func (s *BaseStrategy) SetPositionSizer(sizer PositionSizer) {
+	s.sizer = sizer
+}
+
+func (s *BaseStrategy) positionSizer() PositionSizer {
+	if s.sizer != nil {
+		return s.sizer
+	}
+
+	sizer, err := NewPositionSizer(s.GetConfig())
+	if err != nil {
+		return CashSizer{Fraction: decimal.NewFromFloat(cashSizerFraction)}
+	}
+	return sizer
+}
+
+func (s *BaseStrategy) strategyTrades(portfolio *models.Portfolio) []*models.Trade {
+	id := s.ID()
+	var trades []*models.Trade
+	for _, trade := range portfolio.TradeHistory {
+		if trade.StrategyID == id {
+			trades = append(trades, trade)
+		}
+	}
+	return trades
+}
@@ -0,0 +1,107 @@
+package strategies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRoundTrips(strategyID string, wins, losses int, win, loss float64) []*models.Trade {
+	var trades []*models.Trade
+	at := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+	add := func(side models.OrderSide, price float64) {
+		at = at.Add(time.Minute)
+		trades = append(trades, &models.Trade{Symbol: "AAPL", Side: side, Quantity: decimal.NewFromInt(10), Price: decimal.NewFromFloat(price), Timestamp: at, StrategyID: strategyID})
+	}
+	total := wins + losses
+	for i := 0; i < total; i++ {
+		add(models.OrderSideBuy, 100.0)
+		if i%5 < 3 && wins > 0 || losses == 0 {
+			add(models.OrderSideSell, 100.0+win/10)
+			wins--
+		} else {
+			add(models.OrderSideSell, 100.0-loss/10)
+			losses--
+		}
+	}
+	return trades
+}
+
+func TestKellyFraction(t *testing.T) {
+	fraction, ok := KellyFraction(createRoundTrips("kelly", 12, 8, 200, 100), minKellyRoundTrips)
+
+	require.True(t, ok)
+	assert.True(t, decimal.NewFromFloat(0.4).Equal(fraction), fraction.String())
+}
+
+func TestKellyFraction_InsufficientHistory(t *testing.T) {
+	_, ok := KellyFraction(createRoundTrips("kelly", 10, 9, 200, 100), minKellyRoundTrips)
+
+	assert.False(t, ok)
+}
+
+func TestKellyFraction_NoEdge(t *testing.T) {
+	fraction, ok := KellyFraction(createRoundTrips("kelly", 8, 12, 100, 100), minKellyRoundTrips)
+
+	require.True(t, ok)
+	assert.True(t, fraction.IsNegative(), fraction.String())
+}
+
+func TestKellySizer_Size(t *testing.T) {
+	sizer, err := NewPositionSizer(&models.StrategyConfig{PositionSizing: SizerKelly})
+	require.NoError(t, err)
+	portfolio := createTestPortfolio()
+	signal := &models.AlgorithmResult{Symbol: "AAPL", Price: decimal.NewFromFloat(100.0)}
+
+	assert.True(t, decimal.NewFromInt(200).Equal(sizer.Size(signal, portfolio, createRoundTrips("kelly", 12, 8, 200, 100)).Round(8)))
+	assert.True(t, decimal.NewFromInt(100).Equal(sizer.Size(signal, portfolio, createRoundTrips("kelly", 5, 5, 200, 100)).Round(8)))
+	assert.True(t, decimal.NewFromInt(0).Equal(sizer.Size(signal, portfolio, createRoundTrips("kelly", 8, 12, 100, 100)).Round(8)))
+}
+
+func TestNewPositionSizer(t *testing.T) {
+	sizer, err := NewPositionSizer(&models.StrategyConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, CashSizer{Fraction: decimal.NewFromFloat(cashSizerFraction)}, sizer)
+
+	sizer, err = NewPositionSizer(&models.StrategyConfig{PositionSizing: SizerKelly, KellyFraction: decimal.NewFromFloat(0.25)})
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromFloat(0.25).Equal(sizer.(KellySizer).Fraction))
+
+	_, err = NewPositionSizer(&models.StrategyConfig{PositionSizing: "martingale"})
+	assert.ErrorIs(t, err, ErrUnknownPositionSizer)
+}
+
+func TestBaseStrategy_CalculateOptimalQuantity_UsesOwnTrades(t *testing.T) {
+	strategy := NewMovingAverageStrategy(&models.StrategyConfig{
+		ID:             "kelly",
+		PositionSizing: SizerKelly,
+		MaxOrderSize:   decimal.NewFromFloat(50000.0),
+	})
+	portfolio := createTestPortfolio()
+	portfolio.TradeHistory = append(createRoundTrips("kelly", 12, 8, 200, 100), createRoundTrips("other", 0, 20, 0, 100)...)
+
+	assert.True(t, decimal.NewFromInt(200).Equal(strategy.calculateOptimalQuantity("AAPL", decimal.NewFromFloat(100.0), portfolio)))
+
+	strategy.GetConfig().MaxOrderSize = decimal.NewFromFloat(15000.0)
+	assert.True(t, decimal.NewFromInt(150).Equal(strategy.calculateOptimalQuantity("AAPL", decimal.NewFromFloat(100.0), portfolio)))
+}
+
+func TestBaseStrategy_CalculateOptimalQuantity_RoundsToLotSize(t *testing.T) {
+	strategy := NewMovingAverageStrategy(&models.StrategyConfig{ID: "crypto", MaxOrderSize: decimal.NewFromFloat(50000.0)})
+	portfolio := createTestPortfolio()
+	price := decimal.NewFromFloat(43210.5)
+
+	assert.True(t, decimal.NewFromInt(1).Equal(strategy.calculateOptimalQuantity("BTCUSDT", price, portfolio)))
+
+	registry := symbols.NewRegistry()
+	require.NoError(t, registry.Register(models.Instrument{Symbol: "BTCUSDT", LotSize: decimal.NewFromFloat(0.001)}))
+	strategy.SetSymbols(registry)
+
+	quantity := strategy.calculateOptimalQuantity("BTCUSDT", price, portfolio)
+	assert.True(t, decimal.RequireFromString("1.157").Equal(quantity), quantity.String())
+}
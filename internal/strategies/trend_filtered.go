@@ -0,0 +1,185 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/indicators"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type TrendFilteredMAStrategy struct {
+	*BaseStrategy
+	fastTimeframe  time.Duration
+	trendTimeframe time.Duration
+	shortPeriod    int
+	longPeriod     int
+	trendPeriod    int
+}
+
+func NewTrendFilteredMAStrategy(config *models.StrategyConfig) *TrendFilteredMAStrategy {
+	return &TrendFilteredMAStrategy{
+		BaseStrategy:   NewBaseStrategy(config),
+		fastTimeframe:  time.Minute,
+		trendTimeframe: 5 * time.Minute,
+		shortPeriod:    5,
+		longPeriod:     20,
+		trendPeriod:    10,
+	}
+}
+
+func (s *TrendFilteredMAStrategy) SetTimeframes(fast, trend time.Duration) error {
+	if fast <= 0 || trend <= fast || trend%fast != 0 {
+		return fmt.Errorf("%w: trend timeframe %s must be a multiple of fast timeframe %s", ErrInvalidConfig, trend, fast)
+	}
+	s.fastTimeframe = fast
+	s.trendTimeframe = trend
+	return nil
+}
+
+func (s *TrendFilteredMAStrategy) SetPeriods(short, long, trend int) {
+	s.shortPeriod = short
+	s.longPeriod = long
+	s.trendPeriod = trend
+}
+
+func (s *TrendFilteredMAStrategy) RequiredBars() map[time.Duration]int {
+	return map[time.Duration]int{
+		s.fastTimeframe:  s.longPeriod + 1,
+		s.trendTimeframe: s.trendPeriod + 1,
+	}
+}
+
+func (s *TrendFilteredMAStrategy) Parameters() []string {
+	return []string{"short_period", "long_period", "trend_period"}
+}
+
+func (s *TrendFilteredMAStrategy) SetParameters(params map[string]decimal.Decimal) error {
+	if err := checkParameters(params, s.Parameters()...); err != nil {
+		return err
+	}
+	short, err := intParameter(params, "short_period", s.shortPeriod)
+	if err != nil {
+		return err
+	}
+	long, err := intParameter(params, "long_period", s.longPeriod)
+	if err != nil {
+		return err
+	}
+	trend, err := intParameter(params, "trend_period", s.trendPeriod)
+	if err != nil {
+		return err
+	}
+	if short >= long {
+		return fmt.Errorf("%w: short_period %d must be below long_period %d", ErrInvalidConfig, short, long)
+	}
+	s.SetPeriods(short, long, trend)
+	return nil
+}
+
+func (s *TrendFilteredMAStrategy) Execute(ctx context.Context, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+
+	symbols := make([]string, 0, len(market.Latest))
+	for symbol := range market.Latest {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	var bestSignal *models.AlgorithmResult
+	for _, symbol := range symbols {
+		signal := s.analyzeSymbol(symbol, market, portfolio)
+		if signal != nil && (bestSignal == nil || signal.Confidence.GreaterThan(bestSignal.Confidence)) {
+			bestSignal = signal
+		}
+	}
+	return bestSignal, nil
+}
+
+func (s *TrendFilteredMAStrategy) ExecuteOnTick(ctx context.Context, symbol string, portfolio *models.Portfolio, market *models.MarketSnapshot) (*models.AlgorithmResult, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStrategyDisabled
+	}
+	return s.analyzeSymbol(symbol, market, portfolio), nil
+}
+
+func (s *TrendFilteredMAStrategy) analyzeSymbol(symbol string, market *models.MarketSnapshot, portfolio *models.Portfolio) *models.AlgorithmResult {
+	bars := market.GetBars(symbol, s.fastTimeframe, s.longPeriod+1)
+	closes := barCloses(bars)
+	if len(closes) < s.longPeriod+1 {
+		return nil
+	}
+	previous := closes[:len(closes)-1]
+	prevShort, _ := indicators.SMA(previous, s.shortPeriod)
+	prevLong, _ := indicators.SMA(previous, s.longPeriod)
+	shortMA, _ := indicators.SMA(closes, s.shortPeriod)
+	longMA, _ := indicators.SMA(closes, s.longPeriod)
+	if !longMA.IsPositive() {
+		return nil
+	}
+
+	held := decimal.Zero
+	if position, exists := portfolio.Positions[symbol]; exists {
+		held = position.Quantity
+	}
+	price, timestamp := closes[len(closes)-1], bars[len(bars)-1].Timestamp
+	if data, exists := market.Latest[symbol]; exists && data.Price.IsPositive() {
+		price, timestamp = data.Price, data.Timestamp
+	}
+	confidence := decimal.Min(decimal.NewFromFloat(0.5).Add(shortMA.Sub(longMA).Div(longMA).Abs().Mul(decimal.NewFromInt(50))), decimal.NewFromInt(1))
+
+	switch {
+	case !prevShort.GreaterThan(prevLong) && shortMA.GreaterThan(longMA) && held.IsZero():
+		slope, ok := s.trendSlope(symbol, market)
+		if !ok || !slope.IsPositive() {
+			return nil
+		}
+		quantity := s.calculateOptimalQuantity(symbol, price, portfolio)
+		if !quantity.IsPositive() {
+			return nil
+		}
+		reason := fmt.Sprintf("%s SMA %s crossed above %s and %s trend slope %s is positive", s.fastTimeframe, shortMA.StringFixed(2), longMA.StringFixed(2), s.trendTimeframe, slope.StringFixed(4))
+		return s.buildResult(symbol, timestamp, models.ActionBuy, "trend_entry", reason, quantity, price, confidence)
+	case !prevShort.LessThan(prevLong) && shortMA.LessThan(longMA) && held.IsPositive():
+		reason := fmt.Sprintf("%s SMA %s crossed below %s", s.fastTimeframe, shortMA.StringFixed(2), longMA.StringFixed(2))
+		return s.buildResult(symbol, timestamp, models.ActionSell, "trend_exit", reason, held, price, decimal.NewFromInt(1))
+	}
+	return nil
+}
+
+func (s *TrendFilteredMAStrategy) trendSlope(symbol string, market *models.MarketSnapshot) (decimal.Decimal, bool) {
+	closes := barCloses(market.GetBars(symbol, s.trendTimeframe, s.trendPeriod+1))
+	if len(closes) < s.trendPeriod+1 {
+		return decimal.Zero, false
+	}
+	current, _ := indicators.SMA(closes, s.trendPeriod)
+	previous, _ := indicators.SMA(closes[:len(closes)-1], s.trendPeriod)
+	return current.Sub(previous), true
+}
+
+func (s *TrendFilteredMAStrategy) buildResult(symbol string, timestamp time.Time, action models.Action, signal, reason string, quantity, price, confidence decimal.Decimal) *models.AlgorithmResult {
+	return &models.AlgorithmResult{
+		StrategyID: s.ID(),
+		Symbol:     symbol,
+		Action:     action,
+		Quantity:   quantity,
+		Price:      price,
+		Confidence: confidence,
+		Signal:     signal,
+		Reason:     reason,
+		Timestamp:  timestamp,
+	}
+}
+
+func barCloses(bars []*models.MarketData) []decimal.Decimal {
+	closes := make([]decimal.Decimal, len(bars))
+	for i, bar := range bars {
+		closes[i] = indicators.Close(bar)
+	}
+	return closes
+}
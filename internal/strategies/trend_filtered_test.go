@@ -0,0 +1,91 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTrendFilteredStrategy() *TrendFilteredMAStrategy {
+	strategy := NewTrendFilteredMAStrategy(createTestBreakoutConfig())
+	strategy.SetPeriods(2, 4, 2)
+	return strategy
+}
+
+func createTrendMarket(fast, trend []float64) *models.MarketSnapshot {
+	bars := func(closes []float64, timeframe time.Duration) []*models.MarketData {
+		series := make([]*models.MarketData, len(closes))
+		for i, close := range closes {
+			series[i] = &models.MarketData{Symbol: "AAPL", Kind: models.MarketDataKindBar, Price: decimal.NewFromFloat(close), Close: decimal.NewFromFloat(close), Interval: timeframe}
+		}
+		return series
+	}
+	fastBars := bars(fast, time.Minute)
+	return &models.MarketSnapshot{
+		Latest: map[string]*models.MarketData{"AAPL": fastBars[len(fastBars)-1]},
+		Bars: map[string]map[time.Duration][]*models.MarketData{"AAPL": {
+			time.Minute:     fastBars,
+			5 * time.Minute: bars(trend, 5*time.Minute),
+		}},
+	}
+}
+
+var (
+	upCross   = []float64{100, 100, 100, 99, 104}
+	downCross = []float64{100, 100, 100, 101, 96}
+)
+
+func TestTrendFilteredMAStrategy_RequiredBars(t *testing.T) {
+	strategy := createTrendFilteredStrategy()
+
+	assert.Equal(t, map[time.Duration]int{time.Minute: 5, 5 * time.Minute: 3}, strategy.RequiredBars())
+	assert.ErrorIs(t, strategy.SetTimeframes(time.Minute, 90*time.Second), ErrInvalidConfig)
+	require.NoError(t, strategy.SetTimeframes(30*time.Second, 15*time.Minute))
+	assert.Equal(t, map[time.Duration]int{30 * time.Second: 5, 15 * time.Minute: 3}, strategy.RequiredBars())
+}
+
+func TestTrendFilteredMAStrategy_Execute_BuysCrossInUptrend(t *testing.T) {
+	strategy := createTrendFilteredStrategy()
+
+	result, err := strategy.Execute(context.Background(), createTestPortfolio(), createTrendMarket(upCross, []float64{100, 101, 102}))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	assert.Equal(t, models.ActionBuy, result.Action)
+	assert.Equal(t, "trend_entry", result.Signal)
+	assert.True(t, result.Quantity.IsPositive())
+	assert.True(t, result.Price.Equal(decimal.NewFromInt(104)))
+}
+
+func TestTrendFilteredMAStrategy_Execute_FiltersCrossAgainstTrend(t *testing.T) {
+	strategy := createTrendFilteredStrategy()
+
+	for _, trend := range [][]float64{{102, 101, 100}, {101, 101, 101}, {100, 101}} {
+		result, err := strategy.Execute(context.Background(), createTestPortfolio(), createTrendMarket(upCross, trend))
+		require.NoError(t, err)
+		assert.Nil(t, result, trend)
+	}
+}
+
+func TestTrendFilteredMAStrategy_Execute_SellsOnDownCross(t *testing.T) {
+	strategy := createTrendFilteredStrategy()
+	portfolio := createTestPortfolio()
+	portfolio.Positions["AAPL"] = &models.Position{Symbol: "AAPL", Quantity: decimal.NewFromInt(50)}
+
+	result, err := strategy.Execute(context.Background(), portfolio, createTrendMarket(downCross, []float64{102, 101, 100}))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	assert.Equal(t, models.ActionSell, result.Action)
+	assert.Equal(t, "trend_exit", result.Signal)
+	assert.True(t, decimal.NewFromInt(50).Equal(result.Quantity))
+
+	result, err = strategy.Execute(context.Background(), createTestPortfolio(), createTrendMarket(downCross, []float64{102, 101, 100}))
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
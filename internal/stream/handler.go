@@ -0,0 +1,163 @@
+package stream
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/events"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	clientBuffer = 256
+	writeTimeout = 5 * time.Second
+)
+
+const eventTypeSubscribed events.EventType = "subscribed"
+
+type subscribeMessage struct {
+	Action  string             `json:"action"`
+	Symbols []string           `json:"symbols"`
+	Types   []events.EventType `json:"types"`
+}
+
+type filter struct {
+	mu      sync.RWMutex
+	symbols map[string]bool
+	types   map[events.EventType]bool
+}
+
+func (f *filter) set(message subscribeMessage) {
+	symbols := make(map[string]bool, len(message.Symbols))
+	for _, symbol := range message.Symbols {
+		symbols[symbol] = true
+	}
+	types := make(map[events.EventType]bool, len(message.Types))
+	for _, eventType := range message.Types {
+		types[eventType] = true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.symbols = symbols
+	f.types = types
+}
+
+func (f *filter) matches(event events.Event) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if len(f.types) > 0 && !f.types[event.Type] {
+		return false
+	}
+	if len(f.symbols) > 0 && event.Symbol != "" && !f.symbols[event.Symbol] {
+		return false
+	}
+	return true
+}
+
+type Handler struct {
+	bus      *events.Bus
+	upgrader websocket.Upgrader
+	logger   *zap.Logger
+}
+
+func NewHandler(bus *events.Bus, logger *zap.Logger, allowedOrigins ...string) *Handler {
+	handler := &Handler{bus: bus, logger: logger}
+	if len(allowedOrigins) > 0 {
+		allowed := make(map[string]bool, len(allowedOrigins))
+		for _, origin := range allowedOrigins {
+			allowed[strings.ToLower(strings.TrimSuffix(strings.TrimSpace(origin), "/"))] = true
+		}
+		handler.upgrader.CheckOrigin = func(r *http.Request) bool {
+			return sameOrigin(r) || allowed[strings.ToLower(r.Header.Get("Origin"))]
+		}
+	}
+	return handler
+}
+
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(parsed.Host, r.Host)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Warn("WebSocket upgrade failed", zap.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	sub := h.bus.Subscribe(clientBuffer)
+	defer sub.Close()
+
+	clientFilter := &filter{}
+	acks := make(chan events.Event, 1)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go h.readLoop(conn, clientFilter, acks, done, stopped)
+
+	for {
+		select {
+		case event, open := <-sub.Events():
+			if !open {
+				return
+			}
+			if sub.Dropped() > 0 {
+				h.logger.Warn("Disconnecting slow WebSocket client", zap.String("remote", r.RemoteAddr), zap.Uint64("dropped", sub.Dropped()))
+				h.closeWith(conn, websocket.ClosePolicyViolation, "client too slow")
+				return
+			}
+			if !clientFilter.matches(event) {
+				continue
+			}
+			if err := h.write(conn, event); err != nil {
+				return
+			}
+		case ack := <-acks:
+			if err := h.write(conn, ack); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func (h *Handler) readLoop(conn *websocket.Conn, clientFilter *filter, acks chan<- events.Event, done, stopped chan struct{}) {
+	defer close(done)
+	for {
+		var message subscribeMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			return
+		}
+		if message.Action != "subscribe" {
+			continue
+		}
+		clientFilter.set(message)
+		select {
+		case acks <- events.Event{Type: eventTypeSubscribed, Timestamp: time.Now(), Data: message}:
+		case <-stopped:
+			return
+		}
+	}
+}
+
+func (h *Handler) write(conn *websocket.Conn, event events.Event) error {
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return conn.WriteJSON(event)
+}
+
+func (h *Handler) closeWith(conn *websocket.Conn, code int, reason string) {
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeTimeout))
+}
@@ -0,0 +1,144 @@
+package stream
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/events"
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type receivedEvent struct {
+	Type   events.EventType `json:"type"`
+	Symbol string           `json:"symbol"`
+	Data   json.RawMessage  `json:"data"`
+}
+
+func dialTestServer(t *testing.T, bus *events.Bus) *websocket.Conn {
+	t.Helper()
+	server := httptest.NewServer(NewHandler(bus, zap.NewNop()))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestHandler_CheckOrigin(t *testing.T) {
+	bus := events.NewBus()
+	restricted := httptest.NewServer(NewHandler(bus, zap.NewNop()))
+	t.Cleanup(restricted.Close)
+	allowList := httptest.NewServer(NewHandler(bus, zap.NewNop(), "https://dash.example.com/"))
+	t.Cleanup(allowList.Close)
+
+	dial := func(server *httptest.Server, origin string) error {
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), header)
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	assert.NoError(t, dial(restricted, ""))
+	assert.NoError(t, dial(restricted, restricted.URL))
+	assert.ErrorIs(t, dial(restricted, "https://evil.example.com"), websocket.ErrBadHandshake)
+	assert.NoError(t, dial(allowList, "https://dash.example.com"))
+	assert.NoError(t, dial(allowList, allowList.URL))
+	assert.ErrorIs(t, dial(allowList, "https://evil.example.com"), websocket.ErrBadHandshake)
+}
+
+func subscribe(t *testing.T, conn *websocket.Conn, message subscribeMessage) {
+	t.Helper()
+	message.Action = "subscribe"
+	require.NoError(t, conn.WriteJSON(message))
+	ack := readEvent(t, conn)
+	require.Equal(t, eventTypeSubscribed, ack.Type)
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn) receivedEvent {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var event receivedEvent
+	require.NoError(t, conn.ReadJSON(&event))
+	return event
+}
+
+func publishBuyFill(bus *events.Bus, symbol string) {
+	order := &models.Order{ID: "order_" + symbol, Symbol: symbol, Side: models.OrderSideBuy, Quantity: decimal.NewFromInt(10), Price: decimal.NewFromFloat(150.0), Status: models.OrderStatusPending}
+	bus.Publish(events.Event{Type: events.EventTypeMarketData, Symbol: symbol, Data: &models.MarketData{Symbol: symbol}})
+	bus.Publish(events.Event{Type: events.EventTypeOrder, Symbol: symbol, Data: order})
+	filled := *order
+	filled.Status = models.OrderStatusFilled
+	bus.Publish(events.Event{Type: events.EventTypeOrder, Symbol: symbol, Data: &filled})
+	bus.Publish(events.Event{Type: events.EventTypeTrade, Symbol: symbol, Data: &models.Trade{OrderID: order.ID, Symbol: symbol, Quantity: decimal.NewFromInt(10)}})
+}
+
+func TestHandler_BuyFillSequence(t *testing.T) {
+	bus := events.NewBus()
+	conn := dialTestServer(t, bus)
+	subscribe(t, conn, subscribeMessage{})
+
+	publishBuyFill(bus, "AAPL")
+
+	expected := []events.EventType{events.EventTypeMarketData, events.EventTypeOrder, events.EventTypeOrder, events.EventTypeTrade}
+	var orders []models.Order
+	for _, eventType := range expected {
+		event := readEvent(t, conn)
+		require.Equal(t, eventType, event.Type)
+		if event.Type == events.EventTypeOrder {
+			var order models.Order
+			require.NoError(t, json.Unmarshal(event.Data, &order))
+			orders = append(orders, order)
+		}
+	}
+	require.Len(t, orders, 2)
+	assert.Equal(t, models.OrderStatusPending, orders[0].Status)
+	assert.Equal(t, models.OrderStatusFilled, orders[1].Status)
+}
+
+func TestHandler_SubscriptionFilters(t *testing.T) {
+	bus := events.NewBus()
+	conn := dialTestServer(t, bus)
+	subscribe(t, conn, subscribeMessage{Symbols: []string{"MSFT"}, Types: []events.EventType{events.EventTypeTrade, events.EventTypePortfolio}})
+
+	publishBuyFill(bus, "AAPL")
+	publishBuyFill(bus, "MSFT")
+	bus.Publish(events.Event{Type: events.EventTypePortfolio, Data: &models.Portfolio{ID: "PORT-1"}})
+
+	trade := readEvent(t, conn)
+	assert.Equal(t, events.EventTypeTrade, trade.Type)
+	assert.Equal(t, "MSFT", trade.Symbol)
+	assert.Equal(t, events.EventTypePortfolio, readEvent(t, conn).Type)
+}
+
+func TestHandler_DisconnectsSlowClient(t *testing.T) {
+	bus := events.NewBus()
+	conn := dialTestServer(t, bus)
+	subscribe(t, conn, subscribeMessage{})
+
+	for i := 0; i < clientBuffer*4; i++ {
+		bus.Publish(events.Event{Type: events.EventTypeMarketData, Symbol: "AAPL", Data: strings.Repeat("x", 4096)})
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var err error
+	for err == nil {
+		_, _, err = conn.ReadMessage()
+	}
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), err.Error())
+	assert.Eventually(t, func() bool { return !bus.HasSubscribers() }, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,5 @@
+package stress
+
+import "errors"
+
+var ErrInvalidScenario = errors.New("invalid stress scenario")
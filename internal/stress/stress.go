@@ -0,0 +1,89 @@
+package stress
+
+import (
+	"fmt"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+type Shock struct {
+	Symbols              []string        `json:"symbols,omitempty"`
+	PriceChange          decimal.Decimal `json:"price_change"`
+	VolatilityMultiplier decimal.Decimal `json:"volatility_multiplier,omitempty"`
+}
+
+type Scenario struct {
+	Name   string  `json:"name"`
+	Shocks []Shock `json:"shocks"`
+}
+
+type PositionImpact struct {
+	Symbol        string            `json:"symbol"`
+	StrategyID    string            `json:"strategy_id"`
+	Quantity      decimal.Decimal   `json:"quantity"`
+	CurrentPrice  decimal.Decimal   `json:"current_price"`
+	StressedPrice decimal.Decimal   `json:"stressed_price"`
+	CurrentValue  decimal.Decimal   `json:"current_value"`
+	StressedValue decimal.Decimal   `json:"stressed_value"`
+	PnL           decimal.Decimal   `json:"pnl"`
+	StressedVaR95 decimal.Decimal   `json:"stressed_var_95"`
+	Exit          models.ExitReason `json:"exit,omitempty"`
+}
+
+type Result struct {
+	Scenario          string           `json:"scenario"`
+	CurrentValue      decimal.Decimal  `json:"current_value"`
+	StressedValue     decimal.Decimal  `json:"stressed_value"`
+	PnL               decimal.Decimal  `json:"pnl"`
+	Drawdown          decimal.Decimal  `json:"drawdown"`
+	DrawdownHalt      bool             `json:"drawdown_halt"`
+	Positions         []PositionImpact `json:"positions"`
+	MaintenanceMargin decimal.Decimal  `json:"maintenance_margin,omitempty"`
+	MarginCall        bool             `json:"margin_call"`
+}
+
+func (s Scenario) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidScenario)
+	}
+	if len(s.Shocks) == 0 {
+		return fmt.Errorf("%w: %s has no shocks", ErrInvalidScenario, s.Name)
+	}
+	for i, shock := range s.Shocks {
+		if shock.PriceChange.LessThanOrEqual(decimal.NewFromInt(-1)) {
+			return fmt.Errorf("%w: %s shock %d price_change must be above -1", ErrInvalidScenario, s.Name, i)
+		}
+		if shock.VolatilityMultiplier.IsNegative() {
+			return fmt.Errorf("%w: %s shock %d volatility_multiplier must not be negative", ErrInvalidScenario, s.Name, i)
+		}
+	}
+	return nil
+}
+
+func (s Scenario) Apply(symbol string, price decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	one := decimal.NewFromInt(1)
+	volatility := one
+	for _, shock := range s.Shocks {
+		if !shock.applies(symbol) {
+			continue
+		}
+		price = price.Mul(one.Add(shock.PriceChange))
+		if shock.VolatilityMultiplier.IsPositive() {
+			volatility = volatility.Mul(shock.VolatilityMultiplier)
+		}
+	}
+	return price, volatility
+}
+
+func (s Shock) applies(symbol string) bool {
+	if len(s.Symbols) == 0 {
+		return true
+	}
+	for _, candidate := range s.Symbols {
+		if candidate == symbol {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package stress
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenario_Apply_CompoundsMatchingShocks(t *testing.T) {
+	scenario := Scenario{Name: "tech", Shocks: []Shock{
+		{PriceChange: decimal.NewFromFloat(-0.1)},
+		{Symbols: []string{"AAPL", "MSFT"}, PriceChange: decimal.NewFromFloat(-0.5), VolatilityMultiplier: decimal.NewFromInt(3)},
+	}}
+
+	price, volatility := scenario.Apply("AAPL", decimal.NewFromInt(200))
+	assert.True(t, decimal.NewFromInt(90).Equal(price))
+	assert.True(t, decimal.NewFromInt(3).Equal(volatility))
+
+	price, volatility = scenario.Apply("TSLA", decimal.NewFromInt(200))
+	assert.True(t, decimal.NewFromInt(180).Equal(price))
+	assert.True(t, decimal.NewFromInt(1).Equal(volatility))
+}
+
+func TestScenario_Validate(t *testing.T) {
+	assert.NoError(t, Scenario{Name: "crash", Shocks: []Shock{{PriceChange: decimal.NewFromFloat(-0.2)}}}.Validate())
+	assert.ErrorIs(t, Scenario{Shocks: []Shock{{PriceChange: decimal.NewFromFloat(-0.2)}}}.Validate(), ErrInvalidScenario)
+	assert.ErrorIs(t, Scenario{Name: "crash"}.Validate(), ErrInvalidScenario)
+	assert.ErrorIs(t, Scenario{Name: "crash", Shocks: []Shock{{PriceChange: decimal.NewFromInt(-1)}}}.Validate(), ErrInvalidScenario)
+	assert.ErrorIs(t, Scenario{Name: "crash", Shocks: []Shock{{VolatilityMultiplier: decimal.NewFromInt(-2)}}}.Validate(), ErrInvalidScenario)
+}
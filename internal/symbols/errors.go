@@ -0,0 +1,12 @@
+package symbols
+
+import "errors"
+
+var (
+	ErrInvalidLotSize   = errors.New("lot size must be positive")
+	ErrBelowLotSize     = errors.New("quantity is below the lot size")
+	ErrNotLotMultiple   = errors.New("quantity is not a multiple of the lot size")
+	ErrInvalidTickSize  = errors.New("tick size must not be negative")
+	ErrInvalidPrecision = errors.New("price precision must not be negative")
+	ErrOffTick          = errors.New("price is not a multiple of the tick size")
+)
@@ -0,0 +1,146 @@
+package symbols
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+const defaultCurrency = "USD"
+
+var defaultLotSize = decimal.NewFromInt(1)
+
+type Registry struct {
+	mu          sync.RWMutex
+	instruments map[string]models.Instrument
+}
+
+func NewRegistry() *Registry {
+	return &Registry{instruments: make(map[string]models.Instrument)}
+}
+
+func Default(symbol string) models.Instrument {
+	return models.Instrument{
+		Symbol:      symbol,
+		LotSize:     defaultLotSize,
+		Currency:    defaultCurrency,
+		SessionType: models.SessionTypeRegular,
+	}
+}
+
+func (r *Registry) Register(instrument models.Instrument) error {
+	switch {
+	case !instrument.LotSize.IsPositive():
+		return fmt.Errorf("%w: %s has lot size %s", ErrInvalidLotSize, instrument.Symbol, instrument.LotSize)
+	case instrument.TickSize.IsNegative():
+		return fmt.Errorf("%w: %s has tick size %s", ErrInvalidTickSize, instrument.Symbol, instrument.TickSize)
+	case instrument.PricePrecision < 0:
+		return fmt.Errorf("%w: %s has price precision %d", ErrInvalidPrecision, instrument.Symbol, instrument.PricePrecision)
+	}
+	if instrument.Currency == "" {
+		instrument.Currency = defaultCurrency
+	}
+	if instrument.SessionType == "" {
+		instrument.SessionType = models.SessionTypeRegular
+	}
+	instrument.PricePrecision = max(instrument.PricePrecision, decimalPlaces(instrument.TickSize))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.instruments[instrument.Symbol] = instrument
+	return nil
+}
+
+func (r *Registry) Instrument(symbol string) models.Instrument {
+	if r == nil {
+		return Default(symbol)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if instrument, exists := r.instruments[symbol]; exists {
+		return instrument
+	}
+	return Default(symbol)
+}
+
+func (r *Registry) Instruments() []models.Instrument {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	instruments := make([]models.Instrument, 0, len(r.instruments))
+	for _, instrument := range r.instruments {
+		instruments = append(instruments, instrument)
+	}
+	return instruments
+}
+
+func (r *Registry) LotSize(symbol string) decimal.Decimal {
+	return r.Instrument(symbol).LotSize
+}
+
+func (r *Registry) TickSize(symbol string) decimal.Decimal {
+	return r.Instrument(symbol).TickSize
+}
+
+func (r *Registry) RoundDown(symbol string, quantity decimal.Decimal) decimal.Decimal {
+	lotSize := r.LotSize(symbol)
+	return quantity.Div(lotSize).Floor().Mul(lotSize)
+}
+
+func (r *Registry) Validate(symbol string, quantity decimal.Decimal) error {
+	lotSize := r.LotSize(symbol)
+	if quantity.Abs().LessThan(lotSize) {
+		return fmt.Errorf("%w: %s %s < %s", ErrBelowLotSize, symbol, quantity, lotSize)
+	}
+	if !quantity.Mod(lotSize).IsZero() {
+		return fmt.Errorf("%w: %s %s is not a multiple of %s", ErrNotLotMultiple, symbol, quantity, lotSize)
+	}
+	return nil
+}
+
+func (r *Registry) RoundPrice(symbol string, price decimal.Decimal) decimal.Decimal {
+	return roundToTick(price, r.TickSize(symbol), func(ticks decimal.Decimal) decimal.Decimal { return ticks.Round(0) })
+}
+
+func (r *Registry) RoundPriceDown(symbol string, price decimal.Decimal) decimal.Decimal {
+	return roundToTick(price, r.TickSize(symbol), decimal.Decimal.Floor)
+}
+
+func (r *Registry) RoundPriceUp(symbol string, price decimal.Decimal) decimal.Decimal {
+	return roundToTick(price, r.TickSize(symbol), decimal.Decimal.Ceil)
+}
+
+func (r *Registry) ValidatePrice(symbol string, price decimal.Decimal) error {
+	tickSize := r.TickSize(symbol)
+	if tickSize.IsPositive() && !price.Mod(tickSize).IsZero() {
+		return fmt.Errorf("%w: %s %s is not a multiple of %s", ErrOffTick, symbol, price, tickSize)
+	}
+	return nil
+}
+
+func (r *Registry) FormatPrice(symbol string, price decimal.Decimal) string {
+	instrument := r.Instrument(symbol)
+	if !instrument.TickSize.IsPositive() && instrument.PricePrecision == 0 {
+		return price.String()
+	}
+	return price.StringFixed(instrument.PricePrecision)
+}
+
+func roundToTick(price, tickSize decimal.Decimal, round func(decimal.Decimal) decimal.Decimal) decimal.Decimal {
+	if !tickSize.IsPositive() {
+		return price
+	}
+	return round(price.Div(tickSize)).Mul(tickSize)
+}
+
+func decimalPlaces(value decimal.Decimal) int32 {
+	places := int32(0)
+	for !value.Shift(places).IsInteger() {
+		places++
+	}
+	return places
+}
@@ -0,0 +1,79 @@
+package symbols
+
+import (
+	"testing"
+
+	"github.com/1cbyc/trade-algo-go/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_DefaultsToWholeUnits(t *testing.T) {
+	var missing *Registry
+	registry := NewRegistry()
+
+	assert.True(t, registry.LotSize("AAPL").Equal(decimal.NewFromInt(1)))
+	assert.True(t, missing.LotSize("AAPL").Equal(decimal.NewFromInt(1)))
+	assert.True(t, registry.RoundDown("AAPL", decimal.NewFromFloat(12.9)).Equal(decimal.NewFromInt(12)))
+	assert.ErrorIs(t, registry.Validate("AAPL", decimal.NewFromFloat(1.5)), ErrNotLotMultiple)
+	assert.NoError(t, registry.Validate("AAPL", decimal.NewFromInt(3)))
+}
+
+func TestRegistry_RoundsToLotSize(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(models.Instrument{Symbol: "BTCUSDT", LotSize: decimal.NewFromFloat(0.001)}))
+
+	assert.Equal(t, "0.053", registry.RoundDown("BTCUSDT", decimal.RequireFromString("0.05391")).String())
+	assert.Equal(t, "0", registry.RoundDown("BTCUSDT", decimal.RequireFromString("0.0009")).String())
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(models.Instrument{Symbol: "BTCUSDT", LotSize: decimal.NewFromFloat(0.001)}))
+
+	assert.NoError(t, registry.Validate("BTCUSDT", decimal.RequireFromString("0.05")))
+	assert.NoError(t, registry.Validate("BTCUSDT", decimal.RequireFromString("-0.05")))
+	assert.ErrorIs(t, registry.Validate("BTCUSDT", decimal.RequireFromString("0.0005")), ErrBelowLotSize)
+	assert.ErrorIs(t, registry.Validate("BTCUSDT", decimal.RequireFromString("0.0505")), ErrNotLotMultiple)
+}
+
+func TestRegistry_RegisterRejectsNonPositiveLotSize(t *testing.T) {
+	registry := NewRegistry()
+
+	assert.ErrorIs(t, registry.Register(models.Instrument{Symbol: "AAPL"}), ErrInvalidLotSize)
+	assert.ErrorIs(t, registry.Register(models.Instrument{Symbol: "AAPL", LotSize: decimal.NewFromInt(-1)}), ErrInvalidLotSize)
+}
+
+func TestRegistry_RoundsPricesToTickSize(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(models.Instrument{Symbol: "ES", TickSize: decimal.NewFromFloat(0.05), LotSize: decimal.NewFromInt(1)}))
+	price := decimal.NewFromFloat(100.03)
+
+	assert.Equal(t, "100.05", registry.RoundPrice("ES", price).String())
+	assert.Equal(t, "100", registry.RoundPriceDown("ES", price).String())
+	assert.Equal(t, "100.05", registry.RoundPriceUp("ES", price).String())
+	assert.ErrorIs(t, registry.ValidatePrice("ES", price), ErrOffTick)
+	assert.NoError(t, registry.ValidatePrice("ES", decimal.NewFromFloat(100.05)))
+	assert.Equal(t, "100.00", registry.FormatPrice("ES", decimal.NewFromInt(100)))
+}
+
+func TestRegistry_UnknownSymbolsUseDefaults(t *testing.T) {
+	registry := NewRegistry()
+	price := decimal.NewFromFloat(100.03)
+
+	instrument := registry.Instrument("AAPL")
+	assert.Equal(t, "USD", instrument.Currency)
+	assert.Equal(t, models.SessionTypeRegular, instrument.SessionType)
+	assert.True(t, instrument.TickSize.IsZero())
+	assert.True(t, price.Equal(registry.RoundPrice("AAPL", price)))
+	assert.NoError(t, registry.ValidatePrice("AAPL", price))
+	assert.Equal(t, "100.03", registry.FormatPrice("AAPL", price))
+}
+
+func TestRegistry_RegisterRejectsNegativeTickSize(t *testing.T) {
+	registry := NewRegistry()
+
+	assert.ErrorIs(t, registry.Register(models.Instrument{Symbol: "ES", LotSize: decimal.NewFromInt(1), TickSize: decimal.NewFromFloat(-0.25)}), ErrInvalidTickSize)
+	assert.ErrorIs(t, registry.Register(models.Instrument{Symbol: "ES", LotSize: decimal.NewFromInt(1), PricePrecision: -1}), ErrInvalidPrecision)
+}
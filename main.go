@@ -1,54 +1,768 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"flag"
 	"fmt"
 	"os"
 	"os/signal"
+	"runtime"
+	"sort"
+	"strings"
 	"syscall"
 	"time"
 
-	"github.com/1cbyc/trade-algo-go/internal/engine"
-	"github.com/1cbyc/trade-algo-go/internal/models"
-	"github.com/1cbyc/trade-algo-go/internal/simulator"
-	"github.com/1cbyc/trade-algo-go/internal/strategies"
+	"github.com/1cbyc/trade-algo-go/pkg/tradealgo"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
+const betaLookback = 60
+
 func main() {
 	var (
 		initialCash = flag.Float64("cash", 100000.0, "Initial portfolio cash")
 		duration    = flag.Duration("duration", 5*time.Minute, "Simulation duration")
 		logLevel    = flag.String("log-level", "info", "Log level (debug, info, warn, error)")
+		backtestDir = flag.String("backtest", "", "Directory of per-symbol OHLCV CSV files to backtest")
+		benchmark   = flag.String("benchmark", "SPY", "Benchmark symbol used for beta calculations")
+		barInterval = flag.Duration("bar-interval", 0, "Aggregate simulator ticks into bars of this interval and trade on bars (e.g. 1s, 1m, 5m)")
+		exportDir   = flag.String("export-dir", "", "Directory to write trade, order and position history to on shutdown")
+		statements  = flag.Bool("daily-statements", false, "Also write each day's P&L statement to -export-dir as the day closes")
+		stateFile   = flag.String("state-file", "", "File the portfolio state is saved to on shutdown")
+		resume      = flag.Bool("resume", false, "Resume from -state-file when it exists, replaying -audit-log entries written after it")
+		snapshots   = flag.Duration("snapshot-interval", 0, "Also write -state-file on this interval while running so a crash can be recovered with -resume; disabled when 0")
+		tradeDB     = flag.String("trade-db", "", "SQLite database file to journal trades and orders to")
+		historyMax  = flag.Int("history-limit", 10000, "Trades and orders kept in memory; older entries are archived")
+		archiveDir  = flag.String("archive-dir", "archive", "Directory older trades and orders are appended to as JSONL when -trade-db is not set")
+		auditFile   = flag.String("audit-log", "", "File every order, validation, risk, fill, cancel and modify decision is appended to as JSONL; disabled when empty")
+		auditSize   = flag.Int64("audit-max-size", 100*1024*1024, "Size in bytes at which -audit-log is rotated; 0 never rotates")
+		auditKeep   = flag.Int("audit-backups", 10, "Rotated -audit-log files kept as <file>.1 to <file>.N")
+		costBasis   = flag.String("cost-basis", string(tradealgo.CostBasisAverage), "Cost basis method for realized PnL (average, fifo, lifo)")
+		varMethod   = flag.String("var-method", tradealgo.VaRParametric, "Method for portfolio VaR (parametric, historical, monte_carlo)")
+		sliceAlgo   = flag.String("slice-algo", "", "Slice market orders above -slice-threshold into child orders (twap, vwap); empty sends them whole")
+		sliceMin    = flag.Float64("slice-threshold", 50000.0, "Order notional above which -slice-algo splits an order")
+		slices      = flag.Int("slices", 10, "Number of child orders a sliced order is split into")
+		sliceInt    = flag.Duration("slice-interval", 30*time.Second, "Interval between the child orders of a sliced order")
+		impactK     = flag.Float64("impact-k", 0, "Square-root market impact coefficient k (impact = k * sqrt(quantity / volume) * price); 0 uses the flat slippage tolerance")
+		latencyType = flag.String("latency-model", "", "Fill latency distribution (fixed, uniform, lognormal); empty fills orders immediately")
+		latency     = flag.Duration("latency", 100*time.Millisecond, "Fixed fill latency, uniform mean or lognormal median")
+		jitter      = flag.Duration("latency-jitter", 50*time.Millisecond, "Half-width of the uniform fill latency range")
+		sigma       = flag.Float64("latency-sigma", 0.5, "Log-space standard deviation of the lognormal fill latency")
+		initMargin  = flag.Float64("initial-margin", 0, "Initial margin rate of a margin account (e.g. 0.5 for 2x buying power); 0 trades on cash only")
+		maintMargin = flag.Float64("maintenance-margin", 0.25, "Share of gross position value equity must cover before a margin call liquidates positions")
+		marginRate  = flag.Float64("margin-interest", 0.05, "Annual interest rate charged daily on borrowed cash in a margin account")
+		signalAge   = flag.Duration("max-signal-age", 0, "Maximum age of market data behind a market order, and of the signal behind newer market data; 0 disables the check")
+		signalDev   = flag.Float64("max-signal-deviation", 0, "Maximum deviation of a market order's price from the latest market price (e.g. 0.01); 0 disables the check")
+		staleAction = flag.String("stale-price-action", string(tradealgo.StalePriceReprice), "What to do with a market order whose signal price is stale (reprice, reject)")
+		maxDrawdown = flag.Float64("max-portfolio-drawdown", 0, "Drawdown of portfolio equity from its peak (e.g. 0.2) at which every strategy is disabled; 0 disables the check")
+		lossLimit   = flag.Float64("circuit-breaker-loss", 0, "Loss of portfolio equity within -circuit-breaker-window (e.g. 0.03) that halts trading; 0 disables the breaker")
+		stratLoss   = flag.Float64("strategy-circuit-breaker-loss", 0, "Loss of a strategy's PnL within -circuit-breaker-window, as a share of its capital, that halts just that strategy; 0 disables it")
+		lossWindow  = flag.Duration("circuit-breaker-window", 15*time.Minute, "Rolling window the circuit breaker losses are measured over")
+		lossCool    = flag.Duration("circuit-breaker-cooldown", 30*time.Minute, "How long a tripped circuit breaker halts trading; 0 halts until trading is resumed")
+		queueSize   = flag.Int("queue-size", 1000, "Capacity of the engine's order and trade queues")
+		enqueueWait = flag.Duration("enqueue-timeout", 100*time.Millisecond, "How long a strategy order waits for room in a full order queue before it is rejected as ErrEngineBusy")
+		roundToGrid = flag.Bool("round-to-grid", false, "Round order quantities down to the lot size and limit prices passively to the tick size instead of rejecting off-grid orders")
+		configFile  = flag.String("config", "", "YAML or JSON file describing cash, duration, symbols and strategies; replaces the built-in setup")
+		configWatch = flag.Duration("config-watch", 0, "Interval between checks of -config for strategy changes, which are applied without a restart; 0 disables reloading")
+		seed        = flag.Int64("seed", 0, "Random seed for the simulator; 0 picks a time-based seed")
+		priceModel  = flag.String("price-model", tradealgo.PriceModelLegacy, "Simulator price model (legacy, gbm); gbm treats volatility and trend as annualized sigma and drift")
+		session     = flag.String("session", "", "Trading session as HH:MM-HH:MM on weekdays (e.g. 09:30-16:00); empty trades 24/7")
+		sessionTZ   = flag.String("session-tz", "America/New_York", "Timezone the -session hours are in")
+		alwaysOpen  = flag.String("always-open", "", "Comma-separated symbols that trade 24/7 regardless of -session")
+		openingGap  = flag.Bool("opening-gap", false, "Gap simulator prices at the session open by the overnight trend")
+		newsRate    = flag.Float64("news-rate", 0, "Random simulator news events per symbol per hour, delivered to strategies that react to news; 0 disables random news")
+		newsLag     = flag.Duration("news-lag", 5*time.Second, "Delay between a simulator news event and its price and volatility impact")
+		dayCutoff   = flag.String("day-cutoff", "", "Time of day as HH:MM in -session-tz at which DAY orders are cancelled when no -session is set; empty keeps them open")
+		feedType    = flag.String("feed", tradealgo.FeedTypeSimulator, "Market data feed to trade on (sim, binance)")
+		feedURL     = flag.String("feed-url", tradealgo.DefaultBinanceURL, "Base WebSocket URL of the binance feed")
+		feedSymbols = flag.String("symbols", "", "Comma-separated symbols to subscribe to on the binance feed (default: config symbols, or BTCUSDT,ETHUSDT)")
+		httpAddr    = flag.String("http-addr", "", "Address to serve the unauthenticated HTTP API on (e.g. 127.0.0.1:8080); besides inspection it can enable and disable strategies and adjust the simulator, so keep it on loopback; disabled when empty")
+		wsOrigins   = flag.String("ws-origins", "", "Comma-separated browser origins besides the API's own allowed to open the /ws stream (e.g. https://dash.example.com)")
+		tickInt     = flag.Duration("tick-interval", time.Second, "Interval between simulator price ticks")
+		strategyInt = flag.Duration("strategy-interval", 5*time.Second, "Interval between strategy runs; 0 runs strategies on every market data update")
+		riskInt     = flag.Duration("risk-interval", 10*time.Second, "Interval between risk checks; 0 checks risk on every market data update")
+		revalueInt  = flag.Duration("portfolio-interval", time.Second, "Interval between portfolio revaluations; 0 revalues on every market data update")
+		debounce    = flag.Duration("strategy-debounce", 0, "With -strategy-interval=0, run strategies at most once per symbol per this interval, coalescing bursts of ticks")
+		statusInt   = flag.Duration("status-interval", 30*time.Second, "Interval between portfolio status log lines")
+		optimizeArg = flag.String("optimize", "", "Parameter grid to sweep over the -backtest data as name=v1,v2;name=v1,v2 (e.g. short_period=5,10,20;long_period=30,50,100)")
+		optimizeID  = flag.String("optimize-strategy", "", "ID of the -config strategy -optimize tunes (default: the first configured strategy, or the built-in moving average)")
+		objective   = flag.String("objective", string(tradealgo.ObjectiveSharpe), "Objective -optimize ranks parameter sets by (sharpe, total_return, return_drawdown)")
+		workers     = flag.Int("optimize-workers", runtime.NumCPU(), "Backtests -optimize runs in parallel")
+		inSample    = flag.Duration("walk-forward-in", 0, "In-sample window of a walk-forward -optimize run (e.g. 2160h); 0 sweeps the whole data set once")
+		outOfSample = flag.Duration("walk-forward-out", 0, "Out-of-sample window each in-sample optimum is tested on; windows roll forward by this length")
+		monteCarlo  = flag.Int("monte-carlo", 0, "Run this many independent simulations of -duration, each with its own seed derived from -seed, and report the distribution of outcomes")
+		mcWorkers   = flag.Int("monte-carlo-workers", runtime.NumCPU(), "Simulations -monte-carlo runs in parallel")
+		warmUp      = flag.Bool("warm-up", true, "Fill strategy history before trading starts: back-dated simulator ticks, or the first bars of each -backtest symbol")
+		recordFile  = flag.String("record", "", "Run the simulator for -duration of simulated time without waiting and write every update it emits to this JSONL file")
+		replayFile  = flag.String("replay", "", "JSONL file written by -record to trade on instead of the simulator; the same settings reproduce the recorded trades")
+		replayShift = flag.Duration("replay-shift", 0, "Shift every timestamp of the -replay recording by this duration")
 	)
 	flag.Parse()
 
 	logger := setupLogger(*logLevel)
 	defer logger.Sync()
 
-	logger.Info("Starting Trade Algorithm Go", zap.Float64("initial_cash", *initialCash))
+	cash := decimal.NewFromFloat(*initialCash)
+	var appConfig *tradealgo.Config
+	if *configFile != "" {
+		loaded, err := tradealgo.LoadConfig(*configFile)
+		if err != nil {
+			logger.Fatal("Failed to load config", zap.String("config", *configFile), zap.Error(err))
+		}
+		appConfig = loaded
+
+		explicit := make(map[string]bool)
+		flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+		if !explicit["cash"] {
+			cash = appConfig.InitialCash
+		}
+		if !explicit["duration"] && appConfig.Duration.Duration > 0 {
+			*duration = appConfig.Duration.Duration
+		}
+		if !explicit["benchmark"] && appConfig.Benchmark != "" {
+			*benchmark = appConfig.Benchmark
+		}
+		if !explicit["bar-interval"] && appConfig.BarInterval.Duration > 0 {
+			*barInterval = appConfig.BarInterval.Duration
+		}
+	}
+
+	logger.Info("Starting Trade Algorithm Go", zap.String("initial_cash", cash.String()))
+
+	if *resume && *stateFile == "" {
+		logger.Fatal("-resume requires -state-file")
+	}
+	if *snapshots > 0 && *stateFile == "" {
+		logger.Fatal("-snapshot-interval requires -state-file")
+	}
+	if *statements && *exportDir == "" {
+		logger.Fatal("-daily-statements requires -export-dir")
+	}
+
+	tradingEngine, startingValue := loadTradingEngine(*stateFile, *auditFile, *resume, cash, logger)
+	if *snapshots != 0 {
+		if err := tradingEngine.SetSnapshotting(*stateFile, *snapshots); err != nil {
+			logger.Fatal("Invalid snapshot settings", zap.Error(err))
+		}
+	}
+	if *statements {
+		tradingEngine.SetStatementSink(tradealgo.NewStatementWriter(*exportDir, tradingEngine.GetPortfolio().ID))
+	}
+	engineSeed := *seed
+	if engineSeed == 0 {
+		engineSeed = time.Now().UnixNano()
+	}
+	settings := engineSettings{
+		seed:      engineSeed,
+		costBasis: tradealgo.CostBasisMethod(*costBasis),
+		varMethod: *varMethod,
+		slicing: tradealgo.SlicingConfig{
+			Algorithm: *sliceAlgo,
+			Threshold: decimal.NewFromFloat(*sliceMin),
+			Slices:    *slices,
+			Interval:  *sliceInt,
+		},
+		impactK: *impactK,
+		latency: tradealgo.LatencyConfig{
+			Model:   *latencyType,
+			Latency: *latency,
+			Jitter:  *jitter,
+			Sigma:   *sigma,
+			Seed:    engineSeed,
+		},
+		stalePrices: tradealgo.StalePriceConfig{
+			MaxAge:       *signalAge,
+			MaxDeviation: decimal.NewFromFloat(*signalDev),
+			Action:       tradealgo.StalePriceAction(*staleAction),
+		},
+		maxDrawdown: decimal.NewFromFloat(*maxDrawdown),
+		breaker: tradealgo.CircuitBreakerConfig{
+			MaxLoss:         decimal.NewFromFloat(*lossLimit),
+			StrategyMaxLoss: decimal.NewFromFloat(*stratLoss),
+			Window:          *lossWindow,
+			Cooldown:        *lossCool,
+		},
+		roundToGrid: *roundToGrid,
+		queues: tradealgo.QueueConfig{
+			OrderQueue:     *queueSize,
+			TradeQueue:     *queueSize,
+			EnqueueTimeout: *enqueueWait,
+		},
+	}
+	if appConfig != nil {
+		registry, err := appConfig.BuildSymbols()
+		if err != nil {
+			logger.Fatal("Invalid symbol metadata", zap.Error(err))
+		}
+		settings.symbols = registry
+		settings.exposure = appConfig.Exposure
+		settings.timeframes = appConfig.BuildTimeframes()
+		if currencies := appConfig.Currencies; currencies != nil {
+			settings.currencies = &tradealgo.CurrencyConfig{
+				Base:        currencies.BaseCurrency(),
+				Rates:       currencies.Rates,
+				Cash:        currencies.Cash,
+				AutoConvert: currencies.AutoConvert,
+			}
+		}
+		if err := tradingEngine.SetStressScenarios(appConfig.Stress); err != nil {
+			logger.Fatal("Invalid stress scenarios", zap.Error(err))
+		}
+	}
+	if *initMargin > 0 {
+		settings.margin = &tradealgo.MarginConfig{
+			InitialRate:     decimal.NewFromFloat(*initMargin),
+			MaintenanceRate: decimal.NewFromFloat(*maintMargin),
+			InterestRate:    decimal.NewFromFloat(*marginRate),
+		}
+	}
+	if err := configureEngine(tradingEngine, settings); err != nil {
+		logger.Fatal("Invalid engine settings", zap.Error(err))
+	}
+
+	if *tradeDB != "" {
+		store, err := tradealgo.NewSQLiteStore(*tradeDB)
+		if err != nil {
+			logger.Fatal("Failed to open trade journal", zap.String("trade_db", *tradeDB), zap.Error(err))
+		}
+		defer store.Close()
+		tradingEngine.SetTradeStore(store, *historyMax)
+	} else {
+		archive := tradealgo.NewJSONLArchive(*archiveDir)
+		defer archive.Close()
+		tradingEngine.SetHistoryLimit(*historyMax)
+		tradingEngine.SetArchive(archive)
+	}
+
+	if *auditFile != "" {
+		auditOptions := []tradealgo.AuditOption{tradealgo.WithAuditMaxSize(*auditSize), tradealgo.WithAuditMaxBackups(*auditKeep)}
+		if *snapshots > 0 {
+			auditOptions = append(auditOptions, tradealgo.WithAuditUnbuffered())
+		}
+		auditLog, err := tradealgo.NewAuditWriter(*auditFile, auditOptions...)
+		if err != nil {
+			logger.Fatal("Failed to open audit log", zap.String("audit_log", *auditFile), zap.Error(err))
+		}
+		defer auditLog.Close()
+		tradingEngine.SetAuditLog(auditLog)
+	}
+
+	if *optimizeArg != "" {
+		if *backtestDir == "" {
+			logger.Fatal("-optimize requires -backtest")
+		}
+		runOptimization(appConfig, settings, optimization{
+			grid:        *optimizeArg,
+			strategyID:  *optimizeID,
+			objective:   *objective,
+			workers:     *workers,
+			walkForward: tradealgo.WalkForwardConfig{InSample: *inSample, OutOfSample: *outOfSample},
+		}, *backtestDir, *exportDir, cash, logger)
+		return
+	}
+
+	if *monteCarlo > 0 {
+		masterSeed := *seed
+		if masterSeed == 0 {
+			masterSeed = time.Now().UnixNano()
+		}
+		tradingCalendar := setupCalendar(*session, *sessionTZ, *alwaysOpen, settings.symbols, logger)
+		simOptions := simulatorOptions(*priceModel, masterSeed, *tickInt, tradingCalendar, settings.symbols, *openingGap, *newsRate, *newsLag, logger)
+		runMonteCarlo(appConfig, settings, simOptions, tradingCalendar, monteCarloStudy{
+			runs:    *monteCarlo,
+			seed:    masterSeed,
+			ticks:   int(*duration / *tickInt),
+			workers: *mcWorkers,
+		}, *exportDir, cash, logger)
+		return
+	}
+
+	if *backtestDir != "" {
+		runBacktest(tradingEngine, appConfig, *backtestDir, *benchmark, *exportDir, *stateFile, *warmUp, startingValue, logger)
+		return
+	}
+
+	if *recordFile != "" || *replayFile != "" {
+		if *recordFile != "" && (*replayFile != "" || *feedType != tradealgo.FeedTypeSimulator) {
+			logger.Fatal("-record requires the simulator feed and cannot be combined with -replay")
+		}
+		tradingCalendar := setupCalendar(*session, *sessionTZ, *alwaysOpen, settings.symbols, logger)
+		runRecorded(tradingEngine, appConfig, recordedRun{
+			record:      *recordFile,
+			replay:      *replayFile,
+			shift:       *replayShift,
+			simOptions:  simulatorOptions(*priceModel, *seed, *tickInt, tradingCalendar, settings.symbols, *openingGap, *newsRate, *newsLag, logger),
+			ticks:       int(*duration / *tickInt),
+			calendar:    tradingCalendar,
+			intervals:   tradealgo.Intervals{Strategy: *strategyInt, Risk: *riskInt, Portfolio: *revalueInt, Debounce: *debounce},
+			benchmark:   *benchmark,
+			barInterval: *barInterval,
+			warmUp:      *warmUp,
+		}, *exportDir, *stateFile, startingValue, logger)
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), *duration)
 	defer cancel()
 
-	tradingEngine := engine.NewTradingEngine(decimal.NewFromFloat(*initialCash), logger)
-	marketSimulator := simulator.NewMarketSimulator(logger)
+	var engineMetrics *tradealgo.Metrics
+	if *httpAddr != "" {
+		engineMetrics = tradealgo.NewMetrics()
+		tradingEngine.SetMetrics(engineMetrics)
+	}
+
+	tradingCalendar := setupCalendar(*session, *sessionTZ, *alwaysOpen, settings.symbols, logger)
+	tradingEngine.SetCalendar(tradingCalendar)
+	if tradingCalendar == nil && *dayCutoff != "" {
+		setupDayCutoff(tradingEngine, *dayCutoff, *sessionTZ, logger)
+	}
+	tradingEngine.SetIntervals(tradealgo.Intervals{Strategy: *strategyInt, Risk: *riskInt, Portfolio: *revalueInt, Debounce: *debounce})
+	if dispatcher := setupAlerts(tradingEngine, appConfig, logger); dispatcher != nil {
+		defer dispatcher.Close()
+	}
 
-	setupSymbols(marketSimulator, logger)
-	setupStrategies(tradingEngine, logger)
+	simOptions := simulatorOptions(*priceModel, *seed, *tickInt, tradingCalendar, settings.symbols, *openingGap, *newsRate, *newsLag, logger)
+	marketFeed := setupFeed(*feedType, *feedURL, *feedSymbols, simOptions, *barInterval, appConfig, engineMetrics, logger)
+	setupStrategies(tradingEngine, appConfig, logger)
+	tradingEngine.SetBenchmark(*benchmark, betaLookback)
+	if *warmUp {
+		warmUpHistory(tradingEngine, marketFeed, logger)
+	}
+	if appConfig != nil && *configWatch > 0 {
+		go tradealgo.NewConfigWatcher(*configFile, appConfig, tradingEngine, logger).Run(ctx, *configWatch)
+	}
+
+	var apiServer *tradealgo.APIServer
+	if *httpAddr != "" {
+		apiServer = tradealgo.NewAPIServer(*httpAddr, tradingEngine, logger)
+		if reporter, ok := marketFeed.(tradealgo.StatusReporter); ok {
+			apiServer.SetFeed(reporter)
+		}
+		if sim, ok := marketFeed.(tradealgo.APISimulator); ok {
+			apiServer.SetSimulator(sim)
+		}
+		apiServer.Handle("/ws", tradealgo.NewStreamHandler(tradingEngine.Events(), logger, originList(*wsOrigins)...))
+		apiServer.Handle("/metrics", engineMetrics.Handler())
+		apiServer.Start()
+	}
 
 	if err := tradingEngine.Start(ctx); err != nil {
 		logger.Fatal("Failed to start trading engine", zap.Error(err))
 	}
 
-	marketSimulator.Start()
+	if err := marketFeed.Start(ctx); err != nil {
+		logger.Fatal("Failed to start market data feed", zap.String("feed", *feedType), zap.Error(err))
+	}
+
+	go handleMarketUpdates(tradingEngine, marketFeed, *barInterval > 0, logger)
+	go printPortfolioStatus(tradingEngine, *statusInt, logger)
+
+	handleShutdown(ctx, tradingEngine, marketFeed, apiServer, *exportDir, *stateFile, startingValue, logger)
+}
+
+type engineSettings struct {
+	seed        int64
+	costBasis   tradealgo.CostBasisMethod
+	varMethod   string
+	slicing     tradealgo.SlicingConfig
+	impactK     float64
+	latency     tradealgo.LatencyConfig
+	symbols     *tradealgo.SymbolRegistry
+	margin      *tradealgo.MarginConfig
+	exposure    *tradealgo.ExposureLimits
+	stalePrices tradealgo.StalePriceConfig
+	maxDrawdown decimal.Decimal
+	breaker     tradealgo.CircuitBreakerConfig
+	roundToGrid bool
+	timeframes  []time.Duration
+	queues      tradealgo.QueueConfig
+	currencies  *tradealgo.CurrencyConfig
+}
+
+func configureEngine(tradingEngine *tradealgo.TradingEngine, settings engineSettings) error {
+	if err := tradingEngine.SetQueueConfig(settings.queues); err != nil {
+		return fmt.Errorf("queues: %w", err)
+	}
+	if err := tradingEngine.SetCostBasisMethod(settings.costBasis); err != nil {
+		return fmt.Errorf("cost basis method: %w", err)
+	}
+	varModel, err := tradealgo.NewVaRModel(settings.varMethod, 1)
+	if err != nil {
+		return fmt.Errorf("VaR method: %w", err)
+	}
+	tradingEngine.SetVaRModel(varModel)
+	tradingEngine.SetSlippageModel(tradealgo.NewSeededSlippage(settings.seed))
+	if err := tradingEngine.SetOrderSlicing(settings.slicing); err != nil {
+		return fmt.Errorf("order slicing: %w", err)
+	}
+	if settings.impactK < 0 {
+		return fmt.Errorf("-impact-k must not be negative")
+	}
+	if settings.impactK > 0 {
+		tradingEngine.SetImpactModel(tradealgo.NewSquareRootImpact(decimal.NewFromFloat(settings.impactK)))
+	}
+	latencyModel, err := tradealgo.NewLatencyModel(settings.latency)
+	if err != nil {
+		return fmt.Errorf("fill latency: %w", err)
+	}
+	if latencyModel != nil {
+		tradingEngine.SetLatencyModel(latencyModel)
+	}
+	if settings.symbols != nil {
+		tradingEngine.SetSymbols(settings.symbols)
+	}
+	if settings.currencies != nil {
+		if err := tradingEngine.SetCurrencies(*settings.currencies); err != nil {
+			return fmt.Errorf("currencies: %w", err)
+		}
+	}
+	tradingEngine.SetGridRounding(settings.roundToGrid)
+	if settings.margin != nil {
+		if err := tradingEngine.SetMargin(*settings.margin); err != nil {
+			return fmt.Errorf("margin account: %w", err)
+		}
+	}
+	if settings.exposure != nil {
+		if err := tradingEngine.SetExposureLimits(*settings.exposure); err != nil {
+			return fmt.Errorf("exposure limits: %w", err)
+		}
+	}
+	if err := tradingEngine.SetStalePriceCheck(settings.stalePrices); err != nil {
+		return fmt.Errorf("stale price check: %w", err)
+	}
+	if err := tradingEngine.SetMaxPortfolioDrawdown(settings.maxDrawdown); err != nil {
+		return fmt.Errorf("portfolio drawdown limit: %w", err)
+	}
+	if err := tradingEngine.SetCircuitBreaker(settings.breaker); err != nil {
+		return fmt.Errorf("circuit breaker: %w", err)
+	}
+	if err := tradingEngine.SetTimeframes(settings.timeframes); err != nil {
+		return fmt.Errorf("timeframes: %w", err)
+	}
+	return nil
+}
+
+func loadTradingEngine(stateFile, auditFile string, resume bool, initialCash decimal.Decimal, logger *zap.Logger) (*tradealgo.TradingEngine, decimal.Decimal) {
+	if resume {
+		if _, err := os.Stat(stateFile); err == nil {
+			tradingEngine, err := tradealgo.RecoverTradingEngine(stateFile, auditFile, logger)
+			if err != nil {
+				logger.Fatal("Failed to resume portfolio state", zap.String("state_file", stateFile), zap.Error(err))
+			}
+			portfolio := tradingEngine.GetPortfolio()
+			logger.Info("Resumed portfolio state",
+				zap.String("state_file", stateFile),
+				zap.String("portfolio_id", portfolio.ID),
+				zap.String("total_value", portfolio.TotalValue.String()),
+			)
+			return tradingEngine, portfolio.TotalValue
+		}
+		logger.Info("No state file found, starting fresh", zap.String("state_file", stateFile))
+	}
+
+	return tradealgo.NewTradingEngine(initialCash, logger), initialCash
+}
+
+func saveState(tradingEngine *tradealgo.TradingEngine, stateFile string, logger *zap.Logger) {
+	if stateFile == "" {
+		return
+	}
 
-	go handleMarketUpdates(tradingEngine, marketSimulator, logger)
-	go printPortfolioStatus(tradingEngine, logger)
+	if err := tradingEngine.SaveState(stateFile); err != nil {
+		logger.Error("Failed to save portfolio state", zap.String("state_file", stateFile), zap.Error(err))
+		return
+	}
+	logger.Info("Portfolio state saved", zap.String("state_file", stateFile))
+}
 
-	handleShutdown(ctx, tradingEngine, marketSimulator, logger)
+func warmUpHistory(tradingEngine *tradealgo.TradingEngine, marketFeed tradealgo.MarketDataFeed, logger *zap.Logger) {
+	var preload func(symbol string) ([]*tradealgo.MarketData, error)
+	switch source := marketFeed.(type) {
+	case interface {
+		PreloadHistory(symbol string, n int) ([]*tradealgo.MarketData, error)
+	}:
+		bars := tradingEngine.RequiredHistory()
+		preload = func(symbol string) ([]*tradealgo.MarketData, error) { return source.PreloadHistory(symbol, bars) }
+	case *tradealgo.ReplayFeed:
+		preload = func(symbol string) ([]*tradealgo.MarketData, error) { return source.WarmUp(symbol), nil }
+	default:
+		return
+	}
+
+	for _, symbol := range marketFeed.Symbols() {
+		history, err := preload(symbol)
+		if err == nil {
+			err = tradingEngine.SeedMarketHistory(symbol, history)
+		}
+		if err != nil {
+			logger.Fatal("Failed to warm up market history", zap.String("symbol", symbol), zap.Error(err))
+		}
+	}
+}
+
+func runBacktest(tradingEngine *tradealgo.TradingEngine, appConfig *tradealgo.Config, dir, benchmark, exportDir, stateFile string, warmUp bool, initialCash decimal.Decimal, logger *zap.Logger) {
+	data, err := tradealgo.LoadBars(dir)
+	if err != nil {
+		logger.Fatal("Failed to load backtest data", zap.String("dir", dir), zap.Error(err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	setupStrategies(tradingEngine, appConfig, logger)
+	tradingEngine.SetBenchmark(benchmark, betaLookback)
+
+	var options []tradealgo.BacktestOption
+	if warmUp {
+		options = append(options, tradealgo.WithWarmUp(tradingEngine.RequiredHistory()))
+	}
+	replayer := tradealgo.NewReplayer(data, logger)
+	replayer.Start(ctx)
+
+	portfolio, err := tradealgo.NewBacktestRunner(tradingEngine, logger, options...).Run(ctx, replayer.GetUpdateChannel())
+	if err != nil {
+		logger.Warn("Backtest stopped early", zap.Error(err))
+	}
+	tradingEngine.Stop()
+
+	logFinalSummary(portfolio, initialCash, logger)
+	logPerformanceReport(performanceReport(tradingEngine, portfolio), logger)
+	logStressTests(tradingEngine.RunStressTests(), logger)
+	exportResults(exportDir, portfolio, tradingEngine.GetEquityCurve(), tradingEngine.Symbols(), logger)
+	saveState(tradingEngine, stateFile, logger)
+}
+
+type recordedRun struct {
+	record      string
+	replay      string
+	shift       time.Duration
+	simOptions  []tradealgo.SimulatorOption
+	ticks       int
+	calendar    *tradealgo.Calendar
+	intervals   tradealgo.Intervals
+	benchmark   string
+	barInterval time.Duration
+	warmUp      bool
+}
+
+func runRecorded(tradingEngine *tradealgo.TradingEngine, appConfig *tradealgo.Config, run recordedRun, exportDir, stateFile string, initialCash decimal.Decimal, logger *zap.Logger) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	tradingEngine.SetCalendar(run.calendar)
+	tradingEngine.SetIntervals(run.intervals)
+	setupStrategies(tradingEngine, appConfig, logger)
+	tradingEngine.SetBenchmark(run.benchmark, betaLookback)
+
+	var marketFeed tradealgo.MarketDataFeed
+	if run.replay != "" {
+		replayFeed, err := tradealgo.NewReplayFeed(run.replay, logger)
+		if err != nil {
+			logger.Fatal("Failed to load recording", zap.String("replay", run.replay), zap.Error(err))
+		}
+		replayFeed.SetTimeShift(run.shift)
+		marketFeed = replayFeed
+	} else {
+		file, err := os.Create(run.record)
+		if err != nil {
+			logger.Fatal("Failed to create recording", zap.String("record", run.record), zap.Error(err))
+		}
+		recording := bufio.NewWriter(file)
+		defer func() {
+			if err := recording.Flush(); err == nil {
+				err = file.Close()
+			}
+			if err != nil {
+				logger.Error("Failed to write recording", zap.String("record", run.record), zap.Error(err))
+				return
+			}
+			logger.Info("Market data recorded", zap.String("record", run.record))
+		}()
+
+		fake := tradealgo.NewFakeClock(time.Now().UTC().Truncate(time.Second))
+		marketSimulator := tradealgo.NewMarketSimulator(logger, append(run.simOptions, tradealgo.WithSimulatorClock(fake), tradealgo.WithRecorder(recording))...)
+		setupSymbols(marketSimulator, appConfig, logger)
+		marketSimulator.SetBarInterval(run.barInterval)
+		marketFeed = tradealgo.NewSteppedFeed(marketSimulator, fake, run.ticks)
+	}
+	if run.warmUp {
+		warmUpHistory(tradingEngine, marketFeed, logger)
+	}
+
+	if err := marketFeed.Start(ctx); err != nil {
+		logger.Fatal("Failed to start market data feed", zap.Error(err))
+	}
+	portfolio, err := tradealgo.NewBacktestRunner(tradingEngine, logger).Run(ctx, tradedUpdates(marketFeed.Updates(), run.barInterval > 0))
+	if err != nil {
+		logger.Warn("Run stopped early", zap.Error(err))
+	}
+	marketFeed.Stop()
+	tradingEngine.Stop()
+
+	logFinalSummary(portfolio, initialCash, logger)
+	logPerformanceReport(performanceReport(tradingEngine, portfolio), logger)
+	exportResults(exportDir, portfolio, tradingEngine.GetEquityCurve(), tradingEngine.Symbols(), logger)
+	saveState(tradingEngine, stateFile, logger)
+}
+
+func tradedUpdates(updates <-chan *tradealgo.MarketData, useBars bool) <-chan *tradealgo.MarketData {
+	kind := tradealgo.MarketDataKindTick
+	if useBars {
+		kind = tradealgo.MarketDataKindBar
+	}
+
+	traded := make(chan *tradealgo.MarketData, cap(updates))
+	go func() {
+		defer close(traded)
+		for marketData := range updates {
+			if marketData.CorporateAction == nil && marketData.News == nil && marketData.Kind != kind {
+				continue
+			}
+			traded <- marketData
+		}
+	}()
+	return traded
+}
+
+type optimization struct {
+	grid        string
+	strategyID  string
+	objective   string
+	workers     int
+	walkForward tradealgo.WalkForwardConfig
+}
+
+func runOptimization(appConfig *tradealgo.Config, settings engineSettings, options optimization, dir, exportDir string, initialCash decimal.Decimal, logger *zap.Logger) {
+	grid, err := tradealgo.ParseGrid(options.grid)
+	if err != nil {
+		logger.Fatal("Invalid -optimize grid", zap.Error(err))
+	}
+	objective, err := tradealgo.ParseObjective(options.objective)
+	if err != nil {
+		logger.Fatal("Invalid -objective", zap.Error(err))
+	}
+	data, err := tradealgo.LoadBars(dir)
+	if err != nil {
+		logger.Fatal("Failed to load backtest data", zap.String("dir", dir), zap.Error(err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	newEngine := func() (*tradealgo.TradingEngine, error) {
+		tradingEngine := tradealgo.NewTradingEngine(initialCash, zap.NewNop())
+		return tradingEngine, configureEngine(tradingEngine, settings)
+	}
+	optimizer := tradealgo.NewOptimizer(data, optimizationStrategy(appConfig, options.strategyID), initialCash, logger,
+		tradealgo.WithOptimizerEngine(newEngine), tradealgo.WithObjective(objective), tradealgo.WithOptimizerWorkers(options.workers))
+
+	if options.walkForward.InSample > 0 || options.walkForward.OutOfSample > 0 {
+		report, err := optimizer.WalkForward(ctx, grid, options.walkForward)
+		if err != nil {
+			logger.Fatal("Walk-forward optimization failed", zap.Error(err))
+		}
+		for _, window := range report.Windows {
+			logger.Info("Walk-forward window",
+				zap.Time("out_of_sample_start", window.OutOfSampleStart),
+				zap.String("params", window.Params.String()),
+				zap.String("in_sample_score", window.InSampleScore.String()),
+				zap.String("out_of_sample_score", window.OutOfSample.Score.String()),
+				zap.String("out_of_sample_return", window.OutOfSample.TotalReturn.String()))
+		}
+		if exportDir != "" {
+			paths, err := tradealgo.WriteWalkForward(exportDir, report)
+			logExport(exportDir, paths, err, logger)
+		}
+		return
+	}
+
+	results, err := optimizer.Run(ctx, grid)
+	if err != nil {
+		logger.Fatal("Parameter sweep failed", zap.Error(err))
+	}
+	for _, result := range results {
+		logger.Info("Parameter set",
+			zap.Int("rank", result.Rank),
+			zap.String("params", result.Params.String()),
+			zap.String("score", result.Score.String()),
+			zap.String("total_return", result.TotalReturn.String()),
+			zap.String("sharpe_ratio", result.SharpeRatio.String()),
+			zap.String("max_drawdown", result.MaxDrawdown.String()),
+			zap.Int("total_trades", result.TotalTrades))
+	}
+	if exportDir != "" {
+		paths, err := tradealgo.WriteOptimizationResults(exportDir, results)
+		logExport(exportDir, paths, err, logger)
+	}
+}
+
+type monteCarloStudy struct {
+	runs    int
+	seed    int64
+	ticks   int
+	workers int
+}
+
+func runMonteCarlo(appConfig *tradealgo.Config, settings engineSettings, simOptions []tradealgo.SimulatorOption, tradingCalendar *tradealgo.Calendar, study monteCarloStudy, exportDir string, initialCash decimal.Decimal, logger *zap.Logger) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	nop := zap.NewNop()
+	newSimulator := func(opts ...tradealgo.SimulatorOption) (*tradealgo.MarketSimulator, error) {
+		marketSimulator := tradealgo.NewMarketSimulator(nop, append(append([]tradealgo.SimulatorOption(nil), simOptions...), opts...)...)
+		setupSymbols(marketSimulator, appConfig, nop)
+		return marketSimulator, nil
+	}
+	newEngine := func() (*tradealgo.TradingEngine, error) {
+		tradingEngine := tradealgo.NewTradingEngine(initialCash, nop)
+		if err := configureEngine(tradingEngine, settings); err != nil {
+			return nil, err
+		}
+		tradingEngine.SetCalendar(tradingCalendar)
+		setupStrategies(tradingEngine, appConfig, nop)
+		return tradingEngine, nil
+	}
+
+	runner := tradealgo.NewMonteCarloRunner(newSimulator, newEngine, study.ticks, logger, tradealgo.WithMonteCarloWorkers(study.workers))
+	result, err := runner.Run(ctx, study.runs, study.seed)
+	if err != nil {
+		logger.Fatal("Monte Carlo study failed", zap.Error(err))
+	}
+
+	summary := result.Summary
+	logger.Info("Monte Carlo Report",
+		zap.Int("runs", summary.Runs),
+		zap.Int64("seed", result.Seed),
+		zap.Int("ticks", result.Ticks),
+		zap.String("mean_return", summary.MeanReturn.String()),
+		zap.String("median_return", summary.MedianReturn.String()),
+		zap.String("stddev_return", summary.StdDevReturn.String()),
+		zap.String("percentile_5_return", summary.Percentile5.String()),
+		zap.String("percentile_95_return", summary.Percentile95.String()),
+		zap.String("probability_of_loss", summary.ProbabilityOfLoss.String()),
+		zap.String("worst_drawdown", summary.WorstDrawdown.String()),
+		zap.String("mean_sharpe", summary.MeanSharpe.String()),
+		zap.String("mean_trades", summary.MeanTrades.String()))
+	if exportDir != "" {
+		paths, err := tradealgo.WriteMonteCarloStudy(exportDir, result)
+		logExport(exportDir, paths, err, logger)
+	}
+}
+
+func optimizationStrategy(appConfig *tradealgo.Config, strategyID string) tradealgo.StrategyBuilder {
+	if appConfig == nil {
+		return func() (tradealgo.Strategy, error) {
+			return tradealgo.NewMovingAverageStrategy(defaultStrategyConfig()), nil
+		}
+	}
+	if strategyID == "" && len(appConfig.Strategies) > 0 {
+		strategyID = appConfig.Strategies[0].ID
+	}
+	return func() (tradealgo.Strategy, error) {
+		return appConfig.BuildStrategy(strategyID)
+	}
 }
 
 func setupLogger(level string) *zap.Logger {
@@ -78,7 +792,133 @@ func setupLogger(level string) *zap.Logger {
 	return logger
 }
 
-func setupSymbols(simulator *simulator.MarketSimulator, logger *zap.Logger) {
+func setupCalendar(session, timezone, alwaysOpen string, registry *tradealgo.SymbolRegistry, logger *zap.Logger) *tradealgo.Calendar {
+	if session == "" {
+		return nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		logger.Fatal("Invalid session timezone", zap.String("timezone", timezone), zap.Error(err))
+	}
+	defaultSession, err := tradealgo.ParseSession(session, location)
+	if err != nil {
+		logger.Fatal("Invalid trading session", zap.Error(err))
+	}
+
+	tradingCalendar := tradealgo.NewCalendar(defaultSession)
+	if alwaysOpen != "" {
+		for _, symbol := range strings.Split(alwaysOpen, ",") {
+			tradingCalendar.SetSession(strings.TrimSpace(symbol), tradealgo.AlwaysOpen())
+		}
+	}
+	for _, instrument := range registry.Instruments() {
+		if instrument.SessionType == tradealgo.SessionTypeContinuous {
+			tradingCalendar.SetSession(instrument.Symbol, tradealgo.AlwaysOpen())
+		}
+	}
+	logger.Info("Trading session configured", zap.String("session", session), zap.String("timezone", timezone), zap.String("always_open", alwaysOpen))
+	return tradingCalendar
+}
+
+func setupDayCutoff(tradingEngine *tradealgo.TradingEngine, cutoff, timezone string, logger *zap.Logger) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		logger.Fatal("Invalid session timezone", zap.String("timezone", timezone), zap.Error(err))
+	}
+	parsed, err := time.Parse("15:04", cutoff)
+	if err != nil {
+		logger.Fatal("Invalid day order cutoff", zap.String("cutoff", cutoff), zap.Error(err))
+	}
+	tradingEngine.SetDayOrderCutoff(time.Duration(parsed.Hour())*time.Hour+time.Duration(parsed.Minute())*time.Minute, location)
+}
+
+func simulatorOptions(priceModelName string, seed int64, tickInterval time.Duration, tradingCalendar *tradealgo.Calendar, registry *tradealgo.SymbolRegistry, openingGap bool, newsRate float64, newsLag time.Duration, logger *zap.Logger) []tradealgo.SimulatorOption {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	logger.Info("Simulator seeded", zap.Int64("seed", seed))
+	priceModel, err := tradealgo.NewPriceModel(priceModelName)
+	if err != nil {
+		logger.Fatal("Invalid price model", zap.Error(err))
+	}
+
+	options := []tradealgo.SimulatorOption{tradealgo.WithSeed(seed), tradealgo.WithPriceModel(priceModel), tradealgo.WithTickInterval(tickInterval), tradealgo.WithNewsLag(newsLag), tradealgo.WithRandomNews(newsRate), tradealgo.WithSymbols(registry)}
+	if tradingCalendar != nil {
+		options = append(options, tradealgo.WithCalendar(tradingCalendar))
+	}
+	if openingGap {
+		options = append(options, tradealgo.WithOpeningGap())
+	}
+	return options
+}
+
+func setupFeed(feedType, feedURL, symbols string, simOptions []tradealgo.SimulatorOption, barInterval time.Duration, appConfig *tradealgo.Config, engineMetrics *tradealgo.Metrics, logger *zap.Logger) tradealgo.MarketDataFeed {
+	switch feedType {
+	case tradealgo.FeedTypeSimulator:
+		marketSimulator := tradealgo.NewMarketSimulator(logger, simOptions...)
+		setupSymbols(marketSimulator, appConfig, logger)
+		marketSimulator.SetBarInterval(barInterval)
+		marketSimulator.SetMetrics(engineMetrics)
+		return marketSimulator
+	case tradealgo.FeedTypeBinance:
+		binanceFeed := tradealgo.NewBinanceFeed(feedURL, feedSymbolList(symbols, appConfig), logger)
+		binanceFeed.SetBarInterval(barInterval)
+		binanceFeed.SetMetrics(engineMetrics)
+		return binanceFeed
+	}
+
+	logger.Fatal("Unknown market data feed", zap.String("feed", feedType))
+	return nil
+}
+
+func originList(origins string) []string {
+	if origins == "" {
+		return nil
+	}
+	return strings.Split(origins, ",")
+}
+
+func feedSymbolList(symbols string, appConfig *tradealgo.Config) []string {
+	if symbols != "" {
+		return strings.Split(symbols, ",")
+	}
+	if appConfig != nil && len(appConfig.Symbols) > 0 {
+		list := make([]string, len(appConfig.Symbols))
+		for i, symbol := range appConfig.Symbols {
+			list[i] = symbol.Symbol
+		}
+		return list
+	}
+	return []string{"BTCUSDT", "ETHUSDT"}
+}
+
+func setupSymbols(simulator *tradealgo.MarketSimulator, appConfig *tradealgo.Config, logger *zap.Logger) {
+	if appConfig != nil {
+		for _, symbol := range appConfig.Symbols {
+			simulator.AddSymbol(symbol.Symbol, symbol.BasePrice, symbol.Volatility)
+			if !symbol.Trend.IsZero() {
+				simulator.SetTrend(symbol.Symbol, symbol.Trend)
+			}
+			logger.Info("Symbol configured", zap.String("symbol", symbol.Symbol), zap.String("base_price", symbol.BasePrice.String()))
+		}
+		if err := simulator.SetCorrelations(appConfig.Correlations); err != nil {
+			logger.Fatal("Invalid symbol correlations", zap.Error(err))
+		}
+		for _, action := range appConfig.Actions {
+			var err error
+			if action.Type == tradealgo.CorporateActionDividend {
+				err = simulator.ScheduleDividend(action.Symbol, action.Amount, action.Date)
+			} else {
+				err = simulator.ScheduleSplit(action.Symbol, action.Ratio, action.Date)
+			}
+			if err != nil {
+				logger.Fatal("Invalid corporate action", zap.Error(err))
+			}
+		}
+		return
+	}
+
 	symbols := map[string]struct {
 		basePrice  float64
 		volatility float64
@@ -91,6 +931,7 @@ func setupSymbols(simulator *simulator.MarketSimulator, logger *zap.Logger) {
 		"NFLX":  {500.0, 0.035},
 		"NVDA":  {600.0, 0.03},
 		"META":  {350.0, 0.028},
+		"SPY":   {450.0, 0.012},
 	}
 
 	for symbol, data := range symbols {
@@ -99,75 +940,161 @@ func setupSymbols(simulator *simulator.MarketSimulator, logger *zap.Logger) {
 	}
 }
 
-func setupStrategies(engine *engine.TradingEngine, logger *zap.Logger) {
-	movingAvgConfig := &models.StrategyConfig{
-		ID:                    "ma_crossover_001",
-		Name:                  "Moving Average Crossover",
-		MaxPositionSize:       decimal.NewFromFloat(0.2),
-		MaxPortfolioRisk:      decimal.NewFromFloat(0.15),
-		MaxDrawdown:           decimal.NewFromFloat(0.1),
-		StopLossPercent:       decimal.NewFromFloat(0.05),
-		TakeProfitPercent:     decimal.NewFromFloat(0.1),
-		TrailingStopPercent:   decimal.NewFromFloat(0.03),
-		RebalanceThreshold:    decimal.NewFromFloat(0.05),
-		MaxOrdersPerDay:       50,
-		MinOrderSize:          decimal.NewFromFloat(1000.0),
-		MaxOrderSize:          decimal.NewFromFloat(10000.0),
-		CommissionRate:        decimal.NewFromFloat(0.001),
-		SlippageTolerance:     decimal.NewFromFloat(0.002),
-		RiskFreeRate:          decimal.NewFromFloat(0.02),
-		MarketDataWindow:      30,
-		TechnicalIndicators:   []string{"SMA", "EMA", "RSI"},
-		Enabled:               true,
-		CreatedAt:             time.Now(),
-		UpdatedAt:             time.Now(),
-	}
-
-	movingAvgStrategy := strategies.NewMovingAverageStrategy(movingAvgConfig)
+func setupAlerts(tradingEngine *tradealgo.TradingEngine, appConfig *tradealgo.Config, logger *zap.Logger) *tradealgo.AlertDispatcher {
+	if appConfig == nil || appConfig.Alerts == nil {
+		return nil
+	}
+	settings := appConfig.Alerts
+	alerters, err := settings.BuildAlerters()
+	if err != nil {
+		logger.Fatal("Invalid alert webhooks", zap.Error(err))
+	}
+
+	var options []tradealgo.AlertOption
+	if settings.Cooldown.Duration > 0 {
+		options = append(options, tradealgo.WithAlertCooldown(settings.Cooldown.Duration))
+	}
+	if settings.Retries > 0 {
+		options = append(options, tradealgo.WithAlertRetries(settings.Retries))
+	}
+	dispatcher := tradealgo.NewAlertDispatcher(alerters, logger, options...)
+	if err := tradingEngine.SetAlerts(dispatcher, tradealgo.AlertConfig{
+		Types:              settings.Events,
+		RejectionThreshold: settings.RejectionThreshold,
+		RejectionWindow:    settings.RejectionWindow.Duration,
+		LargeLoss:          settings.LargeLoss,
+	}); err != nil {
+		logger.Fatal("Invalid alert settings", zap.Error(err))
+	}
+	logger.Info("Alerts enabled", zap.Int("webhooks", len(alerters)))
+	return dispatcher
+}
+
+func setupStrategies(engine *tradealgo.TradingEngine, appConfig *tradealgo.Config, logger *zap.Logger) {
+	if appConfig != nil {
+		configured, err := appConfig.BuildStrategies()
+		if err != nil {
+			logger.Fatal("Failed to build strategies", zap.Error(err))
+		}
+		for i, strategy := range configured {
+			if allocation := appConfig.Strategies[i].Allocation; allocation.IsPositive() {
+				if err := engine.AddStrategyWithAllocation(strategy, allocation); err != nil {
+					logger.Fatal("Failed to allocate strategy", zap.String("strategy_id", strategy.ID()), zap.Error(err))
+				}
+			} else {
+				engine.AddStrategy(strategy)
+			}
+			logger.Info("Strategy configured", zap.String("strategy_id", strategy.ID()), zap.String("name", strategy.Name()))
+		}
+		return
+	}
+
+	movingAvgStrategy := tradealgo.NewMovingAverageStrategy(defaultStrategyConfig())
 	engine.AddStrategy(movingAvgStrategy)
 
 	logger.Info("Strategy configured", zap.String("strategy_id", movingAvgStrategy.ID()), zap.String("name", movingAvgStrategy.Name()))
 }
 
-func handleMarketUpdates(engine *engine.TradingEngine, simulator *simulator.MarketSimulator, logger *zap.Logger) {
-	updateChan := simulator.GetUpdateChannel()
+func defaultStrategyConfig() *tradealgo.StrategyConfig {
+	return &tradealgo.StrategyConfig{
+		ID:                  "ma_crossover_001",
+		Name:                "Moving Average Crossover",
+		MaxPositionSize:     decimal.NewFromFloat(0.2),
+		MaxPortfolioRisk:    decimal.NewFromFloat(0.15),
+		MaxDrawdown:         decimal.NewFromFloat(0.1),
+		StopLossPercent:     decimal.NewFromFloat(0.05),
+		TakeProfitPercent:   decimal.NewFromFloat(0.1),
+		TrailingStopPercent: decimal.NewFromFloat(0.03),
+		RebalanceThreshold:  decimal.NewFromFloat(0.05),
+		MaxOrdersPerDay:     50,
+		MinOrderSize:        decimal.NewFromFloat(1000.0),
+		MaxOrderSize:        decimal.NewFromFloat(10000.0),
+		CommissionRate:      decimal.NewFromFloat(0.001),
+		SlippageTolerance:   decimal.NewFromFloat(0.002),
+		RiskFreeRate:        decimal.NewFromFloat(0.02),
+		MarketDataWindow:    30,
+		TechnicalIndicators: []string{"SMA", "EMA", "RSI"},
+		Enabled:             true,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+	}
+}
+
+func handleMarketUpdates(engine *tradealgo.TradingEngine, marketFeed tradealgo.MarketDataFeed, useBars bool, logger *zap.Logger) {
+	kind := tradealgo.MarketDataKindTick
+	if useBars {
+		kind = tradealgo.MarketDataKindBar
+	}
+
+	updateChan := marketFeed.Updates()
 	for marketData := range updateChan {
+		if marketData.CorporateAction != nil {
+			if err := engine.ApplyCorporateAction(*marketData.CorporateAction); err != nil {
+				logger.Warn("Corporate action skipped", zap.String("symbol", marketData.Symbol), zap.Error(err))
+			}
+			continue
+		}
+		if marketData.News != nil {
+			engine.ProcessNews(*marketData.News)
+			continue
+		}
+		if marketData.Kind != kind {
+			continue
+		}
 		engine.UpdateMarketData(marketData.Symbol, marketData)
 	}
 }
 
-func printPortfolioStatus(engine *engine.TradingEngine, logger *zap.Logger) {
-	ticker := time.NewTicker(30 * time.Second)
+func printPortfolioStatus(engine *tradealgo.TradingEngine, interval time.Duration, logger *zap.Logger) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for range ticker.C {
-		portfolio := engine.GetPortfolio()
-		
+		portfolio := engine.GetPortfolioSummary()
+
 		logger.Info("Portfolio Status",
 			zap.String("portfolio_id", portfolio.ID),
 			zap.String("total_value", portfolio.TotalValue.String()),
+			zap.String("base_currency", portfolio.BaseCurrency),
 			zap.String("cash", portfolio.Cash.String()),
+			zap.Any("cash_balances", portfolio.CashBalances),
 			zap.String("unrealized_pnl", portfolio.UnrealizedPnL.String()),
 			zap.String("realized_pnl", portfolio.RealizedPnL.String()),
 			zap.String("total_risk", portfolio.TotalRisk.String()),
+			zap.String("total_var_95", portfolio.RiskMetrics.TotalVaR95.String()),
+			zap.String("portfolio_beta", portfolio.RiskMetrics.PortfolioBeta.String()),
+			zap.String("diversification", portfolio.RiskMetrics.Diversification.String()),
 			zap.Int("positions_count", len(portfolio.Positions)),
-			zap.Int("trades_count", len(portfolio.TradeHistory)),
+			zap.Int("trades_count", portfolio.TradeCount),
 		)
 
 		for symbol, position := range portfolio.Positions {
-			logger.Info("Position",
+			fields := []zap.Field{
 				zap.String("symbol", symbol),
-				zap.Int64("quantity", position.Quantity),
+				zap.String("quantity", position.Quantity.String()),
 				zap.String("average_price", position.AveragePrice.String()),
 				zap.String("current_price", position.CurrentPrice.String()),
 				zap.String("market_value", position.MarketValue.String()),
 				zap.String("unrealized_pnl", position.UnrealizedPnL.String()),
-			)
+			}
+			if fx := position.FX; fx != nil {
+				fields = append(fields,
+					zap.String("currency", fx.Currency),
+					zap.String("fx_rate", fx.Rate.String()),
+					zap.String("native_market_value", fx.MarketValue.String()),
+					zap.String("price_pnl", fx.PricePnL.String()),
+					zap.String("fx_pnl", fx.FXPnL.String()),
+				)
+			}
+			logger.Info("Position", fields...)
 		}
 	}
 }
 
-func handleShutdown(ctx context.Context, engine *engine.TradingEngine, simulator *simulator.MarketSimulator, logger *zap.Logger) {
+func handleShutdown(ctx context.Context, engine *tradealgo.TradingEngine, marketFeed tradealgo.MarketDataFeed, apiServer *tradealgo.APIServer, exportDir, stateFile string, initialCash decimal.Decimal, logger *zap.Logger) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
@@ -180,19 +1107,172 @@ func handleShutdown(ctx context.Context, engine *engine.TradingEngine, simulator
 
 	logger.Info("Shutting down trading system")
 
-	simulator.Stop()
+	if apiServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := apiServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Failed to shut down HTTP API", zap.Error(err))
+		}
+		cancel()
+	}
+
+	marketFeed.Stop()
 	engine.Stop()
 
-	finalPortfolio := engine.GetPortfolio()
+	portfolio := engine.GetPortfolio()
+	logFinalSummary(portfolio, initialCash, logger)
+	logPerformanceReport(performanceReport(engine, portfolio), logger)
+	logStressTests(engine.RunStressTests(), logger)
+	exportResults(exportDir, portfolio, engine.GetEquityCurve(), engine.Symbols(), logger)
+	saveState(engine, stateFile, logger)
+
+	logger.Info("Trading system shutdown complete")
+}
+
+func logFinalSummary(finalPortfolio *tradealgo.Portfolio, initialCash decimal.Decimal, logger *zap.Logger) {
 	logger.Info("Final Portfolio Summary",
 		zap.String("portfolio_id", finalPortfolio.ID),
-		zap.String("initial_cash", decimal.NewFromFloat(100000.0).String()),
+		zap.String("base_currency", finalPortfolio.BaseCurrency),
+		zap.String("initial_cash", initialCash.String()),
 		zap.String("final_value", finalPortfolio.TotalValue.String()),
-		zap.String("total_return", finalPortfolio.TotalValue.Sub(decimal.NewFromFloat(100000.0)).String()),
-		zap.String("return_percentage", finalPortfolio.TotalValue.Sub(decimal.NewFromFloat(100000.0)).Div(decimal.NewFromFloat(100000.0)).Mul(decimal.NewFromFloat(100)).String()),
+		zap.String("total_return", finalPortfolio.TotalValue.Sub(initialCash).String()),
+		zap.String("return_percentage", finalPortfolio.TotalValue.Sub(initialCash).Div(initialCash).Mul(decimal.NewFromFloat(100)).String()),
+		zap.String("realized_pnl", finalPortfolio.RealizedPnL.String()),
+		zap.Any("cash_balances", finalPortfolio.CashBalances),
+		zap.String("interest_expense", finalPortfolio.InterestExpense.String()),
 		zap.Int("total_trades", len(finalPortfolio.TradeHistory)),
 		zap.Int("final_positions", len(finalPortfolio.Positions)),
 	)
+}
 
-	logger.Info("Trading system shutdown complete")
+func performanceReport(tradingEngine *tradealgo.TradingEngine, portfolio *tradealgo.Portfolio) *tradealgo.PerformanceReport {
+	equityCurve := tradingEngine.GetEquityCurve()
+	report := tradealgo.GeneratePerformanceReport(portfolio, equityCurve)
+	report.AddDailyStatements(tradingEngine.GetDailyStatements())
+	symbol, benchmarkCurve := tradingEngine.GetBenchmarkCurve()
+	report.AddBenchmark(symbol, equityCurve, benchmarkCurve)
+	report.Strategies = tradingEngine.GetStrategyPerformance()
+	return report
+}
+
+func logPerformanceReport(report *tradealgo.PerformanceReport, logger *zap.Logger) {
+	logger.Info("Performance Report",
+		zap.Time("start_time", report.StartTime),
+		zap.Time("end_time", report.EndTime),
+		zap.String("total_return", report.TotalReturn.String()),
+		zap.String("annualized_return", report.AnnualizedReturn.String()),
+		zap.String("max_drawdown", report.MaxDrawdown.String()),
+		zap.Duration("max_drawdown_duration", report.MaxDrawdownDuration),
+		zap.Duration("time_to_recovery", report.TimeToRecovery),
+		zap.String("sharpe_ratio", report.SharpeRatio.String()),
+		zap.String("sortino_ratio", report.SortinoRatio.String()),
+		zap.Int("round_trips", report.RoundTrips),
+		zap.String("win_rate", report.WinRate.String()),
+		zap.String("average_win", report.AverageWin.String()),
+		zap.String("average_loss", report.AverageLoss.String()),
+		zap.String("profit_factor", report.ProfitFactor.String()),
+		zap.String("largest_win", report.LargestWin.String()),
+		zap.String("largest_loss", report.LargestLoss.String()),
+		zap.Duration("average_holding_period", report.AverageHoldingPeriod),
+		zap.String("total_commission", report.TotalCommission.String()),
+		zap.String("total_impact_cost", report.TotalImpactCost.String()),
+		zap.String("implementation_shortfall", report.ImplementationShortfall.String()),
+	)
+
+	if costs := report.TransactionCosts; costs != nil && costs.Total.Trades > 0 {
+		logCostSummary("Transaction Costs", costs.Total, logger)
+		for _, strategyID := range sortedKeys(costs.Strategies) {
+			logCostSummary("Strategy Transaction Costs", costs.Strategies[strategyID], logger, zap.String("strategy_id", strategyID))
+		}
+		for _, symbol := range sortedKeys(costs.Symbols) {
+			logCostSummary("Symbol Transaction Costs", costs.Symbols[symbol], logger, zap.String("symbol", symbol))
+		}
+	}
+
+	if benchmark := report.Benchmark; benchmark != nil {
+		logger.Info("Benchmark Report",
+			zap.String("symbol", benchmark.Symbol),
+			zap.String("benchmark_return", benchmark.TotalReturn.String()),
+			zap.String("excess_return", benchmark.ExcessReturn.String()),
+			zap.String("alpha", benchmark.Alpha.String()),
+			zap.String("beta", benchmark.Beta.String()),
+			zap.String("tracking_error", benchmark.TrackingError.String()),
+			zap.String("information_ratio", benchmark.InformationRatio.String()),
+		)
+	}
+
+	ids := make([]string, 0, len(report.Strategies))
+	for strategyID := range report.Strategies {
+		ids = append(ids, strategyID)
+	}
+	sort.Strings(ids)
+	for _, strategyID := range ids {
+		performance := report.Strategies[strategyID]
+		logger.Info("Strategy Performance",
+			zap.String("strategy_id", strategyID),
+			zap.String("realized_pnl", performance.RealizedPnL.String()),
+			zap.String("unrealized_pnl", performance.UnrealizedPnL.String()),
+			zap.String("total_pnl", performance.TotalPnL.String()),
+			zap.String("commission", performance.Commission.String()),
+			zap.Int("trades", performance.Trades),
+			zap.String("win_rate", performance.WinRate.String()),
+		)
+	}
+}
+
+func logCostSummary(message string, summary tradealgo.CostSummary, logger *zap.Logger, fields ...zap.Field) {
+	logger.Info(message, append(fields,
+		zap.Int("trades", summary.Trades),
+		zap.String("notional", summary.Notional.String()),
+		zap.String("delay_bps", summary.DelayBps.StringFixed(2)),
+		zap.String("spread_bps", summary.SpreadBps.StringFixed(2)),
+		zap.String("impact_bps", summary.ImpactBps.StringFixed(2)),
+		zap.String("shortfall_bps", summary.ShortfallBps.StringFixed(2)),
+	)...)
+}
+
+func sortedKeys(summaries map[string]tradealgo.CostSummary) []string {
+	keys := make([]string, 0, len(summaries))
+	for key := range summaries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func logStressTests(results []*tradealgo.StressResult, logger *zap.Logger) {
+	for _, result := range results {
+		var exits []string
+		for _, position := range result.Positions {
+			if position.Exit != "" {
+				exits = append(exits, position.Symbol+":"+string(position.Exit))
+			}
+		}
+		logger.Info("Stress Test",
+			zap.String("scenario", result.Scenario),
+			zap.String("current_value", result.CurrentValue.String()),
+			zap.String("stressed_value", result.StressedValue.String()),
+			zap.String("pnl", result.PnL.String()),
+			zap.String("drawdown", result.Drawdown.String()),
+			zap.Bool("drawdown_halt", result.DrawdownHalt),
+			zap.Bool("margin_call", result.MarginCall),
+			zap.Strings("exits", exits),
+		)
+	}
+}
+
+func exportResults(dir string, portfolio *tradealgo.Portfolio, equityCurve []tradealgo.EquityPoint, registry *tradealgo.SymbolRegistry, logger *zap.Logger) {
+	if dir == "" {
+		return
+	}
+
+	paths, err := tradealgo.ExportDirectory(dir, portfolio, equityCurve, tradealgo.WithExportSymbols(registry))
+	logExport(dir, paths, err, logger)
+}
+
+func logExport(dir string, paths []string, err error, logger *zap.Logger) {
+	if err != nil {
+		logger.Error("Failed to export results", zap.String("dir", dir), zap.Error(err))
+		return
+	}
+	logger.Info("Results exported", zap.String("dir", dir), zap.Strings("files", paths))
 }
@@ -0,0 +1,44 @@
+package tradealgo
+
+import (
+	"io"
+
+	"github.com/1cbyc/trade-algo-go/internal/backtest"
+	"go.uber.org/zap"
+)
+
+type (
+	BacktestRunner = backtest.Runner
+	BacktestOption = backtest.Option
+	Replayer       = backtest.Replayer
+)
+
+var (
+	ErrInvalidHeader = backtest.ErrInvalidHeader
+	ErrInvalidRecord = backtest.ErrInvalidRecord
+	ErrNoData        = backtest.ErrNoData
+)
+
+func NewBacktestRunner(tradingEngine *TradingEngine, logger *zap.Logger, opts ...BacktestOption) *BacktestRunner {
+	return backtest.NewRunner(tradingEngine, logger, opts...)
+}
+
+func WithWarmUp(bars int) BacktestOption {
+	return backtest.WithWarmUp(bars)
+}
+
+func NewReplayer(data []*MarketData, logger *zap.Logger) *Replayer {
+	return backtest.NewReplayer(data, logger)
+}
+
+func LoadBars(dir string) ([]*MarketData, error) {
+	return backtest.LoadDirectory(dir)
+}
+
+func LoadBarsFile(path, symbol string) ([]*MarketData, error) {
+	return backtest.LoadFile(path, symbol)
+}
+
+func ReadBarsCSV(r io.Reader, symbol string) ([]*MarketData, error) {
+	return backtest.ReadCSV(r, symbol)
+}
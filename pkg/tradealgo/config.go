@@ -0,0 +1,28 @@
+package tradealgo
+
+import (
+	"io"
+
+	"github.com/1cbyc/trade-algo-go/internal/config"
+	"go.uber.org/zap"
+)
+
+type (
+	Config         = config.Config
+	ConfigWatcher  = config.Watcher
+	StrategyEngine = config.StrategyEngine
+)
+
+var ErrInvalidConfig = config.ErrInvalidConfig
+
+func LoadConfig(path string) (*Config, error) {
+	return config.Load(path)
+}
+
+func ParseConfig(r io.Reader) (*Config, error) {
+	return config.Parse(r)
+}
+
+func NewConfigWatcher(path string, current *Config, engine StrategyEngine, logger *zap.Logger) *ConfigWatcher {
+	return config.NewWatcher(path, current, engine, logger)
+}
@@ -0,0 +1,27 @@
+// Package tradealgo is the public API for embedding the trading engine in
+// another program. Its types are aliases of the engine's own, so values move
+// freely between this package and everything the engine returns.
+//
+// Constructors with optional settings take option funcs: NewTradingEngine,
+// NewTradingEngineFromState and RecoverTradingEngine take EngineOption
+// (WithClock), NewMarketSimulator takes SimulatorOption (WithSeed,
+// WithTickInterval, WithSimulatorClock, ...) and NewBacktestRunner takes
+// BacktestOption (WithWarmUp). All other constructors take their settings as
+// arguments, and an engine is configured after construction through its Set
+// methods, which validate their input and return an error.
+//
+// A strategy implements Strategy, usually by embedding *BaseStrategy and
+// providing Execute. The engine also looks for optional methods on it, such as
+// ExecuteOnTick, RequiredHistory, RequiredBars, OnFill and OnOrderRejected.
+//
+// The services the trade-algo-go command builds around the engine are here
+// too, and the command uses nothing else: the HTTP API and WebSocket stream
+// (NewAPIServer, NewStreamHandler), the audit log writer (NewAuditWriter),
+// statement export (NewStatementWriter, ExportDirectory), alert dispatch
+// (NewAlertDispatcher), trade storage (NewSQLiteStore, NewJSONLArchive),
+// performance reports, the parameter optimizer (NewOptimizer) and Monte Carlo
+// studies (NewMonteCarloRunner). Their options carry the service name where
+// it would otherwise clash, as in WithAuditMaxSize or WithOptimizerWorkers. An
+// embedding program can also connect its own implementations through
+// AuditSink, StatementSink and AlertPublisher.
+package tradealgo
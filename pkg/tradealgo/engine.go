@@ -0,0 +1,94 @@
+package tradealgo
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/engine"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type (
+	TradingEngine        = engine.TradingEngine
+	EngineOption         = engine.Option
+	Intervals            = engine.Intervals
+	QueueConfig          = engine.QueueConfig
+	QueueStats           = engine.QueueStats
+	MarginConfig         = engine.MarginConfig
+	CurrencyConfig       = engine.CurrencyConfig
+	SlicingConfig        = engine.SlicingConfig
+	StalePriceAction     = engine.StalePriceAction
+	StalePriceConfig     = engine.StalePriceConfig
+	CircuitBreakerConfig = engine.CircuitBreakerConfig
+	AlertConfig          = engine.AlertConfig
+	AlertPublisher       = engine.AlertPublisher
+	AuditSink            = engine.AuditSink
+	StatementSink        = engine.StatementSink
+)
+
+const (
+	StalePriceReprice = engine.StalePriceReprice
+	StalePriceReject  = engine.StalePriceReject
+)
+
+var (
+	ErrOrderNotFound          = engine.ErrOrderNotFound
+	ErrOrderAlreadyFilled     = engine.ErrOrderAlreadyFilled
+	ErrOrderNotCancellable    = engine.ErrOrderNotCancellable
+	ErrOrderNotModifiable     = engine.ErrOrderNotModifiable
+	ErrStrategyNotFound       = engine.ErrStrategyNotFound
+	ErrInvalidState           = engine.ErrInvalidState
+	ErrStateVersion           = engine.ErrStateVersion
+	ErrDrainTimeout           = engine.ErrDrainTimeout
+	ErrUnknownCostBasis       = engine.ErrUnknownCostBasis
+	ErrInvalidAllocation      = engine.ErrInvalidAllocation
+	ErrAllocationExceeded     = engine.ErrAllocationExceeded
+	ErrUnknownSliceAlgorithm  = engine.ErrUnknownSliceAlgorithm
+	ErrInvalidSlicing         = engine.ErrInvalidSlicing
+	ErrInvalidMargin          = engine.ErrInvalidMargin
+	ErrInvalidDrawdownLimit   = engine.ErrInvalidDrawdownLimit
+	ErrInvalidStalePrice      = engine.ErrInvalidStalePrice
+	ErrStalePrice             = engine.ErrStalePrice
+	ErrInvalidCorporateAction = engine.ErrInvalidCorporateAction
+	ErrInvalidAlerts          = engine.ErrInvalidAlerts
+	ErrNoPositionToExit       = engine.ErrNoPositionToExit
+	ErrUnknownAction          = engine.ErrUnknownAction
+	ErrInvalidMarketData      = engine.ErrInvalidMarketData
+	ErrInvalidExposureLimits  = engine.ErrInvalidExposureLimits
+	ErrInvalidSnapshot        = engine.ErrInvalidSnapshot
+	ErrInvalidTimeframes      = engine.ErrInvalidTimeframes
+	ErrInvalidQueues          = engine.ErrInvalidQueues
+	ErrEngineBusy             = engine.ErrEngineBusy
+	ErrInvalidCurrencies      = engine.ErrInvalidCurrencies
+	ErrNoFXRate               = engine.ErrNoFXRate
+	ErrStrategyTimeout        = engine.ErrStrategyTimeout
+	ErrStrategyPanic          = engine.ErrStrategyPanic
+	ErrSymbolExposureExceeded = engine.ErrSymbolExposureExceeded
+	ErrGroupExposureExceeded  = engine.ErrGroupExposureExceeded
+	ErrPositionLimitExceeded  = engine.ErrPositionLimitExceeded
+	ErrInvalidCircuitBreaker  = engine.ErrInvalidCircuitBreaker
+	ErrTradingHalted          = engine.ErrTradingHalted
+	ErrNotHalted              = engine.ErrNotHalted
+)
+
+func NewTradingEngine(initialCash decimal.Decimal, logger *zap.Logger, opts ...EngineOption) *TradingEngine {
+	return engine.NewTradingEngine(initialCash, logger, opts...)
+}
+
+func NewTradingEngineFromState(path string, logger *zap.Logger, opts ...EngineOption) (*TradingEngine, error) {
+	return engine.NewTradingEngineFromState(path, logger, opts...)
+}
+
+func RecoverTradingEngine(statePath, auditPath string, logger *zap.Logger, opts ...EngineOption) (*TradingEngine, error) {
+	return engine.RecoverTradingEngine(statePath, auditPath, logger, opts...)
+}
+
+func WithClock(c Clock) EngineOption {
+	return engine.WithClock(c)
+}
+
+func DefaultIntervals() Intervals {
+	return engine.DefaultIntervals()
+}
+
+func DefaultQueueConfig() QueueConfig {
+	return engine.DefaultQueueConfig()
+}
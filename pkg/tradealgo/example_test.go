@@ -0,0 +1,97 @@
+package tradealgo_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/pkg/tradealgo"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type dipBuyer struct {
+	*tradealgo.BaseStrategy
+	entry map[string]decimal.Decimal
+}
+
+func newDipBuyer(config *tradealgo.StrategyConfig) *dipBuyer {
+	return &dipBuyer{BaseStrategy: tradealgo.NewBaseStrategy(config), entry: make(map[string]decimal.Decimal)}
+}
+
+func (s *dipBuyer) Execute(ctx context.Context, portfolio *tradealgo.Portfolio, market *tradealgo.MarketSnapshot) (*tradealgo.AlgorithmResult, error) {
+	for symbol, data := range market.Latest {
+		first, seen := s.entry[symbol]
+		if !seen {
+			s.entry[symbol] = data.Price
+			continue
+		}
+		position, held := portfolio.Positions[symbol]
+		switch {
+		case !held && data.Price.LessThan(first):
+			return s.signal(symbol, tradealgo.ActionBuy, decimal.NewFromInt(10), data.Price), nil
+		case held && data.Price.GreaterThan(first):
+			return s.signal(symbol, tradealgo.ActionSell, position.Quantity, data.Price), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *dipBuyer) signal(symbol string, action tradealgo.Action, quantity, price decimal.Decimal) *tradealgo.AlgorithmResult {
+	return &tradealgo.AlgorithmResult{
+		StrategyID: s.ID(),
+		Symbol:     symbol,
+		Action:     action,
+		Quantity:   quantity,
+		Price:      price,
+		Confidence: decimal.NewFromInt(1),
+		Signal:     "dip",
+	}
+}
+
+func Example() {
+	logger := zap.NewNop()
+	start := time.Date(2030, 1, 7, 15, 0, 0, 0, time.UTC)
+	fake := tradealgo.NewFakeClock(start)
+
+	engine := tradealgo.NewTradingEngine(decimal.NewFromInt(100000), logger, tradealgo.WithClock(fake))
+	engine.SetCalendar(tradealgo.NewCalendar(tradealgo.AlwaysOpen()))
+	engine.AddStrategy(newDipBuyer(&tradealgo.StrategyConfig{
+		ID:               "dip_buyer",
+		Name:             "Dip Buyer",
+		MaxPositionSize:  decimal.NewFromFloat(0.5),
+		MaxPortfolioRisk: decimal.NewFromInt(1),
+		MinOrderSize:     decimal.NewFromInt(100),
+		MaxOrderSize:     decimal.NewFromInt(50000),
+		Enabled:          true,
+	}))
+
+	sim := tradealgo.NewMarketSimulator(logger,
+		tradealgo.WithSeed(42),
+		tradealgo.WithSimulatorClock(fake),
+		tradealgo.WithCalendar(tradealgo.NewCalendar(tradealgo.AlwaysOpen())),
+	)
+	sim.AddSymbol("AAPL", decimal.NewFromInt(150), decimal.NewFromFloat(0.02))
+	var marketFeed tradealgo.MarketDataFeed = tradealgo.NewSteppedFeed(sim, fake, 500)
+
+	ctx := context.Background()
+	if err := marketFeed.Start(ctx); err != nil {
+		fmt.Println(err)
+		return
+	}
+	portfolio, err := tradealgo.NewBacktestRunner(engine, logger).Run(ctx, marketFeed.Updates())
+	marketFeed.Stop()
+	engine.Stop()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println("base currency:", engine.BaseCurrency())
+	fmt.Println("trades:", len(portfolio.TradeHistory))
+	fmt.Println("final value:", portfolio.TotalValue.StringFixed(2))
+	// Output:
+	// base currency: USD
+	// trades: 5
+	// final value: 99979.13
+}
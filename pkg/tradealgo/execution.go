@@ -0,0 +1,69 @@
+package tradealgo
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/execution"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	SlippageModel        = execution.SlippageModel
+	UniformSlippage      = execution.UniformSlippage
+	SeededSlippage       = execution.SeededSlippage
+	MaxSlippage          = execution.MaxSlippage
+	ImpactModel          = execution.ImpactModel
+	SquareRootImpact     = execution.SquareRootImpact
+	CommissionModel      = execution.CommissionModel
+	PercentageCommission = execution.PercentageCommission
+	FixedCommission      = execution.FixedCommission
+	PerShareCommission   = execution.PerShareCommission
+	LatencyModel         = execution.LatencyModel
+	LatencyConfig        = execution.LatencyConfig
+	FixedLatency         = execution.FixedLatency
+	UniformLatency       = execution.UniformLatency
+	LognormalLatency     = execution.LognormalLatency
+)
+
+const (
+	LatencyFixed     = execution.LatencyFixed
+	LatencyUniform   = execution.LatencyUniform
+	LatencyLognormal = execution.LatencyLognormal
+
+	SliceTWAP = execution.SliceTWAP
+	SliceVWAP = execution.SliceVWAP
+)
+
+var ErrUnknownLatencyModel = execution.ErrUnknownLatencyModel
+
+func NewSeededSlippage(seed int64) *SeededSlippage {
+	return execution.NewSeededSlippage(seed)
+}
+
+func NewSquareRootImpact(k decimal.Decimal) SquareRootImpact {
+	return execution.NewSquareRootImpact(k)
+}
+
+func NewPercentageCommission(rate decimal.Decimal) *PercentageCommission {
+	return execution.NewPercentageCommission(rate)
+}
+
+func NewFixedCommission(fee decimal.Decimal) *FixedCommission {
+	return execution.NewFixedCommission(fee)
+}
+
+func NewPerShareCommission(perShare, minimum decimal.Decimal) *PerShareCommission {
+	return execution.NewPerShareCommission(perShare, minimum)
+}
+
+func NewLatencyModel(config LatencyConfig) (LatencyModel, error) {
+	return execution.NewLatencyModel(config)
+}
+
+func NewUniformLatency(min, max time.Duration, seed int64) *UniformLatency {
+	return execution.NewUniformLatency(min, max, seed)
+}
+
+func NewLognormalLatency(median time.Duration, sigma float64, seed int64) *LognormalLatency {
+	return execution.NewLognormalLatency(median, sigma, seed)
+}
@@ -0,0 +1,23 @@
+package tradealgo
+
+import (
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand_ImportsOnlyPublicAPI(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "../../main.go", nil, parser.ImportsOnly)
+	require.NoError(t, err)
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		require.NoError(t, err)
+		assert.False(t, strings.Contains(path, "/internal/"), path)
+	}
+}
@@ -0,0 +1,62 @@
+package tradealgo
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/calendar"
+	"github.com/1cbyc/trade-algo-go/internal/clock"
+	"github.com/1cbyc/trade-algo-go/internal/symbols"
+)
+
+type (
+	SymbolRegistry = symbols.Registry
+	Calendar       = calendar.Calendar
+	Session        = calendar.Session
+	Clock          = clock.Clock
+	Ticker         = clock.Ticker
+	FakeClock      = clock.Fake
+)
+
+const AlwaysOpenSpec = calendar.AlwaysOpenSpec
+
+var (
+	ErrInvalidLotSize   = symbols.ErrInvalidLotSize
+	ErrBelowLotSize     = symbols.ErrBelowLotSize
+	ErrNotLotMultiple   = symbols.ErrNotLotMultiple
+	ErrInvalidTickSize  = symbols.ErrInvalidTickSize
+	ErrInvalidPrecision = symbols.ErrInvalidPrecision
+	ErrOffTick          = symbols.ErrOffTick
+	ErrInvalidSession   = calendar.ErrInvalidSession
+)
+
+func NewSymbolRegistry() *SymbolRegistry {
+	return symbols.NewRegistry()
+}
+
+func DefaultInstrument(symbol string) Instrument {
+	return symbols.Default(symbol)
+}
+
+func NewCalendar(defaultSession *Session) *Calendar {
+	return calendar.NewCalendar(defaultSession)
+}
+
+func NewSession(open, close string, location *time.Location, days ...time.Weekday) (*Session, error) {
+	return calendar.NewSession(open, close, location, days...)
+}
+
+func ParseSession(spec string, location *time.Location) (*Session, error) {
+	return calendar.ParseSession(spec, location)
+}
+
+func AlwaysOpen() *Session {
+	return calendar.AlwaysOpen()
+}
+
+func NewClock() Clock {
+	return clock.New()
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	return clock.NewFake(start)
+}
@@ -0,0 +1,136 @@
+package tradealgo
+
+import (
+	"io"
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/feed"
+	"github.com/1cbyc/trade-algo-go/internal/simulator"
+	"go.uber.org/zap"
+)
+
+type (
+	MarketDataFeed  = feed.MarketDataFeed
+	StatusReporter  = feed.StatusReporter
+	FeedStatus      = feed.Status
+	ConnectionState = feed.ConnectionState
+	BinanceFeed     = feed.BinanceFeed
+	ReplayFeed      = feed.ReplayFeed
+
+	MarketSimulator  = simulator.MarketSimulator
+	SimulatorOption  = simulator.Option
+	SymbolData       = simulator.SymbolData
+	PriceModel       = simulator.PriceModel
+	LegacyPriceModel = simulator.LegacyPriceModel
+	GBMPriceModel    = simulator.GBMPriceModel
+	MarketEvent      = simulator.MarketEvent
+	RecordedData     = simulator.RecordedData
+	SteppedFeed      = simulator.SteppedFeed
+	Coalescer        = simulator.Coalescer
+	BarAggregator    = simulator.BarAggregator
+)
+
+const (
+	FeedTypeSimulator = feed.TypeSimulator
+	FeedTypeBinance   = feed.TypeBinance
+	DefaultBinanceURL = feed.DefaultBinanceURL
+
+	StateDisconnected = feed.StateDisconnected
+	StateConnecting   = feed.StateConnecting
+	StateConnected    = feed.StateConnected
+
+	PriceModelLegacy = simulator.PriceModelLegacy
+	PriceModelGBM    = simulator.PriceModelGBM
+
+	EventPriceShock      = simulator.EventPriceShock
+	EventVolatilitySpike = simulator.EventVolatilitySpike
+	EventTrendChange     = simulator.EventTrendChange
+
+	AllSymbols = simulator.AllSymbols
+)
+
+var (
+	ErrFeedRunning    = feed.ErrFeedRunning
+	ErrNoSymbols      = feed.ErrNoSymbols
+	ErrEmptyRecording = feed.ErrEmptyRecording
+
+	ErrUnknownPriceModel  = simulator.ErrUnknownPriceModel
+	ErrInvalidCorrelation = simulator.ErrInvalidCorrelation
+	ErrUnknownEventType   = simulator.ErrUnknownEventType
+	ErrInvalidNews        = simulator.ErrInvalidNews
+	ErrUnknownSymbol      = simulator.ErrUnknownSymbol
+	ErrInvalidRecording   = simulator.ErrInvalidRecording
+	ErrInvalidAdjustment  = simulator.ErrInvalidAdjustment
+)
+
+func NewMarketSimulator(logger *zap.Logger, opts ...SimulatorOption) *MarketSimulator {
+	return simulator.NewMarketSimulator(logger, opts...)
+}
+
+func WithSeed(seed int64) SimulatorOption {
+	return simulator.WithSeed(seed)
+}
+
+func WithPriceModel(model PriceModel) SimulatorOption {
+	return simulator.WithPriceModel(model)
+}
+
+func WithCalendar(cal *Calendar) SimulatorOption {
+	return simulator.WithCalendar(cal)
+}
+
+func WithOpeningGap() SimulatorOption {
+	return simulator.WithOpeningGap()
+}
+
+func WithTickInterval(interval time.Duration) SimulatorOption {
+	return simulator.WithTickInterval(interval)
+}
+
+func WithSimulatorClock(c Clock) SimulatorOption {
+	return simulator.WithClock(c)
+}
+
+func WithSymbols(registry *SymbolRegistry) SimulatorOption {
+	return simulator.WithSymbols(registry)
+}
+
+func WithRecorder(w io.Writer) SimulatorOption {
+	return simulator.WithRecorder(w)
+}
+
+func WithSpikeDecay(ticks int) SimulatorOption {
+	return simulator.WithSpikeDecay(ticks)
+}
+
+func WithNewsLag(lag time.Duration) SimulatorOption {
+	return simulator.WithNewsLag(lag)
+}
+
+func WithRandomNews(rate float64) SimulatorOption {
+	return simulator.WithRandomNews(rate)
+}
+
+func NewPriceModel(name string) (PriceModel, error) {
+	return simulator.NewPriceModel(name)
+}
+
+func NewSteppedFeed(sim *MarketSimulator, fake *FakeClock, ticks int) *SteppedFeed {
+	return simulator.NewSteppedFeed(sim, fake, ticks)
+}
+
+func NewCoalescer(out chan<- *MarketData) *Coalescer {
+	return simulator.NewCoalescer(out)
+}
+
+func NewBarAggregator(interval time.Duration) *BarAggregator {
+	return simulator.NewBarAggregator(interval)
+}
+
+func NewBinanceFeed(baseURL string, symbols []string, logger *zap.Logger) *BinanceFeed {
+	return feed.NewBinanceFeed(baseURL, symbols, logger)
+}
+
+func NewReplayFeed(path string, logger *zap.Logger) (*ReplayFeed, error) {
+	return feed.NewReplayFeed(path, logger)
+}
@@ -0,0 +1,153 @@
+package tradealgo
+
+import "github.com/1cbyc/trade-algo-go/internal/models"
+
+type (
+	OrderType            = models.OrderType
+	OrderSide            = models.OrderSide
+	OrderStatus          = models.OrderStatus
+	TimeInForce          = models.TimeInForce
+	CancelReason         = models.CancelReason
+	CostBasisMethod      = models.CostBasisMethod
+	SessionType          = models.SessionType
+	Action               = models.Action
+	ConfidenceScaling    = models.ConfidenceScaling
+	ExitReason           = models.ExitReason
+	CorporateActionType  = models.CorporateActionType
+	NewsSeverity         = models.NewsSeverity
+	RiskEventType        = models.RiskEventType
+	MarketDataKind       = models.MarketDataKind
+	Trade                = models.Trade
+	TransactionCost      = models.TransactionCost
+	Lot                  = models.Lot
+	ClosedLot            = models.ClosedLot
+	Order                = models.Order
+	Position             = models.Position
+	PositionFX           = models.PositionFX
+	Instrument           = models.Instrument
+	Portfolio            = models.Portfolio
+	MarginAccount        = models.MarginAccount
+	RiskEvent            = models.RiskEvent
+	AdjustmentKind       = models.AdjustmentKind
+	SimulatorAdjustment  = models.SimulatorAdjustment
+	TradingHalt          = models.TradingHalt
+	TradingStatus        = models.TradingStatus
+	CorporateAction      = models.CorporateAction
+	NewsEvent            = models.NewsEvent
+	PortfolioSummary     = models.PortfolioSummary
+	StrategyAllocation   = models.StrategyAllocation
+	StrategyPerformance  = models.StrategyPerformance
+	MarketData           = models.MarketData
+	DailyStatement       = models.DailyStatement
+	EquityPoint          = models.EquityPoint
+	RiskMetrics          = models.RiskMetrics
+	PortfolioRiskMetrics = models.PortfolioRiskMetrics
+	StrategyConfig       = models.StrategyConfig
+	GridConfig           = models.GridConfig
+	ExposureLimits       = models.ExposureLimits
+	ExposureGroup        = models.ExposureGroup
+	AlgorithmResult      = models.AlgorithmResult
+	OrderBook            = models.OrderBook
+	MarketSnapshot       = models.MarketSnapshot
+)
+
+const (
+	OrderTypeMarket = models.OrderTypeMarket
+	OrderTypeLimit  = models.OrderTypeLimit
+	OrderTypeStop   = models.OrderTypeStop
+)
+
+const (
+	OrderSideBuy  = models.OrderSideBuy
+	OrderSideSell = models.OrderSideSell
+)
+
+const (
+	OrderStatusPending         = models.OrderStatusPending
+	OrderStatusFilled          = models.OrderStatusFilled
+	OrderStatusCancelled       = models.OrderStatusCancelled
+	OrderStatusRejected        = models.OrderStatusRejected
+	OrderStatusPartiallyFilled = models.OrderStatusPartiallyFilled
+)
+
+const (
+	TimeInForceGTC = models.TimeInForceGTC
+	TimeInForceDay = models.TimeInForceDay
+	TimeInForceIOC = models.TimeInForceIOC
+)
+
+const (
+	CancelReasonRequested  = models.CancelReasonRequested
+	CancelReasonReplaced   = models.CancelReasonReplaced
+	CancelReasonDayExpired = models.CancelReasonDayExpired
+	CancelReasonIOC        = models.CancelReasonIOC
+	CancelReasonHalted     = models.CancelReasonHalted
+	CancelReasonRecovery   = models.CancelReasonRecovery
+)
+
+const (
+	CostBasisAverage = models.CostBasisAverage
+	CostBasisFIFO    = models.CostBasisFIFO
+	CostBasisLIFO    = models.CostBasisLIFO
+)
+
+const (
+	SessionTypeRegular    = models.SessionTypeRegular
+	SessionTypeContinuous = models.SessionTypeContinuous
+)
+
+const (
+	ActionBuy   = models.ActionBuy
+	ActionSell  = models.ActionSell
+	ActionHold  = models.ActionHold
+	ActionClose = models.ActionClose
+)
+
+const (
+	ConfidenceScalingNone   = models.ConfidenceScalingNone
+	ConfidenceScalingLinear = models.ConfidenceScalingLinear
+	ConfidenceScalingSquare = models.ConfidenceScalingSquare
+)
+
+const (
+	ExitReasonStopLoss     = models.ExitReasonStopLoss
+	ExitReasonTakeProfit   = models.ExitReasonTakeProfit
+	ExitReasonTrailingStop = models.ExitReasonTrailingStop
+	ExitReasonDrawdown     = models.ExitReasonDrawdown
+	ExitReasonMarginCall   = models.ExitReasonMarginCall
+	ExitReasonCashInLieu   = models.ExitReasonCashInLieu
+	ExitReasonClose        = models.ExitReasonClose
+)
+
+const (
+	CorporateActionDividend = models.CorporateActionDividend
+	CorporateActionSplit    = models.CorporateActionSplit
+)
+
+const (
+	NewsSeverityLow    = models.NewsSeverityLow
+	NewsSeverityMedium = models.NewsSeverityMedium
+	NewsSeverityHigh   = models.NewsSeverityHigh
+)
+
+const (
+	RiskEventDrawdownLiquidation = models.RiskEventDrawdownLiquidation
+	RiskEventStrategyDisabled    = models.RiskEventStrategyDisabled
+	RiskEventMarginCall          = models.RiskEventMarginCall
+	RiskEventPortfolioDrawdown   = models.RiskEventPortfolioDrawdown
+	RiskEventCircuitBreaker      = models.RiskEventCircuitBreaker
+	RiskEventTradingResumed      = models.RiskEventTradingResumed
+)
+
+const (
+	MarketDataKindTick            = models.MarketDataKindTick
+	MarketDataKindBar             = models.MarketDataKindBar
+	MarketDataKindCorporateAction = models.MarketDataKindCorporateAction
+	MarketDataKindNews            = models.MarketDataKindNews
+)
+
+const (
+	AdjustmentVolatility = models.AdjustmentVolatility
+	AdjustmentTrend      = models.AdjustmentTrend
+	AdjustmentEvent      = models.AdjustmentEvent
+)
@@ -0,0 +1,38 @@
+package tradealgo
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/montecarlo"
+	"go.uber.org/zap"
+)
+
+type (
+	MonteCarloRunner  = montecarlo.Runner
+	MonteCarloOption  = montecarlo.Option
+	MonteCarloStudy   = montecarlo.Study
+	MonteCarloRun     = montecarlo.RunResult
+	MonteCarloSummary = montecarlo.Summary
+	SimulatorBuilder  = montecarlo.SimulatorBuilder
+)
+
+var (
+	ErrInvalidRuns  = montecarlo.ErrInvalidRuns
+	ErrInvalidTicks = montecarlo.ErrInvalidTicks
+)
+
+func NewMonteCarloRunner(simulator SimulatorBuilder, engine func() (*TradingEngine, error), ticks int, logger *zap.Logger, opts ...MonteCarloOption) *MonteCarloRunner {
+	return montecarlo.NewRunner(simulator, engine, ticks, logger, opts...)
+}
+
+func WithMonteCarloWorkers(workers int) MonteCarloOption {
+	return montecarlo.WithWorkers(workers)
+}
+
+func WithMonteCarloStart(start time.Time) MonteCarloOption {
+	return montecarlo.WithStart(start)
+}
+
+func WriteMonteCarloStudy(dir string, study *MonteCarloStudy) ([]string, error) {
+	return montecarlo.WriteStudy(dir, study)
+}
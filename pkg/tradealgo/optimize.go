@@ -0,0 +1,65 @@
+package tradealgo
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/optimize"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type (
+	Optimizer          = optimize.Optimizer
+	OptimizerOption    = optimize.Option
+	Objective          = optimize.Objective
+	Params             = optimize.Params
+	Grid               = optimize.Grid
+	OptimizationResult = optimize.Result
+	StrategyBuilder    = optimize.StrategyBuilder
+	WalkForwardConfig  = optimize.WalkForwardConfig
+	WalkForwardReport  = optimize.WalkForwardReport
+	WindowResult       = optimize.WindowResult
+)
+
+const (
+	ObjectiveSharpe         = optimize.ObjectiveSharpe
+	ObjectiveTotalReturn    = optimize.ObjectiveTotalReturn
+	ObjectiveReturnDrawdown = optimize.ObjectiveReturnDrawdown
+)
+
+var (
+	ErrInvalidGrid      = optimize.ErrInvalidGrid
+	ErrInvalidObjective = optimize.ErrInvalidObjective
+	ErrInvalidWindow    = optimize.ErrInvalidWindow
+	ErrNotTunable       = optimize.ErrNotTunable
+)
+
+func NewOptimizer(data []*MarketData, strategy StrategyBuilder, initialCash decimal.Decimal, logger *zap.Logger, opts ...OptimizerOption) *Optimizer {
+	return optimize.NewOptimizer(data, strategy, initialCash, logger, opts...)
+}
+
+func WithOptimizerEngine(builder func() (*TradingEngine, error)) OptimizerOption {
+	return optimize.WithEngine(builder)
+}
+
+func WithObjective(objective Objective) OptimizerOption {
+	return optimize.WithObjective(objective)
+}
+
+func WithOptimizerWorkers(workers int) OptimizerOption {
+	return optimize.WithWorkers(workers)
+}
+
+func ParseGrid(spec string) (Grid, error) {
+	return optimize.ParseGrid(spec)
+}
+
+func ParseObjective(name string) (Objective, error) {
+	return optimize.ParseObjective(name)
+}
+
+func WriteOptimizationResults(dir string, results []OptimizationResult) ([]string, error) {
+	return optimize.WriteResults(dir, results)
+}
+
+func WriteWalkForward(dir string, report *WalkForwardReport) ([]string, error) {
+	return optimize.WriteWalkForward(dir, report)
+}
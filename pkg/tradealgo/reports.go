@@ -0,0 +1,32 @@
+package tradealgo
+
+import "github.com/1cbyc/trade-algo-go/internal/analytics"
+
+type (
+	PerformanceReport     = analytics.PerformanceReport
+	BenchmarkReport       = analytics.BenchmarkReport
+	CostSummary           = analytics.CostSummary
+	TransactionCostReport = analytics.TransactionCostReport
+	RoundTrip             = analytics.RoundTrip
+	Drawdown              = analytics.Drawdown
+)
+
+func GeneratePerformanceReport(portfolio *Portfolio, equityCurve []EquityPoint) *PerformanceReport {
+	return analytics.GeneratePerformanceReport(portfolio, equityCurve)
+}
+
+func GenerateBenchmarkReport(symbol string, equityCurve, benchmarkCurve []EquityPoint) *BenchmarkReport {
+	return analytics.GenerateBenchmarkReport(symbol, equityCurve, benchmarkCurve)
+}
+
+func AnalyzeTransactionCosts(trades []*Trade) *TransactionCostReport {
+	return analytics.AnalyzeTransactionCosts(trades)
+}
+
+func MatchRoundTrips(trades []*Trade) []RoundTrip {
+	return analytics.MatchRoundTrips(trades)
+}
+
+func MaxDrawdown(curve []EquityPoint) Drawdown {
+	return analytics.MaxDrawdown(curve)
+}
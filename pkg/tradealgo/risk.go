@@ -0,0 +1,38 @@
+package tradealgo
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/metrics"
+	"github.com/1cbyc/trade-algo-go/internal/risk"
+	"github.com/1cbyc/trade-algo-go/internal/stress"
+)
+
+type (
+	VaRModel             = risk.VaRModel
+	ParametricVaRModel   = risk.ParametricModel
+	HistoricalVaRModel   = risk.HistoricalModel
+	MonteCarloVaRModel   = risk.MonteCarloModel
+	StressScenario       = stress.Scenario
+	StressShock          = stress.Shock
+	StressResult         = stress.Result
+	StressPositionImpact = stress.PositionImpact
+	Metrics              = metrics.Metrics
+)
+
+const (
+	VaRParametric = risk.VaRParametric
+	VaRHistorical = risk.VaRHistorical
+	VaRMonteCarlo = risk.VaRMonteCarlo
+)
+
+var (
+	ErrUnknownVaRMethod = risk.ErrUnknownVaRMethod
+	ErrInvalidScenario  = stress.ErrInvalidScenario
+)
+
+func NewVaRModel(method string, holdingPeriod int) (VaRModel, error) {
+	return risk.NewVaRModel(method, holdingPeriod)
+}
+
+func NewMetrics() *Metrics {
+	return metrics.New()
+}
@@ -0,0 +1,25 @@
+package tradealgo
+
+import (
+	"github.com/1cbyc/trade-algo-go/internal/api"
+	"github.com/1cbyc/trade-algo-go/internal/events"
+	"github.com/1cbyc/trade-algo-go/internal/stream"
+	"go.uber.org/zap"
+)
+
+type (
+	APIServer     = api.Server
+	APIEngine     = api.Engine
+	APISimulator  = api.Simulator
+	StreamHandler = stream.Handler
+	EventBus      = events.Bus
+	Event         = events.Event
+)
+
+func NewAPIServer(addr string, engine APIEngine, logger *zap.Logger) *APIServer {
+	return api.NewServer(addr, engine, logger)
+}
+
+func NewStreamHandler(bus *EventBus, logger *zap.Logger, allowedOrigins ...string) *StreamHandler {
+	return stream.NewHandler(bus, logger, allowedOrigins...)
+}
@@ -0,0 +1,123 @@
+package tradealgo
+
+import (
+	"time"
+
+	"github.com/1cbyc/trade-algo-go/internal/alerts"
+	"github.com/1cbyc/trade-algo-go/internal/audit"
+	"github.com/1cbyc/trade-algo-go/internal/export"
+	"github.com/1cbyc/trade-algo-go/internal/storage"
+	"go.uber.org/zap"
+)
+
+type (
+	AuditRecord     = audit.Record
+	AuditEventType  = audit.EventType
+	AuditWriter     = audit.Writer
+	AuditOption     = audit.Option
+	Alert           = alerts.Alert
+	AlertType       = alerts.Type
+	Alerter         = alerts.Alerter
+	AlertDispatcher = alerts.Dispatcher
+	AlertOption     = alerts.Option
+	WebhookAlerter  = alerts.WebhookAlerter
+	StatementWriter = export.StatementWriter
+	ExportOption    = export.Option
+	TradeStore      = storage.TradeStore
+	SQLiteStore     = storage.SQLiteStore
+	JSONLArchive    = storage.JSONLArchive
+)
+
+const (
+	AuditOrderCreated      = audit.EventOrderCreated
+	AuditOrderValidated    = audit.EventOrderValidated
+	AuditOrderRejected     = audit.EventOrderRejected
+	AuditRiskCalculated    = audit.EventRiskCalculated
+	AuditOrderFilled       = audit.EventOrderFilled
+	AuditOrderCancelled    = audit.EventOrderCancelled
+	AuditOrderModified     = audit.EventOrderModified
+	AuditOrderCompleted    = audit.EventOrderCompleted
+	AuditCorporateAction   = audit.EventCorporateAction
+	AuditMarginInterest    = audit.EventMarginInterest
+	AuditTradingHalted     = audit.EventTradingHalted
+	AuditTradingResumed    = audit.EventTradingResumed
+	AuditSimulatorAdjusted = audit.EventSimulatorAdjusted
+)
+
+const (
+	AlertDrawdownLiquidation = alerts.TypeDrawdownLiquidation
+	AlertStrategyDisabled    = alerts.TypeStrategyDisabled
+	AlertPortfolioDrawdown   = alerts.TypePortfolioDrawdown
+	AlertMarginCall          = alerts.TypeMarginCall
+	AlertOrderRejections     = alerts.TypeOrderRejections
+	AlertLargeLoss           = alerts.TypeLargeLoss
+	AlertCircuitBreaker      = alerts.TypeCircuitBreaker
+	AlertTradingResumed      = alerts.TypeTradingResumed
+)
+
+var (
+	ErrWriterClosed   = audit.ErrWriterClosed
+	ErrOutOfOrder     = audit.ErrOutOfOrder
+	ErrUnknownFormat  = alerts.ErrUnknownFormat
+	ErrDeliveryFailed = alerts.ErrDeliveryFailed
+)
+
+func NewAuditWriter(path string, opts ...AuditOption) (*AuditWriter, error) {
+	return audit.NewWriter(path, opts...)
+}
+
+func WithAuditMaxSize(bytes int64) AuditOption {
+	return audit.WithMaxSize(bytes)
+}
+
+func WithAuditMaxBackups(backups int) AuditOption {
+	return audit.WithMaxBackups(backups)
+}
+
+func WithAuditUnbuffered() AuditOption {
+	return audit.WithUnbuffered()
+}
+
+func ReadAuditLog(path string) ([]AuditRecord, error) {
+	return audit.Read(path)
+}
+
+func NewAlertDispatcher(alerters []Alerter, logger *zap.Logger, opts ...AlertOption) *AlertDispatcher {
+	return alerts.NewDispatcher(alerters, logger, opts...)
+}
+
+func WithAlertCooldown(cooldown time.Duration) AlertOption {
+	return alerts.WithCooldown(cooldown)
+}
+
+func WithAlertRetries(retries int) AlertOption {
+	return alerts.WithRetries(retries)
+}
+
+func WithAlertBackoff(min, max time.Duration) AlertOption {
+	return alerts.WithBackoff(min, max)
+}
+
+func NewWebhookAlerter(url string, format string) (*WebhookAlerter, error) {
+	return alerts.NewWebhook(url, alerts.Format(format))
+}
+
+func NewStatementWriter(dir, portfolioID string) *StatementWriter {
+	return export.NewStatementWriter(dir, portfolioID)
+}
+
+func WithExportSymbols(registry *SymbolRegistry) ExportOption {
+	return export.WithSymbols(registry)
+}
+
+func ExportDirectory(dir string, portfolio *Portfolio, equityCurve []EquityPoint, opts ...ExportOption) ([]string, error) {
+	return export.WriteDirectory(dir, portfolio, equityCurve, opts...)
+}
+
+func NewSQLiteStore(dsn string) (*SQLiteStore, error) {
+	return storage.NewSQLiteStore(dsn)
+}
+
+func NewJSONLArchive(dir string) *JSONLArchive {
+	return storage.NewJSONLArchive(dir)
+}
@@ -0,0 +1,102 @@
+package tradealgo
+
+import "github.com/1cbyc/trade-algo-go/internal/strategies"
+
+type (
+	Strategy                = strategies.Strategy
+	BaseStrategy            = strategies.BaseStrategy
+	MarketHistory           = strategies.MarketHistory
+	PositionSizer           = strategies.PositionSizer
+	Tunable                 = strategies.Tunable
+	CashSizer               = strategies.CashSizer
+	FixedFractionSizer      = strategies.FixedFractionSizer
+	KellySizer              = strategies.KellySizer
+	MovingAverageStrategy   = strategies.MovingAverageStrategy
+	RSIStrategy             = strategies.RSIStrategy
+	MACDStrategy            = strategies.MACDStrategy
+	MomentumStrategy        = strategies.MomentumStrategy
+	GridStrategy            = strategies.GridStrategy
+	BreakoutStrategy        = strategies.BreakoutStrategy
+	SentimentStrategy       = strategies.SentimentStrategy
+	TrendFilteredMAStrategy = strategies.TrendFilteredMAStrategy
+)
+
+const (
+	StrategyMovingAverage = strategies.TypeMovingAverage
+	StrategyRSI           = strategies.TypeRSI
+	StrategyMACD          = strategies.TypeMACD
+	StrategyMomentum      = strategies.TypeMomentum
+	StrategyGrid          = strategies.TypeGrid
+	StrategyBreakout      = strategies.TypeBreakout
+	StrategySentiment     = strategies.TypeSentiment
+	StrategyTrendFiltered = strategies.TypeTrendFiltered
+
+	SizerCash          = strategies.SizerCash
+	SizerFixedFraction = strategies.SizerFixedFraction
+	SizerKelly         = strategies.SizerKelly
+)
+
+var (
+	ErrInvalidQuantity        = strategies.ErrInvalidQuantity
+	ErrOrderTooSmall          = strategies.ErrOrderTooSmall
+	ErrOrderTooLarge          = strategies.ErrOrderTooLarge
+	ErrInsufficientFunds      = strategies.ErrInsufficientFunds
+	ErrInsufficientPosition   = strategies.ErrInsufficientPosition
+	ErrPositionTooLarge       = strategies.ErrPositionTooLarge
+	ErrPortfolioRiskExceeded  = strategies.ErrPortfolioRiskExceeded
+	ErrStrategyDisabled       = strategies.ErrStrategyDisabled
+	ErrInvalidPortfolio       = strategies.ErrInvalidPortfolio
+	ErrInvalidStrategyConfig  = strategies.ErrInvalidConfig
+	ErrMaxDrawdownExceeded    = strategies.ErrMaxDrawdownExceeded
+	ErrMaxOrdersPerDayReached = strategies.ErrMaxOrdersPerDayReached
+	ErrUnknownStrategyType    = strategies.ErrUnknownStrategyType
+	ErrUnknownPositionSizer   = strategies.ErrUnknownPositionSizer
+)
+
+func NewStrategy(strategyType string, config *StrategyConfig) (Strategy, error) {
+	return strategies.New(strategyType, config)
+}
+
+func StrategyTypes() []string {
+	return strategies.Types()
+}
+
+func NewBaseStrategy(config *StrategyConfig) *BaseStrategy {
+	return strategies.NewBaseStrategy(config)
+}
+
+func NewPositionSizer(config *StrategyConfig) (PositionSizer, error) {
+	return strategies.NewPositionSizer(config)
+}
+
+func NewMovingAverageStrategy(config *StrategyConfig) *MovingAverageStrategy {
+	return strategies.NewMovingAverageStrategy(config)
+}
+
+func NewRSIStrategy(config *StrategyConfig) *RSIStrategy {
+	return strategies.NewRSIStrategy(config)
+}
+
+func NewMACDStrategy(config *StrategyConfig) *MACDStrategy {
+	return strategies.NewMACDStrategy(config)
+}
+
+func NewMomentumStrategy(config *StrategyConfig) *MomentumStrategy {
+	return strategies.NewMomentumStrategy(config)
+}
+
+func NewGridStrategy(config *StrategyConfig) *GridStrategy {
+	return strategies.NewGridStrategy(config)
+}
+
+func NewBreakoutStrategy(config *StrategyConfig) *BreakoutStrategy {
+	return strategies.NewBreakoutStrategy(config)
+}
+
+func NewSentimentStrategy(config *StrategyConfig) *SentimentStrategy {
+	return strategies.NewSentimentStrategy(config)
+}
+
+func NewTrendFilteredMAStrategy(config *StrategyConfig) *TrendFilteredMAStrategy {
+	return strategies.NewTrendFilteredMAStrategy(config)
+}